@@ -20,6 +20,9 @@ var (
 	finalityProvidersPath     string
 	replayFlag                bool
 	backfillPubkeyAddressFlag bool
+	backfillWithdrawalPath    bool
+	backfillStakerPkCase      bool
+	rebuildStakerPk           string
 	rootCmd                   = &cobra.Command{
 		Use: "start-server",
 	}
@@ -65,6 +68,24 @@ func Setup() error {
 		false,
 		"Backfill pubkey address mappings",
 	)
+	rootCmd.PersistentFlags().BoolVar(
+		&backfillWithdrawalPath,
+		"backfill-withdrawal-path",
+		false,
+		"Backfill the withdrawal path of the withdrawn delegations",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&backfillStakerPkCase,
+		"backfill-staker-pk-case",
+		false,
+		"Lowercase the staker public keys stored in upper or mixed case",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&rebuildStakerPk,
+		"rebuild-staker-pk",
+		"",
+		"Rebuild the stats of the staker with the given public key",
+	)
 	if err := rootCmd.Execute(); err != nil {
 		return err
 	}
@@ -95,3 +116,15 @@ func GetReplayFlag() bool {
 func GetBackfillPubkeyAddressFlag() bool {
 	return backfillPubkeyAddressFlag
 }
+
+func GetBackfillWithdrawalPathFlag() bool {
+	return backfillWithdrawalPath
+}
+
+func GetBackfillStakerPkCaseFlag() bool {
+	return backfillStakerPkCase
+}
+
+func GetRebuildStakerPk() string {
+	return rebuildStakerPk
+}
@@ -2,16 +2,25 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/cmd/staking-api-service/cli"
 	"github.com/babylonlabs-io/staking-api-service/cmd/staking-api-service/scripts"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/bootreport"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/delegationcount"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/healthcheck"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/integritycheck"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
@@ -47,7 +56,9 @@ func init() {
 // @tag.deprecated
 // @tag.order 2
 func main() {
-	ctx := context.Background()
+	// The context is canceled on SIGINT or SIGTERM to start the shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// setup cli commands and flags
 	if err := cli.Setup(); err != nil {
@@ -73,10 +84,18 @@ func main() {
 		log.Fatal().Err(err).Msg(fmt.Sprintf("error while loading finality providers file: %s", finalityProvidersPath))
 	}
 
-	err = dbmodel.Setup(ctx, cfg)
+	bootReport, err := bootreport.New(
+		cfgPath, cfg, paramsPath, params, finalityProvidersPath, finalityProviders,
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while assembling boot report")
+	}
+
+	indexResults, err := dbmodel.Setup(ctx, cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while setting up staking db model")
 	}
+	bootReport.Indexes = indexResults
 
 	// initialize clients package which is used to interact with external services
 	clients := clients.New(cfg)
@@ -87,13 +106,34 @@ func main() {
 		log.Fatal().Err(err).Msg("error while setting up staking db clients")
 	}
 
+	if topology, err := dbclient.DetectMongoTopology(ctx, dbClients.StakingMongoClient); err != nil {
+		log.Warn().Err(err).Msg("failed to detect staking db topology")
+	} else {
+		bootReport.StakingDb.Topology = topology
+	}
+	if topology, err := dbclient.DetectMongoTopology(ctx, dbClients.IndexerMongoClient); err != nil {
+		log.Warn().Err(err).Msg("failed to detect indexer db topology")
+	} else {
+		bootReport.IndexerDb.Topology = topology
+	}
+	bootreport.Publish(bootReport)
+
+	// The dry-run services are set up first, the services variable shadows
+	// the package below
+	dryRunServices, err := services.NewDryRun(ctx, cfg, params, finalityProviders, clients, dbClients)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while setting up dry-run services layer")
+	}
 	services, err := services.New(ctx, cfg, params, finalityProviders, clients, dbClients)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while setting up staking services layer")
 	}
 
 	// Start the event queue processing
-	v2queues, err := v2queue.New(cfg.Queue, services)
+	v2queues, err := v2queue.New(
+		cfg.Queue, cfg.DryRun, cfg.EventGapDetection, cfg.QueueRetryBackoff, cfg.QueueThrottle,
+		cfg.QueueConsumers, services, dryRunServices,
+	)
 	if err != nil {
 		metrics.RecordServiceCrash("queue")
 		log.Fatal().Err(err).Msg("error while setting up queue service")
@@ -115,6 +155,27 @@ func main() {
 			log.Fatal().Err(err).Msg("error while backfilling pubkey address mappings")
 		}
 		return
+	} else if cli.GetBackfillWithdrawalPathFlag() {
+		log.Info().Msg("Backfill withdrawal path flag is set. Starting backfill of the withdrawal paths.")
+		err := scripts.BackfillWithdrawalPaths(ctx, cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while backfilling withdrawal paths")
+		}
+		return
+	} else if cli.GetBackfillStakerPkCaseFlag() {
+		log.Info().Msg("Backfill staker pk case flag is set. Starting lowercasing of the staker public keys.")
+		err := scripts.BackfillStakerPkCase(ctx, cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while lowercasing the staker public keys")
+		}
+		return
+	} else if stakerPk := cli.GetRebuildStakerPk(); stakerPk != "" {
+		log.Info().Str("stakerPkHex", stakerPk).Msg("Rebuild staker flag is set. Starting rebuild of the staker stats.")
+		err := scripts.RebuildStakerStats(ctx, services.V1Service, stakerPk)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while rebuilding staker stats")
+		}
+		return
 	}
 
 	// initialize metrics with the metrics port from config
@@ -132,12 +193,79 @@ func main() {
 		log.Fatal().Err(healthcheckErr).Msg("error while starting health check cron")
 	}
 
-	apiServer, err := api.New(ctx, cfg, services)
+	err = delegationcount.StartDelegationCountCron(
+		ctx, dbClients, cfg.Metrics.GetDelegationCountInterval(),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while starting delegation count cron")
+	}
+
+	err = services.StartAdminUpdatesRefreshCron(ctx, cfg.Server.GetAdminUpdatesRefreshInterval())
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while starting admin updates refresh cron")
+	}
+
+	if cfg.UnbondingIntegrity != nil {
+		err = integritycheck.StartUnbondingIntegrityCron(ctx, services.V1Service, cfg.UnbondingIntegrity)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while starting unbonding integrity cron")
+		}
+	}
+
+	if cfg.BloomFilter != nil {
+		go func() {
+			if err := services.V1Service.BuildStakerFilter(ctx); err != nil {
+				log.Error().Err(err).Msg("error while building the staker filter, lookups go to the database")
+			}
+		}()
+	}
+
+	apiServer, err := api.New(ctx, cfg, services, v2queues)
 	if err != nil {
 		metrics.RecordServiceCrash("api")
 		log.Fatal().Err(err).Msg("error while setting up staking api service")
 	}
-	if err = apiServer.Start(); err != nil {
-		log.Fatal().Err(err).Msg("error while starting staking api service")
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- apiServer.Start()
+	}()
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal().Err(err).Msg("error while starting staking api service")
+		}
+	case <-ctx.Done():
+	}
+	shutdown(cfg.Server.GetShutdownTimeout(), apiServer, v2queues, dbClients)
+}
+
+// shutdown drains the in-flight HTTP requests and then the queue messages
+// being processed, before closing the queue connections and the mongo clients.
+// Each step is bounded by the timeout on its own, so a slow step does not
+// leave the next ones with an expired deadline. The queue connections are
+// closed first so that the messages still processing after the drain are
+// redelivered rather than acknowledged against closed mongo clients.
+func shutdown(
+	timeout time.Duration, apiServer *api.Server, queues *v2queue.Queues, dbClients *dbclients.DbClients,
+) {
+	log.Info().Dur("timeout", timeout).Msg("shutting down staking api service")
+
+	if err := withTimeout(timeout, apiServer.Shutdown); err != nil {
+		log.Error().Err(err).Msg("error while draining http requests")
 	}
+	if err := withTimeout(timeout, queues.StopReceivingMessages); err != nil {
+		log.Error().Err(err).Msg("error while draining queue messages")
+	}
+	queues.Close()
+	if err := withTimeout(timeout, dbClients.Close); err != nil {
+		log.Error().Err(err).Msg("error while closing db clients")
+	}
+	log.Info().Msg("staking api service stopped")
+}
+
+// withTimeout runs the shutdown step with a context bounded by the timeout
+func withTimeout(timeout time.Duration, step func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return step(ctx)
 }
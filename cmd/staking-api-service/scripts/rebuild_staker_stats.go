@@ -0,0 +1,28 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/rs/zerolog/log"
+)
+
+// RebuildStakerStats re-derives the stats of a single staker from its
+// delegations
+func RebuildStakerStats(ctx context.Context, service v1service.V1ServiceProvider, stakerPkHex string) error {
+	if _, err := utils.GetSchnorrPkFromHex(stakerPkHex); err != nil {
+		return fmt.Errorf("invalid staker public key: %w", err)
+	}
+
+	rebuild, err := service.RebuildStakerStats(ctx, stakerPkHex)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild staker stats: %w", err)
+	}
+	log.Info().
+		Str("stakerPkHex", stakerPkHex).
+		Bool("changed", rebuild.Changed).
+		Msg("Rebuild of the staker stats completed")
+	return nil
+}
@@ -0,0 +1,32 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	"github.com/rs/zerolog/log"
+)
+
+// BackfillStakerPkCase lowercases the staker public keys stored in upper or
+// mixed case, as the delegations are looked up by the exact lowercase forms
+// of the key. It can be run again as the keys already lowercased are skipped.
+func BackfillStakerPkCase(ctx context.Context, cfg *config.Config) error {
+	client, err := dbclient.NewMongoClient(ctx, cfg.StakingDb)
+	if err != nil {
+		return fmt.Errorf("failed to create db client: %w", err)
+	}
+	v1dbClient, err := v1dbclient.New(ctx, client, cfg.StakingDb)
+	if err != nil {
+		return fmt.Errorf("failed to create db client: %w", err)
+	}
+
+	updated, err := v1dbClient.LowercaseStakerPks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to lowercase the staker public keys: %w", err)
+	}
+	log.Info().Int64("delegations", updated).Msg("Lowercased the staker public keys")
+	return nil
+}
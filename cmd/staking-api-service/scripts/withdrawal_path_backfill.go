@@ -0,0 +1,54 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// BackfillWithdrawalPaths records the withdrawal path of the delegations
+// withdrawn before it was recorded, derived from their state history, and
+// counts them in the withdrawn tvl. It can be run again as the delegations
+// already backfilled are skipped.
+func BackfillWithdrawalPaths(ctx context.Context, cfg *config.Config) error {
+	client, err := dbclient.NewMongoClient(ctx, cfg.StakingDb)
+	if err != nil {
+		return fmt.Errorf("failed to create db client: %w", err)
+	}
+	v1dbClient, err := v1dbclient.New(ctx, client, cfg.StakingDb)
+	if err != nil {
+		return fmt.Errorf("failed to create db client: %w", err)
+	}
+
+	counts := make(map[v1dbmodel.WithdrawalPath]int)
+	err = v1dbClient.IterateWithdrawnDelegationsWithoutPath(ctx, func(delegation *v1dbmodel.DelegationDocument) error {
+		withdrawalPath := delegation.DeriveWithdrawalPath()
+		err := v1dbClient.BackfillWithdrawalPath(
+			ctx, delegation.StakingTxHashHex, withdrawalPath, delegation.StakingValue,
+		)
+		if err != nil {
+			if db.IsNotFoundError(err) {
+				// backfilled concurrently
+				return nil
+			}
+			return fmt.Errorf("failed to backfill the withdrawal path of %s: %w", delegation.StakingTxHashHex, err)
+		}
+		counts[withdrawalPath]++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Info().
+		Int("earlyUnbonding", counts[v1dbmodel.WithdrawalPathEarlyUnbonding]).
+		Int("naturalExpiry", counts[v1dbmodel.WithdrawalPathNaturalExpiry]).
+		Int("unknown", counts[v1dbmodel.WithdrawalPathUnknown]).
+		Msg("Backfilled the withdrawal paths")
+	return nil
+}
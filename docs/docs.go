@@ -23,14 +23,14 @@ const docTemplate = `{
     "paths": {
         "/healthcheck": {
             "get": {
-                "description": "Health check the service, including ping database connection",
+                "description": "Liveness check of the service, always succeeds if the server is serving requests",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "shared"
                 ],
-                "summary": "Health check endpoint",
+                "summary": "Liveness check endpoint",
                 "responses": {
                     "200": {
                         "description": "Server is up and running",
@@ -41,128 +41,159 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/delegation": {
+        "/ready": {
             "get": {
-                "description": "[DEPRECATED] Retrieves a delegation by a given transaction hash. Please use /v2/delegation instead.",
+                "description": "Readiness check of the service, pings the databases and checks the queue connections are open.\nThe check answers within 2 seconds even if a dependency is unreachable.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "v1"
-                ],
-                "deprecated": true,
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Staking transaction hash in hex format",
-                        "name": "staking_tx_hash_hex",
-                        "in": "query",
-                        "required": true
-                    }
+                    "shared"
                 ],
+                "summary": "Readiness check endpoint",
                 "responses": {
                     "200": {
-                        "description": "Delegation",
+                        "description": "All the dependencies are healthy",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-v1service_DelegationPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-handler_ReadinessPublic"
                         }
                     },
-                    "400": {
-                        "description": "Error: Bad Request",
+                    "503": {
+                        "description": "The dependencies failing the check",
                         "schema": {
-                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                            "$ref": "#/definitions/handler.PublicResponse-handler_ReadinessPublic"
                         }
                     }
                 }
             }
         },
-        "/v1/finality-providers": {
-            "get": {
-                "description": "[DEPRECATED] Fetches details of all active finality providers sorted by their active total value locked (ActiveTvl) in descending order. Please use /v2/finality-providers instead.",
+        "/v1/admin/config/update-param": {
+            "post": {
+                "description": "Updates one of the phase-1 global params without a restart, from the given BTC height onwards.\nThe update adds a params version activated at that height, which must be after the activation\nheight of the latest version, so the delegations staked before keep their params. Updates from\nthe height of a version added by an earlier update are merged into it.\nThe update is stored into the global params history and applied on top of the global params file\nby this instance right away, the other instances apply it at their next refresh of the history.\nAdmin endpoint, only served when an admin token is configured.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "v1"
                 ],
-                "summary": "Get Active Finality Providers (Deprecated)",
-                "deprecated": true,
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Public key of the finality provider to fetch",
-                        "name": "fp_btc_pk",
-                        "in": "query"
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Pagination key to fetch the next page of finality providers",
-                        "name": "pagination_key",
-                        "in": "query"
+                        "description": "Param update",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1handlers.UpdateGlobalParamRequestPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "A list of finality providers sorted by ActiveTvl in descending order",
+                        "description": "Updated global parameters",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_FpDetailsPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_GlobalParamsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown param, invalid value or effective height",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
                     }
                 }
             }
         },
-        "/v1/global-params": {
+        "/v1/admin/delegations/covenant-missing": {
             "get": {
-                "description": "[DEPRECATED] Retrieves the global parameters for Babylon, including finality provider details. Please use /v2/network-info instead.",
+                "description": "Internal endpoint listing the delegations whose unbonding request\nis pending for more than the given number of hours without any covenant signature.\nAdmin endpoint, only served when an admin token is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "v1"
                 ],
-                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum number of hours the unbonding request is pending, defaults to 48",
+                        "name": "hours",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Global parameters",
+                        "description": "List of delegations missing covenant signatures",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-v1service_GlobalParamsPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_CovenantMissingDelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
                     }
                 }
             }
         },
-        "/v1/staker/delegation/check": {
+        "/v1/admin/delegations/dust": {
             "get": {
-                "description": "Check if a staker has an active delegation by the staker BTC address (Taproot or Native Segwit).\nOptionally, you can provide a timeframe to check if the delegation is active within the provided timeframe\nThe available timeframe is \"today\" which checks after UTC 12AM of the current day",
+                "description": "Internal endpoint listing the active delegations staking less than\nthe minimum staking amount of the latest global params version.\nAdmin endpoint, only served when an admin token is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "shared"
+                    "v1"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staker BTC address in Taproot/Native Segwit format",
-                        "name": "address",
-                        "in": "query",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "today"
-                        ],
                         "type": "string",
-                        "description": "Check if the delegation is active within the provided timeframe",
-                        "name": "timeframe",
+                        "description": "Pagination key to fetch the next page of dust delegations",
+                        "name": "pagination_key",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Delegation check result",
+                        "description": "List of dust delegations and pagination token",
                         "schema": {
-                            "$ref": "#/definitions/v1handlers.DelegationCheckPublicResponse"
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_DustDelegationPublic"
                         }
                     },
                     "400": {
@@ -170,13 +201,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
                     }
                 }
             }
         },
-        "/v1/staker/delegations": {
+        "/v1/admin/delegations/expired-but-not-withdrawn": {
             "get": {
-                "description": "Retrieves phase-1 delegations for a given staker. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.\nThis endpoint is only used to show legacy phase-1 delegations for the purpose of unbonding or registering into phase-2.",
+                "description": "Internal endpoint listing the active and unbonded delegations whose timelock expired\nmore than the given number of blocks before the current BTC tip height.\nThe timelock of a delegation unbonded early is the one of its unbonding tx.\nAdmin endpoint, only served when an admin token is configured.",
                 "produces": [
                     "application/json"
                 ],
@@ -186,15 +223,15 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staker BTC Public Key",
-                        "name": "staker_btc_pk",
-                        "in": "query",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Only return delegations with pending actions which include active, unbonding, unbonding_requested, unbonded",
-                        "name": "pending_action",
+                        "type": "integer",
+                        "description": "Minimum number of blocks since the timelock expired, defaults to 0",
+                        "name": "blocks_overdue",
                         "in": "query"
                     },
                     {
@@ -206,9 +243,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of delegations and pagination token",
+                        "description": "List of delegations overdue for withdrawal and pagination token",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_DelegationPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_OverdueWithdrawalDelegationPublic"
                         }
                     },
                     "400": {
@@ -216,48 +253,67 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "The BTC tip height is not known yet",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
                     }
                 }
             }
         },
-        "/v1/staker/pubkey-lookup": {
+        "/v1/admin/delegations/high-value": {
             "get": {
-                "description": "Retrieves public keys for the given BTC addresses. This endpoint\nonly returns public keys for addresses that have associated delegations in\nthe system. If an address has no associated delegation, it will not be\nincluded in the response. Supports both Taproot and Native Segwit addresses.",
+                "description": "Internal endpoint listing the delegations, in any state, staking at least\nthe given number of satoshis, the largest first.\nAdmin endpoint, only served when an admin token is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "shared"
+                    "v1"
                 ],
-                "summary": "Get stakers' public keys",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "List of BTC addresses to look up (up to 10), currently only supports Taproot and Native Segwit addresses",
-                        "name": "address",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum staking value in satoshis, defaults to 10000000000",
+                        "name": "min_sat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "A map of BTC addresses to their corresponding public keys (only addresses with delegations are returned)",
+                        "description": "List of high value delegations and pagination token",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-map_string_string"
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_HighValueDelegationPublic"
                         }
                     },
                     "400": {
-                        "description": "Bad Request: Invalid input parameters",
+                        "description": "Error: Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Missing or invalid admin token",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
@@ -265,57 +321,82 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/stats": {
+        "/v1/admin/delegations/orphaned": {
             "get": {
-                "description": "[DEPRECATED] Fetches overall stats for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers. Please use /v2/stats instead.",
+                "description": "Internal endpoint listing the delegations whose finality provider\nis not part of the finality providers registry.\nAdmin endpoint, only served when an admin token is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "v1"
                 ],
-                "summary": "Get Overall Stats (Deprecated)",
-                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of orphaned delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Overall stats for babylon staking",
+                        "description": "List of orphaned delegations and pagination token",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-v1service_OverallStatsPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_OrphanedDelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
                     }
                 }
             }
         },
-        "/v1/stats/staker": {
+        "/v1/admin/delegations/verify-consistency": {
             "get": {
-                "description": "[DEPRECATED] Fetches staker stats for babylon staking including tvl, total delegations, active tvl and active delegations. Please use /v2/staker/stats instead.\nIf staker_btc_pk query parameter is provided, it will return stats for the specific staker.\nOtherwise, it will return the top stakers ranked by active tvl.",
+                "description": "Internal endpoint checking randomly sampled delegations for an unknown state, a state\nnot reachable given their transactions and unbonding documents, a zero staking value or missing required fields.\nAdmin endpoint, only served when an admin token is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "v1"
                 ],
-                "summary": "Get Staker Stats (Deprecated)",
-                "deprecated": true,
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Public key of the staker to fetch",
-                        "name": "staker_btc_pk",
-                        "in": "query"
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Pagination key to fetch the next page of top stakers",
-                        "name": "pagination_key",
+                        "type": "integer",
+                        "default": 1000,
+                        "description": "Number of delegations to sample, at most 10000",
+                        "name": "sample_size",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of top stakers by active tvl",
+                        "description": "Number of checked delegations and their issues",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_StakerStatsPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_ConsistencyVerificationPublic"
                         }
                     },
                     "400": {
@@ -323,13 +404,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
                     }
                 }
             }
         },
-        "/v1/unbonding": {
+        "/v1/admin/finality-provider/bulk-register": {
             "post": {
-                "description": "Unbonds a phase-1 delegation by processing the provided transaction details. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.\nThis is an async operation.",
+                "description": "Registers up to 100 phase-1 finality providers at once, in a single bulk write.\nThe registered finality providers are served along the ones of the finality providers file.\nThe invalid ones are reported by their index in the batch, the ones already registered are skipped.\nThis instance serves the registrations right away, the other instances load them at their next refresh of the registrations.\nAdmin endpoint, only served when an admin token is configured.",
                 "consumes": [
                     "application/json"
                 ],
@@ -339,24 +426,42 @@ const docTemplate = `{
                 "tags": [
                     "v1"
                 ],
-                "summary": "Unbond phase-1 delegation",
                 "parameters": [
                     {
-                        "description": "Unbonding Request Payload",
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Finality providers to register",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1handlers.UnbondDelegationRequestPayload"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.FinalityProviderDetails"
+                            }
                         }
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Request accepted and will be processed asynchronously"
+                    "200": {
+                        "description": "Registration result",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_FpBulkRegistrationPublic"
+                        }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid payload, empty batch or more than 100 finality providers",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
@@ -364,62 +469,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/unbonding/eligibility": {
+        "/v1/constants": {
             "get": {
-                "description": "Checks if a delegation identified by its staking transaction hash is eligible for unbonding. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.",
+                "description": "Retrieves the canonical lists of the delegation states, unbonding attempt states,\nactivity types of the staker event stream and error codes, each with a short description.\nThe states and activity types are marked terminal when no delegation or unbonding attempt leaves them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "v1"
                 ],
-                "summary": "Check unbonding eligibility",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Staking Transaction Hash Hex",
-                        "name": "staking_tx_hash_hex",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
                 "responses": {
                     "200": {
-                        "description": "The delegation is eligible for unbonding"
-                    },
-                    "400": {
-                        "description": "Missing or invalid 'staking_tx_hash_hex' query parameter",
+                        "description": "API constants",
                         "schema": {
-                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_ConstantsPublic"
                         }
                     }
                 }
             }
         },
-        "/v2/delegation": {
+        "/v1/debug/unbonding-sighash": {
             "get": {
-                "description": "Retrieves a delegation by a given transaction hash",
+                "description": "Debug endpoint returning the sighash the staker signature of an unbonding request is verified\nagainst, along with the unbonding path script and its tapleaf hash. The unbonding tx known for\nthe delegation is used when unbonding_tx_hex is not given. Only available when enabled in the\ndebug config, which is refused on mainnet, and to the requests carrying the admin token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "v2"
+                    "v1"
                 ],
-                "summary": "Get a delegation",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Staking transaction hash in hex format",
                         "name": "staking_tx_hash_hex",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unsigned unbonding transaction in hex format",
+                        "name": "unbonding_tx_hex",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Staker delegation",
+                        "description": "Unbonding sighash",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-v2service_DelegationPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_UnbondingSigHashPublic"
                         }
                     },
                     "400": {
@@ -428,14 +532,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
                     },
-                    "404": {
-                        "description": "Error: Not Found",
+                    "401": {
+                        "description": "Missing or invalid admin token",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
                     },
-                    "500": {
-                        "description": "Error: Internal Server Error",
+                    "404": {
+                        "description": "Error: Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
@@ -443,36 +547,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/delegations": {
+        "/v1/delegation": {
             "get": {
-                "description": "Fetches delegations for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers.",
+                "description": "[DEPRECATED] Retrieves a delegation by a given transaction hash. Please use /v2/delegation instead.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "v2"
+                    "v1"
                 ],
-                "summary": "Get Delegations",
+                "deprecated": true,
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staker public key in hex format",
-                        "name": "staker_pk_hex",
+                        "description": "Staking transaction hash in hex format",
+                        "name": "staking_tx_hash_hex",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Pagination key to fetch the next page of delegations",
-                        "name": "pagination_key",
+                        "type": "boolean",
+                        "description": "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts",
+                        "name": "include_formatted",
                         "in": "query"
-                    }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the state transitions of the delegation, oldest first",
+                        "name": "include_history",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of staker delegations and pagination token",
+                        "description": "Delegation",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-array_v2service_DelegationPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_DelegationPublic"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the delegation, to be sent as If-Match on POST /v1/unbonding"
+                            }
                         }
                     },
                     "400": {
@@ -486,47 +602,28 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
-                    },
-                    "500": {
-                        "description": "Error: Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
-                        }
                     }
                 }
             }
         },
-        "/v2/finality-providers": {
+        "/v1/delegations/avg-staking-duration": {
             "get": {
-                "description": "Fetches finality providers with its stats, currently does not support pagination",
+                "description": "Retrieves the average, median and 95th percentile of the staking timelock\nof the active phase-1 delegations, their intended staking duration in BTC blocks.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "v2"
+                    "v1"
                 ],
-                "summary": "List Finality Providers",
                 "responses": {
                     "200": {
-                        "description": "List of finality providers with its stats",
-                        "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-array_v2service_FinalityProviderStatsPublic"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid parameters or malformed request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
-                        }
-                    },
-                    "404": {
-                        "description": "No finality providers found",
+                        "description": "Staking duration statistics",
                         "schema": {
-                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_StakingDurationStatsPublic"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error occurred",
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
@@ -534,20 +631,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/network-info": {
+        "/v1/delegations/by-withdrawal-address": {
             "get": {
-                "description": "Get network info, including staking status and param",
+                "description": "Retrieves the phase-1 delegations whose funds were withdrawn to the given BTC address,\nto aggregate the delegations of several wallets by their destination.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "v2"
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Withdrawal BTC address in Taproot/Native Segwit format",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Network info",
+                        "description": "Delegations withdrawn to the address",
                         "schema": {
-                            "$ref": "#/definitions/v2service.NetworkInfoPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_DelegationPublic"
                         }
                     },
                     "400": {
@@ -559,30 +671,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/staker/stats": {
+        "/v1/delegations/co-stakers": {
             "get": {
-                "description": "Fetches staker stats for babylon staking including active tvl and active delegations.",
+                "description": "Retrieves the number of other stakers with active phase-1 delegations to the finality provider\nof the given delegation, and the 5 of them with the highest active tvl.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "v2"
+                    "v1"
                 ],
-                "summary": "Get Staker Stats",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Public key of the staker to fetch",
-                        "name": "staker_pk_hex",
+                        "description": "Staking transaction hash in hex format",
+                        "name": "staking_tx_hash_hex",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Staker stats",
+                        "description": "Co-stakers of the delegation",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-v2service_StakerStatsPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_CoStakersPublic"
                         }
                     },
                     "400": {
@@ -597,8 +708,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
                     },
-                    "500": {
-                        "description": "Error: Internal Server Error",
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
                         }
@@ -606,20 +717,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/stats": {
+        "/v1/delegations/created-by-block": {
             "get": {
-                "description": "Overall system stats",
+                "description": "Retrieves the phase-1 delegations whose staking tx is included in the BTC block of the given height.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "v2"
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "BTC block height",
+                        "name": "height",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Delegations created in the block",
                         "schema": {
-                            "$ref": "#/definitions/handler.PublicResponse-v2service_OverallStatsPublic"
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_DelegationPublic"
                         }
                     },
                     "400": {
@@ -630,487 +756,4639 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error": {
-            "type": "object",
-            "properties": {
-                "err": {},
-                "errorCode": {
-                    "$ref": "#/definitions/types.ErrorCode"
-                },
-                "statusCode": {
-                    "type": "integer"
-                }
-            }
         },
-        "handler.PublicResponse-array_v1service_DelegationPublic": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1service.DelegationPublic"
+        "/v1/delegations/near-covenant-expiry": {
+            "get": {
+                "description": "Retrieves the delegations whose unbonding was requested and whose staking timelock\nexpires in less than the given number of blocks from the current BTC tip height.\nTheir unbonding tx can no longer be broadcast once the timelock expired.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of blocks before the staking timelock expiry, defaults to 144",
+                        "name": "blocks_remaining",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegations near covenant expiry",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_NearCovenantExpiryDelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "The BTC tip height is not known yet",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
                     }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
                 }
             }
         },
-        "handler.PublicResponse-array_v1service_FpDetailsPublic": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1service.FpDetailsPublic"
+        "/v1/delegations/provider-change-candidates": {
+            "get": {
+                "description": "Retrieves the active delegations of the staker whose staking timelock expires in less than\nthe given number of blocks from the current BTC tip height, the earliest expiring first.\nEach of them comes with the registered finality provider with the highest active TVL other\nthan its current one, as a suggestion to stake to once withdrawn.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of blocks before the staking timelock expiry, defaults to 1008",
+                        "name": "blocks_ahead",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegations expiring soon with a suggested finality provider",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_ProviderChangeCandidatePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "The BTC tip height is not known yet",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
                     }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
                 }
             }
         },
-        "handler.PublicResponse-array_v1service_StakerStatsPublic": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1service.StakerStatsPublic"
+        "/v1/delegations/reactivation-candidates": {
+            "get": {
+                "description": "Retrieves the withdrawn delegations of the staker, whose funds can be staked again,\nthe most recently withdrawn first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Withdrawn delegations of the staker",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_ReactivationCandidatePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
                     }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
                 }
             }
         },
-        "handler.PublicResponse-array_v2service_DelegationPublic": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v2service.DelegationPublic"
+        "/v1/delegations/value-concentration": {
+            "get": {
+                "description": "Retrieves the Gini coefficient of the staking values of the active phase-1 delegations,\nfrom 0 when they all stake the same value to 1 when a single one holds all the stake.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegation value concentration",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_ValueConcentrationPublic"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
                     }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "/v1/finality-provider/active-stake-over-time": {
+            "get": {
+                "description": "Returns the phase-1 active stake timeline of a finality provider, one point per period from the period of its first delegation up to the current one, limited to the 720 most recent periods.\nEvery point holds the active stake at the end of the period, along with the delegations which became active or left the active state during the period.\nThe periods start at the date in UTC, weeks start on Monday. The timelines are cached for 15 minutes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public key of the finality provider",
+                        "name": "fp_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period of every point, hour, day (default) or week",
+                        "name": "granularity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Active stake timeline of the finality provider",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_ActiveStakePointPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-provider/logo": {
+            "get": {
+                "description": "Redirects to the logo URL registered by the finality provider.\nWhen the logo proxy is configured, the png, jpeg or svg image is served by the API instead.",
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public key of the finality provider",
+                        "name": "fp_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Finality provider logo",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to the finality provider logo URL"
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Error: Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-provider/report-misbehavior": {
+            "post": {
+                "description": "Reports a suspected misbehavior of a finality provider, the report is stored pending review.\nThe reporter signs the sha256 of the raw evidence transaction with the BIP-340 schnorr key of its BTC public key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public key of the reported finality provider",
+                        "name": "fp_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Misbehavior Report Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1handlers.ReportMisbehaviorRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Report accepted for review",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_MisbehaviorReportPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload (BAD_REQUEST) or reporter signature not matching the evidence (INVALID_SIGNATURE)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Finality provider not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-providers": {
+            "get": {
+                "description": "[DEPRECATED] Fetches details of all active finality providers sorted by their active total value locked (ActiveTvl) in descending order. Please use /v2/finality-providers instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Get Active Finality Providers (Deprecated)",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public key of the finality provider to fetch",
+                        "name": "fp_btc_pk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of finality providers",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A list of finality providers sorted by ActiveTvl in descending order",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_FpDetailsPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-providers/by-total-stakers": {
+            "get": {
+                "description": "Fetches the finality providers with at least the given number of distinct stakers\nholding an active phase-1 delegation to them, the most staked first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Minimum number of unique stakers, defaults to 1",
+                        "name": "min_stakers",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A list of finality providers with their number of unique stakers",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_FpStakersPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-providers/inactive": {
+            "get": {
+                "description": "Fetches the finality providers that have no active delegation,\nincluding the registered finality providers that never received a delegation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A list of finality providers without active delegations",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_FpDetailsPublic"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/global-params": {
+            "get": {
+                "description": "[DEPRECATED] Retrieves the global parameters for Babylon, including finality provider details. Please use /v2/network-info instead.\nIf a height is given, only the params version active at that BTC height is returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "BTC height to get the active params version of",
+                        "name": "height",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Global parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_GlobalParamsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/boot-report": {
+            "get": {
+                "description": "Internal endpoint returning what the instance loaded at boot: config hash,\nparams versions, finality providers count, db topology, enabled features\nand index bootstrap results. Credentials are redacted.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Boot report",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-bootreport_Report"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/consumers/dry-run": {
+            "get": {
+                "description": "Internal endpoint returning whether the messages of each queue are processed in dry-run mode,\nwhere each message is run through a dry-run handler recording the writes it would have performed,\ninstead of being applied by the live handler.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shared"
+                ],
+                "summary": "Get the queue handler modes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Queue handler modes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_handler_QueueModePublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Internal endpoint switching the given queue in or out of the dry-run mode.\nThe change applies from the next received message onwards.\nAdmin endpoint, only served when an admin token is configured.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shared"
+                ],
+                "summary": "Switch a queue handler mode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Queue handler mode",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.QueueDryRunPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Queue handler modes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_handler_QueueModePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/consumers/throttle": {
+            "get": {
+                "description": "Internal endpoint returning the factor the queue consumers are throttled with while the database\nis degraded, and whether it is manually overridden.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shared"
+                ],
+                "summary": "Get the queue consumers throttle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Queue consumers throttle",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-handler_QueueThrottlePublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Internal endpoint throttling the queue consumers with the given factor regardless of the database\nhealth, 0 waits the longest between two messages and 1 consumes at full speed. A null factor\nclears the override.\nAdmin endpoint, only served when an admin token is configured.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shared"
+                ],
+                "summary": "Override the queue consumers throttle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Throttle factor",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.QueueThrottleOverridePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Queue consumers throttle",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-handler_QueueThrottlePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/delegations/consistency": {
+            "get": {
+                "description": "Internal endpoint evaluating the invariants between the state of\na delegation and its unbonding documents, with the offending values of the failed ones.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staking transaction hash in hex format",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invariants evaluation",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_DelegationConsistencyPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/gaps": {
+            "get": {
+                "description": "Internal endpoint listing the suspected gaps in the events received\non the queues: the event height jumped ahead while the producer\nsequence number shows that intermediate events were never received.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of event gaps",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of suspected event gaps and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v2service_EventGapPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/integrity-issues": {
+            "get": {
+                "description": "Internal endpoint listing the unbonding documents flagged by the\nunbonding integrity check, either because the referenced delegation\ndoes not exist or because it is in a state incompatible with an unbonding request.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of integrity issues",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of integrity issues and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_IntegrityIssuePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/staker/dossier": {
+            "get": {
+                "description": "Internal support endpoint gathering the phase-1 delegations of a staker with their state history,\nits unbonding attempts with the progress of their covenant signatures, its stats and its watchlist.\nA section failing to load is null and reported in section_errors, the other sections are still returned.\nSections larger than 100 items only come with their counts.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staker dossier",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_StakerDossierPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/stakers/rebuild-stats": {
+            "post": {
+                "description": "Re-derives the phase-1 stats of a single staker from its delegations and replaces the stored ones.\nInternal maintenance endpoint, the stats of the other stakers are left untouched.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staker stats before and after the rebuild",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_StakerStatsRebuildPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/unprocessable-messages": {
+            "get": {
+                "description": "Internal endpoint listing the queue messages that exhausted their retry\nattempts, oldest first, with the raw payload and the last processing error.\nThey can be replayed by starting the service with the --replay flag.\nAdmin endpoint, only served when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of unprocessable messages",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_service_UnprocessableMessagePublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/active-btc-at-risk": {
+            "get": {
+                "description": "Sums the active phase-1 delegations of the staker to finality providers\nthat are slashed (high risk) or jailed (medium risk) in phase-2.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Active BTC at risk and the risky finality providers",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_ActiveBtcAtRiskPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/covenant-exposure": {
+            "get": {
+                "description": "Lists the covenant committee members guarding the active phase-1 delegations of the staker,\nwith the number of delegations each of them protects.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Covenant members and delegation counts",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_CovenantExposurePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegation-lifecycle-summary": {
+            "get": {
+                "description": "Returns the number of phase-1 delegations of the staker in every phase of their lifecycle.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of delegations per lifecycle phase",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_DelegationLifecycleSummaryPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegation-proof": {
+            "get": {
+                "description": "Retrieves the Merkle proof of inclusion of a phase-1 delegation among all delegations of the staker.\nThe leaves of the tree are the staking transaction hashes of the staker sorted in ascending order.\nLeaves are hashed as sha256(0x00 || tx_hash) and inner nodes as sha256(0x01 || left || right).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staking transaction hash in hex format",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegation inclusion proof",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_DelegationProofPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegation/check": {
+            "get": {
+                "description": "Check if a staker has an active delegation by the staker BTC address (Taproot or Native Segwit), along with the number of its active delegations.\nAddresses of stakers without any processed delegation return false. Addresses of another BTC network are rejected.\nOptionally, you can provide a timeframe to check if the delegation is active within the provided timeframe\nThe available timeframe is \"today\" which checks after UTC 12AM of the current day",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shared"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC address in Taproot/Native Segwit format",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "today"
+                        ],
+                        "type": "string",
+                        "description": "Check if the delegation is active within the provided timeframe",
+                        "name": "timeframe",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegation check result",
+                        "schema": {
+                            "$ref": "#/definitions/v1handlers.DelegationCheckPublicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations": {
+            "get": {
+                "description": "Retrieves phase-1 delegations for a given staker. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.\nThis endpoint is only used to show legacy phase-1 delegations for the purpose of unbonding or registering into phase-2.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key, x-only in 32 bytes or compressed in 33 bytes",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return delegations with pending actions which include active, unbonding, unbonding_requested, unbonded",
+                        "name": "pending_action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated delegation states to filter on, e.g. active,unbonding_requested. Can not be combined with pending_action",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts",
+                        "name": "include_formatted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of delegations and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_DelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations/export": {
+            "get": {
+                "description": "Downloads all the phase-1 delegations of a staker, the most recent first, for accounting purposes.\nThe delegations are streamed as CSV with a header row, or as newline delimited JSON.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format, csv (default) or json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegations of the staker",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations/stream": {
+            "get": {
+                "description": "Server-sent events stream of the state changes of the delegations of a staker, held open until the client disconnects.\nEvery change is sent as a delegation_state_change event whose data holds the staking_tx_hash_hex, staker_pk_hex, new state and unix timestamp of the change, a change may be sent more than once.\nA stale event is sent when changes were dropped because the client did not keep up, the delegations have to be reloaded.\nA heartbeat comment is sent every 30 seconds.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of server-sent events",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/event-stream": {
+            "get": {
+                "description": "Server-sent events stream of the lifecycle events of the delegations of a staker, held open until the client disconnects.\nEvery event is sent as an unnamed message whose data holds its type along with the staking_tx_hash_hex, staker_pk_hex, new state and unix timestamp of the change, an event may be sent more than once.\nThe types are listed in the activity types of /v1/constants.\nA STREAM_STALE event is sent when events were dropped because the client did not keep up, the delegations have to be reloaded.\nA heartbeat comment is sent every 30 seconds.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of server-sent events",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/portfolio-value-usd": {
+            "get": {
+                "description": "Values the active phase-1 stake of the staker at the BTC price of the price oracle,\nthe price is at most 60 seconds old.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Active stake of the staker and its value in USD",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_PortfolioValueUsdPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "No fresh BTC price is available (PRICE_ORACLE_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/pubkey-lookup": {
+            "get": {
+                "description": "Retrieves public keys for the given BTC addresses. This endpoint\nonly returns public keys for addresses that have associated delegations in\nthe system. If an address has no associated delegation, it will not be\nincluded in the response. Supports both Taproot and Native Segwit addresses.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shared"
+                ],
+                "summary": "Get stakers' public keys",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "List of BTC addresses to look up (up to 10), currently only supports Taproot and Native Segwit addresses",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A map of BTC addresses to their corresponding public keys (only addresses with delegations are returned)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-map_string_string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request: Invalid input parameters",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/watchlist": {
+            "get": {
+                "description": "Retrieves the stakers watched by the observer, in the order they were added.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Observer BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of watched stakers",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_WatchlistEntryPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a staker to the watchlist of the observer, up to 100 stakers. Adding a staker already watched returns the existing entry.\nThe observer signs the sha256 of \"add:\u003cwatched_btc_pk_hex\u003e\" with the BIP-340 schnorr key of its BTC public key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Observer BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Watchlist Change Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1handlers.WatchlistRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watched staker",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_WatchlistEntryPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, full watchlist (BAD_REQUEST) or signature not matching the change (INVALID_SIGNATURE)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/watchlist/delegations": {
+            "get": {
+                "description": "Retrieves the phase-1 delegations of all the stakers watched by the observer.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Observer BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of delegations and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_DelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/watchlist/remove": {
+            "post": {
+                "description": "Removes a staker from the watchlist of the observer.\nThe observer signs the sha256 of \"remove:\u003cwatched_btc_pk_hex\u003e\" with the BIP-340 schnorr key of its BTC public key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Observer BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Watchlist Change Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1handlers.WatchlistRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Staker removed from the watchlist"
+                    },
+                    "400": {
+                        "description": "Invalid request payload (BAD_REQUEST) or signature not matching the change (INVALID_SIGNATURE)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Staker not watched",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/state-machine": {
+            "get": {
+                "description": "Retrieves the state transition graph of the phase-1 delegations and of their unbonding attempts,\nwith the trigger of each transition: api, queue-event or job.\nThe graph is built from the eligible previous states enforced on each transition.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegation and unbonding attempt state machines",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_StateMachinesPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats": {
+            "get": {
+                "description": "[DEPRECATED] Fetches overall stats for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers. Please use /v2/stats instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Get Overall Stats (Deprecated)",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts",
+                        "name": "include_formatted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Overall stats for babylon staking",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_OverallStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/covenant-response-time": {
+            "get": {
+                "description": "Fetches the 50th, 95th and 99th percentiles of the time in seconds between the unbonding request\nof a phase-1 delegation and its unbonding tx being observed on BTC, over the delegations unbonded\nin the last 7 days. The unbonding tx is only broadcast once co-signed by the covenants, so this is\nan upper bound of the covenant response time.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Get Covenant Response Time",
+                "responses": {
+                    "200": {
+                        "description": "Covenant response time percentiles",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_CovenantResponseTimePublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/new-stakers-per-day": {
+            "get": {
+                "description": "Fetches the number of stakers whose first delegation started on each day (UTC).\nThe result is refreshed every 10 minutes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Get New Stakers Per Day",
+                "responses": {
+                    "200": {
+                        "description": "Number of new stakers per day",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_NewStakersPerDayPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/provider-churn": {
+            "get": {
+                "description": "Fetches, for every finality provider, the number of phase-1 delegations which became active and\nthe number which left the active state, by unbonding or expiring, over the last 30 days, along with\nthe net change. The finality providers without any such delegation are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Get Provider Churn",
+                "responses": {
+                    "200": {
+                        "description": "Delegation churn of the finality providers",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_ProviderChurnPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/staker": {
+            "get": {
+                "description": "[DEPRECATED] Fetches staker stats for babylon staking including tvl, total delegations, active tvl and active delegations. Please use /v2/staker/stats instead.\nIf staker_btc_pk query parameter is provided, it will return stats for the specific staker.\nOtherwise, it will return the top stakers ranked by active tvl.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Get Staker Stats (Deprecated)",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public key of the staker to fetch",
+                        "name": "staker_btc_pk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of top stakers",
+                        "name": "pagination_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts",
+                        "name": "include_formatted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of top stakers by active tvl",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_StakerStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/time-series": {
+            "get": {
+                "description": "Daily series of the active tvl and active delegations at the end of each UTC day.\nDays without any change carry the previous value forward. The range is limited to 365 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day of the series, formatted as YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the series, formatted as YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daily stats series",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v2service_StatsTimeSeriesPointPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/withdrawal-completion-time": {
+            "get": {
+                "description": "Fetches the 50th and 95th percentiles of the time in seconds between a phase-1 delegation becoming\nunbonded, when its funds are withdrawable, and their withdrawal, over the delegations withdrawn in the\nlast 30 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Get Withdrawal Completion Time",
+                "responses": {
+                    "200": {
+                        "description": "Withdrawal completion time percentiles",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_WithdrawalCompletionTimePublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many aggregations in progress, retry later (QUERY_CAPACITY)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding": {
+            "post": {
+                "description": "Unbonds a phase-1 delegation by processing the provided transaction details. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.\nThis is an async operation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Unbond phase-1 delegation",
+                "parameters": [
+                    {
+                        "description": "Unbonding Request Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1handlers.UnbondDelegationRequestPayload"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the delegation returned by GET /v1/delegation, the request fails if the delegation changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Request accepted and will be processed asynchronously"
+                    },
+                    "400": {
+                        "description": "Invalid request payload (BAD_REQUEST), unbonding tx not spending the staking output as expected (INVALID_UNBONDING_TX), unbonding tx hash not matching the tx (TX_HASH_MISMATCH) or invalid staker signature (INVALID_SIGNATURE)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "The delegation changed since its ETag was fetched, the message holds its current state (PRECONDITION_FAILED)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests from the client IP, retry after the Retry-After header (TOO_MANY_REQUESTS)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding/eligibility": {
+            "get": {
+                "description": "Checks if a delegation identified by its staking transaction hash is eligible for unbonding. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.\nAn eligible delegation comes with its staking value and the unbonding fee to build the unbonding tx.\nThe reason of a not eligible delegation is either ` + "`" + `not_found` + "`" + `, ` + "`" + `wrong_state` + "`" + ` or ` + "`" + `already_requested` + "`" + `, along with its current state and, once its unbonding was requested, the unbonding tx hash and completion.\nThe errorCode and message of a not eligible delegation are deprecated and will be removed in the next release.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Check unbonding eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staking Transaction Hash Hex",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The delegation is eligible for unbonding",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v1service_UnbondingEligibilityDetailsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid 'staking_tx_hash_hex' query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "The delegation is not eligible for unbonding",
+                        "schema": {
+                            "$ref": "#/definitions/v1handlers.UnbondingIneligibleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding/eligibility/bulk": {
+            "post": {
+                "description": "Checks if the delegations identified by their staking transaction hashes are eligible for unbonding. The reason of a not eligible delegation is either ` + "`" + `not_found` + "`" + `, ` + "`" + `wrong_state` + "`" + ` or ` + "`" + `already_requested` + "`" + `. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1"
+                ],
+                "summary": "Check unbonding eligibility of multiple delegations",
+                "parameters": [
+                    {
+                        "description": "Staking transaction hashes, at most 100",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Eligibility of every delegation, in the order of the payload",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v1service_UnbondingEligibilityPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, too many hashes or malformed hashes, identified by their index (BAD_REQUEST)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests from the client IP, retry after the Retry-After header (TOO_MANY_REQUESTS)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/delegation": {
+            "get": {
+                "description": "Retrieves a delegation by a given transaction hash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get a delegation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staking transaction hash in hex format",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts",
+                        "name": "include_formatted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staker delegation",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v2service_DelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/delegations": {
+            "get": {
+                "description": "Fetches delegations for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get Delegations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker public key in hex format",
+                        "name": "staker_pk_hex",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return the delegations made under this babylon staking params version",
+                        "name": "params_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts",
+                        "name": "include_formatted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of staker delegations and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v2service_DelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/finality-providers": {
+            "get": {
+                "description": "Fetches finality providers with its stats, currently does not support pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List Finality Providers",
+                "parameters": [
+                    {
+                        "enum": [
+                            "active_tvl",
+                            "total_tvl",
+                            "active_delegations",
+                            "total_delegations"
+                        ],
+                        "type": "string",
+                        "description": "Sort the finality providers by the given stat in descending order",
+                        "name": "order_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of finality providers with its stats",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-array_v2service_FinalityProviderStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters or malformed request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "No finality providers found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error occurred",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/network-info": {
+            "get": {
+                "description": "Get network info, including staking status and param",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Network info",
+                        "schema": {
+                            "$ref": "#/definitions/v2service.NetworkInfoPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/staker/stats": {
+            "get": {
+                "description": "Fetches staker stats for babylon staking including active tvl and active delegations.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get Staker Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public key of the staker to fetch",
+                        "name": "staker_pk_hex",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts",
+                        "name": "include_formatted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staker stats",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v2service_StakerStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Error: Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/stats": {
+            "get": {
+                "description": "Overall system stats",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts",
+                        "name": "include_formatted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PublicResponse-v2service_OverallStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "bootreport.DbReport": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "db_name": {
+                    "type": "string"
+                },
+                "topology": {
+                    "type": "string"
+                }
+            }
+        },
+        "bootreport.QueueReport": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "type": "string"
+                },
+                "queue_type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "bootreport.Report": {
+            "type": "object",
+            "properties": {
+                "btc_network": {
+                    "type": "string"
+                },
+                "config_path": {
+                    "type": "string"
+                },
+                "config_sha256": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "finality_providers_count": {
+                    "type": "integer"
+                },
+                "finality_providers_path": {
+                    "type": "string"
+                },
+                "global_params_path": {
+                    "type": "string"
+                },
+                "global_params_versions": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "indexer_db": {
+                    "$ref": "#/definitions/bootreport.DbReport"
+                },
+                "indexes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dbmodel.IndexSetupResult"
+                    }
+                },
+                "migration_status": {
+                    "type": "string"
+                },
+                "queue": {
+                    "$ref": "#/definitions/bootreport.QueueReport"
+                },
+                "staking_db": {
+                    "$ref": "#/definitions/bootreport.DbReport"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dbmodel.IndexSetupResult": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "keys": {
+                    "type": "string"
+                },
+                "unique": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_babylonlabs-io_staking-api-service_internal_shared_types.Error": {
+            "type": "object",
+            "properties": {
+                "err": {},
+                "errorCode": {
+                    "$ref": "#/definitions/types.ErrorCode"
+                },
+                "statusCode": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.PublicResponse-array_handler_QueueModePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.QueueModePublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_service_UnprocessableMessagePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.UnprocessableMessagePublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_ActiveStakePointPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ActiveStakePointPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_CovenantExposurePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.CovenantExposurePublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_CovenantMissingDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.CovenantMissingDelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.DelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_DustDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.DustDelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_FpDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.FpDetailsPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_FpStakersPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.FpStakersPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_HighValueDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.HighValueDelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_IntegrityIssuePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.IntegrityIssuePublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_NearCovenantExpiryDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.NearCovenantExpiryDelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_NewStakersPerDayPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.NewStakersPerDayPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_OrphanedDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.OrphanedDelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_OverdueWithdrawalDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.OverdueWithdrawalDelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_ProviderChangeCandidatePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ProviderChangeCandidatePublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_ProviderChurnPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ProviderChurnPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_ReactivationCandidatePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ReactivationCandidatePublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_StakerStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.StakerStatsPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_UnbondingEligibilityPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.UnbondingEligibilityPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v1service_WatchlistEntryPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.WatchlistEntryPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v2service_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2service.DelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v2service_EventGapPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2service.EventGapPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
                 }
             }
         },
         "handler.PublicResponse-array_v2service_FinalityProviderStatsPublic": {
             "type": "object",
             "properties": {
-                "data": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2service.FinalityProviderStatsPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-array_v2service_StatsTimeSeriesPointPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2service.StatsTimeSeriesPointPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-bootreport_Report": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/bootreport.Report"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-handler_QueueThrottlePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.QueueThrottlePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-handler_ReadinessPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.ReadinessPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-map_string_string": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/map_string_string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_ActiveBtcAtRiskPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.ActiveBtcAtRiskPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_CoStakersPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.CoStakersPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_ConsistencyVerificationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.ConsistencyVerificationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_ConstantsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.ConstantsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_CovenantResponseTimePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.CovenantResponseTimePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_DelegationConsistencyPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.DelegationConsistencyPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_DelegationLifecycleSummaryPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.DelegationLifecycleSummaryPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_DelegationProofPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.DelegationProofPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.DelegationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_FpBulkRegistrationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.FpBulkRegistrationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_GlobalParamsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.GlobalParamsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_MisbehaviorReportPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.MisbehaviorReportPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_OverallStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.OverallStatsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_PortfolioValueUsdPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.PortfolioValueUsdPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_StakerDossierPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.StakerDossierPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_StakerStatsRebuildPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.StakerStatsRebuildPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_StakingDurationStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.StakingDurationStatsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_StateMachinesPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.StateMachinesPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_UnbondingEligibilityDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.UnbondingEligibilityDetailsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_UnbondingSigHashPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.UnbondingSigHashPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_ValueConcentrationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.ValueConcentrationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_WatchlistEntryPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.WatchlistEntryPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v1service_WithdrawalCompletionTimePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.WithdrawalCompletionTimePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v2service_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v2service.DelegationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v2service_OverallStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v2service.OverallStatsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.PublicResponse-v2service_StakerStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v2service.StakerStatsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.paginationResponse"
+                }
+            }
+        },
+        "handler.QueueDryRunPayload": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "queue": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.QueueModePublic": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "queue": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.QueueThrottleOverridePayload": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "description": "Factor is the throttle factor within [0, 1], null clears the override",
+                    "type": "number"
+                }
+            }
+        },
+        "handler.QueueThrottlePublic": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "description": "Factor is the share of the full speed the queue messages are consumed\nat, 1 when not throttled",
+                    "type": "number"
+                },
+                "overridden": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.ReadinessPublic": {
+            "type": "object",
+            "properties": {
+                "failed_dependencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ready": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.paginationResponse": {
+            "type": "object",
+            "properties": {
+                "next_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "indexertypes.BbnStakingParams": {
+            "type": "object",
+            "properties": {
+                "allow_list_expiration_height": {
+                    "type": "integer"
+                },
+                "btc_activation_height": {
+                    "type": "integer"
+                },
+                "covenant_pks": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "covenant_quorum": {
+                    "type": "integer"
+                },
+                "delegation_creation_base_gas_fee": {
+                    "type": "integer"
+                },
+                "max_active_finality_providers": {
+                    "type": "integer"
+                },
+                "max_staking_time_blocks": {
+                    "type": "integer"
+                },
+                "max_staking_value_sat": {
+                    "type": "integer"
+                },
+                "min_commission_rate": {
+                    "type": "string"
+                },
+                "min_slashing_tx_fee_sat": {
+                    "type": "integer"
+                },
+                "min_staking_time_blocks": {
+                    "type": "integer"
+                },
+                "min_staking_value_sat": {
+                    "type": "integer"
+                },
+                "slashing_pk_script": {
+                    "type": "string"
+                },
+                "slashing_rate": {
+                    "type": "string"
+                },
+                "unbonding_fee_sat": {
+                    "type": "integer"
+                },
+                "unbonding_time_blocks": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "indexertypes.BtcCheckpointParams": {
+            "type": "object",
+            "properties": {
+                "btc_confirmation_depth": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "map_string_string": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "service.UnprocessableMessagePublic": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message_body": {
+                    "type": "string"
+                },
+                "queue_name": {
+                    "type": "string"
+                },
+                "receipt": {
+                    "type": "string"
+                },
+                "retry_attempts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "INTERNAL_SERVICE_ERROR",
+                "VALIDATION_ERROR",
+                "NOT_FOUND",
+                "BAD_REQUEST",
+                "UNAUTHORIZED",
+                "FORBIDDEN",
+                "UNPROCESSABLE_ENTITY",
+                "REQUEST_TIMEOUT",
+                "INVALID_SIGNATURE",
+                "INVALID_UNBONDING_TX",
+                "TX_HASH_MISMATCH",
+                "INVALID_PAGINATION_TOKEN",
+                "PRECONDITION_FAILED",
+                "TOO_MANY_REQUESTS",
+                "QUERY_CAPACITY",
+                "PRICE_ORACLE_UNAVAILABLE"
+            ],
+            "x-enum-varnames": [
+                "InternalServiceError",
+                "ValidationError",
+                "NotFound",
+                "BadRequest",
+                "Unauthorized",
+                "Forbidden",
+                "UnprocessableEntity",
+                "RequestTimeout",
+                "InvalidSignature",
+                "InvalidUnbondingTx",
+                "TxHashMismatch",
+                "InvalidPaginationToken",
+                "PreconditionFailed",
+                "TooManyRequests",
+                "QueryCapacity",
+                "PriceOracleUnavailable"
+            ]
+        },
+        "types.FinalityProviderDescription": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "string"
+                },
+                "identity": {
+                    "type": "string"
+                },
+                "moniker": {
+                    "type": "string"
+                },
+                "security_contact": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.FinalityProviderDetails": {
+            "type": "object",
+            "properties": {
+                "btc_pk": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "description": {
+                    "$ref": "#/definitions/types.FinalityProviderDescription"
+                },
+                "logo_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.FinalityProviderQueryingState": {
+            "type": "string",
+            "enum": [
+                "active",
+                "standby"
+            ],
+            "x-enum-varnames": [
+                "FinalityProviderStateActive",
+                "FinalityProviderStateStandby"
+            ]
+        },
+        "v1handlers.DelegationCheckPublicResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1handlers.ReportMisbehaviorRequestPayload": {
+            "type": "object",
+            "properties": {
+                "evidence_tx_hex": {
+                    "type": "string"
+                },
+                "evidence_type": {
+                    "type": "string",
+                    "enum": [
+                        "double_sign",
+                        "unavailability"
+                    ]
+                },
+                "reporter_btc_pk_hex": {
+                    "type": "string"
+                },
+                "reporter_sig_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1handlers.UnbondDelegationRequestPayload": {
+            "type": "object",
+            "properties": {
+                "staker_signed_signature_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
+                },
+                "unbonding_tx_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1handlers.UnbondingIneligibleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/v1service.UnbondingEligibilityDetailsPublic"
+                },
+                "errorCode": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1handlers.UpdateGlobalParamRequestPayload": {
+            "type": "object",
+            "properties": {
+                "effective_from_height": {
+                    "type": "integer"
+                },
+                "param_name": {
+                    "type": "string",
+                    "enum": [
+                        "min_staking_amount",
+                        "max_staking_amount",
+                        "min_staking_time",
+                        "max_staking_time"
+                    ]
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1handlers.WatchlistRequestPayload": {
+            "type": "object",
+            "properties": {
+                "signature_hex": {
+                    "type": "string"
+                },
+                "watched_btc_pk_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.ActiveBtcAtRiskPublic": {
+            "type": "object",
+            "properties": {
+                "delegations_at_risk_count": {
+                    "type": "integer"
+                },
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.FinalityProviderRiskPublic"
+                    }
+                },
+                "total_at_risk_sat": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.ActiveStakePointPublic": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "expired_delegations": {
+                    "type": "integer"
+                },
+                "new_delegations": {
+                    "type": "integer"
+                },
+                "total_active_sat": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.CoStakerPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.CoStakersPublic": {
+            "type": "object",
+            "properties": {
+                "co_staker_count": {
+                    "type": "integer"
+                },
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "top_co_stakers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.CoStakerPublic"
+                    }
+                }
+            }
+        },
+        "v1service.ConsistencyIssuePublic": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "string"
+                },
+                "issue": {
+                    "$ref": "#/definitions/v1service.ConsistencyIssueType"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.ConsistencyIssueType": {
+            "type": "string",
+            "enum": [
+                "unknown_state",
+                "invalid_state_transition",
+                "zero_staking_value",
+                "missing_required_field"
+            ],
+            "x-enum-varnames": [
+                "ConsistencyIssueUnknownState",
+                "ConsistencyIssueInvalidTransition",
+                "ConsistencyIssueZeroStakingValue",
+                "ConsistencyIssueMissingField"
+            ]
+        },
+        "v1service.ConsistencyVerificationPublic": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "integer"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ConsistencyIssuePublic"
+                    }
+                }
+            }
+        },
+        "v1service.ConstantPublic": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "terminal": {
+                    "type": "boolean"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.ConstantsPublic": {
+            "type": "object",
+            "properties": {
+                "activity_types": {
+                    "description": "ActivityTypes are the types of the events of the staker event stream",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ConstantPublic"
+                    }
+                },
+                "delegation_states": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ConstantPublic"
+                    }
+                },
+                "error_codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ConstantPublic"
+                    }
+                },
+                "unbonding_attempt_states": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.ConstantPublic"
+                    }
+                }
+            }
+        },
+        "v1service.CovenantExposurePublic": {
+            "type": "object",
+            "properties": {
+                "covenant_pk_hex": {
+                    "type": "string"
+                },
+                "delegation_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.CovenantMissingDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.CovenantResponseTimePublic": {
+            "type": "object",
+            "properties": {
+                "p50_seconds": {
+                    "type": "integer"
+                },
+                "p95_seconds": {
+                    "type": "integer"
+                },
+                "p99_seconds": {
+                    "type": "integer"
+                },
+                "sample_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.CovenantSignatureProgress": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "collected",
+                "abandoned"
+            ],
+            "x-enum-varnames": [
+                "CovenantSignaturesPending",
+                "CovenantSignaturesCollected",
+                "CovenantSignaturesAbandoned"
+            ]
+        },
+        "v1service.DelegationConsistencyPublic": {
+            "type": "object",
+            "properties": {
+                "consistent": {
+                    "type": "boolean"
+                },
+                "invariants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.InvariantResultPublic"
+                    }
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.DelegationLifecycleSummaryPublic": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer"
+                },
+                "transitioned": {
+                    "type": "integer"
+                },
+                "unbonded": {
+                    "description": "Unbonded are the delegations whose timelock expired, they are waiting\nto be withdrawn",
+                    "type": "integer"
+                },
+                "unbonding": {
+                    "description": "Unbonding are the delegations whose unbonding tx is confirmed and\nwhose unbonding timelock has not expired yet",
+                    "type": "integer"
+                },
+                "unbonding_requested": {
+                    "description": "UnbondingRequested are the delegations whose unbonding request is\nwaiting for the covenant signatures and the unbonding tx confirmation",
+                    "type": "integer"
+                },
+                "withdrawn": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.DelegationProofPublic": {
+            "type": "object",
+            "properties": {
+                "leaf_count": {
+                    "type": "integer"
+                },
+                "leaf_index": {
+                    "type": "integer"
+                },
+                "proof": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.DelegationProofStepPublic"
+                    }
+                },
+                "root_hex": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.DelegationProofStepPublic": {
+            "type": "object",
+            "properties": {
+                "hash_hex": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "is_eligible_for_transition": {
+                    "type": "boolean"
+                },
+                "is_overflow": {
+                    "type": "boolean"
+                },
+                "is_slashed": {
+                    "type": "boolean"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx": {
+                    "$ref": "#/definitions/v1service.TransactionPublic"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "staking_value_btc": {
+                    "type": "string"
+                },
+                "staking_value_usd_at_time": {
+                    "description": "StakingValueUsdAtTime is null when no price oracle was enabled at the\ntime the delegation was inserted",
+                    "type": "number"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "state_history": {
+                    "description": "StateHistory is only set if requested, oldest transition first",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/v2service.FinalityProviderStatsPublic"
+                        "$ref": "#/definitions/v1service.StateTransitionPublic"
                     }
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                "unbonding_completion": {
+                    "description": "UnbondingCompletion is only set while the delegation is unbonding",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1service.UnbondingCompletionPublic"
+                        }
+                    ]
+                },
+                "unbonding_tx": {
+                    "$ref": "#/definitions/v1service.TransactionPublic"
+                },
+                "withdrawal_path": {
+                    "description": "WithdrawalPath is only set once the delegation is withdrawn, either\nearly_unbonding, natural_expiry or unknown",
+                    "type": "string"
+                },
+                "withdrawal_tx": {
+                    "$ref": "#/definitions/v1service.WithdrawalTxPublic"
                 }
             }
         },
-        "handler.PublicResponse-map_string_string": {
+        "v1service.DossierDelegationsPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/map_string_string"
+                "count": {
+                    "type": "integer"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                "items": {
+                    "description": "Items is omitted if the staker has more delegations than a section\nholds, they can be fetched from the Link instead",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.DelegationPublic"
+                    }
+                },
+                "link": {
+                    "type": "string"
                 }
             }
         },
-        "handler.PublicResponse-v1service_DelegationPublic": {
+        "v1service.DossierStatsPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/v1service.DelegationPublic"
+                "lifecycle": {
+                    "$ref": "#/definitions/v1service.DelegationLifecycleSummaryPublic"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                "stats": {
+                    "description": "Stats is null if the staker has no stats yet",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1service.StakerStatsPublic"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1service.DossierUnbondingAttemptPublic": {
+            "type": "object",
+            "properties": {
+                "covenant_signatures": {
+                    "$ref": "#/definitions/v1service.CovenantSignatureProgress"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.DossierUnbondingAttemptsPublic": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "counts_by_covenant_signatures": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "items": {
+                    "description": "Items is omitted if the staker has more unbonding attempts than a\nsection holds",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.DossierUnbondingAttemptPublic"
+                    }
+                }
+            }
+        },
+        "v1service.DossierWatchlistPublic": {
+            "type": "object",
+            "properties": {
+                "watched_by_count": {
+                    "description": "WatchedByCount is the number of observers watching the staker, the\nobservers are other stakers and are not disclosed",
+                    "type": "integer"
+                },
+                "watching": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.WatchlistEntryPublic"
+                    }
+                }
+            }
+        },
+        "v1service.DustDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "min_staking_value": {
+                    "type": "integer"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.FinalityProviderRiskPublic": {
+            "type": "object",
+            "properties": {
+                "pk_hex": {
+                    "type": "string"
+                },
+                "risk_level": {
+                    "$ref": "#/definitions/v1service.RiskLevel"
+                }
+            }
+        },
+        "v1service.FpBulkRegistrationPublic": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.FpRegistrationFailurePublic"
+                    }
+                },
+                "registered": {
+                    "type": "integer"
+                },
+                "skipped_duplicates": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.FpDescriptionPublic": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "string"
+                },
+                "identity": {
+                    "type": "string"
+                },
+                "moniker": {
+                    "type": "string"
+                },
+                "security_contact": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.FpDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "btc_pk": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "description": {
+                    "$ref": "#/definitions/v1service.FpDescriptionPublic"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.FpRegistrationFailurePublic": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.FpStakersPublic": {
+            "type": "object",
+            "properties": {
+                "btc_pk": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "description": {
+                    "$ref": "#/definitions/v1service.FpDescriptionPublic"
+                },
+                "unique_stakers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.GlobalParamsPublic": {
+            "type": "object",
+            "properties": {
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.VersionedGlobalParamsPublic"
+                    }
+                }
+            }
+        },
+        "v1service.HighValueDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "start_height": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
                 }
             }
         },
-        "handler.PublicResponse-v1service_GlobalParamsPublic": {
+        "v1service.IntegrityIssuePublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/v1service.GlobalParamsPublic"
+                "delegation_state": {
+                    "type": "string"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                "details": {
+                    "type": "string"
+                },
+                "detected_at": {
+                    "type": "integer"
+                },
+                "invariant": {
+                    "type": "string"
+                },
+                "issue_type": {
+                    "type": "string"
+                },
+                "quarantined": {
+                    "type": "boolean"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
                 }
             }
         },
-        "handler.PublicResponse-v1service_OverallStatsPublic": {
+        "v1service.InvariantResultPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/v1service.OverallStatsPublic"
+                "details": {
+                    "type": "string"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/v1service.InvariantStatus"
                 }
             }
         },
-        "handler.PublicResponse-v2service_DelegationPublic": {
+        "v1service.InvariantStatus": {
+            "type": "string",
+            "enum": [
+                "pass",
+                "fail",
+                "not_applicable"
+            ],
+            "x-enum-varnames": [
+                "InvariantPass",
+                "InvariantFail",
+                "InvariantNotApplicable"
+            ]
+        },
+        "v1service.MisbehaviorReportPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/v2service.DelegationPublic"
+                "evidence_hash_hex": {
+                    "type": "string"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                "report_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "handler.PublicResponse-v2service_OverallStatsPublic": {
+        "v1service.NearCovenantExpiryDelegationPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/v2service.OverallStatsPublic"
+                "blocks_remaining": {
+                    "description": "BlocksRemaining is negative once the staking timelock expired",
+                    "type": "integer"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_expiry_height": {
+                    "type": "integer"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "unbonding_tx_hash_hex": {
+                    "description": "UnbondingTxHashHex is empty if the unbonding request is not recorded\nin the state history of the delegation",
+                    "type": "string"
                 }
             }
         },
-        "handler.PublicResponse-v2service_StakerStatsPublic": {
+        "v1service.NewStakersPerDayPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/v2service.StakerStatsPublic"
+                "date": {
+                    "type": "string"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handler.paginationResponse"
+                "new_stakers": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.paginationResponse": {
+        "v1service.OrphanedDelegationPublic": {
             "type": "object",
             "properties": {
-                "next_key": {
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "start_height": {
+                    "type": "integer"
+                },
+                "state": {
                     "type": "string"
                 }
             }
         },
-        "indexertypes.BbnStakingParams": {
+        "v1service.OverallStatsPublic": {
             "type": "object",
             "properties": {
-                "allow_list_expiration_height": {
+                "active_delegations": {
                     "type": "integer"
                 },
-                "btc_activation_height": {
+                "active_tvl": {
                     "type": "integer"
                 },
-                "covenant_pks": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "active_tvl_btc": {
+                    "type": "string"
                 },
-                "covenant_quorum": {
+                "pending_tvl": {
                     "type": "integer"
                 },
-                "delegation_creation_base_gas_fee": {
-                    "type": "integer"
+                "pending_tvl_btc": {
+                    "type": "string"
                 },
-                "max_active_finality_providers": {
+                "total_delegations": {
                     "type": "integer"
                 },
-                "max_staking_time_blocks": {
+                "total_stakers": {
                     "type": "integer"
                 },
-                "max_staking_value_sat": {
+                "total_tvl": {
                     "type": "integer"
                 },
-                "min_commission_rate": {
+                "total_tvl_btc": {
                     "type": "string"
                 },
-                "min_slashing_tx_fee_sat": {
+                "unconfirmed_tvl": {
                     "type": "integer"
                 },
-                "min_staking_time_blocks": {
+                "unconfirmed_tvl_btc": {
+                    "type": "string"
+                },
+                "withdrawn_tvl": {
+                    "description": "WithdrawnTvl splits the value of the withdrawn delegations by\nwithdrawal path",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1service.WithdrawnTvlPublic"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1service.OverdueWithdrawalDelegationPublic": {
+            "type": "object",
+            "properties": {
+                "blocks_overdue": {
+                    "description": "BlocksOverdue is the number of blocks since the funds are withdrawable",
                     "type": "integer"
                 },
-                "min_staking_value_sat": {
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_expiry_height": {
                     "type": "integer"
                 },
-                "slashing_pk_script": {
+                "staking_tx_hash_hex": {
                     "type": "string"
                 },
-                "slashing_rate": {
+                "staking_value": {
+                    "type": "integer"
+                },
+                "state": {
                     "type": "string"
                 },
-                "unbonding_fee_sat": {
+                "unbonding_expiry_height": {
+                    "description": "UnbondingExpiryHeight is only set for the delegations unbonded early,\ntheir funds are withdrawable from then on",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.PortfolioValueUsdPublic": {
+            "type": "object",
+            "properties": {
+                "btc_price_usd": {
+                    "type": "number"
+                },
+                "total_active_sat": {
                     "type": "integer"
                 },
-                "unbonding_time_blocks": {
+                "total_active_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1service.ProviderChangeCandidatePublic": {
+            "type": "object",
+            "properties": {
+                "blocks_remaining": {
+                    "description": "BlocksRemaining is negative once the staking timelock expired",
                     "type": "integer"
                 },
-                "version": {
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "staking_expiry_height": {
                     "type": "integer"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "suggested_finality_provider": {
+                    "description": "SuggestedFinalityProvider is the registered finality provider with the\nhighest active TVL other than the current one, nil if there is none",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1service.FpDetailsPublic"
+                        }
+                    ]
                 }
             }
         },
-        "indexertypes.BtcCheckpointParams": {
+        "v1service.ProviderChurnPublic": {
             "type": "object",
             "properties": {
-                "btc_confirmation_depth": {
+                "expired_delegations": {
                     "type": "integer"
                 },
-                "version": {
+                "net_change": {
+                    "type": "integer"
+                },
+                "new_delegations": {
                     "type": "integer"
+                },
+                "provider_pk_hex": {
+                    "type": "string"
                 }
             }
         },
-        "map_string_string": {
+        "v1service.ReactivationCandidatePublic": {
             "type": "object",
-            "additionalProperties": {
-                "type": "string"
+            "properties": {
+                "expiry_height": {
+                    "description": "ExpiryHeight is the height at which the timelock of the withdrawn\noutput, the unbonding one if the delegation was unbonded, expired",
+                    "type": "integer"
+                },
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value_sat": {
+                    "type": "integer"
+                },
+                "withdrawal_tx_confirmation_height": {
+                    "description": "WithdrawalTxConfirmationHeight is omitted for the delegations withdrawn\nbefore the withdrawal tx was recorded",
+                    "type": "integer"
+                }
             }
         },
-        "types.ErrorCode": {
+        "v1service.RiskLevel": {
             "type": "string",
             "enum": [
-                "INTERNAL_SERVICE_ERROR",
-                "VALIDATION_ERROR",
-                "NOT_FOUND",
-                "BAD_REQUEST",
-                "FORBIDDEN",
-                "UNPROCESSABLE_ENTITY",
-                "REQUEST_TIMEOUT"
+                "high",
+                "medium"
             ],
             "x-enum-varnames": [
-                "InternalServiceError",
-                "ValidationError",
-                "NotFound",
-                "BadRequest",
-                "Forbidden",
-                "UnprocessableEntity",
-                "RequestTimeout"
+                "RiskLevelHigh",
+                "RiskLevelMedium"
             ]
         },
-        "types.FinalityProviderDescription": {
+        "v1service.StakerDossierPublic": {
             "type": "object",
             "properties": {
-                "details": {
-                    "type": "string"
+                "delegations": {
+                    "$ref": "#/definitions/v1service.DossierDelegationsPublic"
                 },
-                "identity": {
+                "section_errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "staker_pk_hex": {
                     "type": "string"
                 },
-                "moniker": {
+                "stats": {
+                    "$ref": "#/definitions/v1service.DossierStatsPublic"
+                },
+                "unbonding_attempts": {
+                    "$ref": "#/definitions/v1service.DossierUnbondingAttemptsPublic"
+                },
+                "watchlist": {
+                    "$ref": "#/definitions/v1service.DossierWatchlistPublic"
+                }
+            }
+        },
+        "v1service.StakerStatsPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "active_tvl_btc": {
                     "type": "string"
                 },
-                "security_contact": {
+                "staker_pk_hex": {
                     "type": "string"
                 },
-                "website": {
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
+                },
+                "total_tvl_btc": {
                     "type": "string"
                 }
             }
         },
-        "types.FinalityProviderQueryingState": {
-            "type": "string",
-            "enum": [
-                "active",
-                "standby"
-            ],
-            "x-enum-varnames": [
-                "FinalityProviderStateActive",
-                "FinalityProviderStateStandby"
-            ]
+        "v1service.StakerStatsRebuildPublic": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "$ref": "#/definitions/v1service.StakerStatsPublic"
+                },
+                "before": {
+                    "$ref": "#/definitions/v1service.StakerStatsPublic"
+                },
+                "changed": {
+                    "type": "boolean"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                }
+            }
         },
-        "v1handlers.DelegationCheckPublicResponse": {
+        "v1service.StakingDurationStatsPublic": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "avg_staking_duration_blocks": {
+                    "type": "number"
                 },
-                "data": {
-                    "type": "boolean"
+                "median_staking_duration_blocks": {
+                    "type": "number"
+                },
+                "p95_staking_duration_blocks": {
+                    "type": "integer"
                 }
             }
         },
-        "v1handlers.UnbondDelegationRequestPayload": {
+        "v1service.StateMachinePublic": {
             "type": "object",
             "properties": {
-                "staker_signed_signature_hex": {
-                    "type": "string"
-                },
-                "staking_tx_hash_hex": {
-                    "type": "string"
-                },
-                "unbonding_tx_hash_hex": {
-                    "type": "string"
+                "states": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.StateMachineStatePublic"
+                    }
                 },
-                "unbonding_tx_hex": {
-                    "type": "string"
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1service.StateMachineTransitionPublic"
+                    }
                 }
             }
         },
-        "v1service.DelegationPublic": {
+        "v1service.StateMachineStatePublic": {
             "type": "object",
             "properties": {
-                "finality_provider_pk_hex": {
-                    "type": "string"
-                },
-                "is_eligible_for_transition": {
-                    "type": "boolean"
-                },
-                "is_overflow": {
+                "initial": {
                     "type": "boolean"
                 },
-                "is_slashed": {
+                "reachable": {
+                    "description": "Reachable is false for the states never set on the documents of this\nstate machine",
                     "type": "boolean"
                 },
-                "staker_pk_hex": {
+                "state": {
                     "type": "string"
                 },
-                "staking_tx": {
-                    "$ref": "#/definitions/v1service.TransactionPublic"
-                },
-                "staking_tx_hash_hex": {
+                "terminal": {
+                    "description": "Terminal is true for the reachable states without outgoing transition",
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1service.StateMachineTransitionPublic": {
+            "type": "object",
+            "properties": {
+                "from": {
                     "type": "string"
                 },
-                "staking_value": {
-                    "type": "integer"
+                "source": {
+                    "type": "string"
                 },
-                "state": {
+                "to": {
                     "type": "string"
                 },
-                "unbonding_tx": {
-                    "$ref": "#/definitions/v1service.TransactionPublic"
+                "trigger": {
+                    "$ref": "#/definitions/v1service.StateTransitionTrigger"
                 }
             }
         },
-        "v1service.FpDescriptionPublic": {
+        "v1service.StateMachinesPublic": {
             "type": "object",
             "properties": {
-                "details": {
-                    "type": "string"
+                "delegation": {
+                    "$ref": "#/definitions/v1service.StateMachinePublic"
                 },
-                "identity": {
+                "unbonding_attempt": {
+                    "$ref": "#/definitions/v1service.StateMachinePublic"
+                }
+            }
+        },
+        "v1service.StateTransitionPublic": {
+            "type": "object",
+            "properties": {
+                "from_state": {
                     "type": "string"
                 },
-                "moniker": {
+                "timestamp": {
                     "type": "string"
                 },
-                "security_contact": {
+                "to_state": {
                     "type": "string"
                 },
-                "website": {
+                "tx_hash_hex": {
                     "type": "string"
                 }
             }
         },
-        "v1service.FpDetailsPublic": {
+        "v1service.StateTransitionTrigger": {
+            "type": "string",
+            "enum": [
+                "api",
+                "queue-event",
+                "job"
+            ],
+            "x-enum-varnames": [
+                "ApiTrigger",
+                "QueueEventTrigger",
+                "JobTrigger"
+            ]
+        },
+        "v1service.TransactionPublic": {
             "type": "object",
             "properties": {
-                "active_delegations": {
+                "output_index": {
                     "type": "integer"
                 },
-                "active_tvl": {
+                "start_height": {
                     "type": "integer"
                 },
-                "btc_pk": {
-                    "type": "string"
-                },
-                "commission": {
+                "start_timestamp": {
                     "type": "string"
                 },
-                "description": {
-                    "$ref": "#/definitions/v1service.FpDescriptionPublic"
-                },
-                "total_delegations": {
+                "timelock": {
                     "type": "integer"
                 },
-                "total_tvl": {
-                    "type": "integer"
+                "tx_hex": {
+                    "type": "string"
                 }
             }
         },
-        "v1service.GlobalParamsPublic": {
+        "v1service.UnbondingCompletionPublic": {
             "type": "object",
             "properties": {
-                "versions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1service.VersionedGlobalParamsPublic"
-                    }
+                "estimated": {
+                    "type": "boolean"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "v1service.OverallStatsPublic": {
+        "v1service.UnbondingEligibilityDetailsPublic": {
             "type": "object",
             "properties": {
-                "active_delegations": {
-                    "type": "integer"
+                "eligible": {
+                    "type": "boolean"
                 },
-                "active_tvl": {
-                    "type": "integer"
+                "reason": {
+                    "$ref": "#/definitions/v1service.UnbondingIneligibilityReason"
                 },
-                "pending_tvl": {
-                    "type": "integer"
+                "staking_tx_hash_hex": {
+                    "type": "string"
                 },
-                "total_delegations": {
+                "staking_value": {
                     "type": "integer"
                 },
-                "total_stakers": {
-                    "type": "integer"
+                "state": {
+                    "description": "State is empty if the delegation is not found",
+                    "type": "string"
                 },
-                "total_tvl": {
-                    "type": "integer"
+                "unbonding_completion": {
+                    "$ref": "#/definitions/v1service.UnbondingCompletionPublic"
                 },
-                "unconfirmed_tvl": {
+                "unbonding_fee": {
                     "type": "integer"
+                },
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
                 }
             }
         },
-        "v1service.StakerStatsPublic": {
+        "v1service.UnbondingEligibilityPublic": {
             "type": "object",
             "properties": {
-                "active_delegations": {
-                    "type": "integer"
+                "eligible": {
+                    "type": "boolean"
                 },
-                "active_tvl": {
-                    "type": "integer"
+                "reason": {
+                    "$ref": "#/definitions/v1service.UnbondingIneligibilityReason"
                 },
-                "staker_pk_hex": {
+                "staking_tx_hash_hex": {
                     "type": "string"
-                },
-                "total_delegations": {
-                    "type": "integer"
-                },
-                "total_tvl": {
-                    "type": "integer"
                 }
             }
         },
-        "v1service.TransactionPublic": {
+        "v1service.UnbondingIneligibilityReason": {
+            "type": "string",
+            "enum": [
+                "not_found",
+                "wrong_state",
+                "already_requested"
+            ],
+            "x-enum-varnames": [
+                "UnbondingIneligibleNotFound",
+                "UnbondingIneligibleWrongState",
+                "UnbondingIneligibleAlreadyRequested"
+            ]
+        },
+        "v1service.UnbondingSigHashPublic": {
             "type": "object",
             "properties": {
-                "output_index": {
-                    "type": "integer"
+                "leaf_hash_hex": {
+                    "type": "string"
                 },
-                "start_height": {
-                    "type": "integer"
+                "script_hex": {
+                    "type": "string"
                 },
-                "start_timestamp": {
+                "sighash_hex": {
+                    "description": "SigHashHex is the message the staker signature must sign, with the\ndefault taproot sighash type",
                     "type": "string"
                 },
-                "timelock": {
+                "staking_output_pk_script_hex": {
+                    "type": "string"
+                },
+                "staking_output_value": {
                     "type": "integer"
                 },
-                "tx_hex": {
+                "staking_tx_hash_hex": {
                     "type": "string"
+                },
+                "unbonding_tx_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.ValueConcentrationPublic": {
+            "type": "object",
+            "properties": {
+                "gini_coefficient": {
+                    "type": "number"
+                },
+                "sample_size": {
+                    "type": "integer"
                 }
             }
         },
@@ -1164,6 +5442,69 @@ const docTemplate = `{
                 }
             }
         },
+        "v1service.WatchlistEntryPublic": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "watched_pk_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.WithdrawalCompletionTimePublic": {
+            "type": "object",
+            "properties": {
+                "p50_seconds": {
+                    "type": "integer"
+                },
+                "p95_seconds": {
+                    "type": "integer"
+                },
+                "sample_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1service.WithdrawalTxPublic": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "tx_hash_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1service.WithdrawnTvlPublic": {
+            "type": "object",
+            "properties": {
+                "early_unbonding": {
+                    "type": "integer"
+                },
+                "early_unbonding_btc": {
+                    "type": "string"
+                },
+                "natural_expiry": {
+                    "type": "integer"
+                },
+                "natural_expiry_btc": {
+                    "type": "string"
+                },
+                "unknown": {
+                    "description": "Unknown is for the delegations withdrawn before the path was recorded\nand whose history does not tell it",
+                    "type": "integer"
+                },
+                "unknown_btc": {
+                    "type": "string"
+                }
+            }
+        },
         "v2service.CovenantSignature": {
             "type": "object",
             "properties": {
@@ -1219,6 +5560,9 @@ const docTemplate = `{
                 "staking_amount": {
                     "type": "integer"
                 },
+                "staking_amount_btc": {
+                    "type": "string"
+                },
                 "staking_timelock": {
                     "type": "integer"
                 },
@@ -1253,6 +5597,32 @@ const docTemplate = `{
                 }
             }
         },
+        "v2service.EventGapPublic": {
+            "type": "object",
+            "properties": {
+                "detected_at": {
+                    "type": "integer"
+                },
+                "from_height": {
+                    "type": "integer"
+                },
+                "from_sequence": {
+                    "type": "integer"
+                },
+                "previous_event_at": {
+                    "type": "integer"
+                },
+                "queue_name": {
+                    "type": "string"
+                },
+                "to_height": {
+                    "type": "integer"
+                },
+                "to_sequence": {
+                    "type": "integer"
+                }
+            }
+        },
         "v2service.FinalityProviderStatsPublic": {
             "type": "object",
             "properties": {
@@ -1273,6 +5643,12 @@ const docTemplate = `{
                 },
                 "state": {
                     "$ref": "#/definitions/types.FinalityProviderQueryingState"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
                 }
             }
         },
@@ -1302,6 +5678,9 @@ const docTemplate = `{
                 "active_tvl": {
                     "type": "integer"
                 },
+                "active_tvl_btc": {
+                    "type": "string"
+                },
                 "total_finality_providers": {
                     "type": "integer"
                 }
@@ -1333,6 +5712,9 @@ const docTemplate = `{
                 "active_tvl": {
                     "type": "integer"
                 },
+                "active_tvl_btc": {
+                    "type": "string"
+                },
                 "staker_pk_hex": {
                     "type": "string"
                 },
@@ -1342,11 +5724,17 @@ const docTemplate = `{
                 "unbonding_tvl": {
                     "type": "integer"
                 },
+                "unbonding_tvl_btc": {
+                    "type": "string"
+                },
                 "withdrawable_delegations": {
                     "type": "integer"
                 },
                 "withdrawable_tvl": {
                     "type": "integer"
+                },
+                "withdrawable_tvl_btc": {
+                    "type": "string"
                 }
             }
         },
@@ -1369,6 +5757,20 @@ const docTemplate = `{
                 }
             }
         },
+        "v2service.StatsTimeSeriesPointPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
         "v2service.UnbondingSlashing": {
             "type": "object",
             "properties": {
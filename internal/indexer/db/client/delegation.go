@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"go.mongodb.org/mongo-driver/bson"
@@ -30,8 +31,10 @@ func (indexerdbclient *IndexerDatabase) GetDelegation(ctx context.Context, staki
 	return &delegation, nil
 }
 
+// GetDelegations returns the staker delegations, restricted to the ones made
+// under the given params version if it is not nil
 func (indexerdbclient *IndexerDatabase) GetDelegations(
-	ctx context.Context, stakerPKHex string, paginationToken string,
+	ctx context.Context, stakerPKHex string, paramsVersion *uint32, paginationToken string,
 ) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error) {
 	client := indexerdbclient.Client.Database(indexerdbclient.DbName).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
 
@@ -68,30 +71,59 @@ func (indexerdbclient *IndexerDatabase) GetDelegations(
 		}
 	}
 
+	if paramsVersion != nil {
+		filter["params_version"] = *paramsVersion
+	}
+
 	return db.FindWithPagination(
 		ctx, client, filter, options, indexerdbclient.Cfg.MaxPaginationLimit,
 		indexerdbmodel.BuildDelegationPaginationToken,
 	)
 }
 
-// CheckDelegationExistByStakerPk checks if a staker has any
-// delegation in the specified states by the staker's public key
-func (indexerdbclient *IndexerDatabase) CheckDelegationExistByStakerPk(
+// CountDelegationsByStakerPk counts the delegations of a staker in the
+// specified states by the staker's public key
+func (indexerdbclient *IndexerDatabase) CountDelegationsByStakerPk(
 	ctx context.Context, stakerPk string, extraFilter *DelegationFilter,
-) (bool, error) {
+) (int64, error) {
 	client := indexerdbclient.Client.Database(indexerdbclient.DbName).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
 	filter := buildAdditionalDelegationFilter(
 		bson.M{"staker_btc_pk_hex": stakerPk}, extraFilter,
 	)
-	var delegation indexerdbmodel.IndexerDelegationDetails
-	err := client.FindOne(ctx, filter).Decode(&delegation)
+	return client.CountDocuments(ctx, filter)
+}
+
+// CountDelegationsByState returns the number of delegations in every state
+func (indexerdbclient *IndexerDatabase) CountDelegationsByState(
+	ctx context.Context,
+) (map[indexertypes.DelegationState]int64, error) {
+	client := indexerdbclient.Client.Database(indexerdbclient.DbName).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$state",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return false, nil
-		}
-		return false, err
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		State indexertypes.DelegationState `bson:"_id"`
+		Count int64                        `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[indexertypes.DelegationState]int64, len(results))
+	for _, result := range results {
+		counts[result.State] = result.Count
 	}
-	return true, nil
+	return counts, nil
 }
 
 func buildAdditionalDelegationFilter(
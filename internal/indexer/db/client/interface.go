@@ -19,12 +19,15 @@ type IndexerDBClient interface {
 	GetFinalityProviderByPk(ctx context.Context, fpPk string) (*indexerdbmodel.IndexerFinalityProviderDetails, error)
 	// Staker Delegations
 	GetDelegation(ctx context.Context, stakingTxHashHex string) (*indexerdbmodel.IndexerDelegationDetails, error)
-	GetDelegations(ctx context.Context, stakerPKHex string, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error)
+	GetDelegations(
+		ctx context.Context, stakerPKHex string, paramsVersion *uint32, paginationToken string,
+	) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error)
+	CountDelegationsByState(ctx context.Context) (map[indexertypes.DelegationState]int64, error)
 	// GetLastProcessedBbnHeight retrieves the last processed BBN height.
 	GetLastProcessedBbnHeight(ctx context.Context) (lastProcessedHeight uint64, err error)
-	CheckDelegationExistByStakerPk(
-		ctx context.Context, address string, extraFilter *DelegationFilter,
-	) (bool, error)
+	CountDelegationsByStakerPk(
+		ctx context.Context, stakerPk string, extraFilter *DelegationFilter,
+	) (int64, error)
 }
 
 type DelegationFilter struct {
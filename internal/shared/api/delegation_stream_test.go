@@ -0,0 +1,214 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/events"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1handlers "github.com/babylonlabs-io/staking-api-service/internal/v1/api/handlers"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	v2queuehandler "github.com/babylonlabs-io/staking-api-service/internal/v2/queue/handler"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamStakerDelegations(t *testing.T) {
+	ctx := context.Background()
+	const timeout = 50 * time.Millisecond
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationByTxHashHex", mock.Anything, testStakingTxHash).
+		Return(&v1dbmodel.DelegationDocument{
+			StakingTxHashHex: testStakingTxHash,
+			StakerPkHex:      stakerPkHex,
+			State:            types.UnbondingRequested,
+		}, nil).Once()
+	v1DB.On(
+		"TransitionToUnbondingState", mock.Anything, testStakingTxHash,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return(nil).Once()
+
+	v2DB := mocks.NewV2DBClient(t)
+	v2DB.On("WithTransaction", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+	).Once()
+	dbClients := &dbclients.DbClients{V1DBClient: v1DB, V2DBClient: v2DB}
+
+	cfg := &config.Config{RequestTimeout: &config.RequestTimeoutConfig{DefaultGetTimeout: timeout}}
+	v1Service, err := v1service.New(ctx, cfg, nil, nil, nil, dbClients, events.NewBus(1))
+	require.NoError(t, err)
+	h := &v1handlers.V1Handler{Service: v1Service}
+	r := chi.NewRouter()
+	r.Use(middlewares.RequestTimeoutMiddleware(cfg, r, delegationStreamRoute))
+	r.Get(delegationStreamRoute, registerHandler(h.StreamStakerDelegations))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(
+		streamCtx, http.MethodGet, server.URL+delegationStreamRoute+"?staker_btc_pk="+stakerPkHex, nil,
+	)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readFrame := func() []string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				return lines
+			}
+			lines = append(lines, line)
+		}
+	}
+	// the subscription is open once the first comment is received
+	assert.Equal(t, []string{": connected"}, readFrame())
+
+	// the stream outlives the request timeout of the other routes
+	time.Sleep(2 * timeout)
+	queueHandler := v2queuehandler.NewV2QueueHandler(&services.Services{
+		V1Service: v1Service,
+		V2Service: &v2service.V2Service{DbClients: dbClients},
+	})
+	messageBody, err := json.Marshal(map[string]any{
+		"staking_tx_hash_hex":    testStakingTxHash,
+		"staker_btc_pk_hex":      stakerPkHex,
+		"unbonding_tx_hex":       "00",
+		"unbonding_start_height": 100,
+		"unbonding_timelock":     10,
+	})
+	require.NoError(t, err)
+	require.Nil(t, queueHandler.UnbondingStakingHandler(ctx, string(messageBody)))
+
+	frame := readFrame()
+	require.Len(t, frame, 2)
+	assert.Equal(t, "event: delegation_state_change", frame[0])
+	require.True(t, strings.HasPrefix(frame[1], "data: "))
+	var change events.DelegationStateChange
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(frame[1], "data: ")), &change))
+	assert.Equal(t, testStakingTxHash, change.StakingTxHashHex)
+	assert.Equal(t, stakerPkHex, change.StakerPkHex)
+	assert.Equal(t, types.Unbonding, change.State)
+	assert.NotZero(t, change.Timestamp)
+}
+
+func TestStreamStakerEvents(t *testing.T) {
+	ctx := context.Background()
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+
+	v1Service, err := v1service.New(ctx, &config.Config{}, nil, nil, nil, &dbclients.DbClients{}, events.NewBus(8))
+	require.NoError(t, err)
+	h := &v1handlers.V1Handler{Service: v1Service}
+	r := chi.NewRouter()
+	r.Get(stakerEventStreamRoute, registerHandler(h.StreamStakerEvents))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(
+		streamCtx, http.MethodGet, server.URL+stakerEventStreamRoute+"?staker_btc_pk="+stakerPkHex, nil,
+	)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	frames := make(chan []string)
+	go func() {
+		defer close(frames)
+		reader := bufio.NewReader(resp.Body)
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSuffix(line, "\n")
+			if line != "" {
+				lines = append(lines, line)
+				continue
+			}
+			select {
+			case frames <- lines:
+			case <-streamCtx.Done():
+				return
+			}
+			lines = nil
+		}
+	}()
+	nextFrame := func() []string {
+		select {
+		case frame, ok := <-frames:
+			require.True(t, ok, "stream closed")
+			return frame
+		case <-time.After(2 * time.Second):
+			require.FailNow(t, "no event received within 2 seconds")
+			return nil
+		}
+	}
+	// the subscription is open once the first comment is received
+	assert.Equal(t, []string{": connected"}, nextFrame())
+
+	lifecycle := []struct {
+		state     types.DelegationState
+		eventType string
+	}{
+		{types.Active, "DELEGATION_ACTIVE"},
+		{types.UnbondingRequested, "UNBONDING_REQUESTED"},
+		{types.Unbonding, "UNBONDING_STARTED"},
+		{types.Withdrawable, "DELEGATION_WITHDRAWABLE"},
+		{types.Withdrawn, "DELEGATION_WITHDRAWN"},
+	}
+	for _, step := range lifecycle {
+		v1Service.PublishDelegationStateChange(testStakingTxHash, stakerPkHex, step.state)
+	}
+	for _, step := range lifecycle {
+		frame := nextFrame()
+		require.Len(t, frame, 1, step.eventType)
+		require.True(t, strings.HasPrefix(frame[0], "data: "))
+		var event struct {
+			Type string `json:"type"`
+			events.DelegationStateChange
+		}
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(frame[0], "data: ")), &event))
+		assert.Equal(t, step.eventType, event.Type)
+		assert.Equal(t, step.state, event.State)
+		assert.Equal(t, testStakingTxHash, event.StakingTxHashHex)
+		assert.Equal(t, stakerPkHex, event.StakerPkHex)
+	}
+}
@@ -3,8 +3,11 @@ package handler
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"regexp"
+	"slices"
+	"strings"
 
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
@@ -14,13 +17,33 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 )
 
+// QueueConsumers are the consumers of the event queues
+type QueueConsumers interface {
+	// IsConnectionHealthy reports whether the queue connections are open
+	IsConnectionHealthy() error
+	// ThrottleFactor returns the factor the consumers are throttled with, and
+	// whether it is manually overridden
+	ThrottleFactor() (float64, bool)
+	// OverrideThrottleFactor throttles the consumers with the given factor,
+	// a nil factor restores the throttling based on the processing health
+	OverrideThrottleFactor(factor *float64) error
+	// DryRunQueues returns whether the messages of each queue are processed
+	// in dry-run mode, keyed by queue name
+	DryRunQueues() map[string]bool
+	// SetDryRun switches the given queue in or out of the dry-run mode
+	SetDryRun(queueName string, enabled bool) error
+}
+
 type Handler struct {
 	Config  *config.Config
 	Service service.SharedServiceProvider
+	Queues  QueueConsumers
 }
 
-func New(ctx context.Context, config *config.Config, service service.SharedServiceProvider) (*Handler, error) {
-	return &Handler{Config: config, Service: service}, nil
+func New(
+	ctx context.Context, config *config.Config, service service.SharedServiceProvider, queues QueueConsumers,
+) (*Handler, error) {
+	return &Handler{Config: config, Service: service, Queues: queues}, nil
 }
 
 type ResultOptions struct {
@@ -36,9 +59,34 @@ type PublicResponse[T any] struct {
 	Pagination *paginationResponse `json:"pagination,omitempty"`
 }
 
+// ErrorResponse is the body of every failed request
+type ErrorResponse struct {
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+func (e *ErrorResponse) Error() string {
+	return e.Message
+}
+
 type Result struct {
 	Data   interface{}
 	Status int
+	// Location is set for redirect results
+	Location string
+	// Raw is written as is with the ContentType instead of the JSON encoded Data
+	Raw         []byte
+	ContentType string
+	// Stream writes the body with the ContentType incrementally, instead of
+	// the JSON encoded Data. If Filename is set, the body is sent as an
+	// attachment with that name.
+	Stream   func(w io.Writer) *types.Error
+	Filename string
+	// EventStream sends every write of the Stream to the client as soon as
+	// it is made, the stream is held open until the client disconnects
+	EventStream bool
+	// ETag is set as the entity tag header of the response if not empty
+	ETag string
 }
 
 // NewResult returns a successful result, with default status code 200
@@ -52,6 +100,30 @@ func NewResult[T any](data T) *Result {
 	return &Result{Data: res, Status: http.StatusOK}
 }
 
+// NewRedirectResult returns a result redirecting the client to the location
+func NewRedirectResult(location string) *Result {
+	return &Result{Location: location, Status: http.StatusFound}
+}
+
+// NewRawResult returns a successful result whose body is not JSON encoded
+func NewRawResult(contentType string, body []byte) *Result {
+	return &Result{Raw: body, ContentType: contentType, Status: http.StatusOK}
+}
+
+// NewStreamResult returns a successful result whose body is written by
+// stream as an attachment named filename
+func NewStreamResult(contentType, filename string, stream func(w io.Writer) *types.Error) *Result {
+	return &Result{Stream: stream, ContentType: contentType, Filename: filename, Status: http.StatusOK}
+}
+
+// NewEventStreamResult returns a successful result whose body is a stream of
+// server-sent events written by stream
+func NewEventStreamResult(stream func(w io.Writer) *types.Error) *Result {
+	return &Result{
+		Stream: stream, ContentType: "text/event-stream", EventStream: true, Status: http.StatusOK,
+	}
+}
+
 func ParsePaginationQuery(r *http.Request) (string, *types.Error) {
 	pageKey := r.URL.Query().Get("pagination_key")
 	if pageKey == "" {
@@ -75,11 +147,8 @@ func ParsePublicKeyQuery(r *http.Request, queryName string, isOptional bool) (st
 			http.StatusBadRequest, types.BadRequest, queryName+" is required",
 		)
 	}
-	_, err := utils.GetSchnorrPkFromHex(pkHex)
-	if err != nil {
-		return "", types.NewErrorWithMsg(
-			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
-		)
+	if err := ValidatePublicKeyField(queryName, pkHex); err != nil {
+		return "", err
 	}
 	return pkHex, nil
 }
@@ -91,10 +160,8 @@ func ParseTxHashQuery(r *http.Request, queryName string) (string, *types.Error)
 			http.StatusBadRequest, types.BadRequest, queryName+" is required",
 		)
 	}
-	if !utils.IsValidTxHash(txHashHex) {
-		return "", types.NewErrorWithMsg(
-			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
-		)
+	if err := ValidateTxHashField(queryName, txHashHex); err != nil {
+		return "", err
 	}
 	return txHashHex, nil
 }
@@ -151,8 +218,9 @@ func ParseBtcAddressesQuery(
 	return addresses, nil
 }
 
-// ParseStateFilterQuery parses the state filter query and returns the state enum
-// If the state is not provided, it returns an empty string
+// ParseStateFilterQuery parses the state filter query and returns the state enums
+// The states can be repeated or comma separated, e.g. `state=active,unbonding`
+// If the state is not provided, it returns nil
 func ParseStateFilterQuery(
 	r *http.Request, queryName string,
 ) ([]types.DelegationState, *types.Error) {
@@ -162,14 +230,23 @@ func ParseStateFilterQuery(
 	}
 
 	var stateEnums []types.DelegationState
-	for _, state := range states {
-		stateEnum, err := types.FromStringToDelegationState(state)
-		if err != nil {
-			return nil, types.NewErrorWithMsg(
-				http.StatusBadRequest, types.BadRequest, err.Error(),
-			)
+	for _, commaSeparatedStates := range states {
+		for _, state := range strings.Split(commaSeparatedStates, ",") {
+			stateEnum, err := types.FromStringToDelegationState(strings.TrimSpace(state))
+			if err != nil {
+				validStates := make([]string, 0, len(types.SupportedDelegationStates()))
+				for _, validState := range types.SupportedDelegationStates() {
+					validStates = append(validStates, validState.ToString())
+				}
+				return nil, types.NewErrorWithMsg(
+					http.StatusBadRequest, types.BadRequest,
+					fmt.Sprintf("%s, valid values are: %s", err.Error(), strings.Join(validStates, ", ")),
+				)
+			}
+			if !slices.Contains(stateEnums, stateEnum) {
+				stateEnums = append(stateEnums, stateEnum)
+			}
 		}
-		stateEnums = append(stateEnums, stateEnum)
 	}
 	return stateEnums, nil
 }
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStateFilterQuery(t *testing.T) {
+	t.Run("comma separated and repeated states", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?state=active,unbonding_requested&state=active&state=unbonded", nil)
+		states, err := ParseStateFilterQuery(r, "state")
+		require.Nil(t, err)
+		assert.Equal(t, []types.DelegationState{types.Active, types.UnbondingRequested, types.Unbonded}, states)
+	})
+	t.Run("no state", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		states, err := ParseStateFilterQuery(r, "state")
+		require.Nil(t, err)
+		assert.Nil(t, states)
+	})
+	t.Run("unknown state", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?state=active,foo", nil)
+		_, err := ParseStateFilterQuery(r, "state")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Contains(t, err.Err.Error(), "valid values are: active, unbonding_requested")
+	})
+}
+
+func TestParsePaginationQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?pagination_key=eyJrIjoxfQ==", nil)
+	key, err := ParsePaginationQuery(r)
+	require.Nil(t, err)
+	assert.Equal(t, "eyJrIjoxfQ==", key)
+
+	// the shared format check is a plain bad request, only the signed token
+	// check of the staker delegations reports an invalid pagination token
+	r = httptest.NewRequest(http.MethodGet, "/?pagination_key=not-base64!", nil)
+	_, err = ParsePaginationQuery(r)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, types.BadRequest, err.ErrorCode)
+}
@@ -1,23 +1,69 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
 )
 
+const (
+	// QueueDependency names the queue connections in the readiness check
+	QueueDependency = "queue"
+	// readinessTimeout bounds the readiness check so that it answers even if
+	// a database is unreachable
+	readinessTimeout = 2 * time.Second
+)
+
+type ReadinessPublic struct {
+	Ready              bool     `json:"ready"`
+	FailedDependencies []string `json:"failed_dependencies"`
+}
+
 // HealthCheck godoc
-// @Summary Health check endpoint
-// @Description Health check the service, including ping database connection
+// @Summary Liveness check endpoint
+// @Description Liveness check of the service, always succeeds if the server is serving requests
 // @Produce json
 // @Tags shared
 // @Success 200 {string} handler.PublicResponse[string] "Server is up and running"
 // @Router /healthcheck [get]
 func (h *Handler) HealthCheck(request *http.Request) (*Result, *types.Error) {
-	err := h.Service.DoHealthCheck(request.Context())
-	if err != nil {
-		return nil, types.NewInternalServiceError(err)
+	return NewResult("Server is up and running"), nil
+}
+
+// ReadinessCheck godoc
+// @Summary Readiness check endpoint
+// @Description Readiness check of the service, pings the databases and checks the queue connections are open.
+// @Description The check answers within 2 seconds even if a dependency is unreachable.
+// @Produce json
+// @Tags shared
+// @Success 200 {object} handler.PublicResponse[ReadinessPublic] "All the dependencies are healthy"
+// @Failure 503 {object} handler.PublicResponse[ReadinessPublic] "The dependencies failing the check"
+// @Router /ready [get]
+func (h *Handler) ReadinessCheck(request *http.Request) (*Result, *types.Error) {
+	ctx, cancel := context.WithTimeout(request.Context(), readinessTimeout)
+	defer cancel()
+
+	failures := h.Service.PingDatabases(ctx)
+	if h.Queues != nil {
+		if err := h.Queues.IsConnectionHealthy(); err != nil {
+			failures[QueueDependency] = err
+		}
 	}
 
-	return NewResult("Server is up and running"), nil
+	readiness := &ReadinessPublic{Ready: len(failures) == 0, FailedDependencies: []string{}}
+	for dependency, err := range failures {
+		log.Ctx(ctx).Warn().Err(err).Str("dependency", dependency).Msg("readiness check failed")
+		readiness.FailedDependencies = append(readiness.FailedDependencies, dependency)
+	}
+	sort.Strings(readiness.FailedDependencies)
+
+	result := NewResult(readiness)
+	if !readiness.Ready {
+		result.Status = http.StatusServiceUnavailable
+	}
+	return result, nil
 }
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeQueues struct {
+	err error
+}
+
+func (f *fakeQueues) IsConnectionHealthy() error {
+	return f.err
+}
+
+func (f *fakeQueues) ThrottleFactor() (float64, bool) {
+	return 1, false
+}
+
+func (f *fakeQueues) OverrideThrottleFactor(*float64) error {
+	return nil
+}
+
+func (f *fakeQueues) DryRunQueues() map[string]bool {
+	return nil
+}
+
+func (f *fakeQueues) SetDryRun(string, bool) error {
+	return nil
+}
+
+func TestReadinessCheck(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+
+	// the staking db client points at a closed connection
+	closedClient, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://localhost:27017"))
+	require.NoError(t, err)
+	require.NoError(t, closedClient.Disconnect(ctx))
+	closedDB, err := dbclient.New(ctx, closedClient, &config.DbConfig{DbName: "staking-api-service"})
+	require.NoError(t, err)
+
+	check := func(t *testing.T, stakingDB dbclient.DBClient, indexerPing any, queueErr error) (*ReadinessPublic, int) {
+		indexerDB := mocks.NewIndexerDBClient(t)
+		indexerDB.On("Ping", mock.Anything).Return(indexerPing)
+		h := &Handler{
+			Service: &service.Service{DbClients: &dbclients.DbClients{
+				SharedDBClient:  stakingDB,
+				IndexerDBClient: indexerDB,
+			}},
+			Queues: &fakeQueues{err: queueErr},
+		}
+
+		result, rpcErr := h.ReadinessCheck(httptest.NewRequest(http.MethodGet, "/ready", nil))
+		require.Nil(t, rpcErr)
+		return result.Data.(*PublicResponse[*ReadinessPublic]).Data, result.Status
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		stakingDB := mocks.NewDBClient(t)
+		stakingDB.On("Ping", mock.Anything).Return(nil)
+
+		readiness, status := check(t, stakingDB, nil, nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, readiness.Ready)
+		assert.Empty(t, readiness.FailedDependencies)
+	})
+
+	t.Run("closed database connection", func(t *testing.T) {
+		readiness, status := check(t, closedDB, nil, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.False(t, readiness.Ready)
+		assert.Equal(t, []string{service.StakingDbDependency}, readiness.FailedDependencies)
+	})
+
+	t.Run("closed queue connection", func(t *testing.T) {
+		readiness, status := check(t, closedDB, nil, errors.New("rabbitMQ connection is closed"))
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, []string{QueueDependency, service.StakingDbDependency}, readiness.FailedDependencies)
+	})
+
+	t.Run("unreachable database", func(t *testing.T) {
+		stakingDB := mocks.NewDBClient(t)
+		stakingDB.On("Ping", mock.Anything).Return(nil)
+		// the indexer db never answers, the check gives up at its timeout
+		hangingPing := func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		start := time.Now()
+		readiness, status := check(t, stakingDB, hangingPing, nil)
+		assert.Less(t, time.Since(start), readinessTimeout+time.Second)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, []string{service.IndexerDbDependency}, readiness.FailedDependencies)
+	})
+}
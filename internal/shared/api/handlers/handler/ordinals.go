@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
@@ -16,8 +15,8 @@ type VerifyUTXOsRequestPayload struct {
 
 func parseRequestPayload(request *http.Request, maxUTXOs uint32, netParam *chaincfg.Params) (*VerifyUTXOsRequestPayload, *types.Error) {
 	var payload VerifyUTXOsRequestPayload
-	if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
-		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid input format")
+	if err := DecodeJSONPayload(request, &payload); err != nil {
+		return nil, err
 	}
 	utxos := payload.UTXOs
 	if len(utxos) == 0 {
@@ -29,8 +28,8 @@ func parseRequestPayload(request *http.Request, maxUTXOs uint32, netParam *chain
 	}
 
 	for _, utxo := range utxos {
-		if !utils.IsValidTxHash(utxo.Txid) {
-			return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid UTXO txid")
+		if err := ValidateTxHashField("txid", utxo.Txid); err != nil {
+			return nil, err
 		} else if utxo.Vout < 0 {
 			return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid UTXO vout")
 		}
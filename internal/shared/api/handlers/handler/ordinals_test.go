@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequestPayloadUTXOsCap(t *testing.T) {
+	newRequest := func(utxoCount int) *http.Request {
+		payload := VerifyUTXOsRequestPayload{Address: "address"}
+		for i := 0; i < utxoCount; i++ {
+			payload.UTXOs = append(payload.UTXOs, types.UTXOIdentifier{Txid: strings.Repeat("a", 64), Vout: uint32(i)})
+		}
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return httptest.NewRequest(http.MethodPost, "/v1/ordinals/verify-utxos", bytes.NewReader(body))
+	}
+
+	_, err := parseRequestPayload(newRequest(51), 50, &chaincfg.MainNetParams)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, "too many UTXOs in the request", err.Err.Error())
+
+	_, err = parseRequestPayload(newRequest(0), 50, &chaincfg.MainNetParams)
+	require.NotNil(t, err)
+	assert.Equal(t, "empty UTXO array", err.Err.Error())
+
+	// Within the cap, the request is only rejected for its invalid address
+	_, err = parseRequestPayload(newRequest(50), 50, &chaincfg.MainNetParams)
+	require.NotNil(t, err)
+	assert.NotEqual(t, "too many UTXOs in the request", err.Err.Error())
+}
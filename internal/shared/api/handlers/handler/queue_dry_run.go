@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+type QueueModePublic struct {
+	Queue  string `json:"queue"`
+	DryRun bool   `json:"dry_run"`
+}
+
+type QueueDryRunPayload struct {
+	Queue  string `json:"queue"`
+	DryRun bool   `json:"dry_run"`
+}
+
+// GetQueueDryRun godoc
+// @Summary Get the queue handler modes
+// @Description Internal endpoint returning whether the messages of each queue are processed in dry-run mode,
+// @Description where each message is run through a dry-run handler recording the writes it would have performed,
+// @Description instead of being applied by the live handler.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags shared
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {object} handler.PublicResponse[[]QueueModePublic] "Queue handler modes"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/internal/consumers/dry-run [get]
+func (h *Handler) GetQueueDryRun(request *http.Request) (*Result, *types.Error) {
+	if h.Queues == nil {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "queue consumers are not running")
+	}
+	return NewResult(queueModes(h.Queues.DryRunQueues())), nil
+}
+
+// SetQueueDryRun godoc
+// @Summary Switch a queue handler mode
+// @Description Internal endpoint switching the given queue in or out of the dry-run mode.
+// @Description The change applies from the next received message onwards.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Accept json
+// @Produce json
+// @Tags shared
+// @Param Authorization header string true "Bearer admin token"
+// @Param payload body QueueDryRunPayload true "Queue handler mode"
+// @Success 200 {object} handler.PublicResponse[[]QueueModePublic] "Queue handler modes"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/internal/consumers/dry-run [post]
+func (h *Handler) SetQueueDryRun(request *http.Request) (*Result, *types.Error) {
+	if h.Queues == nil {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "queue consumers are not running")
+	}
+	var payload QueueDryRunPayload
+	if err := DecodeJSONPayload(request, &payload); err != nil {
+		return nil, err
+	}
+	if err := h.Queues.SetDryRun(payload.Queue, payload.DryRun); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, err.Error())
+	}
+
+	return NewResult(queueModes(h.Queues.DryRunQueues())), nil
+}
+
+// queueModes lists the modes of the queues sorted by queue name
+func queueModes(dryRunQueues map[string]bool) []QueueModePublic {
+	modes := make([]QueueModePublic, 0, len(dryRunQueues))
+	for queueName, dryRun := range dryRunQueues {
+		modes = append(modes, QueueModePublic{Queue: queueName, DryRun: dryRun})
+	}
+	sort.Slice(modes, func(i, j int) bool {
+		return modes[i].Queue < modes[j].Queue
+	})
+	return modes
+}
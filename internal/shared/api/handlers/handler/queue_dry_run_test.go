@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDryRunQueues struct {
+	fakeQueues
+	modes map[string]bool
+}
+
+func (f *fakeDryRunQueues) DryRunQueues() map[string]bool {
+	return f.modes
+}
+
+func (f *fakeDryRunQueues) SetDryRun(queueName string, enabled bool) error {
+	if _, ok := f.modes[queueName]; !ok {
+		return fmt.Errorf("unknown queue: %s", queueName)
+	}
+	f.modes[queueName] = enabled
+	return nil
+}
+
+func TestSetQueueDryRun(t *testing.T) {
+	h := &Handler{Queues: &fakeDryRunQueues{modes: map[string]bool{"withdrawn": false, "active": false}}}
+	set := func(body string) (*Result, int) {
+		result, err := h.SetQueueDryRun(
+			httptest.NewRequest(http.MethodPost, "/v1/internal/consumers/dry-run", strings.NewReader(body)),
+		)
+		if err != nil {
+			return nil, err.StatusCode
+		}
+		return result, http.StatusOK
+	}
+
+	result, status := set(`{"queue": "withdrawn", "dry_run": true}`)
+	require.Equal(t, http.StatusOK, status)
+	expected := []QueueModePublic{{Queue: "active"}, {Queue: "withdrawn", DryRun: true}}
+	assert.Equal(t, expected, result.Data.(*PublicResponse[[]QueueModePublic]).Data)
+
+	result, err := h.GetQueueDryRun(httptest.NewRequest(http.MethodGet, "/v1/internal/consumers/dry-run", nil))
+	require.Nil(t, err)
+	assert.Equal(t, expected, result.Data.(*PublicResponse[[]QueueModePublic]).Data)
+
+	_, status = set(`{"queue": "unknown", "dry_run": true}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	_, status = set(`{"queue": "active", "dry_run": "yes"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	result, status = set(`{"queue": "withdrawn", "dry_run": false}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []QueueModePublic{{Queue: "active"}, {Queue: "withdrawn"}}, result.Data.(*PublicResponse[[]QueueModePublic]).Data)
+}
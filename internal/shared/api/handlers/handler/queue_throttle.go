@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+type QueueThrottlePublic struct {
+	// Factor is the share of the full speed the queue messages are consumed
+	// at, 1 when not throttled
+	Factor     float64 `json:"factor"`
+	Overridden bool    `json:"overridden"`
+}
+
+type QueueThrottleOverridePayload struct {
+	// Factor is the throttle factor within [0, 1], null clears the override
+	Factor *float64 `json:"factor"`
+}
+
+// GetQueueThrottle godoc
+// @Summary Get the queue consumers throttle
+// @Description Internal endpoint returning the factor the queue consumers are throttled with while the database
+// @Description is degraded, and whether it is manually overridden.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags shared
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {object} handler.PublicResponse[QueueThrottlePublic] "Queue consumers throttle"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/internal/consumers/throttle [get]
+func (h *Handler) GetQueueThrottle(request *http.Request) (*Result, *types.Error) {
+	if h.Queues == nil {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "queue consumers are not running")
+	}
+	factor, overridden := h.Queues.ThrottleFactor()
+	return NewResult(QueueThrottlePublic{Factor: factor, Overridden: overridden}), nil
+}
+
+// OverrideQueueThrottle godoc
+// @Summary Override the queue consumers throttle
+// @Description Internal endpoint throttling the queue consumers with the given factor regardless of the database
+// @Description health, 0 waits the longest between two messages and 1 consumes at full speed. A null factor
+// @Description clears the override.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Accept json
+// @Produce json
+// @Tags shared
+// @Param Authorization header string true "Bearer admin token"
+// @Param payload body QueueThrottleOverridePayload true "Throttle factor"
+// @Success 200 {object} handler.PublicResponse[QueueThrottlePublic] "Queue consumers throttle"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/internal/consumers/throttle [post]
+func (h *Handler) OverrideQueueThrottle(request *http.Request) (*Result, *types.Error) {
+	if h.Queues == nil {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "queue consumers are not running")
+	}
+	var payload QueueThrottleOverridePayload
+	if err := DecodeJSONPayload(request, &payload); err != nil {
+		return nil, err
+	}
+	if err := h.Queues.OverrideThrottleFactor(payload.Factor); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, err.Error())
+	}
+
+	factor, overridden := h.Queues.ThrottleFactor()
+	return NewResult(QueueThrottlePublic{Factor: factor, Overridden: overridden}), nil
+}
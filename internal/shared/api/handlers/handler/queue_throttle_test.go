@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeThrottledQueues struct {
+	fakeQueues
+	override *float64
+}
+
+func (f *fakeThrottledQueues) ThrottleFactor() (float64, bool) {
+	if f.override != nil {
+		return *f.override, true
+	}
+	return 1, false
+}
+
+func (f *fakeThrottledQueues) OverrideThrottleFactor(factor *float64) error {
+	if factor != nil && *factor > 1 {
+		return errors.New("throttle factor must be within [0, 1]")
+	}
+	f.override = factor
+	return nil
+}
+
+func TestOverrideQueueThrottle(t *testing.T) {
+	h := &Handler{Queues: &fakeThrottledQueues{}}
+	override := func(body string) (*Result, int) {
+		result, err := h.OverrideQueueThrottle(
+			httptest.NewRequest(http.MethodPost, "/v1/internal/consumers/throttle", strings.NewReader(body)),
+		)
+		if err != nil {
+			return nil, err.StatusCode
+		}
+		return result, http.StatusOK
+	}
+
+	result, status := override(`{"factor": 0.25}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, QueueThrottlePublic{Factor: 0.25, Overridden: true}, result.Data.(*PublicResponse[QueueThrottlePublic]).Data)
+
+	result, err := h.GetQueueThrottle(httptest.NewRequest(http.MethodGet, "/v1/internal/consumers/throttle", nil))
+	require.Nil(t, err)
+	assert.Equal(t, QueueThrottlePublic{Factor: 0.25, Overridden: true}, result.Data.(*PublicResponse[QueueThrottlePublic]).Data)
+
+	_, status = override(`{"factor": 2}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	_, status = override(`{"factor": "slow"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	result, status = override(`{"factor": null}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, QueueThrottlePublic{Factor: 1}, result.Data.(*PublicResponse[QueueThrottlePublic]).Data)
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// DecodeJSONPayload decodes the JSON body of the request into the payload.
+// Unknown fields, values of the wrong type and data after the JSON value are
+// rejected with a message naming the offending field.
+func DecodeJSONPayload(request *http.Request, payload any) *types.Error {
+	decoder := json.NewDecoder(request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(payload); err != nil {
+		return types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, jsonDecodeErrorMessage(err))
+	}
+	if decoder.More() {
+		return types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid request payload: unexpected data after the JSON value",
+		)
+	}
+	return nil
+}
+
+func jsonDecodeErrorMessage(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return fmt.Sprintf("invalid request payload: expected %s, got %s", typeErr.Type, typeErr.Value)
+		}
+		return fmt.Sprintf("invalid type for field %s: expected %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	case errors.As(err, &maxBytesErr):
+		return "request payload is too large"
+	case errors.Is(err, io.EOF):
+		return "request payload is required"
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// the decoder has no typed error for the unknown fields
+		return "unknown field " + strings.TrimPrefix(err.Error(), "json: unknown field ")
+	default:
+		return "invalid request payload: malformed JSON"
+	}
+}
+
+// decodeHexField decodes a hex encoded field. The field is required and
+// must not have a 0x prefix.
+func decodeHexField(field, value string) ([]byte, *types.Error) {
+	if value == "" {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, field+" is required")
+	}
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, field+" must not have a 0x prefix",
+		)
+	}
+	bytes, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+field+": not a hex string",
+		)
+	}
+	return bytes, nil
+}
+
+func invalidHexFieldLength(field string, expected string, actual int) *types.Error {
+	return types.NewErrorWithMsg(
+		http.StatusBadRequest, types.BadRequest,
+		fmt.Sprintf("invalid %s: expected %s bytes, got %d", field, expected, actual),
+	)
+}
+
+// ValidateTxHashField checks the field holds a 32 bytes BTC transaction hash
+func ValidateTxHashField(field, value string) *types.Error {
+	bytes, err := decodeHexField(field, value)
+	if err != nil {
+		return err
+	}
+	if len(bytes) != chainhash.HashSize {
+		return invalidHexFieldLength(field, "32", len(bytes))
+	}
+	return nil
+}
+
+// ValidatePublicKeyField checks the field holds a BTC public key, either a
+// 32 bytes x-only or a 33 bytes compressed one
+func ValidatePublicKeyField(field, value string) *types.Error {
+	bytes, err := decodeHexField(field, value)
+	if err != nil {
+		return err
+	}
+	if len(bytes) != schnorr.PubKeyBytesLen && len(bytes) != btcec.PubKeyBytesLenCompressed {
+		return invalidHexFieldLength(field, "32 or 33", len(bytes))
+	}
+	if _, pkErr := utils.GetXOnlyOrCompressedPkFromHex(value); pkErr != nil {
+		return types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+field+": not a valid public key",
+		)
+	}
+	return nil
+}
+
+// ValidateSignatureField checks the field holds a 64 bytes schnorr signature
+func ValidateSignatureField(field, value string) *types.Error {
+	bytes, err := decodeHexField(field, value)
+	if err != nil {
+		return err
+	}
+	if len(bytes) != schnorr.SignatureSize {
+		return invalidHexFieldLength(field, "64", len(bytes))
+	}
+	if !utils.IsValidSignatureFormat(value) {
+		return types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+field+": not a valid signature",
+		)
+	}
+	return nil
+}
+
+// ValidateTxHexField checks the field holds a serialized BTC transaction
+func ValidateTxHexField(field, value string) *types.Error {
+	if _, err := decodeHexField(field, value); err != nil {
+		return err
+	}
+	if !utils.IsValidTxHex(value) {
+		return types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+field+": not a valid transaction",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPayload struct {
+	TxHashHex string `json:"tx_hash_hex"`
+	Amount    uint64 `json:"amount"`
+}
+
+func TestDecodeJSONPayload(t *testing.T) {
+	testCases := []struct {
+		name        string
+		body        string
+		expectedErr string
+	}{
+		{"valid", `{"tx_hash_hex": "aa", "amount": 1}`, ""},
+		{"trailing whitespace", "{\"amount\": 1}\n", ""},
+		{"empty body", ``, "request payload is required"},
+		{"malformed JSON", `{"amount": 1`, "invalid request payload: malformed JSON"},
+		{"unknown field", `{"amount": 1, "foo": "bar"}`, `unknown field "foo"`},
+		{"wrong field type", `{"amount": "1"}`, "invalid type for field amount: expected uint64, got string"},
+		{"negative number", `{"amount": -1}`, "invalid type for field amount: expected uint64, got number -1"},
+		{"wrong payload type", `["aa"]`, "invalid request payload: expected handler.testPayload, got array"},
+		{"trailing data", `{"amount": 1}{"amount": 2}`, "invalid request payload: unexpected data after the JSON value"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			err := DecodeJSONPayload(r, &testPayload{})
+			if tc.expectedErr == "" {
+				require.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			assert.Equal(t, tc.expectedErr, err.Err.Error())
+		})
+	}
+
+	t.Run("body over the size limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"tx_hash_hex": "`+strings.Repeat("a", 64)+`"}`))
+		r.Body = http.MaxBytesReader(w, r.Body, 16)
+		err := DecodeJSONPayload(r, &testPayload{})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "request payload is too large", err.Err.Error())
+	})
+}
+
+func TestValidateHexFields(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	compressedPkHex := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+	sig, err := schnorr.Sign(privKey, chainhash.HashB([]byte("msg")))
+	require.NoError(t, err)
+	sigHex := hex.EncodeToString(sig.Serialize())
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+	var buf bytes.Buffer
+	require.NoError(t, tx.Serialize(&buf))
+	txHex := hex.EncodeToString(buf.Bytes())
+	txHashHex := tx.TxHash().String()
+
+	testCases := []struct {
+		name        string
+		validate    func(field, value string) *types.Error
+		value       string
+		expectedErr string
+	}{
+		{"valid tx hash", ValidateTxHashField, txHashHex, ""},
+		{"empty tx hash", ValidateTxHashField, "", "field is required"},
+		{"0x prefixed tx hash", ValidateTxHashField, "0x" + txHashHex, "field must not have a 0x prefix"},
+		{"non-hex tx hash", ValidateTxHashField, strings.Repeat("z", 64), "invalid field: not a hex string"},
+		{"odd length tx hash", ValidateTxHashField, txHashHex[1:], "invalid field: not a hex string"},
+		{"short tx hash", ValidateTxHashField, txHashHex[2:], "invalid field: expected 32 bytes, got 31"},
+
+		{"valid x-only public key", ValidatePublicKeyField, pkHex, ""},
+		{"valid compressed public key", ValidatePublicKeyField, compressedPkHex, ""},
+		{"empty public key", ValidatePublicKeyField, "", "field is required"},
+		{"0X prefixed public key", ValidatePublicKeyField, "0X" + pkHex, "field must not have a 0x prefix"},
+		{"long public key", ValidatePublicKeyField, pkHex + pkHex, "invalid field: expected 32 or 33 bytes, got 64"},
+		{"not on the curve", ValidatePublicKeyField, strings.Repeat("ff", 32), "invalid field: not a valid public key"},
+
+		{"valid signature", ValidateSignatureField, sigHex, ""},
+		{"empty signature", ValidateSignatureField, "", "field is required"},
+		{"short signature", ValidateSignatureField, sigHex[:64], "invalid field: expected 64 bytes, got 32"},
+
+		{"valid tx hex", ValidateTxHexField, txHex, ""},
+		{"empty tx hex", ValidateTxHexField, "", "field is required"},
+		{"0x prefixed tx hex", ValidateTxHexField, "0x" + txHex, "field must not have a 0x prefix"},
+		{"truncated tx hex", ValidateTxHexField, txHex[:20], "invalid field: not a valid transaction"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.validate("field", tc.value)
+			if tc.expectedErr == "" {
+				require.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			assert.Equal(t, tc.expectedErr, err.Err.Error())
+		})
+	}
+}
+
+func TestParsePublicKeyQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?staker_btc_pk=0x"+strings.Repeat("aa", 32), nil)
+	_, err := ParsePublicKeyQuery(r, "staker_btc_pk", false)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, "staker_btc_pk must not have a 0x prefix", err.Err.Error())
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	pk, err := ParsePublicKeyQuery(r, "staker_btc_pk", true)
+	require.Nil(t, err)
+	assert.Empty(t, pk)
+}
@@ -16,8 +16,10 @@ type Handlers struct {
 	V2Handler     *v2handler.V2Handler
 }
 
-func New(ctx context.Context, config *config.Config, services *services.Services) (*Handlers, error) {
-	sharedHandler, err := handler.New(ctx, config, services.SharedService)
+func New(
+	ctx context.Context, config *config.Config, services *services.Services, queues handler.QueueConsumers,
+) (*Handlers, error) {
+	sharedHandler, err := handler.New(ctx, config, services.SharedService, queues)
 	if err != nil {
 		return nil, err
 	}
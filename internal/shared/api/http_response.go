@@ -2,7 +2,10 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"mime"
 	"net/http"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
@@ -10,62 +13,63 @@ import (
 	logger "github.com/rs/zerolog"
 )
 
-type ErrorResponse struct {
-	ErrorCode string `json:"errorCode"`
-	Message   string `json:"message"`
-}
-
-func newInternalServiceError() *ErrorResponse {
-	return &ErrorResponse{
+func newInternalServiceError() *handler.ErrorResponse {
+	return &handler.ErrorResponse{
 		ErrorCode: types.InternalServiceError.String(),
 		Message:   "Internal service error",
 	}
 }
 
-func (e *ErrorResponse) Error() string {
-	return e.Message
-}
-
 func registerHandler(handlerFunc func(*http.Request) (*handler.Result, *types.Error)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Set up metrics recording for the endpoint
-		timer := metrics.StartHttpRequestDurationTimer(r.URL.Path)
-
 		// Handle the actual business logic
 		result, err := handlerFunc(r)
 
 		if err != nil {
-			if http.StatusText(err.StatusCode) == "" {
-				logger.Ctx(r.Context()).Error().Err(err).Int("status_code", err.StatusCode).Msg("invalid status code")
-				err.StatusCode = http.StatusInternalServerError
-			}
-
-			errorResponse := &ErrorResponse{
-				ErrorCode: string(err.ErrorCode),
-				Message:   err.Err.Error(),
-			}
-			// Log the error
-			if err.StatusCode >= http.StatusInternalServerError {
-				logger.Ctx(r.Context()).Error().Err(errorResponse).Msg("request failed with 5xx error")
-				errorResponse.Message = "Internal service error" // Hide the internal message error from client
-			}
-			timer(err.StatusCode)
 			// terminate the request here
-			writeResponse(w, r, err.StatusCode, errorResponse)
+			writeErrorResponse(w, r, err)
 			return
 		}
 
 		if result == nil || http.StatusText(result.Status) == "" {
 			logger.Ctx(r.Context()).Error().Msg("invalid success response, error returned")
-			timer(http.StatusInternalServerError)
 			// terminate the request here
 			writeResponse(w, r, http.StatusInternalServerError, newInternalServiceError())
 			return
 		}
 
-		defer timer(result.Status)
-		writeResponse(w, r, result.Status, result.Data)
+		if result.ETag != "" {
+			w.Header().Set("ETag", result.ETag)
+		}
+		switch {
+		case result.Location != "":
+			http.Redirect(w, r, result.Location, result.Status)
+		case result.Raw != nil:
+			writeRawResponse(w, r, result.Status, result.ContentType, result.Raw)
+		case result.Stream != nil:
+			writeStreamResponse(w, r, result)
+		default:
+			writeResponse(w, r, result.Status, result.Data)
+		}
+	}
+}
+
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, err *types.Error) {
+	if http.StatusText(err.StatusCode) == "" {
+		logger.Ctx(r.Context()).Error().Err(err).Int("status_code", err.StatusCode).Msg("invalid status code")
+		err.StatusCode = http.StatusInternalServerError
 	}
+
+	errorResponse := &handler.ErrorResponse{
+		ErrorCode: string(err.ErrorCode),
+		Message:   err.Err.Error(),
+	}
+	// Log the error
+	if err.StatusCode >= http.StatusInternalServerError {
+		logger.Ctx(r.Context()).Error().Err(errorResponse).Msg("request failed with 5xx error")
+		errorResponse.Message = "Internal service error" // Hide the internal message error from client
+	}
+	writeResponse(w, r, err.StatusCode, errorResponse)
 }
 
 // Write and return response
@@ -85,3 +89,101 @@ func writeResponse(w http.ResponseWriter, r *http.Request, statusCode int, res i
 		metrics.RecordHttpResponseWriteFailure(statusCode)
 	}
 }
+
+// Write a response which is not JSON encoded, the content type is enforced so
+// that browsers do not sniff another one
+func writeRawResponse(w http.ResponseWriter, r *http.Request, statusCode int, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// Prevent scripts embedded in svg images from running when opened directly
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(body); err != nil {
+		logger.Ctx(r.Context()).Err(err).Msg("failed to write response")
+		metrics.RecordHttpResponseWriteFailure(statusCode)
+	}
+}
+
+// streamWriter sends the status code on the first write, so that a stream
+// failing before writing anything can still be answered with an error
+type streamWriter struct {
+	w       http.ResponseWriter
+	status  int
+	started bool
+	// flusher is set for the event streams, every write is flushed with it
+	flusher *http.ResponseController
+	// deadlines is set for the downloads, the write deadline is pushed back
+	// by writeTimeout before every batch of rows written to it
+	deadlines    *http.ResponseController
+	writeTimeout time.Duration
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if !s.started {
+		s.w.WriteHeader(s.status)
+		s.started = true
+	}
+	if s.deadlines != nil {
+		err := s.deadlines.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return 0, err
+		}
+	}
+	n, err := s.w.Write(p)
+	if err != nil || s.flusher == nil {
+		return n, err
+	}
+	return n, s.flusher.Flush()
+}
+
+// Write a response whose body is streamed by the result. Once the body is
+// started, a failure can only truncate it.
+func writeStreamResponse(w http.ResponseWriter, r *http.Request, result *handler.Result) {
+	w.Header().Set("Content-Type", result.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if result.Filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType(
+			"attachment", map[string]string{"filename": result.Filename},
+		))
+	}
+
+	writer := &streamWriter{w: w, status: result.Status}
+	// The read deadline of the server would cancel the request once expired,
+	// the stream outlives it as its request is already read
+	if err := http.NewResponseController(w).SetReadDeadline(time.Time{}); err != nil &&
+		!errors.Is(err, http.ErrNotSupported) {
+		logger.Ctx(r.Context()).Warn().Err(err).Msg("failed to clear the read deadline of the stream")
+	}
+	if server, ok := r.Context().Value(http.ServerContextKey).(*http.Server); ok &&
+		!result.EventStream && server.WriteTimeout > 0 {
+		// The write timeout of the server bounds every batch rather than the
+		// whole download
+		writer.deadlines = http.NewResponseController(w)
+		writer.writeTimeout = server.WriteTimeout
+	}
+	if result.EventStream {
+		w.Header().Set("Cache-Control", "no-cache")
+		// Stop the proxies from buffering the events
+		w.Header().Set("X-Accel-Buffering", "no")
+		writer.flusher = http.NewResponseController(w)
+		// The write timeout of the server would close the stream, the
+		// writer may not support deadlines when it is not the server's own
+		if err := writer.flusher.SetWriteDeadline(time.Time{}); err != nil &&
+			!errors.Is(err, http.ErrNotSupported) {
+			logger.Ctx(r.Context()).Warn().Err(err).Msg("failed to clear the write deadline of the event stream")
+		}
+	}
+	if err := result.Stream(writer); err != nil {
+		if !writer.started {
+			w.Header().Del("Content-Disposition")
+			writeErrorResponse(w, r, err)
+			return
+		}
+		logger.Ctx(r.Context()).Error().Err(err).Msg("failed to stream response")
+		metrics.RecordHttpResponseWriteFailure(result.Status)
+		return
+	}
+	if !writer.started {
+		w.WriteHeader(result.Status)
+	}
+}
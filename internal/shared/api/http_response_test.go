@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1handlers "github.com/babylonlabs-io/staking-api-service/internal/v1/api/handlers"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testStakerPk = "0000000000000000000000000000000000000000000000000000000000000001"
+
+func TestExportStakerDelegations(t *testing.T) {
+	delegations := []*v1dbmodel.DelegationDocument{
+		{
+			StakingTxHashHex:      "tx2",
+			FinalityProviderPkHex: `fp,"quoted"`,
+			StakingValue:          2000,
+			State:                 types.Withdrawn,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 20, StartTimestamp: 1700000000},
+			WithdrawalTx:          &v1dbmodel.WithdrawalTransaction{TxHashHex: "withdrawal-tx"},
+		},
+		{
+			StakingTxHashHex:      "tx1",
+			FinalityProviderPkHex: "fp",
+			StakingValue:          1000,
+			State:                 types.Active,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 10, StartTimestamp: 1600000000},
+		},
+	}
+	newExport := func(iterateErr error) func(*http.Request) *httptest.ResponseRecorder {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("IterateDelegationsByStakerPk", mock.Anything, testStakerPk, mock.Anything).Return(
+			func(_ context.Context, _ string, fn func(*v1dbmodel.DelegationDocument) error) error {
+				if iterateErr != nil {
+					return iterateErr
+				}
+				for _, d := range delegations {
+					if err := fn(d); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		).Maybe()
+		h := &v1handlers.V1Handler{Service: &v1service.V1Service{Service: &service.Service{
+			Cfg:       &config.Config{},
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		}}}
+		return func(r *http.Request) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			registerHandler(h.ExportStakerDelegations)(w, r)
+			return w
+		}
+	}
+	exportURL := "/v1/staker/delegations/export?staker_btc_pk=" + testStakerPk
+
+	t.Run("csv is the default format", func(t *testing.T) {
+		w := newExport(nil)(httptest.NewRequest(http.MethodGet, exportURL, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t,
+			"attachment; filename=delegations-"+testStakerPk+".csv",
+			w.Header().Get("Content-Disposition"),
+		)
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "staking_tx_hash_hex", records[0][0])
+		// values with commas and quotes are escaped
+		assert.Equal(t, `fp,"quoted"`, records[1][5])
+		assert.Equal(t, "withdrawal-tx", records[1][7])
+		assert.Equal(t, []string{"tx1", "active", "1000", "10", records[2][4], "fp", "", ""}, records[2])
+	})
+
+	t.Run("json is exported as one delegation per line", func(t *testing.T) {
+		w := newExport(nil)(httptest.NewRequest(http.MethodGet, exportURL+"&format=json", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), ".json")
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		var export v1service.DelegationExportPublic
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &export))
+		assert.Equal(t, "tx2", export.StakingTxHashHex)
+		assert.Equal(t, uint64(2000), export.StakingValue)
+	})
+
+	t.Run("unknown formats are rejected", func(t *testing.T) {
+		w := newExport(nil)(httptest.NewRequest(http.MethodGet, exportURL+"&format=xml", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("failures before the first row are returned as errors", func(t *testing.T) {
+		w := newExport(errors.New("db down"))(httptest.NewRequest(http.MethodGet, exportURL, nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+}
+
+func TestExportStakerDelegationsOutlivesTimeouts(t *testing.T) {
+	const (
+		timeout  = 100 * time.Millisecond
+		rows     = 200
+		rowDelay = 5 * time.Millisecond
+	)
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("IterateDelegationsByStakerPk", mock.Anything, testStakerPk, mock.Anything).Return(
+		// a slow cursor, running well past the timeouts
+		func(ctx context.Context, _ string, fn func(*v1dbmodel.DelegationDocument) error) error {
+			for i := 0; i < rows; i++ {
+				time.Sleep(rowDelay)
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if err := fn(&v1dbmodel.DelegationDocument{
+					StakingTxHashHex:      strings.Repeat("0", 64),
+					FinalityProviderPkHex: strings.Repeat("1", 64),
+					State:                 types.Active,
+					StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: uint64(i)},
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	).Once()
+	cfg := &config.Config{RequestTimeout: &config.RequestTimeoutConfig{DefaultGetTimeout: timeout}}
+	h := &v1handlers.V1Handler{Service: &v1service.V1Service{Service: &service.Service{
+		Cfg:       cfg,
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}}
+	r := chi.NewRouter()
+	r.Use(middlewares.RequestTimeoutMiddleware(cfg, r, delegationExportRoute))
+	r.Get(delegationExportRoute, registerHandler(h.ExportStakerDelegations))
+	server := httptest.NewUnstartedServer(r)
+	server.Config.ReadTimeout = timeout
+	server.Config.WriteTimeout = timeout
+	server.Start()
+	defer server.Close()
+
+	startTime := time.Now()
+	resp, err := http.Get(server.URL + delegationExportRoute + "?staker_btc_pk=" + testStakerPk)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Greater(t, time.Since(startTime), 5*timeout)
+	// every delegation is downloaded, after the header row
+	require.Len(t, records, rows+1)
+	assert.Equal(t, "199", records[rows][3])
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	token := strings.Repeat("a", 32)
+	h := AdminAuthMiddleware(&config.AdminConfig{Token: token})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	serve := func(authorization string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/internal/stakers/rebuild-stats", nil)
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, serve("Bearer "+token).Code)
+
+	for name, authorization := range map[string]string{
+		"no token":         "",
+		"wrong token":      "Bearer " + strings.Repeat("b", 32),
+		"token prefix":     "Bearer " + token[:16],
+		"not a bearer":     "Basic " + token,
+		"token without it": token,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := serve(authorization)
+			require.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+
+			var resp handler.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, types.Unauthorized.String(), resp.ErrorCode)
+		})
+	}
+}
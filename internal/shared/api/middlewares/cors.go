@@ -33,7 +33,14 @@ func CorsMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 			// Default CORS options for other routes
 			return cors.Options{
 				AllowedOrigins: cfg.Server.AllowedOrigins,
-				MaxAge:         maxAge,
+				// If-Match is sent on conditional writes, with the ETag of the
+				// fetched resource
+				AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "If-Match"},
+				// The rate limit headers let the clients pace their requests
+				ExposedHeaders: []string{
+					"ETag", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset",
+				},
+				MaxAge: maxAge,
 			}
 		}
 
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+)
+
+// unmatchedRoute labels the requests that matched no route, so that the raw
+// paths never end up in the metric labels
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware records the count and the duration of every request,
+// labelled by the route template it matched and its response status.
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		// The route pattern is only known once the router handled the request
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		metrics.RecordHttpRequest(route, status, time.Since(startTime))
+	})
+}
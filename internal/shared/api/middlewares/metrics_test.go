@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// histogramSampleCount returns the number of observations of the histogram
+// with the given labels, 0 if none was recorded yet
+func histogramSampleCount(t *testing.T, name string, labels map[string]string) uint64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] == label.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	metrics.Init(0)
+
+	r := chi.NewRouter()
+	r.Use(MetricsMiddleware)
+	r.Post("/v1/unbonding", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	r.Get("/v1/finality-providers/{pk}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	const durationMetric = "staking_api_http_request_duration_seconds"
+	unbondingLabels := map[string]string{"route": "/v1/unbonding", "status": "202"}
+	fpLabels := map[string]string{"route": "/v1/finality-providers/{pk}", "status": "200"}
+	unmatchedLabels := map[string]string{"route": "unmatched", "status": "404"}
+	unbondingBefore := histogramSampleCount(t, durationMetric, unbondingLabels)
+	fpBefore := histogramSampleCount(t, durationMetric, fpLabels)
+	unmatchedBefore := histogramSampleCount(t, durationMetric, unmatchedLabels)
+
+	for _, request := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v1/unbonding", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/finality-providers/aaaa", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/finality-providers/bbbb", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/unknown", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	// the requests are labelled by their route template, never the raw path
+	assert.Equal(t, unbondingBefore+1, histogramSampleCount(t, durationMetric, unbondingLabels))
+	assert.Equal(t, fpBefore+2, histogramSampleCount(t, durationMetric, fpLabels))
+	assert.Equal(t, unmatchedBefore+1, histogramSampleCount(t, durationMetric, unmatchedLabels))
+	assert.Zero(t, histogramSampleCount(t, durationMetric, map[string]string{
+		"route": "/v1/finality-providers/aaaa",
+	}))
+}
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// maxQueryValueLength is the longest query parameter value accepted. The
+// longest legitimate values are the base64 pagination keys, which stay well
+// below it.
+const maxQueryValueLength = 1024
+
+// QueryLengthMiddleware rejects requests carrying an oversized query parameter
+// value before they reach the handlers
+func QueryLengthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, values := range r.URL.Query() {
+			for _, value := range values {
+				if len(value) > maxQueryValueLength {
+					writeQueryTooLong(w, name)
+					return
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeQueryTooLong(w http.ResponseWriter, name string) {
+	respBytes, err := json.Marshal(&handler.ErrorResponse{
+		ErrorCode: types.BadRequest.String(),
+		Message:   "query parameter " + name + " is too long",
+	})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write(respBytes)
+}
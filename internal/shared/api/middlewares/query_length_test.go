@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryLengthMiddleware(t *testing.T) {
+	h := QueryLengthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Run("value at the limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/delegations?pagination_key="+strings.Repeat("a", maxQueryValueLength), nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("value over the limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/delegations?state=active&pagination_key="+strings.Repeat("a", maxQueryValueLength+1), nil))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var resp handler.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, types.BadRequest.String(), resp.ErrorCode)
+		assert.Equal(t, "query parameter pagination_key is too long", resp.Message)
+	})
+}
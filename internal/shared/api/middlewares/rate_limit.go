@@ -0,0 +1,195 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog/log"
+)
+
+// rateLimitSweepInterval is how often the buckets of the IPs that stopped
+// sending requests are evicted
+const rateLimitSweepInterval = time.Minute
+
+type tokenBucket struct {
+	limit     *config.RateLimit
+	tokens    float64
+	updatedAt time.Time
+}
+
+// rateLimitStatus is the state of the bucket of a client after a request
+type rateLimitStatus struct {
+	allowed bool
+	// remaining is the number of requests the client can still send at once
+	remaining int
+	// retryAfter is how long to wait for the next token, if not allowed
+	retryAfter time.Duration
+	// reset is how long until the bucket is full again
+	reset time.Duration
+}
+
+// take consumes a token if one is available, otherwise it returns how long
+// to wait for the next one
+func (b *tokenBucket) take(now time.Time) rateLimitStatus {
+	refillRate := float64(b.limit.Requests) / float64(b.limit.Period)
+	b.tokens = math.Min(
+		float64(b.limit.Requests),
+		b.tokens+float64(now.Sub(b.updatedAt))*refillRate,
+	)
+	b.updatedAt = now
+	status := rateLimitStatus{}
+	if b.tokens >= 1 {
+		b.tokens--
+		status.allowed = true
+	} else {
+		status.retryAfter = time.Duration((1 - b.tokens) / refillRate)
+	}
+	status.remaining = int(b.tokens)
+	status.reset = time.Duration((float64(b.limit.Requests) - b.tokens) / refillRate)
+	return status
+}
+
+// isFull returns true once the bucket is refilled, it then behaves as a new
+// one and can be evicted
+func (b *tokenBucket) isFull(now time.Time) bool {
+	return now.Sub(b.updatedAt) >= b.limit.Period
+}
+
+type rateLimiter struct {
+	cfg       *config.RateLimitConfig
+	mutex     sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func (l *rateLimiter) allow(key string, limit *config.RateLimit) rateLimitStatus {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		for bucketKey, bucket := range l.buckets {
+			if bucket.isFull(now) {
+				delete(l.buckets, bucketKey)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{limit: limit, tokens: float64(limit.Requests), updatedAt: now}
+		l.buckets[key] = bucket
+	}
+	return bucket.take(now)
+}
+
+// RateLimitMiddleware limits the requests of every client IP per route, as
+// configured for the route template the request matches in the given routes.
+// Limited requests are rejected with a 429 and a Retry-After header. The
+// responses of limited routes carry the X-RateLimit-Limit, -Remaining and
+// -Reset headers so that clients can pace their requests.
+func RateLimitMiddleware(cfg *config.Config, routes chi.Routes) func(http.Handler) http.Handler {
+	return newRateLimitMiddleware(cfg.RateLimit, routes, time.Now)
+}
+
+func newRateLimitMiddleware(
+	cfg *config.RateLimitConfig, routes chi.Routes, now func() time.Time,
+) func(http.Handler) http.Handler {
+	limiter := &rateLimiter{
+		cfg:       cfg,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: now(),
+		now:       now,
+	}
+	return func(next http.Handler) http.Handler {
+		if cfg == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.NewRouteContext()
+			if !routes.Match(rctx, r.Method, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			route := rctx.RoutePattern()
+			limit := cfg.Limit(r.Method, route)
+			if limit == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ip := clientIP(r, cfg)
+			status := limiter.allow(r.Method+" "+route+" "+ip, limit)
+			writeRateLimitHeaders(w, limit, status)
+			if !status.allowed {
+				log.Ctx(r.Context()).Warn().Str("ip", ip).Str("route", route).Msg("request rate limited")
+				writeTooManyRequests(w, status.retryAfter)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the IP of the client. The X-Forwarded-For header is only
+// read if the request comes from a trusted proxy, the client is then the
+// closest hop that is not a trusted proxy.
+func clientIP(r *http.Request, cfg *config.RateLimitConfig) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !cfg.IsTrustedProxy(ip) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		ip = hop
+		if !cfg.IsTrustedProxy(hop) {
+			break
+		}
+	}
+	return ip.String()
+}
+
+// writeRateLimitHeaders sets the limit of the route, the requests the client
+// can still send at once and the seconds until its bucket is full again
+func writeRateLimitHeaders(w http.ResponseWriter, limit *config.RateLimit, status rateLimitStatus) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(status.remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(status.reset.Seconds()))))
+}
+
+func writeTooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	respBytes, err := json.Marshal(&handler.ErrorResponse{
+		ErrorCode: types.TooManyRequests.String(),
+		Message:   "Too many requests, please retry later",
+	})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+		return
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write(respBytes)
+}
@@ -0,0 +1,166 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitedRouter(t *testing.T, cfg *config.RateLimitConfig, now func() time.Time) *chi.Mux {
+	require.NoError(t, cfg.Validate())
+	r := chi.NewRouter()
+	r.Use(newRateLimitMiddleware(cfg, r, now))
+	r.Post("/v1/unbonding", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	r.Get("/v1/unbonding/eligibility", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Post("/v1/ordinals/verify-utxos", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return r
+}
+
+func sendRequest(r http.Handler, method, path, remoteAddr string, forwardedFor string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, nil)
+	request.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		request.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+	r := newRateLimitedRouter(t, &config.RateLimitConfig{
+		DefaultPostLimit: config.RateLimit{Requests: 100, Period: time.Minute},
+		Routes: []config.RouteRateLimit{{
+			Method: http.MethodPost, Route: "/v1/unbonding",
+			RateLimit: config.RateLimit{Requests: 10, Period: time.Minute},
+		}},
+		TrustedProxies: []string{"10.0.0.0/8"},
+	}, clock)
+
+	t.Run("the unbonding requests above the limit are rejected", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			resp := sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "")
+			require.Equal(t, http.StatusAccepted, resp.Code, "request %d", i)
+		}
+		resp := sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "")
+		require.Equal(t, http.StatusTooManyRequests, resp.Code)
+		assert.Equal(t, "6", resp.Header().Get("Retry-After"))
+		var body handler.ErrorResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+		assert.Equal(t, types.TooManyRequests.String(), body.ErrorCode)
+
+		// the other clients and the other POST routes have their own bucket
+		resp = sendRequest(r, http.MethodPost, "/v1/unbonding", "2.2.2.2:1234", "")
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		resp = sendRequest(r, http.MethodPost, "/v1/ordinals/verify-utxos", "1.1.1.1:1234", "")
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("the GET requests are not limited by default", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			resp := sendRequest(r, http.MethodGet, "/v1/unbonding/eligibility", "1.1.1.1:1234", "")
+			require.Equal(t, http.StatusOK, resp.Code, "request %d", i)
+		}
+	})
+
+	t.Run("a token is refilled every period over the limit", func(t *testing.T) {
+		now = now.Add(6 * time.Second)
+		resp := sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "")
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		resp = sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "")
+		assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	})
+
+	t.Run("the forwarded client IP is only trusted from a trusted proxy", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			resp := sendRequest(r, http.MethodPost, "/v1/unbonding", "10.0.0.1:1234", "3.3.3.3, 10.0.0.2")
+			require.Equal(t, http.StatusAccepted, resp.Code, "request %d", i)
+		}
+		resp := sendRequest(r, http.MethodPost, "/v1/unbonding", "10.0.0.3:1234", "3.3.3.3")
+		assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+		// a spoofed header from an untrusted client does not bypass the limit
+		resp = sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "4.4.4.4")
+		assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	})
+}
+
+func TestRateLimiterEvictsIdleClients(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := &rateLimiter{
+		buckets: make(map[string]*tokenBucket), lastSweep: now,
+		now: func() time.Time { return now },
+	}
+	limit := &config.RateLimit{Requests: 10, Period: time.Minute}
+
+	for i := 0; i < 100; i++ {
+		status := limiter.allow(string(rune('a'+i%26))+"-client", limit)
+		require.True(t, status.allowed)
+	}
+	assert.Len(t, limiter.buckets, 26)
+
+	// once refilled, the buckets of the clients that went silent are dropped
+	now = now.Add(2 * time.Minute)
+	status := limiter.allow("active-client", limit)
+	assert.True(t, status.allowed)
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+	r := newRateLimitedRouter(t, &config.RateLimitConfig{
+		DefaultPostLimit: config.RateLimit{Requests: 3, Period: 30 * time.Second},
+	}, clock)
+
+	// every request consumes a token, refilled every 10 seconds
+	for i, remaining := range []string{"2", "1", "0"} {
+		resp := sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "")
+		require.Equal(t, http.StatusAccepted, resp.Code, "request %d", i)
+		assert.Equal(t, "3", resp.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, remaining, resp.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, strconv.Itoa(10*(i+1)), resp.Header().Get("X-RateLimit-Reset"))
+	}
+
+	resp := sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "")
+	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "3", resp.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", resp.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "30", resp.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, "10", resp.Header().Get("Retry-After"))
+
+	// the reset countdown goes on, and a full bucket is back at the limit
+	now = now.Add(15 * time.Second)
+	resp = sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "")
+	require.Equal(t, http.StatusAccepted, resp.Code)
+	assert.Equal(t, "0", resp.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "25", resp.Header().Get("X-RateLimit-Reset"))
+
+	now = now.Add(time.Minute)
+	resp = sendRequest(r, http.MethodPost, "/v1/unbonding", "1.1.1.1:1234", "")
+	require.Equal(t, http.StatusAccepted, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "10", resp.Header().Get("X-RateLimit-Reset"))
+
+	// the routes that are not limited carry no headers
+	resp = sendRequest(r, http.MethodGet, "/v1/unbonding/eligibility", "1.1.1.1:1234", "")
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("X-RateLimit-Limit"))
+}
@@ -0,0 +1,144 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog/log"
+)
+
+// timeoutWriter forwards the response of the handler until its context is
+// done. A handler which has not written anything by then is answered by the
+// middleware, and anything it writes afterwards is dropped.
+type timeoutWriter struct {
+	w   http.ResponseWriter
+	ctx context.Context
+	// header is owned by the handler, it is copied to the response when the
+	// status code is written so that a late handler never races the
+	// middleware on the response headers
+	header http.Header
+
+	mutex       sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(statusCode int) {
+	tw.mutex.Lock()
+	defer tw.mutex.Unlock()
+	tw.writeHeader(statusCode)
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mutex.Lock()
+	defer tw.mutex.Unlock()
+	if !tw.writeHeader(http.StatusOK) {
+		return 0, http.ErrHandlerTimeout
+	}
+	return tw.w.Write(p)
+}
+
+// writeHeader writes the status code if not done yet, it returns false if the
+// response is no longer owned by the handler
+func (tw *timeoutWriter) writeHeader(statusCode int) bool {
+	if tw.timedOut {
+		return false
+	}
+	if tw.wroteHeader {
+		return true
+	}
+	if tw.ctx.Err() != nil {
+		tw.timedOut = true
+		return false
+	}
+	for key, values := range tw.header {
+		tw.w.Header()[key] = values
+	}
+	tw.w.WriteHeader(statusCode)
+	tw.wroteHeader = true
+	return true
+}
+
+// RequestTimeoutMiddleware cancels the context of a request once the timeout
+// of the route template it matches in the given routes is reached. A request
+// without response by then is answered with a 504, a streamed response
+// already started is truncated. The untimed route templates, held open until
+// the client disconnects, are not bounded.
+func RequestTimeoutMiddleware(
+	cfg *config.Config, routes chi.Routes, untimedRoutes ...string,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.NewRouteContext()
+			if !routes.Match(rctx, r.Method, r.URL.Path) ||
+				slices.Contains(untimedRoutes, rctx.RoutePattern()) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			timeout := cfg.RequestTimeout.Timeout(r.Method, rctx.RoutePattern())
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			tw := &timeoutWriter{w: w, ctx: ctx, header: w.Header().Clone()}
+
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+						return
+					}
+					close(done)
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+			}()
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+			case <-ctx.Done():
+			}
+
+			tw.mutex.Lock()
+			defer tw.mutex.Unlock()
+			if ctx.Err() == nil {
+				return
+			}
+			wroteHeader := tw.wroteHeader
+			tw.timedOut = true
+			if !wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				log.Ctx(r.Context()).Warn().Str("route", rctx.RoutePattern()).Dur("timeout", timeout).
+					Msg("request timed out")
+				writeRequestTimeout(w)
+			}
+		})
+	}
+}
+
+func writeRequestTimeout(w http.ResponseWriter) {
+	respBytes, err := json.Marshal(&handler.ErrorResponse{
+		ErrorCode: types.RequestTimeout.String(),
+		Message:   "Request timed out, please retry later",
+	})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusGatewayTimeout)
+	_, _ = w.Write(respBytes)
+}
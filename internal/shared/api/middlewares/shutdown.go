@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+)
+
+// CancelOnShutdownMiddleware cancels the context of the requests once the
+// given shutdown context is done. The streaming requests are held open until
+// the client disconnects, so the server would otherwise wait on them for the
+// whole shutdown timeout.
+func CancelOnShutdownMiddleware(shutdownCtx context.Context) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			stop := context.AfterFunc(shutdownCtx, cancel)
+			defer stop()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelOnShutdownMiddleware(t *testing.T) {
+	shutdownCtx, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+	started := make(chan struct{})
+	handler := CancelOnShutdownMiddleware(shutdownCtx)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		close(done)
+	}()
+	<-started
+	select {
+	case <-done:
+		t.Fatal("the request returned before the shutdown")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	shutdown()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the request was not cancelled on shutdown")
+	}
+	assert.Error(t, shutdownCtx.Err())
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1handlers "github.com/babylonlabs-io/staking-api-service/internal/v1/api/handlers"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testStakingTxHash = "0000000000000000000000000000000000000000000000000000000000000001"
+
+func TestRequestTimeout(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	cfg := &config.Config{RequestTimeout: &config.RequestTimeoutConfig{
+		Routes: []config.RouteRequestTimeout{
+			{Method: http.MethodGet, Route: "/v1/delegation", Timeout: timeout},
+		},
+	}}
+	newRouter := func(v1DB *mocks.V1DBClient) *chi.Mux {
+		h := &v1handlers.V1Handler{Service: &v1service.V1Service{Service: &service.Service{
+			Cfg:       cfg,
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		}}}
+		r := chi.NewRouter()
+		r.Use(middlewares.RequestTimeoutMiddleware(cfg, r))
+		r.Get("/v1/delegation", registerHandler(h.GetDelegationByTxHash))
+		return r
+	}
+	requestDelegation := func(r *chi.Mux) (*httptest.ResponseRecorder, time.Duration) {
+		w := httptest.NewRecorder()
+		startTime := time.Now()
+		r.ServeHTTP(w, httptest.NewRequest(
+			http.MethodGet, "/v1/delegation?staking_tx_hash_hex="+testStakingTxHash, nil,
+		))
+		return w, time.Since(startTime)
+	}
+	assertTimedOut := func(t *testing.T, w *httptest.ResponseRecorder) {
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		var errorResponse handler.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+		assert.Equal(t, types.RequestTimeout.String(), errorResponse.ErrorCode)
+	}
+
+	t.Run("slow query ignoring the context", func(t *testing.T) {
+		released := make(chan struct{})
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", mock.Anything, testStakingTxHash).Return(
+			func(context.Context, string) (*v1dbmodel.DelegationDocument, error) {
+				defer close(released)
+				time.Sleep(10 * timeout)
+				return nil, context.DeadlineExceeded
+			},
+		).Once()
+
+		w, elapsed := requestDelegation(newRouter(v1DB))
+		assertTimedOut(t, w)
+		assert.Less(t, elapsed, 5*timeout)
+		// the late error of the handler is dropped
+		<-released
+		assertTimedOut(t, w)
+	})
+
+	t.Run("slow query cancelled with the request", func(t *testing.T) {
+		queryErr := make(chan error, 1)
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", mock.Anything, testStakingTxHash).Return(
+			func(ctx context.Context, _ string) (*v1dbmodel.DelegationDocument, error) {
+				<-ctx.Done()
+				queryErr <- ctx.Err()
+				return nil, ctx.Err()
+			},
+		).Once()
+
+		w, elapsed := requestDelegation(newRouter(v1DB))
+		assertTimedOut(t, w)
+		assert.Less(t, elapsed, 5*timeout)
+		assert.ErrorIs(t, <-queryErr, context.DeadlineExceeded)
+	})
+
+	t.Run("query within the timeout", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", mock.Anything, testStakingTxHash).
+			Return(nil, &db.NotFoundError{Key: testStakingTxHash, Message: "not found"}).Once()
+
+		w, _ := requestDelegation(newRouter(v1DB))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
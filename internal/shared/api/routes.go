@@ -2,14 +2,25 @@ package api
 
 import (
 	_ "github.com/babylonlabs-io/staking-api-service/docs"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
 	"github.com/go-chi/chi"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+// The stream routes are held open until the client disconnects or the server
+// shuts down, and the exports last as long as the staker has delegations to
+// download, they are not bounded by the request timeout
+const (
+	delegationStreamRoute  = "/v1/staker/delegations/stream"
+	stakerEventStreamRoute = "/v1/staker/event-stream"
+	delegationExportRoute  = "/v1/staker/delegations/export"
+)
+
 func (a *Server) SetupRoutes(r *chi.Mux) {
 	handlers := a.handlers
 	// Common routes
 	r.Get("/healthcheck", registerHandler(handlers.SharedHandler.HealthCheck))
+	r.Get("/ready", registerHandler(handlers.SharedHandler.ReadinessCheck))
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 	r.Get("/v1/staker/pubkey-lookup", registerHandler(handlers.V1Handler.GetPubKeys))
 	r.Get("/v1/staker/delegation/check", registerHandler(handlers.V1Handler.CheckStakerDelegationExist))
@@ -33,7 +44,81 @@ func (a *Server) SetupRoutes(r *chi.Mux) {
 	// These will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.
 	r.Post("/v1/unbonding", registerHandler(handlers.V1Handler.UnbondDelegation))
 	r.Get("/v1/unbonding/eligibility", registerHandler(handlers.V1Handler.GetUnbondingEligibility))
+	r.Post("/v1/unbonding/eligibility/bulk", registerHandler(handlers.V1Handler.GetBulkUnbondingEligibility))
 	r.Get("/v1/staker/delegations", registerHandler(handlers.V1Handler.GetStakerDelegations))
+	r.Get(delegationExportRoute, registerHandler(handlers.V1Handler.ExportStakerDelegations))
+	streams := r.With(middlewares.CancelOnShutdownMiddleware(a.streamsCtx))
+	streams.Get(delegationStreamRoute, registerHandler(handlers.V1Handler.StreamStakerDelegations))
+	streams.Get(stakerEventStreamRoute, registerHandler(handlers.V1Handler.StreamStakerEvents))
+	r.Get("/v1/staker/delegation-proof", registerHandler(handlers.V1Handler.GetStakerDelegationProof))
+	r.Get("/v1/staker/delegation-lifecycle-summary", registerHandler(handlers.V1Handler.GetStakerDelegationLifecycleSummary))
+	r.Get("/v1/staker/covenant-exposure", registerHandler(handlers.V1Handler.GetStakerCovenantExposure))
+	r.Get("/v1/staker/active-btc-at-risk", registerHandler(handlers.V1Handler.GetStakerActiveBtcAtRisk))
+	r.Get("/v1/staker/portfolio-value-usd", registerHandler(handlers.V1Handler.GetStakerPortfolioValueUsd))
+	r.Get("/v1/staker/watchlist", registerHandler(handlers.V1Handler.GetWatchlist))
+	r.Post("/v1/staker/watchlist", registerHandler(handlers.V1Handler.AddToWatchlist))
+	r.Post("/v1/staker/watchlist/remove", registerHandler(handlers.V1Handler.RemoveFromWatchlist))
+	r.Get("/v1/staker/watchlist/delegations", registerHandler(handlers.V1Handler.GetWatchlistDelegations))
+	r.Get("/v1/finality-providers/inactive", registerHandler(handlers.V1Handler.GetInactiveFinalityProviders))
+	r.Get("/v1/finality-providers/by-total-stakers", registerHandler(handlers.V1Handler.GetFinalityProvidersByStakers))
+	r.Get("/v1/finality-provider/logo", registerHandler(handlers.V1Handler.GetFinalityProviderLogo))
+	r.Get("/v1/finality-provider/active-stake-over-time", registerHandler(handlers.V1Handler.GetFinalityProviderActiveStakeOverTime))
+	r.Post("/v1/finality-provider/report-misbehavior", registerHandler(handlers.V1Handler.ReportMisbehavior))
+
+	// Phase-1 stats and delegation insights. They are not deprecated along with
+	// the phase-1 endpoints below
+	r.Get("/v1/stats/new-stakers-per-day", registerHandler(handlers.V1Handler.GetNewStakersPerDay))
+	r.Get("/v1/stats/covenant-response-time", registerHandler(handlers.V1Handler.GetCovenantResponseTime))
+	r.Get("/v1/stats/withdrawal-completion-time", registerHandler(handlers.V1Handler.GetWithdrawalCompletionTime))
+	r.Get("/v1/stats/provider-churn", registerHandler(handlers.V1Handler.GetProviderChurn))
+	// The time series is built from the daily rollup of the v2 stats
+	r.Get("/v1/stats/time-series", registerHandler(handlers.V2Handler.GetStatsTimeSeries))
+	r.Get("/v1/delegations/reactivation-candidates", registerHandler(handlers.V1Handler.GetReactivationCandidates))
+	r.Get("/v1/delegations/by-withdrawal-address", registerHandler(handlers.V1Handler.GetDelegationsByWithdrawalAddress))
+	r.Get("/v1/delegations/created-by-block", registerHandler(handlers.V1Handler.GetDelegationsCreatedByBlock))
+	r.Get("/v1/delegations/avg-staking-duration", registerHandler(handlers.V1Handler.GetStakingDurationStats))
+	r.Get("/v1/delegations/value-concentration", registerHandler(handlers.V1Handler.GetDelegationValueConcentration))
+	r.Get("/v1/delegations/near-covenant-expiry", registerHandler(handlers.V1Handler.GetDelegationsNearCovenantExpiry))
+	r.Get("/v1/delegations/provider-change-candidates", registerHandler(handlers.V1Handler.GetProviderChangeCandidates))
+	r.Get("/v1/delegations/co-stakers", registerHandler(handlers.V1Handler.GetCoStakers))
+	r.Get("/v1/state-machine", registerHandler(handlers.V1Handler.GetStateMachines))
+	r.Get("/v1/constants", registerHandler(handlers.V1Handler.GetConstants))
+
+	// Admin endpoints, only registered when an admin token is configured and
+	// only served to the requests carrying it
+	if a.cfg.Admin != nil {
+		admin := r.With(middlewares.AdminAuthMiddleware(a.cfg.Admin))
+		admin.Post("/v1/internal/stakers/rebuild-stats", registerHandler(handlers.V1Handler.RebuildStakerStats))
+		admin.Post("/v1/admin/config/update-param", registerHandler(handlers.V1Handler.UpdateGlobalParam))
+		admin.Post("/v1/admin/finality-provider/bulk-register", registerHandler(handlers.V1Handler.BulkRegisterFinalityProviders))
+		admin.Get("/v1/internal/delegations/consistency", registerHandler(handlers.V1Handler.GetDelegationConsistency))
+		admin.Get("/v1/admin/delegations/expired-but-not-withdrawn", registerHandler(handlers.V1Handler.GetDelegationsOverdueForWithdrawal))
+		admin.Get("/v1/admin/delegations/dust", registerHandler(handlers.V1Handler.GetDustDelegations))
+		admin.Get("/v1/admin/delegations/covenant-missing", registerHandler(handlers.V1Handler.GetCovenantMissingDelegations))
+		admin.Get("/v1/admin/delegations/orphaned", registerHandler(handlers.V1Handler.GetOrphanedDelegations))
+		admin.Get("/v1/admin/delegations/verify-consistency", registerHandler(handlers.V1Handler.VerifyDelegationsConsistency))
+		admin.Get("/v1/admin/delegations/high-value", registerHandler(handlers.V1Handler.GetHighValueDelegations))
+		admin.Get("/v1/internal/unprocessable-messages", registerHandler(handlers.V1Handler.GetUnprocessableMessages))
+		admin.Get("/v1/internal/boot-report", registerHandler(handlers.V1Handler.GetBootReport))
+		admin.Get("/v1/internal/staker/dossier", registerHandler(handlers.V1Handler.GetStakerDossier))
+		admin.Get("/v1/internal/consumers/throttle", registerHandler(handlers.SharedHandler.GetQueueThrottle))
+		admin.Post("/v1/internal/consumers/throttle", registerHandler(handlers.SharedHandler.OverrideQueueThrottle))
+		admin.Get("/v1/internal/consumers/dry-run", registerHandler(handlers.SharedHandler.GetQueueDryRun))
+		admin.Post("/v1/internal/consumers/dry-run", registerHandler(handlers.SharedHandler.SetQueueDryRun))
+		// Only register this route if the unbonding integrity check is configured
+		if a.cfg.UnbondingIntegrity != nil {
+			admin.Get("/v1/internal/integrity-issues", registerHandler(handlers.V1Handler.GetIntegrityIssues))
+		}
+		// Only register this route if the event gap detection is configured
+		if a.cfg.EventGapDetection != nil {
+			admin.Get("/v1/internal/gaps", registerHandler(handlers.V2Handler.GetEventGaps))
+		}
+		// Only register this route if enabled in the debug config, which is
+		// refused on mainnet
+		if a.cfg.Debug != nil && a.cfg.Debug.UnbondingSighash {
+			admin.Get("/v1/debug/unbonding-sighash", registerHandler(handlers.V1Handler.GetUnbondingSigHash))
+		}
+	}
 
 	// Deprecated endpoints that were used in phase-1. Will be removed in the future
 	r.Get("/v1/global-params", registerHandler(handlers.V1Handler.GetBabylonGlobalParams))
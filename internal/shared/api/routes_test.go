@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugRoutesGate(t *testing.T) {
+	const unbondingSigHashPath = "/v1/debug/unbonding-sighash"
+	newRouter := func(cfg *config.Config) *chi.Mux {
+		r := chi.NewRouter()
+		server := &Server{handlers: &handlers.Handlers{}, cfg: cfg}
+		server.SetupRoutes(r)
+		return r
+	}
+	isRegistered := func(cfg *config.Config) bool {
+		return newRouter(cfg).Match(chi.NewRouteContext(), http.MethodGet, unbondingSigHashPath)
+	}
+	token := strings.Repeat("a", 32)
+	admin := &config.AdminConfig{Token: token}
+	enabled := &config.DebugConfig{UnbondingSighash: true}
+
+	assert.False(t, isRegistered(&config.Config{}))
+	assert.False(t, isRegistered(&config.Config{Debug: &config.DebugConfig{}, Admin: admin}))
+	// the feature flag alone does not expose the route without an admin token
+	assert.False(t, isRegistered(&config.Config{Debug: enabled}))
+	assert.True(t, isRegistered(&config.Config{Debug: enabled, Admin: admin}))
+
+	// served behind the admin token while the flag is on
+	r := newRouter(&config.Config{Debug: enabled, Admin: admin})
+	for _, authorization := range []string{"", "Bearer " + strings.Repeat("b", 32)} {
+		req := httptest.NewRequest(http.MethodGet, unbondingSigHashPath+"?staking_tx_hash_hex=00", nil)
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestAdminRoutesGate(t *testing.T) {
+	adminRoutes := []struct{ method, path string }{
+		{http.MethodPost, "/v1/internal/stakers/rebuild-stats"},
+		{http.MethodPost, "/v1/admin/config/update-param"},
+		{http.MethodPost, "/v1/admin/finality-provider/bulk-register"},
+		{http.MethodGet, "/v1/internal/consumers/throttle"},
+		{http.MethodPost, "/v1/internal/consumers/throttle"},
+		{http.MethodGet, "/v1/internal/consumers/dry-run"},
+		{http.MethodPost, "/v1/internal/consumers/dry-run"},
+		{http.MethodGet, "/v1/internal/staker/dossier"},
+		{http.MethodGet, "/v1/internal/boot-report"},
+		{http.MethodGet, "/v1/internal/integrity-issues"},
+		{http.MethodGet, "/v1/internal/gaps"},
+		{http.MethodGet, "/v1/internal/delegations/consistency"},
+		{http.MethodGet, "/v1/admin/delegations/expired-but-not-withdrawn"},
+		{http.MethodGet, "/v1/admin/delegations/dust"},
+		{http.MethodGet, "/v1/admin/delegations/covenant-missing"},
+		{http.MethodGet, "/v1/admin/delegations/orphaned"},
+		{http.MethodGet, "/v1/admin/delegations/verify-consistency"},
+		{http.MethodGet, "/v1/internal/unprocessable-messages"},
+		{http.MethodGet, "/v1/admin/delegations/high-value"},
+	}
+	serve := func(cfg *config.Config, method, path, authorization string) int {
+		r := chi.NewRouter()
+		server := &Server{handlers: &handlers.Handlers{}, cfg: cfg}
+		server.SetupRoutes(r)
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+	token := strings.Repeat("a", 32)
+	// the features gating some of the admin routes are enabled
+	featuresCfg := config.Config{
+		UnbondingIntegrity: &config.UnbondingIntegrityConfig{},
+		EventGapDetection:  &config.EventGapDetectionConfig{},
+	}
+	adminCfg := featuresCfg
+	adminCfg.Admin = &config.AdminConfig{Token: token}
+
+	for _, route := range adminRoutes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			// not registered without an admin token, even with the right header
+			code := serve(&featuresCfg, route.method, route.path, "Bearer "+token)
+			assert.Contains(t, []int{http.StatusNotFound, http.StatusMethodNotAllowed}, code)
+			// registered behind the admin token, rejected before reaching the
+			// handler
+			assert.Equal(t, http.StatusUnauthorized, serve(&adminCfg, route.method, route.path, ""))
+			assert.Equal(t, http.StatusUnauthorized,
+				serve(&adminCfg, route.method, route.path, "Bearer "+strings.Repeat("b", 32)))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
@@ -18,10 +19,13 @@ type Server struct {
 	httpServer *http.Server
 	handlers   *handlers.Handlers
 	cfg        *config.Config
+	// streamsCtx is cancelled when the server shuts down, ending the stream
+	// requests which would otherwise be held open until the client disconnects
+	streamsCtx context.Context
 }
 
 func New(
-	ctx context.Context, cfg *config.Config, services *services.Services,
+	ctx context.Context, cfg *config.Config, services *services.Services, queues handler.QueueConsumers,
 ) (*Server, error) {
 	r := chi.NewRouter()
 
@@ -34,8 +38,14 @@ func New(
 	r.Use(middlewares.CorsMiddleware(cfg))
 	r.Use(middlewares.SecurityHeadersMiddleware())
 	r.Use(middlewares.TracingMiddleware)
+	r.Use(middlewares.MetricsMiddleware)
+	r.Use(middlewares.RateLimitMiddleware(cfg, r))
 	r.Use(middlewares.LoggingMiddleware)
 	r.Use(middlewares.ContentLengthMiddleware(cfg))
+	r.Use(middlewares.QueryLengthMiddleware)
+	r.Use(middlewares.RequestTimeoutMiddleware(
+		cfg, r, delegationStreamRoute, stakerEventStreamRoute, delegationExportRoute,
+	))
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
@@ -49,21 +59,33 @@ func New(
 		return nil, fmt.Errorf("error while setting up handlers: %w", err)
 	}
 
-	handlers, err := handlers.New(ctx, cfg, services)
+	handlers, err := handlers.New(ctx, cfg, services, queues)
 	if err != nil {
 		return nil, fmt.Errorf("error while setting up handlers: %w", err)
 	}
 
+	streamsCtx, cancelStreams := context.WithCancel(context.Background())
+	srv.RegisterOnShutdown(cancelStreams)
+
 	server := &Server{
 		httpServer: srv,
 		handlers:   handlers,
 		cfg:        cfg,
+		streamsCtx: streamsCtx,
 	}
 	server.SetupRoutes(r)
 	return server, nil
 }
 
+// Start serves the API until the server is shut down, in which case it
+// returns http.ErrServerClosed
 func (a *Server) Start() error {
 	log.Info().Msgf("Starting server on %s", a.httpServer.Addr)
 	return a.httpServer.ListenAndServe()
 }
+
+// Shutdown stops accepting new connections, ends the stream requests and
+// waits for the other in-flight requests until the context is done
+func (a *Server) Shutdown(ctx context.Context) error {
+	return a.httpServer.Shutdown(ctx)
+}
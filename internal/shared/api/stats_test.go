@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1handlers "github.com/babylonlabs-io/staking-api-service/internal/v1/api/handlers"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	v2handlers "github.com/babylonlabs-io/staking-api-service/internal/v2/api/handlers"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// serveData serves the request and decodes the data of the response
+func serveData[T any](
+	t *testing.T, handlerFunc func(*http.Request) (*handler.Result, *types.Error), url string,
+) T {
+	w := httptest.NewRecorder()
+	registerHandler(handlerFunc)(w, httptest.NewRequest(http.MethodGet, url, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Data T `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Data
+}
+
+func TestV1StatsIncludeFormatted(t *testing.T) {
+	metrics.Init(0)
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("GetOverallStats", mock.Anything).Return(&v1dbmodel.OverallStatsDocument{
+		TotalTvl:                   300000000,
+		WithdrawnTvlEarlyUnbonding: 1,
+		WithdrawnTvlNaturalExpiry:  20,
+		WithdrawnTvlUnknown:        300,
+	}, nil).Once()
+	v1DB.On("GetLatestBtcInfo", mock.Anything).
+		Return(&v1dbmodel.BtcInfo{ConfirmedTvl: 150000000, UnconfirmedTvl: 250000000}, nil).Once()
+	v1DB.On("GetStakerStats", mock.Anything, testStakerPk).
+		Return(&v1dbmodel.StakerStatsDocument{ActiveTvl: 5000, TotalTvl: 7000}, nil).Once()
+	v1DB.On("FindTopStakersByTvl", mock.Anything, "").
+		Return(&db.DbResultMap[*v1dbmodel.StakerStatsDocument]{Data: []*v1dbmodel.StakerStatsDocument{
+			{StakerPkHex: testStakerPk, ActiveTvl: 100000000, TotalTvl: 100000001},
+		}}, nil).Once()
+	h := &v1handlers.V1Handler{Service: &v1service.V1Service{Service: &service.Service{
+		Cfg:       &config.Config{},
+		Cache:     cache.NewMemoryCache(),
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}}
+
+	stats := serveData[map[string]any](t, h.GetOverallStats, "/v1/stats?include_formatted=true")
+	assert.Equal(t, "1.50000000", stats["active_tvl_btc"])
+	assert.Equal(t, "3.00000000", stats["total_tvl_btc"])
+	assert.Equal(t, "2.50000000", stats["unconfirmed_tvl_btc"])
+	assert.Equal(t, "1.00000000", stats["pending_tvl_btc"])
+	assert.Equal(t, map[string]any{
+		"early_unbonding": 1.0, "early_unbonding_btc": "0.00000001",
+		"natural_expiry": 20.0, "natural_expiry_btc": "0.00000020",
+		"unknown": 300.0, "unknown_btc": "0.00000300",
+	}, stats["withdrawn_tvl"])
+
+	// the overall stats are cached, the formatted amounts are not
+	stats = serveData[map[string]any](t, h.GetOverallStats, "/v1/stats")
+	assert.NotContains(t, stats, "active_tvl_btc")
+	assert.NotContains(t, stats, "total_tvl_btc")
+	assert.NotContains(t, stats["withdrawn_tvl"], "unknown_btc")
+
+	stakerStats := serveData[[]map[string]any](
+		t, h.GetStakersStats, "/v1/stats/staker?include_formatted=true&staker_btc_pk="+testStakerPk,
+	)
+	require.Len(t, stakerStats, 1)
+	assert.Equal(t, "0.00005000", stakerStats[0]["active_tvl_btc"])
+	assert.Equal(t, "0.00007000", stakerStats[0]["total_tvl_btc"])
+
+	topStakers := serveData[[]map[string]any](t, h.GetStakersStats, "/v1/stats/staker?include_formatted=true")
+	require.Len(t, topStakers, 1)
+	assert.Equal(t, "1.00000000", topStakers[0]["active_tvl_btc"])
+	assert.Equal(t, "1.00000001", topStakers[0]["total_tvl_btc"])
+
+	w := httptest.NewRecorder()
+	registerHandler(h.GetOverallStats)(w, httptest.NewRequest(http.MethodGet, "/v1/stats?include_formatted=maybe", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestV2StatsIncludeFormatted(t *testing.T) {
+	metrics.Init(0)
+
+	v2DB := mocks.NewV2DBClient(t)
+	v2DB.On("GetOverallStats", mock.Anything).
+		Return(&v2dbmodel.V2OverallStatsDocument{ActiveTvl: 123456789, ActiveDelegations: 2}, nil).Once()
+	v2DB.On("GetActiveStakersCount", mock.Anything).Return(int64(1), nil).Once()
+	indexerDB := mocks.NewIndexerDBClient(t)
+	indexerDB.On("GetFinalityProviders", mock.Anything).
+		Return([]*indexerdbmodel.IndexerFinalityProviderDetails{}, nil).Once()
+	h := &v2handlers.V2Handler{Service: &v2service.V2Service{
+		Cfg:       &config.Config{},
+		Cache:     cache.NewMemoryCache(),
+		DbClients: &dbclients.DbClients{V2DBClient: v2DB, IndexerDBClient: indexerDB},
+	}}
+
+	stats := serveData[map[string]any](t, h.GetOverallStats, "/v2/stats?include_formatted=true")
+	assert.Equal(t, "1.23456789", stats["active_tvl_btc"])
+
+	// the overall stats are cached, the formatted amounts are not
+	stats = serveData[map[string]any](t, h.GetOverallStats, "/v2/stats")
+	assert.Equal(t, 123456789.0, stats["active_tvl"])
+	assert.NotContains(t, stats, "active_tvl_btc")
+}
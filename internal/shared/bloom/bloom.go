@@ -0,0 +1,65 @@
+// Package bloom provides a Bloom filter answering whether a key may have been
+// added, with no false negatives and a bounded rate of false positives.
+package bloom
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+type Filter struct {
+	mu     sync.RWMutex
+	bits   []uint64
+	m      uint64
+	hashes uint64
+}
+
+// New sizes the filter so that its false positive rate stays below the given
+// one while it holds up to expectedItems keys
+func New(expectedItems uint64, falsePositiveRate float64) *Filter {
+	n := float64(max(expectedItems, 1))
+	m := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	hashes := uint64(math.Round(float64(m) / n * math.Ln2))
+	return &Filter{
+		bits:   make([]uint64, (m+63)/64),
+		m:      m,
+		hashes: max(hashes, 1),
+	}
+}
+
+func (f *Filter) Add(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h1, h2 := f.baseHashes(key)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MayContain returns false if the key was definitely not added
+func (f *Filter) MayContain(key string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	h1, h2 := f.baseHashes(key)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// baseHashes derives the two hashes combined into the filter hashes of the key
+func (f *Filter) baseHashes(key string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h1 := h.Sum64()
+	// an odd step visits distinct bits whatever the filter size
+	h.Write([]byte{0})
+	h2 := h.Sum64() | 1
+	return h1, h2
+}
@@ -0,0 +1,30 @@
+package bloom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	const items = 10000
+	filter := New(items, 0.01)
+	for i := 0; i < items; i++ {
+		filter.Add(fmt.Sprintf("staker-%d", i))
+	}
+
+	// no false negatives
+	for i := 0; i < items; i++ {
+		assert.True(t, filter.MayContain(fmt.Sprintf("staker-%d", i)))
+	}
+
+	// the false positive rate stays close to the configured one
+	falsePositives := 0
+	for i := 0; i < items; i++ {
+		if filter.MayContain(fmt.Sprintf("unknown-%d", i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/items, 0.02)
+}
@@ -0,0 +1,20 @@
+package config
+
+import "fmt"
+
+// minAdminTokenLength keeps the admin token out of reach of guessing
+const minAdminTokenLength = 32
+
+// AdminConfig enables the admin endpoints, the internal endpoints changing
+// the state of the service or exposing per-staker data. Their requests have
+// to carry the token as a bearer token in the Authorization header.
+type AdminConfig struct {
+	Token string `mapstructure:"token"`
+}
+
+func (cfg *AdminConfig) Validate() error {
+	if len(cfg.Token) < minAdminTokenLength {
+		return fmt.Errorf("admin token must be at least %d characters long", minAdminTokenLength)
+	}
+	return nil
+}
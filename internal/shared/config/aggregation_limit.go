@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultAggregationCapacity = 8
+	defaultAggregationMaxWait  = 2 * time.Second
+	defaultAggregationWeight   = 1
+)
+
+// Names of the endpoints running aggregations, as used in the weights of the
+// aggregation limit config
+const (
+	V1StakingDurationAggregation              = "v1-staking-duration"
+	V1NewStakersAggregation                   = "v1-new-stakers"
+	V1InactiveFinalityProvidersAggregation    = "v1-inactive-finality-providers"
+	V1StakerCovenantExposureAggregation       = "v1-staker-covenant-exposure"
+	V1StakerBtcAtRiskAggregation              = "v1-staker-btc-at-risk"
+	V1CovenantResponseTimeAggregation         = "v1-covenant-response-time"
+	V1CoStakersAggregation                    = "v1-co-stakers"
+	V1FinalityProviderActiveStakeAggregation  = "v1-finality-provider-active-stake"
+	V1WithdrawalCompletionTimeAggregation     = "v1-withdrawal-completion-time"
+	V1FinalityProvidersByStakersAggregation   = "v1-finality-providers-by-stakers"
+	V1ProviderChurnAggregation                = "v1-provider-churn"
+	V1DelegationValueConcentrationAggregation = "v1-delegation-value-concentration"
+)
+
+var aggregationEndpoints = []string{
+	V1StakingDurationAggregation,
+	V1NewStakersAggregation,
+	V1InactiveFinalityProvidersAggregation,
+	V1StakerCovenantExposureAggregation,
+	V1StakerBtcAtRiskAggregation,
+	V1CovenantResponseTimeAggregation,
+	V1CoStakersAggregation,
+	V1FinalityProviderActiveStakeAggregation,
+	V1WithdrawalCompletionTimeAggregation,
+	V1FinalityProvidersByStakersAggregation,
+	V1ProviderChurnAggregation,
+	V1DelegationValueConcentrationAggregation,
+}
+
+// AggregationLimitConfig bounds the aggregations running concurrently against
+// the database. Every aggregation holds its endpoint weight out of the
+// capacity while it runs, an aggregation waiting longer than max-wait for its
+// weight is rejected. The defaults are used when not set.
+type AggregationLimitConfig struct {
+	Capacity int64         `mapstructure:"capacity"`
+	MaxWait  time.Duration `mapstructure:"max-wait"`
+	// Weights overrides the default weight of 1 of the given endpoints
+	Weights map[string]int64 `mapstructure:"weights"`
+}
+
+func (cfg *AggregationLimitConfig) Validate() error {
+	if cfg.Capacity < 0 {
+		return errors.New("aggregation limit capacity cannot be negative")
+	}
+	if cfg.MaxWait < 0 {
+		return errors.New("aggregation limit max-wait cannot be negative")
+	}
+	for endpoint, weight := range cfg.Weights {
+		if !isAggregationEndpoint(endpoint) {
+			return fmt.Errorf("unknown aggregation endpoint %q", endpoint)
+		}
+		if weight <= 0 {
+			return fmt.Errorf("aggregation weight of %q must be positive", endpoint)
+		}
+		if weight > cfg.GetCapacity() {
+			return fmt.Errorf("aggregation weight of %q cannot exceed the capacity", endpoint)
+		}
+	}
+	return nil
+}
+
+// GetCapacity returns the total weight of the aggregations allowed to run
+// concurrently
+func (cfg *AggregationLimitConfig) GetCapacity() int64 {
+	if cfg == nil || cfg.Capacity == 0 {
+		return defaultAggregationCapacity
+	}
+	return cfg.Capacity
+}
+
+// GetMaxWait returns how long an aggregation waits for capacity before being
+// rejected
+func (cfg *AggregationLimitConfig) GetMaxWait() time.Duration {
+	if cfg == nil || cfg.MaxWait == 0 {
+		return defaultAggregationMaxWait
+	}
+	return cfg.MaxWait
+}
+
+// Weight returns the share of the capacity held by the aggregation of the
+// endpoint
+func (cfg *AggregationLimitConfig) Weight(endpoint string) int64 {
+	if cfg == nil {
+		return defaultAggregationWeight
+	}
+	if weight, ok := cfg.Weights[endpoint]; ok {
+		return weight
+	}
+	return defaultAggregationWeight
+}
+
+func isAggregationEndpoint(endpoint string) bool {
+	for _, e := range aggregationEndpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
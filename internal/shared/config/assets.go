@@ -1,10 +1,20 @@
 package config
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// maxUTXOsLimit is the largest number of UTXOs a single request may ask to
+// verify, regardless of the configured max_utxos
+const maxUTXOsLimit = 50
 
 type AssetsConfig struct {
 	MaxUTXOs uint32          `mapstructure:"max_utxos"`
 	Ordinals *OrdinalsConfig `mapstructure:"ordinals"`
+	// SecondaryOrdinals is optional, it is queried when the primary ordinals
+	// service fails
+	SecondaryOrdinals *OrdinalsConfig `mapstructure:"secondary_ordinals"`
 }
 
 func (cfg *AssetsConfig) Validate() error {
@@ -12,9 +22,19 @@ func (cfg *AssetsConfig) Validate() error {
 		return err
 	}
 
+	if cfg.SecondaryOrdinals != nil {
+		if err := cfg.SecondaryOrdinals.Validate(); err != nil {
+			return fmt.Errorf("secondary ordinals: %w", err)
+		}
+	}
+
 	if cfg.MaxUTXOs <= 0 {
 		return errors.New("max_utxos cannot be smaller or equal to 0")
 	}
 
+	if cfg.MaxUTXOs > maxUTXOsLimit {
+		return fmt.Errorf("max_utxos cannot be greater than %d", maxUTXOsLimit)
+	}
+
 	return nil
 }
@@ -0,0 +1,18 @@
+package config
+
+import "errors"
+
+// BloomFilterConfig enables the filter of the phase-1 stakers, the staker
+// lookups skip the database for the stakers definitely without delegations.
+type BloomFilterConfig struct {
+	// FalsePositiveRate is the share of the unknown stakers still looked up
+	// in the database
+	FalsePositiveRate float64 `mapstructure:"false-positive-rate"`
+}
+
+func (cfg *BloomFilterConfig) Validate() error {
+	if cfg.FalsePositiveRate <= 0 || cfg.FalsePositiveRate >= 1 {
+		return errors.New("bloom filter false-positive-rate must be within (0, 1)")
+	}
+	return nil
+}
@@ -17,6 +17,21 @@ type Config struct {
 	Metrics              *MetricsConfig              `mapstructure:"metrics"`
 	Assets               *AssetsConfig               `mapstructure:"assets"`
 	DelegationTransition *DelegationTransitionConfig `mapstructure:"delegation-transition"`
+	DryRun               *DryRunConfig               `mapstructure:"dry-run"`
+	UnbondingIntegrity   *UnbondingIntegrityConfig   `mapstructure:"unbonding-integrity"`
+	FpLogoProxy          *FpLogoProxyConfig          `mapstructure:"fp-logo-proxy"`
+	EventGapDetection    *EventGapDetectionConfig    `mapstructure:"event-gap-detection"`
+	QueueRetryBackoff    *QueueRetryBackoffConfig    `mapstructure:"queue-retry-backoff"`
+	QueueThrottle        *QueueThrottleConfig        `mapstructure:"queue-throttle"`
+	QueueConsumers       *QueueConsumersConfig       `mapstructure:"queue-consumers"`
+	BloomFilter          *BloomFilterConfig          `mapstructure:"bloom-filter"`
+	RateLimit            *RateLimitConfig            `mapstructure:"rate-limit"`
+	ResponseCache        *ResponseCacheConfig        `mapstructure:"response-cache"`
+	UnbondingEstimate    *UnbondingEstimateConfig    `mapstructure:"unbonding-estimate"`
+	AggregationLimit     *AggregationLimitConfig     `mapstructure:"aggregation-limit"`
+	Debug                *DebugConfig                `mapstructure:"debug"`
+	Admin                *AdminConfig                `mapstructure:"admin"`
+	RequestTimeout       *RequestTimeoutConfig       `mapstructure:"request-timeout"`
 }
 
 func (cfg *Config) Validate() error {
@@ -28,6 +43,10 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	if err := cfg.StakingDb.validatePaginationTokenSecret(); err != nil {
+		return err
+	}
+
 	if err := cfg.IndexerDb.Validate(); err != nil {
 		return err
 	}
@@ -53,6 +72,114 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	// DryRun is optional
+	if cfg.DryRun != nil {
+		if err := cfg.DryRun.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// UnbondingIntegrity is optional
+	if cfg.UnbondingIntegrity != nil {
+		if err := cfg.UnbondingIntegrity.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// FpLogoProxy is optional, logos are served by redirect when not set
+	if cfg.FpLogoProxy != nil {
+		if err := cfg.FpLogoProxy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// EventGapDetection is optional
+	if cfg.EventGapDetection != nil {
+		if err := cfg.EventGapDetection.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// QueueRetryBackoff is optional, failed messages are requeued right away when not set
+	if cfg.QueueRetryBackoff != nil {
+		if err := cfg.QueueRetryBackoff.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// QueueThrottle is optional, the messages are consumed at full speed
+	// unless throttled manually when not set
+	if cfg.QueueThrottle != nil {
+		if err := cfg.QueueThrottle.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// QueueConsumers is optional, every queue processes one message at a time
+	// when not set
+	if cfg.QueueConsumers != nil {
+		if err := cfg.QueueConsumers.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// BloomFilter is optional, every staker lookup queries the database when
+	// not set
+	if cfg.BloomFilter != nil {
+		if err := cfg.BloomFilter.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// RateLimit is optional, requests are not limited when not set
+	if cfg.RateLimit != nil {
+		if err := cfg.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// ResponseCache is optional, the responses are cached with the default ttl when not set
+	if cfg.ResponseCache != nil {
+		if err := cfg.ResponseCache.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// UnbondingEstimate is optional, the defaults are used when not set
+	if cfg.UnbondingEstimate != nil {
+		if err := cfg.UnbondingEstimate.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// AggregationLimit is optional, the defaults are used when not set
+	if cfg.AggregationLimit != nil {
+		if err := cfg.AggregationLimit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// RequestTimeout is optional, the defaults are used when not set
+	if cfg.RequestTimeout != nil {
+		if err := cfg.RequestTimeout.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Debug is optional, the debug endpoints are disabled when not set
+	if cfg.Debug != nil {
+		if err := cfg.Debug.Validate(cfg.Server.BTCNet); err != nil {
+			return err
+		}
+	}
+
+	// Admin is optional, the admin endpoints are disabled when not set
+	if cfg.Admin != nil {
+		if err := cfg.Admin.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
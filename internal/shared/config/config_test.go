@@ -0,0 +1,37 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShippedConfigs checks every config file shipped in the config directory
+// parses and passes the validation
+func TestShippedConfigs(t *testing.T) {
+	cfgFiles, err := filepath.Glob("../../../config/config-*.yml")
+	require.NoError(t, err)
+	require.NotEmpty(t, cfgFiles)
+
+	for _, cfgFile := range cfgFiles {
+		t.Run(filepath.Base(cfgFile), func(t *testing.T) {
+			cfg, err := New(cfgFile)
+			require.NoError(t, err)
+			assert.NotNil(t, cfg.Server)
+		})
+	}
+}
+
+func TestPaginationTokenSecretValidation(t *testing.T) {
+	cfg, err := New("../../../config/config-local.yml")
+	require.NoError(t, err)
+
+	for _, secret := range []string{"", placeholderPaginationTokenSecret} {
+		cfg.StakingDb.PaginationTokenSecret = secret
+		assert.ErrorContains(t, cfg.Validate(), "pagination token secret", "secret %q", secret)
+	}
+	cfg.StakingDb.PaginationTokenSecret = "secret"
+	assert.NoError(t, cfg.Validate())
+}
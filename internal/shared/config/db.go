@@ -4,10 +4,16 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 const (
-	maxLogicalShardCount = 100
+	maxLogicalShardCount      = 100
+	defaultPaginationTokenTtl = time.Hour
+	defaultMaxInListSize      = 1000
+	// placeholderPaginationTokenSecret is the value the example configs used
+	// to ship with, it must be replaced by a secret of the deployment
+	placeholderPaginationTokenSecret = "example"
 )
 
 type DbConfig struct {
@@ -17,6 +23,13 @@ type DbConfig struct {
 	Address            string `mapstructure:"address"`
 	MaxPaginationLimit int64  `mapstructure:"max-pagination-limit"`
 	LogicalShardCount  *int64 `mapstructure:"logical-shard-count"`
+	// PaginationTokenSecret signs the pagination tokens of the endpoints
+	// supporting them, it is required for the staking db
+	PaginationTokenSecret string        `mapstructure:"pagination-token-secret"`
+	PaginationTokenTtl    time.Duration `mapstructure:"pagination-token-ttl"`
+	// MaxInListSize is the maximum number of values of an $in filter, the
+	// lookups of larger lists are rejected
+	MaxInListSize int `mapstructure:"max-in-list-size"`
 }
 
 func (cfg *DbConfig) Validate() error {
@@ -67,6 +80,14 @@ func (cfg *DbConfig) Validate() error {
 		return fmt.Errorf("max pagination limit must be greater than 1")
 	}
 
+	if cfg.PaginationTokenTtl < 0 {
+		return fmt.Errorf("pagination token ttl cannot be negative")
+	}
+
+	if cfg.MaxInListSize < 0 {
+		return fmt.Errorf("max in list size cannot be negative")
+	}
+
 	if cfg.LogicalShardCount != nil {
 		if *cfg.LogicalShardCount <= 1 {
 			return fmt.Errorf("logical shard count must be greater than 1")
@@ -81,3 +102,31 @@ func (cfg *DbConfig) Validate() error {
 
 	return nil
 }
+
+// validatePaginationTokenSecret checks the secret signing the pagination
+// tokens is set, the tokens are never left unsigned
+func (cfg *DbConfig) validatePaginationTokenSecret() error {
+	switch cfg.PaginationTokenSecret {
+	case "":
+		return fmt.Errorf("missing pagination token secret")
+	case placeholderPaginationTokenSecret:
+		return fmt.Errorf("pagination token secret must not be left at the placeholder %q", placeholderPaginationTokenSecret)
+	}
+	return nil
+}
+
+// GetPaginationTokenTtl returns how long a signed pagination token stays valid
+func (cfg *DbConfig) GetPaginationTokenTtl() time.Duration {
+	if cfg.PaginationTokenTtl == 0 {
+		return defaultPaginationTokenTtl
+	}
+	return cfg.PaginationTokenTtl
+}
+
+// GetMaxInListSize returns the maximum number of values of an $in filter
+func (cfg *DbConfig) GetMaxInListSize() int {
+	if cfg.MaxInListSize == 0 {
+		return defaultMaxInListSize
+	}
+	return cfg.MaxInListSize
+}
@@ -0,0 +1,20 @@
+package config
+
+import "errors"
+
+// DebugConfig enables the endpoints helping integrators to debug their
+// requests. They expose internals of the verification and are refused on
+// mainnet. Like the admin endpoints, they are only served when an admin token
+// is configured and to the requests carrying it.
+type DebugConfig struct {
+	// UnbondingSighash enables the endpoint returning the sighash an unbonding
+	// signature is verified against
+	UnbondingSighash bool `mapstructure:"unbonding-sighash"`
+}
+
+func (cfg *DebugConfig) Validate(btcNet string) error {
+	if cfg.UnbondingSighash && btcNet == "mainnet" {
+		return errors.New("debug endpoints cannot be enabled on mainnet")
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugConfigValidate(t *testing.T) {
+	enabled := &DebugConfig{UnbondingSighash: true}
+	assert.NoError(t, enabled.Validate("signet"))
+	assert.EqualError(t, enabled.Validate("mainnet"), "debug endpoints cannot be enabled on mainnet")
+
+	disabled := &DebugConfig{}
+	assert.NoError(t, disabled.Validate("mainnet"))
+}
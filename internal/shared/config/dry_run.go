@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+
+	queueclient "github.com/babylonlabs-io/staking-queue-client/client"
+)
+
+// DryRunConfig lists the queues whose handlers start in dry-run mode.
+// In dry-run mode the messages are run through a dry-run handler instead of
+// the live one, which records the writes it would have performed without
+// applying them.
+type DryRunConfig struct {
+	Queues []string `mapstructure:"queues"`
+}
+
+func (cfg *DryRunConfig) Validate() error {
+	for _, queueName := range cfg.Queues {
+		if !isStakingQueueName(queueName) {
+			return fmt.Errorf("unknown dry-run queue: %s", queueName)
+		}
+	}
+
+	return nil
+}
+
+func isStakingQueueName(queueName string) bool {
+	switch queueName {
+	case queueclient.ActiveStakingQueueName,
+		queueclient.UnbondingStakingQueueName,
+		queueclient.WithdrawableStakingQueueName,
+		queueclient.WithdrawnStakingQueueName:
+		return true
+	default:
+		return false
+	}
+}
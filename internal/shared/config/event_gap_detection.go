@@ -0,0 +1,19 @@
+package config
+
+import "errors"
+
+// EventGapDetectionConfig configures the detection of events the indexer
+// may have skipped while publishing to the queues.
+type EventGapDetectionConfig struct {
+	// MaxHeightGap is the number of blocks an event height can be ahead of
+	// the highest height seen on its queue before the jump is inspected
+	MaxHeightGap uint64 `mapstructure:"max-height-gap"`
+}
+
+func (cfg *EventGapDetectionConfig) Validate() error {
+	if cfg.MaxHeightGap == 0 {
+		return errors.New("event gap detection max-height-gap must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxFpLogoSizeBytes is the upper bound of the finality provider logo size
+const MaxFpLogoSizeBytes = 256 * 1024
+
+// FpLogoProxyConfig enables serving the finality provider logos from the API
+// instead of redirecting to the URL supplied by the finality provider.
+// The fetched images are cached in memory for CacheTtl.
+type FpLogoProxyConfig struct {
+	Timeout      time.Duration `mapstructure:"timeout"`
+	MaxSizeBytes int64         `mapstructure:"max-size-bytes"`
+	CacheTtl     time.Duration `mapstructure:"cache-ttl"`
+}
+
+func (cfg *FpLogoProxyConfig) Validate() error {
+	if cfg.Timeout <= 0 {
+		return errors.New("fp logo proxy timeout must be positive")
+	}
+
+	if cfg.MaxSizeBytes <= 0 || cfg.MaxSizeBytes > MaxFpLogoSizeBytes {
+		return errors.New("fp logo proxy max-size-bytes must be between 1 and 262144")
+	}
+
+	if cfg.CacheTtl < 0 {
+		return errors.New("fp logo proxy cache-ttl cannot be negative")
+	}
+
+	return nil
+}
@@ -3,14 +3,20 @@ package config
 import (
 	"fmt"
 	"net"
+	"time"
 )
 
+const defaultDelegationCountInterval = time.Minute
+
 // MetricsConfig defines the server's metric configuration
 type MetricsConfig struct {
 	// IP of the prometheus server
 	Host string `mapstructure:"host"`
 	// Port of the prometheus server
 	Port int `mapstructure:"port"`
+	// DelegationCountInterval is how often the delegation counts per state
+	// are refreshed from the db, every minute if not set
+	DelegationCountInterval time.Duration `mapstructure:"delegation-count-interval"`
 }
 
 func (cfg *MetricsConfig) Validate() error {
@@ -23,6 +29,10 @@ func (cfg *MetricsConfig) Validate() error {
 		return fmt.Errorf("invalid metrics server host: %v", cfg.Host)
 	}
 
+	if cfg.DelegationCountInterval < 0 {
+		return fmt.Errorf("metrics delegation count interval must not be negative")
+	}
+
 	return nil
 }
 
@@ -30,9 +40,17 @@ func (cfg *MetricsConfig) GetMetricsPort() int {
 	return cfg.Port
 }
 
+func (cfg *MetricsConfig) GetDelegationCountInterval() time.Duration {
+	if cfg.DelegationCountInterval == 0 {
+		return defaultDelegationCountInterval
+	}
+	return cfg.DelegationCountInterval
+}
+
 func DefaultMetricsConfig() MetricsConfig {
 	return MetricsConfig{
-		Host: "0.0.0.0",
-		Port: 2112,
+		Host:                    "0.0.0.0",
+		Port:                    2112,
+		DelegationCountInterval: defaultDelegationCountInterval,
 	}
 }
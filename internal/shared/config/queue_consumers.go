@@ -0,0 +1,35 @@
+package config
+
+import "fmt"
+
+// QueueConsumersConfig sets how the messages of each queue are consumed
+type QueueConsumersConfig struct {
+	// Workers is the number of messages processed in parallel per queue name.
+	// Messages of the same delegation are never processed in parallel. The
+	// queues not listed process one message at a time.
+	Workers map[string]int `mapstructure:"workers"`
+}
+
+func (cfg *QueueConsumersConfig) Validate() error {
+	for queueName, workers := range cfg.Workers {
+		if !isStakingQueueName(queueName) {
+			return fmt.Errorf("unknown queue consumers queue: %s", queueName)
+		}
+		if workers <= 0 {
+			return fmt.Errorf("queue consumers workers of %s must be positive", queueName)
+		}
+	}
+	return nil
+}
+
+// GetWorkers returns the number of messages of the queue processed in
+// parallel
+func (cfg *QueueConsumersConfig) GetWorkers(queueName string) int {
+	if cfg == nil {
+		return 1
+	}
+	if workers, ok := cfg.Workers[queueName]; ok {
+		return workers
+	}
+	return 1
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// QueueRetryBackoffConfig delays the requeue of a failed message
+// exponentially with its retry attempts, on top of the requeue delay of the
+// queue. The max attempts are configured by the queue msg_max_retry_attempts.
+type QueueRetryBackoffConfig struct {
+	// BaseDelay is the delay before the first retry, doubled on every attempt
+	BaseDelay time.Duration `mapstructure:"base-delay"`
+	// MaxDelay caps the delay between two attempts
+	MaxDelay time.Duration `mapstructure:"max-delay"`
+}
+
+func (cfg *QueueRetryBackoffConfig) Validate() error {
+	if cfg.BaseDelay <= 0 {
+		return errors.New("queue retry backoff base-delay must be positive")
+	}
+
+	if cfg.MaxDelay < cfg.BaseDelay {
+		return errors.New("queue retry backoff max-delay must not be lower than base-delay")
+	}
+
+	return nil
+}
+
+// Delay returns the backoff before requeuing a message that failed the
+// given number of previous attempts
+func (cfg *QueueRetryBackoffConfig) Delay(attempts int32) time.Duration {
+	delay := cfg.BaseDelay
+	for i := int32(0); i < attempts; i++ {
+		if delay >= cfg.MaxDelay/2 {
+			return cfg.MaxDelay
+		}
+		delay *= 2
+	}
+	return delay
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+const defaultQueueThrottleMaxDelay = 5 * time.Second
+
+// QueueThrottleConfig slows down the consumption of the queue messages while
+// their processing shows the database is degraded. The health is evaluated
+// every window of processed messages: when the share of messages failing
+// with a 5xx error exceeds max-error-rate, or the p99 processing time exceeds
+// max-p99-latency, the throttle factor is halved. Otherwise it is restored by
+// a tenth, up to full speed. Consumers wait (1 - factor) * max-delay between
+// two messages.
+type QueueThrottleConfig struct {
+	Window        int           `mapstructure:"window"`
+	MaxErrorRate  float64       `mapstructure:"max-error-rate"`
+	MaxP99Latency time.Duration `mapstructure:"max-p99-latency"`
+	MaxDelay      time.Duration `mapstructure:"max-delay"`
+}
+
+func (cfg *QueueThrottleConfig) Validate() error {
+	if cfg.Window <= 0 {
+		return errors.New("queue throttle window must be positive")
+	}
+	if cfg.MaxErrorRate <= 0 || cfg.MaxErrorRate > 1 {
+		return errors.New("queue throttle max-error-rate must be within (0, 1]")
+	}
+	if cfg.MaxP99Latency <= 0 {
+		return errors.New("queue throttle max-p99-latency must be positive")
+	}
+	if cfg.MaxDelay <= 0 {
+		return errors.New("queue throttle max-delay must be positive")
+	}
+	return nil
+}
+
+// GetMaxDelay returns the delay between two messages when fully throttled,
+// it applies to a manual throttle even if the adaptive one is not configured
+func (cfg *QueueThrottleConfig) GetMaxDelay() time.Duration {
+	if cfg == nil {
+		return defaultQueueThrottleMaxDelay
+	}
+	return cfg.MaxDelay
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RateLimitConfig limits the requests of every client IP per route with a
+// token bucket. POST routes are limited by default, GET routes only if a
+// default GET limit or a limit for their route is set.
+type RateLimitConfig struct {
+	// DefaultPostLimit applies to the POST routes without their own limit
+	DefaultPostLimit RateLimit `mapstructure:"default-post-limit"`
+	// DefaultGetLimit applies to the GET routes without their own limit,
+	// they are not limited if it is not set
+	DefaultGetLimit *RateLimit `mapstructure:"default-get-limit"`
+	// Routes overrides the default limits of the given routes
+	Routes []RouteRateLimit `mapstructure:"routes"`
+	// TrustedProxies are the IPs or CIDRs of the proxies whose
+	// X-Forwarded-For header is trusted to carry the client IP
+	TrustedProxies []string `mapstructure:"trusted-proxies"`
+
+	trustedProxyNets []*net.IPNet
+}
+
+// RateLimit allows Requests per Period, which is also the burst size
+type RateLimit struct {
+	Requests int           `mapstructure:"requests"`
+	Period   time.Duration `mapstructure:"period"`
+}
+
+// RouteRateLimit is the limit of a route, identified by its method and
+// its path template as registered in the router
+type RouteRateLimit struct {
+	Method    string `mapstructure:"method"`
+	Route     string `mapstructure:"route"`
+	RateLimit `mapstructure:",squash"`
+}
+
+func (cfg *RateLimitConfig) Validate() error {
+	if err := cfg.DefaultPostLimit.validate(); err != nil {
+		return fmt.Errorf("invalid rate limit default-post-limit: %w", err)
+	}
+	if cfg.DefaultGetLimit != nil {
+		if err := cfg.DefaultGetLimit.validate(); err != nil {
+			return fmt.Errorf("invalid rate limit default-get-limit: %w", err)
+		}
+	}
+
+	for _, route := range cfg.Routes {
+		if route.Method != http.MethodGet && route.Method != http.MethodPost {
+			return fmt.Errorf("invalid rate limit method %q, only GET and POST are supported", route.Method)
+		}
+		if !strings.HasPrefix(route.Route, "/") {
+			return fmt.Errorf("invalid rate limit route %q", route.Route)
+		}
+		if err := route.RateLimit.validate(); err != nil {
+			return fmt.Errorf("invalid rate limit of %s %s: %w", route.Method, route.Route, err)
+		}
+	}
+
+	cfg.trustedProxyNets = make([]*net.IPNet, 0, len(cfg.TrustedProxies))
+	for _, proxy := range cfg.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return fmt.Errorf("invalid rate limit trusted proxy: %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			cfg.trustedProxyNets = append(cfg.trustedProxyNets, &net.IPNet{
+				IP: ip, Mask: net.CIDRMask(bits, bits),
+			})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid rate limit trusted proxy: %s", proxy)
+		}
+		cfg.trustedProxyNets = append(cfg.trustedProxyNets, ipNet)
+	}
+
+	return nil
+}
+
+// Limit returns the limit of the route, nil if the route is not limited
+func (cfg *RateLimitConfig) Limit(method, route string) *RateLimit {
+	for i := range cfg.Routes {
+		if cfg.Routes[i].Method == method && cfg.Routes[i].Route == route {
+			return &cfg.Routes[i].RateLimit
+		}
+	}
+	switch method {
+	case http.MethodPost:
+		return &cfg.DefaultPostLimit
+	case http.MethodGet:
+		return cfg.DefaultGetLimit
+	default:
+		return nil
+	}
+}
+
+// IsTrustedProxy returns true if the X-Forwarded-For header set by the
+// given IP can be trusted
+func (cfg *RateLimitConfig) IsTrustedProxy(ip net.IP) bool {
+	for _, ipNet := range cfg.trustedProxyNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *RateLimit) validate() error {
+	if l.Requests <= 0 {
+		return errors.New("requests must be positive")
+	}
+	if l.Period <= 0 {
+		return errors.New("period must be positive")
+	}
+	return nil
+}
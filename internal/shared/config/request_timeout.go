@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultGetRequestTimeout  = 10 * time.Second
+	defaultPostRequestTimeout = 30 * time.Second
+)
+
+// RequestTimeoutConfig bounds the time a request is handled for per route,
+// the request context is cancelled and a 504 returned once it is reached.
+// The defaults are used when not set.
+type RequestTimeoutConfig struct {
+	// DefaultGetTimeout applies to the GET routes without their own timeout,
+	// 10s if not set
+	DefaultGetTimeout time.Duration `mapstructure:"default-get-timeout"`
+	// DefaultPostTimeout applies to the POST routes without their own
+	// timeout, 30s if not set
+	DefaultPostTimeout time.Duration `mapstructure:"default-post-timeout"`
+	// Routes overrides the default timeouts of the given routes
+	Routes []RouteRequestTimeout `mapstructure:"routes"`
+}
+
+// RouteRequestTimeout is the timeout of a route, identified by its method and
+// its path template as registered in the router
+type RouteRequestTimeout struct {
+	Method  string        `mapstructure:"method"`
+	Route   string        `mapstructure:"route"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+func (cfg *RequestTimeoutConfig) Validate() error {
+	if cfg.DefaultGetTimeout < 0 {
+		return errors.New("request timeout default-get-timeout cannot be negative")
+	}
+	if cfg.DefaultPostTimeout < 0 {
+		return errors.New("request timeout default-post-timeout cannot be negative")
+	}
+	for _, route := range cfg.Routes {
+		if route.Method != http.MethodGet && route.Method != http.MethodPost {
+			return fmt.Errorf("invalid request timeout method %q, only GET and POST are supported", route.Method)
+		}
+		if !strings.HasPrefix(route.Route, "/") {
+			return fmt.Errorf("invalid request timeout route %q", route.Route)
+		}
+		if route.Timeout <= 0 {
+			return fmt.Errorf("request timeout of %s %s must be positive", route.Method, route.Route)
+		}
+	}
+	return nil
+}
+
+// Timeout returns the timeout of the route
+func (cfg *RequestTimeoutConfig) Timeout(method, route string) time.Duration {
+	if cfg == nil {
+		if method == http.MethodPost {
+			return defaultPostRequestTimeout
+		}
+		return defaultGetRequestTimeout
+	}
+	for _, routeTimeout := range cfg.Routes {
+		if routeTimeout.Method == method && routeTimeout.Route == route {
+			return routeTimeout.Timeout
+		}
+	}
+	if method == http.MethodPost {
+		if cfg.DefaultPostTimeout == 0 {
+			return defaultPostRequestTimeout
+		}
+		return cfg.DefaultPostTimeout
+	}
+	if cfg.DefaultGetTimeout == 0 {
+		return defaultGetRequestTimeout
+	}
+	return cfg.DefaultGetTimeout
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultResponseCacheTtl is how long the cached responses are served when
+// no ttl is configured for their endpoint
+const DefaultResponseCacheTtl = 60 * time.Second
+
+// Names of the endpoints whose responses are cached, as used in the ttls of
+// the response cache config
+const (
+	V1OverallStatsCacheEndpoint      = "v1-overall-stats"
+	V1FinalityProvidersCacheEndpoint = "v1-finality-providers"
+	V2OverallStatsCacheEndpoint      = "v2-overall-stats"
+	V2FinalityProvidersCacheEndpoint = "v2-finality-providers"
+	// V1FinalityProviderActiveStakeCacheEndpoint caches the active stake
+	// timelines of the finality providers
+	V1FinalityProviderActiveStakeCacheEndpoint = "v1-finality-provider-active-stake"
+	// V1DelegationValueConcentrationCacheEndpoint caches the concentration
+	// of the staking values of the active delegations
+	V1DelegationValueConcentrationCacheEndpoint = "v1-delegation-value-concentration"
+)
+
+var responseCacheEndpoints = []string{
+	V1OverallStatsCacheEndpoint,
+	V1FinalityProvidersCacheEndpoint,
+	V2OverallStatsCacheEndpoint,
+	V2FinalityProvidersCacheEndpoint,
+	V1FinalityProviderActiveStakeCacheEndpoint,
+	V1DelegationValueConcentrationCacheEndpoint,
+}
+
+// endpointDefaultResponseCacheTtls are the default ttls of the endpoints
+// whose responses are costly to load and are not expected to be fresh
+var endpointDefaultResponseCacheTtls = map[string]time.Duration{
+	V1FinalityProviderActiveStakeCacheEndpoint:  15 * time.Minute,
+	V1DelegationValueConcentrationCacheEndpoint: 10 * time.Minute,
+}
+
+// ResponseCacheConfig sets how long the responses of the stats and finality
+// provider endpoints are cached in memory. The responses are cached for
+// DefaultResponseCacheTtl when not set, a ttl of 0 disables the cache of the
+// endpoint. The endpoints with their own default ttl only follow the ttls.
+type ResponseCacheConfig struct {
+	DefaultTtl *time.Duration `mapstructure:"default-ttl"`
+	// Ttls overrides the default ttl of the given endpoints
+	Ttls map[string]time.Duration `mapstructure:"ttls"`
+}
+
+func (cfg *ResponseCacheConfig) Validate() error {
+	if cfg.DefaultTtl != nil && *cfg.DefaultTtl < 0 {
+		return errors.New("response cache default-ttl cannot be negative")
+	}
+	for endpoint, ttl := range cfg.Ttls {
+		if !isResponseCacheEndpoint(endpoint) {
+			return fmt.Errorf("unknown response cache endpoint %q", endpoint)
+		}
+		if ttl < 0 {
+			return fmt.Errorf("response cache ttl of %q cannot be negative", endpoint)
+		}
+	}
+	return nil
+}
+
+// Ttl returns how long the responses of the endpoint are cached
+func (cfg *ResponseCacheConfig) Ttl(endpoint string) time.Duration {
+	if cfg != nil {
+		if ttl, ok := cfg.Ttls[endpoint]; ok {
+			return ttl
+		}
+	}
+	if ttl, ok := endpointDefaultResponseCacheTtls[endpoint]; ok {
+		return ttl
+	}
+	if cfg != nil && cfg.DefaultTtl != nil {
+		return *cfg.DefaultTtl
+	}
+	return DefaultResponseCacheTtl
+}
+
+func isResponseCacheEndpoint(endpoint string) bool {
+	for _, e := range responseCacheEndpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
@@ -11,6 +11,11 @@ import (
 	"github.com/rs/zerolog"
 )
 
+const (
+	defaultShutdownTimeout             = 30 * time.Second
+	defaultAdminUpdatesRefreshInterval = time.Minute
+)
+
 type ServerConfig struct {
 	Host                 string        `mapstructure:"host"`
 	Port                 int           `mapstructure:"port"`
@@ -22,6 +27,14 @@ type ServerConfig struct {
 	LogLevel             string        `mapstructure:"log-level"`
 	MaxContentLength     int64         `mapstructure:"max-content-length"`
 	HealthCheckInterval  int           `mapstructure:"health-check-interval"`
+	// ShutdownTimeout bounds each shutdown step: the draining of the in-flight
+	// HTTP requests, of the queue messages and the closing of the db clients,
+	// 30s if not set
+	ShutdownTimeout      time.Duration `mapstructure:"shutdown-timeout"`
+	// AdminUpdatesRefreshInterval is how often the state updated through the
+	// admin endpoints of any instance, e.g. the global params, is reloaded,
+	// every minute if not set
+	AdminUpdatesRefreshInterval time.Duration `mapstructure:"admin-updates-refresh-interval"`
 
 	BTCNetParam *chaincfg.Params
 }
@@ -48,6 +61,14 @@ func (cfg *ServerConfig) Validate() error {
 		return errors.New("idle timeout cannot be negative")
 	}
 
+	if cfg.ShutdownTimeout < 0 {
+		return errors.New("shutdown timeout cannot be negative")
+	}
+
+	if cfg.AdminUpdatesRefreshInterval < 0 {
+		return errors.New("admin updates refresh interval cannot be negative")
+	}
+
 	if cfg.MaxContentLength <= 0 {
 		return fmt.Errorf("MaxContentLength must be a positive integer")
 	}
@@ -66,6 +87,23 @@ func (cfg *ServerConfig) Validate() error {
 	return nil
 }
 
+// GetShutdownTimeout returns the deadline of the graceful shutdown
+func (cfg *ServerConfig) GetShutdownTimeout() time.Duration {
+	if cfg.ShutdownTimeout == 0 {
+		return defaultShutdownTimeout
+	}
+	return cfg.ShutdownTimeout
+}
+
+// GetAdminUpdatesRefreshInterval returns how often the state updated through
+// the admin endpoints is reloaded
+func (cfg *ServerConfig) GetAdminUpdatesRefreshInterval() time.Duration {
+	if cfg.AdminUpdatesRefreshInterval == 0 {
+		return defaultAdminUpdatesRefreshInterval
+	}
+	return cfg.AdminUpdatesRefreshInterval
+}
+
 func (cfg *ServerConfig) ValidateServerLogLevel() error {
 	// If log level is not set, we don't need to validate it, a default value will be used in service
 	if cfg.LogLevel == "" {
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	defaultConfirmationBufferBlocks = 6
+	defaultBtcBlockInterval         = 10 * time.Minute
+)
+
+// UnbondingEstimateConfig tunes the estimated completion of the unbonding of
+// the delegations. The defaults are used when not set.
+type UnbondingEstimateConfig struct {
+	// ConfirmationBufferBlocks is the number of blocks an unbonding tx is
+	// expected to wait before being confirmed
+	ConfirmationBufferBlocks uint64 `mapstructure:"confirmation-buffer-blocks"`
+	// BlockInterval is the expected time between two BTC blocks
+	BlockInterval time.Duration `mapstructure:"block-interval"`
+}
+
+func (cfg *UnbondingEstimateConfig) Validate() error {
+	if cfg.BlockInterval < 0 {
+		return errors.New("unbonding estimate block-interval cannot be negative")
+	}
+	return nil
+}
+
+// GetConfirmationBufferBlocks returns the number of blocks an unbonding tx is
+// expected to wait before being confirmed
+func (cfg *UnbondingEstimateConfig) GetConfirmationBufferBlocks() uint64 {
+	if cfg == nil || cfg.ConfirmationBufferBlocks == 0 {
+		return defaultConfirmationBufferBlocks
+	}
+	return cfg.ConfirmationBufferBlocks
+}
+
+// GetBlockInterval returns the expected time between two BTC blocks
+func (cfg *UnbondingEstimateConfig) GetBlockInterval() time.Duration {
+	if cfg == nil || cfg.BlockInterval == 0 {
+		return defaultBtcBlockInterval
+	}
+	return cfg.BlockInterval
+}
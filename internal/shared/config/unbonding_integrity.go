@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// UnbondingIntegrityConfig configures the background job verifying that the
+// recently inserted unbonding documents reference a delegation in a state
+// compatible with an unbonding request.
+type UnbondingIntegrityConfig struct {
+	// Interval between two integrity checks
+	Interval time.Duration `mapstructure:"interval"`
+	// LookbackPeriod limits each check to the unbonding documents inserted
+	// within this period
+	LookbackPeriod time.Duration `mapstructure:"lookback-period"`
+	// Quarantine marks the offending unbonding documents as skipped so that
+	// they are not picked up by the unbonding pipeline
+	Quarantine bool `mapstructure:"quarantine"`
+}
+
+func (cfg *UnbondingIntegrityConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("unbonding integrity interval must be positive")
+	}
+
+	if cfg.LookbackPeriod <= 0 {
+		return errors.New("unbonding integrity lookback-period must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// Leaves and inner nodes are hashed with distinct prefixes (RFC 6962) so an
+// inner node can never be presented as a leaf of the tree.
+const (
+	leafPrefix byte = 0x00
+	nodePrefix byte = 0x01
+)
+
+// ProofStep is a sibling hash on the path from a leaf to the root.
+// Left is true when the sibling is the left child of the parent node.
+type ProofStep struct {
+	Hash []byte
+	Left bool
+}
+
+// MerkleTree is a binary Merkle tree over a list of leaves. A node without a
+// sibling is promoted to the next level unchanged.
+type MerkleTree struct {
+	// levels[0] holds the leaf hashes, the last level holds the root
+	levels [][][]byte
+}
+
+// New builds a Merkle tree over the given leaves, in the given order
+func New(leaves [][]byte) (*MerkleTree, error) {
+	if len(leaves) == 0 {
+		return nil, errors.New("cannot build a merkle tree without leaves")
+	}
+
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = HashLeaf(leaf)
+	}
+
+	levels := [][][]byte{level}
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, hashNode(level[i], level[i+1]))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	return &MerkleTree{levels: levels}, nil
+}
+
+// Root returns the root hash of the tree
+func (t *MerkleTree) Root() []byte {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// LeafCount returns the number of leaves of the tree
+func (t *MerkleTree) LeafCount() int {
+	return len(t.levels[0])
+}
+
+// Proof returns the sibling hashes needed to recompute the root from the leaf
+// at the given index, ordered from the leaf level upwards
+func (t *MerkleTree) Proof(index int) ([]ProofStep, error) {
+	if index < 0 || index >= t.LeafCount() {
+		return nil, fmt.Errorf("leaf index %d out of range", index)
+	}
+
+	var proof []ProofStep
+	for _, level := range t.levels[:len(t.levels)-1] {
+		if index%2 == 1 {
+			proof = append(proof, ProofStep{Hash: level[index-1], Left: true})
+		} else if index+1 < len(level) {
+			proof = append(proof, ProofStep{Hash: level[index+1], Left: false})
+		}
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+// Verify checks that the leaf is included in the tree with the given root
+func Verify(root, leaf []byte, proof []ProofStep) bool {
+	hash := HashLeaf(leaf)
+	for _, step := range proof {
+		if step.Left {
+			hash = hashNode(step.Hash, hash)
+		} else {
+			hash = hashNode(hash, step.Hash)
+		}
+	}
+	return bytes.Equal(hash, root)
+}
+
+// HashLeaf returns the hash of a leaf as stored in the tree
+func HashLeaf(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func hashNode(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
@@ -0,0 +1,76 @@
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = []byte(fmt.Sprintf("leaf-%d", i))
+	}
+	return leaves
+}
+
+func TestMerkleTreeProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		leaves := buildLeaves(n)
+		tree, err := New(leaves)
+		require.NoError(t, err)
+		require.Equal(t, n, tree.LeafCount())
+
+		for i, leaf := range leaves {
+			proof, err := tree.Proof(i)
+			require.NoError(t, err)
+			assert.True(t, Verify(tree.Root(), leaf, proof), "leaf %d of %d", i, n)
+		}
+	}
+}
+
+func TestMerkleTreeTamperedProof(t *testing.T) {
+	leaves := buildLeaves(5)
+	tree, err := New(leaves)
+	require.NoError(t, err)
+
+	proof, err := tree.Proof(2)
+	require.NoError(t, err)
+	require.NotEmpty(t, proof)
+
+	t.Run("tampered sibling hash", func(t *testing.T) {
+		tampered := append([]ProofStep(nil), proof...)
+		hash := append([]byte(nil), tampered[0].Hash...)
+		hash[0] ^= 0xff
+		tampered[0].Hash = hash
+		assert.False(t, Verify(tree.Root(), leaves[2], tampered))
+	})
+
+	t.Run("flipped sibling position", func(t *testing.T) {
+		tampered := append([]ProofStep(nil), proof...)
+		tampered[0].Left = !tampered[0].Left
+		assert.False(t, Verify(tree.Root(), leaves[2], tampered))
+	})
+
+	t.Run("proof of another leaf", func(t *testing.T) {
+		assert.False(t, Verify(tree.Root(), leaves[3], proof))
+	})
+
+	t.Run("leaf not in the tree", func(t *testing.T) {
+		assert.False(t, Verify(tree.Root(), []byte("leaf-99"), proof))
+	})
+}
+
+func TestMerkleTreeErrors(t *testing.T) {
+	_, err := New(nil)
+	assert.Error(t, err)
+
+	tree, err := New(buildLeaves(3))
+	require.NoError(t, err)
+	_, err = tree.Proof(3)
+	assert.Error(t, err)
+	_, err = tree.Proof(-1)
+	assert.Error(t, err)
+}
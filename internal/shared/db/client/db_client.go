@@ -2,9 +2,11 @@ package dbclient
 
 import (
 	"context"
+	"sync"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -13,6 +15,9 @@ type Database struct {
 	DbName string
 	Client *mongo.Client
 	Cfg    *config.DbConfig
+
+	topologyMutex sync.Mutex
+	topology      string
 }
 
 func NewMongoClient(ctx context.Context, cfg *config.DbConfig) (*mongo.Client, error) {
@@ -40,3 +45,26 @@ func New(ctx context.Context, client *mongo.Client, cfg *config.DbConfig) (*Data
 		Cfg:    cfg,
 	}, nil
 }
+
+const (
+	MongoTopologyStandalone = "standalone"
+	MongoTopologyReplicaSet = "replica_set"
+	MongoTopologySharded    = "sharded"
+)
+
+// DetectMongoTopology tells whether the client is connected to a standalone
+// server, a replica set or a sharded cluster using the hello command
+func DetectMongoTopology(ctx context.Context, client *mongo.Client) (string, error) {
+	var hello bson.M
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := hello["setName"]; ok {
+		return MongoTopologyReplicaSet, nil
+	}
+	if msg, ok := hello["msg"].(string); ok && msg == "isdbgrid" {
+		return MongoTopologySharded, nil
+	}
+	return MongoTopologyStandalone, nil
+}
@@ -0,0 +1,19 @@
+package dbclient
+
+import (
+	"context"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+)
+
+func (db *Database) SaveDryRunShadowRecord(ctx context.Context, record *dbmodel.DryRunShadowRecordDocument) error {
+	client := db.Client.Database(db.DbName).Collection(dbmodel.DryRunShadowRecordCollection)
+
+	_, err := client.InsertOne(ctx, record)
+	if err != nil {
+		metrics.RecordDbError("save_dry_run_shadow_record")
+	}
+
+	return err
+}
@@ -0,0 +1,42 @@
+package dbclient
+
+import (
+	"context"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (db *Database) InsertGlobalParamsUpdate(ctx context.Context, update *dbmodel.GlobalParamsUpdateDocument) error {
+	client := db.Client.Database(db.DbName).Collection(dbmodel.GlobalParamsHistoryCollection)
+
+	_, err := client.InsertOne(ctx, update)
+	if err != nil {
+		metrics.RecordDbError("insert_global_params_update")
+	}
+
+	return err
+}
+
+func (db *Database) FindGlobalParamsUpdates(ctx context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(dbmodel.GlobalParamsHistoryCollection)
+	// the updates are applied in the order they were stored
+	options := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := client.Find(ctx, bson.M{}, options)
+	if err != nil {
+		metrics.RecordDbError("find_global_params_updates")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var updates []dbmodel.GlobalParamsUpdateDocument
+	if err = cursor.All(ctx, &updates); err != nil {
+		metrics.RecordDbError("find_global_params_updates")
+		return nil, err
+	}
+
+	return updates, nil
+}
@@ -6,18 +6,14 @@ import (
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 )
 
-//go:generate mockery --name=DBClient --output=../../../../tests/mocks --outpkg=mocks --filename=mock_db_client.go
-type DBClient interface {
+// DBReader holds the methods of DBClient that only read from the database
+type DBReader interface {
 	Ping(ctx context.Context) error
-	// InsertPkAddressMappings inserts the btc public key and
-	// its corresponding btc addresses into the database.
-	InsertPkAddressMappings(
-		ctx context.Context, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven string,
-	) error
 	// FindPkMappingsByTaprootAddress finds the PK address mappings by taproot address.
 	// The returned slice addressMapping will only contain documents for addresses
 	// that were found in the database. If some addresses do not have a matching
 	// document, those addresses will simply be absent from the result.
+	// It returns an InListTooLargeError if there are too many addresses.
 	FindPkMappingsByTaprootAddress(
 		ctx context.Context, taprootAddresses []string,
 	) ([]*dbmodel.PkAddressMapping, error)
@@ -26,10 +22,36 @@ type DBClient interface {
 	// documents for addresses that were found in the database.
 	// If some addresses do not have a matching document, those addresses will
 	// simply be absent from the result.
+	// It returns an InListTooLargeError if there are too many addresses.
 	FindPkMappingsByNativeSegwitAddress(
 		ctx context.Context, nativeSegwitAddresses []string,
 	) ([]*dbmodel.PkAddressMapping, error)
-	SaveUnprocessableMessage(ctx context.Context, messageBody, receipt string) error
 	FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error)
+	// FindGlobalParamsUpdates returns the updates of the global params in the
+	// order they were stored.
+	FindGlobalParamsUpdates(ctx context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error)
+}
+
+//go:generate mockery --name=DBClient --output=../../../../tests/mocks --outpkg=mocks --filename=mock_db_client.go
+type DBClient interface {
+	DBReader
+	// WithTransaction runs fn in a multi-document transaction, or joins the
+	// one ctx already runs in. fn runs without a transaction on a standalone
+	// server.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// InsertPkAddressMappings inserts the btc public key and
+	// its corresponding btc addresses into the database.
+	InsertPkAddressMappings(
+		ctx context.Context, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven string,
+	) error
+	SaveUnprocessableMessage(
+		ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+	) error
 	DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error
+	// SaveDryRunShadowRecord stores the writes a queue handler would have
+	// performed while running in dry-run mode.
+	SaveDryRunShadowRecord(ctx context.Context, record *dbmodel.DryRunShadowRecordDocument) error
+	// InsertGlobalParamsUpdate stores an update of the global params into the
+	// global params history.
+	InsertGlobalParamsUpdate(ctx context.Context, update *dbmodel.GlobalParamsUpdateDocument) error
 }
@@ -3,6 +3,7 @@ package dbclient
 import (
 	"context"
 
+	shareddb "github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
 	"go.mongodb.org/mongo-driver/bson"
@@ -30,6 +31,9 @@ func (db *Database) InsertPkAddressMappings(
 func (db *Database) FindPkMappingsByTaprootAddress(
 	ctx context.Context, taprootAddresses []string,
 ) ([]*dbmodel.PkAddressMapping, error) {
+	if err := shareddb.CheckInListSize(len(taprootAddresses), db.Cfg.GetMaxInListSize()); err != nil {
+		return nil, err
+	}
 	client := db.Client.Database(db.DbName).Collection(dbmodel.PkAddressMappingsCollection)
 	filter := bson.M{"taproot": bson.M{"$in": taprootAddresses}}
 
@@ -50,6 +54,9 @@ func (db *Database) FindPkMappingsByTaprootAddress(
 func (db *Database) FindPkMappingsByNativeSegwitAddress(
 	ctx context.Context, nativeSegwitAddresses []string,
 ) ([]*dbmodel.PkAddressMapping, error) {
+	if err := shareddb.CheckInListSize(len(nativeSegwitAddresses), db.Cfg.GetMaxInListSize()); err != nil {
+		return nil, err
+	}
 	client := db.Client.Database(db.DbName).Collection(dbmodel.PkAddressMappingsCollection)
 	filter := bson.M{
 		"$or": []bson.M{
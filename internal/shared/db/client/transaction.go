@@ -0,0 +1,45 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WithTransaction runs fn in a multi-document transaction, the driver retries
+// it and its commit on transient errors. It joins the transaction ctx already
+// runs in, if any. On a standalone server, which does not support
+// transactions, fn runs as is and its writes must be idempotent on their own.
+func (db *Database) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil || !db.supportsTransactions(ctx) {
+		return fn(ctx)
+	}
+
+	session, err := db.Client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// supportsTransactions detects the topology of the deployment once. Failing
+// to detect it is not cached and assumes transactions are supported.
+func (db *Database) supportsTransactions(ctx context.Context) bool {
+	db.topologyMutex.Lock()
+	defer db.topologyMutex.Unlock()
+	if db.topology == "" {
+		topology, err := DetectMongoTopology(ctx, db.Client)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to detect the mongo topology, assuming transactions are supported")
+			return true
+		}
+		db.topology = topology
+	}
+	return db.topology != MongoTopologyStandalone
+}
@@ -2,6 +2,7 @@ package dbclient
 
 import (
 	"context"
+	"time"
 
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
@@ -9,10 +10,14 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (db *Database) SaveUnprocessableMessage(ctx context.Context, messageBody, receipt string) error {
+func (db *Database) SaveUnprocessableMessage(
+	ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+) error {
 	unprocessableMsgClient := db.Client.Database(db.DbName).Collection(dbmodel.V1UnprocessableMsgCollection)
 
-	_, err := unprocessableMsgClient.InsertOne(ctx, dbmodel.NewUnprocessableMessageDocument(messageBody, receipt))
+	_, err := unprocessableMsgClient.InsertOne(ctx, dbmodel.NewUnprocessableMessageDocument(
+		queueName, messageBody, receipt, processingErr, retryAttempts, time.Now().Unix(),
+	))
 	if err != nil {
 		metrics.RecordDbError("save_unprocessable_message")
 	}
@@ -23,7 +28,8 @@ func (db *Database) SaveUnprocessableMessage(ctx context.Context, messageBody, r
 func (db *Database) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
 	client := db.Client.Database(db.DbName).Collection(dbmodel.V1UnprocessableMsgCollection)
 	filter := bson.M{}
-	options := options.FindOptions{}
+	// oldest first, so the messages are inspected and replayed in the order they failed
+	options := options.FindOptions{Sort: bson.D{{Key: "created_at", Value: 1}}}
 
 	cursor, err := client.Find(ctx, filter, &options)
 	if err != nil {
@@ -62,3 +62,14 @@ func New(ctx context.Context, cfg *config.Config) (*DbClients, error) {
 
 	return &dbClients, nil
 }
+
+// Close disconnects the staking and indexer mongo clients
+func (c *DbClients) Close(ctx context.Context) error {
+	if err := c.StakingMongoClient.Disconnect(ctx); err != nil {
+		return fmt.Errorf("error while disconnecting staking mongo client: %w", err)
+	}
+	if err := c.IndexerMongoClient.Disconnect(ctx); err != nil {
+		return fmt.Errorf("error while disconnecting indexer mongo client: %w", err)
+	}
+	return nil
+}
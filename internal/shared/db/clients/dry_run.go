@@ -0,0 +1,414 @@
+package dbclients
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v2dbclient "github.com/babylonlabs-io/staking-api-service/internal/v2/db/client"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+)
+
+// ErrDryRunUnsupported is returned by the dry-run writes whose result can
+// not be made up without applying them
+var ErrDryRunUnsupported = errors.New("write not supported in dry-run")
+
+// DryRunWrites collects the writes skipped by the dry-run db clients, in the
+// order they were called
+type DryRunWrites struct {
+	mu     sync.Mutex
+	writes []string
+}
+
+type dryRunWritesKey struct{}
+
+// WithDryRunWrites returns a context whose writes through the dry-run db
+// clients are collected into the returned DryRunWrites
+func WithDryRunWrites(ctx context.Context) (context.Context, *DryRunWrites) {
+	writes := &DryRunWrites{}
+	return context.WithValue(ctx, dryRunWritesKey{}, writes), writes
+}
+
+// Writes returns the names of the skipped writes, prefixed by their client
+func (w *DryRunWrites) Writes() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.writes...)
+}
+
+// recordDryRunWrite adds the write to the ones collected for ctx, it is
+// dropped if ctx collects none
+func recordDryRunWrite(ctx context.Context, write string) {
+	writes, ok := ctx.Value(dryRunWritesKey{}).(*DryRunWrites)
+	if !ok {
+		return
+	}
+	writes.mu.Lock()
+	defer writes.mu.Unlock()
+	writes.writes = append(writes.writes, write)
+}
+
+// NewDryRun returns db clients reading from the given ones that never write:
+// every write is recorded into the DryRunWrites of its context and reported
+// as successful. The stats locks are read without being created, and the
+// transactions run their function right away.
+// Only the reader part of the given clients is kept, so a write method added
+// to the clients does not compile until it is recorded here as well.
+func NewDryRun(dbClients *DbClients) *DbClients {
+	return &DbClients{
+		SharedDBClient:  &dryRunDBClient{DBReader: dbClients.SharedDBClient},
+		V1DBClient:      &dryRunV1DBClient{V1DBReader: dbClients.V1DBClient},
+		V2DBClient:      &dryRunV2DBClient{V2DBReader: dbClients.V2DBClient},
+		IndexerDBClient: dbClients.IndexerDBClient,
+	}
+}
+
+var (
+	_ dbclient.DBClient     = (*dryRunDBClient)(nil)
+	_ v1dbclient.V1DBClient = (*dryRunV1DBClient)(nil)
+	_ v2dbclient.V2DBClient = (*dryRunV2DBClient)(nil)
+)
+
+type dryRunDBClient struct {
+	dbclient.DBReader
+}
+
+func (c *dryRunDBClient) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (c *dryRunDBClient) InsertPkAddressMappings(
+	ctx context.Context, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven string,
+) error {
+	recordDryRunWrite(ctx, "shared.InsertPkAddressMappings")
+	return nil
+}
+
+func (c *dryRunDBClient) SaveUnprocessableMessage(
+	ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+) error {
+	recordDryRunWrite(ctx, "shared.SaveUnprocessableMessage")
+	return nil
+}
+
+func (c *dryRunDBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
+	recordDryRunWrite(ctx, "shared.DeleteUnprocessableMessage")
+	return nil
+}
+
+func (c *dryRunDBClient) SaveDryRunShadowRecord(ctx context.Context, record *dbmodel.DryRunShadowRecordDocument) error {
+	recordDryRunWrite(ctx, "shared.SaveDryRunShadowRecord")
+	return nil
+}
+
+func (c *dryRunDBClient) InsertGlobalParamsUpdate(ctx context.Context, update *dbmodel.GlobalParamsUpdateDocument) error {
+	recordDryRunWrite(ctx, "shared.InsertGlobalParamsUpdate")
+	return nil
+}
+
+type dryRunV1DBClient struct {
+	v1dbclient.V1DBReader
+}
+
+func (c *dryRunV1DBClient) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (c *dryRunV1DBClient) InsertPkAddressMappings(
+	ctx context.Context, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven string,
+) error {
+	recordDryRunWrite(ctx, "v1.InsertPkAddressMappings")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveUnprocessableMessage(
+	ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+) error {
+	recordDryRunWrite(ctx, "v1.SaveUnprocessableMessage")
+	return nil
+}
+
+func (c *dryRunV1DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
+	recordDryRunWrite(ctx, "v1.DeleteUnprocessableMessage")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveDryRunShadowRecord(ctx context.Context, record *dbmodel.DryRunShadowRecordDocument) error {
+	recordDryRunWrite(ctx, "v1.SaveDryRunShadowRecord")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveFinalityProviderRegistrations(
+	ctx context.Context, registrations []*v1dbmodel.FinalityProviderRegistrationDocument,
+) ([]int, error) {
+	recordDryRunWrite(ctx, "v1.SaveFinalityProviderRegistrations")
+	return nil, nil
+}
+
+func (c *dryRunV1DBClient) InsertGlobalParamsUpdate(ctx context.Context, update *dbmodel.GlobalParamsUpdateDocument) error {
+	recordDryRunWrite(ctx, "v1.InsertGlobalParamsUpdate")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveActiveStakingDelegation(
+	ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
+	stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
+	startTimestamp int64, isOverflow bool, stakingValueUsd *float64,
+) error {
+	recordDryRunWrite(ctx, "v1.SaveActiveStakingDelegation")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveUnbondingTx(
+	ctx context.Context, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex string,
+) error {
+	recordDryRunWrite(ctx, "v1.SaveUnbondingTx")
+	return nil
+}
+
+func (c *dryRunV1DBClient) TransitionToTransitionedState(ctx context.Context, stakingTxHashHex string) error {
+	recordDryRunWrite(ctx, "v1.TransitionToTransitionedState")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveTimeLockExpireCheck(
+	ctx context.Context, stakingTxHashHex string, expireHeight uint64, txType string,
+) error {
+	recordDryRunWrite(ctx, "v1.SaveTimeLockExpireCheck")
+	return nil
+}
+
+func (c *dryRunV1DBClient) TransitionToUnbondedState(
+	ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState,
+) error {
+	recordDryRunWrite(ctx, "v1.TransitionToUnbondedState")
+	return nil
+}
+
+func (c *dryRunV1DBClient) TransitionToUnbondingState(
+	ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64,
+) error {
+	recordDryRunWrite(ctx, "v1.TransitionToUnbondingState")
+	return nil
+}
+
+func (c *dryRunV1DBClient) TransitionToWithdrawnState(
+	ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction,
+	withdrawalPath v1dbmodel.WithdrawalPath, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.TransitionToWithdrawnState")
+	return nil
+}
+
+func (c *dryRunV1DBClient) BackfillWithdrawalPath(
+	ctx context.Context, txHashHex string, withdrawalPath v1dbmodel.WithdrawalPath, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.BackfillWithdrawalPath")
+	return nil
+}
+
+func (c *dryRunV1DBClient) LowercaseStakerPks(ctx context.Context) (int64, error) {
+	recordDryRunWrite(ctx, "v1.LowercaseStakerPks")
+	return 0, nil
+}
+
+func (c *dryRunV1DBClient) GetOrCreateStatsLock(
+	ctx context.Context, stakingTxHashHex string, state string,
+) (*v1dbmodel.StatsLockDocument, error) {
+	return c.V1DBReader.GetStatsLock(ctx, stakingTxHashHex, state)
+}
+
+func (c *dryRunV1DBClient) SubtractOverallStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.SubtractOverallStats")
+	return nil
+}
+
+func (c *dryRunV1DBClient) IncrementOverallStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.IncrementOverallStats")
+	return nil
+}
+
+func (c *dryRunV1DBClient) IncrementFinalityProviderStats(
+	ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.IncrementFinalityProviderStats")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SubtractFinalityProviderStats(
+	ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.SubtractFinalityProviderStats")
+	return nil
+}
+
+func (c *dryRunV1DBClient) IncrementStakerStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.IncrementStakerStats")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SubtractStakerStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.SubtractStakerStats")
+	return nil
+}
+
+func (c *dryRunV1DBClient) RebuildStakerStats(
+	ctx context.Context, stakerPkHex string,
+) (*v1dbmodel.StakerStatsDocument, *v1dbmodel.StakerStatsDocument, error) {
+	recordDryRunWrite(ctx, "v1.RebuildStakerStats")
+	return nil, nil, ErrDryRunUnsupported
+}
+
+func (c *dryRunV1DBClient) UpsertLatestBtcInfo(
+	ctx context.Context, height uint64, confirmedTvl uint64, unconfirmedTvl uint64,
+) error {
+	recordDryRunWrite(ctx, "v1.UpsertLatestBtcInfo")
+	return nil
+}
+
+func (c *dryRunV1DBClient) QuarantineUnbondingDocument(ctx context.Context, unbondingTxHashHex string) error {
+	recordDryRunWrite(ctx, "v1.QuarantineUnbondingDocument")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveIntegrityIssue(ctx context.Context, issue *v1dbmodel.IntegrityIssueDocument) error {
+	recordDryRunWrite(ctx, "v1.SaveIntegrityIssue")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveMisbehaviorReport(ctx context.Context, report *v1dbmodel.MisbehaviorReportDocument) error {
+	recordDryRunWrite(ctx, "v1.SaveMisbehaviorReport")
+	return nil
+}
+
+func (c *dryRunV1DBClient) SaveWatchlistEntry(ctx context.Context, entry *v1dbmodel.WatchlistDocument) error {
+	recordDryRunWrite(ctx, "v1.SaveWatchlistEntry")
+	return nil
+}
+
+func (c *dryRunV1DBClient) DeleteWatchlistEntry(ctx context.Context, observerPkHex, watchedPkHex string) error {
+	recordDryRunWrite(ctx, "v1.DeleteWatchlistEntry")
+	return nil
+}
+
+type dryRunV2DBClient struct {
+	v2dbclient.V2DBReader
+}
+
+func (c *dryRunV2DBClient) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (c *dryRunV2DBClient) InsertPkAddressMappings(
+	ctx context.Context, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven string,
+) error {
+	recordDryRunWrite(ctx, "v2.InsertPkAddressMappings")
+	return nil
+}
+
+func (c *dryRunV2DBClient) SaveUnprocessableMessage(
+	ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+) error {
+	recordDryRunWrite(ctx, "v2.SaveUnprocessableMessage")
+	return nil
+}
+
+func (c *dryRunV2DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
+	recordDryRunWrite(ctx, "v2.DeleteUnprocessableMessage")
+	return nil
+}
+
+func (c *dryRunV2DBClient) SaveDryRunShadowRecord(ctx context.Context, record *dbmodel.DryRunShadowRecordDocument) error {
+	recordDryRunWrite(ctx, "v2.SaveDryRunShadowRecord")
+	return nil
+}
+
+func (c *dryRunV2DBClient) InsertGlobalParamsUpdate(ctx context.Context, update *dbmodel.GlobalParamsUpdateDocument) error {
+	recordDryRunWrite(ctx, "v2.InsertGlobalParamsUpdate")
+	return nil
+}
+
+func (c *dryRunV2DBClient) GetOrCreateStatsLock(
+	ctx context.Context, stakingTxHashHex string, state string,
+) (*v2dbmodel.V2StatsLockDocument, error) {
+	return c.V2DBReader.GetStatsLock(ctx, stakingTxHashHex, state)
+}
+
+func (c *dryRunV2DBClient) IncrementOverallStats(
+	ctx context.Context, stakingTxHashHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v2.IncrementOverallStats")
+	return nil
+}
+
+func (c *dryRunV2DBClient) SubtractOverallStats(
+	ctx context.Context, stakingTxHashHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v2.SubtractOverallStats")
+	return nil
+}
+
+func (c *dryRunV2DBClient) HandleActiveStakerStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v2.HandleActiveStakerStats")
+	return nil
+}
+
+func (c *dryRunV2DBClient) HandleUnbondingStakerStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64, stateHistory []string,
+) error {
+	recordDryRunWrite(ctx, "v2.HandleUnbondingStakerStats")
+	return nil
+}
+
+func (c *dryRunV2DBClient) HandleWithdrawableStakerStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64, stateHistory []string,
+) error {
+	recordDryRunWrite(ctx, "v2.HandleWithdrawableStakerStats")
+	return nil
+}
+
+func (c *dryRunV2DBClient) HandleWithdrawnStakerStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64, stateHistory []string,
+) error {
+	recordDryRunWrite(ctx, "v2.HandleWithdrawnStakerStats")
+	return nil
+}
+
+func (c *dryRunV2DBClient) IncrementFinalityProviderStats(
+	ctx context.Context, stakingTxHashHex string, fpPkHexes []string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v2.IncrementFinalityProviderStats")
+	return nil
+}
+
+func (c *dryRunV2DBClient) SubtractFinalityProviderStats(
+	ctx context.Context, stakingTxHashHex string, fpPkHexes []string, amount uint64,
+) error {
+	recordDryRunWrite(ctx, "v2.SubtractFinalityProviderStats")
+	return nil
+}
+
+func (c *dryRunV2DBClient) SaveEventGap(ctx context.Context, gap *v2dbmodel.EventGapDocument) error {
+	recordDryRunWrite(ctx, "v2.SaveEventGap")
+	return nil
+}
+
+func (c *dryRunV2DBClient) DeleteQueuePayload(ctx context.Context, id string) error {
+	recordDryRunWrite(ctx, "v2.DeleteQueuePayload")
+	return nil
+}
@@ -0,0 +1,107 @@
+package dbclients
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v2dbclient "github.com/babylonlabs-io/staking-api-service/internal/v2/db/client"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// writesNotRecorded are the write methods of the dry-run clients that do not
+// record a write: the transactions only run their function, and the stats
+// locks are read instead of being created
+var writesNotRecorded = map[string]bool{
+	"WithTransaction":      true,
+	"GetOrCreateStatsLock": true,
+}
+
+func TestDryRunClientsNeverWrite(t *testing.T) {
+	sharedDB := mocks.NewDBClient(t)
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("GetStatsLock", mock.Anything, mock.Anything, mock.Anything).
+		Return(&v1dbmodel.StatsLockDocument{}, nil).Maybe()
+	v2DB := mocks.NewV2DBClient(t)
+	v2DB.On("GetStatsLock", mock.Anything, mock.Anything, mock.Anything).
+		Return(&v2dbmodel.V2StatsLockDocument{}, nil).Maybe()
+
+	dryRun := NewDryRun(&DbClients{
+		SharedDBClient: sharedDB,
+		V1DBClient:     v1DB,
+		V2DBClient:     v2DB,
+	})
+
+	clients := []struct {
+		prefix string
+		client any
+		// full and reader are the interface of the client and its read-only part
+		full, reader reflect.Type
+		live         *mock.Mock
+	}{
+		{
+			"shared", dryRun.SharedDBClient,
+			reflect.TypeOf((*dbclient.DBClient)(nil)).Elem(),
+			reflect.TypeOf((*dbclient.DBReader)(nil)).Elem(),
+			&sharedDB.Mock,
+		},
+		{
+			"v1", dryRun.V1DBClient,
+			reflect.TypeOf((*v1dbclient.V1DBClient)(nil)).Elem(),
+			reflect.TypeOf((*v1dbclient.V1DBReader)(nil)).Elem(),
+			&v1DB.Mock,
+		},
+		{
+			"v2", dryRun.V2DBClient,
+			reflect.TypeOf((*v2dbclient.V2DBClient)(nil)).Elem(),
+			reflect.TypeOf((*v2dbclient.V2DBReader)(nil)).Elem(),
+			&v2DB.Mock,
+		},
+	}
+
+	for _, c := range clients {
+		for i := 0; i < c.full.NumMethod(); i++ {
+			method := c.full.Method(i)
+			if _, isRead := c.reader.MethodByName(method.Name); isRead {
+				continue
+			}
+
+			t.Run(c.prefix+"."+method.Name, func(t *testing.T) {
+				ctx, writes := WithDryRunWrites(context.Background())
+				args := []reflect.Value{reflect.ValueOf(ctx)}
+				for j := 1; j < method.Type.NumIn(); j++ {
+					args = append(args, zeroArg(method.Type.In(j)))
+				}
+				reflect.ValueOf(c.client).MethodByName(method.Name).Call(args)
+
+				for _, call := range c.live.Calls {
+					assert.NotEqual(t, method.Name, call.Method, "write reached the live client")
+				}
+				if !writesNotRecorded[method.Name] {
+					assert.Contains(t, writes.Writes(), c.prefix+"."+method.Name)
+				}
+			})
+		}
+	}
+}
+
+// zeroArg returns the zero value of the given type, functions return the
+// zero values of their results when called
+func zeroArg(argType reflect.Type) reflect.Value {
+	if argType.Kind() != reflect.Func {
+		return reflect.Zero(argType)
+	}
+	return reflect.MakeFunc(argType, func([]reflect.Value) []reflect.Value {
+		results := make([]reflect.Value, argType.NumOut())
+		for i := range results {
+			results[i] = reflect.Zero(argType.Out(i))
+		}
+		return results
+	})
+}
@@ -1,5 +1,7 @@
 package db
 
+import "fmt"
+
 // DuplicateKeyError is an error type for duplicate key errors
 type DuplicateKeyError struct {
 	Key     string
@@ -43,3 +45,19 @@ func IsNotFoundError(err error) bool {
 	_, ok := err.(*NotFoundError)
 	return ok
 }
+
+// InListTooLargeError is returned when the values of an $in filter exceed
+// the configured maximum
+type InListTooLargeError struct {
+	Size    int
+	MaxSize int
+}
+
+func (e *InListTooLargeError) Error() string {
+	return fmt.Sprintf("too many values to look up: %d, the maximum is %d", e.Size, e.MaxSize)
+}
+
+func IsInListTooLargeError(err error) bool {
+	_, ok := err.(*InListTooLargeError)
+	return ok
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"sync"
+)
+
+// InListChunkParallelism is the number of chunks looked up concurrently by
+// FindInChunks
+const InListChunkParallelism = 4
+
+// CheckInListSize returns an InListTooLargeError if the number of values of
+// an $in filter exceeds the maximum
+func CheckInListSize(size, maxSize int) error {
+	if size > maxSize {
+		return &InListTooLargeError{Size: size, MaxSize: maxSize}
+	}
+	return nil
+}
+
+// FindInChunks looks up a list of values too large for a single $in filter by
+// splitting it into chunks of at most chunkSize values. Up to parallelism
+// chunks are looked up concurrently, the results are returned in the order of
+// the chunks. The first failing chunk cancels the remaining ones.
+func FindInChunks[T any](
+	ctx context.Context, values []string, chunkSize, parallelism int,
+	find func(ctx context.Context, chunk []string) ([]T, error),
+) ([]T, error) {
+	if len(values) <= chunkSize {
+		return find(ctx, values)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunkCount := (len(values) + chunkSize - 1) / chunkSize
+	results := make([][]T, chunkCount)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	semaphore := make(chan struct{}, parallelism)
+	for i := 0; i < chunkCount; i++ {
+		chunk := values[i*chunkSize : min((i+1)*chunkSize, len(values))]
+		semaphore <- struct{}{}
+		if ctx.Err() != nil {
+			<-semaphore
+			break
+		}
+		wg.Add(1)
+		go func(i int, chunk []string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			result, err := find(ctx, chunk)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = result
+		}(i, chunk)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var all []T
+	for _, result := range results {
+		all = append(all, result...)
+	}
+	return all, nil
+}
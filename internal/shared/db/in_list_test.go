@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInListSize(t *testing.T) {
+	require.NoError(t, CheckInListSize(3, 3))
+
+	err := CheckInListSize(4, 3)
+	require.Error(t, err)
+	assert.True(t, IsInListTooLargeError(err))
+	assert.Equal(t, &InListTooLargeError{Size: 4, MaxSize: 3}, err)
+}
+
+func TestFindInChunks(t *testing.T) {
+	ctx := context.Background()
+	values := make([]string, 10)
+	for i := range values {
+		values[i] = fmt.Sprintf("value-%d", i)
+	}
+
+	t.Run("chunks are looked up separately and results kept in order", func(t *testing.T) {
+		var (
+			mutex  sync.Mutex
+			chunks [][]string
+		)
+		results, err := FindInChunks(ctx, values, 3, 2, func(_ context.Context, chunk []string) ([]string, error) {
+			if err := CheckInListSize(len(chunk), 3); err != nil {
+				return nil, err
+			}
+			mutex.Lock()
+			chunks = append(chunks, chunk)
+			mutex.Unlock()
+			return chunk, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, values, results)
+		assert.Len(t, chunks, 4)
+	})
+
+	t.Run("small lists are looked up at once", func(t *testing.T) {
+		calls := 0
+		results, err := FindInChunks(ctx, values, len(values), 2, func(_ context.Context, chunk []string) ([]string, error) {
+			calls++
+			return chunk, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, values, results)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("a failing chunk fails the lookup", func(t *testing.T) {
+		lookupErr := errors.New("lookup failed")
+		results, err := FindInChunks(ctx, values, 3, 2, func(_ context.Context, chunk []string) ([]string, error) {
+			if chunk[0] == "value-3" {
+				return nil, lookupErr
+			}
+			return chunk, nil
+		})
+		assert.ErrorIs(t, err, lookupErr)
+		assert.Nil(t, results)
+	})
+}
@@ -0,0 +1,24 @@
+package dbmodel
+
+// DryRunShadowRecordDocument captures the writes a queue handler would have
+// performed for a message while running in dry-run mode. The original message
+// body is kept so that the message can be replayed once the handler is live.
+type DryRunShadowRecordDocument struct {
+	QueueName        string   `bson:"queue_name"`
+	StakingTxHashHex string   `bson:"staking_tx_hash_hex"`
+	MessageBody      string   `bson:"message_body"`
+	IntendedWrites   []string `bson:"intended_writes"`
+	CreatedAt        int64    `bson:"created_at"`
+}
+
+func NewDryRunShadowRecordDocument(
+	queueName, stakingTxHashHex, messageBody string, intendedWrites []string, createdAt int64,
+) *DryRunShadowRecordDocument {
+	return &DryRunShadowRecordDocument{
+		QueueName:        queueName,
+		StakingTxHashHex: stakingTxHashHex,
+		MessageBody:      messageBody,
+		IntendedWrites:   intendedWrites,
+		CreatedAt:        createdAt,
+	}
+}
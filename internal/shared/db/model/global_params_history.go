@@ -0,0 +1,22 @@
+package dbmodel
+
+// GlobalParamsUpdateDocument records the update of one of the global params
+// from the given BTC height onwards. The updates are applied in the order
+// they were stored on top of the params of the global params file.
+type GlobalParamsUpdateDocument struct {
+	ParamName           string `bson:"param_name"`
+	Value               uint64 `bson:"value"`
+	EffectiveFromHeight uint64 `bson:"effective_from_height"`
+	CreatedAt           int64  `bson:"created_at"`
+}
+
+func NewGlobalParamsUpdateDocument(
+	paramName string, value, effectiveFromHeight uint64, createdAt int64,
+) *GlobalParamsUpdateDocument {
+	return &GlobalParamsUpdateDocument{
+		ParamName:           paramName,
+		Value:               value,
+		EffectiveFromHeight: effectiveFromHeight,
+		CreatedAt:           createdAt,
+	}
+}
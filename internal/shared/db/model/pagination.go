@@ -1,8 +1,14 @@
 package dbmodel
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func DecodePaginationToken[T any](token string) (*T, error) {
@@ -25,3 +31,61 @@ func GetPaginationToken[PaginationType any](d PaginationType) (string, error) {
 	}
 	return base64.URLEncoding.EncodeToString(tokenBytes), nil
 }
+
+var (
+	ErrInvalidPaginationTokenSignature = errors.New("invalid pagination token signature")
+	ErrExpiredPaginationToken          = errors.New("pagination token expired")
+)
+
+// SignedPaginationTokenBuilder wraps a pagination token builder so that the
+// tokens it builds carry an expiry time and an HMAC-SHA256 signature over the
+// token and the expiry. The signed token is the base64 encoding of
+// `<token>.<expiry>.<signature>`.
+func SignedPaginationTokenBuilder[T any](
+	builder func(T) (string, error), secret string, ttl time.Duration,
+) func(T) (string, error) {
+	return func(d T) (string, error) {
+		token, err := builder(d)
+		if err != nil {
+			return "", err
+		}
+		expiry := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+		signature := signPaginationToken(token, expiry, secret)
+		return base64.URLEncoding.EncodeToString(
+			[]byte(token + "." + expiry + "." + signature),
+		), nil
+	}
+}
+
+// VerifySignedPaginationToken checks the signature and the expiry of a token
+// built by SignedPaginationTokenBuilder and returns the unsigned token.
+func VerifySignedPaginationToken(signedToken, secret string, now time.Time) (string, error) {
+	decoded, err := base64.URLEncoding.DecodeString(signedToken)
+	if err != nil {
+		return "", ErrInvalidPaginationTokenSignature
+	}
+	parts := strings.Split(string(decoded), ".")
+	if len(parts) != 3 {
+		return "", ErrInvalidPaginationTokenSignature
+	}
+	token, expiry, signature := parts[0], parts[1], parts[2]
+
+	if !hmac.Equal([]byte(signature), []byte(signPaginationToken(token, expiry, secret))) {
+		return "", ErrInvalidPaginationTokenSignature
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", ErrInvalidPaginationTokenSignature
+	}
+	if now.Unix() > expiresAt {
+		return "", ErrExpiredPaginationToken
+	}
+
+	return token, nil
+}
+
+func signPaginationToken(token, expiry, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(token + "." + expiry))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
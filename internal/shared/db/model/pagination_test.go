@@ -0,0 +1,44 @@
+package dbmodel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPagination struct {
+	Id string `json:"id"`
+}
+
+func TestSignedPaginationToken(t *testing.T) {
+	builder := SignedPaginationTokenBuilder(
+		func(d testPagination) (string, error) { return GetPaginationToken(d) },
+		"secret", time.Hour,
+	)
+	signedToken, err := builder(testPagination{Id: "abc"})
+	require.NoError(t, err)
+
+	t.Run("valid token", func(t *testing.T) {
+		token, err := VerifySignedPaginationToken(signedToken, "secret", time.Now())
+		require.NoError(t, err)
+		decoded, err := DecodePaginationToken[testPagination](token)
+		require.NoError(t, err)
+		assert.Equal(t, "abc", decoded.Id)
+	})
+	t.Run("wrong secret", func(t *testing.T) {
+		_, err := VerifySignedPaginationToken(signedToken, "other", time.Now())
+		assert.ErrorIs(t, err, ErrInvalidPaginationTokenSignature)
+	})
+	t.Run("expired token", func(t *testing.T) {
+		_, err := VerifySignedPaginationToken(signedToken, "secret", time.Now().Add(2*time.Hour))
+		assert.ErrorIs(t, err, ErrExpiredPaginationToken)
+	})
+	t.Run("unsigned token", func(t *testing.T) {
+		token, err := GetPaginationToken(testPagination{Id: "forged"})
+		require.NoError(t, err)
+		_, err = VerifySignedPaginationToken(token, "secret", time.Now())
+		assert.ErrorIs(t, err, ErrInvalidPaginationTokenSignature)
+	})
+}
@@ -3,6 +3,7 @@ package dbmodel
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
@@ -15,7 +16,9 @@ import (
 
 const (
 	// Shared
-	PkAddressMappingsCollection = "pk_address_mappings"
+	PkAddressMappingsCollection   = "pk_address_mappings"
+	DryRunShadowRecordCollection  = "dry_run_shadow_records"
+	GlobalParamsHistoryCollection = "global_params_history"
 	// V1
 	V1StatsLockCollection             = "stats_lock"
 	V1OverallStatsCollection          = "overall_stats"
@@ -26,45 +29,90 @@ const (
 	V1UnbondingCollection             = "unbonding_queue"
 	V1BtcInfoCollection               = "btc_info"
 	V1UnprocessableMsgCollection      = "unprocessable_messages"
+	V1IntegrityIssuesCollection       = "integrity_issues"
+	V1MisbehaviorReportsCollection    = "misbehavior_reports"
+	V1WatchlistsCollection            = "watchlists"
+	V1FpRegistrationsCollection       = "finality_provider_registrations"
 	// V2
 	V2StatsLockCollection             = "v2_stats_lock"
 	V2OverallStatsCollection          = "v2_overall_stats"
 	V2FinalityProviderStatsCollection = "v2_finality_providers_stats"
 	V2StakerStatsCollection           = "v2_staker_stats"
+	V2EventGapsCollection             = "v2_event_gaps"
+	V2DailyStatsCollection            = "v2_daily_stats"
+	V2QueuePayloadsCollection         = "v2_queue_payloads"
 )
 
+// IndexSetupResult is the outcome of creating one of the declared indexes
+// during the db setup
+type IndexSetupResult struct {
+	Collection string `json:"collection"`
+	Keys       string `json:"keys"`
+	Unique     bool   `json:"unique"`
+	Error      string `json:"error,omitempty"`
+}
+
 type index struct {
-	Indexes map[string]int
+	Indexes bson.D
 	Unique  bool
 }
 
 var collections = map[string][]index{
 	// Shared
 	PkAddressMappingsCollection: {
-		{Indexes: map[string]int{"taproot": 1}, Unique: true},
-		{Indexes: map[string]int{"native_segwit_odd": 1}, Unique: true},
-		{Indexes: map[string]int{"native_segwit_even": 1}, Unique: true},
+		{Indexes: bson.D{{Key: "taproot", Value: 1}}, Unique: true},
+		{Indexes: bson.D{{Key: "native_segwit_odd", Value: 1}}, Unique: true},
+		{Indexes: bson.D{{Key: "native_segwit_even", Value: 1}}, Unique: true},
+	},
+	DryRunShadowRecordCollection: {
+		{Indexes: bson.D{{Key: "staking_tx_hash_hex", Value: 1}}, Unique: false},
 	},
+	GlobalParamsHistoryCollection: {{Indexes: bson.D{{Key: "created_at", Value: 1}}, Unique: false}},
 	// V1
-	V1StatsLockCollection:             {{Indexes: map[string]int{}}},
-	V1OverallStatsCollection:          {{Indexes: map[string]int{}}},
-	V1FinalityProviderStatsCollection: {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
-	V1StakerStatsCollection:           {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
+	V1StatsLockCollection:             {{Indexes: bson.D{}}},
+	V1OverallStatsCollection:          {{Indexes: bson.D{}}},
+	V1FinalityProviderStatsCollection: {{Indexes: bson.D{{Key: "active_tvl", Value: -1}}, Unique: false}},
+	V1StakerStatsCollection: {
+		{Indexes: bson.D{{Key: "active_tvl", Value: -1}, {Key: "_id", Value: -1}}, Unique: false},
+	},
 	V1DelegationCollection: {
-		{Indexes: map[string]int{"staker_pk_hex": 1, "staking_tx.start_height": -1, "_id": 1}, Unique: false},
+		{Indexes: bson.D{{Key: "staker_pk_hex", Value: 1}, {Key: "staking_tx.start_height", Value: -1}, {Key: "_id", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "finality_provider_pk_hex", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "withdrawal_tx.address", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "staking_tx.start_height", Value: 1}, {Key: "_id", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "state_history.to_state", Value: 1}, {Key: "state_history.timestamp", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "staking_value", Value: -1}, {Key: "_id", Value: -1}}, Unique: false},
+	},
+	V1TimeLockCollection: {{Indexes: bson.D{{Key: "expire_height", Value: 1}}, Unique: false}},
+	V1UnbondingCollection: {
+		{Indexes: bson.D{{Key: "unbonding_tx_hash_hex", Value: 1}}, Unique: true},
+		{Indexes: bson.D{{Key: "stakingtxhashhex", Value: 1}}, Unique: false},
 	},
-	V1TimeLockCollection:         {{Indexes: map[string]int{"expire_height": 1}, Unique: false}},
-	V1UnbondingCollection:        {{Indexes: map[string]int{"unbonding_tx_hash_hex": 1}, Unique: true}},
-	V1UnprocessableMsgCollection: {{Indexes: map[string]int{}}},
-	V1BtcInfoCollection:          {{Indexes: map[string]int{}}},
+	V1UnprocessableMsgCollection: {{Indexes: bson.D{}}},
+	V1BtcInfoCollection:          {{Indexes: bson.D{}}},
+	V1IntegrityIssuesCollection:  {{Indexes: bson.D{{Key: "issue_type", Value: 1}}, Unique: false}},
+	V1MisbehaviorReportsCollection: {
+		{Indexes: bson.D{{Key: "finality_provider_pk_hex", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "status", Value: 1}}, Unique: false},
+	},
+	V1WatchlistsCollection: {
+		{Indexes: bson.D{{Key: "observer_pk_hex", Value: 1}, {Key: "added_at", Value: 1}}, Unique: false},
+		{Indexes: bson.D{{Key: "watched_pk_hex", Value: 1}}, Unique: false},
+	},
+	V1FpRegistrationsCollection: {{Indexes: bson.D{{Key: "registered_at", Value: 1}}, Unique: false}},
 	// V2
-	V2StatsLockCollection:             {{Indexes: map[string]int{}}},
-	V2OverallStatsCollection:          {{Indexes: map[string]int{}}},
-	V2FinalityProviderStatsCollection: {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
-	V2StakerStatsCollection:           {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
+	V2StatsLockCollection:             {{Indexes: bson.D{}}},
+	V2OverallStatsCollection:          {{Indexes: bson.D{}}},
+	V2FinalityProviderStatsCollection: {{Indexes: bson.D{{Key: "active_tvl", Value: -1}}, Unique: false}},
+	V2StakerStatsCollection:           {{Indexes: bson.D{{Key: "active_tvl", Value: -1}}, Unique: false}},
+	V2EventGapsCollection:             {{Indexes: bson.D{{Key: "queue_name", Value: 1}}, Unique: false}},
+	V2DailyStatsCollection:            {{Indexes: bson.D{}}},
+	V2QueuePayloadsCollection:         {{Indexes: bson.D{}}},
 }
 
-func Setup(ctx context.Context, cfg *config.Config) error {
+// Setup creates the collections and indexes of the staking db and reports
+// the outcome of each index creation
+func Setup(ctx context.Context, cfg *config.Config) ([]IndexSetupResult, error) {
 	credential := options.Credential{
 		Username: cfg.StakingDb.Username,
 		Password: cfg.StakingDb.Password,
@@ -72,7 +120,7 @@ func Setup(ctx context.Context, cfg *config.Config) error {
 	clientOps := options.Client().ApplyURI(cfg.StakingDb.Address).SetAuth(credential)
 	client, err := mongo.Connect(ctx, clientOps)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create a context with timeout.
@@ -87,14 +135,34 @@ func Setup(ctx context.Context, cfg *config.Config) error {
 		createCollection(ctx, database, collection)
 	}
 
+	var results []IndexSetupResult
 	for name, idxs := range collections {
 		for _, idx := range idxs {
-			createIndex(ctx, database, name, idx)
+			if len(idx.Indexes) == 0 {
+				continue
+			}
+			result := IndexSetupResult{
+				Collection: name,
+				Keys:       formatIndexKeys(idx.Indexes),
+				Unique:     idx.Unique,
+			}
+			if err := createIndex(ctx, database, name, idx); err != nil {
+				result.Error = err.Error()
+			}
+			results = append(results, result)
 		}
 	}
 
 	log.Info().Msg("Collections and Indexes created successfully.")
-	return nil
+	return results, nil
+}
+
+func formatIndexKeys(keys bson.D) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s:%v", key.Key, key.Value))
+	}
+	return strings.Join(parts, ",")
 }
 
 func createCollection(ctx context.Context, database *mongo.Database, collectionName string) {
@@ -113,25 +181,21 @@ func createCollection(ctx context.Context, database *mongo.Database, collectionN
 	log.Debug().Msg("Collection created successfully: " + collectionName)
 }
 
-func createIndex(ctx context.Context, database *mongo.Database, collectionName string, idx index) {
+func createIndex(ctx context.Context, database *mongo.Database, collectionName string, idx index) error {
 	if len(idx.Indexes) == 0 {
-		return
-	}
-
-	indexKeys := bson.D{}
-	for k, v := range idx.Indexes {
-		indexKeys = append(indexKeys, bson.E{Key: k, Value: v})
+		return nil
 	}
 
 	index := mongo.IndexModel{
-		Keys:    indexKeys,
+		Keys:    idx.Indexes,
 		Options: options.Index().SetUnique(idx.Unique),
 	}
 
 	if _, err := database.Collection(collectionName).Indexes().CreateOne(ctx, index); err != nil {
 		log.Debug().Msg(fmt.Sprintf("Failed to create index on collection '%s': %v", collectionName, err))
-		return
+		return err
 	}
 
 	log.Debug().Msg("Index created successfully on collection: " + collectionName)
+	return nil
 }
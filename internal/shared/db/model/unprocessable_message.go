@@ -1,13 +1,23 @@
 package dbmodel
 
 type UnprocessableMessageDocument struct {
-	MessageBody string `bson:"message_body"`
-	Receipt     string `bson:"receipt"`
+	MessageBody   string `bson:"message_body"`
+	Receipt       string `bson:"receipt"`
+	QueueName     string `bson:"queue_name"`
+	Error         string `bson:"error"`
+	RetryAttempts int32  `bson:"retry_attempts"`
+	CreatedAt     int64  `bson:"created_at"`
 }
 
-func NewUnprocessableMessageDocument(messageBody, receipt string) *UnprocessableMessageDocument {
+func NewUnprocessableMessageDocument(
+	queueName, messageBody, receipt, processingErr string, retryAttempts int32, createdAt int64,
+) *UnprocessableMessageDocument {
 	return &UnprocessableMessageDocument{
-		MessageBody: messageBody,
-		Receipt:     receipt,
+		MessageBody:   messageBody,
+		Receipt:       receipt,
+		QueueName:     queueName,
+		Error:         processingErr,
+		RetryAttempts: retryAttempts,
+		CreatedAt:     createdAt,
 	}
 }
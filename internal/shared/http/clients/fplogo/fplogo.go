@@ -0,0 +1,126 @@
+package fplogo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+const (
+	ContentTypePng  = "image/png"
+	ContentTypeJpeg = "image/jpeg"
+	ContentTypeSvg  = "image/svg+xml"
+)
+
+var allowedContentTypes = []string{ContentTypePng, ContentTypeJpeg, ContentTypeSvg}
+
+type FpLogo struct {
+	ContentType string
+	Data        []byte
+}
+
+type FpLogoProxy struct {
+	config     *config.FpLogoProxyConfig
+	httpClient *http.Client
+}
+
+func New(config *config.FpLogoProxyConfig) *FpLogoProxy {
+	// Client is disabled if config is nil
+	if config == nil {
+		return nil
+	}
+	return &FpLogoProxy{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+func (c *FpLogoProxy) FetchLogo(ctx context.Context, logoUrl string) (*FpLogo, *types.Error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logoUrl, nil)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusUnprocessableEntity, types.UnprocessableEntity,
+			"failed to fetch finality provider logo",
+		)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, types.NewErrorWithMsg(
+			http.StatusUnprocessableEntity, types.UnprocessableEntity,
+			fmt.Sprintf("finality provider logo request failed with status %d", resp.StatusCode),
+		)
+	}
+	if resp.ContentLength > c.config.MaxSizeBytes {
+		return nil, logoTooLargeError(c.config.MaxSizeBytes)
+	}
+
+	// Read one more byte than the limit to detect oversized bodies without
+	// trusting the Content-Length header
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.config.MaxSizeBytes+1))
+	if err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusUnprocessableEntity, types.UnprocessableEntity,
+			"failed to read finality provider logo",
+		)
+	}
+	if int64(len(data)) > c.config.MaxSizeBytes {
+		return nil, logoTooLargeError(c.config.MaxSizeBytes)
+	}
+
+	contentType := SniffContentType(data)
+	if !slices.Contains(allowedContentTypes, contentType) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusUnprocessableEntity, types.UnprocessableEntity,
+			fmt.Sprintf("finality provider logo content type %s is not allowed", contentType),
+		)
+	}
+
+	return &FpLogo{ContentType: contentType, Data: data}, nil
+}
+
+// SniffContentType detects the content type from the image bytes, the content
+// type announced by the remote server is ignored.
+// http.DetectContentType does not detect svg images, they are recognised by
+// their root element.
+func SniffContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case ContentTypePng, ContentTypeJpeg:
+		return contentType
+	}
+	if isSvg(data) {
+		return ContentTypeSvg
+	}
+	return contentType
+}
+
+func isSvg(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	// Skip the xml declaration, the comments and the doctype preceding the root element
+	for bytes.HasPrefix(trimmed, []byte("<?")) || bytes.HasPrefix(trimmed, []byte("<!")) {
+		end := bytes.IndexByte(trimmed, '>')
+		if end < 0 {
+			return false
+		}
+		trimmed = bytes.TrimSpace(trimmed[end+1:])
+	}
+	return bytes.HasPrefix(trimmed, []byte("<svg"))
+}
+
+func logoTooLargeError(maxSizeBytes int64) *types.Error {
+	return types.NewErrorWithMsg(
+		http.StatusUnprocessableEntity, types.UnprocessableEntity,
+		fmt.Sprintf("finality provider logo exceeds the maximum size of %d bytes", maxSizeBytes),
+	)
+}
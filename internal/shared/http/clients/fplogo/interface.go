@@ -0,0 +1,17 @@
+package fplogo
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+//go:generate mockery --name=FpLogoClient --output=../../../../../tests/mocks --outpkg=mocks --filename=mock_fp_logo_client.go
+type FpLogoClient interface {
+	/*
+		FetchLogo downloads the finality provider logo from the given URL.
+		The image is rejected if it is larger than the configured size or if its
+		sniffed content type is not png, jpeg or svg.
+	*/
+	FetchLogo(ctx context.Context, logoUrl string) (*FpLogo, *types.Error)
+}
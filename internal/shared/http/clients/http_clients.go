@@ -2,21 +2,36 @@ package clients
 
 import (
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients/fplogo"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients/ordinals"
 )
 
 type Clients struct {
 	Ordinals ordinals.OrdinalsClient
+	// SecondaryOrdinals is nil if no secondary ordinals service is configured
+	SecondaryOrdinals ordinals.OrdinalsClient
+	FpLogo            fplogo.FpLogoClient
 }
 
 func New(cfg *config.Config) *Clients {
-	var ordinalsClient ordinals.OrdinalsClient
+	var ordinalsClient, secondaryOrdinalsClient ordinals.OrdinalsClient
 	// If the assets config is set, create the ordinal related clients
 	if cfg.Assets != nil {
 		ordinalsClient = ordinals.New(cfg.Assets.Ordinals)
+		if cfg.Assets.SecondaryOrdinals != nil {
+			secondaryOrdinalsClient = ordinals.New(cfg.Assets.SecondaryOrdinals)
+		}
+	}
+
+	var fpLogoClient fplogo.FpLogoClient
+	// If the fp logo proxy config is set, logos are fetched and served by the API
+	if cfg.FpLogoProxy != nil {
+		fpLogoClient = fplogo.New(cfg.FpLogoProxy)
 	}
 
 	return &Clients{
-		Ordinals: ordinalsClient,
+		Ordinals:          ordinalsClient,
+		SecondaryOrdinals: secondaryOrdinalsClient,
+		FpLogo:            fpLogoClient,
 	}
 }
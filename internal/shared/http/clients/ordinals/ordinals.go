@@ -78,6 +78,13 @@ func (c *Ordinals) FetchUTXOInfos(
 
 	// The response from ordinal service shall contain all requested UTXOs and in
 	// the same order
+	if len(outputs) != len(utxos) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError,
+			types.InternalServiceError,
+			"response does not contain all requested UTXOs or in the wrong order",
+		)
+	}
 	for i, utxo := range utxos {
 		if outputs[i].Transaction != utxo.Txid {
 			return nil, types.NewErrorWithMsg(
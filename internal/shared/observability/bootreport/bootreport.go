@@ -0,0 +1,169 @@
+package bootreport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// QueueBackendRabbitMq is the only queue backend supported by the service
+	QueueBackendRabbitMq = "rabbitmq"
+	// MigrationStatusNone is reported as the service does not run schema
+	// migrations, collections and indexes are bootstrapped on every start
+	MigrationStatusNone = "none"
+	// MongoTopologyUnknown is reported when the topology could not be detected
+	MongoTopologyUnknown = "unknown"
+
+	redactedValue = "REDACTED"
+)
+
+// Report describes what the instance loaded at boot. It must never carry
+// credentials, every address is redacted before being stored.
+type Report struct {
+	StartedAt              time.Time                  `json:"started_at"`
+	ConfigPath             string                     `json:"config_path"`
+	ConfigSha256           string                     `json:"config_sha256"`
+	GlobalParamsPath       string                     `json:"global_params_path"`
+	GlobalParamsVersions   []uint64                   `json:"global_params_versions"`
+	FinalityProvidersPath  string                     `json:"finality_providers_path"`
+	FinalityProvidersCount int                        `json:"finality_providers_count"`
+	BtcNetwork             string                     `json:"btc_network"`
+	StakingDb              DbReport                   `json:"staking_db"`
+	IndexerDb              DbReport                   `json:"indexer_db"`
+	Queue                  QueueReport                `json:"queue"`
+	Features               map[string]bool            `json:"features"`
+	Indexes                []dbmodel.IndexSetupResult `json:"indexes"`
+	MigrationStatus        string                     `json:"migration_status"`
+}
+
+type DbReport struct {
+	Address  string `json:"address"`
+	DbName   string `json:"db_name"`
+	Topology string `json:"topology"`
+}
+
+type QueueReport struct {
+	Backend   string `json:"backend"`
+	Url       string `json:"url"`
+	QueueType string `json:"queue_type"`
+}
+
+var current atomic.Pointer[Report]
+
+// New assembles the boot report from the loaded config, params and
+// finality providers. The db topologies and the index results are filled by
+// the caller once the db has been set up.
+func New(
+	cfgPath string,
+	cfg *config.Config,
+	paramsPath string,
+	params *types.GlobalParams,
+	finalityProvidersPath string,
+	finalityProviders []types.FinalityProviderDetails,
+) (*Report, error) {
+	cfgContent, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	cfgHash := sha256.Sum256(cfgContent)
+
+	report := &Report{
+		StartedAt:              time.Now().UTC(),
+		ConfigPath:             cfgPath,
+		ConfigSha256:           hex.EncodeToString(cfgHash[:]),
+		GlobalParamsPath:       paramsPath,
+		GlobalParamsVersions:   []uint64{},
+		FinalityProvidersPath:  finalityProvidersPath,
+		FinalityProvidersCount: len(finalityProviders),
+		StakingDb:              newDbReport(cfg.StakingDb),
+		IndexerDb:              newDbReport(cfg.IndexerDb),
+		Features:               features(cfg),
+		Indexes:                []dbmodel.IndexSetupResult{},
+		MigrationStatus:        MigrationStatusNone,
+	}
+	if cfg.Server != nil {
+		report.BtcNetwork = cfg.Server.BTCNet
+	}
+	if cfg.Queue != nil {
+		report.Queue = QueueReport{
+			Backend:   QueueBackendRabbitMq,
+			Url:       redactUrl(cfg.Queue.Url),
+			QueueType: cfg.Queue.QueueType,
+		}
+	}
+	if params != nil {
+		for _, version := range params.Versions {
+			report.GlobalParamsVersions = append(report.GlobalParamsVersions, version.Version)
+		}
+	}
+
+	return report, nil
+}
+
+// Publish logs the report as a single structured event and keeps it for the
+// lifetime of the process
+func Publish(report *Report) {
+	current.Store(report)
+	log.Info().Interface("boot_report", report).Msg("Boot report")
+}
+
+// Current returns the published boot report, nil if none was published yet
+func Current() *Report {
+	return current.Load()
+}
+
+func newDbReport(cfg *config.DbConfig) DbReport {
+	if cfg == nil {
+		return DbReport{}
+	}
+	return DbReport{
+		Address:  redactUrl(cfg.Address),
+		DbName:   cfg.DbName,
+		Topology: MongoTopologyUnknown,
+	}
+}
+
+func features(cfg *config.Config) map[string]bool {
+	return map[string]bool{
+		"admin":                    cfg.Admin != nil,
+		"assets":                   cfg.Assets != nil,
+		"bloom_filter":             cfg.BloomFilter != nil,
+		"debug_unbonding_sighash":  cfg.Debug != nil && cfg.Debug.UnbondingSighash,
+		"delegation_transition":    cfg.DelegationTransition != nil,
+		"dry_run":                  cfg.DryRun != nil,
+		"unbonding_integrity":      cfg.UnbondingIntegrity != nil,
+		"fp_logo_proxy":            cfg.FpLogoProxy != nil,
+		"event_gap_detection":      cfg.EventGapDetection != nil,
+		"queue_retry_backoff":      cfg.QueueRetryBackoff != nil,
+		"queue_throttle":           cfg.QueueThrottle != nil,
+		"signed_pagination_tokens": cfg.StakingDb != nil && cfg.StakingDb.PaginationTokenSecret != "",
+	}
+}
+
+// redactUrl strips the credentials from an address, the whole value is
+// redacted if it carries credentials but can not be parsed
+func redactUrl(address string) string {
+	u, err := url.Parse(address)
+	if err != nil || u.Host == "" {
+		if strings.Contains(address, "@") {
+			return redactedValue
+		}
+		return address
+	}
+	if u.User != nil {
+		u.User = url.User(redactedValue)
+	}
+	u.RawQuery = ""
+	return u.String()
+}
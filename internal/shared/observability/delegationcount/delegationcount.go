@@ -0,0 +1,82 @@
+package delegationcount
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	v1Version = "v1"
+	v2Version = "v2"
+)
+
+var logger zerolog.Logger = log.Logger
+
+func SetLogger(customLogger zerolog.Logger) {
+	logger = customLogger
+}
+
+// StartDelegationCountCron refreshes the delegation counts per state exposed
+// as metrics, once right away then at every interval
+func StartDelegationCountCron(ctx context.Context, dbClients *dbclients.DbClients, interval time.Duration) error {
+	c := cron.New()
+	logger.Info().Msg("Initiated Delegation Count Cron")
+
+	cronSpec := fmt.Sprintf("@every %s", interval)
+
+	_, err := c.AddFunc(cronSpec, func() {
+		refreshDelegationCounts(ctx, dbClients, interval)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	c.Start()
+	go refreshDelegationCounts(ctx, dbClients, interval)
+
+	go func() {
+		<-ctx.Done()
+		logger.Info().Msg("Stopping Delegation Count Cron")
+		c.Stop()
+	}()
+
+	return nil
+}
+
+// refreshDelegationCounts counts the phase-1 delegations of the staking db
+// and the phase-2 ones of the indexer db. The counts of a version are kept
+// as they were if they could not be refreshed.
+func refreshDelegationCounts(ctx context.Context, dbClients *dbclients.DbClients, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	v1Counts, err := dbClients.V1DBClient.CountDelegationsByState(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to count the v1 delegations by state")
+	} else {
+		counts := make(map[string]int64, len(v1Counts))
+		for state, count := range v1Counts {
+			counts[state.ToString()] = count
+		}
+		metrics.SetDelegationCounts(v1Version, counts)
+	}
+
+	v2Counts, err := dbClients.IndexerDBClient.CountDelegationsByState(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to count the v2 delegations by state")
+	} else {
+		counts := make(map[string]int64, len(v2Counts))
+		for state, count := range v2Counts {
+			counts[state.String()] = count
+		}
+		metrics.SetDelegationCounts(v2Version, counts)
+	}
+}
@@ -0,0 +1,68 @@
+package delegationcount
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// delegationGauges returns the delegation counts exposed for the version
+func delegationGauges(t *testing.T, version string) map[string]float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	gauges := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "staking_api_delegations" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["version"] == version {
+				gauges[labels["state"]] = metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return gauges
+}
+
+func TestRefreshDelegationCounts(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	indexerDB := mocks.NewIndexerDBClient(t)
+	dbClients := &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB}
+
+	v1DB.On("CountDelegationsByState", mock.Anything).Return(map[types.DelegationState]int64{
+		types.Active: 3, types.Withdrawn: 1,
+	}, nil).Once()
+	indexerDB.On("CountDelegationsByState", mock.Anything).Return(map[indexertypes.DelegationState]int64{
+		indexertypes.StateActive: 5,
+	}, nil).Once()
+	refreshDelegationCounts(ctx, dbClients, time.Second)
+	assert.Equal(t, map[string]float64{"active": 3, "withdrawn": 1}, delegationGauges(t, v1Version))
+	assert.Equal(t, map[string]float64{"ACTIVE": 5}, delegationGauges(t, v2Version))
+
+	// the states that are gone are dropped, and a failed refresh keeps the
+	// previous counts
+	v1DB.On("CountDelegationsByState", mock.Anything).Return(map[types.DelegationState]int64{
+		types.Active: 2,
+	}, nil).Once()
+	indexerDB.On("CountDelegationsByState", mock.Anything).Return(nil, errors.New("db down")).Once()
+	refreshDelegationCounts(ctx, dbClients, time.Second)
+	assert.Equal(t, map[string]float64{"active": 2}, delegationGauges(t, v1Version))
+	assert.Equal(t, map[string]float64{"ACTIVE": 5}, delegationGauges(t, v2Version))
+}
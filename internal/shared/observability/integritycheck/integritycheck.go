@@ -0,0 +1,59 @@
+package integritycheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var logger zerolog.Logger = log.Logger
+
+func SetLogger(customLogger zerolog.Logger) {
+	logger = customLogger
+}
+
+func StartUnbondingIntegrityCron(
+	ctx context.Context, service v1service.V1ServiceProvider, cfg *config.UnbondingIntegrityConfig,
+) error {
+	c := cron.New()
+	logger.Info().Msg("Initiated Unbonding Integrity Cron")
+
+	cronSpec := fmt.Sprintf("@every %s", cfg.Interval)
+
+	_, err := c.AddFunc(cronSpec, func() {
+		unbondingIntegrityCheck(ctx, service, cfg)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	c.Start()
+
+	go func() {
+		<-ctx.Done()
+		logger.Info().Msg("Stopping Unbonding Integrity Cron")
+		c.Stop()
+	}()
+
+	return nil
+}
+
+func unbondingIntegrityCheck(
+	ctx context.Context, service v1service.V1ServiceProvider, cfg *config.UnbondingIntegrityConfig,
+) {
+	issueCounts, err := service.VerifyUnbondingDocumentsIntegrity(ctx, cfg.LookbackPeriod, cfg.Quarantine)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unbonding integrity check failed.")
+		return
+	}
+	for issueType, count := range issueCounts {
+		metrics.RecordIntegrityIssues(string(issueType), count)
+	}
+}
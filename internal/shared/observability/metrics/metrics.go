@@ -15,6 +15,9 @@ import (
 type Outcome string
 
 const (
+	// Namespace prefixes the metrics of the http handlers, the queue
+	// consumers and the delegation counts
+	Namespace                string        = "staking_api"
 	Success                  Outcome       = "success"
 	Error                    Outcome       = "error"
 	MetricRequestTimeout     time.Duration = 5 * time.Second
@@ -29,6 +32,10 @@ var (
 	once                             sync.Once
 	metricsRouter                    *chi.Mux
 	httpRequestDurationHistogram     *prometheus.HistogramVec
+	httpRequestsCounter              *prometheus.CounterVec
+	queueMessageDurationHistogram    *prometheus.HistogramVec
+	queueMessagesCounter             *prometheus.CounterVec
+	delegationsGauge                 *prometheus.GaugeVec
 	eventProcessingDurationHistogram *prometheus.HistogramVec
 	unprocessableEntityCounter       *prometheus.CounterVec
 	queueOperationFailureCounter     *prometheus.CounterVec
@@ -36,6 +43,12 @@ var (
 	clientRequestDurationHistogram   *prometheus.HistogramVec
 	serviceCrashCounter              *prometheus.CounterVec
 	dbErrorsCounter                  *prometheus.CounterVec
+	integrityIssuesCounter           *prometheus.CounterVec
+	eventGapsCounter                 *prometheus.CounterVec
+	responseCacheLookupsCounter      *prometheus.CounterVec
+	aggregationQueueDepthGauge       prometheus.Gauge
+	aggregationRejectionsCounter     *prometheus.CounterVec
+	queueThrottleFactorGauge         prometheus.Gauge
 )
 
 // Init initializes the metrics package.
@@ -77,11 +90,49 @@ func registerMetrics() {
 
 	httpRequestDurationHistogram = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
-			Name:    "http_request_duration_seconds",
-			Help:    "Histogram of http request durations in seconds.",
-			Buckets: defaultHistogramBucketsSeconds,
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Histogram of http request durations in seconds per route template.",
+			Buckets:   defaultHistogramBucketsSeconds,
+		},
+		[]string{"route", "status"},
+	)
+
+	httpRequestsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "http_requests_total",
+			Help:      "Total number of http requests per route template.",
+		},
+		[]string{"route", "status"},
+	)
+
+	queueMessageDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "queue_message_duration_seconds",
+			Help:      "Histogram of queue message processing durations in seconds.",
+			Buckets:   defaultHistogramBucketsSeconds,
 		},
-		[]string{"endpoint", "status"},
+		[]string{"queue", "outcome", "mode"},
+	)
+
+	queueMessagesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "queue_messages_total",
+			Help:      "Total number of processed queue messages per outcome and handler mode.",
+		},
+		[]string{"queue", "outcome", "mode"},
+	)
+
+	delegationsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "delegations",
+			Help:      "Current number of delegations per state, refreshed periodically.",
+		},
+		[]string{"version", "state"},
 	)
 
 	eventProcessingDurationHistogram = prometheus.NewHistogramVec(
@@ -90,7 +141,7 @@ func registerMetrics() {
 			Help:    "Histogram of event processing durations in seconds.",
 			Buckets: defaultHistogramBucketsSeconds,
 		},
-		[]string{"queuename", "status", "attempts"},
+		[]string{"queuename", "status", "attempts", "mode"},
 	)
 
 	unprocessableEntityCounter = prometheus.NewCounterVec(
@@ -140,31 +191,100 @@ func registerMetrics() {
 		},
 		[]string{"method"},
 	)
+	integrityIssuesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "integrity_issues_total",
+			Help: "Total number of integrity issues detected by type",
+		},
+		[]string{"type"},
+	)
+	eventGapsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "event_gaps_total",
+			Help: "Total number of suspected gaps in the events received per queue",
+		},
+		[]string{"queuename"},
+	)
+	responseCacheLookupsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "response_cache_lookups_total",
+			Help: "Total number of lookups in the response cache per endpoint and result (hit or miss)",
+		},
+		[]string{"endpoint", "result"},
+	)
+	aggregationQueueDepthGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "aggregation_queue_depth",
+			Help: "Number of aggregations waiting for capacity",
+		},
+	)
+	aggregationRejectionsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aggregation_rejections_total",
+			Help: "Total number of aggregations rejected for lack of capacity per endpoint",
+		},
+		[]string{"endpoint"},
+	)
+	queueThrottleFactorGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "queue_throttle_factor",
+			Help: "Share of the full speed the queue messages are consumed at, 1 when not throttled",
+		},
+	)
 
 	prometheus.MustRegister(
 		httpRequestDurationHistogram,
+		httpRequestsCounter,
+		queueMessageDurationHistogram,
+		queueMessagesCounter,
+		delegationsGauge,
 		eventProcessingDurationHistogram,
 		unprocessableEntityCounter,
 		queueOperationFailureCounter,
 		httpResponseWriteFailureCounter,
 		clientRequestDurationHistogram,
 		serviceCrashCounter,
+		integrityIssuesCounter,
+		eventGapsCounter,
+		responseCacheLookupsCounter,
+		aggregationQueueDepthGauge,
+		aggregationRejectionsCounter,
+		queueThrottleFactorGauge,
 	)
 }
 
-// StartHttpRequestDurationTimer starts a timer to measure http request handling duration.
-func StartHttpRequestDurationTimer(endpoint string) func(statusCode int) {
+// RecordHttpRequest counts the http request and records its duration. The
+// route is the template the request matched, not its raw path.
+func RecordHttpRequest(route string, statusCode int, duration time.Duration) {
+	status := fmt.Sprintf("%d", statusCode)
+	httpRequestDurationHistogram.WithLabelValues(route, status).Observe(duration.Seconds())
+	httpRequestsCounter.WithLabelValues(route, status).Inc()
+}
+
+// StartQueueMessageTimer starts a timer to measure the processing of a queue
+// message, the message is counted under the outcome it is stopped with. The
+// mode keeps the messages processed in dry-run apart from the live ones.
+func StartQueueMessageTimer(queue, mode string) func(outcome Outcome) {
 	startTime := time.Now()
-	return func(statusCode int) {
+	return func(outcome Outcome) {
 		duration := time.Since(startTime).Seconds()
-		httpRequestDurationHistogram.WithLabelValues(
-			endpoint,
-			fmt.Sprintf("%d", statusCode),
-		).Observe(duration)
+		queueMessageDurationHistogram.WithLabelValues(queue, outcome.String(), mode).Observe(duration)
+		queueMessagesCounter.WithLabelValues(queue, outcome.String(), mode).Inc()
+	}
+}
+
+// SetDelegationCounts replaces the delegation counts of the given version,
+// the states missing from the counts are dropped.
+func SetDelegationCounts(version string, counts map[string]int64) {
+	delegationsGauge.DeletePartialMatch(prometheus.Labels{"version": version})
+	for state, count := range counts {
+		delegationsGauge.WithLabelValues(version, state).Set(float64(count))
 	}
 }
 
-func StartEventProcessingDurationTimer(queuename string, attempts int32) func(statusCode int) {
+// StartEventProcessingDurationTimer starts a timer to measure event processing duration.
+// The mode distinguishes handlers running live from the ones running in dry-run.
+func StartEventProcessingDurationTimer(queuename string, attempts int32, mode string) func(statusCode int) {
 	startTime := time.Now()
 	return func(statusCode int) {
 		duration := time.Since(startTime).Seconds()
@@ -172,6 +292,7 @@ func StartEventProcessingDurationTimer(queuename string, attempts int32) func(st
 			queuename,
 			fmt.Sprintf("%d", statusCode),
 			fmt.Sprintf("%d", attempts),
+			mode,
 		).Observe(duration)
 	}
 }
@@ -214,3 +335,42 @@ func RecordServiceCrash(service string) {
 func RecordDbError(method string) {
 	dbErrorsCounter.WithLabelValues(method).Inc()
 }
+
+// RecordIntegrityIssues increments the integrity issues counter by the number
+// of issues found for the given type.
+func RecordIntegrityIssues(issueType string, count int) {
+	integrityIssuesCounter.WithLabelValues(issueType).Add(float64(count))
+}
+
+// RecordEventGap increments the suspected event gaps counter of the queue
+func RecordEventGap(queueName string) {
+	eventGapsCounter.WithLabelValues(queueName).Inc()
+}
+
+// RecordResponseCacheLookup counts a lookup of the endpoint response in the
+// response cache as a hit or a miss
+func RecordResponseCacheLookup(endpoint string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	responseCacheLookupsCounter.WithLabelValues(endpoint, result).Inc()
+}
+
+// AddAggregationQueueDepth adds delta to the number of aggregations waiting
+// for capacity
+func AddAggregationQueueDepth(delta int) {
+	aggregationQueueDepthGauge.Add(float64(delta))
+}
+
+// RecordAggregationRejection counts an aggregation of the endpoint rejected
+// for lack of capacity
+func RecordAggregationRejection(endpoint string) {
+	aggregationRejectionsCounter.WithLabelValues(endpoint).Inc()
+}
+
+// SetQueueThrottleFactor sets the share of the full speed the queue messages
+// are consumed at
+func SetQueueThrottleFactor(factor float64) {
+	queueThrottleFactorGauge.Set(factor)
+}
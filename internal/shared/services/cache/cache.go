@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// maxEntriesPerEndpoint bounds the responses cached per endpoint, the
+// responses over the bound are not cached
+const maxEntriesPerEndpoint = 1000
+
+// Cache stores the responses of the endpoints, identified by a key built
+// from the request. Every invalidation of an endpoint bumps its version so
+// that a response loaded before the invalidation is not cached after it.
+type Cache interface {
+	// Get returns the unexpired response of the endpoint for the key, along
+	// with the current version of the endpoint
+	Get(endpoint, key string) (value any, version uint64, ok bool)
+	// Set caches the response for ttl, unless the endpoint was invalidated
+	// since the given version was returned by Get
+	Set(endpoint, key string, value any, ttl time.Duration, version uint64)
+	// Invalidate drops the cached responses of the endpoints
+	Invalidate(endpoints ...string)
+}
+
+type entry struct {
+	value     any
+	expiresAt time.Time
+}
+
+type endpointCache struct {
+	version uint64
+	entries map[string]entry
+}
+
+// MemoryCache is the default Cache, it keeps the responses in memory
+type MemoryCache struct {
+	mu        sync.RWMutex
+	endpoints map[string]*endpointCache
+	now       func() time.Time
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		endpoints: make(map[string]*endpointCache),
+		now:       time.Now,
+	}
+}
+
+func (c *MemoryCache) Get(endpoint, key string) (any, uint64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cached, ok := c.endpoints[endpoint]
+	if !ok {
+		return nil, 0, false
+	}
+	e, ok := cached.entries[key]
+	if !ok || c.now().After(e.expiresAt) {
+		return nil, cached.version, false
+	}
+	return e.value, cached.version, true
+}
+
+func (c *MemoryCache) Set(endpoint, key string, value any, ttl time.Duration, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.endpoints[endpoint]
+	if !ok {
+		cached = &endpointCache{entries: make(map[string]entry)}
+		c.endpoints[endpoint] = cached
+	}
+	if cached.version != version {
+		return
+	}
+
+	now := c.now()
+	if _, ok := cached.entries[key]; !ok && len(cached.entries) >= maxEntriesPerEndpoint {
+		for k, e := range cached.entries {
+			if now.After(e.expiresAt) {
+				delete(cached.entries, k)
+			}
+		}
+		if len(cached.entries) >= maxEntriesPerEndpoint {
+			return
+		}
+	}
+	cached.entries[key] = entry{value: value, expiresAt: now.Add(ttl)}
+}
+
+func (c *MemoryCache) Invalidate(endpoints ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, endpoint := range endpoints {
+		cached, ok := c.endpoints[endpoint]
+		if !ok {
+			cached = &endpointCache{}
+			c.endpoints[endpoint] = cached
+		}
+		cached.version++
+		cached.entries = make(map[string]entry)
+	}
+}
+
+// GetOrLoad returns the cached response of the endpoint for the key, or
+// loads and caches it for ttl. Failed loads are not cached. A nil cache or a
+// ttl of 0 always loads. The cached responses are shared between the
+// callers, they must not be modified.
+func GetOrLoad[T any](
+	c Cache, endpoint, key string, ttl time.Duration, load func() (T, *types.Error),
+) (T, *types.Error) {
+	if c == nil || ttl <= 0 {
+		return load()
+	}
+	value, version, ok := c.Get(endpoint, key)
+	metrics.RecordResponseCacheLookup(endpoint, ok)
+	if ok {
+		return value.(T), nil
+	}
+
+	loaded, err := load()
+	if err != nil {
+		return loaded, err
+	}
+	c.Set(endpoint, key, loaded, ttl, version)
+	return loaded, nil
+}
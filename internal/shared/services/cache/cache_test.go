@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrLoad(t *testing.T) {
+	metrics.Init(0)
+	now := time.Unix(1700000000, 0)
+	c := NewMemoryCache()
+	c.now = func() time.Time { return now }
+
+	loads := 0
+	load := func() (int, *types.Error) {
+		loads++
+		return loads, nil
+	}
+
+	t.Run("responses are cached for the ttl", func(t *testing.T) {
+		value, err := GetOrLoad(c, "stats", "", time.Minute, load)
+		require.Nil(t, err)
+		assert.Equal(t, 1, value)
+		value, err = GetOrLoad(c, "stats", "", time.Minute, load)
+		require.Nil(t, err)
+		assert.Equal(t, 1, value)
+
+		now = now.Add(time.Minute + time.Second)
+		value, err = GetOrLoad(c, "stats", "", time.Minute, load)
+		require.Nil(t, err)
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("keys are cached separately", func(t *testing.T) {
+		first, _ := GetOrLoad(c, "fps", "page:a", time.Minute, load)
+		second, _ := GetOrLoad(c, "fps", "page:b", time.Minute, load)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("invalidation drops the responses of the endpoint", func(t *testing.T) {
+		before, _ := GetOrLoad(c, "stats", "", time.Minute, load)
+		fps, _ := GetOrLoad(c, "fps", "page:a", time.Minute, load)
+		c.Invalidate("stats")
+
+		after, _ := GetOrLoad(c, "stats", "", time.Minute, load)
+		assert.NotEqual(t, before, after)
+		stillCached, _ := GetOrLoad(c, "fps", "page:a", time.Minute, load)
+		assert.Equal(t, fps, stillCached)
+	})
+
+	t.Run("responses loaded across an invalidation are not cached", func(t *testing.T) {
+		c.Invalidate("stats")
+		value, err := GetOrLoad(c, "stats", "", time.Minute, func() (int, *types.Error) {
+			// the stats are updated while the response is being loaded
+			c.Invalidate("stats")
+			return -1, nil
+		})
+		require.Nil(t, err)
+		assert.Equal(t, -1, value)
+
+		value, _ = GetOrLoad(c, "stats", "", time.Minute, load)
+		assert.NotEqual(t, -1, value)
+	})
+
+	t.Run("failed loads are not cached", func(t *testing.T) {
+		_, err := GetOrLoad(c, "failing", "", time.Minute, func() (int, *types.Error) {
+			return 0, types.NewError(http.StatusInternalServerError, types.InternalServiceError, errors.New("db down"))
+		})
+		require.NotNil(t, err)
+		value, err := GetOrLoad(c, "failing", "", time.Minute, load)
+		require.Nil(t, err)
+		assert.Positive(t, value)
+	})
+
+	t.Run("zero ttl and nil cache always load", func(t *testing.T) {
+		before := loads
+		_, _ = GetOrLoad(c, "uncached", "", 0, load)
+		_, _ = GetOrLoad(c, "uncached", "", 0, load)
+		_, _ = GetOrLoad[int](nil, "uncached", "", time.Minute, load)
+		assert.Equal(t, before+3, loads)
+	})
+}
+
+func TestMemoryCacheConcurrentAccess(t *testing.T) {
+	metrics.Init(0)
+	c := NewMemoryCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				key := fmt.Sprintf("page:%d", j%5)
+				_, _ = GetOrLoad(c, "fps", key, time.Minute, func() (string, *types.Error) {
+					return key, nil
+				})
+				if j%50 == 0 {
+					c.Invalidate("fps")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, _, ok := c.Get("fps", "page:1")
+	if ok {
+		assert.Equal(t, "page:1", value)
+	}
+}
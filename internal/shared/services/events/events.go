@@ -0,0 +1,113 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// DelegationStateChange is published once a delegation has transitioned to a
+// new state. A redelivered queue event publishes the change again, consumers
+// should expect duplicates.
+type DelegationStateChange struct {
+	StakingTxHashHex string                `json:"staking_tx_hash_hex"`
+	StakerPkHex      string                `json:"staker_pk_hex"`
+	State            types.DelegationState `json:"state"`
+	Timestamp        int64                 `json:"timestamp"`
+}
+
+// Bus fans the delegation state changes out to the subscriptions of their
+// staker. Publishing never blocks, a subscription whose buffer is full drops
+// the change and is marked stale instead.
+type Bus struct {
+	bufferSize int
+
+	mu            sync.RWMutex
+	subscriptions map[string]map[*Subscription]struct{}
+}
+
+func NewBus(bufferSize int) *Bus {
+	return &Bus{
+		bufferSize:    bufferSize,
+		subscriptions: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the state changes of the delegations of a staker
+// until it is closed
+type Subscription struct {
+	bus         *Bus
+	stakerPkHex string
+	changes     chan DelegationStateChange
+	stale       chan struct{}
+	closeOnce   sync.Once
+}
+
+// Changes returns the state changes published since the subscription
+func (s *Subscription) Changes() <-chan DelegationStateChange {
+	return s.changes
+}
+
+// Stale receives a value when changes were dropped since it was last read,
+// the subscriber has to reload the delegations it tracks
+func (s *Subscription) Stale() <-chan struct{} {
+	return s.stale
+}
+
+// Close stops the subscription, it is safe to call more than once
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.bus.unsubscribe(s)
+	})
+}
+
+// Subscribe returns a subscription to the state changes of the delegations
+// of the staker, it has to be closed once no longer read
+func (b *Bus) Subscribe(stakerPkHex string) *Subscription {
+	subscription := &Subscription{
+		bus:         b,
+		stakerPkHex: stakerPkHex,
+		changes:     make(chan DelegationStateChange, b.bufferSize),
+		stale:       make(chan struct{}, 1),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stakerSubscriptions, ok := b.subscriptions[stakerPkHex]
+	if !ok {
+		stakerSubscriptions = make(map[*Subscription]struct{})
+		b.subscriptions[stakerPkHex] = stakerSubscriptions
+	}
+	stakerSubscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+func (b *Bus) unsubscribe(subscription *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stakerSubscriptions := b.subscriptions[subscription.stakerPkHex]
+	delete(stakerSubscriptions, subscription)
+	if len(stakerSubscriptions) == 0 {
+		delete(b.subscriptions, subscription.stakerPkHex)
+	}
+}
+
+// Publish hands the change to the subscriptions of its staker. It does
+// nothing on a nil bus.
+func (b *Bus) Publish(change DelegationStateChange) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for subscription := range b.subscriptions[change.StakerPkHex] {
+		select {
+		case subscription.changes <- change:
+		default:
+			select {
+			case subscription.stale <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus(t *testing.T) {
+	change := func(stakerPkHex, stakingTxHashHex string) DelegationStateChange {
+		return DelegationStateChange{
+			StakingTxHashHex: stakingTxHashHex,
+			StakerPkHex:      stakerPkHex,
+			State:            types.Unbonding,
+		}
+	}
+
+	t.Run("changes are delivered to the subscriptions of the staker", func(t *testing.T) {
+		bus := NewBus(1)
+		first := bus.Subscribe("staker")
+		defer first.Close()
+		second := bus.Subscribe("staker")
+		defer second.Close()
+		other := bus.Subscribe("other")
+		defer other.Close()
+
+		bus.Publish(change("staker", "tx"))
+		assert.Equal(t, change("staker", "tx"), <-first.Changes())
+		assert.Equal(t, change("staker", "tx"), <-second.Changes())
+		assert.Empty(t, other.Changes())
+	})
+
+	t.Run("full subscription drops the change and is marked stale", func(t *testing.T) {
+		bus := NewBus(1)
+		subscription := bus.Subscribe("staker")
+		defer subscription.Close()
+
+		bus.Publish(change("staker", "tx1"))
+		bus.Publish(change("staker", "tx2"))
+		bus.Publish(change("staker", "tx3"))
+
+		require.Len(t, subscription.Stale(), 1)
+		<-subscription.Stale()
+		assert.Equal(t, change("staker", "tx1"), <-subscription.Changes())
+		assert.Empty(t, subscription.Changes())
+	})
+
+	t.Run("closed subscription is no longer delivered to", func(t *testing.T) {
+		bus := NewBus(1)
+		subscription := bus.Subscribe("staker")
+		subscription.Close()
+		subscription.Close()
+
+		bus.Publish(change("staker", "tx"))
+		assert.Empty(t, subscription.Changes())
+		assert.Empty(t, bus.subscriptions)
+	})
+
+	t.Run("nil bus ignores the changes", func(t *testing.T) {
+		var bus *Bus
+		assert.NotPanics(t, func() { bus.Publish(change("staker", "tx")) })
+	})
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"golang.org/x/sync/semaphore"
+)
+
+// AggregationLimiter bounds the aggregations running concurrently against the
+// database with a weighted semaphore shared by the aggregation endpoints
+type AggregationLimiter struct {
+	cfg *config.AggregationLimitConfig
+	sem *semaphore.Weighted
+}
+
+func NewAggregationLimiter(cfg *config.AggregationLimitConfig) *AggregationLimiter {
+	return &AggregationLimiter{
+		cfg: cfg,
+		sem: semaphore.NewWeighted(cfg.GetCapacity()),
+	}
+}
+
+// Acquire takes the weight of the endpoint out of the capacity, waiting at
+// most the configured max wait for it. The returned release must be called
+// once the aggregation is done. A nil limiter does not limit anything.
+func (l *AggregationLimiter) Acquire(ctx context.Context, endpoint string) (func(), *types.Error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	weight := l.cfg.Weight(endpoint)
+	release := func() { l.sem.Release(weight) }
+	if l.sem.TryAcquire(weight) {
+		return release, nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, l.cfg.GetMaxWait())
+	defer cancel()
+	metrics.AddAggregationQueueDepth(1)
+	err := l.sem.Acquire(waitCtx, weight)
+	metrics.AddAggregationQueueDepth(-1)
+	if err != nil {
+		metrics.RecordAggregationRejection(endpoint)
+		return nil, types.NewErrorWithMsg(
+			http.StatusServiceUnavailable, types.QueryCapacity,
+			"too many aggregations in progress, please retry later",
+		)
+	}
+	return release, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregationLimiter(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+	cfg := &config.AggregationLimitConfig{
+		Capacity: 3,
+		MaxWait:  50 * time.Millisecond,
+		Weights:  map[string]int64{config.V1NewStakersAggregation: 2},
+	}
+	require.NoError(t, cfg.Validate())
+
+	// startSlowAggregation holds the weight of the endpoint until the returned
+	// func is called, like an aggregation still running in the database
+	startSlowAggregation := func(l *AggregationLimiter, endpoint string) func() {
+		release, err := l.Acquire(ctx, endpoint)
+		require.Nil(t, err)
+		done := make(chan struct{})
+		go func() {
+			<-done
+			release()
+		}()
+		return func() { close(done) }
+	}
+
+	t.Run("saturated capacity rejects once the max wait is over", func(t *testing.T) {
+		l := NewAggregationLimiter(cfg)
+		finishNewStakers := startSlowAggregation(l, config.V1NewStakersAggregation)
+		finishStakingDuration := startSlowAggregation(l, config.V1StakingDurationAggregation)
+		defer finishNewStakers()
+		defer finishStakingDuration()
+
+		start := time.Now()
+		_, err := l.Acquire(ctx, config.V1StakerBtcAtRiskAggregation)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+		assert.Equal(t, types.QueryCapacity, err.ErrorCode)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("waiting aggregation runs once capacity is released", func(t *testing.T) {
+		l := NewAggregationLimiter(cfg)
+		finishNewStakers := startSlowAggregation(l, config.V1NewStakersAggregation)
+		finishStakingDuration := startSlowAggregation(l, config.V1StakingDurationAggregation)
+		defer finishStakingDuration()
+
+		time.AfterFunc(10*time.Millisecond, finishNewStakers)
+		release, err := l.Acquire(ctx, config.V1NewStakersAggregation)
+		require.Nil(t, err)
+		release()
+	})
+
+	t.Run("weight larger than the free capacity waits", func(t *testing.T) {
+		l := NewAggregationLimiter(cfg)
+		finishStakingDuration := startSlowAggregation(l, config.V1StakingDurationAggregation)
+		finishBtcAtRisk := startSlowAggregation(l, config.V1StakerBtcAtRiskAggregation)
+		defer finishStakingDuration()
+		defer finishBtcAtRisk()
+
+		// one unit is still free, the new stakers aggregation needs two
+		_, err := l.Acquire(ctx, config.V1NewStakersAggregation)
+		require.NotNil(t, err)
+		release, err := l.Acquire(ctx, config.V1StakerCovenantExposureAggregation)
+		require.Nil(t, err)
+		release()
+	})
+
+	t.Run("nil limiter does not limit", func(t *testing.T) {
+		var l *AggregationLimiter
+		for i := 0; i < 10; i++ {
+			_, err := l.Acquire(ctx, config.V1StakingDurationAggregation)
+			require.Nil(t, err)
+		}
+	})
+}
@@ -7,7 +7,8 @@ import (
 )
 
 type SharedServiceProvider interface {
-	DoHealthCheck(ctx context.Context) error
+	PingDatabases(ctx context.Context) map[string]error
 	VerifyUTXOs(ctx context.Context, utxos []types.UTXOIdentifier, address string) ([]*SafeUTXOPublic, *types.Error)
-	SaveUnprocessableMessages(ctx context.Context, messages string, receipt string) *types.Error
+	SaveUnprocessableMessages(ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32) *types.Error
+	GetUnprocessableMessages(ctx context.Context) ([]UnprocessableMessagePublic, *types.Error)
 }
@@ -4,38 +4,78 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients/ordinals"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/rs/zerolog/log"
 )
 
+// UTXOVerdict is the outcome of the verification of a UTXO
+type UTXOVerdict string
+
+const (
+	UTXOVerdictInscription UTXOVerdict = "inscription"
+	UTXOVerdictClean       UTXOVerdict = "clean"
+	// UTXOVerdictUnknown is returned when no ordinals service could verify
+	// the UTXO, it must not be considered safe to stake
+	UTXOVerdictUnknown UTXOVerdict = "unknown"
+)
+
 type SafeUTXOPublic struct {
-	TxId        string `json:"txid"`
-	Vout        uint32 `json:"vout"`
-	Inscription bool   `json:"inscription"`
+	TxId        string      `json:"txid"`
+	Vout        uint32      `json:"vout"`
+	Inscription bool        `json:"inscription"`
+	Verdict     UTXOVerdict `json:"verdict"`
 }
 
+// VerifyUTXOs checks whether the UTXOs carry an inscription. The secondary
+// ordinals service, if any, is queried when the primary one fails. If neither
+// can verify the UTXOs, the verdict of all of them is unknown.
 func (s *Service) VerifyUTXOs(
 	ctx context.Context, utxos []types.UTXOIdentifier, address string,
 ) ([]*SafeUTXOPublic, *types.Error) {
-	result, err := s.verifyViaOrdinalService(ctx, utxos)
-	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg(
-			"failed to verify ordinals via ordinals service",
-		)
-		return nil, err
+	result, err := s.verifyViaOrdinalService(ctx, s.Clients.Ordinals, utxos)
+	if err == nil {
+		return result, nil
+	}
+	log.Ctx(ctx).Warn().Err(err).Msg("failed to verify ordinals via ordinals service")
+
+	if s.Clients.SecondaryOrdinals != nil {
+		result, err = s.verifyViaOrdinalService(ctx, s.Clients.SecondaryOrdinals, utxos)
+		if err == nil {
+			return result, nil
+		}
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to verify ordinals via secondary ordinals service")
 	}
-	return result, nil
+
+	log.Ctx(ctx).Error().Int("utxos", len(utxos)).
+		Msg("no ordinals service could verify the UTXOs, returning unknown verdicts")
+	results := make([]*SafeUTXOPublic, 0, len(utxos))
+	for _, utxo := range utxos {
+		results = append(results, &SafeUTXOPublic{
+			TxId:    utxo.Txid,
+			Vout:    utxo.Vout,
+			Verdict: UTXOVerdictUnknown,
+		})
+	}
+	return results, nil
 }
 
 func (s *Service) verifyViaOrdinalService(
-	ctx context.Context, utxos []types.UTXOIdentifier,
+	ctx context.Context, client ordinals.OrdinalsClient, utxos []types.UTXOIdentifier,
 ) ([]*SafeUTXOPublic, *types.Error) {
 	var results []*SafeUTXOPublic
 
-	outputs, err := s.Clients.Ordinals.FetchUTXOInfos(ctx, utxos)
+	outputs, err := client.FetchUTXOInfos(ctx, utxos)
 	if err != nil {
 		return nil, err
 	}
+	if len(outputs) != len(utxos) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError,
+			types.InternalServiceError,
+			"ordinal service response does not contain all requested UTXOs",
+		)
+	}
 
 	for index, output := range outputs {
 		// Check the order of the response is the same as the request
@@ -54,10 +94,15 @@ func (s *Service) verifyViaOrdinalService(
 		} else if len(output.Inscriptions) > 0 { // Check if Inscriptions is not empty
 			hasInscription = true
 		}
+		verdict := UTXOVerdictClean
+		if hasInscription {
+			verdict = UTXOVerdictInscription
+		}
 		results = append(results, &SafeUTXOPublic{
 			TxId:        output.Transaction,
 			Vout:        utxos[index].Vout,
 			Inscription: hasInscription,
+			Verdict:     verdict,
 		})
 	}
 
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients/ordinals"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyUTXOs(t *testing.T) {
+	ctx := context.Background()
+	utxos := []types.UTXOIdentifier{{Txid: "clean-tx", Vout: 0}, {Txid: "inscribed-tx", Vout: 1}}
+	outputs := []ordinals.OrdinalsOutputResponse{
+		{Transaction: "clean-tx", Runes: json.RawMessage("{}")},
+		{Transaction: "inscribed-tx", Inscriptions: []string{"inscription-id"}},
+	}
+	primaryErr := types.NewErrorWithMsg(http.StatusRequestTimeout, types.RequestTimeout, "request timeout")
+
+	t.Run("primary service verifies the UTXOs", func(t *testing.T) {
+		primary := mocks.NewOrdinalsClient(t)
+		primary.On("FetchUTXOInfos", ctx, utxos).Return(outputs, nil).Once()
+		s := &Service{Clients: &clients.Clients{Ordinals: primary}}
+
+		results, err := s.VerifyUTXOs(ctx, utxos, "address")
+		require.Nil(t, err)
+		assert.Equal(t, []*SafeUTXOPublic{
+			{TxId: "clean-tx", Vout: 0, Verdict: UTXOVerdictClean},
+			{TxId: "inscribed-tx", Vout: 1, Inscription: true, Verdict: UTXOVerdictInscription},
+		}, results)
+	})
+
+	t.Run("secondary service is used when the primary fails", func(t *testing.T) {
+		primary := mocks.NewOrdinalsClient(t)
+		primary.On("FetchUTXOInfos", ctx, utxos).Return(nil, primaryErr).Once()
+		secondary := mocks.NewOrdinalsClient(t)
+		secondary.On("FetchUTXOInfos", ctx, utxos).Return(outputs, nil).Once()
+		s := &Service{Clients: &clients.Clients{Ordinals: primary, SecondaryOrdinals: secondary}}
+
+		results, err := s.VerifyUTXOs(ctx, utxos, "address")
+		require.Nil(t, err)
+		assert.Equal(t, UTXOVerdictClean, results[0].Verdict)
+		assert.Equal(t, UTXOVerdictInscription, results[1].Verdict)
+	})
+
+	t.Run("verdicts are unknown when every service fails", func(t *testing.T) {
+		primary := mocks.NewOrdinalsClient(t)
+		primary.On("FetchUTXOInfos", ctx, utxos).Return(nil, primaryErr).Once()
+		secondary := mocks.NewOrdinalsClient(t)
+		// A truncated response is as unusable as an error
+		secondary.On("FetchUTXOInfos", ctx, utxos).Return(outputs[:1], nil).Once()
+		s := &Service{Clients: &clients.Clients{Ordinals: primary, SecondaryOrdinals: secondary}}
+
+		results, err := s.VerifyUTXOs(ctx, utxos, "address")
+		require.Nil(t, err)
+		assert.Equal(t, []*SafeUTXOPublic{
+			{TxId: "clean-tx", Vout: 0, Verdict: UTXOVerdictUnknown},
+			{TxId: "inscribed-tx", Vout: 1, Verdict: UTXOVerdictUnknown},
+		}, results)
+	})
+
+	t.Run("verdicts are unknown when the primary fails without a secondary", func(t *testing.T) {
+		primary := mocks.NewOrdinalsClient(t)
+		primary.On("FetchUTXOInfos", ctx, utxos).Return(nil, primaryErr).Once()
+		s := &Service{Clients: &clients.Clients{Ordinals: primary}}
+
+		results, err := s.VerifyUTXOs(ctx, utxos, "address")
+		require.Nil(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, UTXOVerdictUnknown, results[0].Verdict)
+		assert.Equal(t, UTXOVerdictUnknown, results[1].Verdict)
+	})
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/babylonlabs-io/networks/parameters/parser"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// updatableGlobalParams sets the global params that can be updated without
+// a restart, by their name in the global params file
+var updatableGlobalParams = map[string]func(params *types.VersionedGlobalParams, value uint64){
+	"min_staking_amount": func(params *types.VersionedGlobalParams, value uint64) { params.MinStakingAmount = value },
+	"max_staking_amount": func(params *types.VersionedGlobalParams, value uint64) { params.MaxStakingAmount = value },
+	"min_staking_time":   func(params *types.VersionedGlobalParams, value uint64) { params.MinStakingTime = value },
+	"max_staking_time":   func(params *types.VersionedGlobalParams, value uint64) { params.MaxStakingTime = value },
+}
+
+// ParamsCache holds the global params of the global params file with the
+// updates stored in the global params history applied on top. The params of
+// the file are never changed: an update adds a params version activated at
+// the height it is effective from, so the delegations staked before keep the
+// params they were validated with.
+type ParamsCache struct {
+	dbClient   dbclient.DBClient
+	fileParams *types.GlobalParams
+	params     atomic.Pointer[types.GlobalParams]
+	// mu serialises the updates, so that each one is validated against the
+	// params it is applied to
+	mu sync.Mutex
+}
+
+func NewParamsCache(fileParams *types.GlobalParams, dbClient dbclient.DBClient) *ParamsCache {
+	cache := &ParamsCache{dbClient: dbClient, fileParams: fileParams}
+	cache.params.Store(fileParams)
+	return cache
+}
+
+// Get returns the current global params, they must not be modified
+func (c *ParamsCache) Get() *types.GlobalParams {
+	return c.params.Load()
+}
+
+// Refresh reloads the global params history and applies it on top of the
+// params of the file. An update no longer valid against the file, e.g. once
+// a version from a later height was added to it, is skipped.
+func (c *ParamsCache) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refresh(ctx)
+}
+
+func (c *ParamsCache) refresh(ctx context.Context) error {
+	updates, err := c.dbClient.FindGlobalParamsUpdates(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := c.fileParams
+	for _, update := range updates {
+		updated, err := applyGlobalParamsUpdate(
+			c.fileParams, params, update.ParamName, update.Value, update.EffectiveFromHeight,
+		)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).
+				Str("param_name", update.ParamName).
+				Uint64("effective_from_height", update.EffectiveFromHeight).
+				Msg("Skipping the global params update no longer valid")
+			continue
+		}
+		params = updated
+	}
+	c.params.Store(params)
+	return nil
+}
+
+// Update checks the update is valid against the current params, stores it
+// into the global params history and refreshes the params with it
+func (c *ParamsCache) Update(
+	ctx context.Context, paramName string, value, effectiveFromHeight uint64,
+) *types.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := applyGlobalParamsUpdate(c.fileParams, c.Get(), paramName, value, effectiveFromHeight); err != nil {
+		return types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, err.Error())
+	}
+	err := c.dbClient.InsertGlobalParamsUpdate(ctx, dbmodel.NewGlobalParamsUpdateDocument(
+		paramName, value, effectiveFromHeight, time.Now().Unix(),
+	))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to store the global params update")
+		return types.NewInternalServiceError(err)
+	}
+	if err := c.refresh(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to refresh the global params")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// applyGlobalParamsUpdate returns the params with the given param updated
+// from the given height. The update adds a version copied from the latest
+// one, unless the latest version was added by an update from the same height.
+func applyGlobalParamsUpdate(
+	fileParams, params *types.GlobalParams, paramName string, value, effectiveFromHeight uint64,
+) (*types.GlobalParams, error) {
+	set, ok := updatableGlobalParams[paramName]
+	if !ok {
+		return nil, fmt.Errorf(
+			"param %s can not be updated, expected one of %s",
+			paramName, strings.Join(slices.Sorted(maps.Keys(updatableGlobalParams)), ", "),
+		)
+	}
+	if len(params.Versions) == 0 {
+		return nil, fmt.Errorf("no global params version to update")
+	}
+
+	versions := slices.Clone(params.Versions)
+	latest := versions[len(versions)-1]
+	updated := *latest
+	updated.CovenantPks = slices.Clone(latest.CovenantPks)
+	switch {
+	case effectiveFromHeight > latest.ActivationHeight:
+		updated.Version++
+		updated.ActivationHeight = effectiveFromHeight
+		versions = append(versions, &updated)
+	case effectiveFromHeight == latest.ActivationHeight && len(versions) > len(fileParams.Versions):
+		versions[len(versions)-1] = &updated
+	default:
+		return nil, fmt.Errorf(
+			"effective_from_height %d must be after the activation height %d of the latest params version",
+			effectiveFromHeight, latest.ActivationHeight,
+		)
+	}
+	set(&updated, value)
+
+	updatedParams := &types.GlobalParams{Versions: versions}
+	if _, err := parser.ParseGlobalParams(updatedParams); err != nil {
+		return nil, err
+	}
+	return updatedParams, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testGlobalParamsPath = "../../../../config/global-params.json"
+
+// newTestParamsCache returns a params cache on the global params of the
+// config directory, whose history is kept in the returned slice
+func newTestParamsCache(t *testing.T) (*ParamsCache, *[]dbmodel.GlobalParamsUpdateDocument) {
+	history := &[]dbmodel.GlobalParamsUpdateDocument{}
+	return newTestParamsCacheOnHistory(t, history), history
+}
+
+// newTestParamsCacheOnHistory returns a params cache sharing the given
+// history, as the instances of a deployment share the database
+func newTestParamsCacheOnHistory(t *testing.T, history *[]dbmodel.GlobalParamsUpdateDocument) *ParamsCache {
+	fileParams, err := types.NewGlobalParams(testGlobalParamsPath)
+	require.NoError(t, err)
+	require.Len(t, fileParams.Versions, 1)
+
+	db := mocks.NewDBClient(t)
+	db.On("InsertGlobalParamsUpdate", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, update *dbmodel.GlobalParamsUpdateDocument) error {
+			*history = append(*history, *update)
+			return nil
+		},
+	).Maybe()
+	db.On("FindGlobalParamsUpdates", mock.Anything).Return(
+		func(context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error) {
+			return append([]dbmodel.GlobalParamsUpdateDocument(nil), *history...), nil
+		},
+	).Maybe()
+
+	return NewParamsCache(fileParams, db)
+}
+
+func TestParamsCacheUpdate(t *testing.T) {
+	ctx := context.Background()
+	// the single version of the file is activated at 192840
+	const fileActivationHeight = uint64(192840)
+
+	t.Run("valid params add a version from the given height", func(t *testing.T) {
+		for _, update := range []struct {
+			paramName string
+			value     uint64
+			get       func(*types.VersionedGlobalParams) uint64
+		}{
+			{"min_staking_amount", 2_000_000, func(p *types.VersionedGlobalParams) uint64 { return p.MinStakingAmount }},
+			{"max_staking_amount", 2_000_000_000, func(p *types.VersionedGlobalParams) uint64 { return p.MaxStakingAmount }},
+			{"min_staking_time", 63_000, func(p *types.VersionedGlobalParams) uint64 { return p.MinStakingTime }},
+			{"max_staking_time", 65_535, func(p *types.VersionedGlobalParams) uint64 { return p.MaxStakingTime }},
+		} {
+			t.Run(update.paramName, func(t *testing.T) {
+				cache, history := newTestParamsCache(t)
+				fileVersion := *cache.Get().Versions[0]
+
+				err := cache.Update(ctx, update.paramName, update.value, fileActivationHeight+100)
+				require.Nil(t, err)
+
+				versions := cache.Get().Versions
+				require.Len(t, versions, 2)
+				assert.Equal(t, fileVersion, *versions[0], "the version of the file is left as is")
+				assert.Equal(t, uint64(1), versions[1].Version)
+				assert.Equal(t, fileActivationHeight+100, versions[1].ActivationHeight)
+				assert.Equal(t, update.value, update.get(versions[1]))
+				assert.Equal(t, fileVersion.CovenantPks, versions[1].CovenantPks)
+				require.Len(t, *history, 1)
+				assert.Equal(t, update.paramName, (*history)[0].ParamName)
+			})
+		}
+	})
+
+	t.Run("updates from the same height are merged", func(t *testing.T) {
+		cache, history := newTestParamsCache(t)
+		require.Nil(t, cache.Update(ctx, "min_staking_amount", 2_000_000, fileActivationHeight+100))
+		require.Nil(t, cache.Update(ctx, "max_staking_amount", 2_000_000_000, fileActivationHeight+100))
+		require.Nil(t, cache.Update(ctx, "min_staking_amount", 3_000_000, fileActivationHeight+200))
+
+		versions := cache.Get().Versions
+		require.Len(t, versions, 3)
+		assert.Equal(t, uint64(2_000_000), versions[1].MinStakingAmount)
+		assert.Equal(t, uint64(2_000_000_000), versions[1].MaxStakingAmount)
+		assert.Equal(t, uint64(3_000_000), versions[2].MinStakingAmount)
+		assert.Equal(t, uint64(2_000_000_000), versions[2].MaxStakingAmount)
+		assert.Len(t, *history, 3)
+	})
+
+	t.Run("invalid updates are rejected", func(t *testing.T) {
+		for _, update := range []struct {
+			name                string
+			paramName           string
+			value               uint64
+			effectiveFromHeight uint64
+		}{
+			{"param not in the whitelist", "covenant_quorum", 1, fileActivationHeight + 100},
+			{"param set at the version level only", "staking_cap", 60_000_000_000, fileActivationHeight + 100},
+			{"param name of another case", "MIN_STAKING_AMOUNT", 2_000_000, fileActivationHeight + 100},
+			{"unknown param", "min_staking_value", 2_000_000, fileActivationHeight + 100},
+			{"height of the version of the file", "min_staking_amount", 2_000_000, fileActivationHeight},
+			{"height before the latest version", "min_staking_amount", 2_000_000, fileActivationHeight - 1},
+			{"min above the max", "min_staking_amount", 2_000_000_000, fileActivationHeight + 100},
+			{"timelock out of range", "max_staking_time", 70_000, fileActivationHeight + 100},
+		} {
+			t.Run(update.name, func(t *testing.T) {
+				cache, history := newTestParamsCache(t)
+				params := cache.Get()
+
+				err := cache.Update(ctx, update.paramName, update.value, update.effectiveFromHeight)
+				require.NotNil(t, err)
+				assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+				assert.Same(t, params, cache.Get())
+				assert.Empty(t, *history)
+			})
+		}
+	})
+}
+
+func TestParamsCacheRefresh(t *testing.T) {
+	ctx := context.Background()
+	cache, history := newTestParamsCache(t)
+	fileActivationHeight := cache.Get().Versions[0].ActivationHeight
+	*history = []dbmodel.GlobalParamsUpdateDocument{
+		{ParamName: "min_staking_amount", Value: 2_000_000, EffectiveFromHeight: fileActivationHeight + 100},
+		// no longer valid, e.g. once the param was removed from the whitelist
+		{ParamName: "unbonding_fee", Value: 30_000, EffectiveFromHeight: fileActivationHeight + 200},
+		{ParamName: "max_staking_time", Value: 65_535, EffectiveFromHeight: fileActivationHeight + 300},
+	}
+
+	require.NoError(t, cache.Refresh(ctx))
+
+	versions := cache.Get().Versions
+	require.Len(t, versions, 3)
+	assert.Equal(t, uint64(2_000_000), versions[1].MinStakingAmount)
+	assert.Equal(t, fileActivationHeight+300, versions[2].ActivationHeight)
+	assert.Equal(t, uint64(65_535), versions[2].MaxStakingTime)
+	assert.Equal(t, versions[0].UnbondingFee, versions[2].UnbondingFee)
+}
+
+func TestParamsCacheRefreshUpdateOfAnotherInstance(t *testing.T) {
+	ctx := context.Background()
+	history := &[]dbmodel.GlobalParamsUpdateDocument{}
+	updating := newTestParamsCacheOnHistory(t, history)
+	other := newTestParamsCacheOnHistory(t, history)
+	fileActivationHeight := updating.Get().Versions[0].ActivationHeight
+
+	require.Nil(t, updating.Update(ctx, "min_staking_amount", 2_000_000, fileActivationHeight+100))
+	assert.Len(t, other.Get().Versions, 1, "not applied before the refresh")
+
+	require.NoError(t, other.Refresh(ctx))
+	versions := other.Get().Versions
+	require.Len(t, versions, 2)
+	assert.Equal(t, uint64(2_000_000), versions[1].MinStakingAmount)
+	assert.Equal(t, updating.Get(), other.Get())
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// btcUsdPriceCacheEndpoint caches the price of the oracle under a single
+	// key
+	btcUsdPriceCacheEndpoint = "btc-usd-price"
+	// BtcUsdPriceMaxAge is how long a price of the oracle is used for
+	BtcUsdPriceMaxAge = 60 * time.Second
+)
+
+// BTCPriceOracle provides the current BTC price in USD. It is optional, the
+// USD denominated values are left empty when no oracle is set.
+//
+//go:generate mockery --name=BTCPriceOracle --output=../../../../tests/mocks --outpkg=mocks --filename=mock_btc_price_oracle.go
+type BTCPriceOracle interface {
+	GetBtcUsdPrice(ctx context.Context) (float64, error)
+}
+
+// CachedBtcUsdPrice returns the BTC price in USD of the oracle, at most
+// BtcUsdPriceMaxAge old. It returns a PriceOracleUnavailable error if no
+// oracle is set or no fresh price can be fetched.
+func (s *Service) CachedBtcUsdPrice(ctx context.Context) (float64, *types.Error) {
+	if s.BtcPriceOracle == nil {
+		return 0, types.NewErrorWithMsg(
+			http.StatusServiceUnavailable, types.PriceOracleUnavailable, "no price oracle is enabled",
+		)
+	}
+	return cache.GetOrLoad(s.Cache, btcUsdPriceCacheEndpoint, "", BtcUsdPriceMaxAge,
+		func() (float64, *types.Error) {
+			price, err := s.BtcPriceOracle.GetBtcUsdPrice(ctx)
+			if err == nil && price <= 0 {
+				err = errors.New("the price is not positive")
+			}
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("Failed to get the BTC price")
+				return 0, types.NewError(http.StatusServiceUnavailable, types.PriceOracleUnavailable, err)
+			}
+			return price, nil
+		},
+	)
+}
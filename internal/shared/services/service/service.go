@@ -3,15 +3,24 @@ package service
 import (
 	"context"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
 
+// Names of the databases reported by the readiness check
+const (
+	StakingDbDependency = "staking_db"
+	IndexerDbDependency = "indexer_db"
+)
+
 // Services layer contains the business logic and is used to interact with
 // the database and other external clients (if any).
 type Service struct {
@@ -20,6 +29,17 @@ type Service struct {
 	Cfg               *config.Config
 	Params            *types.GlobalParams
 	FinalityProviders []types.FinalityProviderDetails
+	// ParamsCache holds Params with the updates of the global params history
+	// applied, Params are used as is if nil
+	ParamsCache *ParamsCache
+	// BtcPriceOracle is nil when no price oracle is enabled
+	BtcPriceOracle BTCPriceOracle
+	// Cache holds the responses of the stats and finality provider endpoints,
+	// they are not cached if nil
+	Cache cache.Cache
+	// AggregationLimiter bounds the concurrent aggregations, they are not
+	// limited if nil
+	AggregationLimiter *AggregationLimiter
 }
 
 func New(
@@ -31,27 +51,101 @@ func New(
 	dbClients *dbclients.DbClients,
 ) (*Service, error) {
 	return &Service{
-		DbClients:         dbClients,
-		Clients:           clients,
-		Cfg:               cfg,
-		Params:            globalParams,
-		FinalityProviders: finalityProviders,
+		DbClients:          dbClients,
+		Clients:            clients,
+		Cfg:                cfg,
+		Params:             globalParams,
+		FinalityProviders:  finalityProviders,
+		Cache:              cache.NewMemoryCache(),
+		AggregationLimiter: NewAggregationLimiter(cfg.AggregationLimit),
 	}, nil
 }
 
-// DoHealthCheck checks the health of the services by ping the database.
-func (s *Service) DoHealthCheck(ctx context.Context) error {
-	if err := s.DbClients.SharedDBClient.Ping(ctx); err != nil {
-		return err
+// GlobalParams returns the current global params, they must not be modified
+func (s *Service) GlobalParams() *types.GlobalParams {
+	if s.ParamsCache == nil {
+		return s.Params
+	}
+	return s.ParamsCache.Get()
+}
+
+// ResponseCacheTtl returns how long the responses of the endpoint are cached
+func (s *Service) ResponseCacheTtl(endpoint string) time.Duration {
+	if s.Cfg == nil {
+		return 0
+	}
+	return s.Cfg.ResponseCache.Ttl(endpoint)
+}
+
+// PingDatabases pings the staking and the indexer databases concurrently. It
+// returns the error of each failing database by dependency name.
+func (s *Service) PingDatabases(ctx context.Context) map[string]error {
+	pings := map[string]func(context.Context) error{
+		StakingDbDependency: s.DbClients.SharedDBClient.Ping,
+		IndexerDbDependency: s.DbClients.IndexerDBClient.Ping,
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		failures = make(map[string]error)
+	)
+	for name, ping := range pings {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ping(ctx); err != nil {
+				mutex.Lock()
+				failures[name] = err
+				mutex.Unlock()
+			}
+		}()
 	}
-	return s.DbClients.IndexerDBClient.Ping(ctx)
+	wg.Wait()
+	return failures
 }
 
-func (s *Service) SaveUnprocessableMessages(ctx context.Context, messageBody, receipt string) *types.Error {
-	err := s.DbClients.V1DBClient.SaveUnprocessableMessage(ctx, messageBody, receipt)
+func (s *Service) SaveUnprocessableMessages(
+	ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+) *types.Error {
+	err := s.DbClients.V1DBClient.SaveUnprocessableMessage(
+		ctx, queueName, messageBody, receipt, processingErr, retryAttempts,
+	)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while saving unprocessable message")
 		return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "error while saving unprocessable message")
 	}
 	return nil
 }
+
+type UnprocessableMessagePublic struct {
+	QueueName     string `json:"queue_name"`
+	MessageBody   string `json:"message_body"`
+	Receipt       string `json:"receipt"`
+	Error         string `json:"error"`
+	RetryAttempts int32  `json:"retry_attempts"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
+// GetUnprocessableMessages lists the messages that exhausted their retry
+// attempts, oldest first, so they can be inspected before being replayed
+func (s *Service) GetUnprocessableMessages(ctx context.Context) ([]UnprocessableMessagePublic, *types.Error) {
+	messages, err := s.DbClients.SharedDBClient.FindUnprocessableMessages(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching unprocessable messages")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	messagesPublic := make([]UnprocessableMessagePublic, 0, len(messages))
+	for _, message := range messages {
+		messagesPublic = append(messagesPublic, UnprocessableMessagePublic{
+			QueueName:     message.QueueName,
+			MessageBody:   message.MessageBody,
+			Receipt:       message.Receipt,
+			Error:         message.Error,
+			RetryAttempts: message.RetryAttempts,
+			CreatedAt:     message.CreatedAt,
+		})
+	}
+	return messagesPublic, nil
+}
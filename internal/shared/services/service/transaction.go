@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// WithTransaction runs fn in a transaction of the given database, so that
+// either all or none of its writes are applied. The error returned by fn is
+// returned as is, any other failure of the transaction as an internal error.
+// The functions registered with AfterTransaction run once the outermost
+// transaction is over.
+func WithTransaction(
+	ctx context.Context, dbClient dbclient.DBClient, fn func(ctx context.Context) *types.Error,
+) *types.Error {
+	hooks, joined := ctx.Value(afterTransactionKey{}).(*afterTransactionHooks)
+	if !joined {
+		hooks = &afterTransactionHooks{}
+		ctx = context.WithValue(ctx, afterTransactionKey{}, hooks)
+		defer hooks.run()
+	}
+	err := dbClient.WithTransaction(ctx, func(ctx context.Context) error {
+		// the hooks of an attempt retried by the driver are registered again
+		if !joined {
+			hooks.reset()
+		}
+		// a nil *types.Error must not be returned as a non-nil error
+		if fnErr := fn(ctx); fnErr != nil {
+			return fnErr
+		}
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+	var typesErr *types.Error
+	if errors.As(err, &typesErr) {
+		return typesErr
+	}
+	log.Ctx(ctx).Error().Err(err).Msg("failed to commit the transaction")
+	return types.NewInternalServiceError(err)
+}
+
+type afterTransactionKey struct{}
+
+// afterTransactionHooks holds the functions to run once a transaction is over
+type afterTransactionHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+func (h *afterTransactionHooks) reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = nil
+}
+
+func (h *afterTransactionHooks) run() {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// AfterTransaction runs fn once the transaction ctx runs in is over, whether
+// it is committed or aborted, or right away if ctx runs in none. It suits the
+// invalidation of data cached from the database, which would otherwise be
+// reloaded from the state before the commit.
+func AfterTransaction(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterTransactionKey{}).(*afterTransactionHooks)
+	if !ok {
+		fn()
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.hooks = append(hooks.hooks, fn)
+}
@@ -2,20 +2,37 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/events"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
 	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
 )
 
+// delegationEventsBufferSize is the number of delegation state changes held
+// for a subscriber before the next ones are dropped
+const delegationEventsBufferSize = 16
+
 type Services struct {
 	SharedService service.SharedServiceProvider
 	V1Service     v1service.V1ServiceProvider
 	V2Service     v2service.V2ServiceProvider
+	// adminUpdates reload the state changed through the admin endpoints, so
+	// that the updates made by any instance reach all of them
+	adminUpdates []adminUpdate
+}
+
+type adminUpdate struct {
+	name    string
+	refresh func(ctx context.Context) error
 }
 
 func New(
@@ -26,24 +43,113 @@ func New(
 	clients *clients.Clients,
 	dbClients *dbclients.DbClients,
 ) (*Services, error) {
+	// the params updated through the API are shared by the services
+	paramsCache := service.NewParamsCache(globalParams, dbClients.SharedDBClient)
+	if err := paramsCache.Refresh(ctx); err != nil {
+		return nil, err
+	}
 	service, err := service.New(ctx, cfg, globalParams, finalityProviders, clients, dbClients)
 	if err != nil {
 		return nil, err
 	}
-	v1Service, err := v1service.New(ctx, cfg, globalParams, finalityProviders, clients, dbClients)
+	delegationEvents := events.NewBus(delegationEventsBufferSize)
+	v1Service, err := v1service.New(
+		ctx, cfg, globalParams, finalityProviders, clients, dbClients, delegationEvents,
+	)
 	if err != nil {
 		return nil, err
 	}
+	if err := v1Service.RefreshRegisteredFinalityProviders(ctx); err != nil {
+		return nil, err
+	}
 	v2Service, err := v2service.New(ctx, cfg, clients, dbClients)
 	if err != nil {
 		return nil, err
 	}
+	service.ParamsCache = paramsCache
+	v1Service.ParamsCache = paramsCache
 
 	services := Services{
 		SharedService: service,
 		V1Service:     v1Service,
 		V2Service:     v2Service,
+		adminUpdates: []adminUpdate{
+			{name: "global params", refresh: paramsCache.Refresh},
+			{name: "finality provider registrations", refresh: v1Service.RefreshRegisteredFinalityProviders},
+		},
 	}
 
 	return &services, nil
 }
+
+// NewDryRun returns the services running on the dry-run counterpart of the db
+// clients: they read the databases, but their writes are only recorded. The
+// responses are not cached and the delegation state changes are published to
+// a bus nobody subscribes to.
+func NewDryRun(
+	ctx context.Context,
+	cfg *config.Config,
+	globalParams *types.GlobalParams,
+	finalityProviders []types.FinalityProviderDetails,
+	clients *clients.Clients,
+	dbClients *dbclients.DbClients,
+) (*Services, error) {
+	dryRunDbClients := dbclients.NewDryRun(dbClients)
+	service, err := service.New(ctx, cfg, globalParams, finalityProviders, clients, dryRunDbClients)
+	if err != nil {
+		return nil, err
+	}
+	service.Cache = nil
+	v1Service, err := v1service.New(
+		ctx, cfg, globalParams, finalityProviders, clients, dryRunDbClients, events.NewBus(0),
+	)
+	if err != nil {
+		return nil, err
+	}
+	v1Service.Cache = nil
+	v2Service, err := v2service.New(ctx, cfg, clients, dryRunDbClients)
+	if err != nil {
+		return nil, err
+	}
+	v2Service.Cache = nil
+
+	return &Services{
+		SharedService: service,
+		V1Service:     v1Service,
+		V2Service:     v2Service,
+	}, nil
+}
+
+// StartAdminUpdatesRefreshCron reloads the state changed through the admin
+// endpoints at every interval, until the context is canceled. The state is
+// loaded once already when the services are set up.
+func (s *Services) StartAdminUpdatesRefreshCron(ctx context.Context, interval time.Duration) error {
+	c := cron.New()
+	_, err := c.AddFunc(fmt.Sprintf("@every %s", interval), func() {
+		s.refreshAdminUpdates(ctx, interval)
+	})
+	if err != nil {
+		return err
+	}
+	c.Start()
+
+	go func() {
+		<-ctx.Done()
+		c.Stop()
+	}()
+	return nil
+}
+
+// refreshAdminUpdates reloads each state on its own, a state failing to
+// reload is kept as it was until the next refresh
+func (s *Services) refreshAdminUpdates(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, update := range s.adminUpdates {
+		if err := update.refresh(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("state", update.name).
+				Msg("Failed to reload the state updated through the admin endpoints")
+		}
+	}
+}
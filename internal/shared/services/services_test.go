@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminUpdatesRefreshCron(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var failing, refreshed atomic.Int32
+	s := &Services{adminUpdates: []adminUpdate{
+		{name: "failing", refresh: func(context.Context) error {
+			failing.Add(1)
+			return errors.New("db unavailable")
+		}},
+		{name: "refreshed", refresh: func(context.Context) error {
+			refreshed.Add(1)
+			return nil
+		}},
+	}}
+
+	require.NoError(t, s.StartAdminUpdatesRefreshCron(ctx, time.Second))
+	// a state failing to reload does not stop the others from reloading
+	require.Eventually(t, func() bool {
+		return refreshed.Load() >= 2
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.GreaterOrEqual(t, failing.Load(), int32(2))
+
+	cancel()
+	// let a refresh already started complete
+	time.Sleep(100 * time.Millisecond)
+	stoppedAt := refreshed.Load()
+	time.Sleep(1500 * time.Millisecond)
+	assert.Equal(t, stoppedAt, refreshed.Load(), "no refresh once the context is canceled")
+}
@@ -19,6 +19,14 @@ func (s DelegationState) ToString() string {
 	return string(s)
 }
 
+// SupportedDelegationStates returns the states accepted by FromStringToDelegationState
+func SupportedDelegationStates() []DelegationState {
+	return []DelegationState{
+		Active, UnbondingRequested, Unbonding, Unbonded,
+		Withdrawable, Withdrawn, Transitioned,
+	}
+}
+
 func FromStringToDelegationState(s string) (DelegationState, error) {
 	switch s {
 	case "active":
@@ -39,3 +47,30 @@ func FromStringToDelegationState(s string) (DelegationState, error) {
 		return "", fmt.Errorf("invalid delegation state: %s", s)
 	}
 }
+
+// DelegationStateInfo describes a delegation state to the clients
+type DelegationStateInfo struct {
+	State       DelegationState
+	Description string
+	// Terminal is true for the states a delegation never leaves
+	Terminal bool
+}
+
+// delegationStateRegistry lists every delegation state, a state missing from
+// it fails the registry tests
+var delegationStateRegistry = []DelegationStateInfo{
+	{State: Active, Description: "The staking tx is confirmed and the stake is active"},
+	{State: UnbondingRequested, Description: "The staker requested the unbonding, the unbonding tx is not confirmed yet"},
+	{State: Unbonding, Description: "The unbonding tx is confirmed, its timelock has not expired yet"},
+	{State: Unbonded, Description: "The staking or unbonding timelock expired, the funds can be withdrawn"},
+	{State: Withdrawable, Description: "Phase-2 only, the funds can be withdrawn"},
+	{State: Withdrawn, Description: "The funds were withdrawn", Terminal: true},
+	{State: Transitioned, Description: "The delegation was registered into phase-2", Terminal: true},
+	{State: Slashed, Description: "Phase-2 only, the finality provider was slashed along with the stake"},
+}
+
+// RegisteredDelegationStates returns every delegation state with its
+// description
+func RegisteredDelegationStates() []DelegationStateInfo {
+	return append([]DelegationStateInfo(nil), delegationStateRegistry...)
+}
@@ -13,15 +13,56 @@ func (e ErrorCode) String() string {
 
 const (
 	// 5XX
-	InternalServiceError ErrorCode = "INTERNAL_SERVICE_ERROR"
-	ValidationError      ErrorCode = "VALIDATION_ERROR"
-	NotFound             ErrorCode = "NOT_FOUND"
-	BadRequest           ErrorCode = "BAD_REQUEST"
-	Forbidden            ErrorCode = "FORBIDDEN"
-	UnprocessableEntity  ErrorCode = "UNPROCESSABLE_ENTITY"
-	RequestTimeout       ErrorCode = "REQUEST_TIMEOUT"
+	InternalServiceError   ErrorCode = "INTERNAL_SERVICE_ERROR"
+	ValidationError        ErrorCode = "VALIDATION_ERROR"
+	NotFound               ErrorCode = "NOT_FOUND"
+	BadRequest             ErrorCode = "BAD_REQUEST"
+	Unauthorized           ErrorCode = "UNAUTHORIZED"
+	Forbidden              ErrorCode = "FORBIDDEN"
+	UnprocessableEntity    ErrorCode = "UNPROCESSABLE_ENTITY"
+	RequestTimeout         ErrorCode = "REQUEST_TIMEOUT"
+	InvalidSignature       ErrorCode = "INVALID_SIGNATURE"
+	InvalidUnbondingTx     ErrorCode = "INVALID_UNBONDING_TX"
+	TxHashMismatch         ErrorCode = "TX_HASH_MISMATCH"
+	InvalidPaginationToken ErrorCode = "INVALID_PAGINATION_TOKEN"
+	PreconditionFailed     ErrorCode = "PRECONDITION_FAILED"
+	TooManyRequests        ErrorCode = "TOO_MANY_REQUESTS"
+	QueryCapacity          ErrorCode = "QUERY_CAPACITY"
+	PriceOracleUnavailable ErrorCode = "PRICE_ORACLE_UNAVAILABLE"
 )
 
+// ErrorCodeInfo describes an error code to the clients
+type ErrorCodeInfo struct {
+	Code        ErrorCode
+	Description string
+}
+
+// errorCodeRegistry lists every error code, a code missing from it fails the
+// registry tests
+var errorCodeRegistry = []ErrorCodeInfo{
+	{Code: InternalServiceError, Description: "Unexpected error of the service"},
+	{Code: ValidationError, Description: "The request failed the validation"},
+	{Code: NotFound, Description: "The requested resource does not exist"},
+	{Code: BadRequest, Description: "The request is malformed"},
+	{Code: Unauthorized, Description: "The admin token is missing or invalid"},
+	{Code: Forbidden, Description: "The request is not allowed"},
+	{Code: UnprocessableEntity, Description: "The request is well formed but cannot be processed"},
+	{Code: RequestTimeout, Description: "The request did not complete in time"},
+	{Code: InvalidSignature, Description: "The staker signature of the unbonding tx is invalid"},
+	{Code: InvalidUnbondingTx, Description: "The unbonding tx does not match the delegation"},
+	{Code: TxHashMismatch, Description: "The unbonding tx hash does not match the unbonding tx"},
+	{Code: InvalidPaginationToken, Description: "The pagination key is invalid"},
+	{Code: PreconditionFailed, Description: "The If-Match entity tag does not match the current delegation"},
+	{Code: TooManyRequests, Description: "The rate limit was exceeded, retry later"},
+	{Code: QueryCapacity, Description: "Too many aggregations in progress, retry later"},
+	{Code: PriceOracleUnavailable, Description: "The BTC price is not available"},
+}
+
+// RegisteredErrorCodes returns every error code with its description
+func RegisteredErrorCodes() []ErrorCodeInfo {
+	return append([]ErrorCodeInfo(nil), errorCodeRegistry...)
+}
+
 // Error represents an error with an HTTP status code and an application-specific error code.
 type Error struct {
 	Err        error
@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 )
@@ -25,13 +27,15 @@ type FinalityProviderDetails struct {
 	Description FinalityProviderDescription `json:"description"`
 	Commission  string                      `json:"commission"`
 	BtcPk       string                      `json:"btc_pk"`
+	LogoUrl     string                      `json:"logo_url"`
 }
 
 type FinalityProviderFromFile struct {
 	Description FinalityProviderDescription `json:"description"`
 	Commission  string                      `json:"commission"`
 	BtcPk       string                      `json:"btc_pk"`
-	EotsPk      string                      `json:"eots_pk"` // eots is the default field for the pk
+	EotsPk      string                      `json:"eots_pk"`  // eots is the default field for the pk
+	LogoUrl     string                      `json:"logo_url"` // optional http(s) URL of the logo
 }
 
 type FinalityProviders struct {
@@ -57,13 +61,27 @@ func NewFinalityProviders(path string) ([]FinalityProviderDetails, error) {
 		if btcPk == "" {
 			btcPk = fp.BtcPk
 		}
+		if fp.LogoUrl != "" && !IsValidLogoUrl(fp.LogoUrl) {
+			return nil, fmt.Errorf("invalid logo url for finality provider %s", btcPk)
+		}
 
 		finalityProviderDetails = append(finalityProviderDetails, FinalityProviderDetails{
 			Description: fp.Description,
 			Commission:  fp.Commission,
 			BtcPk:       btcPk,
+			LogoUrl:     fp.LogoUrl,
 		})
 	}
 
 	return finalityProviderDetails, nil
 }
+
+// IsValidLogoUrl returns true if the logo url is an http(s) url without
+// credentials
+func IsValidLogoUrl(logoUrl string) bool {
+	u, err := url.Parse(logoUrl)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "https" || u.Scheme == "http") && u.Host != "" && u.User == nil
+}
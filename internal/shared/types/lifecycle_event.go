@@ -0,0 +1,62 @@
+package types
+
+// LifecycleEventType is the type of the events of the staker event stream
+type LifecycleEventType string
+
+const (
+	DelegationActiveEvent       LifecycleEventType = "DELEGATION_ACTIVE"
+	UnbondingRequestedEvent     LifecycleEventType = "UNBONDING_REQUESTED"
+	UnbondingStartedEvent       LifecycleEventType = "UNBONDING_STARTED"
+	DelegationUnbondedEvent     LifecycleEventType = "DELEGATION_UNBONDED"
+	DelegationWithdrawableEvent LifecycleEventType = "DELEGATION_WITHDRAWABLE"
+	DelegationWithdrawnEvent    LifecycleEventType = "DELEGATION_WITHDRAWN"
+	DelegationTransitionedEvent LifecycleEventType = "DELEGATION_TRANSITIONED"
+	DelegationSlashedEvent      LifecycleEventType = "DELEGATION_SLASHED"
+	// StreamStaleEvent is sent when events were dropped
+	StreamStaleEvent LifecycleEventType = "STREAM_STALE"
+)
+
+// lifecycleEventTypes names the event of every state a delegation
+// transitions to
+var lifecycleEventTypes = map[DelegationState]LifecycleEventType{
+	Active:             DelegationActiveEvent,
+	UnbondingRequested: UnbondingRequestedEvent,
+	Unbonding:          UnbondingStartedEvent,
+	Unbonded:           DelegationUnbondedEvent,
+	Withdrawable:       DelegationWithdrawableEvent,
+	Withdrawn:          DelegationWithdrawnEvent,
+	Transitioned:       DelegationTransitionedEvent,
+	Slashed:            DelegationSlashedEvent,
+}
+
+// LifecycleEventTypeOf returns the type of the event of a delegation
+// transitioning to the state
+func LifecycleEventTypeOf(state DelegationState) LifecycleEventType {
+	return lifecycleEventTypes[state]
+}
+
+// LifecycleEventTypeInfo describes a lifecycle event type to the clients
+type LifecycleEventTypeInfo struct {
+	Type        LifecycleEventType
+	Description string
+	// Terminal is true for the events of the states a delegation never
+	// leaves
+	Terminal bool
+}
+
+// RegisteredLifecycleEventTypes returns every lifecycle event type with its
+// description, in the order of the delegation states it is sent for
+func RegisteredLifecycleEventTypes() []LifecycleEventTypeInfo {
+	infos := make([]LifecycleEventTypeInfo, 0, len(delegationStateRegistry)+1)
+	for _, state := range delegationStateRegistry {
+		infos = append(infos, LifecycleEventTypeInfo{
+			Type:        LifecycleEventTypeOf(state.State),
+			Description: "The delegation transitioned to the " + state.State.ToString() + " state",
+			Terminal:    state.Terminal,
+		})
+	}
+	return append(infos, LifecycleEventTypeInfo{
+		Type:        StreamStaleEvent,
+		Description: "Events were dropped, the delegations have to be reloaded",
+	})
+}
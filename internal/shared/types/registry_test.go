@@ -0,0 +1,75 @@
+package types
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// declaredConstants returns the values of the string constants of the
+// package declared with the given type
+func declaredConstants(t *testing.T, typeName string) []string {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), ".", func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	var values []string
+	for _, file := range pkgs["types"].Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			if ident, ok := spec.Type.(*ast.Ident); !ok || ident.Name != typeName {
+				return true
+			}
+			for _, value := range spec.Values {
+				lit, ok := value.(*ast.BasicLit)
+				require.True(t, ok, "constant of %s is not a literal", typeName)
+				unquoted, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				values = append(values, unquoted)
+			}
+			return true
+		})
+	}
+	require.NotEmpty(t, values, "no constant of %s found", typeName)
+	return values
+}
+
+func TestRegistriesAreComplete(t *testing.T) {
+	t.Run("delegation states", func(t *testing.T) {
+		var registered []string
+		for _, info := range RegisteredDelegationStates() {
+			require.NotEmpty(t, info.Description, info.State)
+			registered = append(registered, info.State.ToString())
+		}
+		assert.ElementsMatch(t, declaredConstants(t, "DelegationState"), registered)
+	})
+
+	t.Run("lifecycle event types", func(t *testing.T) {
+		var registered []string
+		for _, info := range RegisteredLifecycleEventTypes() {
+			require.NotEmpty(t, info.Type)
+			registered = append(registered, string(info.Type))
+		}
+		assert.ElementsMatch(t, declaredConstants(t, "LifecycleEventType"), registered)
+	})
+
+	t.Run("error codes", func(t *testing.T) {
+		var registered []string
+		for _, info := range RegisteredErrorCodes() {
+			require.NotEmpty(t, info.Description, info.Code)
+			registered = append(registered, info.Code.String())
+		}
+		assert.ElementsMatch(t, declaredConstants(t, "ErrorCode"), registered)
+	})
+}
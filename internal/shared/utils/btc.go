@@ -3,6 +3,7 @@ package utils
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
 	"github.com/babylonlabs-io/babylon/btcstaking"
@@ -14,11 +15,24 @@ import (
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 )
 
 const PublickKeyWithNoCoordinatesSize = 32
 
+var (
+	// ErrInvalidUnbondingSignature is returned by VerifyUnbondingRequest when the
+	// staker signature does not sign the unbonding tx over the staking output
+	ErrInvalidUnbondingSignature = errors.New("invalid unbonding signature")
+	// ErrInvalidUnbondingTx is returned by VerifyUnbondingRequest when the
+	// unbonding tx does not spend the staking output into the expected unbonding output
+	ErrInvalidUnbondingTx = errors.New("invalid unbonding tx")
+	// ErrUnbondingTxHashMismatch is returned by VerifyUnbondingRequest when the
+	// provided unbonding tx hash is not the hash of the provided unbonding tx
+	ErrUnbondingTxHashMismatch = errors.New("unbonding tx hash mismatch")
+)
+
 type publicKeyWithCoordinates struct {
 	odd  *btcec.PublicKey
 	even *btcec.PublicKey
@@ -40,6 +54,38 @@ func GetSchnorrPkFromHex(pkHex string) (*btcec.PublicKey, error) {
 	return schnorr.ParsePubKey(pkBytes)
 }
 
+// GetXOnlyOrCompressedPkFromHex parses public keys either in 32 bytes or
+// compressed in 33 bytes, in any case. Only the x coordinate of a compressed
+// key is kept as defined by BIP340, so that both forms give the same key. It
+// is meant for the keys given by the wallets or stored in either form.
+func GetXOnlyOrCompressedPkFromHex(pkHex string) (*btcec.PublicKey, error) {
+	pkBytes, err := hex.DecodeString(pkHex)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkBytes) == btcec.PubKeyBytesLenCompressed {
+		pk, err := btcec.ParsePubKey(pkBytes)
+		if err != nil {
+			return nil, err
+		}
+		pkBytes = schnorr.SerializePubKey(pk)
+	}
+
+	return schnorr.ParsePubKey(pkBytes)
+}
+
+// CanonicalSchnorrPkHex returns the lowercase hex of the 32 bytes x-only form
+// of a public key given either x-only or compressed in 33 bytes, in any case.
+// The parity byte of a compressed key is dropped.
+func CanonicalSchnorrPkHex(pkHex string) (string, error) {
+	pk, err := GetXOnlyOrCompressedPkFromHex(pkHex)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(schnorr.SerializePubKey(pk)), nil
+}
+
 // GetCovenantPksFromStrings parses BTC public keys in 33 bytes
 func GetCovenantPksFromStrings(pkStrings []string) ([]*btcec.PublicKey, error) {
 	pks := make([]*btcec.PublicKey, len(pkStrings))
@@ -89,7 +135,7 @@ func VerifyUnbondingRequest(
 	// 1. validate that un-bonding transaction has proper shape
 	unbondingTx, err := parseUnbondingTxHex(unbondingTxHex)
 	if err != nil {
-		return fmt.Errorf("invalid unbonding tx hex: %w", err)
+		return fmt.Errorf("%w: %v", ErrInvalidUnbondingTx, err)
 	}
 
 	// 2. validate that un-bonding tx hash is valid and matches the hash of the
@@ -103,7 +149,11 @@ func VerifyUnbondingRequest(
 	unbondingTxHashFromTx := unbondingTx.TxHash()
 
 	if !unbondingTxHashFromTx.IsEqual(unbondingTxHash) {
-		return fmt.Errorf("unbonding_tx_hash_hex must match the hash calculated from the provided unbonding tx")
+		return fmt.Errorf("%w: unbonding_tx_hash_hex must match the hash calculated from the provided unbonding tx, expected: %s, got: %s",
+			ErrUnbondingTxHashMismatch,
+			unbondingTxHashFromTx.String(),
+			unbondingTxHashHex,
+		)
 	}
 
 	// 3. validate the un-bonding transaction points to the previous staking tx
@@ -112,13 +162,15 @@ func VerifyUnbondingRequest(
 		return fmt.Errorf("failed to decode staking tx hash from hex: %w", err)
 	}
 	if !unbondingTx.TxIn[0].PreviousOutPoint.Hash.IsEqual(stakingTxHash) {
-		return fmt.Errorf("the unbonding tx input must match the previous staking tx hash, expected: %s, got: %s",
+		return fmt.Errorf("%w: the unbonding tx input must match the previous staking tx hash, expected: %s, got: %s",
+			ErrInvalidUnbondingTx,
 			stakingTxHashHex,
 			unbondingTx.TxIn[0].PreviousOutPoint.Hash.String(),
 		)
 	}
 	if uint64(unbondingTx.TxIn[0].PreviousOutPoint.Index) != stakingOutputIndex {
-		return fmt.Errorf("the unbonding tx input must match the previous staking tx output index, expected: %d, got: %d",
+		return fmt.Errorf("%w: the unbonding tx input must match the previous staking tx output index, expected: %d, got: %d",
+			ErrInvalidUnbondingTx,
 			stakingOutputIndex,
 			unbondingTx.TxIn[0].PreviousOutPoint.Index,
 		)
@@ -130,7 +182,9 @@ func VerifyUnbondingRequest(
 		return fmt.Errorf("failed to decode coveant public keys from strings: %w", err)
 	}
 
-	stakerPk, err := GetSchnorrPkFromHex(stakerPkHex)
+	// the delegations stored before the keys were normalized may hold the
+	// compressed staker key
+	stakerPk, err := GetXOnlyOrCompressedPkFromHex(stakerPkHex)
 	if err != nil {
 		return fmt.Errorf("failed to decode staker public key from hex: %w", err)
 	}
@@ -159,11 +213,96 @@ func VerifyUnbondingRequest(
 		return fmt.Errorf("failed to build unbonding info")
 	}
 
+	if unbondingTx.TxOut[0].Value != unbondingInfo.UnbondingOutput.Value {
+		return fmt.Errorf("%w: unbonding output value must be the staking value minus the unbonding fee, expected: %d, got: %d",
+			ErrInvalidUnbondingTx,
+			unbondingInfo.UnbondingOutput.Value,
+			unbondingTx.TxOut[0].Value,
+		)
+	}
 	if !outputsAreEqual(unbondingInfo.UnbondingOutput, unbondingTx.TxOut[0]) {
-		return fmt.Errorf("unbonding output does not match expected output")
+		return fmt.Errorf("%w: unbonding output script does not match the expected unbonding timelock script", ErrInvalidUnbondingTx)
 	}
 
 	// 5. verify the signature
+	sigBytes, err := hex.DecodeString(unbondingSigHex)
+	if err != nil {
+		return fmt.Errorf("%w: failed to decode signature from hex", ErrInvalidUnbondingSignature)
+	}
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidUnbondingSignature, err)
+	}
+	sigHash, err := computeUnbondingSigHash(
+		unbondingTx, stakerPk, finalityProviderPk, covenantPks, stakingTimeLock, stakingValue, params, btcNetParam,
+	)
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(sigHash.SigHash, stakerPk) {
+		return fmt.Errorf("%w: signature is not valid", ErrInvalidUnbondingSignature)
+	}
+	return nil
+}
+
+// UnbondingSigHash is the message the staker signs to unbond, along with the
+// inputs it is computed from
+type UnbondingSigHash struct {
+	// SigHash is the BIP341 script path sighash of the unbonding tx, with the
+	// default sighash type
+	SigHash []byte
+	// Script is the unbonding path script of the staking output
+	Script []byte
+	// LeafHash is the tapleaf hash of the script
+	LeafHash []byte
+	// StakingOutput is the output spent by the unbonding tx
+	StakingOutput *wire.TxOut
+}
+
+// ComputeUnbondingSigHash computes the sighash the staker signature of the
+// unbonding tx is verified against by VerifyUnbondingRequest. The unbonding tx
+// must be a valid pre-signed unbonding tx but is not checked against the
+// staking tx.
+func ComputeUnbondingSigHash(
+	unbondingTxHex,
+	stakerPkHex,
+	finalityProviderPkHex string,
+	stakingTimeLock,
+	stakingValue uint64,
+	params *types.VersionedGlobalParams,
+	btcNetParam *chaincfg.Params,
+) (*UnbondingSigHash, error) {
+	unbondingTx, err := parseUnbondingTxHex(unbondingTxHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUnbondingTx, err)
+	}
+	covenantPks, err := GetCovenantPksFromStrings(params.CovenantPks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode coveant public keys from strings: %w", err)
+	}
+	stakerPk, err := GetXOnlyOrCompressedPkFromHex(stakerPkHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode staker public key from hex: %w", err)
+	}
+	finalityProviderPk, err := GetSchnorrPkFromHex(finalityProviderPkHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode finality provider public key from hex: %w", err)
+	}
+	return computeUnbondingSigHash(
+		unbondingTx, stakerPk, finalityProviderPk, covenantPks, stakingTimeLock, stakingValue, params, btcNetParam,
+	)
+}
+
+func computeUnbondingSigHash(
+	unbondingTx *wire.MsgTx,
+	stakerPk *btcec.PublicKey,
+	finalityProviderPk *btcec.PublicKey,
+	covenantPks []*btcec.PublicKey,
+	stakingTimeLock,
+	stakingValue uint64,
+	params *types.VersionedGlobalParams,
+	btcNetParam *chaincfg.Params,
+) (*UnbondingSigHash, error) {
 	stakingInfo, err := btcstaking.BuildStakingInfo(
 		stakerPk,
 		[]*btcec.PublicKey{finalityProviderPk},
@@ -174,26 +313,32 @@ func VerifyUnbondingRequest(
 		btcNetParam,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to build staking info")
-	}
-	sigBytes, err := hex.DecodeString(unbondingSigHex)
-	if err != nil {
-		return fmt.Errorf("failed to decode unbonding signature from hex")
+		return nil, fmt.Errorf("failed to build staking info")
 	}
 	unbondingSpendInfo, err := stakingInfo.UnbondingPathSpendInfo()
 	if err != nil {
-		return fmt.Errorf("failed to build unbonding path spend info")
+		return nil, fmt.Errorf("failed to build unbonding path spend info")
 	}
-	if err := btcstaking.VerifyTransactionSigWithOutput(
-		unbondingTx,
-		stakingInfo.StakingOutput,
-		unbondingSpendInfo.GetPkScriptPath(),
-		stakerPk,
-		sigBytes,
-	); err != nil {
-		return fmt.Errorf("invalid unbonding signature")
+
+	script := unbondingSpendInfo.GetPkScriptPath()
+	tapLeaf := txscript.NewBaseTapLeaf(script)
+	fetcher := txscript.NewCannedPrevOutputFetcher(
+		stakingInfo.StakingOutput.PkScript, stakingInfo.StakingOutput.Value,
+	)
+	sigHash, err := txscript.CalcTapscriptSignaturehash(
+		txscript.NewTxSigHashes(unbondingTx, fetcher), txscript.SigHashDefault, unbondingTx, 0, fetcher, tapLeaf,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the unbonding sighash: %w", err)
 	}
-	return nil
+
+	leafHash := tapLeaf.TapHash()
+	return &UnbondingSigHash{
+		SigHash:       sigHash,
+		Script:        script,
+		LeafHash:      leafHash[:],
+		StakingOutput: stakingInfo.StakingOutput,
+	}, nil
 }
 
 func outputsAreEqual(a *wire.TxOut, b *wire.TxOut) bool {
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// SatoshisPerBtc is the number of satoshis in one BTC
+	SatoshisPerBtc = 100_000_000
+	btcDecimals    = 8
+)
+
+// FormatSatoshisAsBtc formats an amount of satoshis as an exact BTC decimal
+// string with all the 8 decimals, e.g. 1 sat is "0.00000001".
+// Only integer math is used so no precision is ever lost.
+func FormatSatoshisAsBtc(sat int64) string {
+	if sat < 0 {
+		// -(sat+1)+1 avoids the overflow when negating math.MinInt64
+		return "-" + FormatUnsignedSatoshisAsBtc(uint64(-(sat+1))+1)
+	}
+	return FormatUnsignedSatoshisAsBtc(uint64(sat))
+}
+
+// FormatUnsignedSatoshisAsBtc is the uint64 counterpart of FormatSatoshisAsBtc
+func FormatUnsignedSatoshisAsBtc(sat uint64) string {
+	whole := sat / SatoshisPerBtc
+	fraction := sat % SatoshisPerBtc
+	return strconv.FormatUint(whole, 10) + "." + fmt.Sprintf("%0*d", btcDecimals, fraction)
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSatoshisAsBtc(t *testing.T) {
+	tests := []struct {
+		name     string
+		sat      int64
+		expected string
+	}{
+		{"zero", 0, "0.00000000"},
+		{"one sat", 1, "0.00000001"},
+		{"ten sats", 10, "0.00000010"},
+		{"just below one btc", 99_999_999, "0.99999999"},
+		{"one btc", 100_000_000, "1.00000000"},
+		{"one btc and one sat", 100_000_001, "1.00000001"},
+		{"fractional btc", 123_456_789, "1.23456789"},
+		{"21M btc", 21_000_000 * SatoshisPerBtc, "21000000.00000000"},
+		{"21M btc minus one sat", 21_000_000*SatoshisPerBtc - 1, "20999999.99999999"},
+		{"minus one sat", -1, "-0.00000001"},
+		{"minus one btc", -100_000_000, "-1.00000000"},
+		{"max int64", math.MaxInt64, "92233720368.54775807"},
+		{"min int64", math.MinInt64, "-92233720368.54775808"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatSatoshisAsBtc(tt.sat))
+		})
+	}
+}
+
+func TestFormatUnsignedSatoshisAsBtc(t *testing.T) {
+	assert.Equal(t, "0.00000001", FormatUnsignedSatoshisAsBtc(1))
+	assert.Equal(t, "21000000.00000000", FormatUnsignedSatoshisAsBtc(21_000_000*SatoshisPerBtc))
+	assert.Equal(t, "184467440737.09551615", FormatUnsignedSatoshisAsBtc(math.MaxUint64))
+}
@@ -0,0 +1,330 @@
+package utils
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/btcstaking"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStakingValue    = uint64(1_000_000)
+	testStakingTimeLock = uint64(1000)
+)
+
+// unbondingFixture holds a staking tx and a matching unsigned unbonding tx
+// built the same way as a wallet would build them.
+type unbondingFixture struct {
+	stakerPrivKey    *btcec.PrivateKey
+	fpPkHex          string
+	params           *types.VersionedGlobalParams
+	stakingTxHashHex string
+	stakingInfo      *btcstaking.StakingInfo
+	unbondingTx      *wire.MsgTx
+}
+
+func newUnbondingFixture(t *testing.T) *unbondingFixture {
+	net := &chaincfg.SigNetParams
+
+	stakerPrivKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	fpPrivKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	var covenantPks []*btcec.PublicKey
+	var covenantPkHexes []string
+	for i := 0; i < 3; i++ {
+		covenantPrivKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		covenantPks = append(covenantPks, covenantPrivKey.PubKey())
+		covenantPkHexes = append(covenantPkHexes, hex.EncodeToString(covenantPrivKey.PubKey().SerializeCompressed()))
+	}
+
+	params := &types.VersionedGlobalParams{
+		CovenantPks:    covenantPkHexes,
+		CovenantQuorum: 2,
+		UnbondingTime:  101,
+		UnbondingFee:   10_000,
+	}
+
+	stakingInfo, err := btcstaking.BuildStakingInfo(
+		stakerPrivKey.PubKey(),
+		[]*btcec.PublicKey{fpPrivKey.PubKey()},
+		covenantPks,
+		uint32(params.CovenantQuorum),
+		uint16(testStakingTimeLock),
+		btcutil.Amount(testStakingValue),
+		net,
+	)
+	require.NoError(t, err)
+
+	stakingTx := wire.NewMsgTx(2)
+	stakingTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	stakingTx.AddTxOut(stakingInfo.StakingOutput)
+	stakingTxHash := stakingTx.TxHash()
+
+	unbondingInfo, err := btcstaking.BuildUnbondingInfo(
+		stakerPrivKey.PubKey(),
+		[]*btcec.PublicKey{fpPrivKey.PubKey()},
+		covenantPks,
+		uint32(params.CovenantQuorum),
+		uint16(params.UnbondingTime),
+		btcutil.Amount(testStakingValue-params.UnbondingFee),
+		net,
+	)
+	require.NoError(t, err)
+
+	unbondingTx := wire.NewMsgTx(2)
+	unbondingTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&stakingTxHash, 0), nil, nil))
+	unbondingTx.AddTxOut(unbondingInfo.UnbondingOutput)
+
+	return &unbondingFixture{
+		stakerPrivKey:    stakerPrivKey,
+		fpPkHex:          hex.EncodeToString(schnorr.SerializePubKey(fpPrivKey.PubKey())),
+		params:           params,
+		stakingTxHashHex: stakingTxHash.String(),
+		stakingInfo:      stakingInfo,
+		unbondingTx:      unbondingTx,
+	}
+}
+
+// sign signs the unbonding tx over the unbonding path of the staking output
+// using the given sighash type and returns the 64 bytes schnorr signature.
+func (f *unbondingFixture) sign(t *testing.T, hashType txscript.SigHashType) string {
+	spendInfo, err := f.stakingInfo.UnbondingPathSpendInfo()
+	require.NoError(t, err)
+
+	fetcher := txscript.NewCannedPrevOutputFetcher(
+		f.stakingInfo.StakingOutput.PkScript, f.stakingInfo.StakingOutput.Value,
+	)
+	sig, err := txscript.RawTxInTapscriptSignature(
+		f.unbondingTx, txscript.NewTxSigHashes(f.unbondingTx, fetcher), 0,
+		f.stakingInfo.StakingOutput.Value, f.stakingInfo.StakingOutput.PkScript,
+		txscript.NewBaseTapLeaf(spendInfo.GetPkScriptPath()), hashType, f.stakerPrivKey,
+	)
+	require.NoError(t, err)
+
+	// drop the sighash flag appended for non default sighash types
+	return hex.EncodeToString(sig[:schnorr.SignatureSize])
+}
+
+func (f *unbondingFixture) verify(t *testing.T, stakerPkHex, sigHex string) error {
+	unbondingTxHex, err := bbntypes.SerializeBTCTx(f.unbondingTx)
+	require.NoError(t, err)
+
+	return VerifyUnbondingRequest(
+		f.stakingTxHashHex,
+		f.unbondingTx.TxHash().String(),
+		hex.EncodeToString(unbondingTxHex),
+		stakerPkHex,
+		f.fpPkHex,
+		sigHex,
+		testStakingTimeLock,
+		0,
+		testStakingValue,
+		f.params,
+		&chaincfg.SigNetParams,
+	)
+}
+
+func TestVerifyUnbondingRequestSignature(t *testing.T) {
+	f := newUnbondingFixture(t)
+	xOnlyStakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey()))
+	compressedStakerPkHex := hex.EncodeToString(f.stakerPrivKey.PubKey().SerializeCompressed())
+
+	t.Run("valid signature with x-only staker pk", func(t *testing.T) {
+		err := f.verify(t, xOnlyStakerPkHex, f.sign(t, txscript.SigHashDefault))
+		assert.NoError(t, err)
+	})
+
+	t.Run("valid signature with compressed staker pk", func(t *testing.T) {
+		err := f.verify(t, compressedStakerPkHex, f.sign(t, txscript.SigHashDefault))
+		assert.NoError(t, err)
+	})
+
+	t.Run("signature over the wrong sighash type", func(t *testing.T) {
+		err := f.verify(t, xOnlyStakerPkHex, f.sign(t, txscript.SigHashAll))
+		assert.ErrorIs(t, err, ErrInvalidUnbondingSignature)
+	})
+
+	t.Run("garbage signature", func(t *testing.T) {
+		err := f.verify(t, xOnlyStakerPkHex, "1234567890abcdef")
+		assert.ErrorIs(t, err, ErrInvalidUnbondingSignature)
+	})
+
+	t.Run("signature by another key", func(t *testing.T) {
+		otherPrivKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		other := *f
+		other.stakerPrivKey = otherPrivKey
+		err = f.verify(t, xOnlyStakerPkHex, other.sign(t, txscript.SigHashDefault))
+		assert.ErrorIs(t, err, ErrInvalidUnbondingSignature)
+	})
+}
+
+func TestVerifyUnbondingRequestTxStructure(t *testing.T) {
+	t.Run("valid unbonding tx", func(t *testing.T) {
+		f := newUnbondingFixture(t)
+		stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey()))
+		assert.NoError(t, f.verify(t, stakerPkHex, f.sign(t, txscript.SigHashDefault)))
+	})
+
+	t.Run("unbonding tx with multiple inputs", func(t *testing.T) {
+		f := newUnbondingFixture(t)
+		stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey()))
+		sigHex := f.sign(t, txscript.SigHashDefault)
+		f.unbondingTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{2}, 0), nil, nil))
+		assert.ErrorIs(t, f.verify(t, stakerPkHex, sigHex), ErrInvalidUnbondingTx)
+	})
+
+	t.Run("unbonding tx spending another output", func(t *testing.T) {
+		f := newUnbondingFixture(t)
+		stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey()))
+		f.unbondingTx.TxIn[0].PreviousOutPoint.Index = 1
+		assert.ErrorIs(t, f.verify(t, stakerPkHex, f.sign(t, txscript.SigHashDefault)), ErrInvalidUnbondingTx)
+	})
+
+	t.Run("unbonding tx with wrong output value", func(t *testing.T) {
+		f := newUnbondingFixture(t)
+		stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey()))
+		f.unbondingTx.TxOut[0].Value -= 1
+		err := f.verify(t, stakerPkHex, f.sign(t, txscript.SigHashDefault))
+		assert.ErrorIs(t, err, ErrInvalidUnbondingTx)
+		assert.ErrorContains(t, err, "unbonding output value")
+	})
+
+	t.Run("unbonding tx with wrong timelock script", func(t *testing.T) {
+		f := newUnbondingFixture(t)
+		stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey()))
+		f.params.UnbondingTime++
+		err := f.verify(t, stakerPkHex, f.sign(t, txscript.SigHashDefault))
+		assert.ErrorIs(t, err, ErrInvalidUnbondingTx)
+		assert.ErrorContains(t, err, "timelock script")
+	})
+
+	t.Run("valid unbonding tx with mismatching hash", func(t *testing.T) {
+		f := newUnbondingFixture(t)
+		stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey()))
+		unbondingTxHex, err := bbntypes.SerializeBTCTx(f.unbondingTx)
+		require.NoError(t, err)
+
+		err = VerifyUnbondingRequest(
+			f.stakingTxHashHex,
+			f.stakingTxHashHex, // not the hash of the unbonding tx
+			hex.EncodeToString(unbondingTxHex),
+			stakerPkHex,
+			f.fpPkHex,
+			f.sign(t, txscript.SigHashDefault),
+			testStakingTimeLock,
+			0,
+			testStakingValue,
+			f.params,
+			&chaincfg.SigNetParams,
+		)
+		assert.ErrorIs(t, err, ErrUnbondingTxHashMismatch)
+	})
+}
+
+func TestComputeUnbondingSigHash(t *testing.T) {
+	f := newUnbondingFixture(t)
+	stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey()))
+	unbondingTxBytes, err := bbntypes.SerializeBTCTx(f.unbondingTx)
+	require.NoError(t, err)
+
+	sigHash, err := ComputeUnbondingSigHash(
+		hex.EncodeToString(unbondingTxBytes), stakerPkHex, f.fpPkHex,
+		testStakingTimeLock, testStakingValue, f.params, &chaincfg.SigNetParams,
+	)
+	require.NoError(t, err)
+
+	spendInfo, err := f.stakingInfo.UnbondingPathSpendInfo()
+	require.NoError(t, err)
+	leafHash := spendInfo.RevealedLeaf.TapHash()
+	assert.Equal(t, spendInfo.GetPkScriptPath(), sigHash.Script)
+	assert.Equal(t, leafHash[:], sigHash.LeafHash)
+	assert.Equal(t, f.stakingInfo.StakingOutput, sigHash.StakingOutput)
+
+	t.Run("wallet signature signs the sighash", func(t *testing.T) {
+		sigBytes, err := hex.DecodeString(f.sign(t, txscript.SigHashDefault))
+		require.NoError(t, err)
+		sig, err := schnorr.ParseSignature(sigBytes)
+		require.NoError(t, err)
+		assert.True(t, sig.Verify(sigHash.SigHash, f.stakerPrivKey.PubKey()))
+	})
+
+	t.Run("signature of the sighash passes the verification", func(t *testing.T) {
+		sig, err := schnorr.Sign(f.stakerPrivKey, sigHash.SigHash)
+		require.NoError(t, err)
+		assert.NoError(t, f.verify(t, stakerPkHex, hex.EncodeToString(sig.Serialize())))
+	})
+
+	t.Run("invalid unbonding tx", func(t *testing.T) {
+		_, err := ComputeUnbondingSigHash(
+			"00", stakerPkHex, f.fpPkHex,
+			testStakingTimeLock, testStakingValue, f.params, &chaincfg.SigNetParams,
+		)
+		assert.ErrorIs(t, err, ErrInvalidUnbondingTx)
+	})
+}
+
+func TestCanonicalSchnorrPkHex(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	xOnlyPkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	compressedPkHex := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+
+	for _, pkHex := range []string{
+		xOnlyPkHex,
+		compressedPkHex,
+		strings.ToUpper(xOnlyPkHex),
+		strings.ToUpper(compressedPkHex),
+	} {
+		canonicalPkHex, err := CanonicalSchnorrPkHex(pkHex)
+		require.NoError(t, err, pkHex)
+		assert.Equal(t, xOnlyPkHex, canonicalPkHex)
+	}
+
+	for name, pkHex := range map[string]string{
+		"31 bytes":       xOnlyPkHex[2:],
+		"34 bytes":       compressedPkHex + "00",
+		"uncompressed":   hex.EncodeToString(privKey.PubKey().SerializeUncompressed()),
+		"invalid prefix": "04" + xOnlyPkHex,
+		"not hex":        "zz" + xOnlyPkHex[2:],
+	} {
+		_, err := CanonicalSchnorrPkHex(pkHex)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestGetSchnorrPkFromHex(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	xOnlyPkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	compressedPkHex := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+
+	pk, err := GetSchnorrPkFromHex(xOnlyPkHex)
+	require.NoError(t, err)
+	assert.Equal(t, xOnlyPkHex, hex.EncodeToString(schnorr.SerializePubKey(pk)))
+	_, err = GetSchnorrPkFromHex(compressedPkHex)
+	assert.Error(t, err, "only x-only keys are accepted")
+
+	for _, pkHex := range []string{xOnlyPkHex, compressedPkHex, strings.ToUpper(compressedPkHex)} {
+		pk, err := GetXOnlyOrCompressedPkFromHex(pkHex)
+		require.NoError(t, err, pkHex)
+		assert.Equal(t, xOnlyPkHex, hex.EncodeToString(schnorr.SerializePubKey(pk)))
+	}
+	_, err = GetXOnlyOrCompressedPkFromHex("04" + xOnlyPkHex)
+	assert.Error(t, err)
+}
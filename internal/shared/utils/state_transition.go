@@ -36,8 +36,10 @@ func OutdatedStatesForUnbonded() []types.DelegationState {
 }
 
 // QualifiedStatesToWithdrawn returns the qualified exisitng states to transition to "withdrawn"
+// The Unbonding state is allowed as the withdrawal can be confirmed before the
+// expiry of the unbonding timelock has been processed
 func QualifiedStatesToWithdraw() []types.DelegationState {
-	return []types.DelegationState{types.Unbonded}
+	return []types.DelegationState{types.Unbonded, types.Unbonding}
 }
 
 func OutdatedStatesForWithdraw() []types.DelegationState {
@@ -48,3 +50,12 @@ func OutdatedStatesForWithdraw() []types.DelegationState {
 func QualifiedStatesToTransitioned() []types.DelegationState {
 	return []types.DelegationState{types.Active, types.UnbondingRequested}
 }
+
+// CompatibleStatesForUnbondingDocument returns the delegation states a
+// delegation can be in once an unbonding request has been saved for it
+func CompatibleStatesForUnbondingDocument() []types.DelegationState {
+	return []types.DelegationState{
+		types.UnbondingRequested, types.Unbonding, types.Unbonded,
+		types.Withdrawn, types.Transitioned,
+	}
+}
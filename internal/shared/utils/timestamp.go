@@ -19,3 +19,15 @@ func GetTodayStartTimestampInSeconds() int64 {
 	// Convert the start of today to a Unix timestamp in seconds
 	return startOfDay.Unix()
 }
+
+// EstimateHeightTimestamp estimates when the BTC chain reaches the target
+// height, assuming a block every blockInterval from the current height.
+// Heights already reached are estimated as now.
+func EstimateHeightTimestamp(
+	currentHeight, targetHeight uint64, blockInterval time.Duration, now time.Time,
+) time.Time {
+	if targetHeight <= currentHeight {
+		return now
+	}
+	return now.Add(time.Duration(targetHeight-currentHeight) * blockInterval)
+}
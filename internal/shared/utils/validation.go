@@ -13,6 +13,11 @@ import (
 // IsValidTxHash checks if the given string is a valid BTC transaction hash
 // Note: it does not check the actual content of the hash.
 func IsValidTxHash(txHash string) bool {
+	// chainhash accepts hashes shorter than the full 32 bytes, a tx hash
+	// must always be exactly 64 hex characters
+	if len(txHash) != chainhash.MaxHashStringSize {
+		return false
+	}
 	// Check if the hash is valid
 	_, err := chainhash.NewHashFromStr(txHash)
 	return err == nil
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidTxHash(t *testing.T) {
+	validHash := strings.Repeat("ab", 32)
+	assert.True(t, IsValidTxHash(validHash))
+	assert.False(t, IsValidTxHash(validHash[:62]), "short hash")
+	assert.False(t, IsValidTxHash(validHash+"ab"), "long hash")
+	assert.False(t, IsValidTxHash(strings.Repeat("zz", 32)), "not hex")
+	assert.False(t, IsValidTxHash(""))
+}
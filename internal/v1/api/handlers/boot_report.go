@@ -0,0 +1,31 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/bootreport"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// GetBootReport @Summary Get boot report
+// @Description Internal endpoint returning what the instance loaded at boot: config hash,
+// @Description params versions, finality providers count, db topology, enabled features
+// @Description and index bootstrap results. Credentials are redacted.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {object} handler.PublicResponse[bootreport.Report] "Boot report"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/internal/boot-report [get]
+func (h *V1Handler) GetBootReport(request *http.Request) (*handler.Result, *types.Error) {
+	report := bootreport.Current()
+	if report == nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusNotFound, types.NotFound, "boot report is not available",
+		)
+	}
+	return handler.NewResult(report), nil
+}
@@ -0,0 +1,20 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// GetConstants @Summary Get the API constants
+// @Description Retrieves the canonical lists of the delegation states, unbonding attempt states,
+// @Description activity types of the staker event stream and error codes, each with a short description.
+// @Description The states and activity types are marked terminal when no delegation or unbonding attempt leaves them.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[v1service.ConstantsPublic] "API constants"
+// @Router /v1/constants [get]
+func (h *V1Handler) GetConstants(request *http.Request) (*handler.Result, *types.Error) {
+	return handler.NewResult(h.Service.GetConstants()), nil
+}
@@ -1,10 +1,13 @@
 package v1handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
 )
 
 // GetDelegationByTxHash @Summary Get a delegation (Deprecated)
@@ -13,18 +16,285 @@ import (
 // @Tags v1
 // @Deprecated
 // @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Param include_formatted query boolean false "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts"
+// @Param include_history query boolean false "Include the state transitions of the delegation, oldest first"
 // @Success 200 {object} handler.PublicResponse[v1service.DelegationPublic] "Delegation"
+// @Header 200 {string} ETag "Entity tag of the delegation, to be sent as If-Match on POST /v1/unbonding"
 // @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
 // @Router /v1/delegation [get]
 func (h *V1Handler) GetDelegationByTxHash(request *http.Request) (*handler.Result, *types.Error) {
 	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
 	if err != nil {
 		return nil, err
 	}
+	includeFormatted, err := handler.ParseBooleanQuery(request, "include_formatted", true)
+	if err != nil {
+		return nil, err
+	}
+	includeHistory, err := handler.ParseBooleanQuery(request, "include_history", true)
+	if err != nil {
+		return nil, err
+	}
 	delegation, err := h.Service.GetDelegation(request.Context(), stakingTxHash)
 	if err != nil {
 		return nil, err
 	}
+	if includeFormatted {
+		delegation.AddFormattedFields()
+	}
+	if includeHistory {
+		delegation.AddStateHistory()
+	}
+	h.Service.AddUnbondingCompletions(request.Context(), delegation)
+
+	result := handler.NewResult(delegation)
+	result.ETag = v1service.DelegationETag(delegation.StakingTxHashHex, delegation.State)
+	return result, nil
+}
+
+// defaultBlocksRemaining is about a day of BTC blocks
+const defaultBlocksRemaining = 144
+
+// parseBlocksRemainingQuery returns defaultBlocksRemaining if the
+// blocks_remaining query is not set
+func parseBlocksRemainingQuery(r *http.Request) (uint64, *types.Error) {
+	value := r.URL.Query().Get("blocks_remaining")
+	if value == "" {
+		return defaultBlocksRemaining, nil
+	}
+	blocks, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || blocks == 0 {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid blocks_remaining value: %s", value),
+		)
+	}
+	return blocks, nil
+}
+
+// GetDelegationsNearCovenantExpiry @Summary Get delegations near covenant expiry
+// @Description Retrieves the delegations whose unbonding was requested and whose staking timelock
+// @Description expires in less than the given number of blocks from the current BTC tip height.
+// @Description Their unbonding tx can no longer be broadcast once the timelock expired.
+// @Produce json
+// @Tags v1
+// @Param blocks_remaining query integer false "Number of blocks before the staking timelock expiry, defaults to 144"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.NearCovenantExpiryDelegationPublic] "Delegations near covenant expiry"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 503 {object} types.Error "The BTC tip height is not known yet"
+// @Router /v1/delegations/near-covenant-expiry [get]
+func (h *V1Handler) GetDelegationsNearCovenantExpiry(request *http.Request) (*handler.Result, *types.Error) {
+	blocksRemaining, err := parseBlocksRemainingQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, paginationToken, err := h.Service.GetDelegationsNearCovenantExpiry(
+		request.Context(), blocksRemaining, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(delegations, paginationToken), nil
+}
+
+// defaultBlocksAhead is about a week of BTC blocks
+const defaultBlocksAhead = 1008
+
+// parseBlocksAheadQuery returns defaultBlocksAhead if the blocks_ahead query
+// is not set
+func parseBlocksAheadQuery(r *http.Request) (uint64, *types.Error) {
+	value := r.URL.Query().Get("blocks_ahead")
+	if value == "" {
+		return defaultBlocksAhead, nil
+	}
+	blocks, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || blocks == 0 {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid blocks_ahead value: %s", value),
+		)
+	}
+	return blocks, nil
+}
+
+// GetProviderChangeCandidates @Summary Get provider change candidates
+// @Description Retrieves the active delegations of the staker whose staking timelock expires in less than
+// @Description the given number of blocks from the current BTC tip height, the earliest expiring first.
+// @Description Each of them comes with the registered finality provider with the highest active TVL other
+// @Description than its current one, as a suggestion to stake to once withdrawn.
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param blocks_ahead query integer false "Number of blocks before the staking timelock expiry, defaults to 1008"
+// @Success 200 {object} handler.PublicResponse[[]v1service.ProviderChangeCandidatePublic] "Delegations expiring soon with a suggested finality provider"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 503 {object} types.Error "The BTC tip height is not known yet"
+// @Router /v1/delegations/provider-change-candidates [get]
+func (h *V1Handler) GetProviderChangeCandidates(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	blocksAhead, err := parseBlocksAheadQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := h.Service.GetProviderChangeCandidates(request.Context(), stakerBtcPk, blocksAhead)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(candidates), nil
+}
+
+// GetReactivationCandidates @Summary Get reactivation candidates
+// @Description Retrieves the withdrawn delegations of the staker, whose funds can be staked again,
+// @Description the most recently withdrawn first.
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.ReactivationCandidatePublic] "Withdrawn delegations of the staker"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/delegations/reactivation-candidates [get]
+func (h *V1Handler) GetReactivationCandidates(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, paginationToken, err := h.Service.GetReactivationCandidates(
+		request.Context(), stakerBtcPk, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(candidates, paginationToken), nil
+}
+
+// GetDelegationsByWithdrawalAddress @Summary Get delegations by withdrawal address
+// @Description Retrieves the phase-1 delegations whose funds were withdrawn to the given BTC address,
+// @Description to aggregate the delegations of several wallets by their destination.
+// @Produce json
+// @Tags v1
+// @Param address query string true "Withdrawal BTC address in Taproot/Native Segwit format"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.DelegationPublic] "Delegations withdrawn to the address"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/delegations/by-withdrawal-address [get]
+func (h *V1Handler) GetDelegationsByWithdrawalAddress(request *http.Request) (*handler.Result, *types.Error) {
+	address, err := handler.ParseBtcAddressQuery(request, "address", h.Handler.Config.Server.BTCNetParam)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
 
-	return handler.NewResult(delegation), nil
+	delegations, paginationToken, err := h.Service.DelegationsByWithdrawalAddress(
+		request.Context(), address, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(delegations, paginationToken), nil
+}
+
+// GetDelegationsCreatedByBlock @Summary Get delegations created in a BTC block
+// @Description Retrieves the phase-1 delegations whose staking tx is included in the BTC block of the given height.
+// @Produce json
+// @Tags v1
+// @Param height query integer true "BTC block height"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.DelegationPublic] "Delegations created in the block"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/delegations/created-by-block [get]
+func (h *V1Handler) GetDelegationsCreatedByBlock(request *http.Request) (*handler.Result, *types.Error) {
+	height, err := parseHeightQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	if height == nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "height is required")
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, paginationToken, err := h.Service.DelegationsByStartHeight(
+		request.Context(), *height, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(delegations, paginationToken), nil
+}
+
+// GetStakingDurationStats @Summary Get staking duration statistics
+// @Description Retrieves the average, median and 95th percentile of the staking timelock
+// @Description of the active phase-1 delegations, their intended staking duration in BTC blocks.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[v1service.StakingDurationStatsPublic] "Staking duration statistics"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/delegations/avg-staking-duration [get]
+func (h *V1Handler) GetStakingDurationStats(request *http.Request) (*handler.Result, *types.Error) {
+	stats, err := h.Service.GetStakingDurationStats(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(stats), nil
+}
+
+// GetDelegationValueConcentration @Summary Get delegation value concentration
+// @Description Retrieves the Gini coefficient of the staking values of the active phase-1 delegations,
+// @Description from 0 when they all stake the same value to 1 when a single one holds all the stake.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[v1service.ValueConcentrationPublic] "Delegation value concentration"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/delegations/value-concentration [get]
+func (h *V1Handler) GetDelegationValueConcentration(request *http.Request) (*handler.Result, *types.Error) {
+	concentration, err := h.Service.GetDelegationValueConcentration(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(concentration), nil
+}
+
+// GetCoStakers @Summary Get co-stakers of a delegation
+// @Description Retrieves the number of other stakers with active phase-1 delegations to the finality provider
+// @Description of the given delegation, and the 5 of them with the highest active tvl.
+// @Produce json
+// @Tags v1
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 {object} handler.PublicResponse[v1service.CoStakersPublic] "Co-stakers of the delegation"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/delegations/co-stakers [get]
+func (h *V1Handler) GetCoStakers(request *http.Request) (*handler.Result, *types.Error) {
+	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+
+	coStakers, err := h.Service.GetCoStakers(request.Context(), stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(coStakers), nil
 }
@@ -0,0 +1,136 @@
+package v1handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/events"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// delegationStreamHeartbeatInterval keeps idle streams from being closed by
+// the proxies in front of the service
+const delegationStreamHeartbeatInterval = 30 * time.Second
+
+const (
+	delegationStateChangeEvent = "delegation_state_change"
+	delegationStaleEvent       = "stale"
+)
+
+// lifecycleEvent is the data of the messages of the staker event stream
+type lifecycleEvent struct {
+	Type types.LifecycleEventType `json:"type"`
+	*events.DelegationStateChange
+}
+
+// StreamStakerDelegations @Summary Stream phase-1 staker delegation state changes
+// @Description Server-sent events stream of the state changes of the delegations of a staker, held open until the client disconnects.
+// @Description Every change is sent as a delegation_state_change event whose data holds the staking_tx_hash_hex, staker_pk_hex, new state and unix timestamp of the change, a change may be sent more than once.
+// @Description A stale event is sent when changes were dropped because the client did not keep up, the delegations have to be reloaded.
+// @Description A heartbeat comment is sent every 30 seconds.
+// @Produce text/event-stream
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {string} string "Stream of server-sent events"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/delegations/stream [get]
+func (h *V1Handler) StreamStakerDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.streamDelegationStateChanges(request.Context(), stakerBtcPk,
+		func(change events.DelegationStateChange) (string, error) {
+			data, err := json.Marshal(change)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("event: %s\ndata: %s\n\n", delegationStateChangeEvent, data), nil
+		},
+		fmt.Sprintf("event: %s\ndata: {}\n\n", delegationStaleEvent),
+	), nil
+}
+
+// StreamStakerEvents @Summary Stream phase-1 staker lifecycle events
+// @Description Server-sent events stream of the lifecycle events of the delegations of a staker, held open until the client disconnects.
+// @Description Every event is sent as an unnamed message whose data holds its type along with the staking_tx_hash_hex, staker_pk_hex, new state and unix timestamp of the change, an event may be sent more than once.
+// @Description The types are listed in the activity types of /v1/constants.
+// @Description A STREAM_STALE event is sent when events were dropped because the client did not keep up, the delegations have to be reloaded.
+// @Description A heartbeat comment is sent every 30 seconds.
+// @Produce text/event-stream
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {string} string "Stream of server-sent events"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/event-stream [get]
+func (h *V1Handler) StreamStakerEvents(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	staleData, jsonErr := json.Marshal(lifecycleEvent{Type: types.StreamStaleEvent})
+	if jsonErr != nil {
+		return nil, types.NewInternalServiceError(jsonErr)
+	}
+	return h.streamDelegationStateChanges(request.Context(), stakerBtcPk,
+		func(change events.DelegationStateChange) (string, error) {
+			data, err := json.Marshal(lifecycleEvent{
+				Type:                  types.LifecycleEventTypeOf(change.State),
+				DelegationStateChange: &change,
+			})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("data: %s\n\n", data), nil
+		},
+		fmt.Sprintf("data: %s\n\n", staleData),
+	), nil
+}
+
+// streamDelegationStateChanges writes the state changes of the delegations of
+// the staker, as formatted by changeFrame, until the client disconnects
+func (h *V1Handler) streamDelegationStateChanges(
+	ctx context.Context, stakerBtcPk string,
+	changeFrame func(events.DelegationStateChange) (string, error), staleFrame string,
+) *handler.Result {
+	return handler.NewEventStreamResult(func(w io.Writer) *types.Error {
+		subscription := h.Service.SubscribeDelegationStateChanges(stakerBtcPk)
+		defer subscription.Close()
+		heartbeat := time.NewTicker(delegationStreamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		// The comment sends the response headers, the changes are delivered
+		// from then on
+		if _, err := io.WriteString(w, ": connected\n\n"); err != nil {
+			return nil
+		}
+		for {
+			var frame string
+			select {
+			case <-ctx.Done():
+				return nil
+			case change := <-subscription.Changes():
+				var err error
+				frame, err = changeFrame(change)
+				if err != nil {
+					return types.NewInternalServiceError(err)
+				}
+			case <-subscription.Stale():
+				frame = staleFrame
+			case <-heartbeat.C:
+				frame = ": heartbeat\n\n"
+			}
+			// A failed write means the client is gone
+			if _, err := io.WriteString(w, frame); err != nil {
+				return nil
+			}
+		}
+	})
+}
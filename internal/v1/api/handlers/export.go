@@ -0,0 +1,105 @@
+package v1handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+)
+
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+)
+
+// delegationExportColumns is the header row of the CSV export, in the order
+// of the fields of DelegationExportPublic
+var delegationExportColumns = []string{
+	"staking_tx_hash_hex",
+	"state",
+	"staking_value",
+	"start_height",
+	"start_timestamp",
+	"finality_provider_pk_hex",
+	"unbonding_tx_hash_hex",
+	"withdrawal_tx_hash_hex",
+}
+
+// ExportStakerDelegations @Summary Export phase-1 staker delegations
+// @Description Downloads all the phase-1 delegations of a staker, the most recent first, for accounting purposes.
+// @Description The delegations are streamed as CSV with a header row, or as newline delimited JSON.
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param format query string false "Export format, csv (default) or json"
+// @Success 200 {file} file "Delegations of the staker"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/delegations/export [get]
+func (h *V1Handler) ExportStakerDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	format := request.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatCSV
+	}
+
+	ctx := request.Context()
+	filename := fmt.Sprintf("delegations-%s.%s", stakerBtcPk, format)
+	switch format {
+	case exportFormatCSV:
+		return handler.NewStreamResult("text/csv; charset=utf-8", filename, func(w io.Writer) *types.Error {
+			csvWriter := csv.NewWriter(w)
+			if err := csvWriter.Write(delegationExportColumns); err != nil {
+				return types.NewInternalServiceError(err)
+			}
+			if err := h.Service.ExportStakerDelegations(ctx, stakerBtcPk, func(d *v1service.DelegationExportPublic) error {
+				return csvWriter.Write([]string{
+					d.StakingTxHashHex,
+					d.State,
+					strconv.FormatUint(d.StakingValue, 10),
+					strconv.FormatUint(d.StartHeight, 10),
+					d.StartTimestamp,
+					d.FinalityProviderPkHex,
+					d.UnbondingTxHashHex,
+					d.WithdrawalTxHashHex,
+				})
+			}); err != nil {
+				return err
+			}
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return types.NewInternalServiceError(err)
+			}
+			return nil
+		}), nil
+	case exportFormatJSON:
+		return handler.NewStreamResult("application/x-ndjson", filename, func(w io.Writer) *types.Error {
+			// the rows are written in batches, like the csv ones
+			buffered := bufio.NewWriter(w)
+			encoder := json.NewEncoder(buffered)
+			if err := h.Service.ExportStakerDelegations(ctx, stakerBtcPk, func(d *v1service.DelegationExportPublic) error {
+				return encoder.Encode(d)
+			}); err != nil {
+				return err
+			}
+			if err := buffered.Flush(); err != nil {
+				return types.NewInternalServiceError(err)
+			}
+			return nil
+		}), nil
+	default:
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid format, must be csv or json",
+		)
+	}
+}
@@ -1,7 +1,9 @@
 package v1handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
@@ -46,3 +48,164 @@ func (h *V1Handler) GetFinalityProviders(request *http.Request) (*handler.Result
 	}
 	return handler.NewResultWithPagination(fps, paginationToken), nil
 }
+
+// GetInactiveFinalityProviders @Summary Get inactive finality providers
+// @Description Fetches the finality providers that have no active delegation,
+// @Description including the registered finality providers that never received a delegation.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[[]v1service.FpDetailsPublic] "A list of finality providers without active delegations"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/finality-providers/inactive [get]
+func (h *V1Handler) GetInactiveFinalityProviders(request *http.Request) (*handler.Result, *types.Error) {
+	fps, err := h.Service.GetInactiveFinalityProviders(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(fps), nil
+}
+
+// GetFinalityProvidersByStakers @Summary Get finality providers by unique stakers
+// @Description Fetches the finality providers with at least the given number of distinct stakers
+// @Description holding an active phase-1 delegation to them, the most staked first.
+// @Produce json
+// @Tags v1
+// @Param min_stakers query integer false "Minimum number of unique stakers, defaults to 1"
+// @Success 200 {object} handler.PublicResponse[[]v1service.FpStakersPublic] "A list of finality providers with their number of unique stakers"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/finality-providers/by-total-stakers [get]
+func (h *V1Handler) GetFinalityProvidersByStakers(request *http.Request) (*handler.Result, *types.Error) {
+	minStakers, err := parseMinStakersQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	fps, err := h.Service.GetFinalityProvidersByStakers(request.Context(), minStakers)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(fps), nil
+}
+
+// parseMinStakersQuery returns 1 if the min_stakers query is not set
+func parseMinStakersQuery(r *http.Request) (int64, *types.Error) {
+	value := r.URL.Query().Get("min_stakers")
+	if value == "" {
+		return 1, nil
+	}
+	minStakers, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || minStakers < 1 {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid min_stakers value: %s", value),
+		)
+	}
+	return minStakers, nil
+}
+
+// GetFinalityProviderLogo @Summary Get finality provider logo
+// @Description Redirects to the logo URL registered by the finality provider.
+// @Description When the logo proxy is configured, the png, jpeg or svg image is served by the API instead.
+// @Produce png
+// @Produce jpeg
+// @Produce image/svg+xml
+// @Tags v1
+// @Param fp_btc_pk query string true "Public key of the finality provider"
+// @Success 200 {file} binary "Finality provider logo"
+// @Success 302 "Redirect to the finality provider logo URL"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Failure 422 {object} types.Error "Error: Unprocessable Entity"
+// @Router /v1/finality-provider/logo [get]
+func (h *V1Handler) GetFinalityProviderLogo(request *http.Request) (*handler.Result, *types.Error) {
+	fpPk, err := handler.ParsePublicKeyQuery(request, "fp_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	logo, err := h.Service.GetFinalityProviderLogo(request.Context(), fpPk)
+	if err != nil {
+		return nil, err
+	}
+	if logo.Logo == nil {
+		return handler.NewRedirectResult(logo.RedirectUrl), nil
+	}
+
+	return handler.NewRawResult(logo.Logo.ContentType, logo.Logo.Data), nil
+}
+
+// GetFinalityProviderActiveStakeOverTime @Summary Get finality provider active stake over time
+// @Description Returns the phase-1 active stake timeline of a finality provider, one point per period from the period of its first delegation up to the current one, limited to the 720 most recent periods.
+// @Description Every point holds the active stake at the end of the period, along with the delegations which became active or left the active state during the period.
+// @Description The periods start at the date in UTC, weeks start on Monday. The timelines are cached for 15 minutes.
+// @Produce json
+// @Tags v1
+// @Param fp_btc_pk query string true "Public key of the finality provider"
+// @Param granularity query string false "Period of every point, hour, day (default) or week"
+// @Success 200 {object} handler.PublicResponse[[]v1service.ActiveStakePointPublic] "Active stake timeline of the finality provider"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/finality-provider/active-stake-over-time [get]
+func (h *V1Handler) GetFinalityProviderActiveStakeOverTime(request *http.Request) (*handler.Result, *types.Error) {
+	fpPk, err := handler.ParsePublicKeyQuery(request, "fp_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	granularity, err := parseActiveStakeGranularityQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := h.Service.GetFinalityProviderActiveStakeOverTime(request.Context(), fpPk, granularity)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(points), nil
+}
+
+func parseActiveStakeGranularityQuery(r *http.Request) (v1service.ActiveStakeGranularity, *types.Error) {
+	granularity := r.URL.Query().Get("granularity")
+	if granularity == "" {
+		return v1service.ActiveStakeGranularityDay, nil
+	}
+	for _, supported := range v1service.SupportedActiveStakeGranularities() {
+		if granularity == string(supported) {
+			return supported, nil
+		}
+	}
+	return "", types.NewErrorWithMsg(
+		http.StatusBadRequest, types.BadRequest,
+		fmt.Sprintf("invalid granularity value: %s, must be hour, day or week", granularity),
+	)
+}
+
+// BulkRegisterFinalityProviders @Summary Bulk register finality providers
+// @Description Registers up to 100 phase-1 finality providers at once, in a single bulk write.
+// @Description The registered finality providers are served along the ones of the finality providers file.
+// @Description The invalid ones are reported by their index in the batch, the ones already registered are skipped.
+// @Description This instance serves the registrations right away, the other instances load them at their next refresh of the registrations.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param payload body []types.FinalityProviderDetails true "Finality providers to register"
+// @Success 200 {object} handler.PublicResponse[v1service.FpBulkRegistrationPublic] "Registration result"
+// @Failure 400 {object} types.Error "Invalid payload, empty batch or more than 100 finality providers"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/admin/finality-provider/bulk-register [post]
+func (h *V1Handler) BulkRegisterFinalityProviders(request *http.Request) (*handler.Result, *types.Error) {
+	var providers []types.FinalityProviderDetails
+	if err := handler.DecodeJSONPayload(request, &providers); err != nil {
+		return nil, err
+	}
+
+	result, err := h.Service.BulkRegisterFinalityProviders(request.Context(), providers)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(result), nil
+}
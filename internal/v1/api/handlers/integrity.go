@@ -0,0 +1,312 @@
+package v1handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+const (
+	defaultCovenantMissingHours  = 48
+	defaultConsistencySampleSize = 1000
+	maxConsistencySampleSize     = 10000
+	// defaultHighValueMinSat is 100 BTC
+	defaultHighValueMinSat = 10_000_000_000
+)
+
+// GetIntegrityIssues @Summary Get unbonding integrity issues
+// @Description Internal endpoint listing the unbonding documents flagged by the
+// @Description unbonding integrity check, either because the referenced delegation
+// @Description does not exist or because it is in a state incompatible with an unbonding request.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param pagination_key query string false "Pagination key to fetch the next page of integrity issues"
+// @Success 200 {object} handler.PublicResponse[[]v1service.IntegrityIssuePublic]{array} "List of integrity issues and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/internal/integrity-issues [get]
+func (h *V1Handler) GetIntegrityIssues(request *http.Request) (*handler.Result, *types.Error) {
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, newPaginationKey, err := h.Service.GetIntegrityIssues(request.Context(), paginationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResultWithPagination(issues, newPaginationKey), nil
+}
+
+// GetDelegationConsistency @Summary Check delegation consistency
+// @Description Internal endpoint evaluating the invariants between the state of
+// @Description a delegation and its unbonding documents, with the offending values of the failed ones.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 {object} handler.PublicResponse[v1service.DelegationConsistencyPublic] "Invariants evaluation"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/internal/delegations/consistency [get]
+func (h *V1Handler) GetDelegationConsistency(request *http.Request) (*handler.Result, *types.Error) {
+	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+
+	consistency, err := h.Service.GetDelegationConsistency(request.Context(), stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(consistency), nil
+}
+
+// VerifyDelegationsConsistency @Summary Verify the consistency of sampled delegations
+// @Description Internal endpoint checking randomly sampled delegations for an unknown state, a state
+// @Description not reachable given their transactions and unbonding documents, a zero staking value or missing required fields.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param sample_size query integer false "Number of delegations to sample, at most 10000" default(1000)
+// @Success 200 {object} handler.PublicResponse[v1service.ConsistencyVerificationPublic] "Number of checked delegations and their issues"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/admin/delegations/verify-consistency [get]
+func (h *V1Handler) VerifyDelegationsConsistency(request *http.Request) (*handler.Result, *types.Error) {
+	sampleSize, err := parseSampleSizeQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := h.Service.VerifyDelegationsConsistency(request.Context(), sampleSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(verification), nil
+}
+
+// parseSampleSizeQuery returns defaultConsistencySampleSize if the sample_size
+// query is not set
+func parseSampleSizeQuery(r *http.Request) (int64, *types.Error) {
+	value := r.URL.Query().Get("sample_size")
+	if value == "" {
+		return defaultConsistencySampleSize, nil
+	}
+	sampleSize, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || sampleSize <= 0 || sampleSize > maxConsistencySampleSize {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid sample_size value: %s", value),
+		)
+	}
+	return sampleSize, nil
+}
+
+// GetOrphanedDelegations @Summary Get orphaned delegations
+// @Description Internal endpoint listing the delegations whose finality provider
+// @Description is not part of the finality providers registry.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param pagination_key query string false "Pagination key to fetch the next page of orphaned delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.OrphanedDelegationPublic]{array} "List of orphaned delegations and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/admin/delegations/orphaned [get]
+func (h *V1Handler) GetOrphanedDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, newPaginationKey, err := h.Service.GetOrphanedDelegations(request.Context(), paginationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResultWithPagination(delegations, newPaginationKey), nil
+}
+
+// GetDustDelegations @Summary Get dust delegations
+// @Description Internal endpoint listing the active delegations staking less than
+// @Description the minimum staking amount of the latest global params version.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param pagination_key query string false "Pagination key to fetch the next page of dust delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.DustDelegationPublic]{array} "List of dust delegations and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/admin/delegations/dust [get]
+func (h *V1Handler) GetDustDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, newPaginationKey, err := h.Service.GetDustDelegations(request.Context(), paginationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResultWithPagination(delegations, newPaginationKey), nil
+}
+
+// GetCovenantMissingDelegations @Summary Get delegations missing covenant signatures
+// @Description Internal endpoint listing the delegations whose unbonding request
+// @Description is pending for more than the given number of hours without any covenant signature.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param hours query integer false "Minimum number of hours the unbonding request is pending, defaults to 48"
+// @Success 200 {object} handler.PublicResponse[[]v1service.CovenantMissingDelegationPublic]{array} "List of delegations missing covenant signatures"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/admin/delegations/covenant-missing [get]
+func (h *V1Handler) GetCovenantMissingDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	hours, err := parseHoursQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, err := h.Service.GetCovenantMissingDelegations(
+		request.Context(), time.Duration(hours)*time.Hour,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(delegations), nil
+}
+
+// parseHoursQuery returns defaultCovenantMissingHours if the hours query is not set
+func parseHoursQuery(r *http.Request) (uint64, *types.Error) {
+	value := r.URL.Query().Get("hours")
+	if value == "" {
+		return defaultCovenantMissingHours, nil
+	}
+	hours, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || hours == 0 {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid hours value: %s", value),
+		)
+	}
+	return hours, nil
+}
+
+// GetDelegationsOverdueForWithdrawal @Summary Get delegations overdue for withdrawal
+// @Description Internal endpoint listing the active and unbonded delegations whose timelock expired
+// @Description more than the given number of blocks before the current BTC tip height.
+// @Description The timelock of a delegation unbonded early is the one of its unbonding tx.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param blocks_overdue query integer false "Minimum number of blocks since the timelock expired, defaults to 0"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.OverdueWithdrawalDelegationPublic]{array} "List of delegations overdue for withdrawal and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 503 {object} types.Error "The BTC tip height is not known yet"
+// @Router /v1/admin/delegations/expired-but-not-withdrawn [get]
+func (h *V1Handler) GetDelegationsOverdueForWithdrawal(request *http.Request) (*handler.Result, *types.Error) {
+	blocksOverdue, err := parseBlocksOverdueQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, newPaginationKey, err := h.Service.GetDelegationsOverdueForWithdrawal(
+		request.Context(), blocksOverdue, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResultWithPagination(delegations, newPaginationKey), nil
+}
+
+// parseBlocksOverdueQuery returns 0 if the blocks_overdue query is not set
+func parseBlocksOverdueQuery(r *http.Request) (uint64, *types.Error) {
+	value := r.URL.Query().Get("blocks_overdue")
+	if value == "" {
+		return 0, nil
+	}
+	blocks, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid blocks_overdue value: %s", value),
+		)
+	}
+	return blocks, nil
+}
+
+// GetHighValueDelegations @Summary Get high value delegations
+// @Description Internal endpoint listing the delegations, in any state, staking at least
+// @Description the given number of satoshis, the largest first.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param min_sat query integer false "Minimum staking value in satoshis, defaults to 10000000000"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.HighValueDelegationPublic]{array} "List of high value delegations and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/admin/delegations/high-value [get]
+func (h *V1Handler) GetHighValueDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	minSat, err := parseMinSatQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, newPaginationKey, err := h.Service.GetHighValueDelegations(
+		request.Context(), minSat, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResultWithPagination(delegations, newPaginationKey), nil
+}
+
+// parseMinSatQuery returns defaultHighValueMinSat if the min_sat query is not set
+func parseMinSatQuery(r *http.Request) (uint64, *types.Error) {
+	value := r.URL.Query().Get("min_sat")
+	if value == "" {
+		return defaultHighValueMinSat, nil
+	}
+	minSat, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || minSat == 0 {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid min_sat value: %s", value),
+		)
+	}
+	return minSat, nil
+}
@@ -0,0 +1,74 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+)
+
+type ReportMisbehaviorRequestPayload struct {
+	EvidenceTxHex    string `json:"evidence_tx_hex"`
+	EvidenceType     string `json:"evidence_type" enums:"double_sign,unavailability"`
+	ReporterBtcPkHex string `json:"reporter_btc_pk_hex"`
+	ReporterSigHex   string `json:"reporter_sig_hex"`
+}
+
+func parseReportMisbehaviorRequestPayload(request *http.Request) (*ReportMisbehaviorRequestPayload, *types.Error) {
+	payload := &ReportMisbehaviorRequestPayload{}
+	if err := handler.DecodeJSONPayload(request, payload); err != nil {
+		return nil, err
+	}
+	if err := handler.ValidateTxHexField("evidence_tx_hex", payload.EvidenceTxHex); err != nil {
+		return nil, err
+	}
+	if !v1dbmodel.MisbehaviorEvidenceType(payload.EvidenceType).IsValid() {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid evidence_type, must be double_sign or unavailability",
+		)
+	}
+	if err := handler.ValidatePublicKeyField("reporter_btc_pk_hex", payload.ReporterBtcPkHex); err != nil {
+		return nil, err
+	}
+	if err := handler.ValidateSignatureField("reporter_sig_hex", payload.ReporterSigHex); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// ReportMisbehavior @Summary Report a finality provider misbehavior
+// @Description Reports a suspected misbehavior of a finality provider, the report is stored pending review.
+// @Description The reporter signs the sha256 of the raw evidence transaction with the BIP-340 schnorr key of its BTC public key.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param fp_btc_pk query string true "Public key of the reported finality provider"
+// @Param payload body ReportMisbehaviorRequestPayload true "Misbehavior Report Payload"
+// @Success 202 {object} handler.PublicResponse[v1service.MisbehaviorReportPublic] "Report accepted for review"
+// @Failure 400 {object} types.Error "Invalid request payload (BAD_REQUEST) or reporter signature not matching the evidence (INVALID_SIGNATURE)"
+// @Failure 404 {object} types.Error "Finality provider not found"
+// @Router /v1/finality-provider/report-misbehavior [post]
+func (h *V1Handler) ReportMisbehavior(request *http.Request) (*handler.Result, *types.Error) {
+	fpPk, err := handler.ParsePublicKeyQuery(request, "fp_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := parseReportMisbehaviorRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := h.Service.ReportMisbehavior(
+		request.Context(), fpPk, v1dbmodel.MisbehaviorEvidenceType(payload.EvidenceType),
+		payload.EvidenceTxHex, payload.ReporterBtcPkHex, payload.ReporterSigHex,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := handler.NewResult(report)
+	result.Status = http.StatusAccepted
+	return result, nil
+}
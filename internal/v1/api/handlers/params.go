@@ -1,7 +1,9 @@
 package v1handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
@@ -9,12 +11,100 @@ import (
 
 // GetBabylonGlobalParams @Summary Get Babylon global parameters (Deprecated)
 // @Description [DEPRECATED] Retrieves the global parameters for Babylon, including finality provider details. Please use /v2/network-info instead.
+// @Description If a height is given, only the params version active at that BTC height is returned.
 // @Produce json
 // @Tags v1
 // @Deprecated
+// @Param height query integer false "BTC height to get the active params version of"
 // @Success 200 {object} handler.PublicResponse[v1service.GlobalParamsPublic] "Global parameters"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
 // @Router /v1/global-params [get]
 func (h *V1Handler) GetBabylonGlobalParams(request *http.Request) (*handler.Result, *types.Error) {
-	params := h.Service.GetGlobalParamsPublic()
+	height, err := parseHeightQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	if height == nil {
+		return handler.NewResult(h.Service.GetGlobalParamsPublic()), nil
+	}
+	params, err := h.Service.GetGlobalParamsPublicByHeight(*height)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(params), nil
+}
+
+// parseHeightQuery returns nil if the height query is not set
+func parseHeightQuery(r *http.Request) (*uint64, *types.Error) {
+	value := r.URL.Query().Get("height")
+	if value == "" {
+		return nil, nil
+	}
+	height, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid height value: %s", value),
+		)
+	}
+	return &height, nil
+}
+
+type UpdateGlobalParamRequestPayload struct {
+	ParamName           string  `json:"param_name" enums:"min_staking_amount,max_staking_amount,min_staking_time,max_staking_time"`
+	Value               *uint64 `json:"value"`
+	EffectiveFromHeight *uint64 `json:"effective_from_height"`
+}
+
+func parseUpdateGlobalParamRequestPayload(request *http.Request) (*UpdateGlobalParamRequestPayload, *types.Error) {
+	payload := &UpdateGlobalParamRequestPayload{}
+	if err := handler.DecodeJSONPayload(request, payload); err != nil {
+		return nil, err
+	}
+	if payload.ParamName == "" {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "param_name is required")
+	}
+	if payload.Value == nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "value is required")
+	}
+	if payload.EffectiveFromHeight == nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "effective_from_height is required",
+		)
+	}
+	return payload, nil
+}
+
+// UpdateGlobalParam @Summary Update a global param
+// @Description Updates one of the phase-1 global params without a restart, from the given BTC height onwards.
+// @Description The update adds a params version activated at that height, which must be after the activation
+// @Description height of the latest version, so the delegations staked before keep their params. Updates from
+// @Description the height of a version added by an earlier update are merged into it.
+// @Description The update is stored into the global params history and applied on top of the global params file
+// @Description by this instance right away, the other instances apply it at their next refresh of the history.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param payload body UpdateGlobalParamRequestPayload true "Param update"
+// @Success 200 {object} handler.PublicResponse[v1service.GlobalParamsPublic] "Updated global parameters"
+// @Failure 400 {object} types.Error "Unknown param, invalid value or effective height"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/admin/config/update-param [post]
+func (h *V1Handler) UpdateGlobalParam(request *http.Request) (*handler.Result, *types.Error) {
+	payload, err := parseUpdateGlobalParamRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := h.Service.UpdateGlobalParam(
+		request.Context(), payload.ParamName, *payload.Value, *payload.EffectiveFromHeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return handler.NewResult(params), nil
 }
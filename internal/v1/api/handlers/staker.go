@@ -9,8 +9,9 @@ import (
 )
 
 type DelegationCheckPublicResponse struct {
-	Data bool `json:"data"`
-	Code int  `json:"code"`
+	Data  bool  `json:"data"`
+	Count int64 `json:"count"`
+	Code  int   `json:"code"`
 }
 
 // GetStakerDelegations @Summary Get phase-1 staker delegations
@@ -18,9 +19,11 @@ type DelegationCheckPublicResponse struct {
 // @Description This endpoint is only used to show legacy phase-1 delegations for the purpose of unbonding or registering into phase-2.
 // @Produce json
 // @Tags v1
-// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param staker_btc_pk query string true "Staker BTC Public Key, x-only in 32 bytes or compressed in 33 bytes"
 // @Param pending_action query boolean false "Only return delegations with pending actions which include active, unbonding, unbonding_requested, unbonded"
+// @Param state query string false "Comma separated delegation states to filter on, e.g. active,unbonding_requested. Can not be combined with pending_action"
 // @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Param include_formatted query boolean false "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts"
 // @Success 200 {object} handler.PublicResponse[[]v1service.DelegationPublic]{array} "List of delegations and pagination token"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/staker/delegations [get]
@@ -37,7 +40,20 @@ func (h *V1Handler) GetStakerDelegations(request *http.Request) (*handler.Result
 	if err != nil {
 		return nil, err
 	}
-	stateFilter := []types.DelegationState{}
+	includeFormatted, err := handler.ParseBooleanQuery(request, "include_formatted", true)
+	if err != nil {
+		return nil, err
+	}
+	stateFilter, err := handler.ParseStateFilterQuery(request, "state")
+	if err != nil {
+		return nil, err
+	}
+	if pendingAction && len(stateFilter) > 0 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			"state and pending_action can not be combined",
+		)
+	}
 	if pendingAction {
 		// We only fetch for states that can have pending actions.
 		// We don't care terminal states such as "withdrawn" or "transitioned".
@@ -53,12 +69,49 @@ func (h *V1Handler) GetStakerDelegations(request *http.Request) (*handler.Result
 	if err != nil {
 		return nil, err
 	}
+	if includeFormatted {
+		for _, delegation := range delegations {
+			delegation.AddFormattedFields()
+		}
+	}
+	h.Service.AddUnbondingCompletions(request.Context(), delegations...)
 
 	return handler.NewResultWithPagination(delegations, newPaginationKey), nil
 }
 
+// GetStakerDelegationProof @Summary Get phase-1 delegation inclusion proof
+// @Description Retrieves the Merkle proof of inclusion of a phase-1 delegation among all delegations of the staker.
+// @Description The leaves of the tree are the staking transaction hashes of the staker sorted in ascending order.
+// @Description Leaves are hashed as sha256(0x00 || tx_hash) and inner nodes as sha256(0x01 || left || right).
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 {object} handler.PublicResponse[v1service.DelegationProofPublic] "Delegation inclusion proof"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/staker/delegation-proof [get]
+func (h *V1Handler) GetStakerDelegationProof(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := h.Service.GetDelegationProof(request.Context(), stakerBtcPk, stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(proof), nil
+}
+
 // CheckStakerDelegationExist @Summary Check if a staker has an active delegation
-// @Description Check if a staker has an active delegation by the staker BTC address (Taproot or Native Segwit).
+// @Description Check if a staker has an active delegation by the staker BTC address (Taproot or Native Segwit), along with the number of its active delegations.
+// @Description Addresses of stakers without any processed delegation return false. Addresses of another BTC network are rejected.
 // @Description Optionally, you can provide a timeframe to check if the delegation is active within the provided timeframe
 // @Description The available timeframe is "today" which checks after UTC 12AM of the current day
 // @Produce json
@@ -84,23 +137,23 @@ func (h *V1Handler) CheckStakerDelegationExist(request *http.Request) (*handler.
 		return nil, err
 	}
 	if _, exist := addressToPkMapping[address]; !exist {
-		return buildDelegationCheckResponse(false), nil
+		return buildDelegationCheckResponse(0), nil
 	}
 
-	exist, err := h.Service.CheckStakerHasActiveDelegationByPk(
+	count, err := h.Service.CountStakerActiveDelegationsByPk(
 		request.Context(), addressToPkMapping[address], afterTimestamp,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return buildDelegationCheckResponse(exist), nil
+	return buildDelegationCheckResponse(count), nil
 }
 
-func buildDelegationCheckResponse(exist bool) *handler.Result {
+func buildDelegationCheckResponse(count int64) *handler.Result {
 	return &handler.Result{
 		Data: &DelegationCheckPublicResponse{
-			Data: exist, Code: 0,
+			Data: count > 0, Count: count, Code: 0,
 		},
 		Status: http.StatusOK,
 	}
@@ -118,3 +171,146 @@ func parseTimeframeToAfterTimestamp(timeframe string) (int64, *types.Error) {
 		)
 	}
 }
+
+// GetStakerDelegationLifecycleSummary @Summary Get staker delegation lifecycle summary
+// @Description Returns the number of phase-1 delegations of the staker in every phase of their lifecycle.
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} handler.PublicResponse[v1service.DelegationLifecycleSummaryPublic] "Number of delegations per lifecycle phase"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/delegation-lifecycle-summary [get]
+func (h *V1Handler) GetStakerDelegationLifecycleSummary(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := h.Service.GetDelegationLifecycleSummary(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(summary), nil
+}
+
+// RebuildStakerStats @Summary Rebuild staker stats
+// @Description Re-derives the phase-1 stats of a single staker from its delegations and replaces the stored ones.
+// @Description Internal maintenance endpoint, the stats of the other stakers are left untouched.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} handler.PublicResponse[v1service.StakerStatsRebuildPublic] "Staker stats before and after the rebuild"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/internal/stakers/rebuild-stats [post]
+func (h *V1Handler) RebuildStakerStats(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	rebuild, err := h.Service.RebuildStakerStats(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(rebuild), nil
+}
+
+// GetStakerDossier @Summary Get staker dossier
+// @Description Internal support endpoint gathering the phase-1 delegations of a staker with their state history,
+// @Description its unbonding attempts with the progress of their covenant signatures, its stats and its watchlist.
+// @Description A section failing to load is null and reported in section_errors, the other sections are still returned.
+// @Description Sections larger than 100 items only come with their counts.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} handler.PublicResponse[v1service.StakerDossierPublic] "Staker dossier"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/internal/staker/dossier [get]
+func (h *V1Handler) GetStakerDossier(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(h.Service.GetStakerDossier(request.Context(), stakerBtcPk)), nil
+}
+
+// GetStakerCovenantExposure @Summary Get staker covenant exposure
+// @Description Lists the covenant committee members guarding the active phase-1 delegations of the staker,
+// @Description with the number of delegations each of them protects.
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} handler.PublicResponse[[]v1service.CovenantExposurePublic] "Covenant members and delegation counts"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/staker/covenant-exposure [get]
+func (h *V1Handler) GetStakerCovenantExposure(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	exposure, err := h.Service.GetStakerCovenantExposure(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(exposure), nil
+}
+
+// GetStakerActiveBtcAtRisk @Summary Get staker active BTC at risk
+// @Description Sums the active phase-1 delegations of the staker to finality providers
+// @Description that are slashed (high risk) or jailed (medium risk) in phase-2.
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} handler.PublicResponse[v1service.ActiveBtcAtRiskPublic] "Active BTC at risk and the risky finality providers"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/staker/active-btc-at-risk [get]
+func (h *V1Handler) GetStakerActiveBtcAtRisk(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	atRisk, err := h.Service.GetStakerActiveBtcAtRisk(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(atRisk), nil
+}
+
+// GetStakerPortfolioValueUsd @Summary Get staker portfolio value in USD
+// @Description Values the active phase-1 stake of the staker at the BTC price of the price oracle,
+// @Description the price is at most 60 seconds old.
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} handler.PublicResponse[v1service.PortfolioValueUsdPublic] "Active stake of the staker and its value in USD"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 503 {object} types.Error "No fresh BTC price is available (PRICE_ORACLE_UNAVAILABLE)"
+// @Router /v1/staker/portfolio-value-usd [get]
+func (h *V1Handler) GetStakerPortfolioValueUsd(request *http.Request) (*handler.Result, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio, err := h.Service.GetStakerPortfolioValueUsd(request.Context(), stakerBtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(portfolio), nil
+}
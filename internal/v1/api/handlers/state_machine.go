@@ -0,0 +1,20 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// GetStateMachines @Summary Get the delegation state machines
+// @Description Retrieves the state transition graph of the phase-1 delegations and of their unbonding attempts,
+// @Description with the trigger of each transition: api, queue-event or job.
+// @Description The graph is built from the eligible previous states enforced on each transition.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[v1service.StateMachinesPublic] "Delegation and unbonding attempt state machines"
+// @Router /v1/state-machine [get]
+func (h *V1Handler) GetStateMachines(request *http.Request) (*handler.Result, *types.Error) {
+	return handler.NewResult(h.Service.GetStateMachines()), nil
+}
@@ -14,13 +14,22 @@ import (
 // @Produce json
 // @Tags v1
 // @Deprecated
+// @Param include_formatted query boolean false "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts"
 // @Success 200 {object} handler.PublicResponse[v1service.OverallStatsPublic] "Overall stats for babylon staking"
+// @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/stats [get]
 func (h *V1Handler) GetOverallStats(request *http.Request) (*handler.Result, *types.Error) {
+	includeFormatted, err := handler.ParseBooleanQuery(request, "include_formatted", true)
+	if err != nil {
+		return nil, err
+	}
 	stats, err := h.Service.GetOverallStats(request.Context())
 	if err != nil {
 		return nil, err
 	}
+	if includeFormatted {
+		stats.AddFormattedFields()
+	}
 
 	return handler.NewResult(stats), nil
 }
@@ -35,6 +44,7 @@ func (h *V1Handler) GetOverallStats(request *http.Request) (*handler.Result, *ty
 // @Deprecated
 // @Param  staker_btc_pk query string false "Public key of the staker to fetch"
 // @Param  pagination_key query string false "Pagination key to fetch the next page of top stakers"
+// @Param include_formatted query boolean false "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts"
 // @Success 200 {object} handler.PublicResponse[[]v1service.StakerStatsPublic]{array} "List of top stakers by active tvl"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/stats/staker [get]
@@ -44,6 +54,10 @@ func (h *V1Handler) GetStakersStats(request *http.Request) (*handler.Result, *ty
 	if err != nil {
 		return nil, err
 	}
+	includeFormatted, err := handler.ParseBooleanQuery(request, "include_formatted", true)
+	if err != nil {
+		return nil, err
+	}
 	if stakerPk != "" {
 		var result []v1service.StakerStatsPublic
 		stakerStats, err := h.Service.GetStakerStats(request.Context(), stakerPk)
@@ -51,6 +65,9 @@ func (h *V1Handler) GetStakersStats(request *http.Request) (*handler.Result, *ty
 			return nil, err
 		}
 		if stakerStats != nil {
+			if includeFormatted {
+				stakerStats.AddFormattedFields()
+			}
 			result = append(result, *stakerStats)
 		}
 
@@ -66,6 +83,91 @@ func (h *V1Handler) GetStakersStats(request *http.Request) (*handler.Result, *ty
 	if err != nil {
 		return nil, err
 	}
+	if includeFormatted {
+		for i := range topStakerStats {
+			topStakerStats[i].AddFormattedFields()
+		}
+	}
 
 	return handler.NewResultWithPagination(topStakerStats, paginationToken), nil
 }
+
+// GetNewStakersPerDay gets the number of new stakers per day
+// @Summary Get New Stakers Per Day
+// @Description Fetches the number of stakers whose first delegation started on each day (UTC).
+// @Description The result is refreshed every 10 minutes.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[[]v1service.NewStakersPerDayPublic]{array} "Number of new stakers per day"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/stats/new-stakers-per-day [get]
+func (h *V1Handler) GetNewStakersPerDay(request *http.Request) (*handler.Result, *types.Error) {
+	days, err := h.Service.GetNewStakersPerDay(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(days), nil
+}
+
+// GetCovenantResponseTime gets the covenant response time percentiles
+// @Summary Get Covenant Response Time
+// @Description Fetches the 50th, 95th and 99th percentiles of the time in seconds between the unbonding request
+// @Description of a phase-1 delegation and its unbonding tx being observed on BTC, over the delegations unbonded
+// @Description in the last 7 days. The unbonding tx is only broadcast once co-signed by the covenants, so this is
+// @Description an upper bound of the covenant response time.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[v1service.CovenantResponseTimePublic] "Covenant response time percentiles"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/stats/covenant-response-time [get]
+func (h *V1Handler) GetCovenantResponseTime(request *http.Request) (*handler.Result, *types.Error) {
+	responseTime, err := h.Service.GetCovenantResponseTime(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(responseTime), nil
+}
+
+// GetWithdrawalCompletionTime gets the withdrawal completion time percentiles
+// @Summary Get Withdrawal Completion Time
+// @Description Fetches the 50th and 95th percentiles of the time in seconds between a phase-1 delegation becoming
+// @Description unbonded, when its funds are withdrawable, and their withdrawal, over the delegations withdrawn in the
+// @Description last 30 days.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[v1service.WithdrawalCompletionTimePublic] "Withdrawal completion time percentiles"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/stats/withdrawal-completion-time [get]
+func (h *V1Handler) GetWithdrawalCompletionTime(request *http.Request) (*handler.Result, *types.Error) {
+	completionTime, err := h.Service.GetWithdrawalCompletionTime(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(completionTime), nil
+}
+
+// GetProviderChurn gets the delegation churn of the finality providers
+// @Summary Get Provider Churn
+// @Description Fetches, for every finality provider, the number of phase-1 delegations which became active and
+// @Description the number which left the active state, by unbonding or expiring, over the last 30 days, along with
+// @Description the net change. The finality providers without any such delegation are left out.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[[]v1service.ProviderChurnPublic] "Delegation churn of the finality providers"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Failure 503 {object} types.Error "Too many aggregations in progress, retry later (QUERY_CAPACITY)"
+// @Router /v1/stats/provider-churn [get]
+func (h *V1Handler) GetProviderChurn(request *http.Request) (*handler.Result, *types.Error) {
+	churn, err := h.Service.GetProviderChurn(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(churn), nil
+}
@@ -1,12 +1,14 @@
 package v1handlers
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
-	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
 )
 
 type UnbondDelegationRequestPayload struct {
@@ -18,33 +20,64 @@ type UnbondDelegationRequestPayload struct {
 
 func parseUnbondDelegationRequestPayload(request *http.Request) (*UnbondDelegationRequestPayload, *types.Error) {
 	payload := &UnbondDelegationRequestPayload{}
-	err := json.NewDecoder(request.Body).Decode(payload)
-	if err != nil {
-		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	if err := handler.DecodeJSONPayload(request, payload); err != nil {
+		return nil, err
 	}
 	// Validate the payload fields
-	if !utils.IsValidTxHash(payload.StakingTxHashHex) {
-		return nil, types.NewErrorWithMsg(
-			http.StatusBadRequest, types.BadRequest, "invalid staking transaction hash",
-		)
+	if err := handler.ValidateTxHashField("staking_tx_hash_hex", payload.StakingTxHashHex); err != nil {
+		return nil, err
+	}
+	if err := handler.ValidateTxHashField("unbonding_tx_hash_hex", payload.UnbondingTxHashHex); err != nil {
+		return nil, err
+	}
+	if err := handler.ValidateTxHexField("unbonding_tx_hex", payload.UnbondingTxHex); err != nil {
+		return nil, err
+	}
+	if err := handler.ValidateSignatureField("staker_signed_signature_hex", payload.StakerSignedSignatureHex); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// maxBulkUnbondingEligibilityHashes is the maximum number of staking tx
+// hashes checked in a single bulk unbonding eligibility request
+const maxBulkUnbondingEligibilityHashes = 100
+
+func parseBulkUnbondingEligibilityRequestPayload(request *http.Request) ([]string, *types.Error) {
+	var stakingTxHashHexes []string
+	if err := handler.DecodeJSONPayload(request, &stakingTxHashHexes); err != nil {
+		return nil, err
 	}
-	if !utils.IsValidTxHash(payload.UnbondingTxHashHex) {
+	if len(stakingTxHashHexes) == 0 {
 		return nil, types.NewErrorWithMsg(
-			http.StatusBadRequest, types.BadRequest, "invalid unbonding transaction hash",
+			http.StatusBadRequest, types.BadRequest, "at least one staking transaction hash is required",
 		)
 	}
-	if !utils.IsValidTxHex(payload.UnbondingTxHex) {
+	if len(stakingTxHashHexes) > maxBulkUnbondingEligibilityHashes {
 		return nil, types.NewErrorWithMsg(
-			http.StatusBadRequest, types.BadRequest, "invalid unbonding transaction hex",
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf(
+				"too many staking transaction hashes, at most %d are allowed, entries from index %d are over the limit",
+				maxBulkUnbondingEligibilityHashes, maxBulkUnbondingEligibilityHashes,
+			),
 		)
 	}
-	if !utils.IsValidSignatureFormat(payload.StakerSignedSignatureHex) {
+
+	var invalidIndexes []string
+	for i, stakingTxHashHex := range stakingTxHashHexes {
+		if handler.ValidateTxHashField("staking_tx_hash_hex", stakingTxHashHex) != nil {
+			invalidIndexes = append(invalidIndexes, strconv.Itoa(i))
+		}
+	}
+	if len(invalidIndexes) > 0 {
 		return nil, types.NewErrorWithMsg(
-			http.StatusBadRequest, types.BadRequest, "invalid staker signed signature hex",
+			http.StatusBadRequest, types.BadRequest,
+			"invalid staking transaction hashes at indexes: "+strings.Join(invalidIndexes, ", "),
 		)
 	}
 
-	return payload, nil
+	return stakingTxHashHexes, nil
 }
 
 // UnbondDelegation godoc
@@ -55,8 +88,11 @@ func parseUnbondDelegationRequestPayload(request *http.Request) (*UnbondDelegati
 // @Produce json
 // @Tags v1
 // @Param payload body UnbondDelegationRequestPayload true "Unbonding Request Payload"
+// @Param If-Match header string false "ETag of the delegation returned by GET /v1/delegation, the request fails if the delegation changed since"
 // @Success 202 "Request accepted and will be processed asynchronously"
-// @Failure 400 {object} types.Error "Invalid request payload"
+// @Failure 400 {object} types.Error "Invalid request payload (BAD_REQUEST), unbonding tx not spending the staking output as expected (INVALID_UNBONDING_TX), unbonding tx hash not matching the tx (TX_HASH_MISMATCH) or invalid staker signature (INVALID_SIGNATURE)"
+// @Failure 412 {object} types.Error "The delegation changed since its ETag was fetched, the message holds its current state (PRECONDITION_FAILED)"
+// @Failure 429 {object} types.Error "Too many requests from the client IP, retry after the Retry-After header (TOO_MANY_REQUESTS)"
 // @Router /v1/unbonding [post]
 func (h *V1Handler) UnbondDelegation(request *http.Request) (*handler.Result, *types.Error) {
 	payload, err := parseUnbondDelegationRequestPayload(request)
@@ -66,7 +102,7 @@ func (h *V1Handler) UnbondDelegation(request *http.Request) (*handler.Result, *t
 	unbondErr := h.Service.UnbondDelegation(
 		request.Context(), payload.StakingTxHashHex,
 		payload.UnbondingTxHashHex, payload.UnbondingTxHex,
-		payload.StakerSignedSignatureHex,
+		payload.StakerSignedSignatureHex, request.Header.Get("If-Match"),
 	)
 	if unbondErr != nil {
 		return nil, unbondErr
@@ -75,24 +111,116 @@ func (h *V1Handler) UnbondDelegation(request *http.Request) (*handler.Result, *t
 	return &handler.Result{Status: http.StatusAccepted}, nil
 }
 
+// UnbondingIneligibleResponse is the body returned for a delegation not
+// eligible for unbonding. The errorCode and message of the former error
+// response are kept for one release, clients should rely on data instead.
+type UnbondingIneligibleResponse struct {
+	ErrorCode string                                       `json:"errorCode"`
+	Message   string                                       `json:"message"`
+	Data      *v1service.UnbondingEligibilityDetailsPublic `json:"data"`
+}
+
+func newUnbondingIneligibleResponse(
+	eligibility *v1service.UnbondingEligibilityDetailsPublic,
+) *UnbondingIneligibleResponse {
+	response := &UnbondingIneligibleResponse{
+		ErrorCode: types.Forbidden.String(),
+		Message:   "delegation state is not active",
+		Data:      eligibility,
+	}
+	if eligibility.Reason == v1service.UnbondingIneligibleNotFound {
+		response.ErrorCode = types.NotFound.String()
+		response.Message = "delegation not found"
+	}
+	return response
+}
+
 // GetUnbondingEligibility godoc
 // @Summary Check unbonding eligibility
 // @Description Checks if a delegation identified by its staking transaction hash is eligible for unbonding. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.
+// @Description An eligible delegation comes with its staking value and the unbonding fee to build the unbonding tx.
+// @Description The reason of a not eligible delegation is either `not_found`, `wrong_state` or `already_requested`, along with its current state and, once its unbonding was requested, the unbonding tx hash and completion.
+// @Description The errorCode and message of a not eligible delegation are deprecated and will be removed in the next release.
 // @Produce json
 // @Tags v1
 // @Param staking_tx_hash_hex query string true "Staking Transaction Hash Hex"
-// @Success 200 "The delegation is eligible for unbonding"
+// @Success 200 {object} handler.PublicResponse[v1service.UnbondingEligibilityDetailsPublic] "The delegation is eligible for unbonding"
 // @Failure 400 {object} types.Error "Missing or invalid 'staking_tx_hash_hex' query parameter"
+// @Failure 403 {object} UnbondingIneligibleResponse "The delegation is not eligible for unbonding"
 // @Router /v1/unbonding/eligibility [get]
 func (h *V1Handler) GetUnbondingEligibility(request *http.Request) (*handler.Result, *types.Error) {
 	stakingTxHashHex, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
 	if err != nil {
 		return nil, err
 	}
-	err = h.Service.IsEligibleForUnbondingRequest(request.Context(), stakingTxHashHex)
+	eligibility, err := h.Service.GetUnbondingEligibility(request.Context(), stakingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+	if !eligibility.Eligible {
+		return &handler.Result{
+			Data:   newUnbondingIneligibleResponse(eligibility),
+			Status: http.StatusForbidden,
+		}, nil
+	}
+
+	return handler.NewResult(eligibility), nil
+}
+
+// GetBulkUnbondingEligibility godoc
+// @Summary Check unbonding eligibility of multiple delegations
+// @Description Checks if the delegations identified by their staking transaction hashes are eligible for unbonding. The reason of a not eligible delegation is either `not_found`, `wrong_state` or `already_requested`. This endpoint will be deprecated once all phase-1 delegations are either withdrawn or registered into phase-2.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param payload body []string true "Staking transaction hashes, at most 100"
+// @Success 200 {object} handler.PublicResponse[[]v1service.UnbondingEligibilityPublic] "Eligibility of every delegation, in the order of the payload"
+// @Failure 400 {object} types.Error "Invalid request payload, too many hashes or malformed hashes, identified by their index (BAD_REQUEST)"
+// @Failure 429 {object} types.Error "Too many requests from the client IP, retry after the Retry-After header (TOO_MANY_REQUESTS)"
+// @Router /v1/unbonding/eligibility/bulk [post]
+func (h *V1Handler) GetBulkUnbondingEligibility(request *http.Request) (*handler.Result, *types.Error) {
+	stakingTxHashHexes, err := parseBulkUnbondingEligibilityRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	eligibilities, err := h.Service.GetUnbondingEligibilities(request.Context(), stakingTxHashHexes)
 	if err != nil {
 		return nil, err
 	}
 
-	return &handler.Result{Status: http.StatusOK}, nil
+	return handler.NewResult(eligibilities), nil
+}
+
+// GetUnbondingSigHash @Summary Get the sighash of an unbonding signature
+// @Description Debug endpoint returning the sighash the staker signature of an unbonding request is verified
+// @Description against, along with the unbonding path script and its tapleaf hash. The unbonding tx known for
+// @Description the delegation is used when unbonding_tx_hex is not given. Only available when enabled in the
+// @Description debug config, which is refused on mainnet, and to the requests carrying the admin token.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Param unbonding_tx_hex query string false "Unsigned unbonding transaction in hex format"
+// @Success 200 {object} handler.PublicResponse[v1service.UnbondingSigHashPublic] "Unbonding sighash"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/debug/unbonding-sighash [get]
+func (h *V1Handler) GetUnbondingSigHash(request *http.Request) (*handler.Result, *types.Error) {
+	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	unbondingTxHex := request.URL.Query().Get("unbonding_tx_hex")
+	if unbondingTxHex != "" {
+		if err := handler.ValidateTxHexField("unbonding_tx_hex", unbondingTxHex); err != nil {
+			return nil, err
+		}
+	}
+
+	sigHash, err := h.Service.GetUnbondingSigHash(request.Context(), stakingTxHash, unbondingTxHex)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(sigHash), nil
 }
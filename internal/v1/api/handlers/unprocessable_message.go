@@ -0,0 +1,28 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// GetUnprocessableMessages @Summary Get unprocessable messages
+// @Description Internal endpoint listing the queue messages that exhausted their retry
+// @Description attempts, oldest first, with the raw payload and the last processing error.
+// @Description They can be replayed by starting the service with the --replay flag.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v1
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {object} handler.PublicResponse[[]service.UnprocessableMessagePublic]{array} "List of unprocessable messages"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Router /v1/internal/unprocessable-messages [get]
+func (h *V1Handler) GetUnprocessableMessages(request *http.Request) (*handler.Result, *types.Error) {
+	messages, err := h.Service.GetUnprocessableMessages(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(messages), nil
+}
@@ -0,0 +1,139 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+type WatchlistRequestPayload struct {
+	WatchedBtcPkHex string `json:"watched_btc_pk_hex"`
+	SignatureHex    string `json:"signature_hex"`
+}
+
+func parseWatchlistRequestPayload(request *http.Request) (*WatchlistRequestPayload, *types.Error) {
+	payload := &WatchlistRequestPayload{}
+	if err := handler.DecodeJSONPayload(request, payload); err != nil {
+		return nil, err
+	}
+	if err := handler.ValidatePublicKeyField("watched_btc_pk_hex", payload.WatchedBtcPkHex); err != nil {
+		return nil, err
+	}
+	if err := handler.ValidateSignatureField("signature_hex", payload.SignatureHex); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// GetWatchlist @Summary Get the stakers watched by an observer
+// @Description Retrieves the stakers watched by the observer, in the order they were added.
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Observer BTC Public Key"
+// @Success 200 {object} handler.PublicResponse[[]v1service.WatchlistEntryPublic]{array} "List of watched stakers"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/watchlist [get]
+func (h *V1Handler) GetWatchlist(request *http.Request) (*handler.Result, *types.Error) {
+	observerPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	watchlist, err := h.Service.GetWatchlist(request.Context(), observerPk)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(watchlist), nil
+}
+
+// AddToWatchlist @Summary Watch a staker
+// @Description Adds a staker to the watchlist of the observer, up to 100 stakers. Adding a staker already watched returns the existing entry.
+// @Description The observer signs the sha256 of "add:<watched_btc_pk_hex>" with the BIP-340 schnorr key of its BTC public key.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Observer BTC Public Key"
+// @Param payload body WatchlistRequestPayload true "Watchlist Change Payload"
+// @Success 200 {object} handler.PublicResponse[v1service.WatchlistEntryPublic] "Watched staker"
+// @Failure 400 {object} types.Error "Invalid request payload, full watchlist (BAD_REQUEST) or signature not matching the change (INVALID_SIGNATURE)"
+// @Router /v1/staker/watchlist [post]
+func (h *V1Handler) AddToWatchlist(request *http.Request) (*handler.Result, *types.Error) {
+	observerPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := parseWatchlistRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := h.Service.AddToWatchlist(
+		request.Context(), observerPk, payload.WatchedBtcPkHex, payload.SignatureHex,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(entry), nil
+}
+
+// RemoveFromWatchlist @Summary Stop watching a staker
+// @Description Removes a staker from the watchlist of the observer.
+// @Description The observer signs the sha256 of "remove:<watched_btc_pk_hex>" with the BIP-340 schnorr key of its BTC public key.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Observer BTC Public Key"
+// @Param payload body WatchlistRequestPayload true "Watchlist Change Payload"
+// @Success 202 "Staker removed from the watchlist"
+// @Failure 400 {object} types.Error "Invalid request payload (BAD_REQUEST) or signature not matching the change (INVALID_SIGNATURE)"
+// @Failure 404 {object} types.Error "Staker not watched"
+// @Router /v1/staker/watchlist/remove [post]
+func (h *V1Handler) RemoveFromWatchlist(request *http.Request) (*handler.Result, *types.Error) {
+	observerPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := parseWatchlistRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.Service.RemoveFromWatchlist(
+		request.Context(), observerPk, payload.WatchedBtcPkHex, payload.SignatureHex,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &handler.Result{Status: http.StatusAccepted}, nil
+}
+
+// GetWatchlistDelegations @Summary Get the delegations of the watched stakers
+// @Description Retrieves the phase-1 delegations of all the stakers watched by the observer.
+// @Produce json
+// @Tags v1
+// @Param staker_btc_pk query string true "Observer BTC Public Key"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.DelegationPublic]{array} "List of delegations and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/watchlist/delegations [get]
+func (h *V1Handler) GetWatchlistDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	observerPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, newPaginationKey, err := h.Service.WatchlistDelegations(
+		request.Context(), observerPk, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	h.Service.AddUnbondingCompletions(request.Context(), delegations...)
+
+	return handler.NewResultWithPagination(delegations, newPaginationKey), nil
+}
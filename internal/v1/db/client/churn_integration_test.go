@@ -0,0 +1,70 @@
+//go:build integration
+
+package v1dbclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountFinalityProviderChurnSince(t *testing.T) {
+	ctx := context.Background()
+	v1DB := newReplicaSetDatabase(t)
+	now := time.Now()
+	daysAgo := func(days int) int64 {
+		return now.Add(-time.Duration(days) * 24 * time.Hour).Unix()
+	}
+	since := daysAgo(30)
+	delegation := func(
+		txHash, fpPkHex string, state types.DelegationState, startedDaysAgo int,
+		history []v1dbmodel.StateTransition,
+	) v1dbmodel.DelegationDocument {
+		return v1dbmodel.DelegationDocument{
+			StakingTxHashHex:      txHash,
+			StakerPkHex:           "staker-" + txHash,
+			FinalityProviderPkHex: fpPkHex,
+			State:                 state,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartTimestamp: daysAgo(startedDaysAgo)},
+			StateHistory:          history,
+		}
+	}
+	leftActive := func(days int) []v1dbmodel.StateTransition {
+		return []v1dbmodel.StateTransition{
+			{ToState: types.Active},
+			{FromState: types.Active, ToState: types.UnbondingRequested, Timestamp: daysAgo(days)},
+		}
+	}
+	// recorded before the state history, it left the active state when its
+	// unbonding started
+	unbondedWithoutHistory := delegation("unbonding-3d", "fp-a", types.Unbonding, 60, nil)
+	unbondedWithoutHistory.UnbondingTx = &v1dbmodel.TimelockTransaction{StartTimestamp: daysAgo(3)}
+
+	documents := []any{
+		delegation("new-1d", "fp-a", types.Active, 1, nil),
+		delegation("new-2d-left-1d", "fp-a", types.UnbondingRequested, 2, leftActive(1)),
+		delegation("old-left-5d", "fp-a", types.UnbondingRequested, 60, leftActive(5)),
+		unbondedWithoutHistory,
+		// left before the window
+		delegation("old-left-40d", "fp-a", types.UnbondingRequested, 60, leftActive(40)),
+		delegation("new-10d", "fp-b", types.Active, 10, nil),
+		// no change over the window
+		delegation("old-active", "fp-c", types.Active, 60, nil),
+	}
+	client := v1DB.Client.Database(v1DB.DbName).Collection(dbmodel.V1DelegationCollection)
+	_, err := client.InsertMany(ctx, documents)
+	require.NoError(t, err)
+
+	churn, err := v1DB.CountFinalityProviderChurnSince(ctx, since)
+	require.NoError(t, err)
+	assert.Equal(t, []v1dbmodel.FinalityProviderChurn{
+		{FinalityProviderPkHex: "fp-a", NewDelegations: 2, ExpiredDelegations: 3},
+		{FinalityProviderPkHex: "fp-b", NewDelegations: 1},
+	}, churn)
+}
@@ -3,6 +3,7 @@ package v1dbclient
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -19,7 +20,7 @@ import (
 func (v1dbclient *V1Database) SaveActiveStakingDelegation(
 	ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
 	stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
-	startTimestamp int64, isOverflow bool,
+	startTimestamp int64, isOverflow bool, stakingValueUsd *float64,
 ) error {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
 	document := v1dbmodel.DelegationDocument{
@@ -35,7 +36,13 @@ func (v1dbclient *V1Database) SaveActiveStakingDelegation(
 			StartHeight:    startHeight,
 			TimeLock:       timelock,
 		},
-		IsOverflow: isOverflow,
+		IsOverflow:      isOverflow,
+		StakingValueUsd: stakingValueUsd,
+		StateHistory: []v1dbmodel.StateTransition{{
+			ToState:   types.Active,
+			Timestamp: time.Now().Unix(),
+			TxHashHex: stakingTxHashHex,
+		}},
 	}
 	_, err := client.InsertOne(ctx, document)
 	if err != nil {
@@ -56,6 +63,46 @@ func (v1dbclient *V1Database) SaveActiveStakingDelegation(
 	return nil
 }
 
+// FindWithdrawnDelegationsByStakerPk returns the withdrawn delegations of the
+// staker in a paginated way, the most recently withdrawn first. Delegations
+// withdrawn before the withdrawal tx was recorded come last.
+func (v1dbclient *V1Database) FindWithdrawnDelegationsByStakerPk(
+	ctx context.Context, stakerPk string, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+
+	filter := bson.M{"staker_pk_hex": stakerPk, "state": types.Withdrawn}
+	options := options.Find().SetSort(bson.D{
+		{Key: "withdrawal_tx.height", Value: -1},
+		{Key: "_id", Value: 1},
+	})
+
+	if paginationToken != "" {
+		decodedToken, err := dbmodel.DecodePaginationToken[v1dbmodel.WithdrawnDelegationPagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		// A missing withdrawal height sorts after all the known ones
+		if decodedToken.WithdrawalHeight == nil {
+			filter["withdrawal_tx.height"] = nil
+			filter["_id"] = bson.M{"$gt": decodedToken.StakingTxHashHex}
+		} else {
+			filter["$or"] = []bson.M{
+				{"withdrawal_tx.height": bson.M{"$lt": *decodedToken.WithdrawalHeight}},
+				{"withdrawal_tx.height": *decodedToken.WithdrawalHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
+				{"withdrawal_tx.height": nil},
+			}
+		}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildWithdrawnDelegationPaginationToken,
+	)
+}
+
 // CheckDelegationExistByStakerPk checks if a staker has any
 // delegation in the specified states by the staker's public key
 func (v1dbclient *V1Database) CheckDelegationExistByStakerPk(
@@ -82,35 +129,701 @@ func (v1dbclient *V1Database) FindDelegationsByStakerPk(
 ) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
 
-	filter := bson.M{"staker_pk_hex": stakerPk}
+	filter := stakerPkFilter(stakerPk)
 	filter = buildAdditionalDelegationFilter(filter, extraFilter)
 	options := options.Find().SetSort(bson.D{
 		{Key: "staking_tx.start_height", Value: -1},
 		{Key: "_id", Value: 1},
 	})
 
+	var states []types.DelegationState
+	if extraFilter != nil {
+		states = extraFilter.States
+	}
+	tokenBuilder := v1dbclient.delegationPaginationTokenBuilder(states)
+
 	// Decode the pagination token first if it exist
 	if paginationToken != "" {
-		decodedToken, err := dbmodel.DecodePaginationToken[v1dbmodel.DelegationByStakerPagination](paginationToken)
+		decodedToken, err := v1dbclient.decodeDelegationPaginationToken(paginationToken)
 		if err != nil {
+			return nil, err
+		}
+		if !decodedToken.MatchesStates(states) {
 			return nil, &db.InvalidPaginationTokenError{
-				Message: "Invalid pagination token",
+				Message: "Pagination token was issued for a different state filter",
 			}
 		}
-		filter = bson.M{
-			"$or": []bson.M{
-				{"staker_pk_hex": stakerPk, "staking_tx.start_height": bson.M{"$lt": decodedToken.StakingStartHeight}},
-				{"staker_pk_hex": stakerPk, "staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
-			},
+		filter["$or"] = []bson.M{
+			{"staking_tx.start_height": bson.M{"$lt": decodedToken.StakingStartHeight}},
+			{"staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
 		}
 	}
 
 	return db.FindWithPagination(
 		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
-		v1dbmodel.BuildDelegationByStakerPaginationToken,
+		tokenBuilder,
 	)
 }
 
+// stakerPkFilter matches the staker public key, given in canonical form, in
+// any of the forms it was stored in before the keys were normalized: x-only or
+// compressed with either parity. The keys are matched exactly, so that the
+// staker pk index is used, the ones stored in upper or mixed case are
+// lowercased by LowercaseStakerPks.
+func stakerPkFilter(stakerPk string) bson.M {
+	return bson.M{"staker_pk_hex": bson.M{"$in": []string{stakerPk, "02" + stakerPk, "03" + stakerPk}}}
+}
+
+// LowercaseStakerPks lowercases the staker public keys stored in upper or
+// mixed case, and returns the number of delegations updated. It can be run
+// again as the keys already lowercased are not matched.
+func (v1dbclient *V1Database) LowercaseStakerPks(ctx context.Context) (int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"staker_pk_hex": primitive.Regex{Pattern: "[A-F]"}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"staker_pk_hex": bson.M{"$toLower": "$staker_pk_hex"}}}},
+	}
+	result, err := client.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// FindDelegationsByWithdrawalAddress returns the delegations withdrawn to
+// the given address in a paginated way, sorted like the staker delegations
+func (v1dbclient *V1Database) FindDelegationsByWithdrawalAddress(
+	ctx context.Context, address string, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+
+	filter := bson.M{"withdrawal_tx.address": address}
+	options := options.Find().SetSort(bson.D{
+		{Key: "staking_tx.start_height", Value: -1},
+		{Key: "_id", Value: 1},
+	})
+
+	if paginationToken != "" {
+		decodedToken, err := v1dbclient.decodeDelegationPaginationToken(paginationToken)
+		if err != nil {
+			return nil, err
+		}
+		filter["$or"] = []bson.M{
+			{"staking_tx.start_height": bson.M{"$lt": decodedToken.StakingStartHeight}},
+			{"staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
+		}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbclient.delegationPaginationTokenBuilder(nil),
+	)
+}
+
+// FindDelegationsByStartHeight returns the delegations whose staking tx is
+// included in the BTC block of the given height in a paginated way, sorted by
+// staking tx hash
+func (v1dbclient *V1Database) FindDelegationsByStartHeight(
+	ctx context.Context, height uint64, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+
+	filter := bson.M{"staking_tx.start_height": height}
+	options := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	if paginationToken != "" {
+		decodedToken, err := v1dbclient.decodeDelegationPaginationToken(paginationToken)
+		if err != nil {
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.StakingTxHashHex}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbclient.delegationPaginationTokenBuilder(nil),
+	)
+}
+
+// FindDelegationsByStakerPks returns the delegations of all the given stakers
+// in a paginated way, sorted like the staker delegations.
+// It returns an InListTooLargeError if there are too many stakers.
+func (v1dbclient *V1Database) FindDelegationsByStakerPks(
+	ctx context.Context, stakerPks []string, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	if err := db.CheckInListSize(len(stakerPks), v1dbclient.Cfg.GetMaxInListSize()); err != nil {
+		return nil, err
+	}
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+
+	filter := bson.M{"staker_pk_hex": bson.M{"$in": stakerPks}}
+	options := options.Find().SetSort(bson.D{
+		{Key: "staking_tx.start_height", Value: -1},
+		{Key: "_id", Value: 1},
+	})
+
+	if paginationToken != "" {
+		decodedToken, err := v1dbclient.decodeDelegationPaginationToken(paginationToken)
+		if err != nil {
+			return nil, err
+		}
+		filter["$or"] = []bson.M{
+			{"staking_tx.start_height": bson.M{"$lt": decodedToken.StakingStartHeight}},
+			{"staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
+		}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbclient.delegationPaginationTokenBuilder(nil),
+	)
+}
+
+// delegationPaginationTokenBuilder returns the builder of the signed tokens
+// paging the delegations by staking start height
+func (v1dbclient *V1Database) delegationPaginationTokenBuilder(
+	states []types.DelegationState,
+) func(v1dbmodel.DelegationDocument) (string, error) {
+	return dbmodel.SignedPaginationTokenBuilder(
+		v1dbmodel.NewDelegationByStakerPaginationTokenBuilder(states),
+		v1dbclient.Cfg.PaginationTokenSecret, v1dbclient.Cfg.GetPaginationTokenTtl(),
+	)
+}
+
+// decodeDelegationPaginationToken decodes a token built by
+// delegationPaginationTokenBuilder, the unsigned tokens are rejected
+func (v1dbclient *V1Database) decodeDelegationPaginationToken(
+	paginationToken string,
+) (*v1dbmodel.DelegationByStakerPagination, error) {
+	unsignedToken, err := dbmodel.VerifySignedPaginationToken(
+		paginationToken, v1dbclient.Cfg.PaginationTokenSecret, time.Now(),
+	)
+	if err != nil {
+		return nil, &db.InvalidPaginationTokenError{
+			Message: err.Error(),
+		}
+	}
+	decodedToken, err := dbmodel.DecodePaginationToken[v1dbmodel.DelegationByStakerPagination](unsignedToken)
+	if err != nil {
+		return nil, &db.InvalidPaginationTokenError{
+			Message: "Invalid pagination token",
+		}
+	}
+	return decodedToken, nil
+}
+
+// CountActiveDelegationsByTimelock returns the number of active delegations
+// grouped by their staking timelock
+func (v1dbclient *V1Database) CountActiveDelegationsByTimelock(
+	ctx context.Context,
+) (map[uint64]int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"state": types.Active}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$staking_tx.timelock",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		TimeLock uint64 `bson:"_id"`
+		Count    int64  `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint64]int64, len(results))
+	for _, result := range results {
+		counts[result.TimeLock] = result.Count
+	}
+	return counts, nil
+}
+
+// CountActiveDelegationsByStakingValue returns the number of active
+// delegations grouped by their staking value
+func (v1dbclient *V1Database) CountActiveDelegationsByStakingValue(
+	ctx context.Context,
+) (map[uint64]int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"state": types.Active}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$staking_value",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		StakingValue uint64 `bson:"_id"`
+		Count        int64  `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint64]int64, len(results))
+	for _, result := range results {
+		counts[result.StakingValue] = result.Count
+	}
+	return counts, nil
+}
+
+// FindStateHistoriesUnbondedSince returns the state history of the
+// delegations which transitioned to the unbonding state at or after the
+// given unix timestamp
+func (v1dbclient *V1Database) FindStateHistoriesUnbondedSince(
+	ctx context.Context, since int64,
+) ([][]v1dbmodel.StateTransition, error) {
+	return v1dbclient.findStateHistoriesTransitionedSince(ctx, types.Unbonding, since)
+}
+
+// FindStateHistoriesWithdrawnSince returns the state history of the
+// delegations which transitioned to the withdrawn state at or after the
+// given unix timestamp
+func (v1dbclient *V1Database) FindStateHistoriesWithdrawnSince(
+	ctx context.Context, since int64,
+) ([][]v1dbmodel.StateTransition, error) {
+	return v1dbclient.findStateHistoriesTransitionedSince(ctx, types.Withdrawn, since)
+}
+
+func (v1dbclient *V1Database) findStateHistoriesTransitionedSince(
+	ctx context.Context, state types.DelegationState, since int64,
+) ([][]v1dbmodel.StateTransition, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"state_history": bson.M{"$elemMatch": bson.M{
+		"to_state":  state,
+		"timestamp": bson.M{"$gte": since},
+	}}}
+	opts := options.Find().SetProjection(bson.M{"_id": 0, "state_history": 1})
+
+	cursor, err := client.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var histories [][]v1dbmodel.StateTransition
+	for cursor.Next(ctx) {
+		var d v1dbmodel.DelegationDocument
+		if err := cursor.Decode(&d); err != nil {
+			return nil, err
+		}
+		histories = append(histories, d.StateHistory)
+	}
+	return histories, cursor.Err()
+}
+
+// FindFinalityProviderStakeHistories returns the delegations of the finality
+// provider with only their staking value, state, staking and unbonding start
+// timestamps and state history
+func (v1dbclient *V1Database) FindFinalityProviderStakeHistories(
+	ctx context.Context, fpPkHex string,
+) ([]*v1dbmodel.DelegationDocument, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"finality_provider_pk_hex": fpPkHex}
+	opts := options.Find().SetProjection(bson.M{
+		"staking_value":                1,
+		"state":                        1,
+		"staking_tx.start_timestamp":   1,
+		"unbonding_tx.start_timestamp": 1,
+		"state_history":                1,
+	})
+
+	cursor, err := client.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []*v1dbmodel.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// CountActiveDelegationsByStartHeight returns the number of active delegations
+// of the staker grouped by their staking start height
+func (v1dbclient *V1Database) CountActiveDelegationsByStartHeight(
+	ctx context.Context, stakerPk string,
+) (map[uint64]int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"staker_pk_hex": stakerPk, "state": types.Active}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$staking_tx.start_height",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		StartHeight uint64 `bson:"_id"`
+		Count       int64  `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint64]int64, len(results))
+	for _, result := range results {
+		counts[result.StartHeight] = result.Count
+	}
+	return counts, nil
+}
+
+// CountDelegationsByState returns the number of delegations in every state
+func (v1dbclient *V1Database) CountDelegationsByState(ctx context.Context) (map[types.DelegationState]int64, error) {
+	return v1dbclient.countDelegationsByState(ctx, bson.M{})
+}
+
+// CountStakerDelegationsByState returns the number of delegations of the
+// staker in every state
+func (v1dbclient *V1Database) CountStakerDelegationsByState(
+	ctx context.Context, stakerPkHex string,
+) (map[types.DelegationState]int64, error) {
+	return v1dbclient.countDelegationsByState(ctx, bson.M{"staker_pk_hex": stakerPkHex})
+}
+
+func (v1dbclient *V1Database) countDelegationsByState(
+	ctx context.Context, filter bson.M,
+) (map[types.DelegationState]int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$state",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		State types.DelegationState `bson:"_id"`
+		Count int64                 `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[types.DelegationState]int64, len(results))
+	for _, result := range results {
+		counts[result.State] = result.Count
+	}
+	return counts, nil
+}
+
+// SumActiveDelegationsByFinalityProvider returns the number and the total
+// value of the active delegations of the staker grouped by finality provider
+func (v1dbclient *V1Database) SumActiveDelegationsByFinalityProvider(
+	ctx context.Context, stakerPk string,
+) ([]v1dbmodel.FinalityProviderDelegationsSum, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"staker_pk_hex": stakerPk, "state": types.Active}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              "$finality_provider_pk_hex",
+			"total_value":      bson.M{"$sum": "$staking_value"},
+			"delegation_count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sums []v1dbmodel.FinalityProviderDelegationsSum
+	if err = cursor.All(ctx, &sums); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// SumActiveDelegationsByStaker returns the number and the total value of the
+// active delegations to the finality provider grouped by staker, the
+// delegations of the excluded staker are left out
+func (v1dbclient *V1Database) SumActiveDelegationsByStaker(
+	ctx context.Context, fpPkHex, excludedStakerPkHex string,
+) ([]v1dbmodel.StakerDelegationsSum, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"finality_provider_pk_hex": fpPkHex,
+			"state":                    types.Active,
+			"staker_pk_hex":            bson.M{"$ne": excludedStakerPkHex},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              "$staker_pk_hex",
+			"total_value":      bson.M{"$sum": "$staking_value"},
+			"delegation_count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sums []v1dbmodel.StakerDelegationsSum
+	if err = cursor.All(ctx, &sums); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// CountUniqueStakersByFinalityProvider returns the number of distinct
+// stakers with an active delegation to every finality provider having at
+// least minStakers of them, the most staked first
+func (v1dbclient *V1Database) CountUniqueStakersByFinalityProvider(
+	ctx context.Context, minStakers int64,
+) ([]v1dbmodel.FinalityProviderStakersCount, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	cursor, err := client.Aggregate(ctx, uniqueStakersByFinalityProviderPipeline(minStakers))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var counts []v1dbmodel.FinalityProviderStakersCount
+	if err = cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// uniqueStakersByFinalityProviderPipeline groups the active delegations by
+// finality provider and staker first, so that every staker is counted once
+// per finality provider
+func uniqueStakersByFinalityProviderPipeline(minStakers int64) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"state": types.Active}}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{
+			"finality_provider_pk_hex": "$finality_provider_pk_hex",
+			"staker_pk_hex":            "$staker_pk_hex",
+		}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":            "$_id.finality_provider_pk_hex",
+			"unique_stakers": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"unique_stakers": bson.M{"$gte": minStakers}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "unique_stakers", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+}
+
+// CountFinalityProviderChurnSince returns, for every finality provider, the
+// number of its delegations which became active and the number which left
+// the active state at or after the given unix timestamp
+func (v1dbclient *V1Database) CountFinalityProviderChurnSince(
+	ctx context.Context, since int64,
+) ([]v1dbmodel.FinalityProviderChurn, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	cursor, err := client.Aggregate(ctx, finalityProviderChurnPipeline(since))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var churn []v1dbmodel.FinalityProviderChurn
+	if err = cursor.All(ctx, &churn); err != nil {
+		return nil, err
+	}
+	return churn, nil
+}
+
+// finalityProviderChurnPipeline counts the delegations by finality provider.
+// A delegation became active at its staking start. It left the active state
+// on its first transition out of it, or when its unbonding started if the
+// transition is no longer in its history.
+func finalityProviderChurnPipeline(since int64) mongo.Pipeline {
+	firstTransitionOutOfActive := bson.M{"$arrayElemAt": bson.A{
+		bson.M{"$filter": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$state_history", bson.A{}}},
+			"as":    "transition",
+			"cond":  bson.M{"$eq": bson.A{"$$transition.from_state", types.Active}},
+		}},
+		0,
+	}}
+	leftActiveAt := bson.M{"$ifNull": bson.A{
+		bson.M{"$let": bson.M{
+			"vars": bson.M{"transition": firstTransitionOutOfActive},
+			"in":   "$$transition.timestamp",
+		}},
+		"$unbonding_tx.start_timestamp",
+	}}
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"staking_tx.start_timestamp": bson.M{"$gte": since}},
+			bson.M{"state_history": bson.M{"$elemMatch": bson.M{
+				"from_state": types.Active,
+				"timestamp":  bson.M{"$gte": since},
+			}}},
+			bson.M{"unbonding_tx.start_timestamp": bson.M{"$gte": since}},
+		}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": "$finality_provider_pk_hex",
+			"new_delegations": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{"$staking_tx.start_timestamp", since}}, 1, 0,
+			}}},
+			"expired_delegations": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{leftActiveAt, since}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+}
+
+// FindStakersFirstSeenTimestamps returns, for every staker, the staking start
+// timestamp of its earliest delegation
+func (v1dbclient *V1Database) FindStakersFirstSeenTimestamps(ctx context.Context) ([]int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$staker_pk_hex",
+			"first_seen": bson.M{"$min": "$staking_tx.start_timestamp"},
+		}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "first_seen": 1}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var timestamps []int64
+	for cursor.Next(ctx) {
+		var result struct {
+			FirstSeen int64 `bson:"first_seen"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		timestamps = append(timestamps, result.FirstSeen)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return timestamps, nil
+}
+
+// IterateDelegationsByStakerPk calls fn for every delegation of the staker,
+// the most recent first. The delegations are read from a cursor one at a time,
+// the iteration stops at the first error returned by fn.
+func (v1dbclient *V1Database) IterateDelegationsByStakerPk(
+	ctx context.Context, stakerPk string, fn func(*v1dbmodel.DelegationDocument) error,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"staker_pk_hex": stakerPk}
+	options := options.Find().SetSort(bson.D{
+		{Key: "staking_tx.start_height", Value: -1},
+		{Key: "_id", Value: 1},
+	})
+
+	cursor, err := client.Find(ctx, filter, options)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var delegation v1dbmodel.DelegationDocument
+		if err := cursor.Decode(&delegation); err != nil {
+			return err
+		}
+		if err := fn(&delegation); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// FindStakerPks returns the public keys of all the stakers with at least one
+// delegation
+func (v1dbclient *V1Database) FindStakerPks(ctx context.Context) ([]string, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	// grouping streams the keys through a cursor, unlike distinct whose
+	// result is bound by the document size limit
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$staker_pk_hex"}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stakerPks []string
+	for cursor.Next(ctx) {
+		var result struct {
+			StakerPkHex string `bson:"_id"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		stakerPks = append(stakerPks, result.StakerPkHex)
+	}
+	return stakerPks, cursor.Err()
+}
+
+// FindDelegationTxHashesByStakerPk returns the staking tx hashes of all the
+// delegations of the staker, sorted in ascending order.
+func (v1dbclient *V1Database) FindDelegationTxHashesByStakerPk(
+	ctx context.Context, stakerPk string,
+) ([]string, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"staker_pk_hex": stakerPk}
+	options := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.M{"_id": 1})
+
+	cursor, err := client.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		StakingTxHashHex string `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	txHashes := make([]string, 0, len(results))
+	for _, result := range results {
+		txHashes = append(txHashes, result.StakingTxHashHex)
+	}
+	return txHashes, nil
+}
+
 // SaveUnbondingTx saves the unbonding transaction details for a staking transaction
 // It returns an NotFoundError if the staking transaction is not found
 func (v1dbclient *V1Database) FindDelegationByTxHashHex(ctx context.Context, stakingTxHashHex string) (*v1dbmodel.DelegationDocument, error) {
@@ -157,42 +870,263 @@ func (v1dbclient *V1Database) ScanDelegationsPaginated(
 	)
 }
 
+func (v1dbclient *V1Database) FindDelegationsWithUnknownFinalityProvider(
+	ctx context.Context, registeredFpPkHexes []string, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"finality_provider_pk_hex": bson.M{"$nin": registeredFpPkHexes}}
+	options := options.Find().SetSort(bson.M{"_id": 1})
+	if paginationToken != "" {
+		decodedToken, err :=
+			dbmodel.DecodePaginationToken[v1dbmodel.DelegationScanPagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.StakingTxHashHex}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildDelegationScanPaginationToken,
+	)
+}
+
+func (v1dbclient *V1Database) FindActiveDelegationsBelowStakingValue(
+	ctx context.Context, minStakingValue uint64, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{
+		"state":         types.Active.ToString(),
+		"staking_value": bson.M{"$lt": minStakingValue},
+	}
+	options := options.Find().SetSort(bson.M{"_id": 1})
+	if paginationToken != "" {
+		decodedToken, err :=
+			dbmodel.DecodePaginationToken[v1dbmodel.DelegationScanPagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.StakingTxHashHex}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildDelegationScanPaginationToken,
+	)
+}
+
+// FindUnbondingRequestedDelegationsExpiringBefore returns the delegations in
+// the unbonding requested state whose staking timelock expires before the
+// given height, in a paginated way
+func (v1dbclient *V1Database) FindUnbondingRequestedDelegationsExpiringBefore(
+	ctx context.Context, expiryHeight uint64, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{
+		"state": types.UnbondingRequested.ToString(),
+		"$expr": bson.M{"$lt": bson.A{
+			bson.M{"$add": bson.A{"$staking_tx.start_height", "$staking_tx.timelock"}},
+			expiryHeight,
+		}},
+	}
+	options := options.Find().SetSort(bson.M{"_id": 1})
+	if paginationToken != "" {
+		decodedToken, err :=
+			dbmodel.DecodePaginationToken[v1dbmodel.DelegationScanPagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.StakingTxHashHex}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildDelegationScanPaginationToken,
+	)
+}
+
+// FindActiveDelegationsByStakerPkExpiringBefore returns the active
+// delegations of the staker whose staking timelock expires before the given
+// height
+func (v1dbclient *V1Database) FindActiveDelegationsByStakerPkExpiringBefore(
+	ctx context.Context, stakerPk string, expiryHeight uint64,
+) ([]v1dbmodel.DelegationDocument, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := stakerPkFilter(stakerPk)
+	filter["state"] = types.Active.ToString()
+	filter["$expr"] = bson.M{"$lt": bson.A{
+		bson.M{"$add": bson.A{"$staking_tx.start_height", "$staking_tx.timelock"}},
+		expiryHeight,
+	}}
+
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []v1dbmodel.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// FindDelegationsWithMinStakingValue returns the delegations staking at
+// least the given value, the largest first, in a paginated way
+func (v1dbclient *V1Database) FindDelegationsWithMinStakingValue(
+	ctx context.Context, minStakingValue uint64, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter, err := highValueDelegationsFilter(minStakingValue, paginationToken)
+	if err != nil {
+		return nil, err
+	}
+	options := options.Find().SetSort(highValueDelegationsSort)
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildDelegationByValuePaginationToken,
+	)
+}
+
+// highValueDelegationsSort ranks the delegations by staking value, the
+// staking tx hash breaks the ties so that pages neither skip nor repeat
+// delegations with the same value
+var highValueDelegationsSort = bson.D{{Key: "staking_value", Value: -1}, {Key: "_id", Value: -1}}
+
+// highValueDelegationsFilter selects the delegations staking at least the
+// given value, ranked after the last delegation of the previous page
+func highValueDelegationsFilter(minStakingValue uint64, paginationToken string) (bson.M, error) {
+	filter := bson.M{"staking_value": bson.M{"$gte": minStakingValue}}
+	if paginationToken == "" {
+		return filter, nil
+	}
+	decodedToken, err := dbmodel.DecodePaginationToken[v1dbmodel.DelegationByValuePagination](paginationToken)
+	if err != nil {
+		return nil, &db.InvalidPaginationTokenError{
+			Message: "Invalid pagination token",
+		}
+	}
+	filter["$or"] = []bson.M{
+		{"staking_value": bson.M{"$lt": decodedToken.StakingValue}},
+		{"staking_value": decodedToken.StakingValue, "_id": bson.M{"$lt": decodedToken.StakingTxHashHex}},
+	}
+	return filter, nil
+}
+
+// FindDelegationsOverdueForWithdrawal returns the active and unbonded
+// delegations whose timelock expires before the given height, in a paginated
+// way. The timelock of an early unbonded delegation is the one of its
+// unbonding tx, the one of its staking tx otherwise.
+func (v1dbclient *V1Database) FindDelegationsOverdueForWithdrawal(
+	ctx context.Context, expiryHeight uint64, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{
+		"state": bson.M{"$in": []types.DelegationState{types.Active, types.Unbonded}},
+		"$expr": bson.M{"$lt": bson.A{
+			bson.M{"$ifNull": bson.A{
+				bson.M{"$add": bson.A{"$unbonding_tx.start_height", "$unbonding_tx.timelock"}},
+				bson.M{"$add": bson.A{"$staking_tx.start_height", "$staking_tx.timelock"}},
+			}},
+			expiryHeight,
+		}},
+	}
+	options := options.Find().SetSort(bson.M{"_id": 1})
+	if paginationToken != "" {
+		decodedToken, err :=
+			dbmodel.DecodePaginationToken[v1dbmodel.DelegationScanPagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.StakingTxHashHex}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildDelegationScanPaginationToken,
+	)
+}
+
 // TransitionToTransitionedState marks an existing delegation as transitioned
 func (v1dbclient *V1Database) TransitionToTransitionedState(
 	ctx context.Context, stakingTxHashHex string,
 ) error {
 	return v1dbclient.transitionState(
 		ctx, stakingTxHashHex, types.Transitioned.ToString(),
-		utils.QualifiedStatesToTransitioned(), nil,
+		utils.QualifiedStatesToTransitioned(), "", nil,
 	)
 }
 
 // TransitionState updates the state of a staking transaction to a new state
-// It returns an NotFoundError if the staking transaction is not found or not in the eligible state to transition
+// and records the transition, triggered by the given tx if any, in its state
+// history. It returns an NotFoundError if the staking transaction is not
+// found or not in the eligible state to transition
 func (v1dbclient *V1Database) transitionState(
 	ctx context.Context, stakingTxHashHex, newState string,
-	eligiblePreviousState []types.DelegationState, additionalUpdates map[string]interface{},
+	eligiblePreviousState []types.DelegationState, triggeringTxHashHex string,
+	additionalUpdates map[string]interface{},
 ) error {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
 	filter := bson.M{"_id": stakingTxHashHex, "state": bson.M{"$in": eligiblePreviousState}}
-	update := bson.M{"$set": bson.M{"state": newState}}
-	for field, value := range additionalUpdates {
-		// Add additional fields to the $set operation
-		update["$set"].(bson.M)[field] = value
-	}
-	_, err := client.UpdateOne(ctx, filter, update)
+	update := stateTransitionUpdate(newState, triggeringTxHashHex, time.Now().Unix(), additionalUpdates)
+	result, err := client.UpdateOne(ctx, filter, update)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return &db.NotFoundError{
-				Key:     stakingTxHashHex,
-				Message: "Delegation not found or not in eligible state to transition",
-			}
-		}
 		return err
 	}
+	// UpdateOne does not fail when nothing matches, the transition is
+	// rejected if the delegation is missing or not in an eligible state
+	if result.MatchedCount == 0 {
+		return &db.NotFoundError{
+			Key:     stakingTxHashHex,
+			Message: "Delegation not found or not in eligible state to transition",
+		}
+	}
 	return nil
 }
 
+// stateTransitionUpdate returns the pipeline update changing the state of a
+// delegation. The transition is appended to the state history in the same
+// update, so that a state is never changed without being recorded.
+func stateTransitionUpdate(
+	newState, triggeringTxHashHex string, timestamp int64, additionalUpdates map[string]interface{},
+) mongo.Pipeline {
+	transition := bson.M{
+		"from_state": "$state",
+		"to_state":   bson.M{"$literal": newState},
+		"timestamp":  timestamp,
+	}
+	if triggeringTxHashHex != "" {
+		transition["tx_hash_hex"] = bson.M{"$literal": triggeringTxHashHex}
+	}
+	set := bson.D{
+		{Key: "state", Value: bson.M{"$literal": newState}},
+		// The history is evaluated against the document before the update,
+		// $state is then the state the delegation transitions from
+		{Key: "state_history", Value: bson.M{"$slice": bson.A{
+			bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$state_history", bson.A{}}},
+				bson.A{transition},
+			}},
+			-v1dbmodel.MaxStateHistoryLength,
+		}}},
+	}
+	for field, value := range additionalUpdates {
+		// Values are not expressions in a pipeline update
+		set = append(set, bson.E{Key: field, Value: bson.M{"$literal": value}})
+	}
+	return mongo.Pipeline{{{Key: "$set", Value: set}}}
+}
+
 func buildAdditionalDelegationFilter(
 	baseFilter primitive.M,
 	filters *DelegationFilter,
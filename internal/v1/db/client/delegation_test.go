@@ -0,0 +1,145 @@
+package v1dbclient
+
+import (
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestStateTransitionUpdate(t *testing.T) {
+	withdrawalTx := &v1dbmodel.WithdrawalTransaction{TxHashHex: "$withdrawal", Height: 10}
+	update := stateTransitionUpdate(
+		types.Withdrawn.ToString(), "$withdrawal", 1700000000,
+		map[string]interface{}{"withdrawal_tx": withdrawalTx},
+	)
+
+	// the state and its history are changed by a single $set stage
+	require.Len(t, update, 1)
+	require.Equal(t, "$set", update[0][0].Key)
+	set := update[0][0].Value.(bson.D).Map()
+
+	assert.Equal(t, bson.M{"$literal": "withdrawn"}, set["state"])
+	assert.Equal(t, bson.M{"$slice": bson.A{
+		bson.M{"$concatArrays": bson.A{
+			bson.M{"$ifNull": bson.A{"$state_history", bson.A{}}},
+			bson.A{bson.M{
+				"from_state":  "$state",
+				"to_state":    bson.M{"$literal": "withdrawn"},
+				"timestamp":   int64(1700000000),
+				"tx_hash_hex": bson.M{"$literal": "$withdrawal"},
+			}},
+		}},
+		-v1dbmodel.MaxStateHistoryLength,
+	}}, set["state_history"])
+	// values starting with $ must not be read as field paths
+	assert.Equal(t, bson.M{"$literal": withdrawalTx}, set["withdrawal_tx"])
+}
+
+func TestStakerPkFilter(t *testing.T) {
+	const canonicalPkHex = "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+
+	// documents stored in the legacy forms remain reachable, through exact
+	// matches which use the staker pk index
+	assert.Equal(t, bson.M{"staker_pk_hex": bson.M{"$in": []string{
+		canonicalPkHex, "02" + canonicalPkHex, "03" + canonicalPkHex,
+	}}}, stakerPkFilter(canonicalPkHex))
+}
+
+func TestHighValueDelegationsPagination(t *testing.T) {
+	// the delegations are ranked by staking value then by staking tx hash,
+	// both descending
+	assert.Equal(t, bson.D{{Key: "staking_value", Value: -1}, {Key: "_id", Value: -1}}, highValueDelegationsSort)
+
+	t.Run("first page", func(t *testing.T) {
+		filter, err := highValueDelegationsFilter(1000, "")
+		require.NoError(t, err)
+		assert.Equal(t, bson.M{"staking_value": bson.M{"$gte": uint64(1000)}}, filter)
+	})
+
+	t.Run("next page starts after the last delegation of the previous one", func(t *testing.T) {
+		token, err := v1dbmodel.BuildDelegationByValuePaginationToken(
+			v1dbmodel.DelegationDocument{StakingTxHashHex: "tx-b", StakingValue: 5000},
+		)
+		require.NoError(t, err)
+
+		filter, err := highValueDelegationsFilter(1000, token)
+		require.NoError(t, err)
+		assert.Equal(t, bson.M{
+			"staking_value": bson.M{"$gte": uint64(1000)},
+			"$or": []bson.M{
+				{"staking_value": bson.M{"$lt": uint64(5000)}},
+				{"staking_value": uint64(5000), "_id": bson.M{"$lt": "tx-b"}},
+			},
+		}, filter)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := highValueDelegationsFilter(1000, "not a token")
+		assert.True(t, db.IsInvalidPaginationTokenError(err))
+	})
+}
+
+func TestDelegationPaginationTokenIsSigned(t *testing.T) {
+	v1dbclient := &V1Database{Database: &dbclient.Database{
+		Cfg: &config.DbConfig{PaginationTokenSecret: "secret"},
+	}}
+	delegation := v1dbmodel.DelegationDocument{
+		StakingTxHashHex: "tx",
+		StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 10},
+	}
+
+	token, err := v1dbclient.delegationPaginationTokenBuilder(nil)(delegation)
+	require.NoError(t, err)
+	decoded, err := v1dbclient.decodeDelegationPaginationToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "tx", decoded.StakingTxHashHex)
+	assert.Equal(t, uint64(10), decoded.StakingStartHeight)
+
+	// the unsigned tokens are rejected
+	unsignedToken, err := dbmodel.GetPaginationToken(v1dbmodel.DelegationByStakerPagination{
+		StakingTxHashHex:   "forged",
+		StakingStartHeight: 10,
+	})
+	require.NoError(t, err)
+	_, err = v1dbclient.decodeDelegationPaginationToken(unsignedToken)
+	assert.True(t, db.IsInvalidPaginationTokenError(err))
+}
+
+func TestUniqueStakersByFinalityProviderPipeline(t *testing.T) {
+	pipeline := uniqueStakersByFinalityProviderPipeline(100)
+	require.Len(t, pipeline, 5)
+	// the stakers are deduplicated per finality provider before counting
+	assert.Equal(t, bson.E{Key: "$group", Value: bson.M{"_id": bson.M{
+		"finality_provider_pk_hex": "$finality_provider_pk_hex",
+		"staker_pk_hex":            "$staker_pk_hex",
+	}}}, pipeline[1][0])
+	assert.Equal(t, bson.E{Key: "$match", Value: bson.M{"unique_stakers": bson.M{"$gte": int64(100)}}}, pipeline[3][0])
+	assert.Equal(t, bson.E{Key: "$sort", Value: bson.D{
+		{Key: "unique_stakers", Value: -1}, {Key: "_id", Value: 1},
+	}}, pipeline[4][0])
+}
+
+func TestFinalityProviderChurnPipeline(t *testing.T) {
+	pipeline := finalityProviderChurnPipeline(1700000000)
+	require.Len(t, pipeline, 3)
+	// only the delegations which became active or left the active state
+	// since the timestamp are grouped
+	assert.Equal(t, bson.E{Key: "$match", Value: bson.M{"$or": bson.A{
+		bson.M{"staking_tx.start_timestamp": bson.M{"$gte": int64(1700000000)}},
+		bson.M{"state_history": bson.M{"$elemMatch": bson.M{
+			"from_state": types.Active,
+			"timestamp":  bson.M{"$gte": int64(1700000000)},
+		}}},
+		bson.M{"unbonding_tx.start_timestamp": bson.M{"$gte": int64(1700000000)}},
+	}}}, pipeline[0][0])
+	assert.Equal(t, "$group", pipeline[1][0].Key)
+	assert.Equal(t, "$finality_provider_pk_hex", pipeline[1][0].Value.(bson.M)["_id"])
+}
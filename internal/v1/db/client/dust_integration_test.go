@@ -0,0 +1,62 @@
+//go:build integration
+
+package v1dbclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindActiveDelegationsBelowStakingValue(t *testing.T) {
+	ctx := context.Background()
+	v1DB := newReplicaSetDatabase(t)
+	v1DB.Cfg.MaxPaginationLimit = 2
+	delegation := func(txHash string, stakingValue uint64, state types.DelegationState) v1dbmodel.DelegationDocument {
+		return v1dbmodel.DelegationDocument{
+			StakingTxHashHex: txHash,
+			StakerPkHex:      "staker-" + txHash,
+			StakingValue:     stakingValue,
+			State:            state,
+		}
+	}
+	documents := []any{
+		delegation("a-below", 49_999, types.Active),
+		delegation("b-at-min", 50_000, types.Active),
+		delegation("c-above", 80_000, types.Active),
+		delegation("d-below", 20_000, types.Active),
+		// staking less than the minimum but no longer active
+		delegation("e-unbonded", 10_000, types.Unbonded),
+		delegation("f-below", 1, types.Active),
+	}
+	client := v1DB.Client.Database(v1DB.DbName).Collection(dbmodel.V1DelegationCollection)
+	_, err := client.InsertMany(ctx, documents)
+	require.NoError(t, err)
+
+	txHashes := func(result []v1dbmodel.DelegationDocument) []string {
+		hashes := make([]string, 0, len(result))
+		for _, d := range result {
+			hashes = append(hashes, d.StakingTxHashHex)
+		}
+		return hashes
+	}
+
+	firstPage, err := v1DB.FindActiveDelegationsBelowStakingValue(ctx, 50_000, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a-below", "d-below"}, txHashes(firstPage.Data))
+	require.NotEmpty(t, firstPage.PaginationToken)
+
+	secondPage, err := v1DB.FindActiveDelegationsBelowStakingValue(ctx, 50_000, firstPage.PaginationToken)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"f-below"}, txHashes(secondPage.Data))
+	assert.Empty(t, secondPage.PaginationToken)
+
+	_, err = v1DB.FindActiveDelegationsBelowStakingValue(ctx, 50_000, "invalid")
+	assert.True(t, db.IsInvalidPaginationTokenError(err))
+}
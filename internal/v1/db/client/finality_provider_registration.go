@@ -0,0 +1,67 @@
+package v1dbclient
+
+import (
+	"context"
+	"errors"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveFinalityProviderRegistrations stores the registrations in a single
+// unordered bulk write, so that a finality provider already registered does
+// not stop the others from being stored. It returns the indexes of the
+// registrations whose finality provider was already registered.
+func (v1dbclient *V1Database) SaveFinalityProviderRegistrations(
+	ctx context.Context, registrations []*v1dbmodel.FinalityProviderRegistrationDocument,
+) ([]int, error) {
+	if len(registrations) == 0 {
+		return nil, nil
+	}
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1FpRegistrationsCollection)
+	documents := make([]any, 0, len(registrations))
+	for _, registration := range registrations {
+		documents = append(documents, registration)
+	}
+
+	_, err := client.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return nil, nil
+	}
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return nil, err
+	}
+	var duplicates []int
+	for _, writeErr := range bulkErr.WriteErrors {
+		if !mongo.IsDuplicateKeyError(writeErr) {
+			return nil, err
+		}
+		duplicates = append(duplicates, writeErr.Index)
+	}
+	return duplicates, nil
+}
+
+// FindFinalityProviderRegistrations returns the finality providers
+// registered through the API, the oldest registration first
+func (v1dbclient *V1Database) FindFinalityProviderRegistrations(
+	ctx context.Context,
+) ([]v1dbmodel.FinalityProviderRegistrationDocument, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1FpRegistrationsCollection)
+	options := options.Find().SetSort(bson.D{{Key: "registered_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := client.Find(ctx, bson.M{}, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var registrations []v1dbmodel.FinalityProviderRegistrationDocument
+	if err = cursor.All(ctx, &registrations); err != nil {
+		return nil, err
+	}
+	return registrations, nil
+}
@@ -0,0 +1,47 @@
+//go:build integration
+
+package v1dbclient
+
+import (
+	"context"
+	"testing"
+
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveFinalityProviderRegistrations(t *testing.T) {
+	ctx := context.Background()
+	v1DB := newReplicaSetDatabase(t)
+	registration := func(btcPk string, registeredAt int64) *v1dbmodel.FinalityProviderRegistrationDocument {
+		return &v1dbmodel.FinalityProviderRegistrationDocument{
+			BtcPk:        btcPk,
+			Description:  v1dbmodel.FinalityProviderRegistrationDescription{Moniker: "fp-" + btcPk},
+			Commission:   "0.05",
+			RegisteredAt: registeredAt,
+		}
+	}
+
+	duplicates, err := v1DB.SaveFinalityProviderRegistrations(ctx, []*v1dbmodel.FinalityProviderRegistrationDocument{
+		registration("b", 1), registration("a", 1),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, duplicates)
+
+	// the registrations after a duplicate are still stored
+	duplicates, err = v1DB.SaveFinalityProviderRegistrations(ctx, []*v1dbmodel.FinalityProviderRegistrationDocument{
+		registration("a", 2), registration("c", 2), registration("b", 2), registration("d", 0),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, duplicates)
+
+	registrations, err := v1DB.FindFinalityProviderRegistrations(ctx)
+	require.NoError(t, err)
+	btcPks := make([]string, 0, len(registrations))
+	for _, r := range registrations {
+		btcPks = append(btcPks, r.BtcPk)
+	}
+	assert.Equal(t, []string{"d", "a", "b", "c"}, btcPks)
+	assert.Equal(t, int64(1), registrations[1].RegisteredAt, "the first registration is kept")
+}
@@ -0,0 +1,197 @@
+package v1dbclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindUnbondingDocumentsCreatedAfter returns the unbonding documents inserted
+// after the given time. The creation time is taken from the document object id.
+func (v1dbclient *V1Database) FindUnbondingDocumentsCreatedAfter(
+	ctx context.Context, after time.Time,
+) ([]v1dbmodel.UnbondingDocument, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1UnbondingCollection)
+	filter := bson.M{"_id": bson.M{"$gte": primitive.NewObjectIDFromTimestamp(after)}}
+
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var unbondingDocuments []v1dbmodel.UnbondingDocument
+	if err = cursor.All(ctx, &unbondingDocuments); err != nil {
+		return nil, err
+	}
+	return unbondingDocuments, nil
+}
+
+// FindUnprocessedUnbondingDocumentsCreatedBefore returns the unbonding
+// documents inserted before the given time and still in the initial state,
+// meaning the unbonding pipeline never collected the covenant signatures.
+func (v1dbclient *V1Database) FindUnprocessedUnbondingDocumentsCreatedBefore(
+	ctx context.Context, before time.Time,
+) ([]v1dbmodel.UnbondingDocument, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1UnbondingCollection)
+	filter := bson.M{
+		"_id":   bson.M{"$lt": primitive.NewObjectIDFromTimestamp(before)},
+		"state": v1dbmodel.UnbondingInitialState,
+	}
+
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var unbondingDocuments []v1dbmodel.UnbondingDocument
+	if err = cursor.All(ctx, &unbondingDocuments); err != nil {
+		return nil, err
+	}
+	return unbondingDocuments, nil
+}
+
+// FindDelegationsByTxHashHexes returns the delegations matching the given
+// staking tx hashes. Hashes without a delegation are absent from the result.
+// It returns an InListTooLargeError if there are too many hashes.
+func (v1dbclient *V1Database) FindDelegationsByTxHashHexes(
+	ctx context.Context, stakingTxHashHexes []string,
+) ([]v1dbmodel.DelegationDocument, error) {
+	if err := db.CheckInListSize(len(stakingTxHashHexes), v1dbclient.Cfg.GetMaxInListSize()); err != nil {
+		return nil, err
+	}
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"_id": bson.M{"$in": stakingTxHashHexes}}
+
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []v1dbmodel.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// SampleDelegations returns up to size randomly picked delegations
+func (v1dbclient *V1Database) SampleDelegations(
+	ctx context.Context, size int64,
+) ([]v1dbmodel.DelegationDocument, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$sample", Value: bson.M{"size": size}}},
+	}
+
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []v1dbmodel.DelegationDocument
+	if err = cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// QuarantineUnbondingDocument marks a not yet processed unbonding document as
+// skipped so that it is excluded from the unbonding pipeline.
+// It returns a NotFoundError if the document is not in the initial state.
+func (v1dbclient *V1Database) QuarantineUnbondingDocument(
+	ctx context.Context, unbondingTxHashHex string,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1UnbondingCollection)
+	filter := bson.M{
+		"unbonding_tx_hash_hex": unbondingTxHashHex,
+		"state":                 v1dbmodel.UnbondingInitialState,
+	}
+	update := bson.M{"$set": bson.M{"state": v1dbmodel.UnbondingSkippedState}}
+
+	result, err := client.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return &db.NotFoundError{
+			Key:     unbondingTxHashHex,
+			Message: "unbonding document not found or already processed",
+		}
+	}
+	return nil
+}
+
+// SaveIntegrityIssue stores the integrity issue, an already stored issue is
+// updated with the latest detection.
+func (v1dbclient *V1Database) SaveIntegrityIssue(
+	ctx context.Context, issue *v1dbmodel.IntegrityIssueDocument,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1IntegrityIssuesCollection)
+	filter := bson.M{"_id": issue.Id}
+
+	_, err := client.ReplaceOne(ctx, filter, issue, options.Replace().SetUpsert(true))
+	return err
+}
+
+// FindIntegrityIssues returns the stored integrity issues in a paginated way
+func (v1dbclient *V1Database) FindIntegrityIssues(
+	ctx context.Context, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.IntegrityIssueDocument], error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1IntegrityIssuesCollection)
+	filter := bson.M{}
+	options := options.Find().SetSort(bson.M{"_id": 1})
+
+	if paginationToken != "" {
+		decodedToken, err :=
+			dbmodel.DecodePaginationToken[v1dbmodel.IntegrityIssuePagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.Id}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildIntegrityIssuePaginationToken,
+	)
+}
+
+// FindUnbondingDocumentsByStakingTxHashHexes returns all the unbonding
+// documents of the given delegations.
+// It returns an InListTooLargeError if there are too many hashes.
+func (v1dbclient *V1Database) FindUnbondingDocumentsByStakingTxHashHexes(
+	ctx context.Context, stakingTxHashHexes []string,
+) ([]v1dbmodel.UnbondingDocument, error) {
+	if err := db.CheckInListSize(len(stakingTxHashHexes), v1dbclient.Cfg.GetMaxInListSize()); err != nil {
+		return nil, err
+	}
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1UnbondingCollection)
+	// The staking tx hash has no bson tag in the unbonding document, hence it
+	// is stored under the default lowercase field name
+	filter := bson.M{"stakingtxhashhex": bson.M{"$in": stakingTxHashHexes}}
+
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var unbondingDocuments []v1dbmodel.UnbondingDocument
+	if err = cursor.All(ctx, &unbondingDocuments); err != nil {
+		return nil, err
+	}
+	return unbondingDocuments, nil
+}
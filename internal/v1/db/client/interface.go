@@ -2,6 +2,7 @@ package v1dbclient
 
 import (
 	"context"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
@@ -9,15 +10,13 @@ import (
 	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 )
 
-//go:generate mockery --name=V1DBClient --output=../../../../tests/mocks --outpkg=mocks --filename=mock_v1_db_client.go
-type V1DBClient interface {
-	dbclient.DBClient
-	SaveActiveStakingDelegation(
-		ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
-		stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
-		startTimestamp int64, isOverflow bool,
-	) error
+// V1DBReader holds the methods of V1DBClient that only read from the database
+type V1DBReader interface {
+	dbclient.DBReader
 	// FindDelegationsByStakerPk finds all delegations by the staker's public key.
+	// The key is given in canonical form and matched in its x-only and
+	// compressed lowercase forms, the forms it was stored in before the keys
+	// were normalized.
 	// The extraFilter parameter can be used to filter the results by the delegation's
 	// properties. The paginationToken parameter is used to fetch the next page of results.
 	// If the paginationToken is empty, the first page of results will be fetched.
@@ -27,10 +26,215 @@ type V1DBClient interface {
 		ctx context.Context, stakerPk string,
 		extraFilter *DelegationFilter, paginationToken string,
 	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// IterateDelegationsByStakerPk calls fn for every delegation of the
+	// staker, the most recent first, without loading them all in memory.
+	IterateDelegationsByStakerPk(
+		ctx context.Context, stakerPk string, fn func(*v1dbmodel.DelegationDocument) error,
+	) error
+	// FindStakerPks returns the public keys of all the stakers with at least
+	// one delegation
+	FindStakerPks(ctx context.Context) ([]string, error)
+	// FindDelegationTxHashesByStakerPk returns the staking tx hashes of all the
+	// delegations of the staker, sorted in ascending order.
+	FindDelegationTxHashesByStakerPk(ctx context.Context, stakerPk string) ([]string, error)
+	FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error)
+	// IterateWithdrawnDelegationsWithoutPath calls fn for every withdrawn
+	// delegation whose withdrawal path is not recorded
+	IterateWithdrawnDelegationsWithoutPath(
+		ctx context.Context, fn func(*v1dbmodel.DelegationDocument) error,
+	) error
+	// GetStatsLock is the read-only counterpart of GetOrCreateStatsLock, it
+	// never creates the lock document.
+	GetStatsLock(
+		ctx context.Context, stakingTxHashHex string, state string,
+	) (*v1dbmodel.StatsLockDocument, error)
+	GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error)
+	FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error)
+	FindFinalityProviderStatsByFinalityProviderPkHex(
+		ctx context.Context, finalityProviderPkHex []string,
+	) ([]*v1dbmodel.FinalityProviderStatsDocument, error)
+	// CountDelegationsByState returns the number of delegations in every state
+	CountDelegationsByState(ctx context.Context) (map[types.DelegationState]int64, error)
+	// CountStakerDelegationsByState returns the number of delegations of the
+	// staker in every state
+	CountStakerDelegationsByState(
+		ctx context.Context, stakerPkHex string,
+	) (map[types.DelegationState]int64, error)
+	// CountActiveDelegationsByStartHeight returns the number of active
+	// delegations of the staker grouped by their staking start height
+	CountActiveDelegationsByStartHeight(ctx context.Context, stakerPk string) (map[uint64]int64, error)
+	// CountActiveDelegationsByTimelock returns the number of active
+	// delegations grouped by their staking timelock
+	CountActiveDelegationsByTimelock(ctx context.Context) (map[uint64]int64, error)
+	// CountActiveDelegationsByStakingValue returns the number of active
+	// delegations grouped by their staking value
+	CountActiveDelegationsByStakingValue(ctx context.Context) (map[uint64]int64, error)
+	// FindStateHistoriesUnbondedSince returns the state history of the
+	// delegations which transitioned to the unbonding state at or after the
+	// given unix timestamp
+	FindStateHistoriesUnbondedSince(ctx context.Context, since int64) ([][]v1dbmodel.StateTransition, error)
+	// FindStateHistoriesWithdrawnSince returns the state history of the
+	// delegations which transitioned to the withdrawn state at or after the
+	// given unix timestamp
+	FindStateHistoriesWithdrawnSince(ctx context.Context, since int64) ([][]v1dbmodel.StateTransition, error)
+	// FindFinalityProviderStakeHistories returns the delegations of the
+	// finality provider with only their staking value, state, staking and
+	// unbonding start timestamps and state history
+	FindFinalityProviderStakeHistories(
+		ctx context.Context, fpPkHex string,
+	) ([]*v1dbmodel.DelegationDocument, error)
+	// SumActiveDelegationsByFinalityProvider returns the number and the total
+	// value of the active delegations of the staker grouped by finality provider
+	SumActiveDelegationsByFinalityProvider(
+		ctx context.Context, stakerPk string,
+	) ([]v1dbmodel.FinalityProviderDelegationsSum, error)
+	// SumActiveDelegationsByStaker returns the number and the total value of
+	// the active delegations to the finality provider grouped by staker, the
+	// delegations of the excluded staker are left out
+	SumActiveDelegationsByStaker(
+		ctx context.Context, fpPkHex, excludedStakerPkHex string,
+	) ([]v1dbmodel.StakerDelegationsSum, error)
+	// CountUniqueStakersByFinalityProvider returns the number of distinct
+	// stakers with an active delegation to every finality provider having
+	// at least minStakers of them, sorted by that number descending.
+	CountUniqueStakersByFinalityProvider(
+		ctx context.Context, minStakers int64,
+	) ([]v1dbmodel.FinalityProviderStakersCount, error)
+	// CountFinalityProviderChurnSince returns, for every finality provider,
+	// the number of its delegations which became active and the number
+	// which left the active state at or after the given unix timestamp,
+	// sorted by finality provider public key.
+	CountFinalityProviderChurnSince(
+		ctx context.Context, since int64,
+	) ([]v1dbmodel.FinalityProviderChurn, error)
+	// FindStakersFirstSeenTimestamps returns, for every staker, the staking
+	// start timestamp of its earliest delegation
+	FindStakersFirstSeenTimestamps(ctx context.Context) ([]int64, error)
+	// FindFinalityProvidersWithoutActiveDelegations returns the finality
+	// providers stats of the providers without any active delegation
+	FindFinalityProvidersWithoutActiveDelegations(
+		ctx context.Context,
+	) ([]*v1dbmodel.FinalityProviderStatsDocument, error)
+	FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error)
+	// GetStakerStats fetches the staker stats by the staker's public key.
+	GetStakerStats(
+		ctx context.Context, stakerPkHex string,
+	) (*v1dbmodel.StakerStatsDocument, error)
+	GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error)
+	CheckDelegationExistByStakerPk(
+		ctx context.Context, address string, extraFilter *DelegationFilter,
+	) (bool, error)
+	// FindDelegationsByWithdrawalAddress returns the delegations withdrawn to
+	// the given address
+	FindDelegationsByWithdrawalAddress(
+		ctx context.Context, address string, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindDelegationsByStartHeight returns the delegations whose staking tx
+	// is included in the BTC block of the given height in a paginated way,
+	// sorted by staking tx hash
+	FindDelegationsByStartHeight(
+		ctx context.Context, height uint64, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindWithdrawnDelegationsByStakerPk returns the withdrawn delegations of
+	// the staker, the most recently withdrawn first
+	FindWithdrawnDelegationsByStakerPk(
+		ctx context.Context, stakerPk string, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// ScanDelegationsPaginated scans the delegation collection in a paginated way
+	// without applying any filters or sorting, ensuring that all existing items
+	// are eventually fetched.
+	ScanDelegationsPaginated(
+		ctx context.Context,
+		paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindDelegationsWithUnknownFinalityProvider returns the delegations
+	// whose finality provider is not part of the given registered ones,
+	// sorted by staking tx hash.
+	FindDelegationsWithUnknownFinalityProvider(
+		ctx context.Context, registeredFpPkHexes []string, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindActiveDelegationsBelowStakingValue returns the active delegations
+	// staking less than the given value, sorted by staking tx hash.
+	FindActiveDelegationsBelowStakingValue(
+		ctx context.Context, minStakingValue uint64, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindUnbondingRequestedDelegationsExpiringBefore returns the unbonding
+	// requested delegations whose staking timelock expires before the given
+	// height, sorted by staking tx hash.
+	FindUnbondingRequestedDelegationsExpiringBefore(
+		ctx context.Context, expiryHeight uint64, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindActiveDelegationsByStakerPkExpiringBefore returns the active
+	// delegations of the staker whose staking timelock expires before the
+	// given height.
+	FindActiveDelegationsByStakerPkExpiringBefore(
+		ctx context.Context, stakerPk string, expiryHeight uint64,
+	) ([]v1dbmodel.DelegationDocument, error)
+	// FindDelegationsWithMinStakingValue returns the delegations staking at
+	// least the given value, sorted by staking value then staking tx hash,
+	// both descending.
+	FindDelegationsWithMinStakingValue(
+		ctx context.Context, minStakingValue uint64, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindDelegationsOverdueForWithdrawal returns the active and unbonded
+	// delegations whose staking timelock, or unbonding timelock if unbonded
+	// early, expires before the given height, sorted by staking tx hash.
+	FindDelegationsOverdueForWithdrawal(
+		ctx context.Context, expiryHeight uint64, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindUnbondingDocumentsCreatedAfter returns the unbonding documents
+	// inserted after the given time.
+	FindUnbondingDocumentsCreatedAfter(
+		ctx context.Context, after time.Time,
+	) ([]v1dbmodel.UnbondingDocument, error)
+	// FindUnprocessedUnbondingDocumentsCreatedBefore returns the unbonding
+	// documents inserted before the given time and still in the initial state.
+	FindUnprocessedUnbondingDocumentsCreatedBefore(
+		ctx context.Context, before time.Time,
+	) ([]v1dbmodel.UnbondingDocument, error)
+	// SampleDelegations returns up to size randomly picked delegations, the
+	// same delegation may be picked more than once
+	SampleDelegations(ctx context.Context, size int64) ([]v1dbmodel.DelegationDocument, error)
+	// FindDelegationsByTxHashHexes returns the delegations matching the given
+	// staking tx hashes. It returns an InListTooLargeError if there are more
+	// hashes than the configured max in list size.
+	FindDelegationsByTxHashHexes(
+		ctx context.Context, stakingTxHashHexes []string,
+	) ([]v1dbmodel.DelegationDocument, error)
+	// FindUnbondingDocumentsByStakingTxHashHexes returns all the unbonding
+	// documents of the given delegations. It returns an InListTooLargeError if
+	// there are more hashes than the configured max in list size.
+	FindUnbondingDocumentsByStakingTxHashHexes(
+		ctx context.Context, stakingTxHashHexes []string,
+	) ([]v1dbmodel.UnbondingDocument, error)
+	FindIntegrityIssues(
+		ctx context.Context, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.IntegrityIssueDocument], error)
+	FindWatchlistByObserverPk(ctx context.Context, observerPkHex string) ([]v1dbmodel.WatchlistDocument, error)
+	CountWatchlistObserversByWatchedPk(ctx context.Context, watchedPkHex string) (int64, error)
+	// FindDelegationsByStakerPks returns the delegations of all the given
+	// stakers. It returns an InListTooLargeError if there are more stakers
+	// than the configured max in list size.
+	FindDelegationsByStakerPks(
+		ctx context.Context, stakerPks []string, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// FindFinalityProviderRegistrations returns the finality providers
+	// registered through the API, the oldest registration first.
+	FindFinalityProviderRegistrations(ctx context.Context) ([]v1dbmodel.FinalityProviderRegistrationDocument, error)
+}
+
+//go:generate mockery --name=V1DBClient --output=../../../../tests/mocks --outpkg=mocks --filename=mock_v1_db_client.go
+type V1DBClient interface {
+	dbclient.DBClient
+	V1DBReader
+	SaveActiveStakingDelegation(
+		ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
+		stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
+		startTimestamp int64, isOverflow bool, stakingValueUsd *float64,
+	) error
 	SaveUnbondingTx(
 		ctx context.Context, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex string,
 	) error
-	FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error)
 	TransitionToTransitionedState(ctx context.Context, stakingTxHashHex string) error
 	SaveTimeLockExpireCheck(ctx context.Context, stakingTxHashHex string, expireHeight uint64, txType string) error
 	TransitionToUnbondedState(
@@ -39,7 +243,20 @@ type V1DBClient interface {
 	TransitionToUnbondingState(
 		ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64,
 	) error
-	TransitionToWithdrawnState(ctx context.Context, txHashHex string) error
+	// TransitionToWithdrawnState transitions the delegation to withdrawn and
+	// adds its value to the withdrawn tvl of the path, in a single transaction
+	TransitionToWithdrawnState(
+		ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction,
+		withdrawalPath v1dbmodel.WithdrawalPath, amount uint64,
+	) error
+	// BackfillWithdrawalPath records the withdrawal path of a withdrawn
+	// delegation and adds its value to the withdrawn tvl of the path
+	BackfillWithdrawalPath(
+		ctx context.Context, txHashHex string, withdrawalPath v1dbmodel.WithdrawalPath, amount uint64,
+	) error
+	// LowercaseStakerPks lowercases the staker public keys stored in upper
+	// or mixed case, and returns the number of delegations updated
+	LowercaseStakerPks(ctx context.Context) (int64, error)
 	GetOrCreateStatsLock(
 		ctx context.Context, stakingTxHashHex string, state string,
 	) (*v1dbmodel.StatsLockDocument, error)
@@ -49,42 +266,45 @@ type V1DBClient interface {
 	IncrementOverallStats(
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
-	GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error)
 	IncrementFinalityProviderStats(
 		ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
 	) error
 	SubtractFinalityProviderStats(
 		ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
 	) error
-	FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error)
-	FindFinalityProviderStatsByFinalityProviderPkHex(
-		ctx context.Context, finalityProviderPkHex []string,
-	) ([]*v1dbmodel.FinalityProviderStatsDocument, error)
 	IncrementStakerStats(
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
 	SubtractStakerStats(
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
-	FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error)
-	// GetStakerStats fetches the staker stats by the staker's public key.
-	GetStakerStats(
+	// RebuildStakerStats re-derives the stats of the staker from its
+	// delegations and their stats locks, and replaces the stored ones within
+	// a transaction. It returns the stats before, nil if there were none, and
+	// after the rebuild.
+	RebuildStakerStats(
 		ctx context.Context, stakerPkHex string,
-	) (*v1dbmodel.StakerStatsDocument, error)
+	) (*v1dbmodel.StakerStatsDocument, *v1dbmodel.StakerStatsDocument, error)
 	UpsertLatestBtcInfo(
 		ctx context.Context, height uint64, confirmedTvl uint64, unconfirmedTvl uint64,
 	) error
-	GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error)
-	CheckDelegationExistByStakerPk(
-		ctx context.Context, address string, extraFilter *DelegationFilter,
-	) (bool, error)
-	// ScanDelegationsPaginated scans the delegation collection in a paginated way
-	// without applying any filters or sorting, ensuring that all existing items
-	// are eventually fetched.
-	ScanDelegationsPaginated(
-		ctx context.Context,
-		paginationToken string,
-	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	QuarantineUnbondingDocument(ctx context.Context, unbondingTxHashHex string) error
+	SaveIntegrityIssue(ctx context.Context, issue *v1dbmodel.IntegrityIssueDocument) error
+	// SaveMisbehaviorReport stores the misbehavior report. It returns a
+	// DuplicateKeyError if the same report is already stored.
+	SaveMisbehaviorReport(ctx context.Context, report *v1dbmodel.MisbehaviorReportDocument) error
+	// SaveWatchlistEntry stores the staker watched by the observer. It returns
+	// a DuplicateKeyError if the staker is already watched.
+	SaveWatchlistEntry(ctx context.Context, entry *v1dbmodel.WatchlistDocument) error
+	// DeleteWatchlistEntry removes the staker from the watchlist of the
+	// observer. It returns a NotFoundError if the staker is not watched.
+	DeleteWatchlistEntry(ctx context.Context, observerPkHex, watchedPkHex string) error
+	// SaveFinalityProviderRegistrations stores the registrations in a single
+	// bulk write. It returns the indexes of the registrations whose finality
+	// provider is already registered, the others are stored.
+	SaveFinalityProviderRegistrations(
+		ctx context.Context, registrations []*v1dbmodel.FinalityProviderRegistrationDocument,
+	) ([]int, error)
 }
 
 type DelegationFilter struct {
@@ -0,0 +1,29 @@
+package v1dbclient
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SaveMisbehaviorReport stores the misbehavior report.
+// It returns a DuplicateKeyError if the same report is already stored.
+func (v1dbclient *V1Database) SaveMisbehaviorReport(
+	ctx context.Context, report *v1dbmodel.MisbehaviorReportDocument,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1MisbehaviorReportsCollection)
+	_, err := client.InsertOne(ctx, report)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return &db.DuplicateKeyError{
+				Key:     report.Id,
+				Message: "misbehavior report already exists",
+			}
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+//go:build integration
+
+package v1dbclient
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDelegationsByStakerPkLegacyForms(t *testing.T) {
+	ctx := context.Background()
+	v1DB := newReplicaSetDatabase(t)
+	const canonicalPkHex = "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	delegation := func(txHash, stakerPkHex string, startHeight uint64) v1dbmodel.DelegationDocument {
+		return v1dbmodel.DelegationDocument{
+			StakingTxHashHex: txHash,
+			StakerPkHex:      stakerPkHex,
+			State:            types.Active,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: startHeight},
+		}
+	}
+	documents := []any{
+		delegation("a-canonical", canonicalPkHex, 6),
+		delegation("b-compressed", "02"+canonicalPkHex, 5),
+		delegation("c-upper-compressed", strings.ToUpper("03"+canonicalPkHex), 4),
+		// stored in mixed case, neither all lower nor all upper
+		delegation("d-mixed-case", canonicalPkHex[:32]+strings.ToUpper(canonicalPkHex[32:]), 3),
+		delegation("e-other-staker", canonicalPkHex[:62]+"00", 2),
+		delegation("f-invalid-prefix", "04"+canonicalPkHex, 1),
+	}
+	client := v1DB.Client.Database(v1DB.DbName).Collection(dbmodel.V1DelegationCollection)
+	_, err := client.InsertMany(ctx, documents)
+	require.NoError(t, err)
+
+	findTxHashes := func() []string {
+		result, err := v1DB.FindDelegationsByStakerPk(ctx, canonicalPkHex, nil, "")
+		require.NoError(t, err)
+		txHashes := make([]string, 0, len(result.Data))
+		for _, d := range result.Data {
+			txHashes = append(txHashes, d.StakingTxHashHex)
+		}
+		return txHashes
+	}
+	// the keys are matched exactly, in lowercase
+	assert.Equal(t, []string{"a-canonical", "b-compressed"}, findTxHashes())
+
+	// the keys stored in upper or mixed case are found once lowercased
+	updated, err := v1DB.LowercaseStakerPks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+	assert.Equal(t, []string{"a-canonical", "b-compressed", "c-upper-compressed", "d-mixed-case"}, findTxHashes())
+
+	// the backfill can be run again
+	updated, err = v1DB.LowercaseStakerPks(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
@@ -46,6 +46,26 @@ func (db *V1Database) GetOrCreateStatsLock(
 	return &result, nil
 }
 
+// GetStatsLock fetches the lock status for each stats type for the given staking tx hash
+// without creating the document. If the document does not exist, a lock with all
+// stats types marked as unprocessed is returned.
+func (db *V1Database) GetStatsLock(
+	ctx context.Context, stakingTxHashHex string, txType string,
+) (*v1dbmodel.StatsLockDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(dbmodel.V1StatsLockCollection)
+	id := constructStatsLockId(stakingTxHashHex, txType)
+
+	var result v1dbmodel.StatsLockDocument
+	err := client.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return v1dbmodel.NewStatsLockDocument(id, false, false, false), nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
 // IncrementOverallStats increments the overall stats for the given staking tx hash.
 // This method is idempotent, only the first call will be processed. Otherwise it will return a notFoundError for duplicates
 // Refer to the README.md in this directory for more information on the sharding logic
@@ -55,13 +75,6 @@ func (v1dbclient *V1Database) IncrementOverallStats(
 	overallStatsClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1OverallStatsCollection)
 	stakerStatsClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1StakerStatsCollection)
 
-	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
-	if sessionErr != nil {
-		return sessionErr
-	}
-	defer session.EndSession(ctx)
-
 	upsertUpdate := bson.M{
 		"$inc": bson.M{
 			"active_tvl":         int64(amount),
@@ -71,10 +84,10 @@ func (v1dbclient *V1Database) IncrementOverallStats(
 		},
 	}
 	// Define the work to be done in the transaction
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	transactionWork := func(sessCtx context.Context) error {
 		err := v1dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, types.Active.ToString(), "overall_stats")
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		// The order of the overall stats and staker stats update is important.
@@ -84,27 +97,27 @@ func (v1dbclient *V1Database) IncrementOverallStats(
 		stakerStatsFilter := bson.M{"_id": stakerPkHex}
 		stakerErr := stakerStatsClient.FindOne(ctx, stakerStatsFilter).Decode(&stakerStats)
 		if stakerErr != nil {
-			return nil, stakerErr
+			return stakerErr
 		}
 		if stakerStats.TotalDelegations == 1 {
 			upsertUpdate["$inc"].(bson.M)["total_stakers"] = 1
 		}
 		shardId, err := v1dbclient.generateOverallStatsId()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		upsertFilter := bson.M{"_id": shardId}
 
 		_, err = overallStatsClient.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return nil, nil
+		return nil
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	txErr := v1dbclient.WithTransaction(ctx, transactionWork)
 	if txErr != nil {
 		return txErr
 	}
@@ -126,35 +139,28 @@ func (v1dbclient *V1Database) SubtractOverallStats(
 	}
 	overallStatsClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1OverallStatsCollection)
 
-	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
-	if sessionErr != nil {
-		return sessionErr
-	}
-	defer session.EndSession(ctx)
-
 	// Define the work to be done in the transaction
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	transactionWork := func(sessCtx context.Context) error {
 		err := v1dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, types.Unbonded.ToString(), "overall_stats")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		shardId, err := v1dbclient.generateOverallStatsId()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		upsertFilter := bson.M{"_id": shardId}
 
 		_, err = overallStatsClient.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return nil, nil
+		return nil
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	txErr := v1dbclient.WithTransaction(ctx, transactionWork)
 	if txErr != nil {
 		return txErr
 	}
@@ -192,6 +198,9 @@ func (v1dbclient *V1Database) GetOverallStats(ctx context.Context) (*v1dbmodel.O
 		result.ActiveDelegations += stats.ActiveDelegations
 		result.TotalDelegations += stats.TotalDelegations
 		result.TotalStakers += stats.TotalStakers
+		result.WithdrawnTvlEarlyUnbonding += stats.WithdrawnTvlEarlyUnbonding
+		result.WithdrawnTvlNaturalExpiry += stats.WithdrawnTvlNaturalExpiry
+		result.WithdrawnTvlUnknown += stats.WithdrawnTvlUnknown
 	}
 
 	return &result, nil
@@ -311,33 +320,65 @@ func (v1dbclient *V1Database) FindFinalityProviderStatsByFinalityProviderPkHex(
 	return finalityProviders, nil
 }
 
-func (v1dbclient *V1Database) updateFinalityProviderStats(ctx context.Context, state, stakingTxHashHex, fpPkHex string, upsertUpdate primitive.M) error {
+// FindFinalityProvidersWithoutActiveDelegations returns the finality providers
+// stats of the providers that have no delegation in the active state
+func (v1dbclient *V1Database) FindFinalityProvidersWithoutActiveDelegations(
+	ctx context.Context,
+) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1FinalityProviderStatsCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from": dbmodel.V1DelegationCollection,
+			"let":  bson.M{"fpPkHex": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"state": types.Active,
+					"$expr": bson.M{"$eq": bson.A{"$finality_provider_pk_hex", "$$fpPkHex"}},
+				}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"_id": 1}},
+			},
+			"as": "active_delegations_lookup",
+		}}},
+		{{Key: "$match", Value: bson.M{"active_delegations_lookup": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"active_delegations_lookup": 0}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
 
-	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
-	if sessionErr != nil {
-		return sessionErr
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
 	}
-	defer session.EndSession(ctx)
+	defer cursor.Close(ctx)
 
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	var finalityProviders []*v1dbmodel.FinalityProviderStatsDocument
+	if err = cursor.All(ctx, &finalityProviders); err != nil {
+		return nil, err
+	}
+
+	return finalityProviders, nil
+}
+
+func (v1dbclient *V1Database) updateFinalityProviderStats(ctx context.Context, state, stakingTxHashHex, fpPkHex string, upsertUpdate primitive.M) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1FinalityProviderStatsCollection)
+
+	transactionWork := func(sessCtx context.Context) error {
 		err := v1dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, state, "finality_provider_stats")
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		upsertFilter := bson.M{"_id": fpPkHex}
 
 		_, err = client.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return nil, nil
+		return nil
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	txErr := v1dbclient.WithTransaction(ctx, transactionWork)
 	if txErr != nil {
 		return txErr
 	}
@@ -378,53 +419,34 @@ func (v1dbclient *V1Database) SubtractStakerStats(
 func (v1dbclient *V1Database) updateStakerStats(ctx context.Context, state, stakingTxHashHex, stakerPkHex string, upsertUpdate primitive.M) error {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1StakerStatsCollection)
 
-	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
-	if sessionErr != nil {
-		return sessionErr
-	}
-	defer session.EndSession(ctx)
-
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	transactionWork := func(sessCtx context.Context) error {
 		err := v1dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, state, "staker_stats")
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		upsertFilter := bson.M{"_id": stakerPkHex}
 
 		_, err = client.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return nil, nil
+		return nil
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	txErr := v1dbclient.WithTransaction(ctx, transactionWork)
 	return txErr
 }
 
 func (v1dbclient *V1Database) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error) {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1StakerStatsCollection)
 
-	opts := options.Find().SetSort(bson.D{{Key: "active_tvl", Value: -1}})
-	var filter bson.M
-	// Decode the pagination token first if it exist
-	if paginationToken != "" {
-		decodedToken, err := dbmodel.DecodePaginationToken[v1dbmodel.StakerStatsByStakerPagination](paginationToken)
-		if err != nil {
-			return nil, &db.InvalidPaginationTokenError{
-				Message: "Invalid pagination token",
-			}
-		}
-		filter = bson.M{
-			"$or": []bson.M{
-				{"active_tvl": bson.M{"$lt": decodedToken.ActiveTvl}},
-				{"active_tvl": decodedToken.ActiveTvl, "_id": bson.M{"$lt": decodedToken.StakerPkHex}},
-			},
-		}
+	filter, err := topStakersByTvlFilter(paginationToken)
+	if err != nil {
+		return nil, err
 	}
+	opts := options.Find().SetSort(topStakersByTvlSort)
 
 	return db.FindWithPagination(
 		ctx, client, filter, opts, v1dbclient.Cfg.MaxPaginationLimit,
@@ -432,6 +454,30 @@ func (v1dbclient *V1Database) FindTopStakersByTvl(ctx context.Context, paginatio
 	)
 }
 
+// topStakersByTvlSort ranks the stakers by active tvl, the staker pk breaks
+// the ties so that pages neither skip nor repeat stakers with the same tvl
+var topStakersByTvlSort = bson.D{{Key: "active_tvl", Value: -1}, {Key: "_id", Value: -1}}
+
+// topStakersByTvlFilter selects the stakers ranked after the last staker of
+// the previous page
+func topStakersByTvlFilter(paginationToken string) (bson.M, error) {
+	if paginationToken == "" {
+		return nil, nil
+	}
+	decodedToken, err := dbmodel.DecodePaginationToken[v1dbmodel.StakerStatsByStakerPagination](paginationToken)
+	if err != nil {
+		return nil, &db.InvalidPaginationTokenError{
+			Message: "Invalid pagination token",
+		}
+	}
+	return bson.M{
+		"$or": []bson.M{
+			{"active_tvl": bson.M{"$lt": decodedToken.ActiveTvl}},
+			{"active_tvl": decodedToken.ActiveTvl, "_id": bson.M{"$lt": decodedToken.StakerPkHex}},
+		},
+	}, nil
+}
+
 func (v1dbclient *V1Database) GetStakerStats(
 	ctx context.Context, stakerPkHex string,
 ) (*v1dbmodel.StakerStatsDocument, error) {
@@ -451,3 +497,79 @@ func (v1dbclient *V1Database) GetStakerStats(
 	}
 	return &result, nil
 }
+
+// RebuildStakerStats re-derives the stats of the staker from its delegations
+// and their stats locks, and replaces the stored ones. Everything is read and
+// written within a transaction so that a concurrent stats update of the
+// staker makes the rebuild retry instead of being lost.
+func (v1dbclient *V1Database) RebuildStakerStats(
+	ctx context.Context, stakerPkHex string,
+) (*v1dbmodel.StakerStatsDocument, *v1dbmodel.StakerStatsDocument, error) {
+	database := v1dbclient.Client.Database(v1dbclient.DbName)
+	delegationClient := database.Collection(dbmodel.V1DelegationCollection)
+	statsLockClient := database.Collection(dbmodel.V1StatsLockCollection)
+	stakerStatsClient := database.Collection(dbmodel.V1StakerStatsCollection)
+
+	session, sessionErr := v1dbclient.Client.StartSession()
+	if sessionErr != nil {
+		return nil, nil, sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	var before, after *v1dbmodel.StakerStatsDocument
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		cursor, err := delegationClient.Find(sessCtx, bson.M{"staker_pk_hex": stakerPkHex})
+		if err != nil {
+			return nil, err
+		}
+		var delegations []v1dbmodel.DelegationDocument
+		if err = cursor.All(sessCtx, &delegations); err != nil {
+			return nil, err
+		}
+
+		lockIds := make([]string, 0, 2*len(delegations))
+		for _, delegation := range delegations {
+			lockIds = append(lockIds,
+				constructStatsLockId(delegation.StakingTxHashHex, types.Active.ToString()),
+				constructStatsLockId(delegation.StakingTxHashHex, types.Unbonded.ToString()),
+			)
+		}
+		cursor, err = statsLockClient.Find(sessCtx, bson.M{"_id": bson.M{"$in": lockIds}})
+		if err != nil {
+			return nil, err
+		}
+		var statsLocks []v1dbmodel.StatsLockDocument
+		if err = cursor.All(sessCtx, &statsLocks); err != nil {
+			return nil, err
+		}
+		processed := make(map[string]bool, len(statsLocks))
+		for _, statsLock := range statsLocks {
+			processed[statsLock.Id] = statsLock.StakerStats
+		}
+
+		before = nil
+		var current v1dbmodel.StakerStatsDocument
+		err = stakerStatsClient.FindOne(sessCtx, bson.M{"_id": stakerPkHex}).Decode(&current)
+		if err == nil {
+			before = &current
+		} else if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		after = v1dbmodel.DeriveStakerStats(
+			stakerPkHex, delegations,
+			func(stakingTxHashHex string, state types.DelegationState) bool {
+				return processed[constructStatsLockId(stakingTxHashHex, state.ToString())]
+			},
+		)
+		_, err = stakerStatsClient.ReplaceOne(
+			sessCtx, bson.M{"_id": stakerPkHex}, after, options.Replace().SetUpsert(true),
+		)
+		return nil, err
+	}
+
+	if _, txErr := session.WithTransaction(ctx, transactionWork); txErr != nil {
+		return nil, nil, txErr
+	}
+	return before, after, nil
+}
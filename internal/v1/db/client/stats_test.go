@@ -0,0 +1,43 @@
+package v1dbclient
+
+import (
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTopStakersByTvlPagination(t *testing.T) {
+	// the stakers are ranked by active tvl then by staker pk, both descending
+	assert.Equal(t, bson.D{{Key: "active_tvl", Value: -1}, {Key: "_id", Value: -1}}, topStakersByTvlSort)
+
+	t.Run("first page", func(t *testing.T) {
+		filter, err := topStakersByTvlFilter("")
+		require.NoError(t, err)
+		assert.Nil(t, filter)
+	})
+
+	t.Run("next page starts after the last staker of the previous one", func(t *testing.T) {
+		token, err := v1dbmodel.BuildStakerStatsByStakerPaginationToken(
+			&v1dbmodel.StakerStatsDocument{StakerPkHex: "staker-b", ActiveTvl: 100},
+		)
+		require.NoError(t, err)
+
+		filter, err := topStakersByTvlFilter(token)
+		require.NoError(t, err)
+		// stakers with the same tvl are continued by staker pk, in the
+		// order of the secondary sort key
+		assert.Equal(t, bson.M{"$or": []bson.M{
+			{"active_tvl": bson.M{"$lt": int64(100)}},
+			{"active_tvl": int64(100), "_id": bson.M{"$lt": "staker-b"}},
+		}}, filter)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := topStakersByTvlFilter("not a token")
+		assert.True(t, db.IsInvalidPaginationTokenError(err))
+	})
+}
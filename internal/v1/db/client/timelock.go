@@ -24,5 +24,5 @@ func (v1dbclient *V1Database) SaveTimeLockExpireCheck(
 func (v1dbclient *V1Database) TransitionToUnbondedState(
 	ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState,
 ) error {
-	return v1dbclient.transitionState(ctx, stakingTxHashHex, types.Unbonded.ToString(), eligiblePreviousState, nil)
+	return v1dbclient.transitionState(ctx, stakingTxHashHex, types.Unbonded.ToString(), eligiblePreviousState, "", nil)
 }
@@ -0,0 +1,96 @@
+//go:build integration
+
+package v1dbclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newReplicaSetDatabase connects to the replica set at MONGO_REPLICA_SET_ADDRESS,
+// e.g. the one started by bin/init-mongo.sh, and uses a database dropped once
+// the test is done
+func newReplicaSetDatabase(t *testing.T) *V1Database {
+	address := os.Getenv("MONGO_REPLICA_SET_ADDRESS")
+	if address == "" {
+		t.Skip("MONGO_REPLICA_SET_ADDRESS is not set")
+	}
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(address))
+	require.NoError(t, err)
+
+	cfg := &config.DbConfig{DbName: fmt.Sprintf("transaction-test-%d", time.Now().UnixNano())}
+	database := client.Database(cfg.DbName)
+	t.Cleanup(func() {
+		_ = database.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+	for _, collection := range []string{
+		dbmodel.V1DelegationCollection, dbmodel.V1StatsLockCollection, dbmodel.V1StakerStatsCollection,
+	} {
+		require.NoError(t, database.CreateCollection(ctx, collection))
+	}
+
+	v1DB, err := New(ctx, client, cfg)
+	require.NoError(t, err)
+	return v1DB
+}
+
+func TestWithTransaction(t *testing.T) {
+	ctx := context.Background()
+	v1DB := newReplicaSetDatabase(t)
+
+	// saves a delegation then counts it in the staker stats, which joins the
+	// transaction, before failing if asked to
+	write := func(stakingTxHashHex string, fail error) error {
+		return v1DB.WithTransaction(ctx, func(ctx context.Context) error {
+			err := v1DB.SaveActiveStakingDelegation(
+				ctx, stakingTxHashHex, "staker-"+stakingTxHashHex, "fp", "tx", 1000, 1, 10, 0, 1700000000, false, nil,
+			)
+			if err != nil {
+				return err
+			}
+			if _, err = v1DB.GetOrCreateStatsLock(ctx, stakingTxHashHex, types.Active.ToString()); err != nil {
+				return err
+			}
+			if err = v1DB.IncrementStakerStats(ctx, stakingTxHashHex, "staker-"+stakingTxHashHex, 1000); err != nil {
+				return err
+			}
+			return fail
+		})
+	}
+
+	t.Run("a failure rolls back every write", func(t *testing.T) {
+		injected := errors.New("injected failure")
+		require.ErrorIs(t, write("rolled-back", injected), injected)
+
+		_, err := v1DB.FindDelegationByTxHashHex(ctx, "rolled-back")
+		assert.True(t, db.IsNotFoundError(err))
+		_, err = v1DB.GetStakerStats(ctx, "staker-rolled-back")
+		assert.True(t, db.IsNotFoundError(err))
+	})
+
+	t.Run("every write is committed", func(t *testing.T) {
+		require.NoError(t, write("committed", nil))
+
+		delegation, err := v1DB.FindDelegationByTxHashHex(ctx, "committed")
+		require.NoError(t, err)
+		assert.Equal(t, types.Active, delegation.State)
+		stats, err := v1DB.GetStakerStats(ctx, "staker-committed")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), stats.ActiveTvl)
+	})
+}
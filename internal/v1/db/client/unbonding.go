@@ -3,6 +3,7 @@ package v1dbclient
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
@@ -31,7 +32,7 @@ func (v1dbclient *V1Database) SaveUnbondingTx(
 		// Find the existing delegation document first, it will be used later in the transaction
 		delegationFilter := bson.M{
 			"_id":   stakingTxHashHex,
-			"state": types.Active,
+			"state": bson.M{"$in": utils.QualifiedStatesToUnbondingRequest()},
 		}
 		var delegationDocument v1dbmodel.DelegationDocument
 		err = delegationClient.FindOne(sessCtx, delegationFilter).Decode(&delegationDocument)
@@ -45,7 +46,9 @@ func (v1dbclient *V1Database) SaveUnbondingTx(
 			return nil, err
 		}
 		// Update the state to UnbondingRequested
-		delegationUpdate := bson.M{"$set": bson.M{"state": types.UnbondingRequested}}
+		delegationUpdate := stateTransitionUpdate(
+			types.UnbondingRequested.ToString(), txHashHex, time.Now().Unix(), nil,
+		)
 		result, err := delegationClient.UpdateOne(sessCtx, delegationFilter, delegationUpdate)
 		if err != nil {
 			return nil, err
@@ -116,7 +119,7 @@ func (v1dbclient *V1Database) TransitionToUnbondingState(
 
 	err := v1dbclient.transitionState(
 		ctx, txHashHex, types.Unbonding.ToString(),
-		utils.QualifiedStatesToUnbonding(), unbondingTxMap,
+		utils.QualifiedStatesToUnbonding(), "", unbondingTxMap,
 	)
 	if err != nil {
 		return err
@@ -0,0 +1,83 @@
+package v1dbclient
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveWatchlistEntry stores the staker watched by the observer.
+// It returns a DuplicateKeyError if the staker is already watched.
+func (v1dbclient *V1Database) SaveWatchlistEntry(
+	ctx context.Context, entry *v1dbmodel.WatchlistDocument,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1WatchlistsCollection)
+	_, err := client.InsertOne(ctx, entry)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return &db.DuplicateKeyError{
+				Key:     entry.Id,
+				Message: "staker already watched",
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteWatchlistEntry removes the staker from the watchlist of the observer.
+// It returns a NotFoundError if the staker is not watched.
+func (v1dbclient *V1Database) DeleteWatchlistEntry(
+	ctx context.Context, observerPkHex, watchedPkHex string,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1WatchlistsCollection)
+	id := v1dbmodel.WatchlistDocumentId(observerPkHex, watchedPkHex)
+	result, err := client.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return &db.NotFoundError{
+			Key:     id,
+			Message: "staker not watched",
+		}
+	}
+	return nil
+}
+
+// FindWatchlistByObserverPk returns the stakers watched by the observer in the
+// order they were added
+func (v1dbclient *V1Database) FindWatchlistByObserverPk(
+	ctx context.Context, observerPkHex string,
+) ([]v1dbmodel.WatchlistDocument, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1WatchlistsCollection)
+	options := options.Find().SetSort(bson.D{
+		{Key: "added_at", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+	cursor, err := client.Find(ctx, bson.M{"observer_pk_hex": observerPkHex}, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []v1dbmodel.WatchlistDocument{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// CountWatchlistObserversByWatchedPk returns the number of observers watching
+// the staker
+func (v1dbclient *V1Database) CountWatchlistObserversByWatchedPk(
+	ctx context.Context, watchedPkHex string,
+) (int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1WatchlistsCollection)
+	return client.CountDocuments(ctx, bson.M{"watched_pk_hex": watchedPkHex})
+}
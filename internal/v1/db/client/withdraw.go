@@ -3,17 +3,107 @@ package v1dbclient
 import (
 	"context"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (v1dbclient *V1Database) TransitionToWithdrawnState(ctx context.Context, txHashHex string) error {
-	err := v1dbclient.transitionState(
-		ctx, txHashHex, types.Withdrawn.ToString(),
-		utils.QualifiedStatesToWithdraw(), nil,
-	)
+// TransitionToWithdrawnState changes the state to `withdrawn`, saves the
+// withdrawal tx if it is known and adds the staking value to the withdrawn
+// tvl of the withdrawal path
+func (v1dbclient *V1Database) TransitionToWithdrawnState(
+	ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction,
+	withdrawalPath v1dbmodel.WithdrawalPath, amount uint64,
+) error {
+	additionalUpdates := map[string]interface{}{"withdrawal_path": withdrawalPath}
+	var withdrawalTxHashHex string
+	if withdrawalTx != nil {
+		additionalUpdates["withdrawal_tx"] = withdrawalTx
+		withdrawalTxHashHex = withdrawalTx.TxHashHex
+	}
+	return v1dbclient.WithTransaction(ctx, func(ctx context.Context) error {
+		err := v1dbclient.transitionState(
+			ctx, txHashHex, types.Withdrawn.ToString(),
+			utils.QualifiedStatesToWithdraw(), withdrawalTxHashHex, additionalUpdates,
+		)
+		if err != nil {
+			return err
+		}
+		return v1dbclient.incrementWithdrawnTvl(ctx, withdrawalPath, amount)
+	})
+}
+
+// IterateWithdrawnDelegationsWithoutPath calls fn for every withdrawn
+// delegation whose withdrawal path is not recorded, the iteration stops at the
+// first error returned by fn
+func (v1dbclient *V1Database) IterateWithdrawnDelegationsWithoutPath(
+	ctx context.Context, fn func(*v1dbmodel.DelegationDocument) error,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"state": types.Withdrawn, "withdrawal_path": bson.M{"$exists": false}}
+
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var delegation v1dbmodel.DelegationDocument
+		if err := cursor.Decode(&delegation); err != nil {
+			return err
+		}
+		if err := fn(&delegation); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// BackfillWithdrawalPath records the withdrawal path of a delegation withdrawn
+// before it was recorded, and adds its staking value to the withdrawn tvl of
+// the path. It returns a NotFoundError if the path is already recorded.
+func (v1dbclient *V1Database) BackfillWithdrawalPath(
+	ctx context.Context, txHashHex string, withdrawalPath v1dbmodel.WithdrawalPath, amount uint64,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{
+		"_id":             txHashHex,
+		"state":           types.Withdrawn,
+		"withdrawal_path": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"withdrawal_path": withdrawalPath}}
+
+	return v1dbclient.WithTransaction(ctx, func(ctx context.Context) error {
+		result, err := client.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return &db.NotFoundError{
+				Key:     txHashHex,
+				Message: "withdrawn delegation not found or its withdrawal path is already recorded",
+			}
+		}
+		return v1dbclient.incrementWithdrawnTvl(ctx, withdrawalPath, amount)
+	})
+}
+
+// incrementWithdrawnTvl adds the amount to the withdrawn tvl of the path in a
+// random shard of the overall stats
+func (v1dbclient *V1Database) incrementWithdrawnTvl(
+	ctx context.Context, withdrawalPath v1dbmodel.WithdrawalPath, amount uint64,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1OverallStatsCollection)
+	shardId, err := v1dbclient.generateOverallStatsId()
 	if err != nil {
 		return err
 	}
-	return nil
+	update := bson.M{"$inc": bson.M{"withdrawn_tvl_" + string(withdrawalPath): int64(amount)}}
+	_, err = client.UpdateOne(ctx, bson.M{"_id": shardId}, update, options.Update().SetUpsert(true))
+	return err
 }
@@ -1,6 +1,8 @@
 package v1dbmodel
 
 import (
+	"slices"
+
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
@@ -22,17 +24,160 @@ type DelegationDocument struct {
 	StakingTx             *TimelockTransaction  `bson:"staking_tx"` // Always exist
 	UnbondingTx           *TimelockTransaction  `bson:"unbonding_tx,omitempty"`
 	IsOverflow            bool                  `bson:"is_overflow"`
+	// StakingValueUsd is the staking value in USD at the time the delegation
+	// was inserted, nil if no price oracle was enabled
+	StakingValueUsd *float64               `bson:"staking_value_usd,omitempty"`
+	WithdrawalTx    *WithdrawalTransaction `bson:"withdrawal_tx,omitempty"`
+	// WithdrawalPath is only set once the delegation is withdrawn
+	WithdrawalPath WithdrawalPath `bson:"withdrawal_path,omitempty"`
+	// StateHistory lists the state transitions of the delegation, oldest
+	// first, capped to the last MaxStateHistoryLength ones
+	StateHistory []StateTransition `bson:"state_history,omitempty"`
+}
+
+// MaxStateHistoryLength caps the state transitions kept on a delegation,
+// the oldest ones are dropped first
+const MaxStateHistoryLength = 20
+
+// StateTransition records a state change of a delegation. FromState is empty
+// for the transition recorded when the delegation is inserted.
+type StateTransition struct {
+	FromState types.DelegationState `bson:"from_state,omitempty"`
+	ToState   types.DelegationState `bson:"to_state"`
+	Timestamp int64                 `bson:"timestamp"`
+	// TxHashHex is the hash of the tx that triggered the transition, if any
+	TxHashHex string `bson:"tx_hash_hex,omitempty"`
+}
+
+// WithdrawalTransaction is the tx spending the staking or the unbonding output
+// back to the staker once its timelock expired
+type WithdrawalTransaction struct {
+	TxHashHex string `bson:"tx_hash_hex"`
+	Height    uint64 `bson:"height"`
+	// Address receiving the withdrawn funds, empty if unknown
+	Address string `bson:"address,omitempty"`
+}
+
+// WithdrawalPath tells how a withdrawn delegation got unbonded
+type WithdrawalPath string
+
+const (
+	// WithdrawalPathEarlyUnbonding is for delegations unbonded on request of
+	// the staker before their staking timelock expired
+	WithdrawalPathEarlyUnbonding WithdrawalPath = "early_unbonding"
+	// WithdrawalPathNaturalExpiry is for delegations unbonded once their
+	// staking timelock expired
+	WithdrawalPathNaturalExpiry WithdrawalPath = "natural_expiry"
+	// WithdrawalPathUnknown is for delegations withdrawn before the path was
+	// recorded and whose history does not tell it
+	WithdrawalPathUnknown WithdrawalPath = "unknown"
+)
+
+// DeriveWithdrawalPath tells how the delegation got unbonded. Only an early
+// unbonding records an unbonding tx, a delegation unbonded without one reached
+// the expiry of its staking timelock. Otherwise the path is looked up in the
+// state history, which may no longer hold the transition to unbonded.
+func (d *DelegationDocument) DeriveWithdrawalPath() WithdrawalPath {
+	if d.UnbondingTx != nil {
+		return WithdrawalPathEarlyUnbonding
+	}
+	if d.State == types.Unbonded {
+		return WithdrawalPathNaturalExpiry
+	}
+	for i := len(d.StateHistory) - 1; i >= 0; i-- {
+		transition := d.StateHistory[i]
+		if transition.ToState != types.Unbonded {
+			continue
+		}
+		if transition.FromState == types.Unbonding {
+			return WithdrawalPathEarlyUnbonding
+		}
+		return WithdrawalPathNaturalExpiry
+	}
+	return WithdrawalPathUnknown
+}
+
+// FinalityProviderDelegationsSum aggregates the delegations to a finality provider
+type FinalityProviderDelegationsSum struct {
+	FinalityProviderPkHex string `bson:"_id"`
+	TotalValue            uint64 `bson:"total_value"`
+	DelegationCount       int64  `bson:"delegation_count"`
+}
+
+// FinalityProviderStakersCount counts the distinct stakers with an active
+// delegation to a finality provider
+type FinalityProviderStakersCount struct {
+	FinalityProviderPkHex string `bson:"_id"`
+	UniqueStakers         int64  `bson:"unique_stakers"`
+}
+
+// FinalityProviderChurn counts the delegations to a finality provider which
+// became active, and the ones which left the active state, over a period
+type FinalityProviderChurn struct {
+	FinalityProviderPkHex string `bson:"_id"`
+	NewDelegations        int64  `bson:"new_delegations"`
+	ExpiredDelegations    int64  `bson:"expired_delegations"`
+}
+
+// StakerDelegationsSum aggregates the delegations of a staker
+type StakerDelegationsSum struct {
+	StakerPkHex     string `bson:"_id"`
+	TotalValue      uint64 `bson:"total_value"`
+	DelegationCount int64  `bson:"delegation_count"`
 }
 
 type DelegationByStakerPagination struct {
 	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
 	StakingStartHeight uint64 `json:"staking_start_height"`
+	// States is the state filter the token was issued for
+	States []types.DelegationState `json:"states,omitempty"`
+}
+
+// MatchesStates checks the token was issued for the same state filter,
+// regardless of the order of the states
+func (p *DelegationByStakerPagination) MatchesStates(states []types.DelegationState) bool {
+	if len(p.States) != len(states) {
+		return false
+	}
+	for _, state := range states {
+		if !slices.Contains(p.States, state) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewDelegationByStakerPaginationTokenBuilder returns a token builder
+// encoding the state filter of the query into the pagination token
+func NewDelegationByStakerPaginationTokenBuilder(
+	states []types.DelegationState,
+) func(DelegationDocument) (string, error) {
+	return func(d DelegationDocument) (string, error) {
+		page := &DelegationByStakerPagination{
+			StakingTxHashHex:   d.StakingTxHashHex,
+			StakingStartHeight: d.StakingTx.StartHeight,
+			States:             states,
+		}
+		token, err := dbmodel.GetPaginationToken(page)
+		if err != nil {
+			return "", err
+		}
+		return token, nil
+	}
 }
 
-func BuildDelegationByStakerPaginationToken(d DelegationDocument) (string, error) {
-	page := &DelegationByStakerPagination{
-		StakingTxHashHex:   d.StakingTxHashHex,
-		StakingStartHeight: d.StakingTx.StartHeight,
+type WithdrawnDelegationPagination struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	// WithdrawalHeight is nil if the withdrawal tx was not recorded
+	WithdrawalHeight *uint64 `json:"withdrawal_height,omitempty"`
+}
+
+func BuildWithdrawnDelegationPaginationToken(d DelegationDocument) (string, error) {
+	page := &WithdrawnDelegationPagination{
+		StakingTxHashHex: d.StakingTxHashHex,
+	}
+	if d.WithdrawalTx != nil {
+		page.WithdrawalHeight = &d.WithdrawalTx.Height
 	}
 	token, err := dbmodel.GetPaginationToken(page)
 	if err != nil {
@@ -55,3 +200,20 @@ func BuildDelegationScanPaginationToken(d DelegationDocument) (string, error) {
 	}
 	return token, nil
 }
+
+type DelegationByValuePagination struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	StakingValue     uint64 `json:"staking_value"`
+}
+
+func BuildDelegationByValuePaginationToken(d DelegationDocument) (string, error) {
+	page := &DelegationByValuePagination{
+		StakingTxHashHex: d.StakingTxHashHex,
+		StakingValue:     d.StakingValue,
+	}
+	token, err := dbmodel.GetPaginationToken(page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
@@ -0,0 +1,102 @@
+package v1dbmodel
+
+import (
+	"testing"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationByStakerPaginationToken(t *testing.T) {
+	doc := DelegationDocument{
+		StakingTxHashHex: "abc",
+		StakingTx:        &TimelockTransaction{StartHeight: 100},
+	}
+	states := []types.DelegationState{types.Active, types.UnbondingRequested}
+
+	token, err := NewDelegationByStakerPaginationTokenBuilder(states)(doc)
+	require.NoError(t, err)
+	decoded, err := dbmodel.DecodePaginationToken[DelegationByStakerPagination](token)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc", decoded.StakingTxHashHex)
+	assert.Equal(t, uint64(100), decoded.StakingStartHeight)
+	assert.True(t, decoded.MatchesStates(states))
+	assert.True(t, decoded.MatchesStates([]types.DelegationState{types.UnbondingRequested, types.Active}))
+	assert.False(t, decoded.MatchesStates([]types.DelegationState{types.Active}))
+	assert.False(t, decoded.MatchesStates(nil))
+
+	unfilteredToken, err := NewDelegationByStakerPaginationTokenBuilder(nil)(doc)
+	require.NoError(t, err)
+	decoded, err = dbmodel.DecodePaginationToken[DelegationByStakerPagination](unfilteredToken)
+	require.NoError(t, err)
+	assert.True(t, decoded.MatchesStates(nil))
+	assert.False(t, decoded.MatchesStates(states))
+}
+
+func TestDeriveWithdrawalPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		delegation DelegationDocument
+		expected   WithdrawalPath
+	}{
+		{
+			name: "withdrawn while unbonding",
+			delegation: DelegationDocument{
+				State:       types.Unbonding,
+				UnbondingTx: &TimelockTransaction{StartHeight: 100, TimeLock: 10},
+			},
+			expected: WithdrawalPathEarlyUnbonding,
+		},
+		{
+			name:       "withdrawn once the staking timelock expired",
+			delegation: DelegationDocument{State: types.Unbonded},
+			expected:   WithdrawalPathNaturalExpiry,
+		},
+		{
+			name: "backfilled from an early unbonding history",
+			delegation: DelegationDocument{
+				State: types.Withdrawn,
+				StateHistory: []StateTransition{
+					{FromState: types.Unbonding, ToState: types.Unbonded},
+					{FromState: types.Unbonded, ToState: types.Withdrawn},
+				},
+			},
+			expected: WithdrawalPathEarlyUnbonding,
+		},
+		{
+			name: "backfilled from a natural expiry history",
+			delegation: DelegationDocument{
+				State: types.Withdrawn,
+				StateHistory: []StateTransition{
+					{ToState: types.Active},
+					{FromState: types.Active, ToState: types.Unbonded},
+					{FromState: types.Unbonded, ToState: types.Withdrawn},
+				},
+			},
+			expected: WithdrawalPathNaturalExpiry,
+		},
+		{
+			name: "backfilled without history",
+			delegation: DelegationDocument{
+				State: types.Withdrawn,
+			},
+			expected: WithdrawalPathUnknown,
+		},
+		{
+			name: "backfilled once the unbonded transition left the history",
+			delegation: DelegationDocument{
+				State:        types.Withdrawn,
+				StateHistory: []StateTransition{{FromState: types.Unbonded, ToState: types.Withdrawn}},
+			},
+			expected: WithdrawalPathUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.delegation.DeriveWithdrawalPath())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package v1dbmodel
+
+import "github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+
+type FinalityProviderRegistrationDescription struct {
+	Moniker         string `bson:"moniker"`
+	Identity        string `bson:"identity"`
+	Website         string `bson:"website"`
+	SecurityContact string `bson:"security_contact"`
+	Details         string `bson:"details"`
+}
+
+// FinalityProviderRegistrationDocument is a phase-1 finality provider
+// registered through the API, served along the ones of the finality providers
+// file. The id is the btc public key, so that a finality provider is only
+// registered once.
+type FinalityProviderRegistrationDocument struct {
+	BtcPk        string                                  `bson:"_id"`
+	Description  FinalityProviderRegistrationDescription `bson:"description"`
+	Commission   string                                  `bson:"commission"`
+	LogoUrl      string                                  `bson:"logo_url,omitempty"`
+	RegisteredAt int64                                   `bson:"registered_at"`
+}
+
+func NewFinalityProviderRegistrationDocument(
+	fp types.FinalityProviderDetails, registeredAt int64,
+) *FinalityProviderRegistrationDocument {
+	return &FinalityProviderRegistrationDocument{
+		BtcPk: fp.BtcPk,
+		Description: FinalityProviderRegistrationDescription{
+			Moniker:         fp.Description.Moniker,
+			Identity:        fp.Description.Identity,
+			Website:         fp.Description.Website,
+			SecurityContact: fp.Description.SecurityContact,
+			Details:         fp.Description.Details,
+		},
+		Commission:   fp.Commission,
+		LogoUrl:      fp.LogoUrl,
+		RegisteredAt: registeredAt,
+	}
+}
+
+// ToFinalityProviderDetails returns the finality provider as if it was
+// registered in the finality providers file
+func (d *FinalityProviderRegistrationDocument) ToFinalityProviderDetails() types.FinalityProviderDetails {
+	return types.FinalityProviderDetails{
+		Description: types.FinalityProviderDescription{
+			Moniker:         d.Description.Moniker,
+			Identity:        d.Description.Identity,
+			Website:         d.Description.Website,
+			SecurityContact: d.Description.SecurityContact,
+			Details:         d.Description.Details,
+		},
+		Commission: d.Commission,
+		BtcPk:      d.BtcPk,
+		LogoUrl:    d.LogoUrl,
+	}
+}
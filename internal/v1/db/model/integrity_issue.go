@@ -0,0 +1,62 @@
+package v1dbmodel
+
+import (
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+)
+
+type IntegrityIssueType string
+
+const (
+	// The unbonding document references a delegation that does not exist
+	OrphanedUnbondingIssue IntegrityIssueType = "ORPHANED_UNBONDING"
+	// The unbonding document references a delegation in a state that can not
+	// have an unbonding request
+	IncompatibleDelegationStateIssue IntegrityIssueType = "INCOMPATIBLE_DELEGATION_STATE"
+	// The delegation state violates an invariant on its unbonding documents
+	UnbondingInvariantViolationIssue IntegrityIssueType = "UNBONDING_INVARIANT_VIOLATION"
+)
+
+// IntegrityIssueDocument represents an inconsistency found by the unbonding
+// integrity check. The id is built from the issue type and the unbonding tx
+// hash so that the same issue is only stored once.
+type IntegrityIssueDocument struct {
+	Id                 string             `bson:"_id"`
+	IssueType          IntegrityIssueType `bson:"issue_type"`
+	UnbondingTxHashHex string             `bson:"unbonding_tx_hash_hex"`
+	StakingTxHashHex   string             `bson:"staking_tx_hash_hex"`
+	DelegationState    string             `bson:"delegation_state,omitempty"`
+	Invariant          string             `bson:"invariant,omitempty"`
+	Details            string             `bson:"details,omitempty"`
+	Quarantined        bool               `bson:"quarantined"`
+	DetectedAt         int64              `bson:"detected_at"`
+}
+
+func NewIntegrityIssueDocument(
+	issueType IntegrityIssueType, unbondingTxHashHex, stakingTxHashHex, delegationState string,
+	quarantined bool, detectedAt int64,
+) *IntegrityIssueDocument {
+	return &IntegrityIssueDocument{
+		Id:                 string(issueType) + ":" + unbondingTxHashHex,
+		IssueType:          issueType,
+		UnbondingTxHashHex: unbondingTxHashHex,
+		StakingTxHashHex:   stakingTxHashHex,
+		DelegationState:    delegationState,
+		Quarantined:        quarantined,
+		DetectedAt:         detectedAt,
+	}
+}
+
+type IntegrityIssuePagination struct {
+	Id string `json:"id"`
+}
+
+func BuildIntegrityIssuePaginationToken(d IntegrityIssueDocument) (string, error) {
+	page := &IntegrityIssuePagination{
+		Id: d.Id,
+	}
+	token, err := dbmodel.GetPaginationToken(page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
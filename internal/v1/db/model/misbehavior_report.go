@@ -0,0 +1,51 @@
+package v1dbmodel
+
+type MisbehaviorEvidenceType string
+
+const (
+	DoubleSignEvidence     MisbehaviorEvidenceType = "double_sign"
+	UnavailabilityEvidence MisbehaviorEvidenceType = "unavailability"
+)
+
+// IsValid returns true if the evidence type is a known one
+func (t MisbehaviorEvidenceType) IsValid() bool {
+	return t == DoubleSignEvidence || t == UnavailabilityEvidence
+}
+
+type MisbehaviorReportStatus string
+
+// PendingReviewReportStatus is the status of the reports not yet reviewed
+const PendingReviewReportStatus MisbehaviorReportStatus = "pending_review"
+
+// MisbehaviorReportDocument is a suspected misbehavior of a finality provider
+// reported by a community member. The id is built from the finality provider,
+// the evidence hash and the reporter so that the same report is only stored
+// once.
+type MisbehaviorReportDocument struct {
+	Id                    string                  `bson:"_id"`
+	FinalityProviderPkHex string                  `bson:"finality_provider_pk_hex"`
+	EvidenceType          MisbehaviorEvidenceType `bson:"evidence_type"`
+	EvidenceTxHex         string                  `bson:"evidence_tx_hex"`
+	EvidenceHashHex       string                  `bson:"evidence_hash_hex"`
+	ReporterPkHex         string                  `bson:"reporter_pk_hex"`
+	ReporterSigHex        string                  `bson:"reporter_sig_hex"`
+	Status                MisbehaviorReportStatus `bson:"status"`
+	ReportedAt            int64                   `bson:"reported_at"`
+}
+
+func NewMisbehaviorReportDocument(
+	fpPkHex string, evidenceType MisbehaviorEvidenceType, evidenceTxHex, evidenceHashHex,
+	reporterPkHex, reporterSigHex string, reportedAt int64,
+) *MisbehaviorReportDocument {
+	return &MisbehaviorReportDocument{
+		Id:                    fpPkHex + ":" + evidenceHashHex + ":" + reporterPkHex,
+		FinalityProviderPkHex: fpPkHex,
+		EvidenceType:          evidenceType,
+		EvidenceTxHex:         evidenceTxHex,
+		EvidenceHashHex:       evidenceHashHex,
+		ReporterPkHex:         reporterPkHex,
+		ReporterSigHex:        reporterSigHex,
+		Status:                PendingReviewReportStatus,
+		ReportedAt:            reportedAt,
+	}
+}
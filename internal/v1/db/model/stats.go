@@ -1,6 +1,9 @@
 package v1dbmodel
 
-import dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+import (
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
 
 // StatsLockDocument represents the document in the stats lock collection
 // It's used as a lock to prevent concurrent stats calculation for the same staking tx hash
@@ -31,6 +34,10 @@ type OverallStatsDocument struct {
 	ActiveDelegations int64  `bson:"active_delegations"`
 	TotalDelegations  int64  `bson:"total_delegations"`
 	TotalStakers      uint64 `bson:"total_stakers"`
+	// WithdrawnTvl sums the value of the withdrawn delegations by withdrawal path
+	WithdrawnTvlEarlyUnbonding int64 `bson:"withdrawn_tvl_early_unbonding"`
+	WithdrawnTvlNaturalExpiry  int64 `bson:"withdrawn_tvl_natural_expiry"`
+	WithdrawnTvlUnknown        int64 `bson:"withdrawn_tvl_unknown"`
 }
 
 type FinalityProviderStatsDocument struct {
@@ -66,6 +73,30 @@ type StakerStatsDocument struct {
 	TotalDelegations  int64  `bson:"total_delegations"`
 }
 
+// DeriveStakerStats computes the stats of the staker from its delegations as
+// recorded by the stats processing: the delegations whose active staker stats
+// were added, less the active part of the ones whose unbonded staker stats
+// were subtracted. isProcessed tells if the staker stats of the delegation
+// were processed for the given state.
+func DeriveStakerStats(
+	stakerPkHex string, delegations []DelegationDocument,
+	isProcessed func(stakingTxHashHex string, state types.DelegationState) bool,
+) *StakerStatsDocument {
+	stats := &StakerStatsDocument{StakerPkHex: stakerPkHex}
+	for _, delegation := range delegations {
+		if !isProcessed(delegation.StakingTxHashHex, types.Active) {
+			continue
+		}
+		stats.TotalTvl += int64(delegation.StakingValue)
+		stats.TotalDelegations++
+		if !isProcessed(delegation.StakingTxHashHex, types.Unbonded) {
+			stats.ActiveTvl += int64(delegation.StakingValue)
+			stats.ActiveDelegations++
+		}
+	}
+	return stats
+}
+
 // StakerStatsByStakerPagination is used to paginate the top stakers by active tvl
 // ActiveTvl is used as the sorting key, whereas StakerPkHex is used as the secondary sorting key
 type StakerStatsByStakerPagination struct {
@@ -2,8 +2,56 @@ package v1dbmodel
 
 const (
 	UnbondingInitialState = "INSERTED"
+	// States set by the unbonding pipeline once it processed the document
+	UnbondingSendState              = "SEND"
+	UnbondingInputAlreadySpentState = "INPUT_ALREADY_SPENT"
+	UnbondingFailedState            = "FAILED"
+	// UnbondingSkippedState marks unbonding documents quarantined by the
+	// integrity check, they are excluded from the unbonding pipeline
+	UnbondingSkippedState = "SKIPPED"
 )
 
+// IsTerminalUnbondingState returns true if the unbonding attempt can no
+// longer lead to the delegation being unbonded
+func IsTerminalUnbondingState(state string) bool {
+	switch state {
+	case UnbondingInputAlreadySpentState, UnbondingFailedState, UnbondingSkippedState:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnbondingStateInfo describes an unbonding attempt state to the clients
+type UnbondingStateInfo struct {
+	State       string
+	Description string
+	// Terminal is true for the states from which the unbonding attempt can no
+	// longer lead to the delegation being unbonded, see IsTerminalUnbondingState
+	Terminal bool
+}
+
+// unbondingStateRegistry lists every unbonding attempt state, a state
+// missing from it fails the registry tests. Terminal is set from
+// IsTerminalUnbondingState when the states are returned.
+var unbondingStateRegistry = []UnbondingStateInfo{
+	{State: UnbondingInitialState, Description: "The unbonding request was accepted and waits for the unbonding pipeline"},
+	{State: UnbondingSendState, Description: "The unbonding tx was sent to the BTC network"},
+	{State: UnbondingInputAlreadySpentState, Description: "The staking tx output was already spent"},
+	{State: UnbondingFailedState, Description: "The unbonding pipeline failed to send the unbonding tx"},
+	{State: UnbondingSkippedState, Description: "The request was quarantined by the integrity check"},
+}
+
+// RegisteredUnbondingStates returns every unbonding attempt state with its
+// description
+func RegisteredUnbondingStates() []UnbondingStateInfo {
+	states := append([]UnbondingStateInfo(nil), unbondingStateRegistry...)
+	for i := range states {
+		states[i].Terminal = IsTerminalUnbondingState(states[i].State)
+	}
+	return states
+}
+
 type UnbondingDocument struct {
 	StakerPkHex        string `bson:"staker_pk_hex"`
 	FinalityPkHex      string `bson:"finality_pk_hex"`
@@ -0,0 +1,56 @@
+package v1dbmodel
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnbondingStateRegistryIsComplete(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "unbonding.go", nil, 0)
+	require.NoError(t, err)
+	// the unbonding states are the Unbonding*State constants
+	var declared []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range spec.Names {
+			if !strings.HasPrefix(name.Name, "Unbonding") || !strings.HasSuffix(name.Name, "State") {
+				continue
+			}
+			value, err := strconv.Unquote(spec.Values[i].(*ast.BasicLit).Value)
+			require.NoError(t, err)
+			declared = append(declared, value)
+		}
+		return true
+	})
+	require.NotEmpty(t, declared)
+
+	var registered []string
+	for _, info := range RegisteredUnbondingStates() {
+		require.NotEmpty(t, info.Description, info.State)
+		registered = append(registered, info.State)
+	}
+	assert.ElementsMatch(t, declared, registered)
+}
+
+func TestUnbondingStateRegistryTerminalStates(t *testing.T) {
+	terminal := make(map[string]bool)
+	for _, info := range RegisteredUnbondingStates() {
+		assert.Equal(t, IsTerminalUnbondingState(info.State), info.Terminal, info.State)
+		terminal[info.State] = info.Terminal
+	}
+	// a sent unbonding tx still has to be confirmed for the delegation to
+	// be unbonded
+	assert.False(t, terminal[UnbondingSendState])
+	assert.False(t, terminal[UnbondingInitialState])
+	assert.True(t, terminal[UnbondingFailedState])
+}
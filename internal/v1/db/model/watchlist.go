@@ -0,0 +1,23 @@
+package v1dbmodel
+
+// WatchlistDocument is a staker watched by an observer. The id is built from
+// both public keys so that a staker is only watched once by the same observer.
+type WatchlistDocument struct {
+	Id            string `bson:"_id"`
+	ObserverPkHex string `bson:"observer_pk_hex"`
+	WatchedPkHex  string `bson:"watched_pk_hex"`
+	AddedAt       int64  `bson:"added_at"`
+}
+
+func NewWatchlistDocument(observerPkHex, watchedPkHex string, addedAt int64) *WatchlistDocument {
+	return &WatchlistDocument{
+		Id:            WatchlistDocumentId(observerPkHex, watchedPkHex),
+		ObserverPkHex: observerPkHex,
+		WatchedPkHex:  watchedPkHex,
+		AddedAt:       addedAt,
+	}
+}
+
+func WatchlistDocumentId(observerPkHex, watchedPkHex string) string {
+	return observerPkHex + ":" + watchedPkHex
+}
@@ -0,0 +1,68 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregationLimit(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		AggregationLimiter: service.NewAggregationLimiter(&config.AggregationLimitConfig{
+			Capacity: 1,
+			MaxWait:  50 * time.Millisecond,
+		}),
+	}}
+
+	// the new stakers per day are cached before the capacity is saturated
+	v1DB.On("FindStakersFirstSeenTimestamps", ctx).Return([]int64{0}, nil).Once()
+	_, err := s.GetNewStakersPerDay(ctx)
+	require.Nil(t, err)
+
+	// a slow staking duration aggregation holds the whole capacity
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	v1DB.On("CountActiveDelegationsByTimelock", ctx).
+		Run(func(_ mock.Arguments) {
+			close(started)
+			<-finish
+		}).
+		Return(map[uint64]int64{}, nil).Once()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := s.GetStakingDurationStats(ctx)
+		assert.Nil(t, err)
+	}()
+	<-started
+
+	start := time.Now()
+	_, err = s.GetStakerCovenantExposure(ctx, "pk")
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+	assert.Equal(t, types.QueryCapacity, err.ErrorCode)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// the cached response does not need any capacity
+	days, err := s.GetNewStakersPerDay(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []NewStakersPerDayPublic{{Date: "1970-01-01", NewStakers: 1}}, days)
+
+	close(finish)
+	<-done
+}
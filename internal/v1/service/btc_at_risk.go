@@ -0,0 +1,91 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+type RiskLevel string
+
+const (
+	// RiskLevelHigh is the risk level of the slashed finality providers
+	RiskLevelHigh RiskLevel = "high"
+	// RiskLevelMedium is the risk level of the jailed finality providers
+	RiskLevelMedium RiskLevel = "medium"
+)
+
+type ActiveBtcAtRiskPublic struct {
+	TotalAtRiskSat         uint64                        `json:"total_at_risk_sat"`
+	DelegationsAtRiskCount int64                         `json:"delegations_at_risk_count"`
+	Providers              []*FinalityProviderRiskPublic `json:"providers"`
+}
+
+type FinalityProviderRiskPublic struct {
+	PkHex     string    `json:"pk_hex"`
+	RiskLevel RiskLevel `json:"risk_level"`
+}
+
+// GetStakerActiveBtcAtRisk sums the active delegations of the staker to the
+// finality providers that are slashed or jailed in phase-2. The service does
+// not track the finality providers uptime, hence a low uptime is not reported
+// as a risk.
+func (s *V1Service) GetStakerActiveBtcAtRisk(
+	ctx context.Context, stakerPkHex string,
+) (*ActiveBtcAtRiskPublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1StakerBtcAtRiskAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	sums, err := s.Service.DbClients.V1DBClient.SumActiveDelegationsByFinalityProvider(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to sum active delegations by finality provider")
+		return nil, types.NewInternalServiceError(err)
+	}
+	atRisk := &ActiveBtcAtRiskPublic{Providers: []*FinalityProviderRiskPublic{}}
+	if len(sums) == 0 {
+		return atRisk, nil
+	}
+
+	fps, err := s.Service.DbClients.IndexerDBClient.GetFinalityProviders(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get finality providers")
+		return nil, types.NewInternalServiceError(err)
+	}
+	riskLevels := make(map[string]RiskLevel)
+	for _, fp := range fps {
+		switch fp.State {
+		case indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_SLASHED:
+			riskLevels[fp.BtcPk] = RiskLevelHigh
+		case indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_JAILED:
+			riskLevels[fp.BtcPk] = RiskLevelMedium
+		}
+	}
+
+	for _, sum := range sums {
+		riskLevel, ok := riskLevels[sum.FinalityProviderPkHex]
+		if !ok {
+			continue
+		}
+		atRisk.TotalAtRiskSat += sum.TotalValue
+		atRisk.DelegationsAtRiskCount += sum.DelegationCount
+		atRisk.Providers = append(atRisk.Providers, &FinalityProviderRiskPublic{
+			PkHex:     sum.FinalityProviderPkHex,
+			RiskLevel: riskLevel,
+		})
+	}
+	// The riskiest finality providers come first
+	sort.Slice(atRisk.Providers, func(i, j int) bool {
+		if atRisk.Providers[i].RiskLevel != atRisk.Providers[j].RiskLevel {
+			return atRisk.Providers[i].RiskLevel == RiskLevelHigh
+		}
+		return atRisk.Providers[i].PkHex < atRisk.Providers[j].PkHex
+	})
+	return atRisk, nil
+}
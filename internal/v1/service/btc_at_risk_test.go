@@ -0,0 +1,56 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStakerActiveBtcAtRisk(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	indexerDB := mocks.NewIndexerDBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB},
+	}}
+
+	v1DB.On("SumActiveDelegationsByFinalityProvider", ctx, "staker").
+		Return([]v1dbmodel.FinalityProviderDelegationsSum{
+			{FinalityProviderPkHex: "active-fp", TotalValue: 1000, DelegationCount: 1},
+			{FinalityProviderPkHex: "jailed-fp", TotalValue: 2000, DelegationCount: 2},
+			{FinalityProviderPkHex: "slashed-fp", TotalValue: 4000, DelegationCount: 3},
+			{FinalityProviderPkHex: "not-transitioned-fp", TotalValue: 8000, DelegationCount: 4},
+		}, nil).Once()
+	indexerDB.On("GetFinalityProviders", ctx).Return([]*indexerdbmodel.IndexerFinalityProviderDetails{
+		{BtcPk: "active-fp", State: indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_ACTIVE},
+		{BtcPk: "inactive-fp", State: indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_INACTIVE},
+		{BtcPk: "jailed-fp", State: indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_JAILED},
+		{BtcPk: "slashed-fp", State: indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_SLASHED},
+	}, nil).Once()
+
+	atRisk, err := s.GetStakerActiveBtcAtRisk(ctx, "staker")
+	require.Nil(t, err)
+	assert.Equal(t, &ActiveBtcAtRiskPublic{
+		TotalAtRiskSat:         6000,
+		DelegationsAtRiskCount: 5,
+		Providers: []*FinalityProviderRiskPublic{
+			{PkHex: "slashed-fp", RiskLevel: RiskLevelHigh},
+			{PkHex: "jailed-fp", RiskLevel: RiskLevelMedium},
+		},
+	}, atRisk)
+
+	// a staker without active delegations has nothing at risk
+	v1DB.On("SumActiveDelegationsByFinalityProvider", ctx, "new-staker").
+		Return([]v1dbmodel.FinalityProviderDelegationsSum{}, nil).Once()
+	atRisk, err = s.GetStakerActiveBtcAtRisk(ctx, "new-staker")
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0), atRisk.TotalAtRiskSat)
+	assert.Empty(t, atRisk.Providers)
+}
@@ -0,0 +1,84 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// topCoStakersLimit is the number of co-stakers listed by active tvl
+const topCoStakersLimit = 5
+
+type CoStakerPublic struct {
+	StakerPkHex       string `json:"staker_pk_hex"`
+	ActiveTvl         int64  `json:"active_tvl"`
+	ActiveDelegations int64  `json:"active_delegations"`
+}
+
+type CoStakersPublic struct {
+	FinalityProviderPkHex string           `json:"finality_provider_pk_hex"`
+	CoStakerCount         int              `json:"co_staker_count"`
+	TopCoStakers          []CoStakerPublic `json:"top_co_stakers"`
+}
+
+// GetCoStakers returns the other stakers with active delegations to the
+// finality provider of the delegation, their number and the ones with the
+// highest active tvl
+func (s *V1Service) GetCoStakers(ctx context.Context, stakingTxHashHex string) (*CoStakersPublic, *types.Error) {
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("stakingTxHash", stakingTxHashHex).Msg("Staking delegation not found")
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "staking delegation not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation by tx hash hex")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1CoStakersAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	sums, err := s.Service.DbClients.V1DBClient.SumActiveDelegationsByStaker(
+		ctx, delegation.FinalityProviderPkHex, delegation.StakerPkHex,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to sum the active delegations to the finality provider by staker")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return &CoStakersPublic{
+		FinalityProviderPkHex: delegation.FinalityProviderPkHex,
+		CoStakerCount:         len(sums),
+		TopCoStakers:          topCoStakers(sums, topCoStakersLimit),
+	}, nil
+}
+
+// topCoStakers returns the limit stakers with the highest active tvl, ties are
+// ordered by staker public key so that the result is stable
+func topCoStakers(sums []v1dbmodel.StakerDelegationsSum, limit int) []CoStakerPublic {
+	sort.Slice(sums, func(i, j int) bool {
+		if sums[i].TotalValue != sums[j].TotalValue {
+			return sums[i].TotalValue > sums[j].TotalValue
+		}
+		return sums[i].StakerPkHex < sums[j].StakerPkHex
+	})
+
+	top := make([]CoStakerPublic, 0, min(limit, len(sums)))
+	for _, sum := range sums[:min(limit, len(sums))] {
+		top = append(top, CoStakerPublic{
+			StakerPkHex:       sum.StakerPkHex,
+			ActiveTvl:         int64(sum.TotalValue),
+			ActiveDelegations: sum.DelegationCount,
+		})
+	}
+	return top
+}
@@ -0,0 +1,96 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCoStakers(t *testing.T) {
+	ctx := context.Background()
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		return &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+	}
+	delegation := &v1dbmodel.DelegationDocument{
+		StakingTxHashHex:      "tx",
+		StakerPkHex:           "staker",
+		FinalityProviderPkHex: "fp",
+		State:                 types.Active,
+	}
+
+	t.Run("counts the co-stakers and lists the top ones by active tvl", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(delegation, nil).Once()
+		// the delegations of the staker itself are left out
+		v1DB.On("SumActiveDelegationsByStaker", ctx, "fp", "staker").Return([]v1dbmodel.StakerDelegationsSum{
+			{StakerPkHex: "a", TotalValue: 100, DelegationCount: 1},
+			{StakerPkHex: "b", TotalValue: 700, DelegationCount: 3},
+			{StakerPkHex: "c", TotalValue: 300, DelegationCount: 1},
+			{StakerPkHex: "d", TotalValue: 500, DelegationCount: 2},
+			{StakerPkHex: "e", TotalValue: 200, DelegationCount: 1},
+			{StakerPkHex: "f", TotalValue: 300, DelegationCount: 2},
+			{StakerPkHex: "g", TotalValue: 50, DelegationCount: 1},
+		}, nil).Once()
+
+		coStakers, err := newService(v1DB).GetCoStakers(ctx, "tx")
+		require.Nil(t, err)
+		assert.Equal(t, &CoStakersPublic{
+			FinalityProviderPkHex: "fp",
+			CoStakerCount:         7,
+			TopCoStakers: []CoStakerPublic{
+				{StakerPkHex: "b", ActiveTvl: 700, ActiveDelegations: 3},
+				{StakerPkHex: "d", ActiveTvl: 500, ActiveDelegations: 2},
+				// ties are ordered by staker public key
+				{StakerPkHex: "c", ActiveTvl: 300, ActiveDelegations: 1},
+				{StakerPkHex: "f", ActiveTvl: 300, ActiveDelegations: 2},
+				{StakerPkHex: "e", ActiveTvl: 200, ActiveDelegations: 1},
+			},
+		}, coStakers)
+	})
+
+	t.Run("fewer co-stakers than the top limit", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(delegation, nil).Once()
+		v1DB.On("SumActiveDelegationsByStaker", ctx, "fp", "staker").Return([]v1dbmodel.StakerDelegationsSum{
+			{StakerPkHex: "a", TotalValue: 100, DelegationCount: 1},
+			{StakerPkHex: "b", TotalValue: 200, DelegationCount: 1},
+		}, nil).Once()
+
+		coStakers, err := newService(v1DB).GetCoStakers(ctx, "tx")
+		require.Nil(t, err)
+		assert.Equal(t, 2, coStakers.CoStakerCount)
+		assert.Equal(t, []CoStakerPublic{
+			{StakerPkHex: "b", ActiveTvl: 200, ActiveDelegations: 1},
+			{StakerPkHex: "a", ActiveTvl: 100, ActiveDelegations: 1},
+		}, coStakers.TopCoStakers)
+	})
+
+	t.Run("sole staker of the finality provider", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(delegation, nil).Once()
+		v1DB.On("SumActiveDelegationsByStaker", ctx, "fp", "staker").Return(nil, nil).Once()
+
+		coStakers, err := newService(v1DB).GetCoStakers(ctx, "tx")
+		require.Nil(t, err)
+		assert.Equal(t, &CoStakersPublic{FinalityProviderPkHex: "fp", TopCoStakers: []CoStakerPublic{}}, coStakers)
+	})
+
+	t.Run("delegation not found", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", ctx, "tx").
+			Return(nil, &db.NotFoundError{Key: "tx", Message: "not found"}).Once()
+
+		_, err := newService(v1DB).GetCoStakers(ctx, "tx")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+}
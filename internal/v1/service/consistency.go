@@ -0,0 +1,290 @@
+package v1service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+type InvariantStatus string
+
+const (
+	InvariantPass          InvariantStatus = "pass"
+	InvariantFail          InvariantStatus = "fail"
+	InvariantNotApplicable InvariantStatus = "not_applicable"
+)
+
+const (
+	// A delegation in the unbonding_requested state has exactly one unbonding
+	// attempt which is not in a terminal state
+	UnbondingRequestedHasOnePendingUnbonding = "unbonding_requested_has_one_pending_unbonding"
+	// An active delegation has no unbonding attempt in a non terminal state
+	ActiveHasNoPendingUnbonding = "active_has_no_pending_unbonding"
+	// A delegation with unbonding documents is in a state reachable after an
+	// unbonding request
+	UnbondingDocumentsMatchDelegationState = "unbonding_documents_match_delegation_state"
+)
+
+type InvariantResultPublic struct {
+	Name    string          `json:"name"`
+	Status  InvariantStatus `json:"status"`
+	Details string          `json:"details,omitempty"`
+}
+
+type DelegationConsistencyPublic struct {
+	StakingTxHashHex string                  `json:"staking_tx_hash_hex"`
+	State            string                  `json:"state"`
+	Consistent       bool                    `json:"consistent"`
+	Invariants       []InvariantResultPublic `json:"invariants"`
+}
+
+// evaluateDelegationInvariants checks the delegation state against all its
+// unbonding documents
+func evaluateDelegationInvariants(
+	state types.DelegationState, unbondingDocs []v1dbmodel.UnbondingDocument,
+) []InvariantResultPublic {
+	return []InvariantResultPublic{
+		checkUnbondingRequestedHasOnePendingUnbonding(state, unbondingDocs),
+		checkActiveHasNoPendingUnbonding(state, unbondingDocs),
+		checkUnbondingDocumentsMatchDelegationState(state, unbondingDocs),
+	}
+}
+
+func checkUnbondingRequestedHasOnePendingUnbonding(
+	state types.DelegationState, unbondingDocs []v1dbmodel.UnbondingDocument,
+) InvariantResultPublic {
+	result := InvariantResultPublic{Name: UnbondingRequestedHasOnePendingUnbonding}
+	if state != types.UnbondingRequested {
+		result.Status = InvariantNotApplicable
+		return result
+	}
+	pending := pendingUnbondingTxHashes(unbondingDocs)
+	if len(pending) != 1 {
+		result.Status = InvariantFail
+		result.Details = fmt.Sprintf("expected 1 pending unbonding, found %d: %v", len(pending), pending)
+		return result
+	}
+	result.Status = InvariantPass
+	return result
+}
+
+func checkActiveHasNoPendingUnbonding(
+	state types.DelegationState, unbondingDocs []v1dbmodel.UnbondingDocument,
+) InvariantResultPublic {
+	result := InvariantResultPublic{Name: ActiveHasNoPendingUnbonding}
+	if state != types.Active {
+		result.Status = InvariantNotApplicable
+		return result
+	}
+	pending := pendingUnbondingTxHashes(unbondingDocs)
+	if len(pending) != 0 {
+		result.Status = InvariantFail
+		result.Details = fmt.Sprintf("expected no pending unbonding, found %d: %v", len(pending), pending)
+		return result
+	}
+	result.Status = InvariantPass
+	return result
+}
+
+func checkUnbondingDocumentsMatchDelegationState(
+	state types.DelegationState, unbondingDocs []v1dbmodel.UnbondingDocument,
+) InvariantResultPublic {
+	result := InvariantResultPublic{Name: UnbondingDocumentsMatchDelegationState}
+	if len(unbondingDocs) == 0 {
+		result.Status = InvariantNotApplicable
+		return result
+	}
+	if !slices.Contains(utils.CompatibleStatesForUnbondingDocument(), state) {
+		result.Status = InvariantFail
+		result.Details = fmt.Sprintf(
+			"delegation in state %s has %d unbonding documents", state, len(unbondingDocs),
+		)
+		return result
+	}
+	result.Status = InvariantPass
+	return result
+}
+
+func pendingUnbondingTxHashes(unbondingDocs []v1dbmodel.UnbondingDocument) []string {
+	var pending []string
+	for _, unbondingDoc := range unbondingDocs {
+		if !v1dbmodel.IsTerminalUnbondingState(unbondingDoc.State) {
+			pending = append(pending, unbondingDoc.UnbondingTxHashHex)
+		}
+	}
+	return pending
+}
+
+// GetDelegationConsistency evaluates the invariants between the delegation
+// state and its unbonding documents
+func (s *V1Service) GetDelegationConsistency(
+	ctx context.Context, stakingTxHashHex string,
+) (*DelegationConsistencyPublic, *types.Error) {
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "staking delegation not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation by tx hash hex")
+		return nil, types.NewInternalServiceError(err)
+	}
+	unbondingDocs, err := s.Service.DbClients.V1DBClient.FindUnbondingDocumentsByStakingTxHashHexes(
+		ctx, []string{stakingTxHashHex},
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find unbonding documents")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	invariants := evaluateDelegationInvariants(delegation.State, unbondingDocs)
+	consistent := true
+	for _, invariant := range invariants {
+		if invariant.Status == InvariantFail {
+			consistent = false
+		}
+	}
+	return &DelegationConsistencyPublic{
+		StakingTxHashHex: stakingTxHashHex,
+		State:            delegation.State.ToString(),
+		Consistent:       consistent,
+		Invariants:       invariants,
+	}, nil
+}
+
+type ConsistencyIssueType string
+
+const (
+	// The delegation state is not part of the delegation state machine
+	ConsistencyIssueUnknownState ConsistencyIssueType = "unknown_state"
+	// The delegation state can not be reached given the transactions and the
+	// unbonding documents of the delegation
+	ConsistencyIssueInvalidTransition ConsistencyIssueType = "invalid_state_transition"
+	ConsistencyIssueZeroStakingValue  ConsistencyIssueType = "zero_staking_value"
+	ConsistencyIssueMissingField      ConsistencyIssueType = "missing_required_field"
+)
+
+type ConsistencyIssuePublic struct {
+	StakingTxHashHex string               `json:"staking_tx_hash_hex"`
+	Issue            ConsistencyIssueType `json:"issue"`
+	Details          string               `json:"details,omitempty"`
+}
+
+type ConsistencyVerificationPublic struct {
+	Checked int                      `json:"checked"`
+	Issues  []ConsistencyIssuePublic `json:"issues"`
+}
+
+// checkDelegationDocument returns the issues of a delegation document, the
+// staking tx hash needs no check as it is the primary key
+func checkDelegationDocument(
+	delegation *v1dbmodel.DelegationDocument, unbondingDocs []v1dbmodel.UnbondingDocument,
+) []ConsistencyIssuePublic {
+	var issues []ConsistencyIssuePublic
+	addIssue := func(issue ConsistencyIssueType, details string) {
+		issues = append(issues, ConsistencyIssuePublic{
+			StakingTxHashHex: delegation.StakingTxHashHex, Issue: issue, Details: details,
+		})
+	}
+
+	for _, field := range []struct {
+		name    string
+		missing bool
+	}{
+		{"staker_pk_hex", delegation.StakerPkHex == ""},
+		{"finality_provider_pk_hex", delegation.FinalityProviderPkHex == ""},
+		{"staking_tx", delegation.StakingTx == nil || delegation.StakingTx.TxHex == ""},
+	} {
+		if field.missing {
+			addIssue(ConsistencyIssueMissingField, field.name)
+		}
+	}
+	if delegation.StakingValue == 0 {
+		addIssue(ConsistencyIssueZeroStakingValue, "")
+	}
+
+	if _, err := types.FromStringToDelegationState(delegation.State.ToString()); err != nil {
+		addIssue(ConsistencyIssueUnknownState, delegation.State.ToString())
+		return issues
+	}
+	switch {
+	case delegation.State == types.Unbonding && delegation.UnbondingTx == nil:
+		addIssue(ConsistencyIssueInvalidTransition, "unbonding delegation has no unbonding tx")
+	case (delegation.State == types.Active || delegation.State == types.UnbondingRequested) &&
+		delegation.UnbondingTx != nil:
+		addIssue(ConsistencyIssueInvalidTransition, fmt.Sprintf(
+			"delegation in state %s has an unbonding tx", delegation.State,
+		))
+	}
+	if delegation.WithdrawalTx != nil && delegation.State != types.Withdrawn {
+		addIssue(ConsistencyIssueInvalidTransition, fmt.Sprintf(
+			"delegation in state %s has a withdrawal tx", delegation.State,
+		))
+	}
+	for _, invariant := range evaluateDelegationInvariants(delegation.State, unbondingDocs) {
+		if invariant.Status == InvariantFail {
+			addIssue(ConsistencyIssueInvalidTransition, invariant.Name+": "+invariant.Details)
+		}
+	}
+	return issues
+}
+
+// VerifyDelegationsConsistency checks a random sample of up to sampleSize
+// delegations for documents that could not have been written by the service,
+// e.g. after a failover of the db
+func (s *V1Service) VerifyDelegationsConsistency(
+	ctx context.Context, sampleSize int64,
+) (*ConsistencyVerificationPublic, *types.Error) {
+	delegations, err := s.Service.DbClients.V1DBClient.SampleDelegations(ctx, sampleSize)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to sample delegations")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	// The sample may hold the same delegation more than once
+	stakingTxHashHexes := make([]string, 0, len(delegations))
+	sampled := make(map[string]*v1dbmodel.DelegationDocument, len(delegations))
+	for i := range delegations {
+		if _, ok := sampled[delegations[i].StakingTxHashHex]; ok {
+			continue
+		}
+		sampled[delegations[i].StakingTxHashHex] = &delegations[i]
+		stakingTxHashHexes = append(stakingTxHashHexes, delegations[i].StakingTxHashHex)
+	}
+
+	verification := &ConsistencyVerificationPublic{
+		Checked: len(stakingTxHashHexes),
+		Issues:  []ConsistencyIssuePublic{},
+	}
+	if len(stakingTxHashHexes) == 0 {
+		return verification, nil
+	}
+
+	unbondingDocs, err := db.FindInChunks(
+		ctx, stakingTxHashHexes, s.inListChunkSize(), db.InListChunkParallelism,
+		s.Service.DbClients.V1DBClient.FindUnbondingDocumentsByStakingTxHashHexes,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find unbonding documents")
+		return nil, types.NewInternalServiceError(err)
+	}
+	unbondingDocsByStakingTx := make(map[string][]v1dbmodel.UnbondingDocument)
+	for _, unbondingDoc := range unbondingDocs {
+		unbondingDocsByStakingTx[unbondingDoc.StakingTxHashHex] = append(
+			unbondingDocsByStakingTx[unbondingDoc.StakingTxHashHex], unbondingDoc,
+		)
+	}
+
+	for _, stakingTxHashHex := range stakingTxHashHexes {
+		verification.Issues = append(verification.Issues, checkDelegationDocument(
+			sampled[stakingTxHashHex], unbondingDocsByStakingTx[stakingTxHashHex],
+		)...)
+	}
+	return verification, nil
+}
@@ -0,0 +1,100 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateDelegationInvariants(t *testing.T) {
+	pending := v1dbmodel.UnbondingDocument{UnbondingTxHashHex: "pending", State: v1dbmodel.UnbondingInitialState}
+	sent := v1dbmodel.UnbondingDocument{UnbondingTxHashHex: "sent", State: v1dbmodel.UnbondingSendState}
+	failed := v1dbmodel.UnbondingDocument{UnbondingTxHashHex: "failed", State: v1dbmodel.UnbondingFailedState}
+
+	testCases := []struct {
+		name          string
+		state         types.DelegationState
+		unbondingDocs []v1dbmodel.UnbondingDocument
+		failed        []string
+	}{
+		{"active without unbonding", types.Active, nil, nil},
+		{"active with failed unbonding", types.Active, []v1dbmodel.UnbondingDocument{failed},
+			[]string{UnbondingDocumentsMatchDelegationState}},
+		{"active with pending unbonding", types.Active, []v1dbmodel.UnbondingDocument{pending},
+			[]string{ActiveHasNoPendingUnbonding, UnbondingDocumentsMatchDelegationState}},
+		{"unbonding requested with one pending unbonding", types.UnbondingRequested,
+			[]v1dbmodel.UnbondingDocument{failed, sent}, nil},
+		{"unbonding requested without pending unbonding", types.UnbondingRequested,
+			[]v1dbmodel.UnbondingDocument{failed}, []string{UnbondingRequestedHasOnePendingUnbonding}},
+		{"unbonding requested with two pending unbonding", types.UnbondingRequested,
+			[]v1dbmodel.UnbondingDocument{pending, sent}, []string{UnbondingRequestedHasOnePendingUnbonding}},
+		{"withdrawn with unbonding", types.Withdrawn, []v1dbmodel.UnbondingDocument{sent}, nil},
+		{"withdrawable with unbonding", types.Withdrawable, []v1dbmodel.UnbondingDocument{sent},
+			[]string{UnbondingDocumentsMatchDelegationState}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var failedInvariants []string
+			for _, invariant := range evaluateDelegationInvariants(tc.state, tc.unbondingDocs) {
+				if invariant.Status == InvariantFail {
+					assert.NotEmpty(t, invariant.Details)
+					failedInvariants = append(failedInvariants, invariant.Name)
+				}
+			}
+			assert.Equal(t, tc.failed, failedInvariants)
+		})
+	}
+}
+
+func TestVerifyDelegationsConsistency(t *testing.T) {
+	ctx := context.Background()
+	consistent := v1dbmodel.DelegationDocument{
+		StakingTxHashHex:      "consistent",
+		StakerPkHex:           "staker",
+		FinalityProviderPkHex: "fp",
+		StakingValue:          1000,
+		State:                 types.UnbondingRequested,
+		StakingTx:             &v1dbmodel.TimelockTransaction{TxHex: "txhex"},
+	}
+	inconsistent := v1dbmodel.DelegationDocument{
+		StakingTxHashHex: "inconsistent",
+		StakerPkHex:      "staker",
+		State:            types.Unbonding,
+		StakingTx:        &v1dbmodel.TimelockTransaction{},
+	}
+
+	v1DB := mocks.NewV1DBClient(t)
+	// the same delegation can be sampled twice
+	v1DB.On("SampleDelegations", ctx, int64(3)).
+		Return([]v1dbmodel.DelegationDocument{consistent, inconsistent, consistent}, nil).Once()
+	v1DB.On("FindUnbondingDocumentsByStakingTxHashHexes", ctx, []string{"consistent", "inconsistent"}).
+		Return([]v1dbmodel.UnbondingDocument{
+			{StakingTxHashHex: "consistent", UnbondingTxHashHex: "unbonding", State: v1dbmodel.UnbondingInitialState},
+		}, nil).Once()
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{StakingDb: &config.DbConfig{}},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+
+	verification, err := s.VerifyDelegationsConsistency(ctx, 3)
+	require.Nil(t, err)
+	assert.Equal(t, 2, verification.Checked)
+	assert.Equal(t, []ConsistencyIssuePublic{
+		{StakingTxHashHex: "inconsistent", Issue: ConsistencyIssueMissingField, Details: "finality_provider_pk_hex"},
+		{StakingTxHashHex: "inconsistent", Issue: ConsistencyIssueMissingField, Details: "staking_tx"},
+		{StakingTxHashHex: "inconsistent", Issue: ConsistencyIssueZeroStakingValue},
+		{
+			StakingTxHashHex: "inconsistent", Issue: ConsistencyIssueInvalidTransition,
+			Details: "unbonding delegation has no unbonding tx",
+		},
+	}, verification.Issues)
+}
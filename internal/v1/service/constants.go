@@ -0,0 +1,50 @@
+package v1service
+
+import (
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+)
+
+// ConstantPublic is a value of an enumeration the clients map. Terminal is
+// only set for the states and the events of the states.
+type ConstantPublic struct {
+	Value       string `json:"value"`
+	Description string `json:"description"`
+	Terminal    *bool  `json:"terminal,omitempty"`
+}
+
+type ConstantsPublic struct {
+	DelegationStates       []ConstantPublic `json:"delegation_states"`
+	UnbondingAttemptStates []ConstantPublic `json:"unbonding_attempt_states"`
+	// ActivityTypes are the types of the events of the staker event stream
+	ActivityTypes []ConstantPublic `json:"activity_types"`
+	ErrorCodes    []ConstantPublic `json:"error_codes"`
+}
+
+// GetConstants returns the canonical lists of the delegation states,
+// unbonding attempt states, activity types and error codes, built from their
+// registries
+func (s *V1Service) GetConstants() *ConstantsPublic {
+	constants := &ConstantsPublic{}
+	for _, info := range types.RegisteredDelegationStates() {
+		constants.DelegationStates = append(constants.DelegationStates,
+			stateConstant(info.State.ToString(), info.Description, info.Terminal))
+	}
+	for _, info := range v1dbmodel.RegisteredUnbondingStates() {
+		constants.UnbondingAttemptStates = append(constants.UnbondingAttemptStates,
+			stateConstant(info.State, info.Description, info.Terminal))
+	}
+	for _, info := range types.RegisteredLifecycleEventTypes() {
+		constants.ActivityTypes = append(constants.ActivityTypes,
+			stateConstant(string(info.Type), info.Description, info.Terminal))
+	}
+	for _, info := range types.RegisteredErrorCodes() {
+		constants.ErrorCodes = append(constants.ErrorCodes,
+			ConstantPublic{Value: info.Code.String(), Description: info.Description})
+	}
+	return constants
+}
+
+func stateConstant(value, description string, terminal bool) ConstantPublic {
+	return ConstantPublic{Value: value, Description: description, Terminal: &terminal}
+}
@@ -0,0 +1,82 @@
+package v1service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConstants(t *testing.T) {
+	constants := (&V1Service{}).GetConstants()
+
+	terminal := make(map[string]bool)
+	for _, c := range constants.DelegationStates {
+		require.NotNil(t, c.Terminal, c.Value)
+		terminal[c.Value] = *c.Terminal
+	}
+	assert.Len(t, constants.DelegationStates, len(types.RegisteredDelegationStates()))
+	assert.False(t, terminal[types.Active.ToString()])
+	assert.False(t, terminal[types.Unbonded.ToString()])
+	assert.True(t, terminal[types.Withdrawn.ToString()])
+	assert.True(t, terminal[types.Transitioned.ToString()])
+
+	for _, c := range constants.UnbondingAttemptStates {
+		require.NotNil(t, c.Terminal, c.Value)
+	}
+	for _, c := range constants.ErrorCodes {
+		assert.Nil(t, c.Terminal, c.Value)
+	}
+
+	// every state has its activity type, along with the stale stream one
+	require.Len(t, constants.ActivityTypes, len(constants.DelegationStates)+1)
+	for i, c := range constants.ActivityTypes[:len(constants.DelegationStates)] {
+		assert.Equal(t, *constants.DelegationStates[i].Terminal, *c.Terminal, c.Value)
+	}
+	assert.Equal(t, string(types.StreamStaleEvent), constants.ActivityTypes[len(constants.DelegationStates)].Value)
+
+	// error codes have no terminal marker
+	data, err := json.Marshal(constants)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"value":"NOT_FOUND","description":"The requested resource does not exist"}`)
+}
+
+func TestGetConstantsMatchStateMachines(t *testing.T) {
+	s := &V1Service{}
+	constants := s.GetConstants()
+	machines := s.GetStateMachines()
+
+	for _, tc := range []struct {
+		name      string
+		constants []ConstantPublic
+		machine   *StateMachinePublic
+		// sinksMayBeLive is set when a state without outgoing transition can
+		// still lead further, e.g. a sent unbonding tx is only followed by
+		// the delegation unbonding
+		sinksMayBeLive bool
+	}{
+		{"delegation states", constants.DelegationStates, machines.Delegation, false},
+		{"unbonding attempt states", constants.UnbondingAttemptStates, machines.UnbondingAttempt, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			terminal := make(map[string]bool)
+			for _, c := range tc.constants {
+				terminal[c.Value] = *c.Terminal
+			}
+			// the unreachable states have no transition to tell whether they
+			// are terminal
+			for _, state := range tc.machine.States {
+				if !state.Reachable {
+					continue
+				}
+				require.Contains(t, terminal, state.State)
+				if tc.sinksMayBeLive && !terminal[state.State] {
+					continue
+				}
+				assert.Equal(t, state.Terminal, terminal[state.State], state.State)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// NearCovenantExpiryDelegationPublic is a delegation waiting for the covenant
+// signatures of its unbonding tx while its staking timelock is about to
+// expire, after which the unbonding tx can no longer be broadcast
+type NearCovenantExpiryDelegationPublic struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	StakerPkHex           string `json:"staker_pk_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingValue          uint64 `json:"staking_value"`
+	// UnbondingTxHashHex is empty if the unbonding request is not recorded
+	// in the state history of the delegation
+	UnbondingTxHashHex  string `json:"unbonding_tx_hash_hex,omitempty"`
+	StakingExpiryHeight uint64 `json:"staking_expiry_height"`
+	// BlocksRemaining is negative once the staking timelock expired
+	BlocksRemaining int64 `json:"blocks_remaining"`
+}
+
+// GetDelegationsNearCovenantExpiry returns the unbonding requested
+// delegations whose staking timelock expires in less than blocksRemaining
+// blocks from the current BTC tip height
+func (s *V1Service) GetDelegationsNearCovenantExpiry(
+	ctx context.Context, blocksRemaining uint64, paginationKey string,
+) ([]*NearCovenantExpiryDelegationPublic, string, *types.Error) {
+	tipHeight, tipErr := s.btcTipHeight(ctx)
+	if tipErr != nil {
+		return nil, "", tipErr
+	}
+
+	resultMap, err := s.Service.DbClients.V1DBClient.FindUnbondingRequestedDelegationsExpiringBefore(
+		ctx, tipHeight+blocksRemaining, paginationKey,
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations near covenant expiry")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations near covenant expiry")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	delegations := make([]*NearCovenantExpiryDelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		expiryHeight := d.StakingTx.StartHeight + d.StakingTx.TimeLock
+		delegations = append(delegations, &NearCovenantExpiryDelegationPublic{
+			StakingTxHashHex:      d.StakingTxHashHex,
+			StakerPkHex:           d.StakerPkHex,
+			FinalityProviderPkHex: d.FinalityProviderPkHex,
+			StakingValue:          d.StakingValue,
+			UnbondingTxHashHex:    unbondingRequestTxHashHex(d.StateHistory),
+			StakingExpiryHeight:   expiryHeight,
+			BlocksRemaining:       int64(expiryHeight) - int64(tipHeight),
+		})
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+// btcTipHeight returns the latest BTC tip height, or a 503 error while it is
+// not known yet
+func (s *V1Service) btcTipHeight(ctx context.Context) (uint64, *types.Error) {
+	btcInfo, err := s.Service.DbClients.V1DBClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("latest btc info not found")
+			return 0, types.NewErrorWithMsg(
+				http.StatusServiceUnavailable, types.InternalServiceError, "btc tip height is not known yet",
+			)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return 0, types.NewInternalServiceError(err)
+	}
+	return btcInfo.BtcHeight, nil
+}
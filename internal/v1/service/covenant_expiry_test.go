@@ -0,0 +1,94 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDelegationsNearCovenantExpiry(t *testing.T) {
+	ctx := context.Background()
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		return &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+	}
+
+	t.Run("delegations expiring within the blocks remaining from the tip", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: 1000}, nil).Once()
+		// the delegations must expire before the tip height plus 144 blocks
+		v1DB.On("FindUnbondingRequestedDelegationsExpiringBefore", ctx, uint64(1144), "token").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+				Data: []v1dbmodel.DelegationDocument{
+					{
+						StakingTxHashHex:      "closing",
+						StakerPkHex:           "staker",
+						FinalityProviderPkHex: "fp",
+						StakingValue:          50000,
+						State:                 types.UnbondingRequested,
+						StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 900, TimeLock: 150},
+						StateHistory: []v1dbmodel.StateTransition{
+							{ToState: types.Active, TxHashHex: "closing"},
+							{FromState: types.Active, ToState: types.UnbondingRequested, TxHashHex: "unbonding-tx"},
+						},
+					},
+					{
+						StakingTxHashHex: "expired",
+						State:            types.UnbondingRequested,
+						StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 800, TimeLock: 150},
+					},
+				},
+				PaginationToken: "next",
+			}, nil).Once()
+
+		delegations, paginationToken, err := newService(v1DB).GetDelegationsNearCovenantExpiry(ctx, 144, "token")
+		require.Nil(t, err)
+		assert.Equal(t, "next", paginationToken)
+		assert.Equal(t, []*NearCovenantExpiryDelegationPublic{
+			{
+				StakingTxHashHex:      "closing",
+				StakerPkHex:           "staker",
+				FinalityProviderPkHex: "fp",
+				StakingValue:          50000,
+				UnbondingTxHashHex:    "unbonding-tx",
+				StakingExpiryHeight:   1050,
+				BlocksRemaining:       50,
+			},
+			{
+				StakingTxHashHex:    "expired",
+				StakingExpiryHeight: 950,
+				BlocksRemaining:     -50,
+			},
+		}, delegations)
+	})
+
+	t.Run("unknown tip height", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).
+			Return(nil, &db.NotFoundError{Key: "btc_info", Message: "not found"}).Once()
+
+		_, _, err := newService(v1DB).GetDelegationsNearCovenantExpiry(ctx, 144, "")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+	})
+
+	t.Run("invalid pagination token", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: 1000}, nil).Once()
+		v1DB.On("FindUnbondingRequestedDelegationsExpiringBefore", ctx, uint64(1010), "bad").
+			Return(nil, &db.InvalidPaginationTokenError{Message: "Invalid pagination token"}).Once()
+
+		_, _, err := newService(v1DB).GetDelegationsNearCovenantExpiry(ctx, 10, "bad")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, types.InvalidPaginationToken, err.ErrorCode)
+	})
+}
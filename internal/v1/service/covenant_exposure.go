@@ -0,0 +1,65 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+type CovenantExposurePublic struct {
+	CovenantPkHex   string `json:"covenant_pk_hex"`
+	DelegationCount int64  `json:"delegation_count"`
+}
+
+// GetStakerCovenantExposure returns the covenant committee members guarding
+// the active delegations of the staker with the number of delegations each of
+// them protects. Phase-1 delegations do not store their covenant keys, the
+// committee of a delegation is the one of the global params version active at
+// its staking start height.
+func (s *V1Service) GetStakerCovenantExposure(
+	ctx context.Context, stakerPkHex string,
+) ([]*CovenantExposurePublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1StakerCovenantExposureAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	countsByHeight, err := s.Service.DbClients.V1DBClient.CountActiveDelegationsByStartHeight(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count active delegations by start height")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	countsByCovenant := make(map[string]int64)
+	for height, count := range countsByHeight {
+		paramsVersion := s.GetVersionedGlobalParamsByHeight(height)
+		if paramsVersion == nil {
+			log.Ctx(ctx).Warn().Uint64("height", height).
+				Msg("no global params found for the staking height of an active delegation")
+			continue
+		}
+		for _, covenantPk := range paramsVersion.CovenantPks {
+			countsByCovenant[strings.ToLower(covenantPk)] += count
+		}
+	}
+
+	exposure := make([]*CovenantExposurePublic, 0, len(countsByCovenant))
+	for covenantPk, count := range countsByCovenant {
+		exposure = append(exposure, &CovenantExposurePublic{
+			CovenantPkHex:   covenantPk,
+			DelegationCount: count,
+		})
+	}
+	sort.Slice(exposure, func(i, j int) bool {
+		if exposure[i].DelegationCount != exposure[j].DelegationCount {
+			return exposure[i].DelegationCount > exposure[j].DelegationCount
+		}
+		return exposure[i].CovenantPkHex < exposure[j].CovenantPkHex
+	})
+	return exposure, nil
+}
@@ -0,0 +1,39 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStakerCovenantExposure(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		Params: &types.GlobalParams{Versions: []*types.VersionedGlobalParams{
+			{Version: 0, ActivationHeight: 100, CovenantPks: []string{"aa", "bb"}},
+			{Version: 1, ActivationHeight: 200, CovenantPks: []string{"BB", "cc"}},
+		}},
+	}}
+
+	v1DB.On("CountActiveDelegationsByStartHeight", ctx, "staker").Return(map[uint64]int64{
+		150: 2, // version 0 committee
+		250: 1, // version 1 committee
+		50:  1, // before the first version, ignored
+	}, nil).Once()
+
+	exposure, err := s.GetStakerCovenantExposure(ctx, "staker")
+	require.Nil(t, err)
+	assert.Equal(t, []*CovenantExposurePublic{
+		{CovenantPkHex: "bb", DelegationCount: 3},
+		{CovenantPkHex: "aa", DelegationCount: 2},
+		{CovenantPkHex: "cc", DelegationCount: 1},
+	}, exposure)
+}
@@ -0,0 +1,73 @@
+package v1service
+
+import (
+	"context"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// CovenantMissingDelegationPublic is a delegation whose unbonding request never
+// got the covenant signatures
+type CovenantMissingDelegationPublic struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	StakerPkHex           string `json:"staker_pk_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingValue          uint64 `json:"staking_value"`
+	UnbondingTxHashHex    string `json:"unbonding_tx_hash_hex"`
+}
+
+// GetCovenantMissingDelegations returns the delegations still in the
+// unbonding requested state whose unbonding request was inserted more than
+// pendingFor ago and was never processed by the unbonding pipeline, hence has
+// no covenant signatures.
+func (s *V1Service) GetCovenantMissingDelegations(
+	ctx context.Context, pendingFor time.Duration,
+) ([]*CovenantMissingDelegationPublic, *types.Error) {
+	unbondingDocs, err := s.Service.DbClients.V1DBClient.FindUnprocessedUnbondingDocumentsCreatedBefore(
+		ctx, time.Now().Add(-pendingFor),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching unprocessed unbonding documents")
+		return nil, types.NewInternalServiceError(err)
+	}
+	delegations := make([]*CovenantMissingDelegationPublic, 0, len(unbondingDocs))
+	if len(unbondingDocs) == 0 {
+		return delegations, nil
+	}
+
+	stakingTxHashHexes := make([]string, 0, len(unbondingDocs))
+	for _, unbondingDoc := range unbondingDocs {
+		stakingTxHashHexes = append(stakingTxHashHexes, unbondingDoc.StakingTxHashHex)
+	}
+	delegationDocs, err := db.FindInChunks(
+		ctx, stakingTxHashHexes, s.inListChunkSize(), db.InListChunkParallelism,
+		s.Service.DbClients.V1DBClient.FindDelegationsByTxHashHexes,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching delegations")
+		return nil, types.NewInternalServiceError(err)
+	}
+	unbondingRequested := make(map[string]bool, len(delegationDocs))
+	for _, delegation := range delegationDocs {
+		unbondingRequested[delegation.StakingTxHashHex] = delegation.State == types.UnbondingRequested
+	}
+
+	for _, unbondingDoc := range unbondingDocs {
+		// Delegations that moved on, or do not exist, are reported by the
+		// unbonding integrity check instead
+		if !unbondingRequested[unbondingDoc.StakingTxHashHex] {
+			continue
+		}
+		delegations = append(delegations, &CovenantMissingDelegationPublic{
+			StakingTxHashHex:      unbondingDoc.StakingTxHashHex,
+			StakerPkHex:           unbondingDoc.StakerPkHex,
+			FinalityProviderPkHex: unbondingDoc.FinalityPkHex,
+			StakingValue:          unbondingDoc.StakingAmount,
+			UnbondingTxHashHex:    unbondingDoc.UnbondingTxHashHex,
+		})
+	}
+	return delegations, nil
+}
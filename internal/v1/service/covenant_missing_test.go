@@ -0,0 +1,74 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCovenantMissingDelegations(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{StakingDb: &config.DbConfig{}},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+
+	// Only the unbonding documents never processed by the unbonding pipeline
+	// are returned by the db, the ones with covenant signatures were sent and
+	// their delegation moved to unbonding once the unbonding tx was confirmed
+	var before time.Time
+	v1DB.On("FindUnprocessedUnbondingDocumentsCreatedBefore", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			before = args.Get(1).(time.Time)
+		}).
+		Return([]v1dbmodel.UnbondingDocument{
+			{
+				StakingTxHashHex:   "unsigned-tx",
+				UnbondingTxHashHex: "unsigned-unbonding-tx",
+				StakerPkHex:        "staker",
+				FinalityPkHex:      "fp",
+				StakingAmount:      1000,
+				State:              v1dbmodel.UnbondingInitialState,
+			},
+			{
+				StakingTxHashHex:   "signed-tx",
+				UnbondingTxHashHex: "signed-unbonding-tx",
+				State:              v1dbmodel.UnbondingInitialState,
+			},
+		}, nil).Once()
+	v1DB.On("FindDelegationsByTxHashHexes", ctx, []string{"unsigned-tx", "signed-tx"}).
+		Return([]v1dbmodel.DelegationDocument{
+			{StakingTxHashHex: "unsigned-tx", State: types.UnbondingRequested},
+			{StakingTxHashHex: "signed-tx", State: types.Unbonding},
+		}, nil).Once()
+
+	delegations, err := s.GetCovenantMissingDelegations(ctx, 48*time.Hour)
+	require.Nil(t, err)
+	assert.WithinDuration(t, time.Now().Add(-48*time.Hour), before, time.Minute)
+	require.Len(t, delegations, 1)
+	assert.Equal(t, &CovenantMissingDelegationPublic{
+		StakingTxHashHex:      "unsigned-tx",
+		StakerPkHex:           "staker",
+		FinalityProviderPkHex: "fp",
+		StakingValue:          1000,
+		UnbondingTxHashHex:    "unsigned-unbonding-tx",
+	}, delegations[0])
+
+	// all the unbonding requests got their covenant signatures
+	v1DB.On("FindUnprocessedUnbondingDocumentsCreatedBefore", ctx, mock.Anything).
+		Return([]v1dbmodel.UnbondingDocument{}, nil).Once()
+	delegations, err = s.GetCovenantMissingDelegations(ctx, time.Hour)
+	require.Nil(t, err)
+	assert.Empty(t, delegations)
+}
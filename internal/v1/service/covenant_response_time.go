@@ -0,0 +1,94 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// covenantResponseTimeWindow is how far back the unbondings are sampled
+const covenantResponseTimeWindow = 7 * 24 * time.Hour
+
+type CovenantResponseTimePublic struct {
+	P50Seconds  int64 `json:"p50_seconds"`
+	P95Seconds  int64 `json:"p95_seconds"`
+	P99Seconds  int64 `json:"p99_seconds"`
+	SampleCount int   `json:"sample_count"`
+}
+
+// GetCovenantResponseTime returns the percentiles of the time between an
+// unbonding request and its unbonding tx being observed on BTC, over the
+// delegations unbonded in the last 7 days. The unbonding tx can only be
+// broadcast once co-signed by the covenants, so this bounds their response
+// time from above.
+func (s *V1Service) GetCovenantResponseTime(ctx context.Context) (*CovenantResponseTimePublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1CovenantResponseTimeAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	since := time.Now().Add(-covenantResponseTimeWindow).Unix()
+	histories, err := s.Service.DbClients.V1DBClient.FindStateHistoriesUnbondedSince(ctx, since)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find the state histories of the unbonded delegations")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	var responseTimes []int64
+	for _, history := range histories {
+		if responseTime, ok := covenantResponseTime(history, since); ok {
+			responseTimes = append(responseTimes, responseTime)
+		}
+	}
+	return covenantResponseTimeStats(responseTimes), nil
+}
+
+// covenantResponseTime returns the seconds between the latest unbonding
+// request and the transition to unbonding following it. Delegations unbonded
+// before since, or without an unbonding request, are not sampled.
+func covenantResponseTime(history []v1dbmodel.StateTransition, since int64) (int64, bool) {
+	var requestedAt int64
+	requested := false
+	for _, transition := range history {
+		switch transition.ToState {
+		case types.UnbondingRequested:
+			requestedAt = transition.Timestamp
+			requested = true
+		case types.Unbonding:
+			if !requested || transition.Timestamp < since {
+				return 0, false
+			}
+			return transition.Timestamp - requestedAt, true
+		}
+	}
+	return 0, false
+}
+
+// covenantResponseTimeStats computes the nearest rank percentiles of the
+// response times
+func covenantResponseTimeStats(responseTimes []int64) *CovenantResponseTimePublic {
+	stats := &CovenantResponseTimePublic{SampleCount: len(responseTimes)}
+	if len(responseTimes) == 0 {
+		return stats
+	}
+	sort.Slice(responseTimes, func(i, j int) bool { return responseTimes[i] < responseTimes[j] })
+
+	stats.P50Seconds = nearestRankPercentile(responseTimes, 50)
+	stats.P95Seconds = nearestRankPercentile(responseTimes, 95)
+	stats.P99Seconds = nearestRankPercentile(responseTimes, 99)
+	return stats
+}
+
+// nearestRankPercentile returns the p-th percentile of the sorted non empty
+// values
+func nearestRankPercentile(sorted []int64, p int) int64 {
+	// ceil(p * n / 100), as a 1-based rank
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[rank-1]
+}
@@ -0,0 +1,79 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCovenantResponseTime(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+	now := time.Now().Unix()
+	unbondedAfter := func(responseTime int64) []v1dbmodel.StateTransition {
+		return []v1dbmodel.StateTransition{
+			{ToState: types.Active, Timestamp: now - 100000},
+			{FromState: types.Active, ToState: types.UnbondingRequested, Timestamp: now - 3600 - responseTime},
+			{FromState: types.UnbondingRequested, ToState: types.Unbonding, Timestamp: now - 3600},
+		}
+	}
+
+	// response times of 1 to 100 minutes
+	var histories [][]v1dbmodel.StateTransition
+	for minutes := int64(100); minutes >= 1; minutes-- {
+		histories = append(histories, unbondedAfter(minutes*60))
+	}
+	// not sampled: unbonded without a request, and unbonded before the window
+	histories = append(histories,
+		[]v1dbmodel.StateTransition{
+			{ToState: types.Active, Timestamp: now - 7200},
+			{FromState: types.Active, ToState: types.Unbonding, Timestamp: now - 3600},
+		},
+		[]v1dbmodel.StateTransition{
+			{ToState: types.UnbondingRequested, Timestamp: now - 9*24*3600},
+			{FromState: types.UnbondingRequested, ToState: types.Unbonding, Timestamp: now - 8*24*3600},
+		},
+	)
+	v1DB.On("FindStateHistoriesUnbondedSince", ctx, mock.MatchedBy(func(since int64) bool {
+		return since <= now-7*24*3600 && since >= now-7*24*3600-60
+	})).Return(histories, nil).Once()
+
+	stats, err := s.GetCovenantResponseTime(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, &CovenantResponseTimePublic{
+		P50Seconds:  50 * 60,
+		P95Seconds:  95 * 60,
+		P99Seconds:  99 * 60,
+		SampleCount: 100,
+	}, stats)
+}
+
+func TestCovenantResponseTimeStats(t *testing.T) {
+	assert.Equal(t, &CovenantResponseTimePublic{}, covenantResponseTimeStats(nil))
+	assert.Equal(t, &CovenantResponseTimePublic{
+		P50Seconds: 20, P95Seconds: 30, P99Seconds: 30, SampleCount: 3,
+	}, covenantResponseTimeStats([]int64{30, 10, 20}))
+}
+
+func TestCovenantResponseTime(t *testing.T) {
+	// a second unbonding request is measured from the latest one
+	responseTime, ok := covenantResponseTime([]v1dbmodel.StateTransition{
+		{ToState: types.UnbondingRequested, Timestamp: 100},
+		{ToState: types.UnbondingRequested, Timestamp: 200},
+		{ToState: types.Unbonding, Timestamp: 260},
+	}, 0)
+	require.True(t, ok)
+	assert.Equal(t, int64(60), responseTime)
+}
@@ -2,7 +2,10 @@ package v1service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
+	"strings"
 
 	indexerdbclient "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/client"
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
@@ -29,17 +32,68 @@ type DelegationPublic struct {
 	FinalityProviderPkHex   string             `json:"finality_provider_pk_hex"`
 	State                   string             `json:"state"`
 	StakingValue            uint64             `json:"staking_value"`
+	StakingValueBtc         string             `json:"staking_value_btc,omitempty"`
 	StakingTx               *TransactionPublic `json:"staking_tx"`
 	UnbondingTx             *TransactionPublic `json:"unbonding_tx,omitempty"`
 	IsOverflow              bool               `json:"is_overflow"`
 	IsEligibleForTransition bool               `json:"is_eligible_for_transition"`
 	IsSlashed               bool               `json:"is_slashed"`
+	// StakingValueUsdAtTime is null when no price oracle was enabled at the
+	// time the delegation was inserted
+	StakingValueUsdAtTime *float64            `json:"staking_value_usd_at_time"`
+	WithdrawalTx          *WithdrawalTxPublic `json:"withdrawal_tx,omitempty"`
+	// WithdrawalPath is only set once the delegation is withdrawn, either
+	// early_unbonding, natural_expiry or unknown
+	WithdrawalPath string `json:"withdrawal_path,omitempty"`
+	// UnbondingCompletion is only set while the delegation is unbonding
+	UnbondingCompletion *UnbondingCompletionPublic `json:"unbonding_completion,omitempty"`
+	// StateHistory is only set if requested, oldest transition first
+	StateHistory []StateTransitionPublic `json:"state_history,omitempty"`
+
+	stateHistory []v1model.StateTransition
+}
+
+type StateTransitionPublic struct {
+	FromState string `json:"from_state,omitempty"`
+	ToState   string `json:"to_state"`
+	Timestamp string `json:"timestamp"`
+	TxHashHex string `json:"tx_hash_hex,omitempty"`
+}
+
+type WithdrawalTxPublic struct {
+	TxHashHex string `json:"tx_hash_hex"`
+	Height    uint64 `json:"height"`
+	Address   string `json:"address,omitempty"`
+}
+
+// AddFormattedFields fills the BTC denominated amounts next to the satoshi ones
+func (d *DelegationPublic) AddFormattedFields() {
+	d.StakingValueBtc = utils.FormatUnsignedSatoshisAsBtc(d.StakingValue)
+}
+
+// AddStateHistory fills the recorded state transitions of the delegation
+func (d *DelegationPublic) AddStateHistory() {
+	d.StateHistory = make([]StateTransitionPublic, 0, len(d.stateHistory))
+	for _, transition := range d.stateHistory {
+		d.StateHistory = append(d.StateHistory, StateTransitionPublic{
+			FromState: transition.FromState.ToString(),
+			ToState:   transition.ToState.ToString(),
+			Timestamp: utils.ParseTimestampToIsoFormat(transition.Timestamp),
+			TxHashHex: transition.TxHashHex,
+		})
+	}
 }
 
 func (s *V1Service) DelegationsByStakerPk(
 	ctx context.Context, stakerPk string,
 	states []types.DelegationState, pageToken string,
 ) ([]*DelegationPublic, string, *types.Error) {
+	// Wallets give the key x-only or compressed, in either case
+	stakerPk = canonicalStakerPkHex(stakerPk)
+	// A page token comes from a previous page, so the staker has delegations
+	if pageToken == "" && s.stakerFilter.hasNoDelegations(stakerPk) {
+		return []*DelegationPublic{}, "", nil
+	}
 	filter := &v1dbclient.DelegationFilter{}
 	if len(states) > 0 {
 		filter = &v1dbclient.DelegationFilter{
@@ -51,29 +105,81 @@ func (s *V1Service) DelegationsByStakerPk(
 	if err != nil {
 		if db.IsInvalidPaginationTokenError(err) {
 			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations by staker pk")
-			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by staker pk")
 		return nil, "", types.NewInternalServiceError(err)
 	}
-	var delegations []*DelegationPublic = make([]*DelegationPublic, 0, len(resultMap.Data))
+	delegations, typesErr := s.fromDelegationDocuments(ctx, resultMap.Data)
+	if typesErr != nil {
+		return nil, "", typesErr
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+// DelegationsByWithdrawalAddress returns the delegations whose funds were
+// withdrawn to the given BTC address
+func (s *V1Service) DelegationsByWithdrawalAddress(
+	ctx context.Context, address string, pageToken string,
+) ([]*DelegationPublic, string, *types.Error) {
+	resultMap, err := s.Service.DbClients.V1DBClient.FindDelegationsByWithdrawalAddress(ctx, address, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations by withdrawal address")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by withdrawal address")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	delegations, typesErr := s.fromDelegationDocuments(ctx, resultMap.Data)
+	if typesErr != nil {
+		return nil, "", typesErr
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+// DelegationsByStartHeight returns the delegations whose staking tx is
+// included in the BTC block of the given height
+func (s *V1Service) DelegationsByStartHeight(
+	ctx context.Context, height uint64, pageToken string,
+) ([]*DelegationPublic, string, *types.Error) {
+	resultMap, err := s.Service.DbClients.V1DBClient.FindDelegationsByStartHeight(ctx, height, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations by start height")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by start height")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	delegations, typesErr := s.fromDelegationDocuments(ctx, resultMap.Data)
+	if typesErr != nil {
+		return nil, "", typesErr
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+func (s *V1Service) fromDelegationDocuments(
+	ctx context.Context, documents []v1model.DelegationDocument,
+) ([]*DelegationPublic, *types.Error) {
+	var delegations []*DelegationPublic = make([]*DelegationPublic, 0, len(documents))
 	bbnHeight, err := s.Service.DbClients.IndexerDBClient.GetLastProcessedBbnHeight(ctx)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get last processed BBN height")
-		return nil, "", types.NewInternalServiceError(err)
+		return nil, types.NewInternalServiceError(err)
 	}
 
 	// Get list of all finality providers in phase-2
 	transitionedFps, err := s.Service.DbClients.IndexerDBClient.GetFinalityProviders(ctx)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get finality providers")
-		return nil, "", types.NewInternalServiceError(err)
+		return nil, types.NewInternalServiceError(err)
 	}
 
-	for _, d := range resultMap.Data {
+	for _, d := range documents {
 		delegations = append(delegations, s.FromDelegationDocument(&d, bbnHeight, transitionedFps))
 	}
-	return delegations, resultMap.PaginationToken, nil
+	return delegations, nil
 }
 
 // SaveActiveStakingDelegation saves the active staking delegation to the database.
@@ -82,9 +188,11 @@ func (s *V1Service) SaveActiveStakingDelegation(
 	value, startHeight uint64, stakingTimestamp int64, timeLock, stakingOutputIndex uint64,
 	stakingTxHex string, isOverflow bool,
 ) *types.Error {
+	stakerPkHex = canonicalStakerPkHex(stakerPkHex)
 	err := s.Service.DbClients.V1DBClient.SaveActiveStakingDelegation(
 		ctx, txHashHex, stakerPkHex, finalityProviderPkHex, stakingTxHex,
 		value, startHeight, timeLock, stakingOutputIndex, stakingTimestamp, isOverflow,
+		s.stakingValueUsd(ctx, value),
 	)
 	if err != nil {
 		if ok := db.IsDuplicateKeyError(err); ok {
@@ -94,9 +202,37 @@ func (s *V1Service) SaveActiveStakingDelegation(
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to save active staking delegation")
 		return types.NewInternalServiceError(err)
 	}
+	s.stakerFilter.add(stakerPkHex)
 	return nil
 }
 
+// canonicalStakerPkHex returns the canonical form of the staker public key.
+// A key which can not be parsed is kept as given, the handlers reject them
+// and the delegations of the queue events are saved regardless.
+func canonicalStakerPkHex(stakerPkHex string) string {
+	canonicalPkHex, err := utils.CanonicalSchnorrPkHex(stakerPkHex)
+	if err != nil {
+		return stakerPkHex
+	}
+	return canonicalPkHex
+}
+
+// stakingValueUsd converts the staking value to USD using the price oracle.
+// A missing oracle or a failing price lookup never blocks the insertion, the
+// value is left empty instead.
+func (s *V1Service) stakingValueUsd(ctx context.Context, value uint64) *float64 {
+	if s.Service.BtcPriceOracle == nil {
+		return nil
+	}
+	price, err := s.Service.BtcPriceOracle.GetBtcUsdPrice(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to get the BTC price, staking value in USD is not set")
+		return nil
+	}
+	valueUsd := float64(value) / utils.SatoshisPerBtc * price
+	return &valueUsd
+}
+
 func (s *V1Service) IsDelegationPresent(ctx context.Context, txHashHex string) (bool, *types.Error) {
 	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, txHashHex)
 	if err != nil {
@@ -139,21 +275,23 @@ func (s *V1Service) GetDelegation(ctx context.Context, txHashHex string) (*Deleg
 	return s.FromDelegationDocument(delegation, bbnHeight, transitionedFps), nil
 }
 
-func (s *V1Service) CheckStakerHasActiveDelegationByPk(
+// CountStakerActiveDelegationsByPk counts the active delegations of the
+// staker, only the ones that became active after the given timestamp if set
+func (s *V1Service) CountStakerActiveDelegationsByPk(
 	ctx context.Context, stakerPk string, afterTimestamp int64,
-) (bool, *types.Error) {
+) (int64, *types.Error) {
 	filter := &indexerdbclient.DelegationFilter{
 		States:         []indexertypes.DelegationState{indexertypes.StateActive},
 		AfterTimestamp: afterTimestamp,
 	}
-	hasDelegation, err := s.Service.DbClients.IndexerDBClient.CheckDelegationExistByStakerPk(
+	count, err := s.Service.DbClients.IndexerDBClient.CountDelegationsByStakerPk(
 		ctx, stakerPk, filter,
 	)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to check if staker has active delegation")
-		return false, types.NewInternalServiceError(err)
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count the staker active delegations")
+		return 0, types.NewInternalServiceError(err)
 	}
-	return hasDelegation, nil
+	return count, nil
 }
 
 // This method checks if the finality provider is slashed and whether it is in the transitioned list
@@ -214,6 +352,8 @@ func (s *V1Service) FromDelegationDocument(
 		IsOverflow:              d.IsOverflow,
 		IsEligibleForTransition: isFpTransitioned && !isSlashed && s.isEligibleForTransition(d, bbnHeight),
 		IsSlashed:               isSlashed,
+		StakingValueUsdAtTime:   d.StakingValueUsd,
+		stateHistory:            d.StateHistory,
 	}
 
 	// Add unbonding transaction if it exists
@@ -226,5 +366,41 @@ func (s *V1Service) FromDelegationDocument(
 			TimeLock:       d.UnbondingTx.TimeLock,
 		}
 	}
+	if d.WithdrawalTx != nil {
+		delPublic.WithdrawalTx = &WithdrawalTxPublic{
+			TxHashHex: d.WithdrawalTx.TxHashHex,
+			Height:    d.WithdrawalTx.Height,
+			Address:   d.WithdrawalTx.Address,
+		}
+	}
+	if d.State == types.Withdrawn {
+		// The path of the delegations withdrawn before it was recorded is
+		// derived the same way as when backfilling it
+		withdrawalPath := d.WithdrawalPath
+		if withdrawalPath == "" {
+			withdrawalPath = d.DeriveWithdrawalPath()
+		}
+		delPublic.WithdrawalPath = string(withdrawalPath)
+	}
 	return delPublic
 }
+
+// DelegationETag returns the entity tag of the delegation. The state of a
+// delegation only moves forward and the delegation data is set along with the
+// state, hence the state identifies the version of the delegation.
+func DelegationETag(stakingTxHashHex, state string) string {
+	hash := sha256.Sum256([]byte(stakingTxHashHex + ":" + state))
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// matchesETag returns true if the If-Match header value lists the etag or is
+// the "*" wildcard
+func matchesETag(ifMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
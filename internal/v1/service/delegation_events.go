@@ -0,0 +1,31 @@
+package v1service
+
+import (
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/events"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// PublishDelegationStateChange notifies the subscribers of the staker that
+// the delegation transitioned to the given state. It is called once the
+// transition is persisted so that an aborted write is never announced.
+func (s *V1Service) PublishDelegationStateChange(
+	stakingTxHashHex, stakerPkHex string, state types.DelegationState,
+) {
+	if stakerPkHex == "" {
+		return
+	}
+	s.delegationEvents.Publish(events.DelegationStateChange{
+		StakingTxHashHex: stakingTxHashHex,
+		StakerPkHex:      stakerPkHex,
+		State:            state,
+		Timestamp:        time.Now().Unix(),
+	})
+}
+
+// SubscribeDelegationStateChanges returns a subscription to the state changes
+// of the delegations of the staker, the caller has to close it
+func (s *V1Service) SubscribeDelegationStateChanges(stakerPkHex string) *events.Subscription {
+	return s.delegationEvents.Subscribe(stakerPkHex)
+}
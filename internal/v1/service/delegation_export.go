@@ -0,0 +1,87 @@
+package v1service
+
+import (
+	"context"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// DelegationExportPublic is a delegation as exported for the accounting of
+// the staker
+type DelegationExportPublic struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	State                 string `json:"state"`
+	StakingValue          uint64 `json:"staking_value"`
+	StartHeight           uint64 `json:"start_height"`
+	StartTimestamp        string `json:"start_timestamp"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	UnbondingTxHashHex    string `json:"unbonding_tx_hash_hex,omitempty"`
+	WithdrawalTxHashHex   string `json:"withdrawal_tx_hash_hex,omitempty"`
+}
+
+// exportWriteError wraps the errors of the export writer, to tell them apart
+// from the db errors
+type exportWriteError struct {
+	err error
+}
+
+func (e *exportWriteError) Error() string {
+	return e.err.Error()
+}
+
+// ExportStakerDelegations calls write for every delegation of the staker, the
+// most recent first. The delegations are read one at a time so that stakers
+// with many delegations are exported without holding them all in memory.
+func (s *V1Service) ExportStakerDelegations(
+	ctx context.Context, stakerPkHex string, write func(*DelegationExportPublic) error,
+) *types.Error {
+	err := s.Service.DbClients.V1DBClient.IterateDelegationsByStakerPk(
+		ctx, stakerPkHex, func(d *v1dbmodel.DelegationDocument) error {
+			if err := write(s.toDelegationExport(ctx, d)); err != nil {
+				return &exportWriteError{err: err}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if _, ok := err.(*exportWriteError); ok {
+			log.Ctx(ctx).Warn().Err(err).Msg("Failed to write the exported delegations")
+		} else {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to iterate the delegations of the staker")
+		}
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+func (s *V1Service) toDelegationExport(
+	ctx context.Context, d *v1dbmodel.DelegationDocument,
+) *DelegationExportPublic {
+	export := &DelegationExportPublic{
+		StakingTxHashHex:      d.StakingTxHashHex,
+		State:                 d.State.ToString(),
+		StakingValue:          d.StakingValue,
+		StartHeight:           d.StakingTx.StartHeight,
+		StartTimestamp:        utils.ParseTimestampToIsoFormat(d.StakingTx.StartTimestamp),
+		FinalityProviderPkHex: d.FinalityProviderPkHex,
+	}
+	// Only the unbonding tx itself is stored, its hash is derived from it
+	if d.UnbondingTx != nil && d.UnbondingTx.TxHex != "" {
+		unbondingTx, _, err := bbntypes.NewBTCTxFromHex(d.UnbondingTx.TxHex)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("stakingTxHashHex", d.StakingTxHashHex).
+				Msg("Failed to parse the unbonding tx of the exported delegation")
+		} else {
+			export.UnbondingTxHashHex = unbondingTx.TxHash().String()
+		}
+	}
+	if d.WithdrawalTx != nil {
+		export.WithdrawalTxHashHex = d.WithdrawalTx.TxHashHex
+	}
+	return export
+}
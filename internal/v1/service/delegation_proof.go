@@ -0,0 +1,96 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/crypto/merkle"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ProofSiblingLeft  = "left"
+	ProofSiblingRight = "right"
+)
+
+type DelegationProofStepPublic struct {
+	HashHex  string `json:"hash_hex"`
+	Position string `json:"position"`
+}
+
+// DelegationProofPublic proves the inclusion of a delegation in the Merkle tree
+// built over the staking tx hashes of all the delegations of a staker.
+// The leaves are the raw bytes of the staking tx hashes in hex sorted in
+// ascending order.
+type DelegationProofPublic struct {
+	StakerPkHex      string                      `json:"staker_pk_hex"`
+	StakingTxHashHex string                      `json:"staking_tx_hash_hex"`
+	RootHex          string                      `json:"root_hex"`
+	LeafIndex        int                         `json:"leaf_index"`
+	LeafCount        int                         `json:"leaf_count"`
+	Proof            []DelegationProofStepPublic `json:"proof"`
+}
+
+// GetDelegationProof builds the Merkle tree of all the staking tx hashes of the
+// staker and returns the root and the inclusion proof of the given delegation.
+func (s *V1Service) GetDelegationProof(
+	ctx context.Context, stakerPkHex, stakingTxHashHex string,
+) (*DelegationProofPublic, *types.Error) {
+	txHashes, err := s.Service.DbClients.V1DBClient.FindDelegationTxHashesByStakerPk(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation tx hashes by staker pk")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	leafIndex := -1
+	leaves := make([][]byte, len(txHashes))
+	for i, txHash := range txHashes {
+		leaf, err := hex.DecodeString(txHash)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", txHash).Msg("Invalid staking tx hash stored")
+			return nil, types.NewInternalServiceError(err)
+		}
+		leaves[i] = leaf
+		if strings.EqualFold(txHash, stakingTxHashHex) {
+			leafIndex = i
+		}
+	}
+	if leafIndex == -1 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusNotFound, types.NotFound, "delegation not found for the staker",
+		)
+	}
+
+	tree, err := merkle.New(leaves)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	proof, err := tree.Proof(leafIndex)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	proofPublic := make([]DelegationProofStepPublic, 0, len(proof))
+	for _, step := range proof {
+		position := ProofSiblingRight
+		if step.Left {
+			position = ProofSiblingLeft
+		}
+		proofPublic = append(proofPublic, DelegationProofStepPublic{
+			HashHex:  hex.EncodeToString(step.Hash),
+			Position: position,
+		})
+	}
+
+	return &DelegationProofPublic{
+		StakerPkHex:      stakerPkHex,
+		StakingTxHashHex: txHashes[leafIndex],
+		RootHex:          hex.EncodeToString(tree.Root()),
+		LeafIndex:        leafIndex,
+		LeafCount:        tree.LeafCount(),
+		Proof:            proofPublic,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package v1service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	indexerdbclient "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/client"
+	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveActiveStakingDelegationUsdValue(t *testing.T) {
+	ctx := context.Background()
+
+	save := func(t *testing.T, oracle service.BTCPriceOracle) *float64 {
+		v1DB := mocks.NewV1DBClient(t)
+		var stakingValueUsd *float64
+		v1DB.On("SaveActiveStakingDelegation",
+			ctx, "tx", "staker", "fp", "txhex",
+			uint64(150_000_000), uint64(100), uint64(1000), uint64(0), int64(1700000000), false,
+			mock.Anything,
+		).Run(func(args mock.Arguments) {
+			stakingValueUsd = args.Get(11).(*float64)
+		}).Return(nil).Once()
+
+		s := &V1Service{Service: &service.Service{
+			DbClients:      &dbclients.DbClients{V1DBClient: v1DB},
+			BtcPriceOracle: oracle,
+		}}
+		err := s.SaveActiveStakingDelegation(
+			ctx, "tx", "staker", "fp", 150_000_000, 100, 1700000000, 1000, 0, "txhex", false,
+		)
+		require.Nil(t, err)
+		return stakingValueUsd
+	}
+
+	t.Run("price from the oracle", func(t *testing.T) {
+		oracle := mocks.NewBTCPriceOracle(t)
+		oracle.On("GetBtcUsdPrice", ctx).Return(60000.0, nil).Once()
+
+		stakingValueUsd := save(t, oracle)
+		require.NotNil(t, stakingValueUsd)
+		assert.InDelta(t, 90000.0, *stakingValueUsd, 1e-9)
+	})
+
+	t.Run("oracle disabled", func(t *testing.T) {
+		assert.Nil(t, save(t, nil))
+	})
+
+	t.Run("oracle failure does not block the insertion", func(t *testing.T) {
+		oracle := mocks.NewBTCPriceOracle(t)
+		oracle.On("GetBtcUsdPrice", ctx).Return(0.0, errors.New("unavailable")).Once()
+
+		assert.Nil(t, save(t, oracle))
+	})
+}
+
+func TestFromDelegationDocumentUsdValue(t *testing.T) {
+	s := &V1Service{Service: &service.Service{}}
+	d := &v1model.DelegationDocument{
+		StakingTxHashHex: "tx",
+		StakingValue:     150_000_000,
+		State:            types.Active,
+		StakingTx:        &v1model.TimelockTransaction{},
+	}
+
+	body, err := json.Marshal(s.FromDelegationDocument(d, 0, nil))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"staking_value_usd_at_time":null`)
+
+	valueUsd := 90000.0
+	d.StakingValueUsd = &valueUsd
+	body, err = json.Marshal(s.FromDelegationDocument(d, 0, nil))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"staking_value_usd_at_time":90000`)
+}
+
+func TestCountStakerActiveDelegationsByPk(t *testing.T) {
+	ctx := context.Background()
+	indexerDB := mocks.NewIndexerDBClient(t)
+	indexerDB.On("CountDelegationsByStakerPk", ctx, "staker", &indexerdbclient.DelegationFilter{
+		States:         []indexertypes.DelegationState{indexertypes.StateActive},
+		AfterTimestamp: 1700000000,
+	}).Return(int64(3), nil).Once()
+	indexerDB.On("CountDelegationsByStakerPk", ctx, "failing", mock.Anything).
+		Return(int64(0), errors.New("db unavailable")).Once()
+	s := &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{IndexerDBClient: indexerDB}}}
+
+	count, err := s.CountStakerActiveDelegationsByPk(ctx, "staker", 1700000000)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+
+	_, err = s.CountStakerActiveDelegationsByPk(ctx, "failing", 0)
+	require.NotNil(t, err)
+	assert.Equal(t, types.InternalServiceError, err.ErrorCode)
+}
@@ -0,0 +1,266 @@
+package v1service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// maxDossierSectionItems bounds the items listed in a dossier section, a
+// larger section only comes with its counts
+const maxDossierSectionItems = 100
+
+// Names of the dossier sections, as reported in the section errors
+const (
+	DossierDelegationsSection       = "delegations"
+	DossierUnbondingAttemptsSection = "unbonding_attempts"
+	DossierStatsSection             = "stats"
+	DossierWatchlistSection         = "watchlist"
+)
+
+var errDossierSectionFull = errors.New("dossier section is full")
+
+type CovenantSignatureProgress string
+
+const (
+	// CovenantSignaturesPending is an unbonding request not yet processed by
+	// the unbonding pipeline, its covenant signatures are not collected
+	CovenantSignaturesPending CovenantSignatureProgress = "pending"
+	// CovenantSignaturesCollected is an unbonding tx sent with its covenant
+	// signatures
+	CovenantSignaturesCollected CovenantSignatureProgress = "collected"
+	// CovenantSignaturesAbandoned is an unbonding attempt that can no longer
+	// lead to the delegation being unbonded
+	CovenantSignaturesAbandoned CovenantSignatureProgress = "abandoned"
+)
+
+func covenantSignatureProgress(unbondingState string) CovenantSignatureProgress {
+	switch {
+	case unbondingState == v1dbmodel.UnbondingSendState:
+		return CovenantSignaturesCollected
+	case v1dbmodel.IsTerminalUnbondingState(unbondingState):
+		return CovenantSignaturesAbandoned
+	default:
+		return CovenantSignaturesPending
+	}
+}
+
+type DossierDelegationsPublic struct {
+	Count int64 `json:"count"`
+	// Items is omitted if the staker has more delegations than a section
+	// holds, they can be fetched from the Link instead
+	Items []*DelegationPublic `json:"items,omitempty"`
+	Link  string              `json:"link,omitempty"`
+}
+
+type DossierUnbondingAttemptPublic struct {
+	StakingTxHashHex   string                    `json:"staking_tx_hash_hex"`
+	UnbondingTxHashHex string                    `json:"unbonding_tx_hash_hex"`
+	State              string                    `json:"state"`
+	CovenantSignatures CovenantSignatureProgress `json:"covenant_signatures"`
+}
+
+type DossierUnbondingAttemptsPublic struct {
+	Count                      int64                               `json:"count"`
+	CountsByCovenantSignatures map[CovenantSignatureProgress]int64 `json:"counts_by_covenant_signatures"`
+	// Items is omitted if the staker has more unbonding attempts than a
+	// section holds
+	Items []*DossierUnbondingAttemptPublic `json:"items,omitempty"`
+}
+
+type DossierStatsPublic struct {
+	// Stats is null if the staker has no stats yet
+	Stats     *StakerStatsPublic                `json:"stats"`
+	Lifecycle *DelegationLifecycleSummaryPublic `json:"lifecycle"`
+}
+
+type DossierWatchlistPublic struct {
+	Watching []*WatchlistEntryPublic `json:"watching"`
+	// WatchedByCount is the number of observers watching the staker, the
+	// observers are other stakers and are not disclosed
+	WatchedByCount int64 `json:"watched_by_count"`
+}
+
+// StakerDossierPublic gathers everything known about a staker. A section
+// failing to load is null and listed in the section errors, the other
+// sections are still returned.
+type StakerDossierPublic struct {
+	StakerPkHex       string                          `json:"staker_pk_hex"`
+	Delegations       *DossierDelegationsPublic       `json:"delegations"`
+	UnbondingAttempts *DossierUnbondingAttemptsPublic `json:"unbonding_attempts"`
+	Stats             *DossierStatsPublic             `json:"stats"`
+	Watchlist         *DossierWatchlistPublic         `json:"watchlist"`
+	SectionErrors     map[string]string               `json:"section_errors,omitempty"`
+}
+
+// GetStakerDossier assembles the dossier of the staker, its sections are
+// loaded concurrently
+func (s *V1Service) GetStakerDossier(ctx context.Context, stakerPkHex string) *StakerDossierPublic {
+	dossier := &StakerDossierPublic{StakerPkHex: stakerPkHex}
+	sections := map[string]func(context.Context) *types.Error{
+		DossierDelegationsSection: func(ctx context.Context) (err *types.Error) {
+			dossier.Delegations, err = s.dossierDelegations(ctx, stakerPkHex)
+			return err
+		},
+		DossierUnbondingAttemptsSection: func(ctx context.Context) (err *types.Error) {
+			dossier.UnbondingAttempts, err = s.dossierUnbondingAttempts(ctx, stakerPkHex)
+			return err
+		},
+		DossierStatsSection: func(ctx context.Context) (err *types.Error) {
+			dossier.Stats, err = s.dossierStats(ctx, stakerPkHex)
+			return err
+		},
+		DossierWatchlistSection: func(ctx context.Context) (err *types.Error) {
+			dossier.Watchlist, err = s.dossierWatchlist(ctx, stakerPkHex)
+			return err
+		},
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+	)
+	for name, load := range sections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := load(ctx); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Str("section", name).Msg("failed to load the staker dossier section")
+				mutex.Lock()
+				if dossier.SectionErrors == nil {
+					dossier.SectionErrors = make(map[string]string)
+				}
+				dossier.SectionErrors[name] = "failed to load the " + name
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return dossier
+}
+
+// dossierDelegations lists the delegations of the staker with their state
+// history, the most recent first. Only the count and the link to the export
+// are returned if there are too many of them.
+func (s *V1Service) dossierDelegations(
+	ctx context.Context, stakerPkHex string,
+) (*DossierDelegationsPublic, *types.Error) {
+	var documents []v1dbmodel.DelegationDocument
+	err := s.Service.DbClients.V1DBClient.IterateDelegationsByStakerPk(
+		ctx, stakerPkHex, func(d *v1dbmodel.DelegationDocument) error {
+			if len(documents) == maxDossierSectionItems {
+				return errDossierSectionFull
+			}
+			documents = append(documents, *d)
+			return nil
+		},
+	)
+	if errors.Is(err, errDossierSectionFull) {
+		counts, err := s.Service.DbClients.V1DBClient.CountStakerDelegationsByState(ctx, stakerPkHex)
+		if err != nil {
+			return nil, types.NewInternalServiceError(err)
+		}
+		section := &DossierDelegationsPublic{
+			Link: "/v1/staker/delegations/export?" + url.Values{"staker_btc_pk": {stakerPkHex}}.Encode(),
+		}
+		for _, count := range counts {
+			section.Count += count
+		}
+		return section, nil
+	}
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	if len(documents) == 0 {
+		return &DossierDelegationsPublic{}, nil
+	}
+
+	delegations, typesErr := s.fromDelegationDocuments(ctx, documents)
+	if typesErr != nil {
+		return nil, typesErr
+	}
+	for _, d := range delegations {
+		d.AddStateHistory()
+	}
+	return &DossierDelegationsPublic{
+		Count: int64(len(delegations)),
+		Items: delegations,
+	}, nil
+}
+
+// dossierUnbondingAttempts lists the unbonding requests of the delegations of
+// the staker with the progress of their covenant signatures
+func (s *V1Service) dossierUnbondingAttempts(
+	ctx context.Context, stakerPkHex string,
+) (*DossierUnbondingAttemptsPublic, *types.Error) {
+	stakingTxHashHexes, err := s.Service.DbClients.V1DBClient.FindDelegationTxHashesByStakerPk(ctx, stakerPkHex)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	section := &DossierUnbondingAttemptsPublic{
+		CountsByCovenantSignatures: make(map[CovenantSignatureProgress]int64),
+	}
+	if len(stakingTxHashHexes) == 0 {
+		return section, nil
+	}
+
+	unbondingDocs, err := db.FindInChunks(
+		ctx, stakingTxHashHexes, s.inListChunkSize(), db.InListChunkParallelism,
+		s.Service.DbClients.V1DBClient.FindUnbondingDocumentsByStakingTxHashHexes,
+	)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	var attempts []*DossierUnbondingAttemptPublic
+	for _, unbondingDoc := range unbondingDocs {
+		// The unbonding documents are found by staking tx hash, a document
+		// of another staker for the same delegation is not disclosed
+		if unbondingDoc.StakerPkHex != stakerPkHex {
+			continue
+		}
+		progress := covenantSignatureProgress(unbondingDoc.State)
+		section.CountsByCovenantSignatures[progress]++
+		attempts = append(attempts, &DossierUnbondingAttemptPublic{
+			StakingTxHashHex:   unbondingDoc.StakingTxHashHex,
+			UnbondingTxHashHex: unbondingDoc.UnbondingTxHashHex,
+			State:              unbondingDoc.State,
+			CovenantSignatures: progress,
+		})
+	}
+	section.Count = int64(len(attempts))
+	if len(attempts) <= maxDossierSectionItems {
+		section.Items = attempts
+	}
+	return section, nil
+}
+
+func (s *V1Service) dossierStats(ctx context.Context, stakerPkHex string) (*DossierStatsPublic, *types.Error) {
+	stats, err := s.GetStakerStats(ctx, stakerPkHex)
+	if err != nil {
+		return nil, err
+	}
+	lifecycle, err := s.GetDelegationLifecycleSummary(ctx, stakerPkHex)
+	if err != nil {
+		return nil, err
+	}
+	return &DossierStatsPublic{Stats: stats, Lifecycle: lifecycle}, nil
+}
+
+func (s *V1Service) dossierWatchlist(ctx context.Context, stakerPkHex string) (*DossierWatchlistPublic, *types.Error) {
+	watching, err := s.GetWatchlist(ctx, stakerPkHex)
+	if err != nil {
+		return nil, err
+	}
+	watchedByCount, countErr := s.Service.DbClients.V1DBClient.CountWatchlistObserversByWatchedPk(ctx, stakerPkHex)
+	if countErr != nil {
+		return nil, types.NewInternalServiceError(countErr)
+	}
+	return &DossierWatchlistPublic{Watching: watching, WatchedByCount: watchedByCount}, nil
+}
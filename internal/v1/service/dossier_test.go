@@ -0,0 +1,194 @@
+package v1service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStakerDossier(t *testing.T) {
+	ctx := context.Background()
+	newService := func(v1DB *mocks.V1DBClient, indexerDB *mocks.IndexerDBClient) *V1Service {
+		return &V1Service{Service: &service.Service{
+			Cfg: &config.Config{
+				Server:    &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams},
+				StakingDb: &config.DbConfig{},
+			},
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB},
+		}}
+	}
+	iterateDelegations := func(v1DB *mocks.V1DBClient, docs ...v1dbmodel.DelegationDocument) {
+		v1DB.On("IterateDelegationsByStakerPk", ctx, "staker", mock.Anything).
+			Return(func(_ context.Context, _ string, fn func(*v1dbmodel.DelegationDocument) error) error {
+				for _, d := range docs {
+					if err := fn(&d); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).Once()
+	}
+
+	t.Run("staker with activity in every section", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		indexerDB := mocks.NewIndexerDBClient(t)
+		indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(100), nil)
+		indexerDB.On("GetFinalityProviders", ctx).Return(nil, nil)
+
+		iterateDelegations(v1DB, v1dbmodel.DelegationDocument{
+			StakingTxHashHex:      "tx",
+			StakerPkHex:           "staker",
+			FinalityProviderPkHex: "fp",
+			StakingValue:          50000,
+			State:                 types.UnbondingRequested,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 10, TimeLock: 20},
+			StateHistory: []v1dbmodel.StateTransition{
+				{ToState: types.Active, Timestamp: 1700000000, TxHashHex: "tx"},
+				{FromState: types.Active, ToState: types.UnbondingRequested, Timestamp: 1700000100, TxHashHex: "unbonding-tx"},
+			},
+		})
+		v1DB.On("FindDelegationTxHashesByStakerPk", ctx, "staker").Return([]string{"tx"}, nil).Once()
+		v1DB.On("FindUnbondingDocumentsByStakingTxHashHexes", ctx, []string{"tx"}).
+			Return([]v1dbmodel.UnbondingDocument{
+				{StakerPkHex: "staker", StakingTxHashHex: "tx", UnbondingTxHashHex: "failed-tx", State: v1dbmodel.UnbondingFailedState},
+				{StakerPkHex: "staker", StakingTxHashHex: "tx", UnbondingTxHashHex: "unbonding-tx", State: v1dbmodel.UnbondingInitialState},
+				// another staker's attempt on the same delegation is not disclosed
+				{StakerPkHex: "other", StakingTxHashHex: "tx", UnbondingTxHashHex: "other-tx", State: v1dbmodel.UnbondingSendState},
+			}, nil).Once()
+		v1DB.On("GetStakerStats", ctx, "staker").Return(&v1dbmodel.StakerStatsDocument{
+			StakerPkHex: "staker", ActiveTvl: 0, TotalTvl: 50000, ActiveDelegations: 0, TotalDelegations: 1,
+		}, nil).Once()
+		v1DB.On("CountStakerDelegationsByState", ctx, "staker").
+			Return(map[types.DelegationState]int64{types.UnbondingRequested: 1}, nil).Once()
+		v1DB.On("FindWatchlistByObserverPk", ctx, "staker").Return([]v1dbmodel.WatchlistDocument{
+			{ObserverPkHex: "staker", WatchedPkHex: "watched", AddedAt: 1700000000},
+		}, nil).Once()
+		v1DB.On("CountWatchlistObserversByWatchedPk", ctx, "staker").Return(int64(2), nil).Once()
+
+		dossier := newService(v1DB, indexerDB).GetStakerDossier(ctx, "staker")
+		got, err := json.Marshal(dossier)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"staker_pk_hex": "staker",
+			"delegations": {
+				"count": 1,
+				"items": [{
+					"staking_tx_hash_hex": "tx",
+					"staker_pk_hex": "staker",
+					"finality_provider_pk_hex": "fp",
+					"state": "unbonding_requested",
+					"staking_value": 50000,
+					"staking_tx": {
+						"tx_hex": "",
+						"output_index": 0,
+						"start_timestamp": "1970-01-01T00:00:00Z",
+						"start_height": 10,
+						"timelock": 20
+					},
+					"is_overflow": false,
+					"is_eligible_for_transition": false,
+					"is_slashed": false,
+					"staking_value_usd_at_time": null,
+					"state_history": [
+						{"to_state": "active", "timestamp": "2023-11-14T22:13:20Z", "tx_hash_hex": "tx"},
+						{"from_state": "active", "to_state": "unbonding_requested", "timestamp": "2023-11-14T22:15:00Z", "tx_hash_hex": "unbonding-tx"}
+					]
+				}]
+			},
+			"unbonding_attempts": {
+				"count": 2,
+				"counts_by_covenant_signatures": {"abandoned": 1, "pending": 1},
+				"items": [
+					{"staking_tx_hash_hex": "tx", "unbonding_tx_hash_hex": "failed-tx", "state": "FAILED", "covenant_signatures": "abandoned"},
+					{"staking_tx_hash_hex": "tx", "unbonding_tx_hash_hex": "unbonding-tx", "state": "INSERTED", "covenant_signatures": "pending"}
+				]
+			},
+			"stats": {
+				"stats": {
+					"staker_pk_hex": "staker",
+					"active_tvl": 0,
+					"total_tvl": 50000,
+					"active_delegations": 0,
+					"total_delegations": 1
+				},
+				"lifecycle": {
+					"active": 0,
+					"unbonding_requested": 1,
+					"unbonding": 0,
+					"unbonded": 0,
+					"withdrawn": 0,
+					"transitioned": 0
+				}
+			},
+			"watchlist": {
+				"watching": [{"watched_pk_hex": "watched", "added_at": "2023-11-14T22:13:20Z"}],
+				"watched_by_count": 2
+			}
+		}`, string(got))
+	})
+
+	t.Run("sections larger than the limit only come with their counts", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		docs := make([]v1dbmodel.DelegationDocument, maxDossierSectionItems+1)
+		hashes := make([]string, maxDossierSectionItems+1)
+		unbondingDocs := make([]v1dbmodel.UnbondingDocument, maxDossierSectionItems+1)
+		for i := range docs {
+			hashes[i] = fmt.Sprintf("tx-%d", i)
+			docs[i] = v1dbmodel.DelegationDocument{StakingTxHashHex: hashes[i], StakerPkHex: "staker"}
+			unbondingDocs[i] = v1dbmodel.UnbondingDocument{
+				StakerPkHex: "staker", StakingTxHashHex: hashes[i], State: v1dbmodel.UnbondingSendState,
+			}
+		}
+		iterateDelegations(v1DB, docs...)
+		v1DB.On("CountStakerDelegationsByState", ctx, "staker").
+			Return(map[types.DelegationState]int64{types.Active: 100, types.Withdrawn: 1}, nil).Twice()
+		v1DB.On("FindDelegationTxHashesByStakerPk", ctx, "staker").Return(hashes, nil).Once()
+		v1DB.On("FindUnbondingDocumentsByStakingTxHashHexes", ctx, hashes).Return(unbondingDocs, nil).Once()
+		v1DB.On("GetStakerStats", ctx, "staker").Return(&v1dbmodel.StakerStatsDocument{}, nil).Once()
+		v1DB.On("FindWatchlistByObserverPk", ctx, "staker").Return(nil, nil).Once()
+		v1DB.On("CountWatchlistObserversByWatchedPk", ctx, "staker").Return(int64(0), nil).Once()
+
+		dossier := newService(v1DB, nil).GetStakerDossier(ctx, "staker")
+		assert.Empty(t, dossier.SectionErrors)
+		assert.Equal(t, &DossierDelegationsPublic{
+			Count: 101,
+			Link:  "/v1/staker/delegations/export?staker_btc_pk=staker",
+		}, dossier.Delegations)
+		assert.Equal(t, int64(101), dossier.UnbondingAttempts.Count)
+		assert.Equal(t, map[CovenantSignatureProgress]int64{CovenantSignaturesCollected: 101},
+			dossier.UnbondingAttempts.CountsByCovenantSignatures)
+		assert.Nil(t, dossier.UnbondingAttempts.Items)
+	})
+
+	t.Run("a failing section does not fail the dossier", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		iterateDelegations(v1DB)
+		v1DB.On("FindDelegationTxHashesByStakerPk", ctx, "staker").Return(nil, errors.New("db down")).Once()
+		v1DB.On("GetStakerStats", ctx, "staker").Return(nil, errors.New("db down")).Once()
+		v1DB.On("FindWatchlistByObserverPk", ctx, "staker").Return(nil, nil).Once()
+		v1DB.On("CountWatchlistObserversByWatchedPk", ctx, "staker").Return(int64(1), nil).Once()
+
+		dossier := newService(v1DB, nil).GetStakerDossier(ctx, "staker")
+		assert.Equal(t, map[string]string{
+			DossierUnbondingAttemptsSection: "failed to load the unbonding_attempts",
+			DossierStatsSection:             "failed to load the stats",
+		}, dossier.SectionErrors)
+		assert.Nil(t, dossier.UnbondingAttempts)
+		assert.Nil(t, dossier.Stats)
+		assert.Equal(t, &DossierDelegationsPublic{}, dossier.Delegations)
+		assert.Equal(t, &DossierWatchlistPublic{Watching: []*WatchlistEntryPublic{}, WatchedByCount: 1}, dossier.Watchlist)
+	})
+}
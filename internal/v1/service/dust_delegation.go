@@ -0,0 +1,57 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// DustDelegationPublic is an active delegation staking less than the
+// minimum staking amount of the latest global params version
+type DustDelegationPublic struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	StakerPkHex      string `json:"staker_pk_hex"`
+	StakingValue     uint64 `json:"staking_value"`
+	MinStakingValue  uint64 `json:"min_staking_value"`
+}
+
+// GetDustDelegations returns the active delegations whose staking value is
+// below the minimum staking amount of the latest global params version
+func (s *V1Service) GetDustDelegations(
+	ctx context.Context, paginationKey string,
+) ([]*DustDelegationPublic, string, *types.Error) {
+	versions := s.GlobalParams().Versions
+	if len(versions) == 0 {
+		log.Ctx(ctx).Error().Msg("no global params version to get the minimum staking amount from")
+		return nil, "", types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError, "no global params version",
+		)
+	}
+	minStakingValue := versions[len(versions)-1].MinStakingAmount
+
+	resultMap, err := s.Service.DbClients.V1DBClient.FindActiveDelegationsBelowStakingValue(
+		ctx, minStakingValue, paginationKey,
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching dust delegations")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find dust delegations")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	delegations := make([]*DustDelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegations = append(delegations, &DustDelegationPublic{
+			StakingTxHashHex: d.StakingTxHashHex,
+			StakerPkHex:      d.StakerPkHex,
+			StakingValue:     d.StakingValue,
+			MinStakingValue:  minStakingValue,
+		})
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
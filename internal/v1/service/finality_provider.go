@@ -4,7 +4,9 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	"github.com/rs/zerolog/log"
@@ -42,11 +44,12 @@ type FpParamsPublic struct {
 	BtcPk       string               `json:"btc_pk"`
 }
 
-// GetFinalityProvidersFromGlobalParams returns the finality providers from the global params.
+// GetFinalityProvidersFromGlobalParams returns the finality providers from the global params,
+// followed by the ones registered through the API.
 // Those FP are treated as "active" finality providers.
 func (s *V1Service) GetFinalityProvidersFromGlobalParams() []*FpParamsPublic {
 	var fpDetails []*FpParamsPublic
-	for _, finalityProvider := range s.Service.FinalityProviders {
+	for _, finalityProvider := range s.finalityProviders() {
 		description := &FpDescriptionPublic{
 			Moniker:         finalityProvider.Description.Moniker,
 			Identity:        finalityProvider.Description.Identity,
@@ -63,8 +66,23 @@ func (s *V1Service) GetFinalityProvidersFromGlobalParams() []*FpParamsPublic {
 	return fpDetails
 }
 
+// GetFinalityProvider returns the finality provider, or nil if it is not
+// found. The result is cached until the next stats update or for the
+// configured ttl.
 func (s *V1Service) GetFinalityProvider(
 	ctx context.Context, fpPkHex string,
+) (*FpDetailsPublic, *types.Error) {
+	return cache.GetOrLoad(
+		s.Service.Cache, config.V1FinalityProvidersCacheEndpoint, "pk:"+fpPkHex,
+		s.Service.ResponseCacheTtl(config.V1FinalityProvidersCacheEndpoint),
+		func() (*FpDetailsPublic, *types.Error) {
+			return s.loadFinalityProvider(ctx, fpPkHex)
+		},
+	)
+}
+
+func (s *V1Service) loadFinalityProvider(
+	ctx context.Context, fpPkHex string,
 ) (*FpDetailsPublic, *types.Error) {
 	fpStatsByPks, err :=
 		s.Service.DbClients.V1DBClient.FindFinalityProviderStatsByFinalityProviderPkHex(
@@ -132,7 +150,34 @@ func (s *V1Service) GetFinalityProvider(
 	}, nil
 }
 
+// fpDetailsPage is a page of finality providers as cached
+type fpDetailsPage struct {
+	fps       []*FpDetailsPublic
+	pageToken string
+}
+
+// GetFinalityProviders returns a page of the finality providers sorted by
+// active tvl. The pages are cached until the next stats update or for the
+// configured ttl.
 func (s *V1Service) GetFinalityProviders(ctx context.Context, page string) ([]*FpDetailsPublic, string, *types.Error) {
+	result, err := cache.GetOrLoad(
+		s.Service.Cache, config.V1FinalityProvidersCacheEndpoint, "page:"+page,
+		s.Service.ResponseCacheTtl(config.V1FinalityProvidersCacheEndpoint),
+		func() (*fpDetailsPage, *types.Error) {
+			fps, pageToken, err := s.loadFinalityProviders(ctx, page)
+			if err != nil {
+				return nil, err
+			}
+			return &fpDetailsPage{fps: fps, pageToken: pageToken}, nil
+		},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return result.fps, result.pageToken, nil
+}
+
+func (s *V1Service) loadFinalityProviders(ctx context.Context, page string) ([]*FpDetailsPublic, string, *types.Error) {
 	fpParams := s.GetFinalityProvidersFromGlobalParams()
 	if len(fpParams) == 0 {
 		log.Ctx(ctx).Error().Msg("No finality providers found from global params")
@@ -257,3 +302,55 @@ func buildFallbackFpDetailsPublic(fpParams []*FpParamsPublic) []*FpDetailsPublic
 	}
 	return finalityProviderDetailsPublic
 }
+
+// GetInactiveFinalityProviders returns the finality providers without any
+// active delegation. This includes the finality providers registered in the
+// global params which never received a delegation.
+func (s *V1Service) GetInactiveFinalityProviders(
+	ctx context.Context,
+) ([]*FpDetailsPublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1InactiveFinalityProvidersAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	fpStats, err := s.Service.DbClients.V1DBClient.FindFinalityProvidersWithoutActiveDelegations(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching inactive finality providers")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	fpParams := s.GetFinalityProvidersFromGlobalParams()
+	fpParamsMap := make(map[string]*FpParamsPublic)
+	for _, fp := range fpParams {
+		fpParamsMap[fp.BtcPk] = fp
+	}
+
+	inactiveFps := make([]*FpDetailsPublic, 0, len(fpStats))
+	for _, fp := range fpStats {
+		description := emptyFpDescriptionPublic
+		commission := ""
+		if paramsPublic := fpParamsMap[fp.FinalityProviderPkHex]; paramsPublic != nil {
+			description = paramsPublic.Description
+			commission = paramsPublic.Commission
+		}
+		inactiveFps = append(inactiveFps, &FpDetailsPublic{
+			Description:       description,
+			Commission:        commission,
+			BtcPk:             fp.FinalityProviderPkHex,
+			ActiveTvl:         fp.ActiveTvl,
+			TotalTvl:          fp.TotalTvl,
+			ActiveDelegations: fp.ActiveDelegations,
+			TotalDelegations:  fp.TotalDelegations,
+		})
+	}
+
+	fpsNotInUse, err := s.FindRegisteredFinalityProvidersNotInUse(ctx, fpParams)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality providers not in use")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return append(inactiveFps, fpsNotInUse...), nil
+}
@@ -0,0 +1,49 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInactiveFinalityProviders(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		FinalityProviders: []types.FinalityProviderDetails{
+			{BtcPk: "fp-with-active-delegations", Description: types.FinalityProviderDescription{Moniker: "active"}},
+			{BtcPk: "fp-without-delegations", Description: types.FinalityProviderDescription{Moniker: "unused"}},
+		},
+	}}
+
+	// The lookup pipeline only returns the providers without active delegations
+	v1DB.On("FindFinalityProvidersWithoutActiveDelegations", ctx).Return(
+		[]*v1dbmodel.FinalityProviderStatsDocument{
+			{FinalityProviderPkHex: "fp-with-withdrawn-delegations", TotalTvl: 1000, TotalDelegations: 1},
+		}, nil,
+	).Once()
+	v1DB.On(
+		"FindFinalityProviderStatsByFinalityProviderPkHex", ctx,
+		[]string{"fp-with-active-delegations", "fp-without-delegations"},
+	).Return(
+		[]*v1dbmodel.FinalityProviderStatsDocument{
+			{FinalityProviderPkHex: "fp-with-active-delegations", ActiveTvl: 500, ActiveDelegations: 1},
+		}, nil,
+	).Once()
+
+	fps, err := s.GetInactiveFinalityProviders(ctx)
+	require.Nil(t, err)
+	require.Len(t, fps, 2)
+	assert.Equal(t, "fp-with-withdrawn-delegations", fps[0].BtcPk)
+	assert.Equal(t, int64(1000), fps[0].TotalTvl)
+	assert.Equal(t, "fp-without-delegations", fps[1].BtcPk)
+	assert.Equal(t, "unused", fps[1].Description.Moniker)
+}
@@ -0,0 +1,189 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// maxActiveStakePoints bounds the points of an active stake timeline, the
+// most recent periods are kept
+const maxActiveStakePoints = 720
+
+// ActiveStakeGranularity is the period covered by every point of an active
+// stake timeline
+type ActiveStakeGranularity string
+
+const (
+	ActiveStakeGranularityHour ActiveStakeGranularity = "hour"
+	ActiveStakeGranularityDay  ActiveStakeGranularity = "day"
+	ActiveStakeGranularityWeek ActiveStakeGranularity = "week"
+)
+
+// SupportedActiveStakeGranularities returns the accepted values of the
+// granularity query parameter
+func SupportedActiveStakeGranularities() []ActiveStakeGranularity {
+	return []ActiveStakeGranularity{
+		ActiveStakeGranularityHour,
+		ActiveStakeGranularityDay,
+		ActiveStakeGranularityWeek,
+	}
+}
+
+// periodStart returns the start of the period holding t, in UTC. The weeks
+// start on Monday.
+func (g ActiveStakeGranularity) periodStart(t time.Time) time.Time {
+	t = t.UTC()
+	switch g {
+	case ActiveStakeGranularityHour:
+		return t.Truncate(time.Hour)
+	case ActiveStakeGranularityWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func (g ActiveStakeGranularity) nextPeriod(start time.Time) time.Time {
+	switch g {
+	case ActiveStakeGranularityHour:
+		return start.Add(time.Hour)
+	case ActiveStakeGranularityWeek:
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// ActiveStakePointPublic is the active stake of a finality provider at the
+// end of the period starting at Date, along with the delegations which
+// became active or left the active state during the period
+type ActiveStakePointPublic struct {
+	Date               string `json:"date"`
+	TotalActiveSat     uint64 `json:"total_active_sat"`
+	NewDelegations     int64  `json:"new_delegations"`
+	ExpiredDelegations int64  `json:"expired_delegations"`
+}
+
+// GetFinalityProviderActiveStakeOverTime returns the phase-1 active stake
+// timeline of the finality provider, from the period of its first delegation
+// up to the current one. The timelines are cached for 15 minutes by default.
+func (s *V1Service) GetFinalityProviderActiveStakeOverTime(
+	ctx context.Context, fpPkHex string, granularity ActiveStakeGranularity,
+) ([]*ActiveStakePointPublic, *types.Error) {
+	return cache.GetOrLoad(
+		s.Service.Cache, config.V1FinalityProviderActiveStakeCacheEndpoint,
+		fpPkHex+":"+string(granularity),
+		s.Service.ResponseCacheTtl(config.V1FinalityProviderActiveStakeCacheEndpoint),
+		func() ([]*ActiveStakePointPublic, *types.Error) {
+			return s.loadFinalityProviderActiveStakeOverTime(ctx, fpPkHex, granularity)
+		},
+	)
+}
+
+func (s *V1Service) loadFinalityProviderActiveStakeOverTime(
+	ctx context.Context, fpPkHex string, granularity ActiveStakeGranularity,
+) ([]*ActiveStakePointPublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(
+		ctx, config.V1FinalityProviderActiveStakeAggregation,
+	)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	delegations, err := s.Service.DbClients.V1DBClient.FindFinalityProviderStakeHistories(ctx, fpPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("fpPkHex", fpPkHex).
+			Msg("Failed to find the stake histories of the finality provider")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return activeStakeOverTime(delegations, granularity, time.Now()), nil
+}
+
+// activeStakeEvent is a delegation becoming active, or leaving the active
+// state if expired is set
+type activeStakeEvent struct {
+	timestamp int64
+	value     uint64
+	expired   bool
+}
+
+// activeStakeEvents returns the events of the delegation. A delegation leaves
+// the active state on its first transition out of it, or when its unbonding
+// started if the transition is no longer in its history. A delegation out of
+// the active state without either is left out, as when it left is unknown.
+func activeStakeEvents(delegation *v1dbmodel.DelegationDocument) []activeStakeEvent {
+	if delegation.StakingTx == nil {
+		return nil
+	}
+	activated := activeStakeEvent{timestamp: delegation.StakingTx.StartTimestamp, value: delegation.StakingValue}
+	for _, transition := range delegation.StateHistory {
+		if transition.FromState == types.Active {
+			return []activeStakeEvent{activated, {
+				timestamp: transition.Timestamp, value: delegation.StakingValue, expired: true,
+			}}
+		}
+	}
+	switch {
+	case delegation.State == types.Active:
+		return []activeStakeEvent{activated}
+	case delegation.UnbondingTx != nil:
+		return []activeStakeEvent{activated, {
+			timestamp: delegation.UnbondingTx.StartTimestamp, value: delegation.StakingValue, expired: true,
+		}}
+	default:
+		return nil
+	}
+}
+
+// activeStakeOverTime folds the events of the delegations into one point per
+// period, from the period of the first event up to the one holding now
+func activeStakeOverTime(
+	delegations []*v1dbmodel.DelegationDocument, granularity ActiveStakeGranularity, now time.Time,
+) []*ActiveStakePointPublic {
+	var events []activeStakeEvent
+	for _, delegation := range delegations {
+		events = append(events, activeStakeEvents(delegation)...)
+	}
+	points := []*ActiveStakePointPublic{}
+	if len(events) == 0 {
+		return points
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].timestamp < events[j].timestamp
+	})
+
+	end := granularity.periodStart(now)
+	if last := granularity.periodStart(time.Unix(events[len(events)-1].timestamp, 0)); last.After(end) {
+		end = last
+	}
+	var activeSat uint64
+	next := 0
+	for start := granularity.periodStart(time.Unix(events[0].timestamp, 0)); !start.After(end); start = granularity.nextPeriod(start) {
+		point := &ActiveStakePointPublic{Date: start.Format(time.RFC3339)}
+		periodEnd := granularity.nextPeriod(start).Unix()
+		for ; next < len(events) && events[next].timestamp < periodEnd; next++ {
+			if events[next].expired {
+				activeSat -= min(activeSat, events[next].value)
+				point.ExpiredDelegations++
+			} else {
+				activeSat += events[next].value
+				point.NewDelegations++
+			}
+		}
+		point.TotalActiveSat = activeSat
+		points = append(points, point)
+	}
+	if len(points) > maxActiveStakePoints {
+		points = points[len(points)-maxActiveStakePoints:]
+	}
+	return points
+}
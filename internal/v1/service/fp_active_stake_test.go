@@ -0,0 +1,122 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveStakeOverTime(t *testing.T) {
+	// a Monday
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) int64 {
+		return base.Add(offset).Unix()
+	}
+	date := func(offset time.Duration) string {
+		return base.Add(offset).Format(time.RFC3339)
+	}
+	delegations := []*v1dbmodel.DelegationDocument{
+		{
+			StakingValue: 1000,
+			State:        types.Active,
+			StakingTx:    &v1dbmodel.TimelockTransaction{StartTimestamp: at(10 * time.Minute)},
+		},
+		{
+			StakingValue: 500,
+			State:        types.Withdrawn,
+			StakingTx:    &v1dbmodel.TimelockTransaction{StartTimestamp: at(90 * time.Minute)},
+			StateHistory: []v1dbmodel.StateTransition{
+				{ToState: types.Active, Timestamp: at(100 * time.Minute)},
+				{FromState: types.Active, ToState: types.UnbondingRequested, Timestamp: at(135 * time.Minute)},
+				{FromState: types.UnbondingRequested, ToState: types.Unbonding, Timestamp: at(4 * time.Hour)},
+			},
+		},
+		// recorded before the state history, it left the active state when
+		// its unbonding started
+		{
+			StakingValue: 200,
+			State:        types.Unbonding,
+			StakingTx:    &v1dbmodel.TimelockTransaction{StartTimestamp: at(25 * time.Hour)},
+			UnbondingTx:  &v1dbmodel.TimelockTransaction{StartTimestamp: at(26 * time.Hour)},
+		},
+		// when it left the active state is unknown
+		{
+			StakingValue: 300,
+			State:        types.Withdrawn,
+			StakingTx:    &v1dbmodel.TimelockTransaction{StartTimestamp: at(time.Hour)},
+		},
+	}
+
+	t.Run("hourly", func(t *testing.T) {
+		points := activeStakeOverTime(delegations, ActiveStakeGranularityHour, base.Add(26*time.Hour+30*time.Minute))
+		require.Len(t, points, 27)
+		assert.Equal(t, []*ActiveStakePointPublic{
+			{Date: date(0), TotalActiveSat: 1000, NewDelegations: 1},
+			{Date: date(time.Hour), TotalActiveSat: 1500, NewDelegations: 1},
+			{Date: date(2 * time.Hour), TotalActiveSat: 1000, ExpiredDelegations: 1},
+			{Date: date(3 * time.Hour), TotalActiveSat: 1000},
+		}, points[:4])
+		assert.Equal(t, []*ActiveStakePointPublic{
+			{Date: date(24 * time.Hour), TotalActiveSat: 1000},
+			{Date: date(25 * time.Hour), TotalActiveSat: 1200, NewDelegations: 1},
+			{Date: date(26 * time.Hour), TotalActiveSat: 1000, ExpiredDelegations: 1},
+		}, points[24:])
+	})
+
+	t.Run("daily", func(t *testing.T) {
+		points := activeStakeOverTime(delegations, ActiveStakeGranularityDay, base.Add(53*time.Hour))
+		assert.Equal(t, []*ActiveStakePointPublic{
+			{Date: date(0), TotalActiveSat: 1000, NewDelegations: 2, ExpiredDelegations: 1},
+			{Date: date(24 * time.Hour), TotalActiveSat: 1000, NewDelegations: 1, ExpiredDelegations: 1},
+			{Date: date(48 * time.Hour), TotalActiveSat: 1000},
+		}, points)
+	})
+
+	t.Run("weekly periods start on Monday", func(t *testing.T) {
+		points := activeStakeOverTime(delegations, ActiveStakeGranularityWeek, base.Add(9*24*time.Hour))
+		assert.Equal(t, []*ActiveStakePointPublic{
+			{Date: date(0), TotalActiveSat: 1000, NewDelegations: 3, ExpiredDelegations: 2},
+			{Date: date(7 * 24 * time.Hour), TotalActiveSat: 1000},
+		}, points)
+	})
+
+	t.Run("no delegation", func(t *testing.T) {
+		assert.Empty(t, activeStakeOverTime(nil, ActiveStakeGranularityDay, base))
+	})
+}
+
+func TestGetFinalityProviderActiveStakeOverTimeIsCached(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+	const fpPkHex = "fp"
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		Cache:     cache.NewMemoryCache(),
+	}}
+	v1DB.On("FindFinalityProviderStakeHistories", ctx, fpPkHex).Return([]*v1dbmodel.DelegationDocument{{
+		StakingValue: 1000,
+		State:        types.Active,
+		StakingTx:    &v1dbmodel.TimelockTransaction{StartTimestamp: time.Now().Add(-time.Hour).Unix()},
+	}}, nil).Once()
+
+	for range 2 {
+		points, err := s.GetFinalityProviderActiveStakeOverTime(ctx, fpPkHex, ActiveStakeGranularityHour)
+		require.Nil(t, err)
+		require.NotEmpty(t, points)
+		assert.Equal(t, uint64(1000), points[len(points)-1].TotalActiveSat)
+	}
+	assert.Equal(t, 15*time.Minute, s.ResponseCacheTtl(config.V1FinalityProviderActiveStakeCacheEndpoint))
+}
@@ -0,0 +1,88 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients/fplogo"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// FpLogoPublic is either the URL the client shall be redirected to, or the
+// logo image when the logo proxy is configured.
+type FpLogoPublic struct {
+	RedirectUrl string
+	Logo        *fplogo.FpLogo
+}
+
+type cachedFpLogo struct {
+	logo      *fplogo.FpLogo
+	expiresAt time.Time
+}
+
+type fpLogoCache struct {
+	mu    sync.Mutex
+	logos map[string]cachedFpLogo
+}
+
+func (c *fpLogoCache) get(fpPkHex string, now time.Time) *fplogo.FpLogo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.logos[fpPkHex]
+	if !ok || now.After(cached.expiresAt) {
+		return nil
+	}
+	return cached.logo
+}
+
+func (c *fpLogoCache) set(fpPkHex string, logo *fplogo.FpLogo, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.logos == nil {
+		c.logos = make(map[string]cachedFpLogo)
+	}
+	c.logos[fpPkHex] = cachedFpLogo{logo: logo, expiresAt: expiresAt}
+}
+
+// GetFinalityProviderLogo returns the logo of the finality provider of the
+// finality providers file or registered through the API. Without the logo proxy configured, the
+// client is redirected to the logo URL. Otherwise the image is fetched, checked
+// and cached by the service.
+func (s *V1Service) GetFinalityProviderLogo(
+	ctx context.Context, fpPkHex string,
+) (*FpLogoPublic, *types.Error) {
+	var logoUrl string
+	for _, fp := range s.finalityProviders() {
+		if fp.BtcPk == fpPkHex {
+			logoUrl = fp.LogoUrl
+			break
+		}
+	}
+	if logoUrl == "" {
+		return nil, types.NewErrorWithMsg(
+			http.StatusNotFound, types.NotFound, "finality provider logo not found",
+		)
+	}
+
+	if s.Service.Clients.FpLogo == nil {
+		return &FpLogoPublic{RedirectUrl: logoUrl}, nil
+	}
+
+	now := time.Now()
+	if logo := s.fpLogoCache.get(fpPkHex, now); logo != nil {
+		return &FpLogoPublic{Logo: logo}, nil
+	}
+	logo, err := s.Service.Clients.FpLogo.FetchLogo(ctx, logoUrl)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("fpPkHex", fpPkHex).
+			Msg("failed to fetch finality provider logo")
+		return nil, err
+	}
+	s.fpLogoCache.set(fpPkHex, logo, now.Add(s.Service.Cfg.FpLogoProxy.CacheTtl))
+
+	return &FpLogoPublic{Logo: logo}, nil
+}
@@ -0,0 +1,93 @@
+package v1service
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients/fplogo"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFpPkHex = "03d5a0bb72d71993e435d6c5a70e2aa4db500a62cfaae33c56050deefee64ec0"
+
+func TestGetFinalityProviderLogo(t *testing.T) {
+	ctx := context.Background()
+	pngHeader := []byte("\x89PNG\r\n\x1a\n")
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The announced content type must be ignored in favour of the sniffed one
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	newService := func(proxyCfg *config.FpLogoProxyConfig) *V1Service {
+		cfg := &config.Config{FpLogoProxy: proxyCfg}
+		return &V1Service{Service: &service.Service{
+			Cfg:     cfg,
+			Clients: clients.New(cfg),
+			FinalityProviders: []types.FinalityProviderDetails{
+				{BtcPk: testFpPkHex, LogoUrl: server.URL + "/logo.png"},
+			},
+		}}
+	}
+	proxyCfg := &config.FpLogoProxyConfig{
+		Timeout:      time.Second,
+		MaxSizeBytes: config.MaxFpLogoSizeBytes,
+		CacheTtl:     time.Minute,
+	}
+
+	t.Run("redirect mode", func(t *testing.T) {
+		logo, err := newService(nil).GetFinalityProviderLogo(ctx, testFpPkHex)
+		require.Nil(t, err)
+		assert.Equal(t, server.URL+"/logo.png", logo.RedirectUrl)
+		assert.Nil(t, logo.Logo)
+	})
+
+	t.Run("unknown finality provider", func(t *testing.T) {
+		_, err := newService(nil).GetFinalityProviderLogo(ctx, "unknown")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("proxy mode", func(t *testing.T) {
+		body = append(pngHeader, make([]byte, 1024)...)
+		logo, err := newService(proxyCfg).GetFinalityProviderLogo(ctx, testFpPkHex)
+		require.Nil(t, err)
+		require.NotNil(t, logo.Logo)
+		assert.Equal(t, fplogo.ContentTypePng, logo.Logo.ContentType)
+		assert.Equal(t, body, logo.Logo.Data)
+	})
+
+	t.Run("proxy mode with an oversized image", func(t *testing.T) {
+		body = append(pngHeader, make([]byte, config.MaxFpLogoSizeBytes)...)
+		_, err := newService(proxyCfg).GetFinalityProviderLogo(ctx, testFpPkHex)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+		assert.Contains(t, err.Err.Error(), "exceeds the maximum size")
+	})
+
+	t.Run("proxy mode with a disallowed content type", func(t *testing.T) {
+		body = []byte("<html><body>not an image</body></html>")
+		_, err := newService(proxyCfg).GetFinalityProviderLogo(ctx, testFpPkHex)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+		assert.Contains(t, err.Err.Error(), "is not allowed")
+	})
+}
+
+func TestSniffContentType(t *testing.T) {
+	svg := []byte(`<?xml version="1.0"?><!-- logo --><svg xmlns="http://www.w3.org/2000/svg"></svg>`)
+	assert.Equal(t, fplogo.ContentTypeSvg, fplogo.SniffContentType(svg))
+	assert.Equal(t, fplogo.ContentTypeJpeg, fplogo.SniffContentType([]byte("\xff\xd8\xff\xe0")))
+	assert.NotEqual(t, fplogo.ContentTypeSvg, fplogo.SniffContentType(bytes.Repeat([]byte("a"), 16)))
+}
@@ -0,0 +1,146 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxFpRegistrationsPerBatch is the number of finality providers registered
+// at most at once
+const MaxFpRegistrationsPerBatch = 100
+
+type FpRegistrationFailurePublic struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+type FpBulkRegistrationPublic struct {
+	Registered        int                           `json:"registered"`
+	SkippedDuplicates int                           `json:"skipped_duplicates"`
+	Failed            []FpRegistrationFailurePublic `json:"failed"`
+}
+
+// finalityProviders returns the finality providers of the finality providers
+// file followed by the ones registered through the API
+func (s *V1Service) finalityProviders() []types.FinalityProviderDetails {
+	registered := s.registeredFinalityProviders.Load()
+	if registered == nil {
+		return s.Service.FinalityProviders
+	}
+	return append(slices.Clip(s.Service.FinalityProviders), *registered...)
+}
+
+// RefreshRegisteredFinalityProviders reloads the finality providers
+// registered through the API, by this instance or any other. The finality
+// providers cache is invalidated when new ones were registered.
+func (s *V1Service) RefreshRegisteredFinalityProviders(ctx context.Context) error {
+	registrations, err := s.Service.DbClients.V1DBClient.FindFinalityProviderRegistrations(ctx)
+	if err != nil {
+		return err
+	}
+	registered := make([]types.FinalityProviderDetails, 0, len(registrations))
+	for _, registration := range registrations {
+		registered = append(registered, registration.ToFinalityProviderDetails())
+	}
+	// the registrations are never removed, a new count means new ones
+	previous := s.registeredFinalityProviders.Swap(&registered)
+	if previous != nil && len(*previous) != len(registered) && s.Service.Cache != nil {
+		s.Service.Cache.Invalidate(config.V1FinalityProvidersCacheEndpoint)
+	}
+	return nil
+}
+
+// BulkRegisterFinalityProviders registers the given phase-1 finality
+// providers in a single bulk write. The invalid ones are reported by their
+// index in the batch, the ones already registered, in the finality providers
+// file, through the API or earlier in the batch, are skipped.
+func (s *V1Service) BulkRegisterFinalityProviders(
+	ctx context.Context, providers []types.FinalityProviderDetails,
+) (*FpBulkRegistrationPublic, *types.Error) {
+	if len(providers) == 0 || len(providers) > MaxFpRegistrationsPerBatch {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf(
+				"between 1 and %d finality providers can be registered at once, got %d",
+				MaxFpRegistrationsPerBatch, len(providers),
+			),
+		)
+	}
+
+	result := &FpBulkRegistrationPublic{Failed: []FpRegistrationFailurePublic{}}
+	registeredPks := make(map[string]bool)
+	for _, fp := range s.finalityProviders() {
+		registeredPks[strings.ToLower(fp.BtcPk)] = true
+	}
+	registeredAt := time.Now().Unix()
+	var registrations []*v1dbmodel.FinalityProviderRegistrationDocument
+	for i, fp := range providers {
+		if reason := validateFpRegistration(fp); reason != "" {
+			result.Failed = append(result.Failed, FpRegistrationFailurePublic{Index: i, Reason: reason})
+			continue
+		}
+		fp.BtcPk = strings.ToLower(fp.BtcPk)
+		if registeredPks[fp.BtcPk] {
+			result.SkippedDuplicates++
+			continue
+		}
+		registeredPks[fp.BtcPk] = true
+		registrations = append(registrations, v1dbmodel.NewFinalityProviderRegistrationDocument(fp, registeredAt))
+	}
+	if len(registrations) == 0 {
+		return result, nil
+	}
+
+	duplicates, err := s.Service.DbClients.V1DBClient.SaveFinalityProviderRegistrations(ctx, registrations)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to save the finality provider registrations")
+		return nil, types.NewInternalServiceError(err)
+	}
+	// registered by another request since the finality providers were loaded
+	result.SkippedDuplicates += len(duplicates)
+	result.Registered = len(registrations) - len(duplicates)
+	if result.Registered == 0 {
+		return result, nil
+	}
+
+	// the registrations are stored, they are served once reloaded
+	if err := s.RefreshRegisteredFinalityProviders(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to reload the registered finality providers")
+	}
+	return result, nil
+}
+
+// validateFpRegistration returns why the finality provider can not be
+// registered, or an empty string if it can be
+func validateFpRegistration(fp types.FinalityProviderDetails) string {
+	pkBytes, err := hex.DecodeString(fp.BtcPk)
+	if err != nil || len(pkBytes) != schnorr.PubKeyBytesLen {
+		return "invalid btc_pk: expected a 32 bytes x-only public key in hex"
+	}
+	if _, err := schnorr.ParsePubKey(pkBytes); err != nil {
+		return "invalid btc_pk: not a valid public key"
+	}
+	if fp.Description.Moniker == "" {
+		return "description.moniker is required"
+	}
+	commission, err := strconv.ParseFloat(fp.Commission, 64)
+	if err != nil || commission < 0 || commission > 1 {
+		return "invalid commission: expected a decimal within [0, 1]"
+	}
+	if fp.LogoUrl != "" && !types.IsValidLogoUrl(fp.LogoUrl) {
+		return "invalid logo_url: expected an http(s) url"
+	}
+	return ""
+}
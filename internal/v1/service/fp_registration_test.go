@@ -0,0 +1,199 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newFpRegistration(t *testing.T, moniker string) types.FinalityProviderDetails {
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	return types.FinalityProviderDetails{
+		Description: types.FinalityProviderDescription{Moniker: moniker},
+		Commission:  "0.05",
+		BtcPk:       hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey())),
+		LogoUrl:     "https://example.com/" + moniker + ".png",
+	}
+}
+
+// newFpRegistrationService returns a service on the given finality providers
+// file, whose registrations are kept in the returned map by btc public key
+func newFpRegistrationService(
+	t *testing.T, fileFps []types.FinalityProviderDetails,
+) (*V1Service, map[string]v1dbmodel.FinalityProviderRegistrationDocument) {
+	stored := make(map[string]v1dbmodel.FinalityProviderRegistrationDocument)
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("SaveFinalityProviderRegistrations", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, registrations []*v1dbmodel.FinalityProviderRegistrationDocument) ([]int, error) {
+			var duplicates []int
+			for i, registration := range registrations {
+				if _, ok := stored[registration.BtcPk]; ok {
+					duplicates = append(duplicates, i)
+					continue
+				}
+				stored[registration.BtcPk] = *registration
+			}
+			return duplicates, nil
+		},
+	).Maybe()
+	v1DB.On("FindFinalityProviderRegistrations", mock.Anything).Return(
+		func(context.Context) ([]v1dbmodel.FinalityProviderRegistrationDocument, error) {
+			registrations := make([]v1dbmodel.FinalityProviderRegistrationDocument, 0, len(stored))
+			for _, registration := range stored {
+				registrations = append(registrations, registration)
+			}
+			return registrations, nil
+		},
+	).Maybe()
+
+	s := &V1Service{Service: &service.Service{
+		DbClients:         &dbclients.DbClients{V1DBClient: v1DB},
+		FinalityProviders: fileFps,
+		Cache:             cache.NewMemoryCache(),
+	}}
+	require.NoError(t, s.RefreshRegisteredFinalityProviders(context.Background()))
+	return s, stored
+}
+
+func TestBulkRegisterFinalityProviders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all new finality providers are registered", func(t *testing.T) {
+		fileFp := newFpRegistration(t, "file-fp")
+		s, stored := newFpRegistrationService(t, []types.FinalityProviderDetails{fileFp})
+		s.Service.Cache.Set(config.V1FinalityProvidersCacheEndpoint, "", "cached", time.Minute, 0)
+		providers := []types.FinalityProviderDetails{newFpRegistration(t, "fp-1"), newFpRegistration(t, "fp-2")}
+
+		result, err := s.BulkRegisterFinalityProviders(ctx, providers)
+		require.Nil(t, err)
+		assert.Equal(t, &FpBulkRegistrationPublic{
+			Registered: 2, SkippedDuplicates: 0, Failed: []FpRegistrationFailurePublic{},
+		}, result)
+		require.Len(t, stored, 2)
+		registration := stored[providers[0].BtcPk]
+		assert.Equal(t, providers[0], registration.ToFinalityProviderDetails())
+
+		// the registered finality providers are served along the ones of the file
+		served := s.finalityProviders()
+		require.Len(t, served, 3)
+		assert.Equal(t, fileFp, served[0])
+		assert.ElementsMatch(t, providers, served[1:])
+		assert.Len(t, s.Service.FinalityProviders, 1, "the file finality providers are left as is")
+		_, _, cached := s.Service.Cache.Get(config.V1FinalityProvidersCacheEndpoint, "")
+		assert.False(t, cached, "the finality providers cache is invalidated")
+	})
+
+	t.Run("all duplicate finality providers are skipped", func(t *testing.T) {
+		fileFp := newFpRegistration(t, "file-fp")
+		s, stored := newFpRegistrationService(t, []types.FinalityProviderDetails{fileFp})
+		registered := newFpRegistration(t, "registered-fp")
+		_, err := s.BulkRegisterFinalityProviders(ctx, []types.FinalityProviderDetails{registered})
+		require.Nil(t, err)
+
+		upperCaseFileFp := fileFp
+		upperCaseFileFp.BtcPk = strings.ToUpper(fileFp.BtcPk)
+		result, err := s.BulkRegisterFinalityProviders(ctx, []types.FinalityProviderDetails{
+			upperCaseFileFp, registered,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, &FpBulkRegistrationPublic{
+			Registered: 0, SkippedDuplicates: 2, Failed: []FpRegistrationFailurePublic{},
+		}, result)
+		assert.Len(t, stored, 1)
+	})
+
+	t.Run("mixed batch", func(t *testing.T) {
+		fileFp := newFpRegistration(t, "file-fp")
+		s, stored := newFpRegistrationService(t, []types.FinalityProviderDetails{fileFp})
+		// registered by another instance since the registrations were loaded
+		registeredElsewhere := newFpRegistration(t, "registered-elsewhere")
+		stored[registeredElsewhere.BtcPk] = *v1dbmodel.NewFinalityProviderRegistrationDocument(registeredElsewhere, 1)
+
+		newFp := newFpRegistration(t, "new-fp")
+		withoutMoniker := newFpRegistration(t, "")
+		invalidCommission := newFpRegistration(t, "invalid-commission")
+		invalidCommission.Commission = "1.5"
+		invalidLogoUrl := newFpRegistration(t, "invalid-logo-url")
+		invalidLogoUrl.LogoUrl = "ftp://example.com/logo.png"
+		compressedPk := newFpRegistration(t, "compressed-pk")
+		compressedPk.BtcPk = "02" + compressedPk.BtcPk
+
+		result, err := s.BulkRegisterFinalityProviders(ctx, []types.FinalityProviderDetails{
+			newFp,
+			fileFp,
+			withoutMoniker,
+			newFp,
+			invalidCommission,
+			registeredElsewhere,
+			invalidLogoUrl,
+			compressedPk,
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 1, result.Registered)
+		assert.Equal(t, 3, result.SkippedDuplicates)
+		assert.Equal(t, []FpRegistrationFailurePublic{
+			{Index: 2, Reason: "description.moniker is required"},
+			{Index: 4, Reason: "invalid commission: expected a decimal within [0, 1]"},
+			{Index: 6, Reason: "invalid logo_url: expected an http(s) url"},
+			{Index: 7, Reason: "invalid btc_pk: expected a 32 bytes x-only public key in hex"},
+		}, result.Failed)
+		assert.Len(t, stored, 2)
+		assert.Contains(t, stored, newFp.BtcPk)
+		assert.Len(t, s.finalityProviders(), 3)
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		s, stored := newFpRegistrationService(t, nil)
+		tooMany := make([]types.FinalityProviderDetails, MaxFpRegistrationsPerBatch+1)
+		for i := range tooMany {
+			tooMany[i] = newFpRegistration(t, "fp")
+		}
+
+		for _, providers := range [][]types.FinalityProviderDetails{nil, tooMany} {
+			result, err := s.BulkRegisterFinalityProviders(ctx, providers)
+			require.NotNil(t, err)
+			assert.Nil(t, result)
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		}
+		assert.Empty(t, stored)
+	})
+}
+
+func TestRefreshRegisteredFinalityProviders(t *testing.T) {
+	ctx := context.Background()
+	fileFp := newFpRegistration(t, "file-fp")
+	s, stored := newFpRegistrationService(t, []types.FinalityProviderDetails{fileFp})
+	s.Service.Cache.Set(config.V1FinalityProvidersCacheEndpoint, "", "cached", time.Minute, 0)
+
+	// nothing registered since the last refresh
+	require.NoError(t, s.RefreshRegisteredFinalityProviders(ctx))
+	_, _, cached := s.Service.Cache.Get(config.V1FinalityProvidersCacheEndpoint, "")
+	assert.True(t, cached, "the finality providers cache is kept")
+
+	// registered by another instance
+	registeredElsewhere := newFpRegistration(t, "registered-elsewhere")
+	stored[registeredElsewhere.BtcPk] = *v1dbmodel.NewFinalityProviderRegistrationDocument(registeredElsewhere, 1)
+	assert.Len(t, s.finalityProviders(), 1, "not served before the refresh")
+
+	require.NoError(t, s.RefreshRegisteredFinalityProviders(ctx))
+	assert.Equal(t, []types.FinalityProviderDetails{fileFp, registeredElsewhere}, s.finalityProviders())
+	_, _, cached = s.Service.Cache.Get(config.V1FinalityProvidersCacheEndpoint, "")
+	assert.False(t, cached, "the finality providers cache is invalidated")
+}
@@ -0,0 +1,57 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// FpStakersPublic is a finality provider with the number of distinct stakers
+// actively delegating to it
+type FpStakersPublic struct {
+	Description   *FpDescriptionPublic `json:"description"`
+	Commission    string               `json:"commission"`
+	BtcPk         string               `json:"btc_pk"`
+	UniqueStakers int64                `json:"unique_stakers"`
+}
+
+// GetFinalityProvidersByStakers returns the finality providers with at least
+// minStakers distinct stakers holding an active delegation to them, the most
+// staked first. The finality providers missing from the finality providers
+// file have an empty description.
+func (s *V1Service) GetFinalityProvidersByStakers(
+	ctx context.Context, minStakers int64,
+) ([]*FpStakersPublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1FinalityProvidersByStakersAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	counts, err := s.Service.DbClients.V1DBClient.CountUniqueStakersByFinalityProvider(ctx, minStakers)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count the unique stakers by finality provider")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	fpParamsMap := make(map[string]*FpParamsPublic)
+	for _, fp := range s.GetFinalityProvidersFromGlobalParams() {
+		fpParamsMap[fp.BtcPk] = fp
+	}
+	fps := make([]*FpStakersPublic, 0, len(counts))
+	for _, count := range counts {
+		fp := &FpStakersPublic{
+			Description:   emptyFpDescriptionPublic,
+			BtcPk:         count.FinalityProviderPkHex,
+			UniqueStakers: count.UniqueStakers,
+		}
+		if paramsPublic := fpParamsMap[count.FinalityProviderPkHex]; paramsPublic != nil {
+			fp.Description = paramsPublic.Description
+			fp.Commission = paramsPublic.Commission
+		}
+		fps = append(fps, fp)
+	}
+	return fps, nil
+}
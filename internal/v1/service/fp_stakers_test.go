@@ -0,0 +1,55 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFinalityProvidersByStakers(t *testing.T) {
+	ctx := context.Background()
+	// the counts of the db client, the most staked first
+	counts := []v1dbmodel.FinalityProviderStakersCount{
+		{FinalityProviderPkHex: "fp-200", UniqueStakers: 200},
+		{FinalityProviderPkHex: "unregistered-fp", UniqueStakers: 100},
+		{FinalityProviderPkHex: "fp-50", UniqueStakers: 50},
+	}
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("CountUniqueStakersByFinalityProvider", ctx, int64(100)).
+		Return(func(_ context.Context, minStakers int64) ([]v1dbmodel.FinalityProviderStakersCount, error) {
+			var kept []v1dbmodel.FinalityProviderStakersCount
+			for _, count := range counts {
+				if count.UniqueStakers >= minStakers {
+					kept = append(kept, count)
+				}
+			}
+			return kept, nil
+		}).Once()
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		FinalityProviders: []types.FinalityProviderDetails{
+			{BtcPk: "fp-200", Commission: "0.05", Description: types.FinalityProviderDescription{Moniker: "popular"}},
+			{BtcPk: "fp-50", Commission: "0.10", Description: types.FinalityProviderDescription{Moniker: "niche"}},
+		},
+	}}
+
+	fps, err := s.GetFinalityProvidersByStakers(ctx, 100)
+	require.Nil(t, err)
+	require.Len(t, fps, 2)
+	assert.Equal(t, "fp-200", fps[0].BtcPk)
+	assert.Equal(t, int64(200), fps[0].UniqueStakers)
+	assert.Equal(t, "popular", fps[0].Description.Moniker)
+	assert.Equal(t, "0.05", fps[0].Commission)
+	// the provider at the threshold is kept, without a description as it is
+	// not in the finality providers file
+	assert.Equal(t, "unregistered-fp", fps[1].BtcPk)
+	assert.Equal(t, int64(100), fps[1].UniqueStakers)
+	assert.Equal(t, emptyFpDescriptionPublic, fps[1].Description)
+}
@@ -0,0 +1,52 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// HighValueDelegationPublic is a delegation staking at least the monitored
+// value, whatever its state
+type HighValueDelegationPublic struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	StakerPkHex           string `json:"staker_pk_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingValue          uint64 `json:"staking_value"`
+	State                 string `json:"state"`
+	StartHeight           uint64 `json:"start_height"`
+}
+
+// GetHighValueDelegations returns the delegations whose staking value is at
+// least minStakingValue, the largest first
+func (s *V1Service) GetHighValueDelegations(
+	ctx context.Context, minStakingValue uint64, paginationKey string,
+) ([]*HighValueDelegationPublic, string, *types.Error) {
+	resultMap, err := s.Service.DbClients.V1DBClient.FindDelegationsWithMinStakingValue(
+		ctx, minStakingValue, paginationKey,
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching high value delegations")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find high value delegations")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	delegations := make([]*HighValueDelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegations = append(delegations, &HighValueDelegationPublic{
+			StakingTxHashHex:      d.StakingTxHashHex,
+			StakerPkHex:           d.StakerPkHex,
+			FinalityProviderPkHex: d.FinalityProviderPkHex,
+			StakingValue:          d.StakingValue,
+			State:                 d.State.ToString(),
+			StartHeight:           d.StakingTx.StartHeight,
+		})
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
@@ -0,0 +1,82 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHighValueDelegations(t *testing.T) {
+	ctx := context.Background()
+	const minStakingValue = 10_000_000_000
+	delegations := []v1dbmodel.DelegationDocument{
+		{
+			StakingTxHashHex: "below",
+			StakingValue:     minStakingValue - 1,
+			State:            types.Active,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 100},
+		},
+		{
+			StakingTxHashHex:      "at-threshold",
+			StakerPkHex:           "staker-a",
+			FinalityProviderPkHex: "fp-a",
+			StakingValue:          minStakingValue,
+			State:                 types.Active,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 200},
+		},
+		{
+			StakingTxHashHex:      "above",
+			StakerPkHex:           "staker-b",
+			FinalityProviderPkHex: "fp-b",
+			StakingValue:          3 * minStakingValue,
+			State:                 types.Unbonding,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 300},
+		},
+	}
+	v1DB := mocks.NewV1DBClient(t)
+	// the db client keeps the delegations staking at least the value, the
+	// largest first
+	v1DB.On("FindDelegationsWithMinStakingValue", ctx, uint64(minStakingValue), "").
+		Return(func(
+			_ context.Context, minStakingValue uint64, _ string,
+		) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+			result := &db.DbResultMap[v1dbmodel.DelegationDocument]{PaginationToken: "next"}
+			for i := len(delegations) - 1; i >= 0; i-- {
+				if delegations[i].StakingValue >= minStakingValue {
+					result.Data = append(result.Data, delegations[i])
+				}
+			}
+			return result, nil
+		}).Once()
+	s := &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+
+	highValue, paginationToken, err := s.GetHighValueDelegations(ctx, minStakingValue, "")
+	require.Nil(t, err)
+	assert.Equal(t, "next", paginationToken)
+	assert.Equal(t, []*HighValueDelegationPublic{
+		{
+			StakingTxHashHex:      "above",
+			StakerPkHex:           "staker-b",
+			FinalityProviderPkHex: "fp-b",
+			StakingValue:          3 * minStakingValue,
+			State:                 "unbonding",
+			StartHeight:           300,
+		},
+		{
+			StakingTxHashHex:      "at-threshold",
+			StakerPkHex:           "staker-a",
+			FinalityProviderPkHex: "fp-a",
+			StakingValue:          minStakingValue,
+			State:                 "active",
+			StartHeight:           200,
+		},
+	}, highValue)
+}
@@ -0,0 +1,214 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+type IntegrityIssuePublic struct {
+	IssueType          string `json:"issue_type"`
+	UnbondingTxHashHex string `json:"unbonding_tx_hash_hex"`
+	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
+	DelegationState    string `json:"delegation_state,omitempty"`
+	Invariant          string `json:"invariant,omitempty"`
+	Details            string `json:"details,omitempty"`
+	Quarantined        bool   `json:"quarantined"`
+	DetectedAt         int64  `json:"detected_at"`
+}
+
+// inListChunkSize returns the number of values looked up per $in filter by
+// the internal checks, which split larger lists rather than being rejected
+func (s *V1Service) inListChunkSize() int {
+	return s.Service.Cfg.StakingDb.GetMaxInListSize()
+}
+
+// VerifyUnbondingDocumentsIntegrity checks that the unbonding documents inserted
+// within the lookback period reference an existing delegation, and evaluates
+// the delegation invariants against all the unbonding documents of the
+// delegation. Each offending document is stored as an integrity issue. If
+// quarantine is set, orphaned documents and documents of delegations in an
+// incompatible state are marked as skipped. It returns the number of issues
+// found by type.
+func (s *V1Service) VerifyUnbondingDocumentsIntegrity(
+	ctx context.Context, lookback time.Duration, quarantine bool,
+) (map[v1dbmodel.IntegrityIssueType]int, *types.Error) {
+	now := time.Now()
+	unbondingDocs, err := s.Service.DbClients.V1DBClient.FindUnbondingDocumentsCreatedAfter(
+		ctx, now.Add(-lookback),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching unbonding documents")
+		return nil, types.NewInternalServiceError(err)
+	}
+	issueCounts := make(map[v1dbmodel.IntegrityIssueType]int)
+	if len(unbondingDocs) == 0 {
+		return issueCounts, nil
+	}
+
+	var stakingTxHashHexes []string
+	for _, unbondingDoc := range unbondingDocs {
+		if !slices.Contains(stakingTxHashHexes, unbondingDoc.StakingTxHashHex) {
+			stakingTxHashHexes = append(stakingTxHashHexes, unbondingDoc.StakingTxHashHex)
+		}
+	}
+	delegations, err := db.FindInChunks(
+		ctx, stakingTxHashHexes, s.inListChunkSize(), db.InListChunkParallelism,
+		s.Service.DbClients.V1DBClient.FindDelegationsByTxHashHexes,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching delegations")
+		return nil, types.NewInternalServiceError(err)
+	}
+	delegationStates := make(map[string]types.DelegationState, len(delegations))
+	for _, delegation := range delegations {
+		delegationStates[delegation.StakingTxHashHex] = delegation.State
+	}
+	// The invariants are evaluated against all the unbonding documents of the
+	// delegation, including the ones inserted before the lookback period
+	allUnbondingDocs, err := db.FindInChunks(
+		ctx, stakingTxHashHexes, s.inListChunkSize(), db.InListChunkParallelism,
+		s.Service.DbClients.V1DBClient.FindUnbondingDocumentsByStakingTxHashHexes,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching unbonding documents by staking tx hashes")
+		return nil, types.NewInternalServiceError(err)
+	}
+	unbondingDocsByStakingTx := make(map[string][]v1dbmodel.UnbondingDocument)
+	for _, unbondingDoc := range allUnbondingDocs {
+		unbondingDocsByStakingTx[unbondingDoc.StakingTxHashHex] = append(
+			unbondingDocsByStakingTx[unbondingDoc.StakingTxHashHex], unbondingDoc,
+		)
+	}
+
+	// Invariants are evaluated per delegation, report each violation once
+	reportedViolations := make(map[string]bool)
+	for _, unbondingDoc := range unbondingDocs {
+		// Already quarantined by a previous check
+		if unbondingDoc.State == v1dbmodel.UnbondingSkippedState {
+			continue
+		}
+
+		state, found := delegationStates[unbondingDoc.StakingTxHashHex]
+		if !found {
+			if err := s.saveIntegrityIssue(
+				ctx, v1dbmodel.OrphanedUnbondingIssue, unbondingDoc, state, nil, quarantine, now,
+			); err != nil {
+				return nil, err
+			}
+			issueCounts[v1dbmodel.OrphanedUnbondingIssue]++
+			continue
+		}
+
+		invariants := evaluateDelegationInvariants(
+			state, unbondingDocsByStakingTx[unbondingDoc.StakingTxHashHex],
+		)
+		for _, invariant := range invariants {
+			if invariant.Status != InvariantFail {
+				continue
+			}
+			violationKey := unbondingDoc.StakingTxHashHex + ":" + invariant.Name
+			if reportedViolations[violationKey] {
+				continue
+			}
+			reportedViolations[violationKey] = true
+
+			issueType := v1dbmodel.UnbondingInvariantViolationIssue
+			if invariant.Name == UnbondingDocumentsMatchDelegationState {
+				issueType = v1dbmodel.IncompatibleDelegationStateIssue
+			}
+			if err := s.saveIntegrityIssue(
+				ctx, issueType, unbondingDoc, state, &invariant, quarantine, now,
+			); err != nil {
+				return nil, err
+			}
+			issueCounts[issueType]++
+		}
+	}
+
+	return issueCounts, nil
+}
+
+// saveIntegrityIssue stores the integrity issue found for the unbonding
+// document. Orphaned documents and documents of delegations in an incompatible
+// state are quarantined if requested, the other violations are only reported.
+func (s *V1Service) saveIntegrityIssue(
+	ctx context.Context, issueType v1dbmodel.IntegrityIssueType,
+	unbondingDoc v1dbmodel.UnbondingDocument, state types.DelegationState,
+	invariant *InvariantResultPublic, quarantine bool, now time.Time,
+) *types.Error {
+	quarantined := false
+	if quarantine && issueType != v1dbmodel.UnbondingInvariantViolationIssue &&
+		unbondingDoc.State == v1dbmodel.UnbondingInitialState {
+		err := s.Service.DbClients.V1DBClient.QuarantineUnbondingDocument(
+			ctx, unbondingDoc.UnbondingTxHashHex,
+		)
+		if err != nil && !db.IsNotFoundError(err) {
+			log.Ctx(ctx).Error().Err(err).
+				Str("unbondingTxHashHex", unbondingDoc.UnbondingTxHashHex).
+				Msg("error while quarantining unbonding document")
+			return types.NewInternalServiceError(err)
+		}
+		quarantined = err == nil
+	}
+
+	issue := v1dbmodel.NewIntegrityIssueDocument(
+		issueType, unbondingDoc.UnbondingTxHashHex, unbondingDoc.StakingTxHashHex,
+		state.ToString(), quarantined, now.Unix(),
+	)
+	if invariant != nil {
+		issue.Invariant = invariant.Name
+		issue.Details = invariant.Details
+	}
+	if err := s.Service.DbClients.V1DBClient.SaveIntegrityIssue(ctx, issue); err != nil {
+		log.Ctx(ctx).Error().Err(err).
+			Str("unbondingTxHashHex", unbondingDoc.UnbondingTxHashHex).
+			Msg("error while saving integrity issue")
+		return types.NewInternalServiceError(err)
+	}
+	log.Ctx(ctx).Warn().
+		Str("issueType", string(issueType)).
+		Str("invariant", issue.Invariant).
+		Str("unbondingTxHashHex", unbondingDoc.UnbondingTxHashHex).
+		Str("stakingTxHashHex", unbondingDoc.StakingTxHashHex).
+		Bool("quarantined", quarantined).
+		Msg("unbonding integrity issue detected")
+	return nil
+}
+
+// GetIntegrityIssues returns the integrity issues found by the unbonding
+// integrity check in a paginated way
+func (s *V1Service) GetIntegrityIssues(
+	ctx context.Context, pageToken string,
+) ([]*IntegrityIssuePublic, string, *types.Error) {
+	resultMap, err := s.Service.DbClients.V1DBClient.FindIntegrityIssues(ctx, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching integrity issues")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find integrity issues")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	issues := make([]*IntegrityIssuePublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		issues = append(issues, &IntegrityIssuePublic{
+			IssueType:          string(d.IssueType),
+			UnbondingTxHashHex: d.UnbondingTxHashHex,
+			StakingTxHashHex:   d.StakingTxHashHex,
+			DelegationState:    d.DelegationState,
+			Invariant:          d.Invariant,
+			Details:            d.Details,
+			Quarantined:        d.Quarantined,
+			DetectedAt:         d.DetectedAt,
+		})
+	}
+	return issues, resultMap.PaginationToken, nil
+}
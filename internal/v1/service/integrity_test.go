@@ -0,0 +1,78 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyUnbondingDocumentsIntegrity(t *testing.T) {
+	ctx := context.Background()
+	unbondingDocs := []v1dbmodel.UnbondingDocument{
+		{UnbondingTxHashHex: "orphan-unbonding", StakingTxHashHex: "orphan-staking", State: v1dbmodel.UnbondingInitialState},
+		{UnbondingTxHashHex: "active-unbonding", StakingTxHashHex: "active-staking", State: v1dbmodel.UnbondingInitialState},
+		{UnbondingTxHashHex: "valid-unbonding", StakingTxHashHex: "valid-staking", State: v1dbmodel.UnbondingInitialState},
+	}
+	delegations := []v1dbmodel.DelegationDocument{
+		{StakingTxHashHex: "active-staking", State: types.Active},
+		{StakingTxHashHex: "valid-staking", State: types.UnbondingRequested},
+	}
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		return &V1Service{Service: &service.Service{
+			Cfg:       &config.Config{StakingDb: &config.DbConfig{}},
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		}}
+	}
+
+	for _, quarantine := range []bool{false, true} {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindUnbondingDocumentsCreatedAfter", ctx, mock.Anything).Return(unbondingDocs, nil).Once()
+		v1DB.On(
+			"FindDelegationsByTxHashHexes", ctx,
+			[]string{"orphan-staking", "active-staking", "valid-staking"},
+		).Return(delegations, nil).Once()
+		v1DB.On(
+			"FindUnbondingDocumentsByStakingTxHashHexes", ctx,
+			[]string{"orphan-staking", "active-staking", "valid-staking"},
+		).Return(unbondingDocs, nil).Once()
+		if quarantine {
+			v1DB.On("QuarantineUnbondingDocument", ctx, "orphan-unbonding").Return(nil).Once()
+			v1DB.On("QuarantineUnbondingDocument", ctx, "active-unbonding").Return(nil).Once()
+		}
+
+		var issues []*v1dbmodel.IntegrityIssueDocument
+		v1DB.On("SaveIntegrityIssue", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			issues = append(issues, args.Get(1).(*v1dbmodel.IntegrityIssueDocument))
+		}).Return(nil).Times(3)
+
+		issueCounts, err := newService(v1DB).VerifyUnbondingDocumentsIntegrity(ctx, time.Hour, quarantine)
+		require.Nil(t, err)
+		assert.Equal(t, map[v1dbmodel.IntegrityIssueType]int{
+			v1dbmodel.OrphanedUnbondingIssue:           1,
+			v1dbmodel.IncompatibleDelegationStateIssue: 1,
+			v1dbmodel.UnbondingInvariantViolationIssue: 1,
+		}, issueCounts)
+
+		require.Len(t, issues, 3)
+		assert.Equal(t, v1dbmodel.OrphanedUnbondingIssue, issues[0].IssueType)
+		assert.Equal(t, "orphan-unbonding", issues[0].UnbondingTxHashHex)
+		assert.Equal(t, quarantine, issues[0].Quarantined)
+		// Violations are only reported, never quarantined
+		assert.Equal(t, v1dbmodel.UnbondingInvariantViolationIssue, issues[1].IssueType)
+		assert.Equal(t, ActiveHasNoPendingUnbonding, issues[1].Invariant)
+		assert.False(t, issues[1].Quarantined)
+		assert.Equal(t, v1dbmodel.IncompatibleDelegationStateIssue, issues[2].IssueType)
+		assert.Equal(t, types.Active.ToString(), issues[2].DelegationState)
+		assert.Equal(t, quarantine, issues[2].Quarantined)
+	}
+}
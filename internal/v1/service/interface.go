@@ -2,41 +2,102 @@ package v1service
 
 import (
 	"context"
+	"time"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/events"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 )
 
 type V1ServiceProvider interface {
 	service.SharedServiceProvider
 	// Delegation
 	DelegationsByStakerPk(ctx context.Context, stakerPk string, states []types.DelegationState, pageToken string) ([]*DelegationPublic, string, *types.Error)
+	DelegationsByWithdrawalAddress(ctx context.Context, address string, pageToken string) ([]*DelegationPublic, string, *types.Error)
+	DelegationsByStartHeight(ctx context.Context, height uint64, pageToken string) ([]*DelegationPublic, string, *types.Error)
 	SaveActiveStakingDelegation(ctx context.Context, txHashHex, stakerPkHex, finalityProviderPkHex string, value, startHeight uint64, stakingTimestamp int64, timeLock, stakingOutputIndex uint64, stakingTxHex string, isOverflow bool) *types.Error
 	IsDelegationPresent(ctx context.Context, txHashHex string) (bool, *types.Error)
 	GetDelegation(ctx context.Context, txHashHex string) (*DelegationPublic, *types.Error)
-	CheckStakerHasActiveDelegationByPk(ctx context.Context, stakerPkHex string, afterTimestamp int64) (bool, *types.Error)
+	AddUnbondingCompletions(ctx context.Context, delegations ...*DelegationPublic)
+	CountStakerActiveDelegationsByPk(ctx context.Context, stakerPkHex string, afterTimestamp int64) (int64, *types.Error)
 	TransitionToUnbondingState(ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64) *types.Error
-	TransitionToWithdrawnState(ctx context.Context, txHashHex string) *types.Error
-	UnbondDelegation(ctx context.Context, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex string) *types.Error
-	IsEligibleForUnbondingRequest(ctx context.Context, stakingTxHashHex string) *types.Error
+	ProcessUnbondingDelegation(ctx context.Context, stakingTxHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64) *types.Error
+	TransitionToWithdrawnState(ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction, withdrawalPath v1dbmodel.WithdrawalPath, amount uint64) *types.Error
+	ProcessWithdrawnDelegation(ctx context.Context, stakingTxHashHex, withdrawalTxHashHex, withdrawalAddress string, withdrawalTxHeight uint64) *types.Error
+	UnbondDelegation(ctx context.Context, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex, ifMatch string) *types.Error
+	GetUnbondingEligibility(ctx context.Context, stakingTxHashHex string) (*UnbondingEligibilityDetailsPublic, *types.Error)
+	GetUnbondingEligibilities(ctx context.Context, stakingTxHashHexes []string) ([]*UnbondingEligibilityPublic, *types.Error)
+	GetDelegationProof(ctx context.Context, stakerPkHex, stakingTxHashHex string) (*DelegationProofPublic, *types.Error)
+	GetReactivationCandidates(ctx context.Context, stakerPkHex string, pageToken string) ([]*ReactivationCandidatePublic, string, *types.Error)
+	GetDelegationsNearCovenantExpiry(ctx context.Context, blocksRemaining uint64, paginationKey string) ([]*NearCovenantExpiryDelegationPublic, string, *types.Error)
+	GetProviderChangeCandidates(ctx context.Context, stakerPkHex string, blocksAhead uint64) ([]*ProviderChangeCandidatePublic, *types.Error)
+	GetHighValueDelegations(ctx context.Context, minStakingValue uint64, paginationKey string) ([]*HighValueDelegationPublic, string, *types.Error)
+	GetDelegationsOverdueForWithdrawal(ctx context.Context, blocksOverdue uint64, paginationKey string) ([]*OverdueWithdrawalDelegationPublic, string, *types.Error)
+	GetStakingDurationStats(ctx context.Context) (*StakingDurationStatsPublic, *types.Error)
+	GetDelegationValueConcentration(ctx context.Context) (*ValueConcentrationPublic, *types.Error)
+	GetCoStakers(ctx context.Context, stakingTxHashHex string) (*CoStakersPublic, *types.Error)
+	GetUnbondingSigHash(ctx context.Context, stakingTxHashHex, unbondingTxHex string) (*UnbondingSigHashPublic, *types.Error)
+	GetCovenantResponseTime(ctx context.Context) (*CovenantResponseTimePublic, *types.Error)
+	GetWithdrawalCompletionTime(ctx context.Context) (*WithdrawalCompletionTimePublic, *types.Error)
+	GetProviderChurn(ctx context.Context) ([]*ProviderChurnPublic, *types.Error)
+	GetStateMachines() *StateMachinesPublic
+	PublishDelegationStateChange(stakingTxHashHex, stakerPkHex string, state types.DelegationState)
+	SubscribeDelegationStateChanges(stakerPkHex string) *events.Subscription
+	GetConstants() *ConstantsPublic
 	// Finality Provider
 	GetFinalityProvidersFromGlobalParams() []*FpParamsPublic
 	GetFinalityProvider(ctx context.Context, finalityProviderPkHex string) (*FpDetailsPublic, *types.Error)
 	GetFinalityProviders(ctx context.Context, pageToken string) ([]*FpDetailsPublic, string, *types.Error)
 	FindRegisteredFinalityProvidersNotInUse(ctx context.Context, fpParams []*FpParamsPublic) ([]*FpDetailsPublic, error)
+	GetInactiveFinalityProviders(ctx context.Context) ([]*FpDetailsPublic, *types.Error)
+	GetFinalityProvidersByStakers(ctx context.Context, minStakers int64) ([]*FpStakersPublic, *types.Error)
+	GetFinalityProviderLogo(ctx context.Context, fpPkHex string) (*FpLogoPublic, *types.Error)
+	GetFinalityProviderActiveStakeOverTime(ctx context.Context, fpPkHex string, granularity ActiveStakeGranularity) ([]*ActiveStakePointPublic, *types.Error)
+	BulkRegisterFinalityProviders(ctx context.Context, providers []types.FinalityProviderDetails) (*FpBulkRegistrationPublic, *types.Error)
 	// Global Params
 	GetGlobalParamsPublic() *GlobalParamsPublic
+	GetGlobalParamsPublicByHeight(height uint64) (*GlobalParamsPublic, *types.Error)
 	GetVersionedGlobalParamsByHeight(height uint64) *types.VersionedGlobalParams
+	UpdateGlobalParam(
+		ctx context.Context, paramName string, value, effectiveFromHeight uint64,
+	) (*GlobalParamsPublic, *types.Error)
 	// Staker
 	ProcessAndSaveBtcAddresses(ctx context.Context, stakerPkHex string) *types.Error
+	ExportStakerDelegations(
+		ctx context.Context, stakerPkHex string, write func(*DelegationExportPublic) error,
+	) *types.Error
+	GetDelegationLifecycleSummary(ctx context.Context, stakerPkHex string) (*DelegationLifecycleSummaryPublic, *types.Error)
+	RebuildStakerStats(ctx context.Context, stakerPkHex string) (*StakerStatsRebuildPublic, *types.Error)
+	GetStakerDossier(ctx context.Context, stakerPkHex string) *StakerDossierPublic
+	BuildStakerFilter(ctx context.Context) error
+	GetStakerCovenantExposure(ctx context.Context, stakerPkHex string) ([]*CovenantExposurePublic, *types.Error)
+	GetStakerPortfolioValueUsd(ctx context.Context, stakerPkHex string) (*PortfolioValueUsdPublic, *types.Error)
+	GetStakerActiveBtcAtRisk(ctx context.Context, stakerPkHex string) (*ActiveBtcAtRiskPublic, *types.Error)
 	GetStakerPublicKeysByAddresses(ctx context.Context, addresses []string) (map[string]string, *types.Error)
+	// Watchlist
+	GetWatchlist(ctx context.Context, observerPkHex string) ([]*WatchlistEntryPublic, *types.Error)
+	AddToWatchlist(ctx context.Context, observerPkHex, watchedPkHex, signatureHex string) (*WatchlistEntryPublic, *types.Error)
+	RemoveFromWatchlist(ctx context.Context, observerPkHex, watchedPkHex, signatureHex string) *types.Error
+	WatchlistDelegations(ctx context.Context, observerPkHex string, pageToken string) ([]*DelegationPublic, string, *types.Error)
 	// Stats
 	ProcessStakingStatsCalculation(ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string, state types.DelegationState, amount uint64) *types.Error
 	GetOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error)
 	GetStakerStats(ctx context.Context, stakerPkHex string) (*StakerStatsPublic, *types.Error)
 	GetTopStakersByActiveTvl(ctx context.Context, pageToken string) ([]StakerStatsPublic, string, *types.Error)
+	GetNewStakersPerDay(ctx context.Context) ([]NewStakersPerDayPublic, *types.Error)
 	ProcessBtcInfoStats(ctx context.Context, btcHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64) *types.Error
 	// Timelock
 	ProcessExpireCheck(ctx context.Context, stakingTxHashHex string, startHeight, timelock uint64, txType types.StakingTxType) *types.Error
 	TransitionToUnbondedState(ctx context.Context, stakingType types.StakingTxType, stakingTxHashHex string) *types.Error
+	// Integrity
+	VerifyUnbondingDocumentsIntegrity(ctx context.Context, lookback time.Duration, quarantine bool) (map[v1dbmodel.IntegrityIssueType]int, *types.Error)
+	GetIntegrityIssues(ctx context.Context, pageToken string) ([]*IntegrityIssuePublic, string, *types.Error)
+	VerifyDelegationsConsistency(ctx context.Context, sampleSize int64) (*ConsistencyVerificationPublic, *types.Error)
+	GetDelegationConsistency(ctx context.Context, stakingTxHashHex string) (*DelegationConsistencyPublic, *types.Error)
+	GetOrphanedDelegations(ctx context.Context, pageToken string) ([]*OrphanedDelegationPublic, string, *types.Error)
+	GetDustDelegations(ctx context.Context, paginationKey string) ([]*DustDelegationPublic, string, *types.Error)
+	GetCovenantMissingDelegations(ctx context.Context, pendingFor time.Duration) ([]*CovenantMissingDelegationPublic, *types.Error)
+	// Misbehavior
+	ReportMisbehavior(ctx context.Context, fpPkHex string, evidenceType v1dbmodel.MisbehaviorEvidenceType, evidenceTxHex, reporterPkHex, reporterSigHex string) (*MisbehaviorReportPublic, *types.Error)
 }
@@ -0,0 +1,47 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// DelegationLifecycleSummaryPublic is the number of phase-1 delegations of a
+// staker in every phase of their lifecycle. Phase-1 delegations are only
+// stored once the staking tx is confirmed, hence there is no pending phase.
+type DelegationLifecycleSummaryPublic struct {
+	Active int64 `json:"active"`
+	// UnbondingRequested are the delegations whose unbonding request is
+	// waiting for the covenant signatures and the unbonding tx confirmation
+	UnbondingRequested int64 `json:"unbonding_requested"`
+	// Unbonding are the delegations whose unbonding tx is confirmed and
+	// whose unbonding timelock has not expired yet
+	Unbonding int64 `json:"unbonding"`
+	// Unbonded are the delegations whose timelock expired, they are waiting
+	// to be withdrawn
+	Unbonded     int64 `json:"unbonded"`
+	Withdrawn    int64 `json:"withdrawn"`
+	Transitioned int64 `json:"transitioned"`
+}
+
+// GetDelegationLifecycleSummary returns the number of delegations of the
+// staker in every phase of their lifecycle
+func (s *V1Service) GetDelegationLifecycleSummary(
+	ctx context.Context, stakerPkHex string,
+) (*DelegationLifecycleSummaryPublic, *types.Error) {
+	counts, err := s.Service.DbClients.V1DBClient.CountStakerDelegationsByState(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while counting the staker delegations by state")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return &DelegationLifecycleSummaryPublic{
+		Active:             counts[types.Active],
+		UnbondingRequested: counts[types.UnbondingRequested],
+		Unbonding:          counts[types.Unbonding],
+		Unbonded:           counts[types.Unbonded],
+		Withdrawn:          counts[types.Withdrawn],
+		Transitioned:       counts[types.Transitioned],
+	}, nil
+}
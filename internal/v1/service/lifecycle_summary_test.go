@@ -0,0 +1,55 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDelegationLifecycleSummary(t *testing.T) {
+	ctx := context.Background()
+	const stakerPkHex = "staker"
+
+	// the staker has an active delegation that stays put while another one
+	// walks through its whole lifecycle
+	states := map[string]types.DelegationState{"still": types.Active}
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("CountStakerDelegationsByState", mock.Anything, stakerPkHex).Return(
+		func(context.Context, string) (map[types.DelegationState]int64, error) {
+			counts := make(map[types.DelegationState]int64)
+			for _, state := range states {
+				counts[state]++
+			}
+			return counts, nil
+		},
+	)
+	s := &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+
+	for _, step := range []struct {
+		state    types.DelegationState
+		expected DelegationLifecycleSummaryPublic
+	}{
+		{types.Active, DelegationLifecycleSummaryPublic{Active: 2}},
+		{types.UnbondingRequested, DelegationLifecycleSummaryPublic{Active: 1, UnbondingRequested: 1}},
+		{types.Unbonding, DelegationLifecycleSummaryPublic{Active: 1, Unbonding: 1}},
+		{types.Unbonded, DelegationLifecycleSummaryPublic{Active: 1, Unbonded: 1}},
+		{types.Withdrawn, DelegationLifecycleSummaryPublic{Active: 1, Withdrawn: 1}},
+	} {
+		states["walking"] = step.state
+		summary, err := s.GetDelegationLifecycleSummary(ctx, stakerPkHex)
+		require.Nil(t, err, step.state)
+		assert.Equal(t, step.expected, *summary, step.state)
+	}
+
+	states["still"] = types.Transitioned
+	summary, err := s.GetDelegationLifecycleSummary(ctx, stakerPkHex)
+	require.Nil(t, err)
+	assert.Equal(t, DelegationLifecycleSummaryPublic{Withdrawn: 1, Transitioned: 1}, *summary)
+}
@@ -0,0 +1,92 @@
+package v1service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/rs/zerolog/log"
+)
+
+type MisbehaviorReportPublic struct {
+	ReportId        string `json:"report_id"`
+	EvidenceHashHex string `json:"evidence_hash_hex"`
+	Status          string `json:"status"`
+}
+
+// MisbehaviorEvidenceHash returns the hash signed by the reporter of a
+// misbehavior, the sha256 of the raw evidence tx
+func MisbehaviorEvidenceHash(evidenceTxHex string) ([]byte, error) {
+	evidenceTx, err := hex.DecodeString(evidenceTxHex)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(evidenceTx)
+	return hash[:], nil
+}
+
+// ReportMisbehavior stores a suspected misbehavior of the finality provider
+// for review. The reporter signs the evidence hash with the key of its BTC
+// public key, reports with an invalid signature are rejected. The same report
+// submitted again is accepted without being stored twice.
+func (s *V1Service) ReportMisbehavior(
+	ctx context.Context, fpPkHex string, evidenceType v1dbmodel.MisbehaviorEvidenceType,
+	evidenceTxHex, reporterPkHex, reporterSigHex string,
+) (*MisbehaviorReportPublic, *types.Error) {
+	fp, err := s.GetFinalityProvider(ctx, fpPkHex)
+	if err != nil {
+		return nil, err
+	}
+	if fp == nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusNotFound, types.NotFound, "finality provider not found",
+		)
+	}
+
+	evidenceHash, hashErr := MisbehaviorEvidenceHash(evidenceTxHex)
+	if hashErr != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid evidence tx hex")
+	}
+	reporterPk, pkErr := utils.GetSchnorrPkFromHex(reporterPkHex)
+	if pkErr != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid reporter public key")
+	}
+	sigBytes, sigErr := hex.DecodeString(reporterSigHex)
+	if sigErr != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid reporter signature hex")
+	}
+	sig, sigErr := schnorr.ParseSignature(sigBytes)
+	if sigErr != nil || !sig.Verify(evidenceHash, reporterPk) {
+		log.Ctx(ctx).Warn().
+			Str("fpPkHex", fpPkHex).Str("reporterPkHex", reporterPkHex).
+			Msg("misbehavior report with an invalid reporter signature")
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.InvalidSignature,
+			"reporter signature does not match the evidence hash",
+		)
+	}
+
+	report := v1dbmodel.NewMisbehaviorReportDocument(
+		fpPkHex, evidenceType, evidenceTxHex, hex.EncodeToString(evidenceHash),
+		reporterPkHex, reporterSigHex, time.Now().Unix(),
+	)
+	if err := s.Service.DbClients.V1DBClient.SaveMisbehaviorReport(ctx, report); err != nil {
+		if !db.IsDuplicateKeyError(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to save misbehavior report")
+			return nil, types.NewInternalServiceError(err)
+		}
+		log.Ctx(ctx).Info().Str("reportId", report.Id).Msg("misbehavior report already submitted")
+	}
+	return &MisbehaviorReportPublic{
+		ReportId:        report.Id,
+		EvidenceHashHex: report.EvidenceHashHex,
+		Status:          string(report.Status),
+	}, nil
+}
@@ -0,0 +1,97 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportMisbehavior(t *testing.T) {
+	ctx := context.Background()
+	const evidenceTxHex = "0100000000010203"
+	evidenceHash, err := MisbehaviorEvidenceHash(evidenceTxHex)
+	require.NoError(t, err)
+
+	reporterKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	reporterPkHex := hex.EncodeToString(schnorr.SerializePubKey(reporterKey.PubKey()))
+	sign := func(key *btcec.PrivateKey, hash []byte) string {
+		sig, err := schnorr.Sign(key, hash)
+		require.NoError(t, err)
+		return hex.EncodeToString(sig.Serialize())
+	}
+
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		v1DB.On("FindFinalityProviderStatsByFinalityProviderPkHex", ctx, []string{"fp"}).Return(
+			[]*v1dbmodel.FinalityProviderStatsDocument{{FinalityProviderPkHex: "fp"}}, nil,
+		).Maybe()
+		return &V1Service{Service: &service.Service{
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		}}
+	}
+
+	t.Run("valid reporter signature stores the report pending review", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		var saved *v1dbmodel.MisbehaviorReportDocument
+		v1DB.On("SaveMisbehaviorReport", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*v1dbmodel.MisbehaviorReportDocument)
+		}).Return(nil).Once()
+
+		report, err := newService(v1DB).ReportMisbehavior(
+			ctx, "fp", v1dbmodel.DoubleSignEvidence, evidenceTxHex, reporterPkHex, sign(reporterKey, evidenceHash),
+		)
+		require.Nil(t, err)
+		assert.Equal(t, "pending_review", report.Status)
+		assert.Equal(t, hex.EncodeToString(evidenceHash), report.EvidenceHashHex)
+		require.NotNil(t, saved)
+		assert.Equal(t, report.ReportId, saved.Id)
+		assert.Equal(t, v1dbmodel.DoubleSignEvidence, saved.EvidenceType)
+		assert.Equal(t, reporterPkHex, saved.ReporterPkHex)
+	})
+
+	t.Run("signature of another key is rejected", func(t *testing.T) {
+		otherKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		_, reportErr := newService(mocks.NewV1DBClient(t)).ReportMisbehavior(
+			ctx, "fp", v1dbmodel.DoubleSignEvidence, evidenceTxHex, reporterPkHex, sign(otherKey, evidenceHash),
+		)
+		require.NotNil(t, reportErr)
+		assert.Equal(t, http.StatusBadRequest, reportErr.StatusCode)
+		assert.Equal(t, types.InvalidSignature, reportErr.ErrorCode)
+	})
+
+	t.Run("signature over other evidence is rejected", func(t *testing.T) {
+		otherHash, err := MisbehaviorEvidenceHash("0200")
+		require.NoError(t, err)
+
+		_, reportErr := newService(mocks.NewV1DBClient(t)).ReportMisbehavior(
+			ctx, "fp", v1dbmodel.UnavailabilityEvidence, evidenceTxHex, reporterPkHex, sign(reporterKey, otherHash),
+		)
+		require.NotNil(t, reportErr)
+		assert.Equal(t, types.InvalidSignature, reportErr.ErrorCode)
+	})
+
+	t.Run("unknown finality provider is not found", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindFinalityProviderStatsByFinalityProviderPkHex", ctx, []string{"unknown"}).Return(nil, nil).Once()
+
+		_, reportErr := newService(v1DB).ReportMisbehavior(
+			ctx, "unknown", v1dbmodel.DoubleSignEvidence, evidenceTxHex, reporterPkHex, sign(reporterKey, evidenceHash),
+		)
+		require.NotNil(t, reportErr)
+		assert.Equal(t, http.StatusNotFound, reportErr.StatusCode)
+	})
+}
@@ -0,0 +1,87 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	newStakersPerDayCacheTtl = 10 * time.Minute
+	newStakersDateFormat     = "2006-01-02"
+)
+
+type NewStakersPerDayPublic struct {
+	Date       string `json:"date"`
+	NewStakers int64  `json:"new_stakers"`
+}
+
+type newStakersPerDayCache struct {
+	mu        sync.Mutex
+	days      []NewStakersPerDayPublic
+	expiresAt time.Time
+}
+
+func (c *newStakersPerDayCache) get(now time.Time) ([]NewStakersPerDayPublic, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.days == nil || now.After(c.expiresAt) {
+		return nil, false
+	}
+	return c.days, true
+}
+
+func (c *newStakersPerDayCache) set(days []NewStakersPerDayPublic, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.days = days
+	c.expiresAt = expiresAt
+}
+
+// groupNewStakersByDay counts the first seen timestamps of the stakers per
+// UTC day, sorted by date in ascending order
+func groupNewStakersByDay(firstSeenTimestamps []int64) []NewStakersPerDayPublic {
+	counts := make(map[string]int64)
+	for _, ts := range firstSeenTimestamps {
+		counts[time.Unix(ts, 0).UTC().Format(newStakersDateFormat)]++
+	}
+
+	days := make([]NewStakersPerDayPublic, 0, len(counts))
+	for date, count := range counts {
+		days = append(days, NewStakersPerDayPublic{Date: date, NewStakers: count})
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
+// GetNewStakersPerDay returns the number of stakers whose first delegation
+// started on each day. The result is cached for 10 minutes.
+func (s *V1Service) GetNewStakersPerDay(ctx context.Context) ([]NewStakersPerDayPublic, *types.Error) {
+	now := time.Now()
+	if days, ok := s.newStakersPerDayCache.get(now); ok {
+		return days, nil
+	}
+
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1NewStakersAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	firstSeenTimestamps, err := s.Service.DbClients.V1DBClient.FindStakersFirstSeenTimestamps(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching stakers first seen timestamps")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	days := groupNewStakersByDay(firstSeenTimestamps)
+	s.newStakersPerDayCache.set(days, now.Add(newStakersPerDayCacheTtl))
+	return days, nil
+}
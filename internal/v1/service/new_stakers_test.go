@@ -0,0 +1,46 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNewStakersPerDay(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+
+	day := func(d, h, m, sec int) int64 {
+		return time.Date(2024, time.March, d, h, m, sec, 0, time.UTC).Unix()
+	}
+	// first seen schedule of 6 stakers, the db is queried only once as the
+	// second call is served from the cache
+	v1DB.On("FindStakersFirstSeenTimestamps", ctx).Return([]int64{
+		day(2, 0, 0, 0),
+		day(1, 23, 59, 59),
+		day(1, 0, 0, 0),
+		day(4, 12, 0, 0),
+		day(2, 23, 59, 59),
+		day(2, 8, 30, 0),
+	}, nil).Once()
+
+	expected := []NewStakersPerDayPublic{
+		{Date: "2024-03-01", NewStakers: 2},
+		{Date: "2024-03-02", NewStakers: 3},
+		{Date: "2024-03-04", NewStakers: 1},
+	}
+	for i := 0; i < 2; i++ {
+		days, err := s.GetNewStakersPerDay(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, expected, days)
+	}
+}
@@ -0,0 +1,59 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// OrphanedDelegationPublic is a delegation whose finality provider is not
+// part of the finality providers registry
+type OrphanedDelegationPublic struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	StakerPkHex           string `json:"staker_pk_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingValue          uint64 `json:"staking_value"`
+	State                 string `json:"state"`
+	StartHeight           uint64 `json:"start_height"`
+}
+
+// GetOrphanedDelegations returns the delegations referencing a finality
+// provider that is neither in the finality providers file nor registered
+// through the API
+func (s *V1Service) GetOrphanedDelegations(
+	ctx context.Context, paginationKey string,
+) ([]*OrphanedDelegationPublic, string, *types.Error) {
+	finalityProviders := s.finalityProviders()
+	registeredFpPkHexes := make([]string, 0, len(finalityProviders))
+	for _, fp := range finalityProviders {
+		registeredFpPkHexes = append(registeredFpPkHexes, fp.BtcPk)
+	}
+
+	resultMap, err := s.Service.DbClients.V1DBClient.FindDelegationsWithUnknownFinalityProvider(
+		ctx, registeredFpPkHexes, paginationKey,
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching orphaned delegations")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find orphaned delegations")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	delegations := make([]*OrphanedDelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegations = append(delegations, &OrphanedDelegationPublic{
+			StakingTxHashHex:      d.StakingTxHashHex,
+			StakerPkHex:           d.StakerPkHex,
+			FinalityProviderPkHex: d.FinalityProviderPkHex,
+			StakingValue:          d.StakingValue,
+			State:                 d.State.ToString(),
+			StartHeight:           d.StakingTx.StartHeight,
+		})
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
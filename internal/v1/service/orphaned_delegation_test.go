@@ -0,0 +1,64 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrphanedDelegations(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		FinalityProviders: []types.FinalityProviderDetails{
+			{BtcPk: "registered-fp-1"}, {BtcPk: "registered-fp-2"},
+		},
+	}}
+
+	v1DB.On(
+		"FindDelegationsWithUnknownFinalityProvider", ctx,
+		[]string{"registered-fp-1", "registered-fp-2"}, "",
+	).Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+		Data: []v1dbmodel.DelegationDocument{{
+			StakingTxHashHex:      "orphaned-tx",
+			StakerPkHex:           "staker",
+			FinalityProviderPkHex: "removed-fp",
+			StakingValue:          1000,
+			State:                 types.Active,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 42},
+		}},
+		PaginationToken: "next",
+	}, nil).Once()
+
+	delegations, paginationKey, err := s.GetOrphanedDelegations(ctx, "")
+	require.Nil(t, err)
+	assert.Equal(t, "next", paginationKey)
+	require.Len(t, delegations, 1)
+	assert.Equal(t, &OrphanedDelegationPublic{
+		StakingTxHashHex:      "orphaned-tx",
+		StakerPkHex:           "staker",
+		FinalityProviderPkHex: "removed-fp",
+		StakingValue:          1000,
+		State:                 types.Active.ToString(),
+		StartHeight:           42,
+	}, delegations[0])
+
+	v1DB.On(
+		"FindDelegationsWithUnknownFinalityProvider", ctx,
+		[]string{"registered-fp-1", "registered-fp-2"}, "invalid",
+	).Return(nil, &db.InvalidPaginationTokenError{Message: "Invalid pagination token"}).Once()
+
+	_, _, err = s.GetOrphanedDelegations(ctx, "invalid")
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
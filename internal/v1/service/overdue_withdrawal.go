@@ -0,0 +1,83 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// OverdueWithdrawalDelegationPublic is a delegation whose timelock expired
+// but which is still active or unbonded, its funds were not withdrawn yet
+type OverdueWithdrawalDelegationPublic struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	StakerPkHex           string `json:"staker_pk_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingValue          uint64 `json:"staking_value"`
+	State                 string `json:"state"`
+	StakingExpiryHeight   uint64 `json:"staking_expiry_height"`
+	// UnbondingExpiryHeight is only set for the delegations unbonded early,
+	// their funds are withdrawable from then on
+	UnbondingExpiryHeight uint64 `json:"unbonding_expiry_height,omitempty"`
+	// BlocksOverdue is the number of blocks since the funds are withdrawable
+	BlocksOverdue uint64 `json:"blocks_overdue"`
+}
+
+// GetDelegationsOverdueForWithdrawal returns the active and unbonded
+// delegations whose timelock expired more than blocksOverdue blocks before
+// the current BTC tip height
+func (s *V1Service) GetDelegationsOverdueForWithdrawal(
+	ctx context.Context, blocksOverdue uint64, paginationKey string,
+) ([]*OverdueWithdrawalDelegationPublic, string, *types.Error) {
+	tipHeight, tipErr := s.btcTipHeight(ctx)
+	if tipErr != nil {
+		return nil, "", tipErr
+	}
+	// No timelock can have expired that many blocks before the tip
+	if blocksOverdue >= tipHeight {
+		return []*OverdueWithdrawalDelegationPublic{}, "", nil
+	}
+
+	resultMap, err := s.Service.DbClients.V1DBClient.FindDelegationsOverdueForWithdrawal(
+		ctx, tipHeight-blocksOverdue, paginationKey,
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations overdue for withdrawal")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations overdue for withdrawal")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	delegations := make([]*OverdueWithdrawalDelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegations = append(delegations, overdueWithdrawalDelegation(&d, tipHeight))
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+func overdueWithdrawalDelegation(
+	d *v1dbmodel.DelegationDocument, tipHeight uint64,
+) *OverdueWithdrawalDelegationPublic {
+	delegation := &OverdueWithdrawalDelegationPublic{
+		StakingTxHashHex:      d.StakingTxHashHex,
+		StakerPkHex:           d.StakerPkHex,
+		FinalityProviderPkHex: d.FinalityProviderPkHex,
+		StakingValue:          d.StakingValue,
+		State:                 d.State.ToString(),
+		StakingExpiryHeight:   d.StakingTx.StartHeight + d.StakingTx.TimeLock,
+	}
+	withdrawableHeight := delegation.StakingExpiryHeight
+	if d.UnbondingTx != nil {
+		delegation.UnbondingExpiryHeight = d.UnbondingTx.StartHeight + d.UnbondingTx.TimeLock
+		withdrawableHeight = delegation.UnbondingExpiryHeight
+	}
+	if tipHeight > withdrawableHeight {
+		delegation.BlocksOverdue = tipHeight - withdrawableHeight
+	}
+	return delegation
+}
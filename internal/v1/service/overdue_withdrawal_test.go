@@ -0,0 +1,130 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDelegationsOverdueForWithdrawal(t *testing.T) {
+	ctx := context.Background()
+	const tipHeight = 1000
+	delegations := []v1dbmodel.DelegationDocument{
+		{
+			StakingTxHashHex: "at-expiry",
+			State:            types.Active,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 900, TimeLock: 100},
+		},
+		{
+			StakingTxHashHex: "one-block-past",
+			State:            types.Unbonded,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 899, TimeLock: 100},
+		},
+		{
+			StakingTxHashHex: "well-past",
+			State:            types.Active,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 100},
+		},
+		{
+			StakingTxHashHex: "unbonded-early",
+			State:            types.Unbonded,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 900, TimeLock: 5000},
+			UnbondingTx:      &v1dbmodel.TimelockTransaction{StartHeight: 980, TimeLock: 10},
+		},
+	}
+	// findOverdue applies the filter of the db client on the delegations
+	findOverdue := func(
+		_ context.Context, expiryHeight uint64, _ string,
+	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+		result := &db.DbResultMap[v1dbmodel.DelegationDocument]{}
+		for _, d := range delegations {
+			withdrawableHeight := d.StakingTx.StartHeight + d.StakingTx.TimeLock
+			if d.UnbondingTx != nil {
+				withdrawableHeight = d.UnbondingTx.StartHeight + d.UnbondingTx.TimeLock
+			}
+			if withdrawableHeight < expiryHeight {
+				result.Data = append(result.Data, d)
+			}
+		}
+		return result, nil
+	}
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		return &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+	}
+
+	t.Run("delegations expired before the tip", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: tipHeight}, nil).Once()
+		v1DB.On("FindDelegationsOverdueForWithdrawal", ctx, uint64(tipHeight), "").
+			Return(findOverdue).Once()
+
+		overdue, _, err := newService(v1DB).GetDelegationsOverdueForWithdrawal(ctx, 0, "")
+		require.Nil(t, err)
+		assert.Equal(t, []*OverdueWithdrawalDelegationPublic{
+			{
+				StakingTxHashHex:    "one-block-past",
+				State:               "unbonded",
+				StakingExpiryHeight: 999,
+				BlocksOverdue:       1,
+			},
+			{
+				StakingTxHashHex:    "well-past",
+				State:               "active",
+				StakingExpiryHeight: 200,
+				BlocksOverdue:       800,
+			},
+			{
+				StakingTxHashHex:      "unbonded-early",
+				State:                 "unbonded",
+				StakingExpiryHeight:   5900,
+				UnbondingExpiryHeight: 990,
+				BlocksOverdue:         10,
+			},
+		}, overdue)
+	})
+
+	t.Run("delegations expired more than the blocks overdue before the tip", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: tipHeight}, nil).Once()
+		v1DB.On("FindDelegationsOverdueForWithdrawal", ctx, uint64(tipHeight-1), "").
+			Return(findOverdue).Once()
+
+		overdue, _, err := newService(v1DB).GetDelegationsOverdueForWithdrawal(ctx, 1, "")
+		require.Nil(t, err)
+		var txHashes []string
+		for _, d := range overdue {
+			txHashes = append(txHashes, d.StakingTxHashHex)
+		}
+		assert.Equal(t, []string{"well-past", "unbonded-early"}, txHashes)
+	})
+
+	t.Run("blocks overdue beyond the tip", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: tipHeight}, nil).Once()
+
+		overdue, _, err := newService(v1DB).GetDelegationsOverdueForWithdrawal(ctx, tipHeight, "")
+		require.Nil(t, err)
+		assert.Empty(t, overdue)
+		v1DB.AssertNotCalled(t, "FindDelegationsOverdueForWithdrawal", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown tip height", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).
+			Return(nil, &db.NotFoundError{Key: "btc_info", Message: "not found"}).Once()
+
+		_, _, err := newService(v1DB).GetDelegationsOverdueForWithdrawal(ctx, 0, "")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+	})
+}
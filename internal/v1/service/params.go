@@ -1,6 +1,10 @@
 package v1service
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
 
@@ -27,37 +31,73 @@ type GlobalParamsPublic struct {
 
 func (s *V1Service) GetGlobalParamsPublic() *GlobalParamsPublic {
 	var versionedParams []VersionedGlobalParamsPublic
-	for _, version := range s.Service.Params.Versions {
-		versionedParams = append(versionedParams, VersionedGlobalParamsPublic{
-			Version:           version.Version,
-			ActivationHeight:  version.ActivationHeight,
-			StakingCap:        version.StakingCap,
-			CapHeight:         version.CapHeight,
-			Tag:               version.Tag,
-			CovenantPks:       version.CovenantPks,
-			CovenantQuorum:    version.CovenantQuorum,
-			UnbondingTime:     version.UnbondingTime,
-			UnbondingFee:      version.UnbondingFee,
-			MaxStakingAmount:  version.MaxStakingAmount,
-			MinStakingAmount:  version.MinStakingAmount,
-			MaxStakingTime:    version.MaxStakingTime,
-			MinStakingTime:    version.MinStakingTime,
-			ConfirmationDepth: version.ConfirmationDepth,
-		})
+	for _, version := range s.GlobalParams().Versions {
+		versionedParams = append(versionedParams, toVersionedGlobalParamsPublic(version))
 	}
 	return &GlobalParamsPublic{
 		Versions: versionedParams,
 	}
 }
 
+// GetGlobalParamsPublicByHeight returns the only params version applicable
+// at the given bitcoin height
+func (s *V1Service) GetGlobalParamsPublicByHeight(height uint64) (*GlobalParamsPublic, *types.Error) {
+	version := s.GetVersionedGlobalParamsByHeight(height)
+	if version == nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusNotFound, types.NotFound,
+			fmt.Sprintf("no global params version is active at height %d", height),
+		)
+	}
+	return &GlobalParamsPublic{
+		Versions: []VersionedGlobalParamsPublic{toVersionedGlobalParamsPublic(version)},
+	}, nil
+}
+
+// UpdateGlobalParam updates the given param from the given bitcoin height
+// onwards and returns the updated global params
+func (s *V1Service) UpdateGlobalParam(
+	ctx context.Context, paramName string, value, effectiveFromHeight uint64,
+) (*GlobalParamsPublic, *types.Error) {
+	if s.ParamsCache == nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError, "global params can not be updated",
+		)
+	}
+	if err := s.ParamsCache.Update(ctx, paramName, value, effectiveFromHeight); err != nil {
+		return nil, err
+	}
+	return s.GetGlobalParamsPublic(), nil
+}
+
+func toVersionedGlobalParamsPublic(version *types.VersionedGlobalParams) VersionedGlobalParamsPublic {
+	return VersionedGlobalParamsPublic{
+		Version:           version.Version,
+		ActivationHeight:  version.ActivationHeight,
+		StakingCap:        version.StakingCap,
+		CapHeight:         version.CapHeight,
+		Tag:               version.Tag,
+		CovenantPks:       version.CovenantPks,
+		CovenantQuorum:    version.CovenantQuorum,
+		UnbondingTime:     version.UnbondingTime,
+		UnbondingFee:      version.UnbondingFee,
+		MaxStakingAmount:  version.MaxStakingAmount,
+		MinStakingAmount:  version.MinStakingAmount,
+		MaxStakingTime:    version.MaxStakingTime,
+		MinStakingTime:    version.MinStakingTime,
+		ConfirmationDepth: version.ConfirmationDepth,
+	}
+}
+
 // GetVersionedGlobalParamsByHeight returns the versioned global params
 // for a particular bitcoin height
 func (s *V1Service) GetVersionedGlobalParamsByHeight(height uint64) *types.VersionedGlobalParams {
 	// Iterate the list in reverse (i.e. decreasing ActivationHeight)
 	// and identify the first element that has an activation height below
 	// the specified BTC height.
-	for i := len(s.Service.Params.Versions) - 1; i >= 0; i-- {
-		paramsVersion := s.Service.Params.Versions[i]
+	versions := s.GlobalParams().Versions
+	for i := len(versions) - 1; i >= 0; i-- {
+		paramsVersion := versions[i]
 		if paramsVersion.ActivationHeight <= height {
 			return paramsVersion
 		}
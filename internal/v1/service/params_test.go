@@ -0,0 +1,174 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/btcstaking"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStakingValue    = uint64(1_000_000)
+	testStakingTimeLock = uint64(1000)
+)
+
+// stakingFixture holds the keys and the staking output of a delegation
+type stakingFixture struct {
+	stakerPrivKey *btcec.PrivateKey
+	fpPrivKey     *btcec.PrivateKey
+	covenantPks   []*btcec.PublicKey
+	stakingInfo   *btcstaking.StakingInfo
+	stakingTxHash chainhash.Hash
+}
+
+func newStakingFixture(t *testing.T, covenantPks []*btcec.PublicKey, covenantQuorum uint64) *stakingFixture {
+	stakerPrivKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	fpPrivKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	stakingInfo, err := btcstaking.BuildStakingInfo(
+		stakerPrivKey.PubKey(), []*btcec.PublicKey{fpPrivKey.PubKey()}, covenantPks,
+		uint32(covenantQuorum), uint16(testStakingTimeLock), btcutil.Amount(testStakingValue),
+		&chaincfg.SigNetParams,
+	)
+	require.NoError(t, err)
+	stakingTx := wire.NewMsgTx(2)
+	stakingTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	stakingTx.AddTxOut(stakingInfo.StakingOutput)
+
+	return &stakingFixture{
+		stakerPrivKey: stakerPrivKey,
+		fpPrivKey:     fpPrivKey,
+		covenantPks:   covenantPks,
+		stakingInfo:   stakingInfo,
+		stakingTxHash: stakingTx.TxHash(),
+	}
+}
+
+// signedUnbonding builds the unbonding tx a wallet would build under the
+// given params, and returns it with its hash and the staker signature
+func (f *stakingFixture) signedUnbonding(
+	t *testing.T, params *types.VersionedGlobalParams,
+) (string, string, string) {
+	unbondingInfo, err := btcstaking.BuildUnbondingInfo(
+		f.stakerPrivKey.PubKey(), []*btcec.PublicKey{f.fpPrivKey.PubKey()}, f.covenantPks,
+		uint32(params.CovenantQuorum), uint16(params.UnbondingTime),
+		btcutil.Amount(testStakingValue-params.UnbondingFee), &chaincfg.SigNetParams,
+	)
+	require.NoError(t, err)
+	unbondingTx := wire.NewMsgTx(2)
+	unbondingTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&f.stakingTxHash, 0), nil, nil))
+	unbondingTx.AddTxOut(unbondingInfo.UnbondingOutput)
+
+	spendInfo, err := f.stakingInfo.UnbondingPathSpendInfo()
+	require.NoError(t, err)
+	fetcher := txscript.NewCannedPrevOutputFetcher(
+		f.stakingInfo.StakingOutput.PkScript, f.stakingInfo.StakingOutput.Value,
+	)
+	sig, err := txscript.RawTxInTapscriptSignature(
+		unbondingTx, txscript.NewTxSigHashes(unbondingTx, fetcher), 0,
+		f.stakingInfo.StakingOutput.Value, f.stakingInfo.StakingOutput.PkScript,
+		txscript.NewBaseTapLeaf(spendInfo.GetPkScriptPath()), txscript.SigHashDefault, f.stakerPrivKey,
+	)
+	require.NoError(t, err)
+
+	unbondingTxBytes, err := bbntypes.SerializeBTCTx(unbondingTx)
+	require.NoError(t, err)
+	return hex.EncodeToString(unbondingTxBytes), unbondingTx.TxHash().String(), hex.EncodeToString(sig)
+}
+
+func TestUnbondDelegationUsesParamsOfStakingHeight(t *testing.T) {
+	ctx := context.Background()
+
+	var covenantPks []*btcec.PublicKey
+	var covenantPkHexes []string
+	for i := 0; i < 3; i++ {
+		covenantPrivKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		covenantPks = append(covenantPks, covenantPrivKey.PubKey())
+		covenantPkHexes = append(covenantPkHexes, hex.EncodeToString(covenantPrivKey.PubKey().SerializeCompressed()))
+	}
+	paramsV1 := &types.VersionedGlobalParams{
+		Version: 0, ActivationHeight: 100,
+		CovenantPks: covenantPkHexes, CovenantQuorum: 2, UnbondingTime: 101, UnbondingFee: 10_000,
+	}
+	// the unbonding fee and time are raised at height 200
+	paramsV2 := &types.VersionedGlobalParams{
+		Version: 1, ActivationHeight: 200,
+		CovenantPks: covenantPkHexes, CovenantQuorum: 2, UnbondingTime: 150, UnbondingFee: 20_000,
+	}
+
+	f := newStakingFixture(t, covenantPks, paramsV1.CovenantQuorum)
+	stakingTxHashHex := f.stakingTxHash.String()
+	v1DB := mocks.NewV1DBClient(t)
+	// the delegation was staked under the first version
+	v1DB.On("FindDelegationByTxHashHex", ctx, stakingTxHashHex).Return(&v1dbmodel.DelegationDocument{
+		StakingTxHashHex:      stakingTxHashHex,
+		StakerPkHex:           hex.EncodeToString(schnorr.SerializePubKey(f.stakerPrivKey.PubKey())),
+		FinalityProviderPkHex: hex.EncodeToString(schnorr.SerializePubKey(f.fpPrivKey.PubKey())),
+		StakingValue:          testStakingValue,
+		State:                 types.Active,
+		StakingTx: &v1dbmodel.TimelockTransaction{
+			StartHeight: 150, TimeLock: testStakingTimeLock, OutputIndex: 0,
+		},
+	}, nil)
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}},
+		Params:    &types.GlobalParams{Versions: []*types.VersionedGlobalParams{paramsV1, paramsV2}},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+
+	t.Run("unbonding tx built with the params of the current height is rejected", func(t *testing.T) {
+		unbondingTxHex, unbondingTxHashHex, sigHex := f.signedUnbonding(t, paramsV2)
+		err := s.UnbondDelegation(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, sigHex, "")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("unbonding tx built with the params of the staking height is accepted", func(t *testing.T) {
+		unbondingTxHex, unbondingTxHashHex, sigHex := f.signedUnbonding(t, paramsV1)
+		v1DB.On("SaveUnbondingTx", ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, sigHex).
+			Return(nil).Once()
+		err := s.UnbondDelegation(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, sigHex, "")
+		require.Nil(t, err)
+	})
+}
+
+func TestGetGlobalParamsPublicByHeight(t *testing.T) {
+	s := &V1Service{Service: &service.Service{Params: &types.GlobalParams{
+		Versions: []*types.VersionedGlobalParams{
+			{Version: 0, ActivationHeight: 100, UnbondingFee: 10_000},
+			{Version: 1, ActivationHeight: 200, UnbondingFee: 20_000},
+		},
+	}}}
+
+	_, err := s.GetGlobalParamsPublicByHeight(99)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	for height, version := range map[uint64]uint64{100: 0, 199: 0, 200: 1, 1000: 1} {
+		params, err := s.GetGlobalParamsPublicByHeight(height)
+		require.Nil(t, err)
+		require.Len(t, params.Versions, 1)
+		assert.Equal(t, version, params.Versions[0].Version, "height %d", height)
+	}
+}
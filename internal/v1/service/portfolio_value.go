@@ -0,0 +1,41 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// PortfolioValueUsdPublic is the phase-1 active stake of a staker valued at
+// the current BTC price
+type PortfolioValueUsdPublic struct {
+	TotalActiveSat int64   `json:"total_active_sat"`
+	BtcPriceUsd    float64 `json:"btc_price_usd"`
+	TotalActiveUsd float64 `json:"total_active_usd"`
+}
+
+// GetStakerPortfolioValueUsd values the active stake of the staker at the
+// cached BTC price of the oracle. A staker without stats has no active stake.
+func (s *V1Service) GetStakerPortfolioValueUsd(
+	ctx context.Context, stakerPkHex string,
+) (*PortfolioValueUsdPublic, *types.Error) {
+	price, priceErr := s.Service.CachedBtcUsdPrice(ctx)
+	if priceErr != nil {
+		return nil, priceErr
+	}
+
+	portfolio := &PortfolioValueUsdPublic{BtcPriceUsd: price}
+	stats, err := s.Service.DbClients.V1DBClient.GetStakerStats(ctx, canonicalStakerPkHex(stakerPkHex))
+	if err != nil && !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching staker stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if stats != nil {
+		portfolio.TotalActiveSat = stats.ActiveTvl
+	}
+	portfolio.TotalActiveUsd = float64(portfolio.TotalActiveSat) / utils.SatoshisPerBtc * price
+	return portfolio, nil
+}
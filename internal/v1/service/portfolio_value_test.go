@@ -0,0 +1,73 @@
+package v1service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStakerPortfolioValueUsd(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+	newService := func(v1DB *mocks.V1DBClient, oracle service.BTCPriceOracle) *V1Service {
+		return &V1Service{Service: &service.Service{
+			DbClients:      &dbclients.DbClients{V1DBClient: v1DB},
+			BtcPriceOracle: oracle,
+			Cache:          cache.NewMemoryCache(),
+		}}
+	}
+
+	t.Run("active stake valued at the cached price", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetStakerStats", ctx, "staker").
+			Return(&v1dbmodel.StakerStatsDocument{StakerPkHex: "staker", ActiveTvl: 150_000_000}, nil).Twice()
+		oracle := mocks.NewBTCPriceOracle(t)
+		// the price is fetched once for both requests
+		oracle.On("GetBtcUsdPrice", ctx).Return(60000.0, nil).Once()
+		s := newService(v1DB, oracle)
+
+		for range 2 {
+			portfolio, err := s.GetStakerPortfolioValueUsd(ctx, "staker")
+			require.Nil(t, err)
+			assert.Equal(t, int64(150_000_000), portfolio.TotalActiveSat)
+			assert.Equal(t, 60000.0, portfolio.BtcPriceUsd)
+			assert.InDelta(t, 90000.0, portfolio.TotalActiveUsd, 1e-9)
+		}
+	})
+
+	t.Run("staker without stats", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetStakerStats", ctx, "staker").
+			Return(nil, &db.NotFoundError{Key: "staker", Message: "not found"}).Once()
+		oracle := mocks.NewBTCPriceOracle(t)
+		oracle.On("GetBtcUsdPrice", ctx).Return(60000.0, nil).Once()
+
+		portfolio, err := newService(v1DB, oracle).GetStakerPortfolioValueUsd(ctx, "staker")
+		require.Nil(t, err)
+		assert.Equal(t, &PortfolioValueUsdPublic{BtcPriceUsd: 60000.0}, portfolio)
+	})
+
+	t.Run("price oracle unavailable", func(t *testing.T) {
+		oracle := mocks.NewBTCPriceOracle(t)
+		oracle.On("GetBtcUsdPrice", ctx).Return(0.0, errors.New("price feed down")).Once()
+
+		for _, oracle := range []service.BTCPriceOracle{nil, oracle} {
+			_, err := newService(mocks.NewV1DBClient(t), oracle).GetStakerPortfolioValueUsd(ctx, "staker")
+			require.NotNil(t, err)
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, types.PriceOracleUnavailable, err.ErrorCode)
+		}
+	})
+}
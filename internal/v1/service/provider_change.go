@@ -0,0 +1,126 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// ProviderChangeCandidatePublic is an active delegation whose staking
+// timelock expires soon, along with the finality provider its stake could be
+// moved to
+type ProviderChangeCandidatePublic struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingValue          uint64 `json:"staking_value"`
+	StakingExpiryHeight   uint64 `json:"staking_expiry_height"`
+	// BlocksRemaining is negative once the staking timelock expired
+	BlocksRemaining int64 `json:"blocks_remaining"`
+	// SuggestedFinalityProvider is the registered finality provider with the
+	// highest active TVL other than the current one, nil if there is none
+	SuggestedFinalityProvider *FpDetailsPublic `json:"suggested_finality_provider"`
+}
+
+// GetProviderChangeCandidates returns the active delegations of the staker
+// whose staking timelock expires in less than blocksAhead blocks from the
+// current BTC tip height, the earliest expiring first
+func (s *V1Service) GetProviderChangeCandidates(
+	ctx context.Context, stakerPkHex string, blocksAhead uint64,
+) ([]*ProviderChangeCandidatePublic, *types.Error) {
+	tipHeight, tipErr := s.btcTipHeight(ctx)
+	if tipErr != nil {
+		return nil, tipErr
+	}
+
+	delegations, err := s.Service.DbClients.V1DBClient.FindActiveDelegationsByStakerPkExpiringBefore(
+		ctx, canonicalStakerPkHex(stakerPkHex), tipHeight+blocksAhead,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find the active delegations of the staker expiring soon")
+		return nil, types.NewInternalServiceError(err)
+	}
+	candidates := make([]*ProviderChangeCandidatePublic, 0, len(delegations))
+	if len(delegations) == 0 {
+		return candidates, nil
+	}
+
+	rankedFps, err := s.finalityProvidersByActiveTvl(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to rank the finality providers by active tvl")
+		return nil, types.NewInternalServiceError(err)
+	}
+	for _, d := range delegations {
+		candidates = append(candidates, providerChangeCandidate(&d, tipHeight, rankedFps))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].StakingExpiryHeight < candidates[j].StakingExpiryHeight
+	})
+	return candidates, nil
+}
+
+// finalityProvidersByActiveTvl returns the registered finality providers,
+// the highest active TVL first and by public key on a tie
+func (s *V1Service) finalityProvidersByActiveTvl(ctx context.Context) ([]*FpDetailsPublic, error) {
+	fpParams := s.GetFinalityProvidersFromGlobalParams()
+	if len(fpParams) == 0 {
+		return nil, nil
+	}
+	fpPkHexes := make([]string, 0, len(fpParams))
+	for _, fp := range fpParams {
+		fpPkHexes = append(fpPkHexes, fp.BtcPk)
+	}
+	fpStats, err := s.Service.DbClients.V1DBClient.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, fpPkHexes)
+	if err != nil {
+		return nil, err
+	}
+	fpStatsByPk := make(map[string]*v1dbmodel.FinalityProviderStatsDocument, len(fpStats))
+	for _, stats := range fpStats {
+		fpStatsByPk[stats.FinalityProviderPkHex] = stats
+	}
+
+	fps := make([]*FpDetailsPublic, 0, len(fpParams))
+	for _, fp := range fpParams {
+		detail := &FpDetailsPublic{
+			Description: fp.Description,
+			Commission:  fp.Commission,
+			BtcPk:       fp.BtcPk,
+		}
+		if stats, ok := fpStatsByPk[fp.BtcPk]; ok {
+			detail.ActiveTvl = stats.ActiveTvl
+			detail.TotalTvl = stats.TotalTvl
+			detail.ActiveDelegations = stats.ActiveDelegations
+			detail.TotalDelegations = stats.TotalDelegations
+		}
+		fps = append(fps, detail)
+	}
+	sort.Slice(fps, func(i, j int) bool {
+		if fps[i].ActiveTvl != fps[j].ActiveTvl {
+			return fps[i].ActiveTvl > fps[j].ActiveTvl
+		}
+		return fps[i].BtcPk < fps[j].BtcPk
+	})
+	return fps, nil
+}
+
+func providerChangeCandidate(
+	d *v1dbmodel.DelegationDocument, tipHeight uint64, rankedFps []*FpDetailsPublic,
+) *ProviderChangeCandidatePublic {
+	expiryHeight := d.StakingTx.StartHeight + d.StakingTx.TimeLock
+	candidate := &ProviderChangeCandidatePublic{
+		StakingTxHashHex:      d.StakingTxHashHex,
+		FinalityProviderPkHex: d.FinalityProviderPkHex,
+		StakingValue:          d.StakingValue,
+		StakingExpiryHeight:   expiryHeight,
+		BlocksRemaining:       int64(expiryHeight) - int64(tipHeight),
+	}
+	for _, fp := range rankedFps {
+		if fp.BtcPk != d.FinalityProviderPkHex {
+			candidate.SuggestedFinalityProvider = fp
+			break
+		}
+	}
+	return candidate
+}
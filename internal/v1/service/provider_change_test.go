@@ -0,0 +1,89 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProviderChangeCandidates(t *testing.T) {
+	ctx := context.Background()
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		return &V1Service{Service: &service.Service{
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+			FinalityProviders: []types.FinalityProviderDetails{
+				{BtcPk: "fp-a", Description: types.FinalityProviderDescription{Moniker: "a"}},
+				{BtcPk: "fp-b", Description: types.FinalityProviderDescription{Moniker: "b"}},
+				{BtcPk: "fp-c", Description: types.FinalityProviderDescription{Moniker: "c"}},
+				// never received a delegation
+				{BtcPk: "fp-d", Description: types.FinalityProviderDescription{Moniker: "d"}},
+			},
+		}}
+	}
+
+	t.Run("the highest active tvl provider other than the current one is suggested", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: 1000}, nil).Once()
+		v1DB.On("FindActiveDelegationsByStakerPkExpiringBefore", ctx, "staker", uint64(2008)).
+			Return([]v1dbmodel.DelegationDocument{
+				{
+					StakingTxHashHex:      "to-b",
+					FinalityProviderPkHex: "fp-b",
+					StakingValue:          1000,
+					State:                 types.Active,
+					StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 500, TimeLock: 1000},
+				},
+				{
+					StakingTxHashHex:      "to-a",
+					FinalityProviderPkHex: "fp-a",
+					StakingValue:          2000,
+					State:                 types.Active,
+					StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 1000},
+				},
+			}, nil).Once()
+		v1DB.On("FindFinalityProviderStatsByFinalityProviderPkHex", ctx, mock.Anything).
+			Return([]*v1dbmodel.FinalityProviderStatsDocument{
+				{FinalityProviderPkHex: "fp-a", ActiveTvl: 500},
+				{FinalityProviderPkHex: "fp-b", ActiveTvl: 900},
+				{FinalityProviderPkHex: "fp-c", ActiveTvl: 900},
+			}, nil).Once()
+
+		candidates, err := newService(v1DB).GetProviderChangeCandidates(ctx, "staker", 1008)
+		require.Nil(t, err)
+		require.Len(t, candidates, 2)
+
+		// the earliest expiring comes first, its timelock expires first
+		assert.Equal(t, "to-a", candidates[0].StakingTxHashHex)
+		assert.Equal(t, uint64(1100), candidates[0].StakingExpiryHeight)
+		assert.Equal(t, int64(100), candidates[0].BlocksRemaining)
+		require.NotNil(t, candidates[0].SuggestedFinalityProvider)
+		// fp-b and fp-c have the same tvl, the public key breaks the tie
+		assert.Equal(t, "fp-b", candidates[0].SuggestedFinalityProvider.BtcPk)
+		assert.Equal(t, "b", candidates[0].SuggestedFinalityProvider.Description.Moniker)
+		assert.Equal(t, int64(900), candidates[0].SuggestedFinalityProvider.ActiveTvl)
+
+		assert.Equal(t, "to-b", candidates[1].StakingTxHashHex)
+		assert.Equal(t, int64(500), candidates[1].BlocksRemaining)
+		require.NotNil(t, candidates[1].SuggestedFinalityProvider)
+		assert.Equal(t, "fp-c", candidates[1].SuggestedFinalityProvider.BtcPk)
+	})
+
+	t.Run("no delegation expiring soon", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: 1000}, nil).Once()
+		v1DB.On("FindActiveDelegationsByStakerPkExpiringBefore", ctx, "staker", uint64(1144)).
+			Return(nil, nil).Once()
+
+		candidates, err := newService(v1DB).GetProviderChangeCandidates(ctx, "staker", 144)
+		require.Nil(t, err)
+		assert.Empty(t, candidates)
+	})
+}
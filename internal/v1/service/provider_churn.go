@@ -0,0 +1,52 @@
+package v1service
+
+import (
+	"context"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// providerChurnWindow is how far back the delegations are counted
+const providerChurnWindow = 30 * 24 * time.Hour
+
+// ProviderChurnPublic counts the delegations to a finality provider which
+// became active, and the ones which left the active state, over the last 30
+// days
+type ProviderChurnPublic struct {
+	ProviderPkHex      string `json:"provider_pk_hex"`
+	NewDelegations     int64  `json:"new_delegations"`
+	ExpiredDelegations int64  `json:"expired_delegations"`
+	NetChange          int64  `json:"net_change"`
+}
+
+// GetProviderChurn returns the churn of every finality provider with a
+// delegation which became active or left the active state in the last 30
+// days, sorted by finality provider public key
+func (s *V1Service) GetProviderChurn(ctx context.Context) ([]*ProviderChurnPublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1ProviderChurnAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	since := time.Now().Add(-providerChurnWindow).Unix()
+	churn, err := s.Service.DbClients.V1DBClient.CountFinalityProviderChurnSince(ctx, since)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count the finality provider churn")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	providers := make([]*ProviderChurnPublic, 0, len(churn))
+	for _, c := range churn {
+		providers = append(providers, &ProviderChurnPublic{
+			ProviderPkHex:      c.FinalityProviderPkHex,
+			NewDelegations:     c.NewDelegations,
+			ExpiredDelegations: c.ExpiredDelegations,
+			NetChange:          c.NewDelegations - c.ExpiredDelegations,
+		})
+	}
+	return providers, nil
+}
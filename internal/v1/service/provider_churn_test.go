@@ -0,0 +1,40 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProviderChurn(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+	now := time.Now().Unix()
+	// fp-a gained stakers, fp-b lost some to it
+	v1DB.On("CountFinalityProviderChurnSince", ctx, mock.MatchedBy(func(since int64) bool {
+		return since <= now-30*24*3600 && since >= now-30*24*3600-60
+	})).Return([]v1dbmodel.FinalityProviderChurn{
+		{FinalityProviderPkHex: "fp-a", NewDelegations: 5, ExpiredDelegations: 1},
+		{FinalityProviderPkHex: "fp-b", NewDelegations: 1, ExpiredDelegations: 4},
+		{FinalityProviderPkHex: "fp-c", NewDelegations: 2, ExpiredDelegations: 2},
+	}, nil).Once()
+
+	churn, err := s.GetProviderChurn(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []*ProviderChurnPublic{
+		{ProviderPkHex: "fp-a", NewDelegations: 5, ExpiredDelegations: 1, NetChange: 4},
+		{ProviderPkHex: "fp-b", NewDelegations: 1, ExpiredDelegations: 4, NetChange: -3},
+		{ProviderPkHex: "fp-c", NewDelegations: 2, ExpiredDelegations: 2, NetChange: 0},
+	}, churn)
+}
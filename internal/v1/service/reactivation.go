@@ -0,0 +1,69 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// ReactivationCandidatePublic is a withdrawn delegation whose funds can be
+// staked again
+type ReactivationCandidatePublic struct {
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingValueSat       uint64 `json:"staking_value_sat"`
+	// ExpiryHeight is the height at which the timelock of the withdrawn
+	// output, the unbonding one if the delegation was unbonded, expired
+	ExpiryHeight uint64 `json:"expiry_height"`
+	// WithdrawalTxConfirmationHeight is omitted for the delegations withdrawn
+	// before the withdrawal tx was recorded
+	WithdrawalTxConfirmationHeight *uint64 `json:"withdrawal_tx_confirmation_height,omitempty"`
+}
+
+// GetReactivationCandidates returns the withdrawn delegations of the staker,
+// the most recently withdrawn first
+func (s *V1Service) GetReactivationCandidates(
+	ctx context.Context, stakerPkHex string, pageToken string,
+) ([]*ReactivationCandidatePublic, string, *types.Error) {
+	resultMap, err := s.Service.DbClients.V1DBClient.FindWithdrawnDelegationsByStakerPk(
+		ctx, stakerPkHex, pageToken,
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching reactivation candidates")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find withdrawn delegations by staker pk")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	candidates := make([]*ReactivationCandidatePublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		candidates = append(candidates, reactivationCandidateFromDocument(&d))
+	}
+	return candidates, resultMap.PaginationToken, nil
+}
+
+func reactivationCandidateFromDocument(d *v1dbmodel.DelegationDocument) *ReactivationCandidatePublic {
+	candidate := &ReactivationCandidatePublic{
+		StakingTxHashHex:      d.StakingTxHashHex,
+		FinalityProviderPkHex: d.FinalityProviderPkHex,
+		StakingValueSat:       d.StakingValue,
+	}
+	expiredTx := d.StakingTx
+	if d.UnbondingTx != nil {
+		expiredTx = d.UnbondingTx
+	}
+	if expiredTx != nil {
+		candidate.ExpiryHeight = expiredTx.StartHeight + expiredTx.TimeLock
+	}
+	if d.WithdrawalTx != nil {
+		height := d.WithdrawalTx.Height
+		candidate.WithdrawalTxConfirmationHeight = &height
+	}
+	return candidate
+}
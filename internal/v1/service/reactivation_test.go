@@ -0,0 +1,95 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReactivationCandidates(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+
+	withdrawn := []v1dbmodel.DelegationDocument{
+		{
+			StakingTxHashHex:      "unbonded",
+			FinalityProviderPkHex: "fp",
+			StakingValue:          3000,
+			State:                 types.Withdrawn,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 1000},
+			UnbondingTx:           &v1dbmodel.TimelockTransaction{StartHeight: 400, TimeLock: 50},
+			WithdrawalTx:          &v1dbmodel.WithdrawalTransaction{TxHashHex: "withdrawal-1", Height: 500},
+		},
+		{
+			StakingTxHashHex:      "expired",
+			FinalityProviderPkHex: "fp",
+			StakingValue:          2000,
+			State:                 types.Withdrawn,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 200},
+			WithdrawalTx:          &v1dbmodel.WithdrawalTransaction{TxHashHex: "withdrawal-2", Height: 320},
+		},
+		{
+			StakingTxHashHex:      "legacy",
+			FinalityProviderPkHex: "other-fp",
+			StakingValue:          1000,
+			State:                 types.Withdrawn,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 10, TimeLock: 20},
+		},
+	}
+	v1DB.On("FindWithdrawnDelegationsByStakerPk", ctx, "staker", "").
+		Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+			Data: withdrawn, PaginationToken: "next",
+		}, nil).Once()
+
+	candidates, paginationToken, err := s.GetReactivationCandidates(ctx, "staker", "")
+	require.Nil(t, err)
+	assert.Equal(t, "next", paginationToken)
+	withdrawalHeights := []uint64{500, 320}
+	assert.Equal(t, []*ReactivationCandidatePublic{
+		{
+			StakingTxHashHex: "unbonded", FinalityProviderPkHex: "fp", StakingValueSat: 3000,
+			ExpiryHeight: 450, WithdrawalTxConfirmationHeight: &withdrawalHeights[0],
+		},
+		{
+			StakingTxHashHex: "expired", FinalityProviderPkHex: "fp", StakingValueSat: 2000,
+			ExpiryHeight: 300, WithdrawalTxConfirmationHeight: &withdrawalHeights[1],
+		},
+		{
+			StakingTxHashHex: "legacy", FinalityProviderPkHex: "other-fp", StakingValueSat: 1000,
+			ExpiryHeight: 30,
+		},
+	}, candidates)
+
+	// the page cursor keeps the withdrawal height, nil once it is unknown
+	token, buildErr := v1dbmodel.BuildWithdrawnDelegationPaginationToken(withdrawn[1])
+	require.NoError(t, buildErr)
+	page, decodeErr := dbmodel.DecodePaginationToken[v1dbmodel.WithdrawnDelegationPagination](token)
+	require.NoError(t, decodeErr)
+	assert.Equal(t, "expired", page.StakingTxHashHex)
+	require.NotNil(t, page.WithdrawalHeight)
+	assert.Equal(t, uint64(320), *page.WithdrawalHeight)
+	token, buildErr = v1dbmodel.BuildWithdrawnDelegationPaginationToken(withdrawn[2])
+	require.NoError(t, buildErr)
+	page, decodeErr = dbmodel.DecodePaginationToken[v1dbmodel.WithdrawnDelegationPagination](token)
+	require.NoError(t, decodeErr)
+	assert.Nil(t, page.WithdrawalHeight)
+
+	v1DB.On("FindWithdrawnDelegationsByStakerPk", ctx, "staker", "bad-token").
+		Return(nil, &db.InvalidPaginationTokenError{Message: "Invalid pagination token"}).Once()
+	_, _, err = s.GetReactivationCandidates(ctx, "staker", "bad-token")
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
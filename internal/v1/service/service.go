@@ -2,16 +2,25 @@ package v1service
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/events"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
 
 type V1Service struct {
 	*service.Service
+	fpLogoCache           fpLogoCache
+	newStakersPerDayCache newStakersPerDayCache
+	stakerFilter          stakerFilter
+	delegationEvents      *events.Bus
+	// registeredFinalityProviders are the finality providers registered
+	// through the API, nil until they are loaded
+	registeredFinalityProviders atomic.Pointer[[]types.FinalityProviderDetails]
 }
 
 func New(
@@ -21,6 +30,7 @@ func New(
 	finalityProviders []types.FinalityProviderDetails,
 	clients *clients.Clients,
 	dbClients *dbclients.DbClients,
+	delegationEvents *events.Bus,
 ) (*V1Service, error) {
 	service, err := service.New(ctx, cfg, globalParams, finalityProviders, clients, dbClients)
 	if err != nil {
@@ -28,6 +38,7 @@ func New(
 	}
 
 	return &V1Service{
-		service,
+		Service:          service,
+		delegationEvents: delegationEvents,
 	}, nil
 }
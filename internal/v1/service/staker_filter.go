@@ -0,0 +1,72 @@
+package v1service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/bloom"
+	"github.com/rs/zerolog/log"
+)
+
+// minStakerFilterHeadroom is the least number of stakers the filter is sized
+// for on top of the ones found when it is built, to absorb later insertions
+const minStakerFilterHeadroom = 1000
+
+// stakerFilter tells the stakers definitely without phase-1 delegations. It
+// answers nothing until built, the stakers saved meanwhile are added once it
+// is.
+type stakerFilter struct {
+	mu      sync.Mutex
+	filter  *bloom.Filter
+	pending []string
+}
+
+// hasNoDelegations returns true if the staker definitely has no delegation
+func (f *stakerFilter) hasNoDelegations(stakerPkHex string) bool {
+	f.mu.Lock()
+	filter := f.filter
+	f.mu.Unlock()
+	return filter != nil && !filter.MayContain(stakerPkHex)
+}
+
+func (f *stakerFilter) add(stakerPkHex string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.filter == nil {
+		f.pending = append(f.pending, stakerPkHex)
+		return
+	}
+	f.filter.Add(stakerPkHex)
+}
+
+func (f *stakerFilter) set(filter *bloom.Filter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, stakerPkHex := range f.pending {
+		filter.Add(stakerPkHex)
+	}
+	f.filter, f.pending = filter, nil
+}
+
+// BuildStakerFilter loads the stakers with phase-1 delegations into the
+// staker filter. It does nothing if the bloom filter is not configured.
+func (s *V1Service) BuildStakerFilter(ctx context.Context) error {
+	if s.Service.Cfg.BloomFilter == nil {
+		return nil
+	}
+	stakerPks, err := s.Service.DbClients.V1DBClient.FindStakerPks(ctx)
+	if err != nil {
+		return err
+	}
+
+	expected := uint64(len(stakerPks) + max(len(stakerPks)/10, minStakerFilterHeadroom))
+	filter := bloom.New(expected, s.Service.Cfg.BloomFilter.FalsePositiveRate)
+	// The keys stored before they were normalized are added in canonical
+	// form, as they are looked up
+	for _, stakerPkHex := range stakerPks {
+		filter.Add(canonicalStakerPkHex(stakerPkHex))
+	}
+	s.stakerFilter.set(filter)
+	log.Ctx(ctx).Info().Int("stakers", len(stakerPks)).Msg("staker filter built")
+	return nil
+}
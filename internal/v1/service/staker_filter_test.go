@@ -0,0 +1,81 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationsByStakerPkStakerFilter(t *testing.T) {
+	ctx := context.Background()
+	newService := func(v1DB *mocks.V1DBClient, indexerDB *mocks.IndexerDBClient) *V1Service {
+		return &V1Service{Service: &service.Service{
+			Cfg: &config.Config{
+				BloomFilter: &config.BloomFilterConfig{FalsePositiveRate: 0.01},
+			},
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB},
+		}}
+	}
+	expectLookup := func(v1DB *mocks.V1DBClient, indexerDB *mocks.IndexerDBClient, stakerPk string) {
+		v1DB.On("FindDelegationsByStakerPk", ctx, stakerPk, mock.Anything, "").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{}, nil).Once()
+		indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(100), nil).Once()
+		indexerDB.On("GetFinalityProviders", ctx).Return(nil, nil).Once()
+	}
+
+	t.Run("staker missing from the filter is answered without the database", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindStakerPks", ctx).Return([]string{"staker"}, nil).Once()
+		s := newService(v1DB, nil)
+		require.NoError(t, s.BuildStakerFilter(ctx))
+
+		delegations, pageToken, err := s.DelegationsByStakerPk(ctx, "unknown", nil, "")
+		require.Nil(t, err)
+		assert.Equal(t, []*DelegationPublic{}, delegations)
+		assert.Empty(t, pageToken)
+	})
+
+	t.Run("staker in the filter is looked up", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		indexerDB := mocks.NewIndexerDBClient(t)
+		v1DB.On("FindStakerPks", ctx).Return([]string{"staker"}, nil).Once()
+		s := newService(v1DB, indexerDB)
+		require.NoError(t, s.BuildStakerFilter(ctx))
+		expectLookup(v1DB, indexerDB, "staker")
+
+		_, _, err := s.DelegationsByStakerPk(ctx, "staker", nil, "")
+		require.Nil(t, err)
+	})
+
+	t.Run("staker saved while the filter is built is looked up", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		indexerDB := mocks.NewIndexerDBClient(t)
+		s := newService(v1DB, indexerDB)
+		s.stakerFilter.add("new")
+		v1DB.On("FindStakerPks", ctx).Return([]string{"staker"}, nil).Once()
+		require.NoError(t, s.BuildStakerFilter(ctx))
+		expectLookup(v1DB, indexerDB, "new")
+
+		_, _, err := s.DelegationsByStakerPk(ctx, "new", nil, "")
+		require.Nil(t, err)
+	})
+
+	t.Run("every staker is looked up until the filter is built", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		indexerDB := mocks.NewIndexerDBClient(t)
+		s := newService(v1DB, indexerDB)
+		expectLookup(v1DB, indexerDB, "unknown")
+
+		_, _, err := s.DelegationsByStakerPk(ctx, "unknown", nil, "")
+		require.Nil(t, err)
+	})
+}
@@ -0,0 +1,82 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakerPkFormats(t *testing.T) {
+	ctx := context.Background()
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	xOnlyPkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	compressedPkHex := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+
+	t.Run("every format returns the same delegations", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		indexerDB := mocks.NewIndexerDBClient(t)
+		s := &V1Service{Service: &service.Service{
+			Cfg: &config.Config{
+				Server:      &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams},
+				BloomFilter: &config.BloomFilterConfig{FalsePositiveRate: 0.01},
+			},
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB},
+		}}
+		indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(100), nil)
+		indexerDB.On("GetFinalityProviders", ctx).Return(nil, nil)
+		// the delegations were stored with the compressed key, before the
+		// keys were normalized
+		v1DB.On("FindStakerPks", ctx).Return([]string{compressedPkHex}, nil).Once()
+		require.NoError(t, s.BuildStakerFilter(ctx))
+		v1DB.On("FindDelegationsByStakerPk", ctx, xOnlyPkHex, mock.Anything, "").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+				Data: []v1dbmodel.DelegationDocument{{
+					StakingTxHashHex: "tx1",
+					StakerPkHex:      compressedPkHex,
+					StakingValue:     1000,
+					State:            types.Active,
+					StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 20},
+				}},
+			}, nil).Times(3)
+
+		for _, stakerPk := range []string{xOnlyPkHex, compressedPkHex, strings.ToUpper(xOnlyPkHex)} {
+			delegations, _, err := s.DelegationsByStakerPk(ctx, stakerPk, nil, "")
+			require.Nil(t, err)
+			require.Len(t, delegations, 1, stakerPk)
+			assert.Equal(t, "tx1", delegations[0].StakingTxHashHex)
+		}
+	})
+
+	t.Run("delegations are saved with the canonical key", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		s := &V1Service{Service: &service.Service{
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		}}
+		v1DB.On(
+			"SaveActiveStakingDelegation", ctx, "tx1", xOnlyPkHex, "fp", "00",
+			uint64(1000), uint64(100), uint64(20), uint64(0), int64(1700000000), false,
+			(*float64)(nil),
+		).Return(nil).Once()
+
+		err := s.SaveActiveStakingDelegation(
+			ctx, "tx1", strings.ToUpper(compressedPkHex), "fp", 1000, 100, 1700000000, 20, 0, "00", false,
+		)
+		require.Nil(t, err)
+	})
+}
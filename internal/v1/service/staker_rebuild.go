@@ -0,0 +1,61 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// StakerStatsRebuildPublic is the outcome of a staker stats rebuild, Before
+// is nil if the staker had no stats
+type StakerStatsRebuildPublic struct {
+	StakerPkHex string             `json:"staker_pk_hex"`
+	Before      *StakerStatsPublic `json:"before"`
+	After       StakerStatsPublic  `json:"after"`
+	Changed     bool               `json:"changed"`
+}
+
+// RebuildStakerStats re-derives the stats of a single staker from its
+// delegations, to repair the stats of a staker without touching the others.
+// The before and after stats are logged as an audit event.
+func (s *V1Service) RebuildStakerStats(
+	ctx context.Context, stakerPkHex string,
+) (*StakerStatsRebuildPublic, *types.Error) {
+	before, after, err := s.Service.DbClients.V1DBClient.RebuildStakerStats(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPkHex).
+			Msg("error while rebuilding staker stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	rebuild := &StakerStatsRebuildPublic{
+		StakerPkHex: stakerPkHex,
+		After:       fromStakerStatsDocument(after),
+		Changed:     before == nil || *before != *after,
+	}
+	if before != nil {
+		beforePublic := fromStakerStatsDocument(before)
+		rebuild.Before = &beforePublic
+	}
+
+	log.Ctx(ctx).Info().
+		Str("audit", "rebuild_staker_stats").
+		Str("stakerPkHex", stakerPkHex).
+		Interface("before", rebuild.Before).
+		Interface("after", rebuild.After).
+		Bool("changed", rebuild.Changed).
+		Msg("staker stats rebuilt")
+	return rebuild, nil
+}
+
+func fromStakerStatsDocument(stats *v1dbmodel.StakerStatsDocument) StakerStatsPublic {
+	return StakerStatsPublic{
+		StakerPkHex:       stats.StakerPkHex,
+		ActiveTvl:         stats.ActiveTvl,
+		TotalTvl:          stats.TotalTvl,
+		ActiveDelegations: stats.ActiveDelegations,
+		TotalDelegations:  stats.TotalDelegations,
+	}
+}
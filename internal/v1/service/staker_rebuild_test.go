@@ -0,0 +1,90 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildStakerStats(t *testing.T) {
+	ctx := context.Background()
+
+	delegations := []v1dbmodel.DelegationDocument{
+		{StakingTxHashHex: "active", StakerPkHex: "corrupted", StakingValue: 100, State: types.Active},
+		{StakingTxHashHex: "unbonded", StakerPkHex: "corrupted", StakingValue: 200, State: types.Withdrawn},
+		// the active staker stats of this one were never processed
+		{StakingTxHashHex: "unprocessed", StakerPkHex: "corrupted", StakingValue: 400, State: types.Active},
+		{StakingTxHashHex: "other", StakerPkHex: "other", StakingValue: 800, State: types.Active},
+	}
+	// the staker stats processed per delegation and state
+	processed := map[string]bool{
+		"active:active":   true,
+		"unbonded:active": true, "unbonded:unbonded": true,
+		"other:active": true,
+	}
+	otherStats := v1dbmodel.StakerStatsDocument{
+		StakerPkHex: "other", ActiveTvl: 1, TotalTvl: 1, ActiveDelegations: 1, TotalDelegations: 1,
+	}
+	stakerStats := map[string]v1dbmodel.StakerStatsDocument{
+		"corrupted": {StakerPkHex: "corrupted", ActiveTvl: -50, TotalTvl: 1000, ActiveDelegations: 7, TotalDelegations: 2},
+		"other":     otherStats,
+	}
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("RebuildStakerStats", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, stakerPkHex string) (*v1dbmodel.StakerStatsDocument, *v1dbmodel.StakerStatsDocument, error) {
+			var stakerDelegations []v1dbmodel.DelegationDocument
+			for _, delegation := range delegations {
+				if delegation.StakerPkHex == stakerPkHex {
+					stakerDelegations = append(stakerDelegations, delegation)
+				}
+			}
+			var before *v1dbmodel.StakerStatsDocument
+			if stats, ok := stakerStats[stakerPkHex]; ok {
+				before = &stats
+			}
+			after := v1dbmodel.DeriveStakerStats(
+				stakerPkHex, stakerDelegations,
+				func(stakingTxHashHex string, state types.DelegationState) bool {
+					return processed[stakingTxHashHex+":"+state.ToString()]
+				},
+			)
+			stakerStats[stakerPkHex] = *after
+			return before, after, nil
+		},
+	)
+	s := &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+
+	rebuild, err := s.RebuildStakerStats(ctx, "corrupted")
+	require.Nil(t, err)
+	assert.True(t, rebuild.Changed)
+	require.NotNil(t, rebuild.Before)
+	assert.Equal(t, int64(-50), rebuild.Before.ActiveTvl)
+	expected := StakerStatsPublic{
+		StakerPkHex: "corrupted", ActiveTvl: 100, TotalTvl: 300, ActiveDelegations: 1, TotalDelegations: 2,
+	}
+	assert.Equal(t, expected, rebuild.After)
+	assert.Equal(t, otherStats, stakerStats["other"])
+
+	// rebuilding consistent stats is a no-op
+	rebuild, err = s.RebuildStakerStats(ctx, "corrupted")
+	require.Nil(t, err)
+	assert.False(t, rebuild.Changed)
+	assert.Equal(t, expected, rebuild.After)
+
+	// a staker without stats gets them created
+	delete(stakerStats, "other")
+	rebuild, err = s.RebuildStakerStats(ctx, "other")
+	require.Nil(t, err)
+	assert.True(t, rebuild.Changed)
+	assert.Nil(t, rebuild.Before)
+	assert.Equal(t, int64(800), rebuild.After.ActiveTvl)
+}
@@ -0,0 +1,77 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+type StakingDurationStatsPublic struct {
+	AvgStakingDurationBlocks    float64 `json:"avg_staking_duration_blocks"`
+	MedianStakingDurationBlocks float64 `json:"median_staking_duration_blocks"`
+	P95StakingDurationBlocks    uint64  `json:"p95_staking_duration_blocks"`
+}
+
+// GetStakingDurationStats returns the statistics of the staking timelock of
+// the active delegations, the intended staking duration in BTC blocks
+func (s *V1Service) GetStakingDurationStats(ctx context.Context) (*StakingDurationStatsPublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1StakingDurationAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	counts, err := s.Service.DbClients.V1DBClient.CountActiveDelegationsByTimelock(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count active delegations by timelock")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return stakingDurationStats(counts), nil
+}
+
+// stakingDurationStats computes the statistics from the number of delegations
+// per timelock. The median of an even number of delegations is the mean of the
+// two middle timelocks, the 95th percentile is the nearest rank one.
+func stakingDurationStats(countsByTimelock map[uint64]int64) *StakingDurationStatsPublic {
+	timelocks := make([]uint64, 0, len(countsByTimelock))
+	var total int64
+	var sum float64
+	for timelock, count := range countsByTimelock {
+		timelocks = append(timelocks, timelock)
+		total += count
+		sum += float64(timelock) * float64(count)
+	}
+	if total == 0 {
+		return &StakingDurationStatsPublic{}
+	}
+	sort.Slice(timelocks, func(i, j int) bool { return timelocks[i] < timelocks[j] })
+
+	// timelockAt returns the timelock of the delegation at the given 1-based
+	// rank in ascending timelock order
+	timelockAt := func(rank int64) uint64 {
+		var seen int64
+		for _, timelock := range timelocks {
+			seen += countsByTimelock[timelock]
+			if seen >= rank {
+				return timelock
+			}
+		}
+		return timelocks[len(timelocks)-1]
+	}
+
+	median := float64(timelockAt((total + 1) / 2))
+	if total%2 == 0 {
+		median = (median + float64(timelockAt(total/2+1))) / 2
+	}
+	// nearest rank, ceil(0.95 * total)
+	p95Rank := (total*95 + 99) / 100
+
+	return &StakingDurationStatsPublic{
+		AvgStakingDurationBlocks:    sum / float64(total),
+		MedianStakingDurationBlocks: median,
+		P95StakingDurationBlocks:    timelockAt(p95Rank),
+	}
+}
@@ -0,0 +1,79 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStakingDurationStats(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+	// timelocks 100, 100, 100, 200 and 1000
+	v1DB.On("CountActiveDelegationsByTimelock", ctx).Return(map[uint64]int64{
+		100: 3, 200: 1, 1000: 1,
+	}, nil).Once()
+
+	stats, err := s.GetStakingDurationStats(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, &StakingDurationStatsPublic{
+		AvgStakingDurationBlocks:    300,
+		MedianStakingDurationBlocks: 100,
+		P95StakingDurationBlocks:    1000,
+	}, stats)
+}
+
+func TestStakingDurationStats(t *testing.T) {
+	testCases := []struct {
+		name     string
+		counts   map[uint64]int64
+		expected *StakingDurationStatsPublic
+	}{
+		{
+			name:     "no active delegation",
+			counts:   map[uint64]int64{},
+			expected: &StakingDurationStatsPublic{},
+		},
+		{
+			name:   "single delegation",
+			counts: map[uint64]int64{64000: 1},
+			expected: &StakingDurationStatsPublic{
+				AvgStakingDurationBlocks:    64000,
+				MedianStakingDurationBlocks: 64000,
+				P95StakingDurationBlocks:    64000,
+			},
+		},
+		{
+			name:   "even number of delegations averages the middle timelocks",
+			counts: map[uint64]int64{150: 2, 300: 1, 64000: 1},
+			expected: &StakingDurationStatsPublic{
+				AvgStakingDurationBlocks:    16150,
+				MedianStakingDurationBlocks: 225,
+				P95StakingDurationBlocks:    64000,
+			},
+		},
+		{
+			name:   "95th percentile ignores the top 5%",
+			counts: map[uint64]int64{100: 19, 500: 1},
+			expected: &StakingDurationStatsPublic{
+				AvgStakingDurationBlocks:    120,
+				MedianStakingDurationBlocks: 100,
+				P95StakingDurationBlocks:    100,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, stakingDurationStats(tc.counts))
+		})
+	}
+}
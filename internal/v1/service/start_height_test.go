@@ -0,0 +1,100 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationsByStartHeight(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	indexerDB := mocks.NewIndexerDBClient(t)
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB},
+	}}
+	indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(100), nil)
+	indexerDB.On("GetFinalityProviders", ctx).Return(nil, nil)
+
+	const height = uint64(850000)
+	created := func(stakingTxHashHex, stakerPkHex string) v1dbmodel.DelegationDocument {
+		return v1dbmodel.DelegationDocument{
+			StakingTxHashHex: stakingTxHashHex,
+			StakerPkHex:      stakerPkHex,
+			StakingValue:     1000,
+			State:            types.Active,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: height, TimeLock: 20},
+		}
+	}
+
+	t.Run("block without delegation", func(t *testing.T) {
+		v1DB.On("FindDelegationsByStartHeight", ctx, uint64(1), "").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{}, nil).Once()
+
+		delegations, paginationToken, err := s.DelegationsByStartHeight(ctx, 1, "")
+		require.Nil(t, err)
+		assert.Empty(t, paginationToken)
+		assert.NotNil(t, delegations)
+		assert.Empty(t, delegations)
+	})
+
+	t.Run("block with one delegation", func(t *testing.T) {
+		v1DB.On("FindDelegationsByStartHeight", ctx, height, "").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+				Data: []v1dbmodel.DelegationDocument{created("tx1", "staker1")},
+			}, nil).Once()
+
+		delegations, paginationToken, err := s.DelegationsByStartHeight(ctx, height, "")
+		require.Nil(t, err)
+		assert.Empty(t, paginationToken)
+		require.Len(t, delegations, 1)
+		assert.Equal(t, "tx1", delegations[0].StakingTxHashHex)
+		assert.Equal(t, height, delegations[0].StakingTx.StartHeight)
+	})
+
+	t.Run("block with delegations over several pages", func(t *testing.T) {
+		v1DB.On("FindDelegationsByStartHeight", ctx, height, "").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+				Data:            []v1dbmodel.DelegationDocument{created("tx1", "staker1"), created("tx2", "staker2")},
+				PaginationToken: "next",
+			}, nil).Once()
+		v1DB.On("FindDelegationsByStartHeight", ctx, height, "next").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+				Data: []v1dbmodel.DelegationDocument{created("tx3", "staker1")},
+			}, nil).Once()
+
+		firstPage, paginationToken, err := s.DelegationsByStartHeight(ctx, height, "")
+		require.Nil(t, err)
+		assert.Equal(t, "next", paginationToken)
+		secondPage, paginationToken, err := s.DelegationsByStartHeight(ctx, height, paginationToken)
+		require.Nil(t, err)
+		assert.Empty(t, paginationToken)
+
+		var txHashes []string
+		for _, d := range append(firstPage, secondPage...) {
+			txHashes = append(txHashes, d.StakingTxHashHex)
+		}
+		assert.Equal(t, []string{"tx1", "tx2", "tx3"}, txHashes)
+	})
+
+	t.Run("invalid pagination token", func(t *testing.T) {
+		v1DB.On("FindDelegationsByStartHeight", ctx, height, "invalid").
+			Return(nil, &db.InvalidPaginationTokenError{Message: "invalid"}).Once()
+
+		_, _, err := s.DelegationsByStartHeight(ctx, height, "invalid")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+}
@@ -0,0 +1,62 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationStateHistory(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	indexerDB := mocks.NewIndexerDBClient(t)
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB},
+	}}
+	indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(100), nil)
+	indexerDB.On("GetFinalityProviders", ctx).Return(nil, nil)
+
+	// the transitions recorded over the full lifecycle of a delegation
+	v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(&v1dbmodel.DelegationDocument{
+		StakingTxHashHex: "tx",
+		State:            types.Withdrawn,
+		StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 10, TimeLock: 20},
+		StateHistory: []v1dbmodel.StateTransition{
+			{ToState: types.Active, Timestamp: 1700000000, TxHashHex: "tx"},
+			{FromState: types.Active, ToState: types.UnbondingRequested, Timestamp: 1700000100, TxHashHex: "unbonding-tx"},
+			{FromState: types.UnbondingRequested, ToState: types.Unbonding, Timestamp: 1700000200},
+			{FromState: types.Unbonding, ToState: types.Unbonded, Timestamp: 1700000300},
+			{FromState: types.Unbonded, ToState: types.Withdrawn, Timestamp: 1700000400, TxHashHex: "withdrawal-tx"},
+		},
+	}, nil)
+
+	t.Run("history is only included if requested", func(t *testing.T) {
+		delegation, err := s.GetDelegation(ctx, "tx")
+		require.Nil(t, err)
+		assert.Nil(t, delegation.StateHistory)
+	})
+
+	t.Run("history lists the transitions in order", func(t *testing.T) {
+		delegation, err := s.GetDelegation(ctx, "tx")
+		require.Nil(t, err)
+		delegation.AddStateHistory()
+		assert.Equal(t, []StateTransitionPublic{
+			{ToState: "active", Timestamp: utils.ParseTimestampToIsoFormat(1700000000), TxHashHex: "tx"},
+			{FromState: "active", ToState: "unbonding_requested", Timestamp: utils.ParseTimestampToIsoFormat(1700000100), TxHashHex: "unbonding-tx"},
+			{FromState: "unbonding_requested", ToState: "unbonding", Timestamp: utils.ParseTimestampToIsoFormat(1700000200)},
+			{FromState: "unbonding", ToState: "unbonded", Timestamp: utils.ParseTimestampToIsoFormat(1700000300)},
+			{FromState: "unbonded", ToState: "withdrawn", Timestamp: utils.ParseTimestampToIsoFormat(1700000400), TxHashHex: "withdrawal-tx"},
+		}, delegation.StateHistory)
+	})
+}
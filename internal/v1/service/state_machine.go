@@ -0,0 +1,178 @@
+package v1service
+
+import (
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+)
+
+type StateTransitionTrigger string
+
+const (
+	ApiTrigger        StateTransitionTrigger = "api"
+	QueueEventTrigger StateTransitionTrigger = "queue-event"
+	JobTrigger        StateTransitionTrigger = "job"
+)
+
+type StateMachineStatePublic struct {
+	State string `json:"state"`
+	// Reachable is false for the states never set on the documents of this
+	// state machine
+	Reachable bool `json:"reachable"`
+	Initial   bool `json:"initial"`
+	// Terminal is true for the reachable states without outgoing transition
+	Terminal bool `json:"terminal"`
+}
+
+type StateMachineTransitionPublic struct {
+	From    string                 `json:"from"`
+	To      string                 `json:"to"`
+	Trigger StateTransitionTrigger `json:"trigger"`
+	Source  string                 `json:"source"`
+}
+
+type StateMachinePublic struct {
+	States      []StateMachineStatePublic      `json:"states"`
+	Transitions []StateMachineTransitionPublic `json:"transitions"`
+}
+
+type StateMachinesPublic struct {
+	Delegation       *StateMachinePublic `json:"delegation"`
+	UnbondingAttempt *StateMachinePublic `json:"unbonding_attempt"`
+}
+
+// stateTransitionRule is a transition to a state from the eligible previous
+// states, which are the ones the db write of the transition filters on
+type stateTransitionRule struct {
+	to      string
+	from    []string
+	trigger StateTransitionTrigger
+	source  string
+}
+
+// delegationTransitionRules lists the transitions of the phase-1 delegations
+func delegationTransitionRules() []stateTransitionRule {
+	return []stateTransitionRule{
+		{
+			to:      types.UnbondingRequested.ToString(),
+			from:    delegationStates(utils.QualifiedStatesToUnbondingRequest()),
+			trigger: ApiTrigger,
+			source:  "POST /v1/unbonding",
+		},
+		{
+			to:      types.Unbonding.ToString(),
+			from:    delegationStates(utils.QualifiedStatesToUnbonding()),
+			trigger: QueueEventTrigger,
+			source:  "unbonding staking event",
+		},
+		{
+			to: types.Unbonded.ToString(),
+			from: delegationStates(append(
+				utils.QualifiedStatesToUnbonded(types.ActiveTxType),
+				utils.QualifiedStatesToUnbonded(types.UnbondingTxType)...,
+			)),
+			trigger: JobTrigger,
+			source:  "timelock expiry check",
+		},
+		{
+			to:      types.Withdrawn.ToString(),
+			from:    delegationStates(utils.QualifiedStatesToWithdraw()),
+			trigger: QueueEventTrigger,
+			source:  "withdrawn staking event",
+		},
+		{
+			to:      types.Transitioned.ToString(),
+			from:    delegationStates(utils.QualifiedStatesToTransitioned()),
+			trigger: QueueEventTrigger,
+			source:  "phase-2 active staking event",
+		},
+	}
+}
+
+// phase2OnlyDelegationStates are carried by the phase-2 staking events but
+// never set on the phase-1 delegations
+func phase2OnlyDelegationStates() []string {
+	return []string{types.Withdrawable.ToString(), types.Slashed.ToString()}
+}
+
+// unbondingAttemptTransitionRules lists the transitions of the unbonding
+// documents, the unbonding requests handed over to the unbonding pipeline
+func unbondingAttemptTransitionRules() []stateTransitionRule {
+	inserted := []string{v1dbmodel.UnbondingInitialState}
+	return []stateTransitionRule{
+		{
+			to: v1dbmodel.UnbondingSendState, from: inserted,
+			trigger: JobTrigger, source: "unbonding pipeline",
+		},
+		{
+			to: v1dbmodel.UnbondingInputAlreadySpentState, from: inserted,
+			trigger: JobTrigger, source: "unbonding pipeline",
+		},
+		{
+			to: v1dbmodel.UnbondingFailedState, from: inserted,
+			trigger: JobTrigger, source: "unbonding pipeline",
+		},
+		{
+			to: v1dbmodel.UnbondingSkippedState, from: inserted,
+			trigger: JobTrigger, source: "unbonding integrity check",
+		},
+	}
+}
+
+// GetStateMachines returns the state transition graphs of the delegations
+// and of their unbonding attempts, built from the transition rules enforced
+// by the db writes
+func (s *V1Service) GetStateMachines() *StateMachinesPublic {
+	return &StateMachinesPublic{
+		Delegation: buildStateMachine(
+			[]string{types.Active.ToString()}, delegationTransitionRules(), phase2OnlyDelegationStates(),
+		),
+		UnbondingAttempt: buildStateMachine(
+			[]string{v1dbmodel.UnbondingInitialState}, unbondingAttemptTransitionRules(), nil,
+		),
+	}
+}
+
+// buildStateMachine lists the states in the order they appear in the
+// transitions, followed by the unreachable ones
+func buildStateMachine(initialStates []string, rules []stateTransitionRule, unreachableStates []string) *StateMachinePublic {
+	machine := &StateMachinePublic{
+		States:      []StateMachineStatePublic{},
+		Transitions: []StateMachineTransitionPublic{},
+	}
+	stateIndexes := make(map[string]int)
+	addState := func(state string, reachable bool) {
+		if _, ok := stateIndexes[state]; ok {
+			return
+		}
+		stateIndexes[state] = len(machine.States)
+		machine.States = append(machine.States, StateMachineStatePublic{State: state, Reachable: reachable, Terminal: reachable})
+	}
+
+	for _, state := range initialStates {
+		addState(state, true)
+		machine.States[stateIndexes[state]].Initial = true
+	}
+	for _, rule := range rules {
+		for _, from := range rule.from {
+			addState(from, true)
+			addState(rule.to, true)
+			machine.States[stateIndexes[from]].Terminal = false
+			machine.Transitions = append(machine.Transitions, StateMachineTransitionPublic{
+				From: from, To: rule.to, Trigger: rule.trigger, Source: rule.source,
+			})
+		}
+	}
+	for _, state := range unreachableStates {
+		addState(state, false)
+	}
+	return machine
+}
+
+func delegationStates(states []types.DelegationState) []string {
+	result := make([]string, 0, len(states))
+	for _, state := range states {
+		result = append(result, state.ToString())
+	}
+	return result
+}
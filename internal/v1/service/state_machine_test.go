@@ -0,0 +1,157 @@
+package v1service
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseStringConstants returns the string constants of the source file
+// accepted by the filter on their name and declared type
+func parseStringConstants(t *testing.T, path string, filter func(name, typeName string) bool) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	require.NoError(t, err)
+
+	var values []string
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			typeName := ""
+			if ident, ok := valueSpec.Type.(*ast.Ident); ok {
+				typeName = ident.Name
+			}
+			for i, name := range valueSpec.Names {
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING || !filter(name.Name, typeName) {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				values = append(values, value)
+			}
+		}
+	}
+	require.NotEmpty(t, values)
+	return values
+}
+
+func machineStates(machine *StateMachinePublic) map[string]StateMachineStatePublic {
+	states := make(map[string]StateMachineStatePublic, len(machine.States))
+	for _, state := range machine.States {
+		states[state.State] = state
+	}
+	return states
+}
+
+func hasTransition(machine *StateMachinePublic, from, to string) bool {
+	for _, transition := range machine.Transitions {
+		if transition.From == from && transition.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStateMachinesAreComplete(t *testing.T) {
+	machines := (&V1Service{}).GetStateMachines()
+
+	t.Run("every delegation state constant is in the graph", func(t *testing.T) {
+		states := machineStates(machines.Delegation)
+		for _, state := range parseStringConstants(t, "../../shared/types/delegation.go", func(_, typeName string) bool {
+			return typeName == "DelegationState"
+		}) {
+			assert.Contains(t, states, state)
+		}
+	})
+
+	t.Run("every unbonding state constant is in the graph", func(t *testing.T) {
+		states := machineStates(machines.UnbondingAttempt)
+		for _, state := range parseStringConstants(t, "../db/model/unbonding.go", func(name, _ string) bool {
+			return strings.HasPrefix(name, "Unbonding") && strings.HasSuffix(name, "State")
+		}) {
+			assert.Contains(t, states, state)
+		}
+	})
+
+	t.Run("every qualified states rule is registered", func(t *testing.T) {
+		file, err := parser.ParseFile(token.NewFileSet(), "../../shared/utils/state_transition.go", nil, 0)
+		require.NoError(t, err)
+		var ruleFuncs []string
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && strings.HasPrefix(funcDecl.Name.Name, "QualifiedStatesTo") {
+				ruleFuncs = append(ruleFuncs, funcDecl.Name.Name)
+			}
+		}
+		require.NotEmpty(t, ruleFuncs)
+
+		file, err = parser.ParseFile(token.NewFileSet(), "state_machine.go", nil, 0)
+		require.NoError(t, err)
+		registered := make(map[string]bool)
+		ast.Inspect(file, func(node ast.Node) bool {
+			if selector, ok := node.(*ast.SelectorExpr); ok {
+				if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Name == "utils" {
+					registered[selector.Sel.Name] = true
+				}
+			}
+			return true
+		})
+		for _, ruleFunc := range ruleFuncs {
+			assert.True(t, registered[ruleFunc], "%s is not part of the state machine", ruleFunc)
+		}
+	})
+
+	t.Run("every enforced delegation transition is represented", func(t *testing.T) {
+		enforced := map[types.DelegationState][]types.DelegationState{
+			types.UnbondingRequested: utils.QualifiedStatesToUnbondingRequest(),
+			types.Unbonding:          utils.QualifiedStatesToUnbonding(),
+			types.Withdrawn:          utils.QualifiedStatesToWithdraw(),
+			types.Transitioned:       utils.QualifiedStatesToTransitioned(),
+			types.Unbonded: append(
+				utils.QualifiedStatesToUnbonded(types.ActiveTxType),
+				utils.QualifiedStatesToUnbonded(types.UnbondingTxType)...,
+			),
+		}
+		for to, froms := range enforced {
+			for _, from := range froms {
+				assert.True(t, hasTransition(machines.Delegation, from.ToString(), to.ToString()),
+					"missing transition from %s to %s", from, to)
+			}
+		}
+	})
+}
+
+func TestStateMachineFlags(t *testing.T) {
+	machines := (&V1Service{}).GetStateMachines()
+
+	delegationStates := machineStates(machines.Delegation)
+	assert.True(t, delegationStates["active"].Initial)
+	assert.False(t, delegationStates["active"].Terminal)
+	assert.True(t, delegationStates["withdrawn"].Terminal)
+	assert.True(t, delegationStates["transitioned"].Terminal)
+	assert.False(t, delegationStates["unbonding"].Terminal)
+	assert.False(t, delegationStates["slashed"].Reachable)
+	assert.False(t, delegationStates["slashed"].Terminal)
+
+	for _, transition := range machines.Delegation.Transitions {
+		if transition.To == "unbonding_requested" {
+			assert.Equal(t, ApiTrigger, transition.Trigger)
+		}
+	}
+
+	unbondingStates := machineStates(machines.UnbondingAttempt)
+	assert.True(t, unbondingStates["INSERTED"].Initial)
+	assert.True(t, unbondingStates["SKIPPED"].Terminal)
+	assert.True(t, hasTransition(machines.UnbondingAttempt, "INSERTED", "SEND"))
+}
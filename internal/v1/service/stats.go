@@ -5,35 +5,78 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	"github.com/rs/zerolog/log"
 )
 
 type OverallStatsPublic struct {
 	ActiveTvl         int64  `json:"active_tvl"`
+	ActiveTvlBtc      string `json:"active_tvl_btc,omitempty"`
 	TotalTvl          int64  `json:"total_tvl"`
+	TotalTvlBtc       string `json:"total_tvl_btc,omitempty"`
 	ActiveDelegations int64  `json:"active_delegations"`
 	TotalDelegations  int64  `json:"total_delegations"`
 	TotalStakers      uint64 `json:"total_stakers"`
 	UnconfirmedTvl    uint64 `json:"unconfirmed_tvl"`
+	UnconfirmedTvlBtc string `json:"unconfirmed_tvl_btc,omitempty"`
 	PendingTvl        uint64 `json:"pending_tvl"`
+	PendingTvlBtc     string `json:"pending_tvl_btc,omitempty"`
+	// WithdrawnTvl splits the value of the withdrawn delegations by
+	// withdrawal path
+	WithdrawnTvl WithdrawnTvlPublic `json:"withdrawn_tvl"`
+}
+
+type WithdrawnTvlPublic struct {
+	EarlyUnbonding    int64  `json:"early_unbonding"`
+	EarlyUnbondingBtc string `json:"early_unbonding_btc,omitempty"`
+	NaturalExpiry     int64  `json:"natural_expiry"`
+	NaturalExpiryBtc  string `json:"natural_expiry_btc,omitempty"`
+	// Unknown is for the delegations withdrawn before the path was recorded
+	// and whose history does not tell it
+	Unknown    int64  `json:"unknown"`
+	UnknownBtc string `json:"unknown_btc,omitempty"`
 }
 
 type StakerStatsPublic struct {
 	StakerPkHex       string `json:"staker_pk_hex"`
 	ActiveTvl         int64  `json:"active_tvl"`
+	ActiveTvlBtc      string `json:"active_tvl_btc,omitempty"`
 	TotalTvl          int64  `json:"total_tvl"`
+	TotalTvlBtc       string `json:"total_tvl_btc,omitempty"`
 	ActiveDelegations int64  `json:"active_delegations"`
 	TotalDelegations  int64  `json:"total_delegations"`
 }
 
+// AddFormattedFields fills the BTC denominated amounts next to the satoshi ones
+func (s *OverallStatsPublic) AddFormattedFields() {
+	s.ActiveTvlBtc = utils.FormatSatoshisAsBtc(s.ActiveTvl)
+	s.TotalTvlBtc = utils.FormatSatoshisAsBtc(s.TotalTvl)
+	s.UnconfirmedTvlBtc = utils.FormatUnsignedSatoshisAsBtc(s.UnconfirmedTvl)
+	s.PendingTvlBtc = utils.FormatUnsignedSatoshisAsBtc(s.PendingTvl)
+	s.WithdrawnTvl.EarlyUnbondingBtc = utils.FormatSatoshisAsBtc(s.WithdrawnTvl.EarlyUnbonding)
+	s.WithdrawnTvl.NaturalExpiryBtc = utils.FormatSatoshisAsBtc(s.WithdrawnTvl.NaturalExpiry)
+	s.WithdrawnTvl.UnknownBtc = utils.FormatSatoshisAsBtc(s.WithdrawnTvl.Unknown)
+}
+
+// AddFormattedFields fills the BTC denominated amounts next to the satoshi ones
+func (s *StakerStatsPublic) AddFormattedFields() {
+	s.ActiveTvlBtc = utils.FormatSatoshisAsBtc(s.ActiveTvl)
+	s.TotalTvlBtc = utils.FormatSatoshisAsBtc(s.TotalTvl)
+}
+
 // ProcessStakingStatsCalculation calculates the staking stats and updates the database.
 // This method tolerates duplicated calls, only the first call will be processed.
 func (s *V1Service) ProcessStakingStatsCalculation(
 	ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
 	state types.DelegationState, amount uint64,
 ) *types.Error {
+	defer s.invalidateStatsCache(ctx)
+
 	// Fetch existing or initialize the stats lock document if not exist
 	statsLockDocument, err := s.Service.DbClients.V1DBClient.GetOrCreateStatsLock(
 		ctx, stakingTxHashHex, state.ToString(),
@@ -153,8 +196,28 @@ func (s *V1Service) ProcessStakingStatsCalculation(
 	return nil
 }
 
+// GetOverallStats returns the overall stats, cached until the next stats
+// update or for the configured ttl. The returned stats are a copy of the
+// cached ones, they can be modified.
 func (s *V1Service) GetOverallStats(
 	ctx context.Context,
+) (*OverallStatsPublic, *types.Error) {
+	cached, err := cache.GetOrLoad(
+		s.Service.Cache, config.V1OverallStatsCacheEndpoint, "",
+		s.Service.ResponseCacheTtl(config.V1OverallStatsCacheEndpoint),
+		func() (*OverallStatsPublic, *types.Error) {
+			return s.loadOverallStats(ctx)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	stats := *cached
+	return &stats, nil
+}
+
+func (s *V1Service) loadOverallStats(
+	ctx context.Context,
 ) (*OverallStatsPublic, *types.Error) {
 	stats, err := s.Service.DbClients.V1DBClient.GetOverallStats(ctx)
 	if err != nil {
@@ -192,6 +255,11 @@ func (s *V1Service) GetOverallStats(
 		TotalStakers:      stats.TotalStakers,
 		UnconfirmedTvl:    unconfirmedTvl,
 		PendingTvl:        pendingTvl,
+		WithdrawnTvl: WithdrawnTvlPublic{
+			EarlyUnbonding: stats.WithdrawnTvlEarlyUnbonding,
+			NaturalExpiry:  stats.WithdrawnTvlNaturalExpiry,
+			Unknown:        stats.WithdrawnTvlUnknown,
+		},
 	}, nil
 }
 
@@ -252,5 +320,22 @@ func (s *V1Service) ProcessBtcInfoStats(
 		log.Ctx(ctx).Error().Err(err).Msg("error while upserting latest btc info")
 		return types.NewInternalServiceError(err)
 	}
+	// The overall stats carry the tvl of the latest btc info
+	s.invalidateStatsCache(ctx)
 	return nil
 }
+
+// invalidateStatsCache drops the cached responses built from the stats. It
+// runs after every stats update, failed ones included as they may be partial,
+// once the transaction the update is part of is over so that the responses
+// are not cached again from the stats before the commit.
+func (s *V1Service) invalidateStatsCache(ctx context.Context) {
+	if s.Service.Cache == nil {
+		return
+	}
+	service.AfterTransaction(ctx, func() {
+		s.Service.Cache.Invalidate(
+			config.V1OverallStatsCacheEndpoint, config.V1FinalityProvidersCacheEndpoint,
+		)
+	})
+}
@@ -0,0 +1,47 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverallStatsCache(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		Cache:     cache.NewMemoryCache(),
+	}}
+
+	v1DB.On("GetOverallStats", ctx).Return(&v1dbmodel.OverallStatsDocument{TotalTvl: 1000}, nil).Once()
+	v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{ConfirmedTvl: 100, UnconfirmedTvl: 150}, nil).Once()
+
+	// the second request is served from the cache
+	for i := 0; i < 2; i++ {
+		stats, err := s.GetOverallStats(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, int64(100), stats.ActiveTvl)
+	}
+
+	// a new btc info invalidates the cached stats
+	v1DB.On("UpsertLatestBtcInfo", ctx, uint64(10), uint64(200), uint64(250)).Return(nil).Once()
+	require.Nil(t, s.ProcessBtcInfoStats(ctx, 10, 200, 250))
+
+	v1DB.On("GetOverallStats", ctx).Return(&v1dbmodel.OverallStatsDocument{TotalTvl: 1000}, nil).Once()
+	v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{ConfirmedTvl: 200, UnconfirmedTvl: 250}, nil).Once()
+	stats, err := s.GetOverallStats(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, int64(200), stats.ActiveTvl)
+}
@@ -2,9 +2,7 @@ package v1service
 
 import (
 	"context"
-	"net/http"
 
-	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	"github.com/rs/zerolog/log"
@@ -32,17 +30,11 @@ func (s *V1Service) ProcessExpireCheck(
 func (s *V1Service) TransitionToUnbondedState(
 	ctx context.Context, stakingType types.StakingTxType, stakingTxHashHex string,
 ) *types.Error {
-	err := s.Service.DbClients.V1DBClient.TransitionToUnbondedState(ctx, stakingTxHashHex, utils.QualifiedStatesToUnbonded(stakingType))
-	if err != nil {
-		// If the delegation is not found, we can ignore the error, it just means the delegation is not in a state that we can transition to unbonded
-		if db.IsNotFoundError(err) {
-			errMsg := "delegation not found or no longer eligible to be unbonded after timelock expired"
-			log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg(errMsg)
-			return types.NewErrorWithMsg(http.StatusForbidden, types.NotFound, errMsg)
-		}
-		log.Ctx(ctx).Err(err).Str("stakingTxHash", stakingTxHashHex).Msg("Failed to transition to unbonded state")
-		return types.NewInternalServiceError(err)
-	}
-	return nil
-
+	// A not found error only means the delegation is no longer in a state
+	// that can transition to unbonded, callers can ignore it
+	return s.transitionDelegation(ctx, stakingTxHashHex, types.Unbonded, func(ctx context.Context) error {
+		return s.Service.DbClients.V1DBClient.TransitionToUnbondedState(
+			ctx, stakingTxHashHex, utils.QualifiedStatesToUnbonded(stakingType),
+		)
+	})
 }
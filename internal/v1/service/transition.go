@@ -0,0 +1,44 @@
+package v1service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// transitionDelegation runs the write moving a delegation to the given state
+// and maps its outcome the same way for every entry point, HTTP or queue.
+// The write is expected to compare-and-set the state against the states
+// eligible for the transition, together with the side effects of the
+// transition, and return a NotFoundError when the delegation is missing or
+// not eligible, so that a duplicated transition is rejected.
+func (s *V1Service) transitionDelegation(
+	ctx context.Context, stakingTxHashHex string, to types.DelegationState,
+	write func(ctx context.Context) error,
+) *types.Error {
+	err := write(ctx)
+	if err == nil {
+		return nil
+	}
+
+	logger := log.Ctx(ctx).With().
+		Str("stakingTxHashHex", stakingTxHashHex).
+		Str("state", to.ToString()).
+		Logger()
+	switch {
+	case db.IsDuplicateKeyError(err):
+		logger.Warn().Err(err).Msg("delegation transition already submitted")
+		return types.NewError(http.StatusForbidden, types.Forbidden, err)
+	case db.IsNotFoundError(err):
+		errMsg := fmt.Sprintf("delegation not found or no longer eligible to transition to %s", to.ToString())
+		logger.Warn().Err(err).Msg(errMsg)
+		return types.NewErrorWithMsg(http.StatusForbidden, types.NotFound, errMsg)
+	default:
+		logger.Error().Err(err).Msg("failed to transition delegation")
+		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	}
+}
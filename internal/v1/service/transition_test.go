@@ -0,0 +1,108 @@
+package v1service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDelegationStates applies the state compare-and-set of the v1 database
+// client to in memory delegations
+type fakeDelegationStates map[string]types.DelegationState
+
+func (f fakeDelegationStates) transition(
+	stakingTxHashHex string, to types.DelegationState, eligible []types.DelegationState,
+) error {
+	state, ok := f[stakingTxHashHex]
+	if !ok || !slices.Contains(eligible, state) {
+		return &db.NotFoundError{Key: stakingTxHashHex, Message: "not eligible"}
+	}
+	f[stakingTxHashHex] = to
+	return nil
+}
+
+func TestTransitionDelegationParity(t *testing.T) {
+	ctx := context.Background()
+	states := fakeDelegationStates{}
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("TransitionToUnbondingState", ctx, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, txHashHex string, _, _, _ uint64, _ string, _ int64) error {
+			return states.transition(txHashHex, types.Unbonding, utils.QualifiedStatesToUnbonding())
+		})
+	v1DB.On("TransitionToUnbondedState", ctx, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, txHashHex string, eligible []types.DelegationState) error {
+			return states.transition(txHashHex, types.Unbonded, eligible)
+		})
+	v1DB.On("TransitionToWithdrawnState", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, txHashHex string, _ *v1dbmodel.WithdrawalTransaction, _ v1dbmodel.WithdrawalPath, _ uint64) error {
+			return states.transition(txHashHex, types.Withdrawn, utils.QualifiedStatesToWithdraw())
+		})
+	s := &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+
+	entryPoints := map[types.DelegationState]func(stakingTxHashHex string) *types.Error{
+		types.Unbonding: func(stakingTxHashHex string) *types.Error {
+			return s.TransitionToUnbondingState(ctx, stakingTxHashHex, 100, 10, 0, "unbonding-tx", 1700000000)
+		},
+		types.Unbonded: func(stakingTxHashHex string) *types.Error {
+			return s.TransitionToUnbondedState(ctx, types.UnbondingTxType, stakingTxHashHex)
+		},
+		types.Withdrawn: func(stakingTxHashHex string) *types.Error {
+			return s.TransitionToWithdrawnState(
+				ctx, stakingTxHashHex, nil, v1dbmodel.WithdrawalPathNaturalExpiry, 1000,
+			)
+		},
+	}
+
+	// every transition is applied once, its redelivery is rejected the same way
+	states["tx"] = types.Active
+	for _, to := range []types.DelegationState{types.Unbonding, types.Unbonded, types.Withdrawn} {
+		require.Nil(t, entryPoints[to]("tx"))
+		assert.Equal(t, to, states["tx"])
+
+		err := entryPoints[to]("tx")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+		assert.Equal(t, types.NotFound, err.ErrorCode)
+		assert.Equal(t, "delegation not found or no longer eligible to transition to "+to.ToString(), err.Err.Error())
+		assert.Equal(t, to, states["tx"])
+	}
+	for to, transition := range entryPoints {
+		err := transition("unknown-tx")
+		require.NotNil(t, err)
+		assert.Equal(t, types.NotFound, err.ErrorCode, to)
+	}
+}
+
+func TestTransitionDelegationErrors(t *testing.T) {
+	ctx := context.Background()
+	s := &V1Service{Service: &service.Service{}}
+
+	err := s.transitionDelegation(ctx, "tx", types.UnbondingRequested, func(context.Context) error {
+		return &db.DuplicateKeyError{Key: "unbonding-tx", Message: "unbonding transaction already exists"}
+	})
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	assert.Equal(t, types.Forbidden, err.ErrorCode)
+
+	err = s.transitionDelegation(ctx, "tx", types.UnbondingRequested, func(context.Context) error {
+		return errors.New("connection reset")
+	})
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+	assert.Equal(t, types.InternalServiceError, err.ErrorCode)
+}
@@ -2,24 +2,29 @@ package v1service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	"github.com/rs/zerolog/log"
 )
 
 // UnbondDelegation verifies the unbonding request and saves the unbonding tx into the DB.
 // It returns an error if the delegation is not eligible for unbonding or if the unbonding request is invalid.
 // If successful, it will change the delegation state to `unbonding_requested`
+// If ifMatch is set, the request fails before any verification if it does
+// not match the current ETag of the delegation.
 func (s *V1Service) UnbondDelegation(
 	ctx context.Context,
 	stakingTxHashHex,
 	unbondingTxHashHex,
 	unbondingTxHex,
-	signatureHex string) *types.Error {
+	signatureHex,
+	ifMatch string) *types.Error {
 	// 1. check the delegation is eligible for unbonding
 	delegationDoc, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
 	if err != nil {
@@ -31,6 +36,19 @@ func (s *V1Service) UnbondDelegation(
 		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 	}
 
+	if ifMatch != "" && !matchesETag(
+		ifMatch, DelegationETag(delegationDoc.StakingTxHashHex, delegationDoc.State.ToString()),
+	) {
+		log.Ctx(ctx).Warn().
+			Str("stakingTxHashHex", stakingTxHashHex).
+			Str("state", delegationDoc.State.ToString()).
+			Msg("delegation changed since the client fetched it")
+		return types.NewErrorWithMsg(
+			http.StatusPreconditionFailed, types.PreconditionFailed,
+			fmt.Sprintf("delegation has changed, current state is %s", delegationDoc.State.ToString()),
+		)
+	}
+
 	if delegationDoc.State != types.Active {
 		log.Ctx(ctx).Warn().
 			Str("stakingTxHashHex", stakingTxHashHex).
@@ -64,41 +82,162 @@ func (s *V1Service) UnbondDelegation(
 	); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg(fmt.Sprintf("unbonding request did not pass unbonding request verification, staking tx hash: %s, unbonding tx hash: %s",
 			delegationDoc.StakingTxHashHex, unbondingTxHashHex))
+		switch {
+		case errors.Is(err, utils.ErrInvalidUnbondingSignature):
+			return types.NewError(http.StatusBadRequest, types.InvalidSignature, err)
+		case errors.Is(err, utils.ErrInvalidUnbondingTx):
+			return types.NewError(http.StatusBadRequest, types.InvalidUnbondingTx, err)
+		case errors.Is(err, utils.ErrUnbondingTxHashMismatch):
+			return types.NewError(http.StatusBadRequest, types.TxHashMismatch, err)
+		}
 		return types.NewError(http.StatusForbidden, types.ValidationError, err)
 	}
 
 	// 3. save unbonding tx into DB
-	err = s.Service.DbClients.V1DBClient.SaveUnbondingTx(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex)
-	if err != nil {
-		if ok := db.IsDuplicateKeyError(err); ok {
-			log.Ctx(ctx).Warn().Err(err).Msg("unbonding request already been submitted into the system")
-			return types.NewError(http.StatusForbidden, types.Forbidden, err)
-		} else if ok := db.IsNotFoundError(err); ok {
-			log.Ctx(ctx).Warn().Err(err).Msg("no active delegation found for unbonding request")
-			return types.NewError(http.StatusForbidden, types.Forbidden, err)
-		}
-		log.Ctx(ctx).Error().Err(err).Msg("failed to save unbonding tx")
-		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	transitionErr := s.transitionDelegation(ctx, stakingTxHashHex, types.UnbondingRequested, func(ctx context.Context) error {
+		return s.Service.DbClients.V1DBClient.SaveUnbondingTx(
+			ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex,
+		)
+	})
+	if transitionErr != nil {
+		return transitionErr
 	}
+	s.PublishDelegationStateChange(stakingTxHashHex, delegationDoc.StakerPkHex, types.UnbondingRequested)
 	return nil
 }
 
-func (s *V1Service) IsEligibleForUnbondingRequest(ctx context.Context, stakingTxHashHex string) *types.Error {
+type UnbondingIneligibilityReason string
+
+const (
+	UnbondingIneligibleNotFound         UnbondingIneligibilityReason = "not_found"
+	UnbondingIneligibleWrongState       UnbondingIneligibilityReason = "wrong_state"
+	UnbondingIneligibleAlreadyRequested UnbondingIneligibilityReason = "already_requested"
+)
+
+// UnbondingEligibilityPublic is the unbonding eligibility of a delegation,
+// Reason is only set if the delegation is not eligible
+type UnbondingEligibilityPublic struct {
+	StakingTxHashHex string                       `json:"staking_tx_hash_hex"`
+	Eligible         bool                         `json:"eligible"`
+	Reason           UnbondingIneligibilityReason `json:"reason,omitempty"`
+}
+
+// unbondingEligibility checks the unbonding eligibility of a delegation in
+// the given state, found is false if the delegation does not exist
+func unbondingEligibility(stakingTxHashHex string, state types.DelegationState, found bool) UnbondingEligibilityPublic {
+	eligibility := UnbondingEligibilityPublic{StakingTxHashHex: stakingTxHashHex}
+	switch {
+	case !found:
+		eligibility.Reason = UnbondingIneligibleNotFound
+	case state == types.UnbondingRequested:
+		eligibility.Reason = UnbondingIneligibleAlreadyRequested
+	case state != types.Active:
+		eligibility.Reason = UnbondingIneligibleWrongState
+	default:
+		eligibility.Eligible = true
+	}
+	return eligibility
+}
+
+// UnbondingEligibilityDetailsPublic is the unbonding eligibility of a single
+// delegation. The staking value and the unbonding fee are set if it is
+// eligible, so that the unbonding tx can be built without another request.
+// The unbonding tx hash and completion are set once the unbonding was
+// requested.
+type UnbondingEligibilityDetailsPublic struct {
+	UnbondingEligibilityPublic
+	// State is empty if the delegation is not found
+	State               string                     `json:"state,omitempty"`
+	StakingValue        uint64                     `json:"staking_value,omitempty"`
+	UnbondingFee        uint64                     `json:"unbonding_fee,omitempty"`
+	UnbondingTxHashHex  string                     `json:"unbonding_tx_hash_hex,omitempty"`
+	UnbondingCompletion *UnbondingCompletionPublic `json:"unbonding_completion,omitempty"`
+}
+
+// GetUnbondingEligibility checks the unbonding eligibility of the delegation.
+// A delegation not eligible is not an error, the reason is set instead.
+func (s *V1Service) GetUnbondingEligibility(
+	ctx context.Context, stakingTxHashHex string,
+) (*UnbondingEligibilityDetailsPublic, *types.Error) {
 	delegationDoc, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
 	if err != nil {
-		if ok := db.IsNotFoundError(err); ok {
+		if db.IsNotFoundError(err) {
 			log.Ctx(ctx).Warn().Err(err).Msg("delegation not found, hence not eligible for unbonding")
-			return types.NewErrorWithMsg(http.StatusForbidden, types.NotFound, "delegation not found")
+			return &UnbondingEligibilityDetailsPublic{
+				UnbondingEligibilityPublic: unbondingEligibility(stakingTxHashHex, "", false),
+			}, nil
 		}
-		log.Error().Err(err).Msg("error while fetching delegation")
-		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching delegation")
+		return nil, types.NewInternalServiceError(err)
 	}
 
-	if delegationDoc.State != types.Active {
-		log.Ctx(ctx).Warn().Msg("delegation state is not active, hence not eligible for unbonding")
-		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "delegation state is not active")
+	details := &UnbondingEligibilityDetailsPublic{
+		UnbondingEligibilityPublic: unbondingEligibility(stakingTxHashHex, delegationDoc.State, true),
+		State:                      delegationDoc.State.ToString(),
 	}
-	return nil
+	if details.Eligible {
+		paramsVersion := s.GetVersionedGlobalParamsByHeight(delegationDoc.StakingTx.StartHeight)
+		if paramsVersion == nil {
+			log.Ctx(ctx).Error().Msg("failed to get global params")
+			return nil, types.NewErrorWithMsg(
+				http.StatusInternalServerError, types.InternalServiceError,
+				"failed to get global params based on the staking tx height",
+			)
+		}
+		details.StakingValue = delegationDoc.StakingValue
+		details.UnbondingFee = paramsVersion.UnbondingFee
+		return details, nil
+	}
+
+	log.Ctx(ctx).Warn().
+		Str("stakingTxHashHex", stakingTxHashHex).
+		Str("state", delegationDoc.State.ToString()).
+		Msg("delegation state is not active, hence not eligible for unbonding")
+	details.UnbondingTxHashHex = unbondingRequestTxHashHex(delegationDoc.StateHistory)
+	delegation := s.FromDelegationDocument(delegationDoc, 0, nil)
+	s.AddUnbondingCompletions(ctx, delegation)
+	details.UnbondingCompletion = delegation.UnbondingCompletion
+	return details, nil
+}
+
+// unbondingRequestTxHashHex returns the hash of the unbonding tx requested
+// for the delegation, empty if none is recorded in its state history
+func unbondingRequestTxHashHex(history []v1dbmodel.StateTransition) string {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].ToState == types.UnbondingRequested {
+			return history[i].TxHashHex
+		}
+	}
+	return ""
+}
+
+// GetUnbondingEligibilities checks the unbonding eligibility of every given
+// delegation. The results are returned in the order of the given hashes.
+func (s *V1Service) GetUnbondingEligibilities(
+	ctx context.Context, stakingTxHashHexes []string,
+) ([]*UnbondingEligibilityPublic, *types.Error) {
+	delegationDocs, err := s.Service.DbClients.V1DBClient.FindDelegationsByTxHashHexes(
+		ctx, stakingTxHashHexes,
+	)
+	if err != nil {
+		if db.IsInListTooLargeError(err) {
+			return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, err.Error())
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching delegations")
+		return nil, types.NewInternalServiceError(err)
+	}
+	states := make(map[string]types.DelegationState, len(delegationDocs))
+	for _, delegation := range delegationDocs {
+		states[delegation.StakingTxHashHex] = delegation.State
+	}
+
+	eligibilities := make([]*UnbondingEligibilityPublic, 0, len(stakingTxHashHexes))
+	for _, stakingTxHashHex := range stakingTxHashHexes {
+		state, ok := states[stakingTxHashHex]
+		eligibility := unbondingEligibility(stakingTxHashHex, state, ok)
+		eligibilities = append(eligibilities, &eligibility)
+	}
+	return eligibilities, nil
 }
 
 // TransitionToUnbondingState process the actual confirmed unbonding tx by updating the delegation state to `unbonding`
@@ -108,14 +247,43 @@ func (s *V1Service) TransitionToUnbondingState(
 	unbondingStartHeight, unbondingTimelock, unbondingOutputIndex uint64,
 	unbondingTxHex string, unbondingStartTimestamp int64,
 ) *types.Error {
-	err := s.Service.DbClients.V1DBClient.TransitionToUnbondingState(ctx, stakingTxHashHex, unbondingStartHeight, unbondingTimelock, unbondingOutputIndex, unbondingTxHex, unbondingStartTimestamp)
+	return s.transitionDelegation(ctx, stakingTxHashHex, types.Unbonding, func(ctx context.Context) error {
+		return s.Service.DbClients.V1DBClient.TransitionToUnbondingState(
+			ctx, stakingTxHashHex, unbondingStartHeight, unbondingTimelock,
+			unbondingOutputIndex, unbondingTxHex, unbondingStartTimestamp,
+		)
+	})
+}
+
+// ProcessUnbondingDelegation processes the confirmation of the unbonding tx
+// emitted by the unbonding pipeline. Redelivered events are ignored, while an
+// event for an unknown delegation is returned as not found so that it ends up
+// in the unprocessable messages instead of being lost.
+func (s *V1Service) ProcessUnbondingDelegation(
+	ctx context.Context, stakingTxHashHex string,
+	unbondingStartHeight, unbondingTimelock, unbondingOutputIndex uint64,
+	unbondingTxHex string, unbondingStartTimestamp int64,
+) *types.Error {
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
 	if err != nil {
-		if ok := db.IsNotFoundError(err); ok {
-			log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("delegation not found or no longer eligible for unbonding")
-			return types.NewErrorWithMsg(http.StatusForbidden, types.NotFound, "delegation not found or no longer eligible for unbonding")
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).
+				Msg("delegation not found for the unbonding event")
+			return types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "delegation not found")
 		}
-		log.Ctx(ctx).Error().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("failed to transition to unbonding state")
-		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while fetching delegation")
+		return types.NewInternalServiceError(err)
 	}
-	return nil
+	if utils.Contains(utils.OutdatedStatesForUnbonding(), delegation.State) {
+		log.Ctx(ctx).Debug().Str("stakingTxHashHex", stakingTxHashHex).
+			Str("state", delegation.State.ToString()).
+			Msg("delegation already processed the unbonding event")
+		return nil
+	}
+
+	return s.TransitionToUnbondingState(
+		ctx, stakingTxHashHex, unbondingStartHeight, unbondingTimelock,
+		unbondingOutputIndex, unbondingTxHex, unbondingStartTimestamp,
+	)
 }
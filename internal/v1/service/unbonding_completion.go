@@ -0,0 +1,87 @@
+package v1service
+
+import (
+	"context"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// UnbondingCompletionPublic is when the funds of an unbonding delegation can
+// be withdrawn. Until the unbonding tx is confirmed the height is estimated
+// as well, the timestamp is always an estimate.
+type UnbondingCompletionPublic struct {
+	Height    uint64 `json:"height"`
+	Timestamp string `json:"timestamp"`
+	Estimated bool   `json:"estimated"`
+}
+
+// AddUnbondingCompletions fills the estimated unbonding completion of the
+// unbonding delegations. The estimates are left empty if the current btc
+// height is unknown.
+func (s *V1Service) AddUnbondingCompletions(ctx context.Context, delegations ...*DelegationPublic) {
+	var unbonding []*DelegationPublic
+	for _, d := range delegations {
+		if d.State == types.UnbondingRequested.ToString() || d.State == types.Unbonding.ToString() {
+			unbonding = append(unbonding, d)
+		}
+	}
+	if len(unbonding) == 0 {
+		return
+	}
+
+	btcInfo, err := s.Service.DbClients.V1DBClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if !db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Failed to get the latest btc info to estimate the unbonding completion")
+		}
+		return
+	}
+	now := time.Now()
+	for _, d := range unbonding {
+		d.UnbondingCompletion = s.unbondingCompletion(d, btcInfo.BtcHeight, now)
+	}
+}
+
+// unbondingCompletion returns when the unbonding of the delegation completes.
+// Once the unbonding tx is confirmed, it completes after its timelock. Before
+// that, the tx is expected to be confirmed within the configured buffer and
+// the unbonding time of the params of the delegation. It returns nil if the
+// delegation is not unbonding or the btc height is unknown.
+func (s *V1Service) unbondingCompletion(
+	d *DelegationPublic, btcHeight uint64, now time.Time,
+) *UnbondingCompletionPublic {
+	if btcHeight == 0 {
+		return nil
+	}
+
+	var estimateCfg *config.UnbondingEstimateConfig
+	if s.Service.Cfg != nil {
+		estimateCfg = s.Service.Cfg.UnbondingEstimate
+	}
+	completion := &UnbondingCompletionPublic{}
+	switch d.State {
+	case types.Unbonding.ToString():
+		if d.UnbondingTx == nil || d.UnbondingTx.StartHeight == 0 {
+			return nil
+		}
+		completion.Height = d.UnbondingTx.StartHeight + d.UnbondingTx.TimeLock
+	case types.UnbondingRequested.ToString():
+		params := s.GetVersionedGlobalParamsByHeight(d.StakingTx.StartHeight)
+		if params == nil {
+			return nil
+		}
+		completion.Height = btcHeight + estimateCfg.GetConfirmationBufferBlocks() + params.UnbondingTime
+		completion.Estimated = true
+	default:
+		return nil
+	}
+	completion.Timestamp = utils.EstimateHeightTimestamp(
+		btcHeight, completion.Height, estimateCfg.GetBlockInterval(), now,
+	).UTC().Format(time.RFC3339)
+	return completion
+}
@@ -0,0 +1,102 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnbondingCompletion(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		return &V1Service{Service: &service.Service{
+			Cfg: &config.Config{UnbondingEstimate: &config.UnbondingEstimateConfig{
+				ConfirmationBufferBlocks: 3,
+				BlockInterval:            10 * time.Minute,
+			}},
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+			Params: &types.GlobalParams{Versions: []*types.VersionedGlobalParams{
+				{Version: 0, ActivationHeight: 0, UnbondingTime: 100},
+			}},
+		}}
+	}
+
+	t.Run("pre-confirmation estimate starts from the current height", func(t *testing.T) {
+		s := newService(mocks.NewV1DBClient(t))
+		completion := s.unbondingCompletion(&DelegationPublic{
+			State:     types.UnbondingRequested.ToString(),
+			StakingTx: &TransactionPublic{StartHeight: 10},
+		}, 1000, now)
+		require.NotNil(t, completion)
+		assert.True(t, completion.Estimated)
+		assert.Equal(t, uint64(1000+3+100), completion.Height)
+		assert.Equal(t, now.Add(103*10*time.Minute).Format(time.RFC3339), completion.Timestamp)
+	})
+
+	t.Run("post-confirmation completion follows the unbonding timelock", func(t *testing.T) {
+		s := newService(mocks.NewV1DBClient(t))
+		completion := s.unbondingCompletion(&DelegationPublic{
+			State:       types.Unbonding.ToString(),
+			StakingTx:   &TransactionPublic{StartHeight: 10},
+			UnbondingTx: &TransactionPublic{StartHeight: 990, TimeLock: 100},
+		}, 1000, now)
+		require.NotNil(t, completion)
+		assert.False(t, completion.Estimated)
+		assert.Equal(t, uint64(1090), completion.Height)
+		assert.Equal(t, now.Add(90*10*time.Minute).Format(time.RFC3339), completion.Timestamp)
+	})
+
+	t.Run("completion is only set while unbonding", func(t *testing.T) {
+		s := newService(mocks.NewV1DBClient(t))
+		for _, state := range []types.DelegationState{types.Active, types.Unbonded, types.Withdrawn} {
+			assert.Nil(t, s.unbondingCompletion(&DelegationPublic{
+				State:     state.ToString(),
+				StakingTx: &TransactionPublic{StartHeight: 10},
+			}, 1000, now))
+		}
+	})
+
+	t.Run("completions read the current height once", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: 2000}, nil).Once()
+		requested := &DelegationPublic{
+			State:     types.UnbondingRequested.ToString(),
+			StakingTx: &TransactionPublic{StartHeight: 10},
+		}
+		confirmed := &DelegationPublic{
+			State:       types.Unbonding.ToString(),
+			StakingTx:   &TransactionPublic{StartHeight: 10},
+			UnbondingTx: &TransactionPublic{StartHeight: 1990, TimeLock: 100},
+		}
+		active := &DelegationPublic{
+			State:     types.Active.ToString(),
+			StakingTx: &TransactionPublic{StartHeight: 10},
+		}
+
+		newService(v1DB).AddUnbondingCompletions(ctx, requested, confirmed, active)
+		require.NotNil(t, requested.UnbondingCompletion)
+		assert.Equal(t, uint64(2103), requested.UnbondingCompletion.Height)
+		assert.True(t, requested.UnbondingCompletion.Estimated)
+		require.NotNil(t, confirmed.UnbondingCompletion)
+		assert.Equal(t, uint64(2090), confirmed.UnbondingCompletion.Height)
+		assert.False(t, confirmed.UnbondingCompletion.Estimated)
+		assert.Nil(t, active.UnbondingCompletion)
+	})
+
+	t.Run("no height is read without unbonding delegations", func(t *testing.T) {
+		newService(mocks.NewV1DBClient(t)).AddUnbondingCompletions(ctx, &DelegationPublic{
+			State:     types.Active.ToString(),
+			StakingTx: &TransactionPublic{StartHeight: 10},
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type UnbondingSigHashPublic struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	UnbondingTxHex   string `json:"unbonding_tx_hex"`
+	// SigHashHex is the message the staker signature must sign, with the
+	// default taproot sighash type
+	SigHashHex               string `json:"sighash_hex"`
+	ScriptHex                string `json:"script_hex"`
+	LeafHashHex              string `json:"leaf_hash_hex"`
+	StakingOutputPkScriptHex string `json:"staking_output_pk_script_hex"`
+	StakingOutputValue       int64  `json:"staking_output_value"`
+}
+
+// GetUnbondingSigHash returns the sighash the staker signature of the
+// unbonding tx of the delegation is verified against, and the inputs it is
+// computed from. The unbonding tx already known for the delegation is used
+// when none is given.
+func (s *V1Service) GetUnbondingSigHash(
+	ctx context.Context, stakingTxHashHex, unbondingTxHex string,
+) (*UnbondingSigHashPublic, *types.Error) {
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("stakingTxHash", stakingTxHashHex).Msg("Staking delegation not found")
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "staking delegation not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation by tx hash hex")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	if unbondingTxHex == "" {
+		if delegation.UnbondingTx == nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest,
+				"no unbonding tx is known for the delegation, unbonding_tx_hex is required",
+			)
+		}
+		unbondingTxHex = delegation.UnbondingTx.TxHex
+	}
+
+	paramsVersion := s.GetVersionedGlobalParamsByHeight(delegation.StakingTx.StartHeight)
+	if paramsVersion == nil {
+		log.Ctx(ctx).Error().Msg("failed to get global params")
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError,
+			"failed to get global params based on the staking tx height",
+		)
+	}
+
+	sigHash, err := utils.ComputeUnbondingSigHash(
+		unbondingTxHex,
+		delegation.StakerPkHex,
+		delegation.FinalityProviderPkHex,
+		delegation.StakingTx.TimeLock,
+		delegation.StakingValue,
+		paramsVersion,
+		s.Service.Cfg.Server.BTCNetParam,
+	)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidUnbondingTx) {
+			return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, err.Error())
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHash", stakingTxHashHex).Msg("Failed to compute the unbonding sighash")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return &UnbondingSigHashPublic{
+		StakingTxHashHex:         stakingTxHashHex,
+		UnbondingTxHex:           unbondingTxHex,
+		SigHashHex:               hex.EncodeToString(sigHash.SigHash),
+		ScriptHex:                hex.EncodeToString(sigHash.Script),
+		LeafHashHex:              hex.EncodeToString(sigHash.LeafHash),
+		StakingOutputPkScriptHex: hex.EncodeToString(sigHash.StakingOutput.PkScript),
+		StakingOutputValue:       sigHash.StakingOutput.Value,
+	}, nil
+}
@@ -0,0 +1,195 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnbondDelegationIfMatch(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(&v1dbmodel.DelegationDocument{
+		StakingTxHashHex: "tx",
+		State:            types.UnbondingRequested,
+		StakingTx:        &v1dbmodel.TimelockTransaction{},
+	}, nil)
+	s := &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+
+	unbond := func(ifMatch string) *types.Error {
+		return s.UnbondDelegation(ctx, "tx", "unbonding-tx-hash", "unbonding-tx", "signature", ifMatch)
+	}
+
+	t.Run("mismatching", func(t *testing.T) {
+		// the client fetched the delegation while it was still active
+		err := unbond(DelegationETag("tx", types.Active.ToString()))
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, err.StatusCode)
+		assert.Equal(t, types.PreconditionFailed, err.ErrorCode)
+		assert.Equal(t, "delegation has changed, current state is unbonding_requested", err.Err.Error())
+	})
+
+	// the request goes on with the usual verification, which rejects the
+	// delegation as it is no longer active
+	for name, ifMatch := range map[string]string{
+		"matching": DelegationETag("tx", types.UnbondingRequested.ToString()),
+		"wildcard": "*",
+		"absent":   "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := unbond(ifMatch)
+			require.NotNil(t, err)
+			assert.Equal(t, http.StatusForbidden, err.StatusCode)
+			assert.Equal(t, "delegation state is not active", err.Err.Error())
+		})
+	}
+}
+
+func TestDelegationETag(t *testing.T) {
+	etag := DelegationETag("tx", types.Active.ToString())
+	assert.Equal(t, etag, DelegationETag("tx", types.Active.ToString()))
+	assert.NotEqual(t, etag, DelegationETag("tx", types.UnbondingRequested.ToString()))
+	assert.NotEqual(t, etag, DelegationETag("other-tx", types.Active.ToString()))
+
+	assert.True(t, matchesETag(etag, etag))
+	assert.True(t, matchesETag(`"other", `+etag, etag))
+	assert.True(t, matchesETag("*", etag))
+	assert.False(t, matchesETag(`"other"`, etag))
+}
+
+func TestGetUnbondingEligibilities(t *testing.T) {
+	ctx := context.Background()
+	hashes := []string{"active", "missing", "requested", "unbonded", "active"}
+	v1DB := mocks.NewV1DBClient(t)
+	// all the delegations are fetched at once
+	v1DB.On("FindDelegationsByTxHashHexes", ctx, hashes).Return([]v1dbmodel.DelegationDocument{
+		{StakingTxHashHex: "active", State: types.Active},
+		{StakingTxHashHex: "requested", State: types.UnbondingRequested},
+		{StakingTxHashHex: "unbonded", State: types.Unbonded},
+	}, nil).Once()
+	s := &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+
+	eligibilities, err := s.GetUnbondingEligibilities(ctx, hashes)
+	require.Nil(t, err)
+	assert.Equal(t, []*UnbondingEligibilityPublic{
+		{StakingTxHashHex: "active", Eligible: true},
+		{StakingTxHashHex: "missing", Reason: UnbondingIneligibleNotFound},
+		{StakingTxHashHex: "requested", Reason: UnbondingIneligibleAlreadyRequested},
+		{StakingTxHashHex: "unbonded", Reason: UnbondingIneligibleWrongState},
+		{StakingTxHashHex: "active", Eligible: true},
+	}, eligibilities)
+}
+
+func TestGetUnbondingEligibilitiesRejectsTooManyHashes(t *testing.T) {
+	ctx := context.Background()
+	hashes := []string{"a", "b"}
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationsByTxHashHexes", ctx, hashes).
+		Return(nil, &db.InListTooLargeError{Size: 2, MaxSize: 1}).Once()
+	s := &V1Service{Service: &service.Service{DbClients: &dbclients.DbClients{V1DBClient: v1DB}}}
+
+	_, err := s.GetUnbondingEligibilities(ctx, hashes)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
+
+func TestGetUnbondingEligibility(t *testing.T) {
+	ctx := context.Background()
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		return &V1Service{Service: &service.Service{
+			Cfg:       &config.Config{},
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+			Params: &types.GlobalParams{Versions: []*types.VersionedGlobalParams{
+				{Version: 0, ActivationHeight: 0, UnbondingTime: 100, UnbondingFee: 1000},
+			}},
+		}}
+	}
+
+	t.Run("eligible", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(&v1dbmodel.DelegationDocument{
+			StakingTxHashHex: "tx",
+			State:            types.Active,
+			StakingValue:     50000,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 10},
+		}, nil).Once()
+
+		eligibility, err := newService(v1DB).GetUnbondingEligibility(ctx, "tx")
+		require.Nil(t, err)
+		assert.Equal(t, &UnbondingEligibilityDetailsPublic{
+			UnbondingEligibilityPublic: UnbondingEligibilityPublic{StakingTxHashHex: "tx", Eligible: true},
+			State:                      types.Active.ToString(),
+			StakingValue:               50000,
+			UnbondingFee:               1000,
+		}, eligibility)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", ctx, "tx").
+			Return(nil, &db.NotFoundError{Key: "tx", Message: "not found"}).Once()
+
+		eligibility, err := newService(v1DB).GetUnbondingEligibility(ctx, "tx")
+		require.Nil(t, err)
+		assert.Equal(t, &UnbondingEligibilityDetailsPublic{
+			UnbondingEligibilityPublic: UnbondingEligibilityPublic{
+				StakingTxHashHex: "tx", Reason: UnbondingIneligibleNotFound,
+			},
+		}, eligibility)
+	})
+
+	t.Run("already requested", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(&v1dbmodel.DelegationDocument{
+			StakingTxHashHex: "tx",
+			State:            types.UnbondingRequested,
+			StakingValue:     50000,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 10},
+			StateHistory: []v1dbmodel.StateTransition{
+				{ToState: types.Active, TxHashHex: "tx"},
+				{FromState: types.Active, ToState: types.UnbondingRequested, TxHashHex: "unbonding-tx"},
+			},
+		}, nil).Once()
+		v1DB.On("GetLatestBtcInfo", ctx).Return(&v1dbmodel.BtcInfo{BtcHeight: 2000}, nil).Once()
+
+		eligibility, err := newService(v1DB).GetUnbondingEligibility(ctx, "tx")
+		require.Nil(t, err)
+		assert.False(t, eligibility.Eligible)
+		assert.Equal(t, UnbondingIneligibleAlreadyRequested, eligibility.Reason)
+		assert.Equal(t, types.UnbondingRequested.ToString(), eligibility.State)
+		assert.Equal(t, "unbonding-tx", eligibility.UnbondingTxHashHex)
+		assert.Zero(t, eligibility.StakingValue)
+		assert.Zero(t, eligibility.UnbondingFee)
+		require.NotNil(t, eligibility.UnbondingCompletion)
+		assert.Equal(t, uint64(2000+6+100), eligibility.UnbondingCompletion.Height)
+		assert.True(t, eligibility.UnbondingCompletion.Estimated)
+	})
+
+	t.Run("wrong state", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(&v1dbmodel.DelegationDocument{
+			StakingTxHashHex: "tx",
+			State:            types.Withdrawn,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 10},
+		}, nil).Once()
+
+		eligibility, err := newService(v1DB).GetUnbondingEligibility(ctx, "tx")
+		require.Nil(t, err)
+		assert.Equal(t, &UnbondingEligibilityDetailsPublic{
+			UnbondingEligibilityPublic: UnbondingEligibilityPublic{
+				StakingTxHashHex: "tx", Reason: UnbondingIneligibleWrongState,
+			},
+			State: types.Withdrawn.ToString(),
+		}, eligibility)
+	})
+}
@@ -0,0 +1,88 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// ValueConcentrationPublic is the Gini coefficient of the staking values of
+// the active delegations, from 0 when they all stake the same value to 1
+// when a single one holds all the stake
+type ValueConcentrationPublic struct {
+	GiniCoefficient float64 `json:"gini_coefficient"`
+	SampleSize      int64   `json:"sample_size"`
+}
+
+// GetDelegationValueConcentration returns the concentration of the staking
+// values of the active delegations. It is cached for 10 minutes by default.
+func (s *V1Service) GetDelegationValueConcentration(
+	ctx context.Context,
+) (*ValueConcentrationPublic, *types.Error) {
+	return cache.GetOrLoad(
+		s.Service.Cache, config.V1DelegationValueConcentrationCacheEndpoint, "",
+		s.Service.ResponseCacheTtl(config.V1DelegationValueConcentrationCacheEndpoint),
+		func() (*ValueConcentrationPublic, *types.Error) {
+			return s.loadDelegationValueConcentration(ctx)
+		},
+	)
+}
+
+func (s *V1Service) loadDelegationValueConcentration(
+	ctx context.Context,
+) (*ValueConcentrationPublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(
+		ctx, config.V1DelegationValueConcentrationAggregation,
+	)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	counts, err := s.Service.DbClients.V1DBClient.CountActiveDelegationsByStakingValue(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count active delegations by staking value")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return valueConcentration(counts), nil
+}
+
+// valueConcentration computes the Gini coefficient from the number of
+// delegations per staking value. With the n values x_1 <= ... <= x_n it is
+//
+//	G = (2 * sum(i * x_i) / (n * sum(x_i)) - (n + 1) / n) * n / (n - 1)
+//
+// the n / (n - 1) correction of the sample making it 1 rather than
+// (n - 1) / n when a single delegation holds all the stake.
+func valueConcentration(countsByValue map[uint64]int64) *ValueConcentrationPublic {
+	values := make([]uint64, 0, len(countsByValue))
+	var n int64
+	var sum float64
+	for value, count := range countsByValue {
+		values = append(values, value)
+		n += count
+		sum += float64(value) * float64(count)
+	}
+	if n < 2 || sum == 0 {
+		return &ValueConcentrationPublic{SampleSize: n}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	// the delegations of a value hold the consecutive ranks after the ones
+	// of the lower values
+	var rankedSum float64
+	var ranked int64
+	for _, value := range values {
+		count := countsByValue[value]
+		ranksSum := float64(count)*float64(ranked) + float64(count)*float64(count+1)/2
+		rankedSum += float64(value) * ranksSum
+		ranked += count
+	}
+	size := float64(n)
+	gini := (2*rankedSum/(size*sum) - (size+1)/size) * size / (size - 1)
+	return &ValueConcentrationPublic{GiniCoefficient: gini, SampleSize: n}
+}
@@ -0,0 +1,92 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueConcentration(t *testing.T) {
+	testCases := []struct {
+		name         string
+		counts       map[uint64]int64
+		expectedGini float64
+		expectedSize int64
+	}{
+		{
+			name:   "no active delegation",
+			counts: map[uint64]int64{},
+		},
+		{
+			name:         "single delegation",
+			counts:       map[uint64]int64{100000: 1},
+			expectedSize: 1,
+		},
+		{
+			name:         "perfectly equal",
+			counts:       map[uint64]int64{50000: 4},
+			expectedSize: 4,
+		},
+		{
+			name:         "perfectly unequal",
+			counts:       map[uint64]int64{0: 3, 200000: 1},
+			expectedGini: 1,
+			expectedSize: 4,
+		},
+		{
+			// 1, 2, 3 and 4 BTC
+			name: "known distribution",
+			counts: map[uint64]int64{
+				100000000: 1, 200000000: 1, 300000000: 1, 400000000: 1,
+			},
+			expectedGini: 1.0 / 3,
+			expectedSize: 4,
+		},
+		{
+			// 1, 1, 1, 1 and 6 BTC, grouped by value
+			name:         "repeated values",
+			counts:       map[uint64]int64{100000000: 4, 600000000: 1},
+			expectedGini: 0.5,
+			expectedSize: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			concentration := valueConcentration(tc.counts)
+			assert.InDelta(t, tc.expectedGini, concentration.GiniCoefficient, 1e-9)
+			assert.Equal(t, tc.expectedSize, concentration.SampleSize)
+		})
+	}
+}
+
+func TestGetDelegationValueConcentrationIsCached(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+		Cache:     cache.NewMemoryCache(),
+	}}
+	v1DB.On("CountActiveDelegationsByStakingValue", ctx).Return(map[uint64]int64{
+		100000000: 1, 200000000: 1, 300000000: 1, 400000000: 1,
+	}, nil).Once()
+
+	for range 2 {
+		concentration, err := s.GetDelegationValueConcentration(ctx)
+		require.Nil(t, err)
+		assert.InDelta(t, 1.0/3, concentration.GiniCoefficient, 1e-9)
+		assert.Equal(t, int64(4), concentration.SampleSize)
+	}
+	assert.Equal(t, 10*time.Minute, s.ResponseCacheTtl(config.V1DelegationValueConcentrationCacheEndpoint))
+}
@@ -0,0 +1,180 @@
+package v1service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxWatchedStakers is the maximum number of stakers watched by an observer
+const MaxWatchedStakers = 100
+
+type WatchlistAction string
+
+const (
+	AddToWatchlist      WatchlistAction = "add"
+	RemoveFromWatchlist WatchlistAction = "remove"
+)
+
+type WatchlistEntryPublic struct {
+	WatchedPkHex string `json:"watched_pk_hex"`
+	AddedAt      string `json:"added_at"`
+}
+
+// WatchlistMessageHash returns the hash signed by the observer to change its
+// watchlist, the sha256 of "<action>:<watched staker public key hex>"
+func WatchlistMessageHash(action WatchlistAction, watchedPkHex string) []byte {
+	hash := sha256.Sum256([]byte(string(action) + ":" + watchedPkHex))
+	return hash[:]
+}
+
+// GetWatchlist returns the stakers watched by the observer
+func (s *V1Service) GetWatchlist(
+	ctx context.Context, observerPkHex string,
+) ([]*WatchlistEntryPublic, *types.Error) {
+	entries, err := s.Service.DbClients.V1DBClient.FindWatchlistByObserverPk(ctx, observerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find watchlist")
+		return nil, types.NewInternalServiceError(err)
+	}
+	watchlist := make([]*WatchlistEntryPublic, 0, len(entries))
+	for _, entry := range entries {
+		watchlist = append(watchlist, fromWatchlistDocument(&entry))
+	}
+	return watchlist, nil
+}
+
+// AddToWatchlist adds the staker to the watchlist of the observer, who signs
+// the change with the key of its BTC public key. Adding a staker already
+// watched returns the existing entry.
+func (s *V1Service) AddToWatchlist(
+	ctx context.Context, observerPkHex, watchedPkHex, signatureHex string,
+) (*WatchlistEntryPublic, *types.Error) {
+	if observerPkHex == watchedPkHex {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "observer can not watch itself",
+		)
+	}
+	if err := verifyWatchlistSignature(ctx, AddToWatchlist, observerPkHex, watchedPkHex, signatureHex); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.Service.DbClients.V1DBClient.FindWatchlistByObserverPk(ctx, observerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find watchlist")
+		return nil, types.NewInternalServiceError(err)
+	}
+	for _, entry := range entries {
+		if entry.WatchedPkHex == watchedPkHex {
+			return fromWatchlistDocument(&entry), nil
+		}
+	}
+	if len(entries) >= MaxWatchedStakers {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "watchlist is full",
+		)
+	}
+
+	entry := v1dbmodel.NewWatchlistDocument(observerPkHex, watchedPkHex, time.Now().Unix())
+	if err := s.Service.DbClients.V1DBClient.SaveWatchlistEntry(ctx, entry); err != nil {
+		if !db.IsDuplicateKeyError(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to save watchlist entry")
+			return nil, types.NewInternalServiceError(err)
+		}
+		log.Ctx(ctx).Info().Str("watchlistEntryId", entry.Id).Msg("staker already watched")
+	}
+	return fromWatchlistDocument(entry), nil
+}
+
+// RemoveFromWatchlist removes the staker from the watchlist of the observer,
+// who signs the change with the key of its BTC public key
+func (s *V1Service) RemoveFromWatchlist(
+	ctx context.Context, observerPkHex, watchedPkHex, signatureHex string,
+) *types.Error {
+	if err := verifyWatchlistSignature(ctx, RemoveFromWatchlist, observerPkHex, watchedPkHex, signatureHex); err != nil {
+		return err
+	}
+	err := s.Service.DbClients.V1DBClient.DeleteWatchlistEntry(ctx, observerPkHex, watchedPkHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "staker not watched")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete watchlist entry")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// WatchlistDelegations returns the delegations of all the stakers watched by
+// the observer
+func (s *V1Service) WatchlistDelegations(
+	ctx context.Context, observerPkHex string, pageToken string,
+) ([]*DelegationPublic, string, *types.Error) {
+	entries, err := s.Service.DbClients.V1DBClient.FindWatchlistByObserverPk(ctx, observerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find watchlist")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	if len(entries) == 0 {
+		return []*DelegationPublic{}, "", nil
+	}
+	stakerPks := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		stakerPks = append(stakerPks, entry.WatchedPkHex)
+	}
+
+	resultMap, err := s.Service.DbClients.V1DBClient.FindDelegationsByStakerPks(ctx, stakerPks, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching watchlist delegations")
+			return nil, "", types.NewError(http.StatusBadRequest, types.InvalidPaginationToken, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find watchlist delegations")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	delegations, typesErr := s.fromDelegationDocuments(ctx, resultMap.Data)
+	if typesErr != nil {
+		return nil, "", typesErr
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+func verifyWatchlistSignature(
+	ctx context.Context, action WatchlistAction, observerPkHex, watchedPkHex, signatureHex string,
+) *types.Error {
+	observerPk, err := utils.GetSchnorrPkFromHex(observerPkHex)
+	if err != nil {
+		return types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid observer public key")
+	}
+	sigBytes, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid signature hex")
+	}
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil || !sig.Verify(WatchlistMessageHash(action, watchedPkHex), observerPk) {
+		log.Ctx(ctx).Warn().
+			Str("observerPkHex", observerPkHex).Str("action", string(action)).
+			Msg("watchlist change with an invalid observer signature")
+		return types.NewErrorWithMsg(
+			http.StatusBadRequest, types.InvalidSignature,
+			"signature does not match the watchlist change",
+		)
+	}
+	return nil
+}
+
+func fromWatchlistDocument(entry *v1dbmodel.WatchlistDocument) *WatchlistEntryPublic {
+	return &WatchlistEntryPublic{
+		WatchedPkHex: entry.WatchedPkHex,
+		AddedAt:      utils.ParseTimestampToIsoFormat(entry.AddedAt),
+	}
+}
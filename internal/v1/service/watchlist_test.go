@@ -0,0 +1,179 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchlist(t *testing.T) {
+	ctx := context.Background()
+	newPk := func() (*btcec.PrivateKey, string) {
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		return key, hex.EncodeToString(schnorr.SerializePubKey(key.PubKey()))
+	}
+	observerKey, observerPkHex := newPk()
+	_, watchedPkHex := newPk()
+	sign := func(key *btcec.PrivateKey, action WatchlistAction, watchedPkHex string) string {
+		sig, err := schnorr.Sign(key, WatchlistMessageHash(action, watchedPkHex))
+		require.NoError(t, err)
+		return hex.EncodeToString(sig.Serialize())
+	}
+	newService := func(v1DB *mocks.V1DBClient) *V1Service {
+		indexerDB := mocks.NewIndexerDBClient(t)
+		indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(100), nil).Maybe()
+		indexerDB.On("GetFinalityProviders", ctx).Return(nil, nil).Maybe()
+		return &V1Service{Service: &service.Service{
+			Cfg:       &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}},
+			DbClients: &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB},
+		}}
+	}
+
+	t.Run("signed addition stores the watched staker", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindWatchlistByObserverPk", ctx, observerPkHex).Return([]v1dbmodel.WatchlistDocument{}, nil).Once()
+		var saved *v1dbmodel.WatchlistDocument
+		v1DB.On("SaveWatchlistEntry", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*v1dbmodel.WatchlistDocument)
+		}).Return(nil).Once()
+
+		entry, err := newService(v1DB).AddToWatchlist(
+			ctx, observerPkHex, watchedPkHex, sign(observerKey, AddToWatchlist, watchedPkHex),
+		)
+		require.Nil(t, err)
+		assert.Equal(t, watchedPkHex, entry.WatchedPkHex)
+		require.NotNil(t, saved)
+		assert.Equal(t, observerPkHex, saved.ObserverPkHex)
+		assert.Equal(t, watchedPkHex, saved.WatchedPkHex)
+	})
+
+	t.Run("adding a watched staker again returns the existing entry", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindWatchlistByObserverPk", ctx, observerPkHex).Return([]v1dbmodel.WatchlistDocument{
+			*v1dbmodel.NewWatchlistDocument(observerPkHex, watchedPkHex, 1700000000),
+		}, nil).Once()
+
+		entry, err := newService(v1DB).AddToWatchlist(
+			ctx, observerPkHex, watchedPkHex, sign(observerKey, AddToWatchlist, watchedPkHex),
+		)
+		require.Nil(t, err)
+		assert.Equal(t, "2023-11-14T22:13:20Z", entry.AddedAt)
+	})
+
+	t.Run("full watchlist is rejected", func(t *testing.T) {
+		entries := make([]v1dbmodel.WatchlistDocument, MaxWatchedStakers)
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindWatchlistByObserverPk", ctx, observerPkHex).Return(entries, nil).Once()
+
+		_, err := newService(v1DB).AddToWatchlist(
+			ctx, observerPkHex, watchedPkHex, sign(observerKey, AddToWatchlist, watchedPkHex),
+		)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("signature of another key or action is rejected", func(t *testing.T) {
+		otherKey, _ := newPk()
+		for _, sig := range []string{
+			sign(otherKey, AddToWatchlist, watchedPkHex),
+			sign(observerKey, RemoveFromWatchlist, watchedPkHex),
+		} {
+			_, err := newService(mocks.NewV1DBClient(t)).AddToWatchlist(ctx, observerPkHex, watchedPkHex, sig)
+			require.NotNil(t, err)
+			assert.Equal(t, types.InvalidSignature, err.ErrorCode)
+		}
+	})
+
+	t.Run("observer can not watch itself", func(t *testing.T) {
+		_, err := newService(mocks.NewV1DBClient(t)).AddToWatchlist(
+			ctx, observerPkHex, observerPkHex, sign(observerKey, AddToWatchlist, observerPkHex),
+		)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("watchlist lists the watched stakers", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindWatchlistByObserverPk", ctx, observerPkHex).Return([]v1dbmodel.WatchlistDocument{
+			*v1dbmodel.NewWatchlistDocument(observerPkHex, "a", 1),
+			*v1dbmodel.NewWatchlistDocument(observerPkHex, "b", 2),
+		}, nil).Once()
+
+		watchlist, err := newService(v1DB).GetWatchlist(ctx, observerPkHex)
+		require.Nil(t, err)
+		require.Len(t, watchlist, 2)
+		assert.Equal(t, "a", watchlist[0].WatchedPkHex)
+		assert.Equal(t, "b", watchlist[1].WatchedPkHex)
+	})
+
+	t.Run("signed removal deletes the watched staker", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("DeleteWatchlistEntry", ctx, observerPkHex, watchedPkHex).Return(nil).Once()
+
+		err := newService(v1DB).RemoveFromWatchlist(
+			ctx, observerPkHex, watchedPkHex, sign(observerKey, RemoveFromWatchlist, watchedPkHex),
+		)
+		require.Nil(t, err)
+	})
+
+	t.Run("removing a staker not watched is not found", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("DeleteWatchlistEntry", ctx, observerPkHex, watchedPkHex).Return(&db.NotFoundError{}).Once()
+
+		err := newService(v1DB).RemoveFromWatchlist(
+			ctx, observerPkHex, watchedPkHex, sign(observerKey, RemoveFromWatchlist, watchedPkHex),
+		)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("delegations of all the watched stakers are aggregated", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindWatchlistByObserverPk", ctx, observerPkHex).Return([]v1dbmodel.WatchlistDocument{
+			*v1dbmodel.NewWatchlistDocument(observerPkHex, "a", 1),
+			*v1dbmodel.NewWatchlistDocument(observerPkHex, "b", 2),
+		}, nil).Once()
+		v1DB.On("FindDelegationsByStakerPks", ctx, []string{"a", "b"}, "").Return(
+			&db.DbResultMap[v1dbmodel.DelegationDocument]{
+				Data: []v1dbmodel.DelegationDocument{
+					{StakingTxHashHex: "tx1", StakerPkHex: "a", State: types.Active, StakingTx: &v1dbmodel.TimelockTransaction{}},
+					{StakingTxHashHex: "tx2", StakerPkHex: "b", State: types.Active, StakingTx: &v1dbmodel.TimelockTransaction{}},
+				},
+				PaginationToken: "next",
+			}, nil,
+		).Once()
+
+		delegations, next, err := newService(v1DB).WatchlistDelegations(ctx, observerPkHex, "")
+		require.Nil(t, err)
+		assert.Equal(t, "next", next)
+		require.Len(t, delegations, 2)
+		assert.Equal(t, "a", delegations[0].StakerPkHex)
+		assert.Equal(t, "b", delegations[1].StakerPkHex)
+	})
+
+	t.Run("empty watchlist has no delegations", func(t *testing.T) {
+		v1DB := mocks.NewV1DBClient(t)
+		v1DB.On("FindWatchlistByObserverPk", ctx, observerPkHex).Return([]v1dbmodel.WatchlistDocument{}, nil).Once()
+
+		delegations, next, err := newService(v1DB).WatchlistDelegations(ctx, observerPkHex, "")
+		require.Nil(t, err)
+		assert.Empty(t, delegations)
+		assert.Empty(t, next)
+	})
+}
@@ -0,0 +1,79 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationsByWithdrawalAddress(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	indexerDB := mocks.NewIndexerDBClient(t)
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB, IndexerDBClient: indexerDB},
+	}}
+	indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(100), nil)
+	indexerDB.On("GetFinalityProviders", ctx).Return(nil, nil)
+
+	const address = "tb1qwithdrawal"
+	withdrawn := func(stakingTxHashHex, stakerPkHex string, value uint64) v1dbmodel.DelegationDocument {
+		return v1dbmodel.DelegationDocument{
+			StakingTxHashHex: stakingTxHashHex,
+			StakerPkHex:      stakerPkHex,
+			StakingValue:     value,
+			State:            types.Withdrawn,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 10, TimeLock: 20},
+			WithdrawalTx: &v1dbmodel.WithdrawalTransaction{
+				TxHashHex: "withdrawal-" + stakingTxHashHex, Height: 50, Address: address,
+			},
+		}
+	}
+
+	t.Run("delegations of several wallets withdrawn to the address", func(t *testing.T) {
+		v1DB.On("FindDelegationsByWithdrawalAddress", ctx, address, "").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+				Data: []v1dbmodel.DelegationDocument{
+					withdrawn("tx1", "staker1", 1000),
+					withdrawn("tx2", "staker2", 2000),
+					withdrawn("tx3", "staker1", 3000),
+				},
+			}, nil).Once()
+
+		delegations, paginationToken, err := s.DelegationsByWithdrawalAddress(ctx, address, "")
+		require.Nil(t, err)
+		assert.Empty(t, paginationToken)
+		require.Len(t, delegations, 3)
+		for i, expected := range []struct {
+			txHash, stakerPk string
+			value            uint64
+		}{{"tx1", "staker1", 1000}, {"tx2", "staker2", 2000}, {"tx3", "staker1", 3000}} {
+			assert.Equal(t, expected.txHash, delegations[i].StakingTxHashHex)
+			assert.Equal(t, expected.stakerPk, delegations[i].StakerPkHex)
+			assert.Equal(t, expected.value, delegations[i].StakingValue)
+			require.NotNil(t, delegations[i].WithdrawalTx)
+			assert.Equal(t, address, delegations[i].WithdrawalTx.Address)
+		}
+	})
+
+	t.Run("no delegation withdrawn to the address", func(t *testing.T) {
+		v1DB.On("FindDelegationsByWithdrawalAddress", ctx, "tb1qunused", "").
+			Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{}, nil).Once()
+
+		delegations, _, err := s.DelegationsByWithdrawalAddress(ctx, "tb1qunused", "")
+		require.Nil(t, err)
+		assert.NotNil(t, delegations)
+		assert.Empty(t, delegations)
+	})
+}
@@ -0,0 +1,81 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// withdrawalCompletionTimeWindow is how far back the withdrawals are sampled
+const withdrawalCompletionTimeWindow = 30 * 24 * time.Hour
+
+type WithdrawalCompletionTimePublic struct {
+	P50Seconds  int64 `json:"p50_seconds"`
+	P95Seconds  int64 `json:"p95_seconds"`
+	SampleCount int   `json:"sample_count"`
+}
+
+// GetWithdrawalCompletionTime returns the percentiles of the time between a
+// delegation becoming unbonded, its funds being withdrawable, and their
+// withdrawal, over the delegations withdrawn in the last 30 days
+func (s *V1Service) GetWithdrawalCompletionTime(ctx context.Context) (*WithdrawalCompletionTimePublic, *types.Error) {
+	release, capacityErr := s.Service.AggregationLimiter.Acquire(ctx, config.V1WithdrawalCompletionTimeAggregation)
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+	defer release()
+
+	since := time.Now().Add(-withdrawalCompletionTimeWindow).Unix()
+	histories, err := s.Service.DbClients.V1DBClient.FindStateHistoriesWithdrawnSince(ctx, since)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find the state histories of the withdrawn delegations")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	var completionTimes []int64
+	for _, history := range histories {
+		if completionTime, ok := withdrawalCompletionTime(history, since); ok {
+			completionTimes = append(completionTimes, completionTime)
+		}
+	}
+	return withdrawalCompletionTimeStats(completionTimes), nil
+}
+
+// withdrawalCompletionTime returns the seconds between the transition to
+// unbonded and the transition to withdrawn following it. Delegations withdrawn
+// before since, or whose history no longer holds the transition to unbonded,
+// are not sampled.
+func withdrawalCompletionTime(history []v1dbmodel.StateTransition, since int64) (int64, bool) {
+	var unbondedAt int64
+	unbonded := false
+	for _, transition := range history {
+		switch transition.ToState {
+		case types.Unbonded:
+			unbondedAt = transition.Timestamp
+			unbonded = true
+		case types.Withdrawn:
+			if !unbonded || transition.Timestamp < since {
+				return 0, false
+			}
+			return transition.Timestamp - unbondedAt, true
+		}
+	}
+	return 0, false
+}
+
+func withdrawalCompletionTimeStats(completionTimes []int64) *WithdrawalCompletionTimePublic {
+	stats := &WithdrawalCompletionTimePublic{SampleCount: len(completionTimes)}
+	if len(completionTimes) == 0 {
+		return stats
+	}
+	sort.Slice(completionTimes, func(i, j int) bool { return completionTimes[i] < completionTimes[j] })
+
+	stats.P50Seconds = nearestRankPercentile(completionTimes, 50)
+	stats.P95Seconds = nearestRankPercentile(completionTimes, 95)
+	return stats
+}
@@ -0,0 +1,67 @@
+package v1service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWithdrawalCompletionTime(t *testing.T) {
+	ctx := context.Background()
+	v1DB := mocks.NewV1DBClient(t)
+	s := &V1Service{Service: &service.Service{
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+	now := time.Now().Unix()
+	withdrawnAfter := func(completionTime int64) []v1dbmodel.StateTransition {
+		return []v1dbmodel.StateTransition{
+			{ToState: types.Active, Timestamp: now - 1000000},
+			{FromState: types.Active, ToState: types.Unbonded, Timestamp: now - 3600 - completionTime},
+			{FromState: types.Unbonded, ToState: types.Withdrawn, Timestamp: now - 3600},
+		}
+	}
+
+	// withdrawal completion times of 1 to 20 hours
+	var histories [][]v1dbmodel.StateTransition
+	for hours := int64(20); hours >= 1; hours-- {
+		histories = append(histories, withdrawnAfter(hours*3600))
+	}
+	// not sampled: withdrawn without the transition to unbonded, and
+	// withdrawn before the window
+	histories = append(histories,
+		[]v1dbmodel.StateTransition{
+			{ToState: types.Withdrawn, Timestamp: now - 3600},
+		},
+		[]v1dbmodel.StateTransition{
+			{ToState: types.Unbonded, Timestamp: now - 32*24*3600},
+			{FromState: types.Unbonded, ToState: types.Withdrawn, Timestamp: now - 31*24*3600},
+		},
+	)
+	v1DB.On("FindStateHistoriesWithdrawnSince", ctx, mock.MatchedBy(func(since int64) bool {
+		return since <= now-30*24*3600 && since >= now-30*24*3600-60
+	})).Return(histories, nil).Once()
+
+	stats, err := s.GetWithdrawalCompletionTime(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, &WithdrawalCompletionTimePublic{
+		P50Seconds:  10 * 3600,
+		P95Seconds:  19 * 3600,
+		SampleCount: 20,
+	}, stats)
+}
+
+func TestWithdrawalCompletionTimeStats(t *testing.T) {
+	assert.Equal(t, &WithdrawalCompletionTimePublic{}, withdrawalCompletionTimeStats(nil))
+	assert.Equal(t, &WithdrawalCompletionTimePublic{
+		P50Seconds: 300, P95Seconds: 900, SampleCount: 4,
+	}, withdrawalCompletionTimeStats([]int64{900, 100, 300, 600}))
+}
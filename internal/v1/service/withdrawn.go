@@ -2,24 +2,102 @@ package v1service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	"github.com/rs/zerolog/log"
 )
 
+// TransitionToWithdrawnState transitions the delegation to withdrawn, its
+// staking value is counted in the withdrawn tvl of the withdrawal path
 func (s *V1Service) TransitionToWithdrawnState(
-	ctx context.Context, stakingTxHashHex string,
+	ctx context.Context, stakingTxHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction,
+	withdrawalPath v1dbmodel.WithdrawalPath, amount uint64,
 ) *types.Error {
-	err := s.Service.DbClients.V1DBClient.TransitionToWithdrawnState(ctx, stakingTxHashHex)
+	defer s.invalidateStatsCache(ctx)
+	return s.transitionDelegation(ctx, stakingTxHashHex, types.Withdrawn, func(ctx context.Context) error {
+		return s.Service.DbClients.V1DBClient.TransitionToWithdrawnState(
+			ctx, stakingTxHashHex, withdrawalTx, withdrawalPath, amount,
+		)
+	})
+}
+
+// ProcessWithdrawnDelegation transitions the delegation to withdrawn once its
+// withdrawal tx is confirmed. Redelivered events are ignored, as well as the
+// delegations that only exist in or were transitioned to phase-2. A delegation still unbonding is
+// accepted if the withdrawal happened after the unbonding timelock expired,
+// its stats are then subtracted as the unbonded transition was skipped.
+// The withdrawal address is empty and the withdrawal height is 0 if unknown.
+func (s *V1Service) ProcessWithdrawnDelegation(
+	ctx context.Context, stakingTxHashHex, withdrawalTxHashHex, withdrawalAddress string, withdrawalTxHeight uint64,
+) *types.Error {
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
 	if err != nil {
-		if ok := db.IsNotFoundError(err); ok {
-			log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("delegation not found or no longer eligible for withdraw")
-			return types.NewErrorWithMsg(http.StatusForbidden, types.NotFound, "delegation not found or no longer eligible for withdraw")
+		if db.IsNotFoundError(err) {
+			return nil
 		}
-		log.Ctx(ctx).Error().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("failed to transition to withdrawn state")
-		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while fetching delegation")
+		return types.NewInternalServiceError(err)
 	}
-	return nil
+
+	// Transitioned delegations are withdrawn through the phase-2 flow
+	if delegation.State == types.Transitioned ||
+		utils.Contains(utils.OutdatedStatesForWithdraw(), delegation.State) {
+		return nil
+	}
+	if !utils.Contains(utils.QualifiedStatesToWithdraw(), delegation.State) {
+		errMsg := fmt.Sprintf(
+			"delegation in %s state can not transition to withdrawn", delegation.State.ToString(),
+		)
+		log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Msg(errMsg)
+		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, errMsg)
+	}
+
+	var withdrawalTx *v1dbmodel.WithdrawalTransaction
+	if withdrawalTxHashHex != "" {
+		withdrawalTx = &v1dbmodel.WithdrawalTransaction{
+			TxHashHex: withdrawalTxHashHex,
+			Height:    withdrawalTxHeight,
+			Address:   withdrawalAddress,
+		}
+	}
+	withdrawalPath := delegation.DeriveWithdrawalPath()
+	if delegation.State != types.Unbonding {
+		return s.TransitionToWithdrawnState(
+			ctx, stakingTxHashHex, withdrawalTx, withdrawalPath, delegation.StakingValue,
+		)
+	}
+
+	// The height is only checked when the event carries it, the indexer does
+	// not report a withdrawal before the timelock expires anyway
+	expireHeight := delegation.UnbondingTx.StartHeight + delegation.UnbondingTx.TimeLock
+	if withdrawalTxHeight != 0 && withdrawalTxHeight < expireHeight {
+		errMsg := fmt.Sprintf(
+			"unbonding delegation can not be withdrawn before its timelock expires at height %d",
+			expireHeight,
+		)
+		log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Msg(errMsg)
+		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, errMsg)
+	}
+	// The stats are subtracted in the same transaction as the transition so
+	// that they can not drift apart. On a standalone database, they are
+	// subtracted first so that a failure is retried, the stats lock makes
+	// sure they are subtracted only once.
+	return service.WithTransaction(ctx, s.Service.DbClients.V1DBClient, func(ctx context.Context) *types.Error {
+		if statsErr := s.ProcessStakingStatsCalculation(
+			ctx, stakingTxHashHex, delegation.StakerPkHex, delegation.FinalityProviderPkHex,
+			types.Unbonded, delegation.StakingValue,
+		); statsErr != nil {
+			return statsErr
+		}
+		return s.TransitionToWithdrawnState(
+			ctx, stakingTxHashHex, withdrawalTx, withdrawalPath, delegation.StakingValue,
+		)
+	})
 }
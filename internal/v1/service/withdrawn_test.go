@@ -0,0 +1,101 @@
+package v1service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type transactionCtxKey struct{}
+
+func TestProcessWithdrawnDelegationWithinTransaction(t *testing.T) {
+	ctx := context.Background()
+	// the mocked transaction hands its own context to the writes, so that
+	// they are only expected when running within it
+	txCtx := context.WithValue(ctx, transactionCtxKey{}, true)
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(&v1dbmodel.DelegationDocument{
+		StakingTxHashHex:      "tx",
+		StakerPkHex:           "staker",
+		FinalityProviderPkHex: "fp",
+		StakingValue:          1000,
+		State:                 types.Unbonding,
+		UnbondingTx:           &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 10},
+	}, nil).Once()
+	var committed bool
+	v1DB.On("WithTransaction", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, fn func(context.Context) error) error {
+			err := fn(txCtx)
+			committed = err == nil
+			return err
+		}).Once()
+	v1DB.On("GetOrCreateStatsLock", txCtx, "tx", types.Unbonded.ToString()).
+		Return(v1dbmodel.NewStatsLockDocument("tx", false, false, false), nil).Once()
+	v1DB.On("SubtractFinalityProviderStats", txCtx, "tx", "fp", uint64(1000)).Return(nil).Once()
+	v1DB.On("SubtractStakerStats", txCtx, "tx", "staker", uint64(1000)).Return(nil).Once()
+	v1DB.On("SubtractOverallStats", txCtx, "tx", "staker", uint64(1000)).Return(nil).Once()
+	// the transition fails after the stats were written
+	v1DB.On("TransitionToWithdrawnState", txCtx, "tx", mock.Anything, v1dbmodel.WithdrawalPathEarlyUnbonding, uint64(1000)).
+		Return(errors.New("db down")).Once()
+
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+	err := s.ProcessWithdrawnDelegation(ctx, "tx", "withdrawal-tx", "", 110)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+	assert.False(t, committed, "the stats must be rolled back along with the transition")
+}
+
+func TestProcessWithdrawnDelegationWithoutHeight(t *testing.T) {
+	ctx := context.Background()
+	delegation := &v1dbmodel.DelegationDocument{
+		StakingTxHashHex:      "tx",
+		StakerPkHex:           "staker",
+		FinalityProviderPkHex: "fp",
+		StakingValue:          1000,
+		State:                 types.Unbonding,
+		UnbondingTx:           &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 10},
+	}
+
+	// a height before the timelock expires is rejected
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(delegation, nil).Once()
+	s := &V1Service{Service: &service.Service{
+		Cfg:       &config.Config{},
+		DbClients: &dbclients.DbClients{V1DBClient: v1DB},
+	}}
+	err := s.ProcessWithdrawnDelegation(ctx, "tx", "withdrawal-tx", "", 105)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+
+	// the events without a height are not checked against the timelock
+	v1DB = mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationByTxHashHex", ctx, "tx").Return(delegation, nil).Once()
+	v1DB.On("WithTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).Once()
+	v1DB.On("GetOrCreateStatsLock", mock.Anything, "tx", types.Unbonded.ToString()).
+		Return(v1dbmodel.NewStatsLockDocument("tx", false, false, false), nil).Once()
+	v1DB.On("SubtractFinalityProviderStats", mock.Anything, "tx", "fp", uint64(1000)).Return(nil).Once()
+	v1DB.On("SubtractStakerStats", mock.Anything, "tx", "staker", uint64(1000)).Return(nil).Once()
+	v1DB.On("SubtractOverallStats", mock.Anything, "tx", "staker", uint64(1000)).Return(nil).Once()
+	v1DB.On("TransitionToWithdrawnState", mock.Anything, "tx", mock.Anything, v1dbmodel.WithdrawalPathEarlyUnbonding, uint64(1000)).
+		Return(nil).Once()
+	s.Service.DbClients = &dbclients.DbClients{V1DBClient: v1DB}
+	require.Nil(t, s.ProcessWithdrawnDelegation(ctx, "tx", "withdrawal-tx", "", 0))
+}
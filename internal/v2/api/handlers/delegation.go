@@ -1,7 +1,9 @@
 package v2handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
@@ -13,6 +15,7 @@ import (
 // @Produce json
 // @Tags v2
 // @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Param include_formatted query boolean false "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts"
 // @Success 200 {object} handler.PublicResponse[v2service.DelegationPublic] "Staker delegation"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Failure 404 {object} types.Error "Error: Not Found"
@@ -23,10 +26,17 @@ func (h *V2Handler) GetDelegation(request *http.Request) (*handler.Result, *type
 	if err != nil {
 		return nil, err
 	}
+	includeFormatted, err := handler.ParseBooleanQuery(request, "include_formatted", true)
+	if err != nil {
+		return nil, err
+	}
 	delegation, err := h.Service.GetDelegation(request.Context(), stakingTxHash)
 	if err != nil {
 		return nil, err
 	}
+	if includeFormatted {
+		delegation.AddFormattedFields()
+	}
 
 	return handler.NewResult(delegation), nil
 }
@@ -37,7 +47,9 @@ func (h *V2Handler) GetDelegation(request *http.Request) (*handler.Result, *type
 // @Produce json
 // @Tags v2
 // @Param staker_pk_hex query string true "Staker public key in hex format"
+// @Param params_version query integer false "Only return the delegations made under this babylon staking params version"
 // @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Param include_formatted query boolean false "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts"
 // @Success 200 {object} handler.PublicResponse[[]v2service.DelegationPublic]{array} "List of staker delegations and pagination token"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Failure 404 {object} types.Error "Error: Not Found"
@@ -49,13 +61,45 @@ func (h *V2Handler) GetDelegations(request *http.Request) (*handler.Result, *typ
 	if err != nil {
 		return nil, err
 	}
+	paramsVersion, err := parseParamsVersionQuery(request)
+	if err != nil {
+		return nil, err
+	}
 	paginationKey, err := handler.ParsePaginationQuery(request)
 	if err != nil {
 		return nil, err
 	}
-	delegations, paginationToken, err := h.Service.GetDelegations(request.Context(), stakerPKHex, paginationKey)
+	includeFormatted, err := handler.ParseBooleanQuery(request, "include_formatted", true)
+	if err != nil {
+		return nil, err
+	}
+	delegations, paginationToken, err := h.Service.GetDelegations(
+		request.Context(), stakerPKHex, paramsVersion, paginationKey,
+	)
 	if err != nil {
 		return nil, err
 	}
+	if includeFormatted {
+		for _, delegation := range delegations {
+			delegation.AddFormattedFields()
+		}
+	}
 	return handler.NewResultWithPagination(delegations, paginationToken), nil
 }
+
+// parseParamsVersionQuery returns nil if the params_version query is not set
+func parseParamsVersionQuery(r *http.Request) (*uint32, *types.Error) {
+	value := r.URL.Query().Get("params_version")
+	if value == "" {
+		return nil, nil
+	}
+	version, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid params_version value: %s", value),
+		)
+	}
+	paramsVersion := uint32(version)
+	return &paramsVersion, nil
+}
@@ -0,0 +1,35 @@
+package v2handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// GetEventGaps @Summary Get suspected event gaps
+// @Description Internal endpoint listing the suspected gaps in the events received
+// @Description on the queues: the event height jumped ahead while the producer
+// @Description sequence number shows that intermediate events were never received.
+// @Description Admin endpoint, only served when an admin token is configured.
+// @Produce json
+// @Tags v2
+// @Param Authorization header string true "Bearer admin token"
+// @Param pagination_key query string false "Pagination key to fetch the next page of event gaps"
+// @Success 200 {object} handler.PublicResponse[[]v2service.EventGapPublic]{array} "List of suspected event gaps and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid admin token"
+// @Router /v1/internal/gaps [get]
+func (h *V2Handler) GetEventGaps(request *http.Request) (*handler.Result, *types.Error) {
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	gaps, newPaginationKey, err := h.Service.GetEventGaps(request.Context(), paginationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResultWithPagination(gaps, newPaginationKey), nil
+}
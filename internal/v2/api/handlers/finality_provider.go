@@ -1,10 +1,12 @@
 package v2handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
 )
 
 // GetFinalityProviders gets a list of finality providers with its stats
@@ -14,16 +16,37 @@ import (
 // this is for the future when we will support pagination
 // @Produce json
 // @Tags v2
+// @Param order_by query string false "Sort the finality providers by the given stat in descending order" Enums(active_tvl, total_tvl, active_delegations, total_delegations)
 // @Success 200 {object} handler.PublicResponse[[]v2service.FinalityProviderStatsPublic] "List of finality providers with its stats"
 // @Failure 400 {object} types.Error "Invalid parameters or malformed request"
 // @Failure 404 {object} types.Error "No finality providers found"
 // @Failure 500 {object} types.Error "Internal server error occurred"
 // @Router /v2/finality-providers [get]
 func (h *V2Handler) GetFinalityProviders(request *http.Request) (*handler.Result, *types.Error) {
-	providers, err := h.Service.GetFinalityProvidersWithStats(request.Context())
+	orderBy, err := parseFinalityProviderOrderByQuery(request)
+	if err != nil {
+		return nil, err
+	}
 
+	providers, err := h.Service.GetFinalityProvidersWithStats(request.Context(), orderBy)
 	if err != nil {
 		return nil, err
 	}
 	return handler.NewResultWithPagination(providers, ""), nil
 }
+
+func parseFinalityProviderOrderByQuery(r *http.Request) (v2service.FinalityProviderOrderBy, *types.Error) {
+	orderBy := r.URL.Query().Get("order_by")
+	if orderBy == "" {
+		return "", nil
+	}
+	for _, supported := range v2service.SupportedFinalityProviderOrderBy() {
+		if orderBy == string(supported) {
+			return supported, nil
+		}
+	}
+	return "", types.NewErrorWithMsg(
+		http.StatusBadRequest, types.BadRequest,
+		fmt.Sprintf("invalid order_by value: %s", orderBy),
+	)
+}
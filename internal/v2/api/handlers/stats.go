@@ -1,10 +1,13 @@
 package v2handlers
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
 )
 
 // GetStakerStats gets staker stats for babylon staking
@@ -13,20 +16,28 @@ import (
 // @Produce json
 // @Tags v2
 // @Param staker_pk_hex query string true "Public key of the staker to fetch"
+// @Param include_formatted query boolean false "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts"
 // @Success 200 {object} handler.PublicResponse[v2service.StakerStatsPublic] "Staker stats"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Failure 404 {object} types.Error "Error: Not Found"
 // @Failure 500 {object} types.Error "Error: Internal Server Error"
 // @Router /v2/staker/stats [get]
 func (h *V2Handler) GetStakerStats(request *http.Request) (*handler.Result, *types.Error) {
-	stakerPKHex := request.URL.Query().Get("staker_pk_hex")
-	if stakerPKHex == "" {
-		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "staker_pk_hex is required")
+	stakerPKHex, err := handler.ParsePublicKeyQuery(request, "staker_pk_hex", false)
+	if err != nil {
+		return nil, err
+	}
+	includeFormatted, err := handler.ParseBooleanQuery(request, "include_formatted", true)
+	if err != nil {
+		return nil, err
 	}
 	stats, err := h.Service.GetStakerStats(request.Context(), stakerPKHex)
 	if err != nil {
 		return nil, err
 	}
+	if includeFormatted {
+		stats.AddFormattedFields()
+	}
 	return handler.NewResult(stats), nil
 }
 
@@ -34,13 +45,65 @@ func (h *V2Handler) GetStakerStats(request *http.Request) (*handler.Result, *typ
 // @Description Overall system stats
 // @Produce json
 // @Tags v2
+// @Param include_formatted query boolean false "Include BTC denominated amounts as exact decimal strings next to the satoshi amounts"
 // @Success 200 {object} handler.PublicResponse[v2service.OverallStatsPublic] ""
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v2/stats [get]
 func (h *V2Handler) GetOverallStats(request *http.Request) (*handler.Result, *types.Error) {
+	includeFormatted, err := handler.ParseBooleanQuery(request, "include_formatted", true)
+	if err != nil {
+		return nil, err
+	}
 	stats, err := h.Service.GetOverallStats(request.Context())
 	if err != nil {
 		return nil, err
 	}
+	if includeFormatted {
+		stats.AddFormattedFields()
+	}
 	return handler.NewResult(stats), nil
 }
+
+// GetStatsTimeSeries @Summary Get overall stats time series
+// @Description Daily series of the active tvl and active delegations at the end of each UTC day.
+// @Description Days without any change carry the previous value forward. The range is limited to 365 days.
+// @Produce json
+// @Tags v1
+// @Param from query string true "First day of the series, formatted as YYYY-MM-DD"
+// @Param to query string true "Last day of the series, formatted as YYYY-MM-DD"
+// @Success 200 {object} handler.PublicResponse[[]v2service.StatsTimeSeriesPointPublic]{array} "Daily stats series"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Router /v1/stats/time-series [get]
+func (h *V2Handler) GetStatsTimeSeries(request *http.Request) (*handler.Result, *types.Error) {
+	from, err := parseDateQuery(request, "from")
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDateQuery(request, "to")
+	if err != nil {
+		return nil, err
+	}
+	series, err := h.Service.GetStatsTimeSeries(request.Context(), from, to)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(series), nil
+}
+
+func parseDateQuery(r *http.Request, queryName string) (time.Time, *types.Error) {
+	value := r.URL.Query().Get(queryName)
+	if value == "" {
+		return time.Time{}, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, queryName+" is required",
+		)
+	}
+	date, err := time.Parse(v2dbmodel.DailyStatsDateFormat, value)
+	if err != nil {
+		return time.Time{}, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid %s: expected a YYYY-MM-DD date", queryName),
+		)
+	}
+	return date, nil
+}
@@ -0,0 +1,45 @@
+package v2dbclient
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveEventGap stores the suspected event gap, the same gap is only stored once
+func (v2dbclient *V2Database) SaveEventGap(ctx context.Context, gap *v2dbmodel.EventGapDocument) error {
+	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2EventGapsCollection)
+	filter := bson.M{"_id": gap.Id}
+
+	_, err := client.ReplaceOne(ctx, filter, gap, options.Replace().SetUpsert(true))
+	return err
+}
+
+// FindEventGaps returns the stored suspected event gaps in a paginated way
+func (v2dbclient *V2Database) FindEventGaps(
+	ctx context.Context, paginationToken string,
+) (*db.DbResultMap[v2dbmodel.EventGapDocument], error) {
+	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2EventGapsCollection)
+	filter := bson.M{}
+	options := options.Find().SetSort(bson.M{"_id": 1})
+
+	if paginationToken != "" {
+		decodedToken, err :=
+			dbmodel.DecodePaginationToken[v2dbmodel.EventGapPagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.Id}
+	}
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, v2dbclient.Cfg.MaxPaginationLimit,
+		v2dbmodel.BuildEventGapPaginationToken,
+	)
+}
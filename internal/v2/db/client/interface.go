@@ -3,16 +3,38 @@ package v2dbclient
 import (
 	"context"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
 	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
 )
 
-//go:generate mockery --name=V2DBClient --output=../../../../tests/mocks --outpkg=mocks --filename=mock_v2_db_client.go
-type V2DBClient interface {
-	dbclient.DBClient
+// V2DBReader holds the methods of V2DBClient that only read from the database
+type V2DBReader interface {
+	dbclient.DBReader
 	GetOverallStats(ctx context.Context) (*v2dbmodel.V2OverallStatsDocument, error)
 	GetStakerStats(ctx context.Context, stakerPKHex string) (*v2dbmodel.V2StakerStatsDocument, error)
 	GetFinalityProviderStats(ctx context.Context) ([]*v2dbmodel.V2FinalityProviderStatsDocument, error)
+	// GetStatsLock is the read-only counterpart of GetOrCreateStatsLock, it
+	// never creates the lock document.
+	GetStatsLock(
+		ctx context.Context, stakingTxHashHex string, state string,
+	) (*v2dbmodel.V2StatsLockDocument, error)
+	GetActiveStakersCount(ctx context.Context) (int64, error)
+	// FindDailyStats returns the daily changes of the overall stats up to
+	// the given date included, sorted by date in ascending order
+	FindDailyStats(ctx context.Context, until string) ([]v2dbmodel.V2DailyStatsDocument, error)
+	// FindEventGaps returns the suspected event gaps in a paginated way
+	FindEventGaps(
+		ctx context.Context, paginationToken string,
+	) (*db.DbResultMap[v2dbmodel.EventGapDocument], error)
+	// FindQueuePayload returns the payload of a reference queue message
+	FindQueuePayload(ctx context.Context, id string) (*v2dbmodel.QueuePayloadDocument, error)
+}
+
+//go:generate mockery --name=V2DBClient --output=../../../../tests/mocks --outpkg=mocks --filename=mock_v2_db_client.go
+type V2DBClient interface {
+	dbclient.DBClient
+	V2DBReader
 	GetOrCreateStatsLock(
 		ctx context.Context, stakingTxHashHex string, state string,
 	) (*v2dbmodel.V2StatsLockDocument, error)
@@ -40,5 +62,9 @@ type V2DBClient interface {
 	SubtractFinalityProviderStats(
 		ctx context.Context, stakingTxHashHex string, fpPkHexes []string, amount uint64,
 	) error
-	GetActiveStakersCount(ctx context.Context) (int64, error)
+	// SaveEventGap stores a suspected gap in the events received on a queue
+	SaveEventGap(ctx context.Context, gap *v2dbmodel.EventGapDocument) error
+	// DeleteQueuePayload removes the payload of a processed reference queue
+	// message, deleting a missing payload is a no-op
+	DeleteQueuePayload(ctx context.Context, id string) error
 }
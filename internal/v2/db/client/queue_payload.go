@@ -0,0 +1,43 @@
+package v2dbclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindQueuePayload returns the payload of a reference queue message.
+// It returns a NotFoundError if the payload does not exist.
+func (v2dbclient *V2Database) FindQueuePayload(
+	ctx context.Context, id string,
+) (*v2dbmodel.QueuePayloadDocument, error) {
+	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2QueuePayloadsCollection)
+	filter := bson.M{"_id": id}
+
+	var payload v2dbmodel.QueuePayloadDocument
+	err := client.FindOne(ctx, filter).Decode(&payload)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     id,
+				Message: "Queue payload not found",
+			}
+		}
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// DeleteQueuePayload removes the payload of a processed reference queue message
+func (v2dbclient *V2Database) DeleteQueuePayload(ctx context.Context, id string) error {
+	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2QueuePayloadsCollection)
+	filter := bson.M{"_id": id}
+
+	_, err := client.DeleteOne(ctx, filter)
+	return err
+}
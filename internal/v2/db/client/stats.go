@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
@@ -48,6 +49,28 @@ func (db *V2Database) GetOrCreateStatsLock(
 	return &result, nil
 }
 
+// GetStatsLock fetches the lock status for each stats type for the given staking tx hash
+// without creating the document. If the document does not exist, a lock with all
+// stats types marked as unprocessed is returned.
+func (db *V2Database) GetStatsLock(
+	ctx context.Context, stakingTxHashHex string, txType string,
+) (*v2dbmodel.V2StatsLockDocument, error) {
+	stakingTxHashHex = strings.ToLower(stakingTxHashHex)
+
+	client := db.Client.Database(db.DbName).Collection(dbmodel.V2StatsLockCollection)
+	id := constructStatsLockId(stakingTxHashHex, txType)
+
+	var result v2dbmodel.V2StatsLockDocument
+	err := client.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return v2dbmodel.NewV2StatsLockDocument(id, false, false, false), nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
 // IncrementOverallStats increments the overall stats for the given staking tx hash.
 // This method is idempotent, only the first call will be processed. Otherwise it will return a notFoundError for duplicates
 func (v2dbclient *V2Database) IncrementOverallStats(
@@ -57,13 +80,6 @@ func (v2dbclient *V2Database) IncrementOverallStats(
 
 	overallStatsClient := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2OverallStatsCollection)
 
-	// Start a session
-	session, sessionErr := v2dbclient.Client.StartSession()
-	if sessionErr != nil {
-		return sessionErr
-	}
-	defer session.EndSession(ctx)
-
 	upsertUpdate := bson.M{
 		"$inc": bson.M{
 			"active_tvl":         int64(amount),
@@ -71,28 +87,31 @@ func (v2dbclient *V2Database) IncrementOverallStats(
 		},
 	}
 	// Define the work to be done in the transaction
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	transactionWork := func(sessCtx context.Context) error {
 		err := v2dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, types.Active.ToString(), "overall_stats")
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		shardId, err := v2dbclient.generateOverallStatsId()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		upsertFilter := bson.M{"_id": shardId}
 
 		_, err = overallStatsClient.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err = v2dbclient.incrementDailyStats(sessCtx, int64(amount), 1); err != nil {
+			return err
 		}
-		return nil, nil
+		return nil
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	txErr := v2dbclient.WithTransaction(ctx, transactionWork)
 	return txErr
 }
 
@@ -111,35 +130,31 @@ func (v2dbclient *V2Database) SubtractOverallStats(
 	}
 	overallStatsClient := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2OverallStatsCollection)
 
-	// Start a session
-	session, sessionErr := v2dbclient.Client.StartSession()
-	if sessionErr != nil {
-		return sessionErr
-	}
-	defer session.EndSession(ctx)
-
 	// Define the work to be done in the transaction
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	transactionWork := func(sessCtx context.Context) error {
 		err := v2dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, types.Unbonding.ToString(), "overall_stats")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		shardId, err := v2dbclient.generateOverallStatsId()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		upsertFilter := bson.M{"_id": shardId}
 
 		_, err = overallStatsClient.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err = v2dbclient.incrementDailyStats(sessCtx, -int64(amount), -1); err != nil {
+			return err
 		}
-		return nil, nil
+		return nil
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	txErr := v2dbclient.WithTransaction(ctx, transactionWork)
 	if txErr != nil {
 		return txErr
 	}
@@ -178,6 +193,45 @@ func (v2dbclient *V2Database) GetOverallStats(ctx context.Context) (*v2dbmodel.V
 	return &result, nil
 }
 
+// incrementDailyStats applies the change of the overall stats to the document
+// of the current UTC day
+func (v2dbclient *V2Database) incrementDailyStats(
+	ctx context.Context, tvlDelta, delegationsDelta int64,
+) error {
+	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2DailyStatsCollection)
+	filter := bson.M{"_id": time.Now().UTC().Format(v2dbmodel.DailyStatsDateFormat)}
+	update := bson.M{
+		"$inc": bson.M{
+			"tvl_delta":         tvlDelta,
+			"delegations_delta": delegationsDelta,
+		},
+	}
+	_, err := client.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// FindDailyStats returns the daily stats up to the given date included,
+// sorted by date in ascending order
+func (v2dbclient *V2Database) FindDailyStats(
+	ctx context.Context, until string,
+) ([]v2dbmodel.V2DailyStatsDocument, error) {
+	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2DailyStatsCollection)
+	filter := bson.M{"_id": bson.M{"$lte": until}}
+	opts := options.Find().SetSort(bson.M{"_id": 1})
+
+	cursor, err := client.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []v2dbmodel.V2DailyStatsDocument
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // Generate the id for the overall stats document. Id is a random number ranged from 0-LogicalShardCount-1
 // It's a logical shard to avoid locking the same field during concurrent writes
 // The sharding number should never be reduced after roll out
@@ -383,30 +437,23 @@ func (v2dbclient *V2Database) updateStakerStats(ctx context.Context, state, stak
 
 	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2StakerStatsCollection)
 
-	// Start a session
-	session, sessionErr := v2dbclient.Client.StartSession()
-	if sessionErr != nil {
-		return sessionErr
-	}
-	defer session.EndSession(ctx)
-
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	transactionWork := func(sessCtx context.Context) error {
 		err := v2dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, state, "staker_stats")
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		upsertFilter := bson.M{"_id": stakerPkHex}
 
 		_, err = client.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return nil, nil
+		return nil
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	txErr := v2dbclient.WithTransaction(ctx, transactionWork)
 	return txErr
 }
 
@@ -469,6 +516,10 @@ func (v2dbclient *V2Database) IncrementFinalityProviderStats(
 				"$inc": bson.M{
 					"active_tvl":         int64(amount),
 					"active_delegations": 1,
+					// the totals only ever grow, they are never subtracted
+					// once the delegation leaves the active state
+					"total_tvl":         int64(amount),
+					"total_delegations": 1,
 				},
 			}).
 			SetUpsert(true)
@@ -525,13 +576,7 @@ func (v2dbclient *V2Database) updateFinalityProviderStats(
 
 	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2FinalityProviderStatsCollection)
 
-	session, sessionErr := v2dbclient.Client.StartSession()
-	if sessionErr != nil {
-		return sessionErr
-	}
-	defer session.EndSession(ctx)
-
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	transactionWork := func(sessCtx context.Context) error {
 		// Single lock for the entire operation
 		err := v2dbclient.updateStatsLockByFieldName(
 			sessCtx,
@@ -540,20 +585,20 @@ func (v2dbclient *V2Database) updateFinalityProviderStats(
 			"finality_provider_stats",
 		)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		// Execute all updates in a single bulk write
 		opts := options.BulkWrite().SetOrdered(true)
 		_, err = client.BulkWrite(sessCtx, operations, opts)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		return nil, nil
+		return nil
 	}
 
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	txErr := v2dbclient.WithTransaction(ctx, transactionWork)
 	return txErr
 }
 
@@ -0,0 +1,52 @@
+package v2dbmodel
+
+import (
+	"fmt"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+)
+
+// EventGapDocument records a suspected gap in the events received on a queue:
+// the event height jumped ahead while the producer sequence number shows
+// that intermediate events were never received.
+type EventGapDocument struct {
+	Id              string `bson:"_id"`
+	QueueName       string `bson:"queue_name"`
+	FromSequence    uint64 `bson:"from_sequence"`
+	ToSequence      uint64 `bson:"to_sequence"`
+	FromHeight      uint64 `bson:"from_height"`
+	ToHeight        uint64 `bson:"to_height"`
+	PreviousEventAt int64  `bson:"previous_event_at"`
+	DetectedAt      int64  `bson:"detected_at"`
+}
+
+func NewEventGapDocument(
+	queueName string, fromSequence, toSequence, fromHeight, toHeight uint64,
+	previousEventAt, detectedAt int64,
+) *EventGapDocument {
+	return &EventGapDocument{
+		Id:              fmt.Sprintf("%s:%d", queueName, toSequence),
+		QueueName:       queueName,
+		FromSequence:    fromSequence,
+		ToSequence:      toSequence,
+		FromHeight:      fromHeight,
+		ToHeight:        toHeight,
+		PreviousEventAt: previousEventAt,
+		DetectedAt:      detectedAt,
+	}
+}
+
+type EventGapPagination struct {
+	Id string `json:"id"`
+}
+
+func BuildEventGapPaginationToken(d EventGapDocument) (string, error) {
+	page := &EventGapPagination{
+		Id: d.Id,
+	}
+	token, err := dbmodel.GetPaginationToken(page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
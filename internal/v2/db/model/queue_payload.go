@@ -0,0 +1,9 @@
+package v2dbmodel
+
+// QueuePayloadDocument holds the payload of a queue message too large for the
+// broker, the message itself only carries a reference to the document.
+type QueuePayloadDocument struct {
+	Id        string `bson:"_id"`
+	Payload   string `bson:"payload"`
+	CreatedAt int64  `bson:"created_at"`
+}
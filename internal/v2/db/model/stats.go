@@ -42,4 +42,17 @@ type V2FinalityProviderStatsDocument struct {
 	FinalityProviderPkHex string `bson:"_id"`
 	ActiveTvl             int64  `bson:"active_tvl"`
 	ActiveDelegations     int64  `bson:"active_delegations"`
+	TotalTvl              int64  `bson:"total_tvl"`
+	TotalDelegations      int64  `bson:"total_delegations"`
+}
+
+// DailyStatsDateFormat is the layout of the daily stats document ids
+const DailyStatsDateFormat = "2006-01-02"
+
+// V2DailyStatsDocument holds the change of the overall stats within a UTC
+// day, the id is the date formatted as YYYY-MM-DD
+type V2DailyStatsDocument struct {
+	Date             string `bson:"_id"`
+	TvlDelta         int64  `bson:"tvl_delta"`
+	DelegationsDelta int64  `bson:"delegations_delta"`
 }
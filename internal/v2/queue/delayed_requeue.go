@@ -0,0 +1,67 @@
+package queue
+
+import (
+	"sync"
+	"time"
+)
+
+// delayedRequeues tracks the requeues of the failed messages waiting for
+// their retry backoff, so that they are not lost once the queue connections
+// are closed on shutdown
+type delayedRequeues struct {
+	mutex   sync.Mutex
+	stopped bool
+	pending map[*time.Timer]func()
+	running sync.WaitGroup
+}
+
+func newDelayedRequeues() *delayedRequeues {
+	return &delayedRequeues{pending: make(map[*time.Timer]func())}
+}
+
+// schedule runs the requeue once the delay elapses, or right away if the
+// requeues are already flushed
+func (d *delayedRequeues) schedule(delay time.Duration, requeue func()) {
+	d.running.Add(1)
+	d.mutex.Lock()
+	if d.stopped {
+		d.mutex.Unlock()
+		d.run(requeue)
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		d.mutex.Lock()
+		_, ok := d.pending[timer]
+		delete(d.pending, timer)
+		d.mutex.Unlock()
+		// the requeue was taken over by the flush
+		if !ok {
+			return
+		}
+		d.run(requeue)
+	})
+	d.pending[timer] = requeue
+	d.mutex.Unlock()
+}
+
+// flush runs the pending requeues without waiting for their delay, and waits
+// for all of them to complete. The requeues scheduled afterwards run right away.
+func (d *delayedRequeues) flush() {
+	d.mutex.Lock()
+	d.stopped = true
+	pending := d.pending
+	d.pending = make(map[*time.Timer]func())
+	d.mutex.Unlock()
+
+	for timer, requeue := range pending {
+		timer.Stop()
+		d.run(requeue)
+	}
+	d.running.Wait()
+}
+
+func (d *delayedRequeues) run(requeue func()) {
+	defer d.running.Done()
+	requeue()
+}
@@ -0,0 +1,36 @@
+package v2queuehandler
+
+import (
+	"context"
+	"encoding/json"
+
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	queueClient "github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/rs/zerolog/log"
+)
+
+// WithDryRun wraps a handler chain built on the dry-run services so that the
+// writes it skipped while processing a message are stored into the shadow
+// collection. The chain runs the same validation and reads as the live one,
+// but the stats lock is only read, so the message is still applied when it is
+// replayed once the queue is back live.
+func (h *V2QueueHandler) WithDryRun(queueName string, next MessageHandler) MessageHandler {
+	return func(ctx context.Context, messageBody string) *types.Error {
+		dryRunCtx, writes := dbclients.WithDryRunWrites(ctx)
+		if err := next(dryRunCtx, messageBody); err != nil {
+			return err
+		}
+
+		// The handler already rejected the bodies that are not staking events,
+		// the staking tx hash of a reference message is left empty
+		var stakingEvent queueClient.StakingEvent
+		if err := json.Unmarshal([]byte(messageBody), &stakingEvent); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("Failed to read the staking tx hash of the dry-run message")
+		}
+
+		return h.Services.V2Service.SaveDryRunShadowRecord(
+			ctx, queueName, stakingEvent.StakingTxHashHex, messageBody, writes.Writes(),
+		)
+	}
+}
@@ -0,0 +1,98 @@
+package v2queuehandler
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	queueClient "github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunStakingHandler(t *testing.T) {
+	ctx := context.Background()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	stakingTxHashHex := "2f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+
+	event := queueClient.NewActiveStakingEvent(
+		stakingTxHashHex, stakerPkHex, []string{"fp"}, 1000, nil,
+	)
+	body, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	t.Run("active staking event only writes a shadow record", func(t *testing.T) {
+		// the dry-run handler must only read from the databases
+		v1DB := mocks.NewV1DBClient(t)
+		v2DB := mocks.NewV2DBClient(t)
+		v2DB.On("GetStatsLock", mock.Anything, stakingTxHashHex, types.Active.ToString()).
+			Return(v2dbmodel.NewV2StatsLockDocument("id", false, false, false), nil).Once()
+
+		liveV2DB := mocks.NewV2DBClient(t)
+		var shadowRecord *dbmodel.DryRunShadowRecordDocument
+		liveV2DB.On("SaveDryRunShadowRecord", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				shadowRecord = args.Get(1).(*dbmodel.DryRunShadowRecordDocument)
+			}).
+			Return(nil).Once()
+
+		cfg := &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}}
+		liveService, err := v2service.New(ctx, cfg, nil, &dbclients.DbClients{V2DBClient: liveV2DB})
+		require.NoError(t, err)
+		handler := NewV2QueueHandler(&services.Services{V2Service: liveService})
+		dryRunServices, err := services.NewDryRun(ctx, cfg, nil, nil, nil, &dbclients.DbClients{
+			V1DBClient: v1DB,
+			V2DBClient: v2DB,
+		})
+		require.NoError(t, err)
+		dryRunHandler := NewV2QueueHandler(dryRunServices)
+
+		rpcErr := handler.WithDryRun("active", dryRunHandler.ActiveStakingHandler)(ctx, string(body))
+		require.Nil(t, rpcErr)
+
+		require.NotNil(t, shadowRecord)
+		assert.Equal(t, "active", shadowRecord.QueueName)
+		assert.Equal(t, stakingTxHashHex, shadowRecord.StakingTxHashHex)
+		assert.Equal(t, string(body), shadowRecord.MessageBody)
+		assert.Equal(t, []string{
+			"v1.InsertPkAddressMappings",
+			"v1.TransitionToTransitionedState",
+			"v2.IncrementFinalityProviderStats",
+			"v2.HandleActiveStakerStats",
+			"v2.IncrementOverallStats",
+		}, shadowRecord.IntendedWrites)
+	})
+
+	t.Run("invalid message is rejected without any write", func(t *testing.T) {
+		cfg := &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}}
+		liveService, err := v2service.New(ctx, cfg, nil, &dbclients.DbClients{V2DBClient: mocks.NewV2DBClient(t)})
+		require.NoError(t, err)
+		handler := NewV2QueueHandler(&services.Services{V2Service: liveService})
+		dryRunServices, err := services.NewDryRun(ctx, cfg, nil, nil, nil, &dbclients.DbClients{
+			V1DBClient: mocks.NewV1DBClient(t),
+			V2DBClient: mocks.NewV2DBClient(t),
+		})
+		require.NoError(t, err)
+		dryRunHandler := NewV2QueueHandler(dryRunServices)
+
+		rpcErr := handler.WithDryRun("active", dryRunHandler.ActiveStakingHandler)(ctx, "{")
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, types.BadRequest, rpcErr.ErrorCode)
+	})
+}
@@ -0,0 +1,108 @@
+package v2queuehandler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// eventProgress holds the optional ordering fields a producer can attach to
+// the queue events. They are not part of the staking event schema yet, events
+// without them are never flagged.
+type eventProgress struct {
+	SequenceNumber *uint64 `json:"sequence_number"`
+	Height         *uint64 `json:"height"`
+}
+
+type queueProgress struct {
+	maxHeight    uint64
+	lastSequence uint64
+	hasSequence  bool
+	lastEventAt  time.Time
+}
+
+// EventGapDetector keeps, per queue, the highest height and sequence number
+// received so far in order to detect events the producer may have skipped.
+type EventGapDetector struct {
+	maxHeightGap uint64
+	mu           sync.Mutex
+	queues       map[string]*queueProgress
+}
+
+func NewEventGapDetector(maxHeightGap uint64) *EventGapDetector {
+	return &EventGapDetector{
+		maxHeightGap: maxHeightGap,
+		queues:       make(map[string]*queueProgress),
+	}
+}
+
+// Observe records the progress carried by the message and returns a suspected
+// gap if the height jumped more than the allowed gap ahead of the previous
+// maximum while the sequence number shows missing intermediate events.
+// Height jumps with consecutive sequence numbers are legitimate quiet periods.
+func (d *EventGapDetector) Observe(queueName, messageBody string, now time.Time) *v2dbmodel.EventGapDocument {
+	var event eventProgress
+	if err := json.Unmarshal([]byte(messageBody), &event); err != nil || event.Height == nil {
+		return nil
+	}
+	height := *event.Height
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	progress, ok := d.queues[queueName]
+	if !ok {
+		progress = &queueProgress{maxHeight: height}
+		d.queues[queueName] = progress
+	}
+
+	var gap *v2dbmodel.EventGapDocument
+	if event.SequenceNumber != nil && progress.hasSequence {
+		sequence := *event.SequenceNumber
+		if height > progress.maxHeight+d.maxHeightGap && sequence > progress.lastSequence+1 {
+			gap = v2dbmodel.NewEventGapDocument(
+				queueName, progress.lastSequence, sequence, progress.maxHeight, height,
+				progress.lastEventAt.Unix(), now.Unix(),
+			)
+		}
+	}
+
+	if height > progress.maxHeight {
+		progress.maxHeight = height
+	}
+	// redelivered and out of order messages do not move the sequence back
+	if event.SequenceNumber != nil && (!progress.hasSequence || *event.SequenceNumber > progress.lastSequence) {
+		progress.lastSequence = *event.SequenceNumber
+		progress.hasSequence = true
+	}
+	progress.lastEventAt = now
+
+	return gap
+}
+
+// WithEventGapDetection wraps the handler of the given queue so that every
+// received message is inspected by the gap detector before being processed.
+// Failing to store a suspected gap never fails the message processing.
+func (qh *V2QueueHandler) WithEventGapDetection(
+	queueName string, detector *EventGapDetector, next MessageHandler,
+) MessageHandler {
+	return func(ctx context.Context, messageBody string) *types.Error {
+		if gap := detector.Observe(queueName, messageBody, time.Now()); gap != nil {
+			log.Ctx(ctx).Warn().
+				Uint64("fromHeight", gap.FromHeight).Uint64("toHeight", gap.ToHeight).
+				Uint64("fromSequence", gap.FromSequence).Uint64("toSequence", gap.ToSequence).
+				Msg("suspected gap in the received events")
+			metrics.RecordEventGap(queueName)
+			if err := qh.Services.V2Service.SaveEventGap(ctx, gap); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("failed to save suspected event gap")
+			}
+		}
+		return next(ctx, messageBody)
+	}
+}
@@ -0,0 +1,65 @@
+package v2queuehandler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventGapDetector(t *testing.T) {
+	const queueName = "v2_active_staking_queue"
+	start := time.Unix(1700000000, 0)
+
+	event := func(sequence, height uint64) string {
+		return fmt.Sprintf(`{"staking_tx_hash_hex":"aa","sequence_number":%d,"height":%d}`, sequence, height)
+	}
+
+	t.Run("consecutive sequence numbers are quiet periods", func(t *testing.T) {
+		detector := NewEventGapDetector(10)
+		assert.Nil(t, detector.Observe(queueName, event(1, 100), start))
+		assert.Nil(t, detector.Observe(queueName, event(2, 105), start))
+		// large height jump, but no event is missing
+		assert.Nil(t, detector.Observe(queueName, event(3, 500), start))
+	})
+
+	t.Run("height jump with missing sequence numbers is a gap", func(t *testing.T) {
+		detector := NewEventGapDetector(10)
+		assert.Nil(t, detector.Observe(queueName, event(1, 100), start))
+		assert.Nil(t, detector.Observe(queueName, event(2, 105), start.Add(time.Minute)))
+		// missing sequence numbers within the allowed height gap
+		assert.Nil(t, detector.Observe(queueName, event(4, 110), start.Add(2*time.Minute)))
+
+		gap := detector.Observe(queueName, event(9, 300), start.Add(3*time.Minute))
+		require.NotNil(t, gap)
+		assert.Equal(t, queueName, gap.QueueName)
+		assert.Equal(t, uint64(4), gap.FromSequence)
+		assert.Equal(t, uint64(9), gap.ToSequence)
+		assert.Equal(t, uint64(110), gap.FromHeight)
+		assert.Equal(t, uint64(300), gap.ToHeight)
+		assert.Equal(t, start.Add(2*time.Minute).Unix(), gap.PreviousEventAt)
+		assert.Equal(t, start.Add(3*time.Minute).Unix(), gap.DetectedAt)
+
+		// redelivered message does not move the progress back
+		assert.Nil(t, detector.Observe(queueName, event(4, 110), start))
+		assert.Nil(t, detector.Observe(queueName, event(10, 301), start))
+	})
+
+	t.Run("events without sequence number are never flagged", func(t *testing.T) {
+		detector := NewEventGapDetector(10)
+		assert.Nil(t, detector.Observe(queueName, `{"height":100}`, start))
+		assert.Nil(t, detector.Observe(queueName, `{"height":1000}`, start))
+		assert.Nil(t, detector.Observe(queueName, `{"staking_tx_hash_hex":"aa"}`, start))
+		// the first sequence number only starts the tracking
+		assert.Nil(t, detector.Observe(queueName, event(5, 2000), start))
+	})
+
+	t.Run("queues are tracked independently", func(t *testing.T) {
+		detector := NewEventGapDetector(10)
+		assert.Nil(t, detector.Observe(queueName, event(1, 100), start))
+		assert.Nil(t, detector.Observe("v2_unbonding_staking_queue", event(7, 500), start))
+		assert.NotNil(t, detector.Observe(queueName, event(3, 500), start))
+	})
+}
@@ -12,7 +12,9 @@ type V2QueueHandler struct {
 }
 
 type MessageHandler func(ctx context.Context, messageBody string) *types.Error
-type UnprocessableMessageHandler func(ctx context.Context, messageBody, receipt string) *types.Error
+type UnprocessableMessageHandler func(
+	ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+) *types.Error
 
 func NewV2QueueHandler(services *services.Services) *V2QueueHandler {
 	return &V2QueueHandler{
@@ -20,6 +22,10 @@ func NewV2QueueHandler(services *services.Services) *V2QueueHandler {
 	}
 }
 
-func (qh *V2QueueHandler) HandleUnprocessedMessage(ctx context.Context, messageBody, receipt string) *types.Error {
-	return qh.Services.SharedService.SaveUnprocessableMessages(ctx, messageBody, receipt)
+func (qh *V2QueueHandler) HandleUnprocessedMessage(
+	ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+) *types.Error {
+	return qh.Services.SharedService.SaveUnprocessableMessages(
+		ctx, queueName, messageBody, receipt, processingErr, retryAttempts,
+	)
 }
@@ -0,0 +1,32 @@
+package v2queuehandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// WithMetrics wraps the handler of the given queue so that the duration and
+// the outcome of every processed message are recorded under the mode the
+// handler runs in.
+func WithMetrics(queueName, mode string, next MessageHandler) MessageHandler {
+	queue := queueMetricLabel(queueName)
+	return func(ctx context.Context, messageBody string) *types.Error {
+		timer := metrics.StartQueueMessageTimer(queue, mode)
+		err := next(ctx, messageBody)
+		if err != nil {
+			timer(metrics.Error)
+		} else {
+			timer(metrics.Success)
+		}
+		return err
+	}
+}
+
+// queueMetricLabel shortens the queue name, e.g. v2_active_staking_queue is
+// labelled active_staking
+func queueMetricLabel(queueName string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(queueName, "v2_"), "_queue")
+}
@@ -0,0 +1,63 @@
+package v2queuehandler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	queueclient "github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queueMessagesCount returns the number of messages counted for the queue,
+// the outcome and the mode, 0 if none was counted yet
+func queueMessagesCount(t *testing.T, queue string, outcome metrics.Outcome, mode string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "staking_api_queue_messages_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["queue"] == queue && labels["outcome"] == outcome.String() && labels["mode"] == mode {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestWithMetrics(t *testing.T) {
+	metrics.Init(0)
+	ctx := context.Background()
+
+	var failure *types.Error
+	next := func(ctx context.Context, messageBody string) *types.Error {
+		return failure
+	}
+	handler := WithMetrics(queueclient.ActiveStakingQueueName, "live", next)
+	dryRunHandler := WithMetrics(queueclient.ActiveStakingQueueName, "dry-run", next)
+
+	errorsBefore := queueMessagesCount(t, "active_staking", metrics.Error, "live")
+	successesBefore := queueMessagesCount(t, "active_staking", metrics.Success, "live")
+	dryRunSuccessesBefore := queueMessagesCount(t, "active_staking", metrics.Success, "dry-run")
+
+	require.Nil(t, handler(ctx, "{}"))
+	require.Nil(t, dryRunHandler(ctx, "{}"))
+	failure = types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "db down")
+	assert.Equal(t, failure, handler(ctx, "{}"))
+	assert.Equal(t, failure, handler(ctx, "{}"))
+
+	assert.Equal(t, successesBefore+1, queueMessagesCount(t, "active_staking", metrics.Success, "live"))
+	assert.Equal(t, errorsBefore+2, queueMessagesCount(t, "active_staking", metrics.Error, "live"))
+	// the dry-run messages are not counted as live ones
+	assert.Equal(t, dryRunSuccessesBefore+1, queueMessagesCount(t, "active_staking", metrics.Success, "dry-run"))
+}
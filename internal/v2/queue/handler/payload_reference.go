@@ -0,0 +1,104 @@
+package v2queuehandler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// PayloadReference points to the payload of a message too large for the
+// broker. The payload is either a document of a collection or an object
+// behind a URL, its hex encoded sha256 is declared by the producer.
+type PayloadReference struct {
+	Collection string `json:"collection,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Url        string `json:"url,omitempty"`
+	Sha256     string `json:"sha256"`
+}
+
+// referenceMessage is the body of a message carrying only a reference to its
+// payload, regular messages have no payload_reference field
+type referenceMessage struct {
+	PayloadReference *PayloadReference `json:"payload_reference"`
+}
+
+// PayloadStore resolves the payloads of the reference messages
+type PayloadStore interface {
+	FetchPayload(ctx context.Context, ref *PayloadReference) (string, *types.Error)
+	DeletePayload(ctx context.Context, ref *PayloadReference) *types.Error
+}
+
+// dbPayloadStore resolves the references to the queue payloads collection
+type dbPayloadStore struct {
+	qh *V2QueueHandler
+}
+
+// DbPayloadStore returns the store of the payloads kept in the db
+func (qh *V2QueueHandler) DbPayloadStore() PayloadStore {
+	return &dbPayloadStore{qh: qh}
+}
+
+func (s *dbPayloadStore) FetchPayload(ctx context.Context, ref *PayloadReference) (string, *types.Error) {
+	if ref.Collection != dbmodel.V2QueuePayloadsCollection || ref.Id == "" {
+		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("unsupported payload reference to collection %q", ref.Collection),
+		)
+	}
+	return s.qh.Services.V2Service.GetQueuePayload(ctx, ref.Id)
+}
+
+func (s *dbPayloadStore) DeletePayload(ctx context.Context, ref *PayloadReference) *types.Error {
+	return s.qh.Services.V2Service.DeleteQueuePayload(ctx, ref.Id)
+}
+
+// WithPayloadReferences wraps the handler so that reference messages are
+// processed with the payload they point to. The payload is validated against
+// its declared hash and removed from the store once processed. Failing to
+// resolve the payload fails the message, which is then retried as usual.
+func WithPayloadReferences(store PayloadStore, next MessageHandler) MessageHandler {
+	return func(ctx context.Context, messageBody string) *types.Error {
+		var message referenceMessage
+		if err := json.Unmarshal([]byte(messageBody), &message); err != nil || message.PayloadReference == nil {
+			return next(ctx, messageBody)
+		}
+		ref := message.PayloadReference
+
+		payload, err := store.FetchPayload(ctx, ref)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).
+				Str("collection", ref.Collection).Str("id", ref.Id).Str("url", ref.Url).
+				Msg("failed to fetch the payload of the reference message")
+			return err
+		}
+		hash := sha256.Sum256([]byte(payload))
+		if !strings.EqualFold(hex.EncodeToString(hash[:]), ref.Sha256) {
+			log.Ctx(ctx).Error().
+				Str("collection", ref.Collection).Str("id", ref.Id).Str("url", ref.Url).
+				Msg("payload of the reference message does not match its declared hash")
+			return types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "payload does not match the declared sha256",
+			)
+		}
+
+		if err := next(ctx, payload); err != nil {
+			return err
+		}
+
+		// The message is processed, a payload left behind is only wasted space
+		if err := store.DeletePayload(ctx, ref); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("collection", ref.Collection).Str("id", ref.Id).Str("url", ref.Url).
+				Msg("failed to delete the payload of the processed reference message")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package v2queuehandler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPayloadReferences(t *testing.T) {
+	ctx := context.Background()
+	const payload = `{"staking_tx_hash_hex":"aa","staking_amount":1000}`
+	payloadHash := sha256.Sum256([]byte(payload))
+
+	referenceMessage := func(sha256Hex string) string {
+		return fmt.Sprintf(
+			`{"payload_reference":{"collection":%q,"id":"payload-id","sha256":%q}}`,
+			dbmodel.V2QueuePayloadsCollection, sha256Hex,
+		)
+	}
+	newHandler := func(v2DB *mocks.V2DBClient, processed *[]string) MessageHandler {
+		service, err := v2service.New(ctx, &config.Config{}, nil, &dbclients.DbClients{V2DBClient: v2DB})
+		require.NoError(t, err)
+		handler := NewV2QueueHandler(&services.Services{V2Service: service})
+		return WithPayloadReferences(handler.DbPayloadStore(), func(_ context.Context, messageBody string) *types.Error {
+			*processed = append(*processed, messageBody)
+			return nil
+		})
+	}
+
+	t.Run("matching hash processes the payload and deletes it", func(t *testing.T) {
+		v2DB := mocks.NewV2DBClient(t)
+		v2DB.On("FindQueuePayload", ctx, "payload-id").
+			Return(&v2dbmodel.QueuePayloadDocument{Id: "payload-id", Payload: payload}, nil).Once()
+		v2DB.On("DeleteQueuePayload", ctx, "payload-id").Return(nil).Once()
+
+		var processed []string
+		err := newHandler(v2DB, &processed)(ctx, referenceMessage(hex.EncodeToString(payloadHash[:])))
+		require.Nil(t, err)
+		assert.Equal(t, []string{payload}, processed)
+	})
+
+	t.Run("mismatching hash fails the message and keeps the payload", func(t *testing.T) {
+		v2DB := mocks.NewV2DBClient(t)
+		v2DB.On("FindQueuePayload", ctx, "payload-id").
+			Return(&v2dbmodel.QueuePayloadDocument{Id: "payload-id", Payload: payload}, nil).Once()
+
+		var processed []string
+		otherHash := sha256.Sum256([]byte("other payload"))
+		err := newHandler(v2DB, &processed)(ctx, referenceMessage(hex.EncodeToString(otherHash[:])))
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Empty(t, processed)
+	})
+
+	t.Run("regular messages are processed as is", func(t *testing.T) {
+		var processed []string
+		err := newHandler(mocks.NewV2DBClient(t), &processed)(ctx, payload)
+		require.Nil(t, err)
+		assert.Equal(t, []string{payload}, processed)
+	})
+
+	t.Run("references to other collections are rejected", func(t *testing.T) {
+		var processed []string
+		err := newHandler(mocks.NewV2DBClient(t), &processed)(
+			ctx, `{"payload_reference":{"collection":"delegations","id":"payload-id","sha256":"aa"}}`,
+		)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Empty(t, processed)
+	})
+}
@@ -20,56 +20,111 @@ func (h *V2QueueHandler) ActiveStakingHandler(ctx context.Context, messageBody s
 		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
 	}
 
-	// Mark as v1 delegation as transitioned if it exists
-	if err := h.Services.V2Service.MarkV1DelegationAsTransitioned(ctx, activeStakingEvent.StakingTxHashHex); err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to mark v1 delegation as transitioned")
-		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
-	}
-
-	// Perform the address lookup conversion
+	// Perform the address lookup conversion, it is kept out of the
+	// transaction as inserting the addresses of a known staker fails with a
+	// duplicate key error, which aborts the transaction
 	addErr := h.Services.V1Service.ProcessAndSaveBtcAddresses(ctx, activeStakingEvent.StakerBtcPkHex)
 	if addErr != nil {
 		log.Ctx(ctx).Error().Err(addErr).Msg("Failed to process and save btc addresses")
 		return addErr
 	}
 
-	statsErr := h.Services.V2Service.ProcessActiveDelegationStats(
-		ctx,
-		activeStakingEvent.StakingTxHashHex,
-		activeStakingEvent.StakerBtcPkHex,
-		activeStakingEvent.FinalityProviderBtcPksHex,
-		activeStakingEvent.StakingAmount,
-	)
-	if statsErr != nil {
-		log.Ctx(ctx).Error().Err(statsErr).Msg("Failed to process staking stats calculation")
-		return statsErr
+	// The v1 delegation is transitioned along with the stats so that they do
+	// not drift apart
+	txErr := h.Services.V2Service.WithTransaction(ctx, func(ctx context.Context) *types.Error {
+		// Mark as v1 delegation as transitioned if it exists
+		if err := h.Services.V2Service.MarkV1DelegationAsTransitioned(ctx, activeStakingEvent.StakingTxHashHex); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to mark v1 delegation as transitioned")
+			return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+		}
+
+		statsErr := h.Services.V2Service.ProcessActiveDelegationStats(
+			ctx,
+			activeStakingEvent.StakingTxHashHex,
+			activeStakingEvent.StakerBtcPkHex,
+			activeStakingEvent.FinalityProviderBtcPksHex,
+			activeStakingEvent.StakingAmount,
+		)
+		if statsErr != nil {
+			log.Ctx(ctx).Error().Err(statsErr).Msg("Failed to process staking stats calculation")
+			return statsErr
+		}
+		return nil
+	})
+	if txErr != nil {
+		return txErr
 	}
-
+	h.publishStateChange(activeStakingEvent, types.Active)
 	return nil
 }
 
+// unbondingStakingEvent extends the staking event with the unbonding tx
+// confirmed by the phase-1 unbonding pipeline, the fields are only set for
+// phase-1 delegations as the queue schema does not carry them yet
+type unbondingStakingEvent struct {
+	queueClient.StakingEvent
+	UnbondingTxHashHex      string `json:"unbonding_tx_hash_hex,omitempty"`
+	UnbondingTxHex          string `json:"unbonding_tx_hex,omitempty"`
+	UnbondingStartHeight    uint64 `json:"unbonding_start_height,omitempty"`
+	UnbondingStartTimestamp int64  `json:"unbonding_start_timestamp,omitempty"`
+	UnbondingTimelock       uint64 `json:"unbonding_timelock,omitempty"`
+	UnbondingOutputIndex    uint64 `json:"unbonding_output_index,omitempty"`
+}
+
 // UnbondingStakingHandler processes unbonding staking events
 func (h *V2QueueHandler) UnbondingStakingHandler(ctx context.Context, messageBody string) *types.Error {
-	var unbondingStakingEvent queueClient.StakingEvent
+	var unbondingStakingEvent unbondingStakingEvent
 	err := json.Unmarshal([]byte(messageBody), &unbondingStakingEvent)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to unmarshal the message body into UnbondingStakingEvent")
 		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
 	}
 
-	// Perform the stats calculation
-	statsErr := h.Services.V2Service.ProcessUnbondingDelegationStats(
-		ctx,
-		unbondingStakingEvent.StakingTxHashHex,
-		unbondingStakingEvent.StakerBtcPkHex,
-		unbondingStakingEvent.FinalityProviderBtcPksHex,
-		unbondingStakingEvent.StakingAmount,
-		unbondingStakingEvent.StateHistory,
-	)
-	if statsErr != nil {
-		log.Ctx(ctx).Error().Err(statsErr).Msg("Failed to process staking stats calculation")
-		return statsErr
+	// The transition and the stats are written in a single transaction so
+	// that they do not drift apart. On a standalone server, which does not
+	// support transactions, the stats locks keep a redelivered event from
+	// being counted twice.
+	txErr := h.Services.V2Service.WithTransaction(ctx, func(ctx context.Context) *types.Error {
+		// Events of the phase-1 unbonding pipeline only transition the v1
+		// delegation, the delegation is not part of the phase-2 stats
+		if unbondingStakingEvent.UnbondingTxHex != "" {
+			unbondingErr := h.Services.V1Service.ProcessUnbondingDelegation(
+				ctx,
+				unbondingStakingEvent.StakingTxHashHex,
+				unbondingStakingEvent.UnbondingStartHeight,
+				unbondingStakingEvent.UnbondingTimelock,
+				unbondingStakingEvent.UnbondingOutputIndex,
+				unbondingStakingEvent.UnbondingTxHex,
+				unbondingStakingEvent.UnbondingStartTimestamp,
+			)
+			if unbondingErr != nil {
+				log.Ctx(ctx).Error().Err(unbondingErr).
+					Str("unbondingTxHashHex", unbondingStakingEvent.UnbondingTxHashHex).
+					Msg("Failed to transition v1 delegation to unbonding")
+				return unbondingErr
+			}
+			return nil
+		}
+
+		// Perform the stats calculation
+		statsErr := h.Services.V2Service.ProcessUnbondingDelegationStats(
+			ctx,
+			unbondingStakingEvent.StakingTxHashHex,
+			unbondingStakingEvent.StakerBtcPkHex,
+			unbondingStakingEvent.FinalityProviderBtcPksHex,
+			unbondingStakingEvent.StakingAmount,
+			unbondingStakingEvent.StateHistory,
+		)
+		if statsErr != nil {
+			log.Ctx(ctx).Error().Err(statsErr).Msg("Failed to process staking stats calculation")
+			return statsErr
+		}
+		return nil
+	})
+	if txErr != nil {
+		return txErr
 	}
+	h.publishStateChange(unbondingStakingEvent.StakingEvent, types.Unbonding)
 	return nil
 }
 
@@ -96,30 +151,69 @@ func (h *V2QueueHandler) WithdrawableStakingHandler(ctx context.Context, message
 		log.Ctx(ctx).Error().Err(statsErr).Msg("Failed to process staking stats calculation")
 		return statsErr
 	}
+	h.publishStateChange(withdrawableStakingEvent, types.Withdrawable)
 
 	return nil
 }
 
+// withdrawnStakingEvent extends the staking event with the withdrawal tx, the
+// fields are optional as the queue schema does not carry them yet
+type withdrawnStakingEvent struct {
+	queueClient.StakingEvent
+	WithdrawalTxHashHex string `json:"withdrawal_tx_hash_hex,omitempty"`
+	WithdrawalTxHeight  uint64 `json:"withdrawal_tx_height,omitempty"`
+	WithdrawalAddress   string `json:"withdrawal_address,omitempty"`
+}
+
 // WithdrawnStakingHandler processes withdrawn staking events
 func (h *V2QueueHandler) WithdrawnStakingHandler(ctx context.Context, messageBody string) *types.Error {
-	var withdrawnStakingEvent queueClient.StakingEvent
+	var withdrawnStakingEvent withdrawnStakingEvent
 	err := json.Unmarshal([]byte(messageBody), &withdrawnStakingEvent)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to unmarshal the message body into WithdrawnStakingEvent")
 		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
 	}
 
-	statsErr := h.Services.V2Service.ProcessWithdrawnDelegationStats(
-		ctx,
-		withdrawnStakingEvent.StakingTxHashHex,
-		withdrawnStakingEvent.StakerBtcPkHex,
-		withdrawnStakingEvent.StakingAmount,
-		withdrawnStakingEvent.StateHistory,
-	)
-	if statsErr != nil {
-		log.Ctx(ctx).Error().Err(statsErr).Msg("Failed to process staking stats calculation")
-		return statsErr
+	// The v1 delegation is transitioned along with the stats so that they do
+	// not drift apart, the stats locks keep a redelivered event from being
+	// counted twice on a standalone server
+	txErr := h.Services.V2Service.WithTransaction(ctx, func(ctx context.Context) *types.Error {
+		statsErr := h.Services.V2Service.ProcessWithdrawnDelegationStats(
+			ctx,
+			withdrawnStakingEvent.StakingTxHashHex,
+			withdrawnStakingEvent.StakerBtcPkHex,
+			withdrawnStakingEvent.StakingAmount,
+			withdrawnStakingEvent.StateHistory,
+		)
+		if statsErr != nil {
+			log.Ctx(ctx).Error().Err(statsErr).Msg("Failed to process staking stats calculation")
+			return statsErr
+		}
+
+		// Transition the v1 delegation to withdrawn if it exists
+		withdrawErr := h.Services.V1Service.ProcessWithdrawnDelegation(
+			ctx,
+			withdrawnStakingEvent.StakingTxHashHex,
+			withdrawnStakingEvent.WithdrawalTxHashHex,
+			withdrawnStakingEvent.WithdrawalAddress,
+			withdrawnStakingEvent.WithdrawalTxHeight,
+		)
+		if withdrawErr != nil {
+			log.Ctx(ctx).Error().Err(withdrawErr).Msg("Failed to transition v1 delegation to withdrawn")
+			return withdrawErr
+		}
+		return nil
+	})
+	if txErr != nil {
+		return txErr
 	}
+	h.publishStateChange(withdrawnStakingEvent.StakingEvent, types.Withdrawn)
 
 	return nil
 }
+
+// publishStateChange notifies the subscribers of the staker once the event
+// is processed
+func (h *V2QueueHandler) publishStateChange(event queueClient.StakingEvent, state types.DelegationState) {
+	h.Services.V1Service.PublishDelegationStateChange(event.StakingTxHashHex, event.StakerBtcPkHex, state)
+}
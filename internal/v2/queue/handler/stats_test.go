@@ -0,0 +1,757 @@
+package v2queuehandler
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	queueClient "github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeV2Stats keeps the finality provider stats, the overall stats and the
+// stats locks in memory, mirroring the idempotency rules of the v2 database client
+type fakeV2Stats struct {
+	locks   map[string]*v2dbmodel.V2StatsLockDocument
+	stats   map[string]*v2dbmodel.V2FinalityProviderStatsDocument
+	overall v2dbmodel.V2OverallStatsDocument
+}
+
+func newFakeV2Stats() *fakeV2Stats {
+	return &fakeV2Stats{
+		locks: make(map[string]*v2dbmodel.V2StatsLockDocument),
+		stats: make(map[string]*v2dbmodel.V2FinalityProviderStatsDocument),
+	}
+}
+
+func (f *fakeV2Stats) getOrCreateStatsLock(
+	_ context.Context, stakingTxHashHex, state string,
+) (*v2dbmodel.V2StatsLockDocument, error) {
+	id := stakingTxHashHex + ":" + state
+	lock, ok := f.locks[id]
+	if !ok {
+		lock = v2dbmodel.NewV2StatsLockDocument(id, false, false, false)
+		f.locks[id] = lock
+	}
+	lockCopy := *lock
+	return &lockCopy, nil
+}
+
+func (f *fakeV2Stats) update(
+	stakingTxHashHex, state string, fpPkHexes []string, apply func(*v2dbmodel.V2FinalityProviderStatsDocument),
+) error {
+	lock := f.locks[stakingTxHashHex+":"+state]
+	if lock == nil || lock.FinalityProviderStats {
+		return &db.NotFoundError{Key: stakingTxHashHex, Message: "document already processed or does not exist"}
+	}
+	lock.FinalityProviderStats = true
+	for _, fpPkHex := range fpPkHexes {
+		stats, ok := f.stats[fpPkHex]
+		if !ok {
+			stats = &v2dbmodel.V2FinalityProviderStatsDocument{FinalityProviderPkHex: fpPkHex}
+			f.stats[fpPkHex] = stats
+		}
+		apply(stats)
+	}
+	return nil
+}
+
+func (f *fakeV2Stats) increment(
+	_ context.Context, stakingTxHashHex string, fpPkHexes []string, amount uint64,
+) error {
+	return f.update(stakingTxHashHex, "active", fpPkHexes, func(s *v2dbmodel.V2FinalityProviderStatsDocument) {
+		s.ActiveTvl += int64(amount)
+		s.ActiveDelegations++
+		s.TotalTvl += int64(amount)
+		s.TotalDelegations++
+	})
+}
+
+func (f *fakeV2Stats) subtract(
+	_ context.Context, stakingTxHashHex string, fpPkHexes []string, amount uint64,
+) error {
+	return f.update(stakingTxHashHex, "unbonding", fpPkHexes, func(s *v2dbmodel.V2FinalityProviderStatsDocument) {
+		s.ActiveTvl -= int64(amount)
+		s.ActiveDelegations--
+	})
+}
+
+func (f *fakeV2Stats) updateOverall(stakingTxHashHex, state string, tvl, delegations int64) error {
+	lock := f.locks[stakingTxHashHex+":"+state]
+	if lock == nil || lock.OverallStats {
+		return &db.NotFoundError{Key: stakingTxHashHex, Message: "document already processed or does not exist"}
+	}
+	lock.OverallStats = true
+	f.overall.ActiveTvl += tvl
+	f.overall.ActiveDelegations += delegations
+	return nil
+}
+
+func (f *fakeV2Stats) incrementOverall(_ context.Context, stakingTxHashHex string, amount uint64) error {
+	return f.updateOverall(stakingTxHashHex, "active", int64(amount), 1)
+}
+
+func (f *fakeV2Stats) subtractOverall(_ context.Context, stakingTxHashHex string, amount uint64) error {
+	return f.updateOverall(stakingTxHashHex, "unbonding", -int64(amount), -1)
+}
+
+func (f *fakeV2Stats) getOverall(_ context.Context) (*v2dbmodel.V2OverallStatsDocument, error) {
+	overall := f.overall
+	return &overall, nil
+}
+
+func (f *fakeV2Stats) list(_ context.Context) ([]*v2dbmodel.V2FinalityProviderStatsDocument, error) {
+	result := make([]*v2dbmodel.V2FinalityProviderStatsDocument, 0, len(f.stats))
+	for _, stats := range f.stats {
+		result = append(result, stats)
+	}
+	return result, nil
+}
+
+// clone returns a deep copy of the stats
+func (f *fakeV2Stats) clone() *fakeV2Stats {
+	cloned := newFakeV2Stats()
+	for id, lock := range f.locks {
+		lockCopy := *lock
+		cloned.locks[id] = &lockCopy
+	}
+	for fpPkHex, stats := range f.stats {
+		statsCopy := *stats
+		cloned.stats[fpPkHex] = &statsCopy
+	}
+	cloned.overall = f.overall
+	return cloned
+}
+
+// runInTransaction runs the transaction of a mocked database client as is
+func runInTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// rollBackOnFailure runs the transaction of a mocked database client, and
+// calls the restore function returned by snapshot if it fails, as aborting
+// the transaction would
+func rollBackOnFailure(
+	snapshot func() (restore func()),
+) func(context.Context, func(context.Context) error) error {
+	return func(ctx context.Context, fn func(context.Context) error) error {
+		restore := snapshot()
+		if err := fn(ctx); err != nil {
+			restore()
+			return err
+		}
+		return nil
+	}
+}
+
+func TestFinalityProviderStatsFromQueueEvents(t *testing.T) {
+	ctx := context.Background()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+
+	const (
+		fpA      = "aa"
+		fpB      = "bb"
+		txHashA1 = "1f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+		txHashA2 = "2f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+		txHashB1 = "3f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	)
+
+	fake := newFakeV2Stats()
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("TransitionToTransitionedState", mock.Anything, mock.Anything).
+		Return(&db.NotFoundError{Message: "not found"})
+	v1DB.On("InsertPkAddressMappings", ctx, stakerPkHex, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	v2DB := &mocks.V2DBClient{}
+	v2DB.On("WithTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	v2DB.On("GetOrCreateStatsLock", mock.Anything, mock.Anything, mock.Anything).Return(fake.getOrCreateStatsLock)
+	v2DB.On("IncrementFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fake.increment)
+	v2DB.On("SubtractFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fake.subtract)
+	v2DB.On("GetFinalityProviderStats", ctx).Return(fake.list)
+	v2DB.On("HandleActiveStakerStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	v2DB.On("IncrementOverallStats", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	v2DB.On("HandleUnbondingStakerStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	v2DB.On("SubtractOverallStats", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	indexerDB := mocks.NewIndexerDBClient(t)
+	indexerDB.On("GetFinalityProviders", ctx).Return([]*indexerdbmodel.IndexerFinalityProviderDetails{
+		{BtcPk: fpA, State: indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_ACTIVE},
+		{BtcPk: fpB, State: indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_ACTIVE},
+	}, nil)
+
+	dbClients := &dbclients.DbClients{
+		V1DBClient:      v1DB,
+		V2DBClient:      v2DB,
+		IndexerDBClient: indexerDB,
+	}
+	cfg := &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}}
+	v2Service, err := v2service.New(ctx, cfg, nil, dbClients)
+	require.NoError(t, err)
+	handler := NewV2QueueHandler(&services.Services{
+		V1Service: &v1service.V1Service{Service: &service.Service{Cfg: cfg, DbClients: dbClients}},
+		V2Service: v2Service,
+	})
+
+	send := func(handle func(context.Context, string) *types.Error, event queueClient.StakingEvent) {
+		body, err := json.Marshal(event)
+		require.NoError(t, err)
+		require.Nil(t, handle(ctx, string(body)))
+	}
+
+	send(handler.ActiveStakingHandler, queueClient.NewActiveStakingEvent(txHashA1, stakerPkHex, []string{fpA}, 1000, nil))
+	send(handler.ActiveStakingHandler, queueClient.NewActiveStakingEvent(txHashA2, stakerPkHex, []string{fpA}, 500, nil))
+	send(handler.ActiveStakingHandler, queueClient.NewActiveStakingEvent(txHashB1, stakerPkHex, []string{fpB}, 2000, nil))
+	// redelivered messages must not be counted twice
+	send(handler.ActiveStakingHandler, queueClient.NewActiveStakingEvent(txHashA1, stakerPkHex, []string{fpA}, 1000, nil))
+	send(handler.UnbondingStakingHandler, queueClient.NewUnbondingStakingEvent(
+		txHashB1, stakerPkHex, []string{fpB}, 2000, nil,
+	))
+	send(handler.UnbondingStakingHandler, queueClient.NewUnbondingStakingEvent(
+		txHashB1, stakerPkHex, []string{fpB}, 2000, nil,
+	))
+
+	providers, rpcErr := v2Service.GetFinalityProvidersWithStats(ctx, v2service.FinalityProviderOrderByActiveTvl)
+	require.Nil(t, rpcErr)
+	require.Len(t, providers, 2)
+
+	assert.Equal(t, fpA, providers[0].BtcPk)
+	assert.Equal(t, int64(1500), providers[0].ActiveTvl)
+	assert.Equal(t, int64(1500), providers[0].TotalTvl)
+	assert.Equal(t, int64(2), providers[0].ActiveDelegations)
+	assert.Equal(t, int64(2), providers[0].TotalDelegations)
+
+	assert.Equal(t, fpB, providers[1].BtcPk)
+	assert.Equal(t, int64(0), providers[1].ActiveTvl)
+	assert.Equal(t, int64(2000), providers[1].TotalTvl)
+	assert.Equal(t, int64(0), providers[1].ActiveDelegations)
+	assert.Equal(t, int64(1), providers[1].TotalDelegations)
+
+	providers, rpcErr = v2Service.GetFinalityProvidersWithStats(ctx, v2service.FinalityProviderOrderByTotalTvl)
+	require.Nil(t, rpcErr)
+	require.Len(t, providers, 2)
+	assert.Equal(t, fpB, providers[0].BtcPk)
+	assert.Equal(t, fpA, providers[1].BtcPk)
+}
+
+func TestOverallStatsCountRedeliveredEventsOnce(t *testing.T) {
+	ctx := context.Background()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	stakerPkHex := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+
+	const (
+		fpPkHex  = "aa"
+		txHashA1 = "1f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+		txHashA2 = "2f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	)
+
+	fake := newFakeV2Stats()
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("TransitionToTransitionedState", mock.Anything, mock.Anything).
+		Return(&db.NotFoundError{Message: "not found"})
+	v1DB.On("InsertPkAddressMappings", ctx, stakerPkHex, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	v2DB := &mocks.V2DBClient{}
+	v2DB.On("WithTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	v2DB.On("GetOrCreateStatsLock", mock.Anything, mock.Anything, mock.Anything).Return(fake.getOrCreateStatsLock)
+	v2DB.On("IncrementFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fake.increment)
+	v2DB.On("SubtractFinalityProviderStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fake.subtract)
+	v2DB.On("HandleActiveStakerStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	v2DB.On("HandleUnbondingStakerStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	v2DB.On("IncrementOverallStats", mock.Anything, mock.Anything, mock.Anything).Return(fake.incrementOverall)
+	v2DB.On("SubtractOverallStats", mock.Anything, mock.Anything, mock.Anything).Return(fake.subtractOverall)
+	v2DB.On("GetOverallStats", ctx).Return(fake.getOverall)
+	v2DB.On("GetActiveStakersCount", ctx).Return(int64(1), nil)
+
+	indexerDB := mocks.NewIndexerDBClient(t)
+	indexerDB.On("GetFinalityProviders", ctx).Return([]*indexerdbmodel.IndexerFinalityProviderDetails{
+		{BtcPk: fpPkHex, State: indexerdbmodel.FinalityProviderStatus_FINALITY_PROVIDER_STATUS_ACTIVE},
+	}, nil)
+
+	dbClients := &dbclients.DbClients{
+		V1DBClient:      v1DB,
+		V2DBClient:      v2DB,
+		IndexerDBClient: indexerDB,
+	}
+	cfg := &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}}
+	v2Service, err := v2service.New(ctx, cfg, nil, dbClients)
+	require.NoError(t, err)
+	handler := NewV2QueueHandler(&services.Services{
+		V1Service: &v1service.V1Service{Service: &service.Service{Cfg: cfg, DbClients: dbClients}},
+		V2Service: v2Service,
+	})
+
+	send := func(handle func(context.Context, string) *types.Error, event queueClient.StakingEvent) {
+		body, err := json.Marshal(event)
+		require.NoError(t, err)
+		// duplicates are acknowledged, not requeued
+		require.Nil(t, handle(ctx, string(body)))
+	}
+	assertOverall := func(activeTvl, activeDelegations int64) {
+		overall, rpcErr := v2Service.GetOverallStats(ctx)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, activeTvl, overall.ActiveTvl)
+		assert.Equal(t, activeDelegations, overall.ActiveDelegations)
+	}
+
+	activeA1 := queueClient.NewActiveStakingEvent(txHashA1, stakerPkHex, []string{fpPkHex}, 1000, nil)
+	send(handler.ActiveStakingHandler, activeA1)
+	send(handler.ActiveStakingHandler, activeA1)
+	assertOverall(1000, 1)
+
+	send(handler.ActiveStakingHandler, queueClient.NewActiveStakingEvent(txHashA2, stakerPkHex, []string{fpPkHex}, 500, nil))
+	assertOverall(1500, 2)
+
+	// the unbonding is guarded by its own lock, a delegation is counted
+	// once when activated and once when leaving the active set
+	unbondingA1 := queueClient.NewUnbondingStakingEvent(txHashA1, stakerPkHex, []string{fpPkHex}, 1000, nil)
+	send(handler.UnbondingStakingHandler, unbondingA1)
+	send(handler.UnbondingStakingHandler, unbondingA1)
+	assertOverall(500, 1)
+
+	// a late redelivery of the activation does not count it again
+	send(handler.ActiveStakingHandler, activeA1)
+	assertOverall(500, 1)
+}
+
+// fakeV1Delegations keeps the v1 delegations in memory, mirroring the state
+// filters of the v1 database client transitions
+type fakeV1Delegations map[string]*v1dbmodel.DelegationDocument
+
+func (f fakeV1Delegations) find(_ context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error) {
+	delegation, ok := f[txHashHex]
+	if !ok {
+		return nil, &db.NotFoundError{Key: txHashHex, Message: "delegation not found"}
+	}
+	delegationCopy := *delegation
+	return &delegationCopy, nil
+}
+
+func (f fakeV1Delegations) transition(
+	txHashHex string, to types.DelegationState, eligible []types.DelegationState,
+) (*v1dbmodel.DelegationDocument, error) {
+	delegation, ok := f[txHashHex]
+	if !ok || !slices.Contains(eligible, delegation.State) {
+		return nil, &db.NotFoundError{Key: txHashHex, Message: "not eligible"}
+	}
+	delegation.State = to
+	return delegation, nil
+}
+
+// snapshot returns a function restoring the delegations as they are now
+func (f fakeV1Delegations) snapshot() (restore func()) {
+	saved := make(map[string]v1dbmodel.DelegationDocument, len(f))
+	for txHashHex, delegation := range f {
+		saved[txHashHex] = *delegation
+	}
+	return func() {
+		clear(f)
+		for txHashHex, delegation := range saved {
+			f[txHashHex] = &delegation
+		}
+	}
+}
+
+func TestWithdrawnStakingLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		stakerPkHex    = "staker"
+		fpPkHex        = "fp"
+		txHashUnbonded = "1f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+		txHashExpired  = "2f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+		withdrawalTx   = "9f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	)
+
+	delegations := fakeV1Delegations{}
+	for _, txHashHex := range []string{txHashUnbonded, txHashExpired} {
+		delegations[txHashHex] = &v1dbmodel.DelegationDocument{
+			StakingTxHashHex:      txHashHex,
+			StakerPkHex:           stakerPkHex,
+			FinalityProviderPkHex: fpPkHex,
+			StakingValue:          1000,
+			State:                 types.Active,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 1000},
+		}
+	}
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationByTxHashHex", mock.Anything, mock.Anything).Return(delegations.find)
+	v1DB.On("TransitionToUnbondingState", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64) error {
+			delegation, err := delegations.transition(txHashHex, types.Unbonding, utils.QualifiedStatesToUnbonding())
+			if err != nil {
+				return err
+			}
+			delegation.UnbondingTx = &v1dbmodel.TimelockTransaction{
+				TxHex: txHex, OutputIndex: outputIndex, StartTimestamp: startTimestamp,
+				StartHeight: startHeight, TimeLock: timelock,
+			}
+			return nil
+		})
+	v1DB.On("TransitionToUnbondedState", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, txHashHex string, eligible []types.DelegationState) error {
+			_, err := delegations.transition(txHashHex, types.Unbonded, eligible)
+			return err
+		})
+	v1DB.On("TransitionToWithdrawnState", mock.Anything, mock.Anything, mock.Anything, mock.Anything, uint64(1000)).
+		Return(func(
+			_ context.Context, txHashHex string, withdrawal *v1dbmodel.WithdrawalTransaction,
+			withdrawalPath v1dbmodel.WithdrawalPath, _ uint64,
+		) error {
+			delegation, err := delegations.transition(txHashHex, types.Withdrawn, utils.QualifiedStatesToWithdraw())
+			if err != nil {
+				return err
+			}
+			delegation.WithdrawalTx = withdrawal
+			delegation.WithdrawalPath = withdrawalPath
+			return nil
+		})
+	// only the delegation withdrawn while unbonding has its stats subtracted
+	// here, along with its transition
+	v1DB.On("WithTransaction", mock.Anything, mock.Anything).Return(runInTransaction).Once()
+	v1DB.On("GetOrCreateStatsLock", mock.Anything, txHashExpired, types.Unbonded.ToString()).
+		Return(v1dbmodel.NewStatsLockDocument(txHashExpired, false, false, false), nil).Once()
+	v1DB.On("SubtractFinalityProviderStats", mock.Anything, txHashExpired, fpPkHex, uint64(1000)).Return(nil).Once()
+	v1DB.On("SubtractStakerStats", mock.Anything, txHashExpired, stakerPkHex, uint64(1000)).Return(nil).Once()
+	v1DB.On("SubtractOverallStats", mock.Anything, txHashExpired, stakerPkHex, uint64(1000)).Return(nil).Once()
+
+	v2DB := mocks.NewV2DBClient(t)
+	v2DB.On("WithTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	v2DB.On("GetOrCreateStatsLock", mock.Anything, mock.Anything, types.Withdrawn.ToString()).
+		Return(v2dbmodel.NewV2StatsLockDocument("", false, false, false), nil)
+	v2DB.On("HandleWithdrawnStakerStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	indexerDB := mocks.NewIndexerDBClient(t)
+	indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(0), nil)
+	indexerDB.On("GetFinalityProviders", ctx).Return([]*indexerdbmodel.IndexerFinalityProviderDetails{}, nil)
+
+	dbClients := &dbclients.DbClients{
+		V1DBClient:      v1DB,
+		V2DBClient:      v2DB,
+		IndexerDBClient: indexerDB,
+	}
+	cfg := &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}}
+	v1Service := &v1service.V1Service{Service: &service.Service{Cfg: cfg, DbClients: dbClients}}
+	v2Service, err := v2service.New(ctx, cfg, nil, dbClients)
+	require.NoError(t, err)
+	handler := NewV2QueueHandler(&services.Services{V1Service: v1Service, V2Service: v2Service})
+
+	withdraw := func(stakingTxHashHex string, height uint64) *types.Error {
+		body, err := json.Marshal(withdrawnStakingEvent{
+			StakingEvent: queueClient.NewWithdrawnStakingEvent(
+				stakingTxHashHex, stakerPkHex, []string{fpPkHex}, 1000, nil,
+			),
+			WithdrawalTxHashHex: withdrawalTx,
+			WithdrawalTxHeight:  height,
+			WithdrawalAddress:   "tb1qwithdrawal",
+		})
+		require.NoError(t, err)
+		return handler.WithdrawnStakingHandler(ctx, string(body))
+	}
+	assertState := func(stakingTxHashHex string, state types.DelegationState) *v1service.DelegationPublic {
+		delegation, err := v1Service.GetDelegation(ctx, stakingTxHashHex)
+		require.Nil(t, err)
+		assert.Equal(t, state.ToString(), delegation.State)
+		return delegation
+	}
+
+	// an active delegation can not be withdrawn
+	rpcErr := withdraw(txHashUnbonded, 2000)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, http.StatusForbidden, rpcErr.StatusCode)
+	assertState(txHashUnbonded, types.Active)
+
+	// active -> unbonding -> unbonded -> withdrawn
+	require.Nil(t, v1Service.TransitionToUnbondingState(ctx, txHashUnbonded, 500, 10, 0, "unbonding-tx", 1700000000))
+	assertState(txHashUnbonded, types.Unbonding)
+	require.Nil(t, v1Service.TransitionToUnbondedState(ctx, types.UnbondingTxType, txHashUnbonded))
+	assertState(txHashUnbonded, types.Unbonded)
+	require.Nil(t, withdraw(txHashUnbonded, 600))
+	delegation := assertState(txHashUnbonded, types.Withdrawn)
+	require.NotNil(t, delegation.WithdrawalTx)
+	assert.Equal(t, withdrawalTx, delegation.WithdrawalTx.TxHashHex)
+	assert.Equal(t, uint64(600), delegation.WithdrawalTx.Height)
+	assert.Equal(t, "tb1qwithdrawal", delegation.WithdrawalTx.Address)
+	assert.Equal(t, "early_unbonding", delegation.WithdrawalPath)
+	// redelivered messages are acknowledged without any change
+	require.Nil(t, withdraw(txHashUnbonded, 600))
+	assertState(txHashUnbonded, types.Withdrawn)
+
+	// an unbonding delegation is withdrawn only once its timelock expired
+	require.Nil(t, v1Service.TransitionToUnbondingState(ctx, txHashExpired, 500, 10, 0, "unbonding-tx", 1700000000))
+	rpcErr = withdraw(txHashExpired, 509)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, http.StatusForbidden, rpcErr.StatusCode)
+	assertState(txHashExpired, types.Unbonding)
+	require.Nil(t, withdraw(txHashExpired, 510))
+	assertState(txHashExpired, types.Withdrawn)
+	require.Nil(t, withdraw(txHashExpired, 510))
+
+	// events of delegations unknown to phase-1 are ignored
+	require.Nil(t, withdraw("unknown", 2000))
+}
+
+func TestUnbondingStakingFromUnbondingPipeline(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		txHashRequested = "1f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+		txHashActive    = "2f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	)
+
+	delegations := fakeV1Delegations{
+		txHashRequested: {
+			StakingTxHashHex: txHashRequested,
+			State:            types.UnbondingRequested,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 1000},
+		},
+		txHashActive: {
+			StakingTxHashHex: txHashActive,
+			State:            types.Active,
+			StakingTx:        &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 1000},
+		},
+	}
+
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationByTxHashHex", mock.Anything, mock.Anything).Return(delegations.find)
+	v1DB.On("TransitionToUnbondingState", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64) error {
+			delegation, err := delegations.transition(txHashHex, types.Unbonding, utils.QualifiedStatesToUnbonding())
+			if err != nil {
+				return err
+			}
+			delegation.UnbondingTx = &v1dbmodel.TimelockTransaction{
+				TxHex: txHex, OutputIndex: outputIndex, StartTimestamp: startTimestamp,
+				StartHeight: startHeight, TimeLock: timelock,
+			}
+			return nil
+		})
+	indexerDB := mocks.NewIndexerDBClient(t)
+	indexerDB.On("GetLastProcessedBbnHeight", ctx).Return(uint64(0), nil)
+	indexerDB.On("GetFinalityProviders", ctx).Return([]*indexerdbmodel.IndexerFinalityProviderDetails{}, nil)
+
+	// the phase-1 delegations are not part of the phase-2 stats, the
+	// transition only runs in a transaction of the staking database
+	v2DB := mocks.NewV2DBClient(t)
+	v2DB.On("WithTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	dbClients := &dbclients.DbClients{
+		V1DBClient:      v1DB,
+		V2DBClient:      v2DB,
+		IndexerDBClient: indexerDB,
+	}
+	cfg := &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}}
+	v1Service := &v1service.V1Service{Service: &service.Service{Cfg: cfg, DbClients: dbClients}}
+	v2Service, err := v2service.New(ctx, cfg, nil, dbClients)
+	require.NoError(t, err)
+	handler := NewV2QueueHandler(&services.Services{V1Service: v1Service, V2Service: v2Service})
+
+	unbond := func(stakingTxHashHex string) *types.Error {
+		body, err := json.Marshal(unbondingStakingEvent{
+			StakingEvent: queueClient.NewUnbondingStakingEvent(
+				stakingTxHashHex, "staker", []string{"fp"}, 1000, nil,
+			),
+			UnbondingTxHashHex:      "unbonding-tx-hash",
+			UnbondingTxHex:          "unbonding-tx",
+			UnbondingStartHeight:    500,
+			UnbondingStartTimestamp: 1700000000,
+			UnbondingTimelock:       10,
+			UnbondingOutputIndex:    1,
+		})
+		require.NoError(t, err)
+		return handler.UnbondingStakingHandler(ctx, string(body))
+	}
+
+	// unbonding requested and expiry-driven unbonding both move to unbonding,
+	// redelivered messages are acknowledged without any change
+	for _, txHashHex := range []string{txHashRequested, txHashActive, txHashRequested} {
+		require.Nil(t, unbond(txHashHex))
+		delegation, rpcErr := v1Service.GetDelegation(ctx, txHashHex)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, types.Unbonding.ToString(), delegation.State)
+		require.NotNil(t, delegation.UnbondingTx)
+		assert.Equal(t, "unbonding-tx", delegation.UnbondingTx.TxHex)
+		assert.Equal(t, uint64(500), delegation.UnbondingTx.StartHeight)
+		assert.Equal(t, uint64(10), delegation.UnbondingTx.TimeLock)
+		assert.Equal(t, uint64(1), delegation.UnbondingTx.OutputIndex)
+	}
+
+	// unknown delegations are not acknowledged so that they end up in the
+	// unprocessable messages
+	rpcErr := unbond("unknown")
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, http.StatusNotFound, rpcErr.StatusCode)
+}
+
+func TestUnbondingStakingRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		stakerPkHex = "staker"
+		fpPkHex     = "fp"
+		txHash      = "1f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	)
+
+	fake := newFakeV2Stats()
+	fake.stats[fpPkHex] = &v2dbmodel.V2FinalityProviderStatsDocument{
+		FinalityProviderPkHex: fpPkHex, ActiveTvl: 1000, ActiveDelegations: 1, TotalTvl: 1000, TotalDelegations: 1,
+	}
+	fake.overall = v2dbmodel.V2OverallStatsDocument{ActiveTvl: 1000, ActiveDelegations: 1}
+
+	v2DB := mocks.NewV2DBClient(t)
+	v2DB.On("WithTransaction", mock.Anything, mock.Anything).Return(rollBackOnFailure(func() func() {
+		saved := fake.clone()
+		return func() { *fake = *saved }
+	}))
+	v2DB.On("GetOrCreateStatsLock", mock.Anything, txHash, types.Unbonding.ToString()).Return(fake.getOrCreateStatsLock)
+	v2DB.On("SubtractFinalityProviderStats", mock.Anything, txHash, []string{fpPkHex}, uint64(1000)).
+		Return(fake.subtract)
+	v2DB.On("HandleUnbondingStakerStats", mock.Anything, txHash, stakerPkHex, uint64(1000), mock.Anything).
+		Return(nil)
+	// the overall stats, written last, fail once
+	v2DB.On("SubtractOverallStats", mock.Anything, txHash, uint64(1000)).
+		Return(errors.New("write conflict")).Once()
+	v2DB.On("SubtractOverallStats", mock.Anything, txHash, uint64(1000)).Return(fake.subtractOverall)
+
+	dbClients := &dbclients.DbClients{V2DBClient: v2DB}
+	cfg := &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}}
+	v2Service, err := v2service.New(ctx, cfg, nil, dbClients)
+	require.NoError(t, err)
+	handler := NewV2QueueHandler(&services.Services{
+		V1Service: &v1service.V1Service{Service: &service.Service{Cfg: cfg, DbClients: dbClients}},
+		V2Service: v2Service,
+	})
+	body, err := json.Marshal(queueClient.NewUnbondingStakingEvent(txHash, stakerPkHex, []string{fpPkHex}, 1000, nil))
+	require.NoError(t, err)
+
+	// the finality provider stats are rolled back with the overall stats
+	rpcErr := handler.UnbondingStakingHandler(ctx, string(body))
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, http.StatusInternalServerError, rpcErr.StatusCode)
+	assert.Equal(t, int64(1000), fake.stats[fpPkHex].ActiveTvl)
+	assert.Equal(t, int64(1000), fake.overall.ActiveTvl)
+	assert.NotContains(t, fake.locks, txHash+":"+types.Unbonding.ToString())
+
+	// the retried event is counted once
+	require.Nil(t, handler.UnbondingStakingHandler(ctx, string(body)))
+	require.Nil(t, handler.UnbondingStakingHandler(ctx, string(body)))
+	assert.Equal(t, int64(0), fake.stats[fpPkHex].ActiveTvl)
+	assert.Equal(t, int64(0), fake.stats[fpPkHex].ActiveDelegations)
+	assert.Equal(t, int64(0), fake.overall.ActiveTvl)
+	assert.Equal(t, int64(0), fake.overall.ActiveDelegations)
+}
+
+func TestWithdrawnStakingRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		stakerPkHex = "staker"
+		fpPkHex     = "fp"
+		txHash      = "1f2a9b2e1b4c8c8e5d0a3f1d6c4b7e9a0f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	)
+
+	delegations := fakeV1Delegations{
+		txHash: {
+			StakingTxHashHex:      txHash,
+			StakerPkHex:           stakerPkHex,
+			FinalityProviderPkHex: fpPkHex,
+			StakingValue:          1000,
+			State:                 types.Unbonded,
+			StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 1000},
+		},
+	}
+	v1DB := mocks.NewV1DBClient(t)
+	v1DB.On("FindDelegationByTxHashHex", mock.Anything, txHash).Return(delegations.find)
+	// the transition, written after the stats, fails once
+	v1DB.On("TransitionToWithdrawnState", mock.Anything, txHash, mock.Anything, mock.Anything, uint64(1000)).
+		Return(errors.New("write conflict")).Once()
+	v1DB.On("TransitionToWithdrawnState", mock.Anything, txHash, mock.Anything, mock.Anything, uint64(1000)).
+		Return(func(context.Context, string, *v1dbmodel.WithdrawalTransaction, v1dbmodel.WithdrawalPath, uint64) error {
+			_, err := delegations.transition(txHash, types.Withdrawn, utils.QualifiedStatesToWithdraw())
+			return err
+		})
+
+	fake := newFakeV2Stats()
+	withdrawnTvl := map[string]uint64{}
+	v2DB := mocks.NewV2DBClient(t)
+	v2DB.On("WithTransaction", mock.Anything, mock.Anything).Return(rollBackOnFailure(func() func() {
+		savedStats, savedTvl := fake.clone(), withdrawnTvl[stakerPkHex]
+		restoreDelegations := delegations.snapshot()
+		return func() {
+			*fake = *savedStats
+			withdrawnTvl[stakerPkHex] = savedTvl
+			restoreDelegations()
+		}
+	}))
+	v2DB.On("GetOrCreateStatsLock", mock.Anything, txHash, types.Withdrawn.ToString()).Return(fake.getOrCreateStatsLock)
+	v2DB.On("HandleWithdrawnStakerStats", mock.Anything, txHash, stakerPkHex, uint64(1000), mock.Anything).
+		Return(func(_ context.Context, stakingTxHashHex, stakerPkHex string, amount uint64, _ []string) error {
+			lock := fake.locks[stakingTxHashHex+":"+types.Withdrawn.ToString()]
+			if lock == nil || lock.StakerStats {
+				return &db.NotFoundError{Key: stakingTxHashHex, Message: "document already processed or does not exist"}
+			}
+			lock.StakerStats = true
+			withdrawnTvl[stakerPkHex] += amount
+			return nil
+		})
+
+	dbClients := &dbclients.DbClients{V1DBClient: v1DB, V2DBClient: v2DB}
+	cfg := &config.Config{Server: &config.ServerConfig{BTCNetParam: &chaincfg.SigNetParams}}
+	v2Service, err := v2service.New(ctx, cfg, nil, dbClients)
+	require.NoError(t, err)
+	handler := NewV2QueueHandler(&services.Services{
+		V1Service: &v1service.V1Service{Service: &service.Service{Cfg: cfg, DbClients: dbClients}},
+		V2Service: v2Service,
+	})
+	body, err := json.Marshal(withdrawnStakingEvent{
+		StakingEvent: queueClient.NewWithdrawnStakingEvent(txHash, stakerPkHex, []string{fpPkHex}, 1000, nil),
+	})
+	require.NoError(t, err)
+
+	// the staker stats are rolled back with the transition
+	rpcErr := handler.WithdrawnStakingHandler(ctx, string(body))
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, http.StatusInternalServerError, rpcErr.StatusCode)
+	assert.Zero(t, withdrawnTvl[stakerPkHex])
+	assert.NotContains(t, fake.locks, txHash+":"+types.Withdrawn.ToString())
+	assert.Equal(t, types.Unbonded, delegations[txHash].State)
+
+	// the retried event is counted once
+	require.Nil(t, handler.WithdrawnStakingHandler(ctx, string(body)))
+	require.Nil(t, handler.WithdrawnStakingHandler(ctx, string(body)))
+	assert.Equal(t, uint64(1000), withdrawnTvl[stakerPkHex])
+	assert.Equal(t, types.Withdrawn, delegations[txHash].State)
+}
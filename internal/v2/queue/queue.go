@@ -2,11 +2,15 @@ package queue
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/tracing"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
@@ -17,17 +21,53 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+const (
+	liveMode   = "live"
+	dryRunMode = "dry-run"
+)
+
 type Queues struct {
-	Handlers                       *v2queuehandler.V2QueueHandler
+	Handlers *v2queuehandler.V2QueueHandler
+	// DryRunHandlers run on the dry-run services, their writes are recorded
+	// into the shadow collection instead of being applied
+	DryRunHandlers                 *v2queuehandler.V2QueueHandler
 	processingTimeout              time.Duration
 	maxRetryAttempts               int32
 	ActiveStakingQueueClient       client.QueueClient
 	UnbondingStakingQueueClient    client.QueueClient
 	WithdrawableStakingQueueClient client.QueueClient
 	WithdrawnStakingQueueClient    client.QueueClient
+	// dryRunQueues holds the names of the queues whose messages are
+	// processed by the dry-run handlers
+	dryRunQueues map[string]bool
+	dryRunMutex  sync.RWMutex
+	// gapDetector inspects the received events for skipped ones, nil if
+	// the event gap detection is not configured
+	gapDetector *v2queuehandler.EventGapDetector
+	// dryRunGapDetector does the same for the dry-run handlers, which see
+	// the same events as the live ones
+	dryRunGapDetector *v2queuehandler.EventGapDetector
+	// retryBackoff delays the requeue of the failed messages, nil if they are
+	// requeued right away
+	retryBackoff *config.QueueRetryBackoffConfig
+	// throttle slows down the consumers while the database is degraded
+	throttle *IngestionThrottle
+	// consumersCfg sets the number of workers of each queue, nil if every
+	// queue has a single one
+	consumersCfg *config.QueueConsumersConfig
+	// stopCh stops the consumers from pulling new messages, consumers tracks
+	// them until the message they are processing is done
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+	consumers sync.WaitGroup
 }
 
-func New(cfg *queueConfig.QueueConfig, service *services.Services) (*Queues, error) {
+func New(
+	cfg *queueConfig.QueueConfig, dryRunCfg *config.DryRunConfig,
+	gapDetectionCfg *config.EventGapDetectionConfig, retryBackoffCfg *config.QueueRetryBackoffConfig,
+	throttleCfg *config.QueueThrottleConfig, consumersCfg *config.QueueConsumersConfig,
+	service *services.Services, dryRunService *services.Services,
+) (*Queues, error) {
 	activeStakingQueueClient, err := client.NewQueueClient(
 		cfg, client.ActiveStakingQueueName,
 	)
@@ -56,52 +96,158 @@ func New(cfg *queueConfig.QueueConfig, service *services.Services) (*Queues, err
 		return nil, fmt.Errorf("error while creating WithdrawnStakingQueueClient: %w", err)
 	}
 
+	dryRunQueues := make(map[string]bool)
+	if dryRunCfg != nil {
+		for _, queueName := range dryRunCfg.Queues {
+			dryRunQueues[queueName] = true
+		}
+	}
+
+	var gapDetector, dryRunGapDetector *v2queuehandler.EventGapDetector
+	if gapDetectionCfg != nil {
+		gapDetector = v2queuehandler.NewEventGapDetector(gapDetectionCfg.MaxHeightGap)
+		dryRunGapDetector = v2queuehandler.NewEventGapDetector(gapDetectionCfg.MaxHeightGap)
+	}
+
 	handlers := v2queuehandler.NewV2QueueHandler(service)
 	return &Queues{
 		Handlers:                       handlers,
+		DryRunHandlers:                 v2queuehandler.NewV2QueueHandler(dryRunService),
 		processingTimeout:              cfg.QueueProcessingTimeout,
 		maxRetryAttempts:               cfg.MsgMaxRetryAttempts,
 		ActiveStakingQueueClient:       activeStakingQueueClient,
 		UnbondingStakingQueueClient:    unbondingStakingQueueClient,
 		WithdrawableStakingQueueClient: withdrawableStakingQueueClient,
 		WithdrawnStakingQueueClient:    withdrawnStakingQueueClient,
+		dryRunQueues:                   dryRunQueues,
+		gapDetector:                    gapDetector,
+		dryRunGapDetector:              dryRunGapDetector,
+		retryBackoff:                   retryBackoffCfg,
+		throttle:                       NewIngestionThrottle(throttleCfg),
+		consumersCfg:                   consumersCfg,
+		stopCh:                         make(chan struct{}),
 	}, nil
 }
 
+// SetDryRun switches the given queue in or out of the dry-run mode, in which
+// every message is run through the dry-run handler before the live one. The
+// change applies from the next received message onwards.
+func (q *Queues) SetDryRun(queueName string, enabled bool) error {
+	switch queueName {
+	case q.ActiveStakingQueueClient.GetQueueName(),
+		q.UnbondingStakingQueueClient.GetQueueName(),
+		q.WithdrawableStakingQueueClient.GetQueueName(),
+		q.WithdrawnStakingQueueClient.GetQueueName():
+	default:
+		return fmt.Errorf("unknown queue: %s", queueName)
+	}
+
+	q.dryRunMutex.Lock()
+	defer q.dryRunMutex.Unlock()
+	q.dryRunQueues[queueName] = enabled
+	log.Info().Str("queueName", queueName).Bool("dryRun", enabled).Msg("queue handler mode changed")
+	return nil
+}
+
+// IsDryRun returns true if the messages of the given queue are processed in dry-run mode
+func (q *Queues) IsDryRun(queueName string) bool {
+	q.dryRunMutex.RLock()
+	defer q.dryRunMutex.RUnlock()
+	return q.dryRunQueues[queueName]
+}
+
+// DryRunQueues returns whether the messages of each queue are processed in
+// dry-run mode, keyed by queue name
+func (q *Queues) DryRunQueues() map[string]bool {
+	queueNames := []string{
+		q.ActiveStakingQueueClient.GetQueueName(),
+		q.UnbondingStakingQueueClient.GetQueueName(),
+		q.WithdrawableStakingQueueClient.GetQueueName(),
+		q.WithdrawnStakingQueueClient.GetQueueName(),
+	}
+
+	q.dryRunMutex.RLock()
+	defer q.dryRunMutex.RUnlock()
+	modes := make(map[string]bool, len(queueNames))
+	for _, queueName := range queueNames {
+		modes[queueName] = q.dryRunQueues[queueName]
+	}
+	return modes
+}
+
+// ThrottleFactor returns the factor the consumers are throttled with, and
+// whether it is manually overridden
+func (q *Queues) ThrottleFactor() (float64, bool) {
+	return q.throttle.Factor()
+}
+
+// OverrideThrottleFactor throttles the consumers with the given factor until
+// the override is cleared with a nil factor
+func (q *Queues) OverrideThrottleFactor(factor *float64) error {
+	return q.throttle.SetOverride(factor)
+}
+
 // Start all message processing
 func (q *Queues) StartReceivingMessages() error {
 	// start processing messages from the active staking queue
 	queues := []struct {
 		client               client.QueueClient
 		handler              v2queuehandler.MessageHandler
+		dryRunHandler        v2queuehandler.MessageHandler
 		unprocessableHandler v2queuehandler.UnprocessableMessageHandler
 	}{
 		{
 			q.ActiveStakingQueueClient,
-			q.Handlers.ActiveStakingHandler, q.Handlers.HandleUnprocessedMessage,
+			q.Handlers.ActiveStakingHandler, q.DryRunHandlers.ActiveStakingHandler,
+			q.Handlers.HandleUnprocessedMessage,
 		},
 		{
 			q.UnbondingStakingQueueClient,
-			q.Handlers.UnbondingStakingHandler, q.Handlers.HandleUnprocessedMessage,
+			q.Handlers.UnbondingStakingHandler, q.DryRunHandlers.UnbondingStakingHandler,
+			q.Handlers.HandleUnprocessedMessage,
 		},
 		{
 			q.WithdrawableStakingQueueClient,
-			q.Handlers.WithdrawableStakingHandler, q.Handlers.HandleUnprocessedMessage,
+			q.Handlers.WithdrawableStakingHandler, q.DryRunHandlers.WithdrawableStakingHandler,
+			q.Handlers.HandleUnprocessedMessage,
 		},
 		{
 			q.WithdrawnStakingQueueClient,
-			q.Handlers.WithdrawnStakingHandler, q.Handlers.HandleUnprocessedMessage,
+			q.Handlers.WithdrawnStakingHandler, q.DryRunHandlers.WithdrawnStakingHandler,
+			q.Handlers.HandleUnprocessedMessage,
 		},
 		// ...add more queues here
 	}
 
 	for _, queue := range queues {
+		queueName := queue.client.GetQueueName()
+		handler := queue.handler
+		dryRunHandler := queue.dryRunHandler
+		if q.gapDetector != nil {
+			handler = q.Handlers.WithEventGapDetection(queueName, q.gapDetector, handler)
+			dryRunHandler = q.DryRunHandlers.WithEventGapDetection(queueName, q.dryRunGapDetector, dryRunHandler)
+		}
+		// reference messages are resolved first so that the gap detection
+		// inspects the actual event
+		handler = v2queuehandler.WithPayloadReferences(q.Handlers.DbPayloadStore(), handler)
+		dryRunHandler = v2queuehandler.WithPayloadReferences(q.DryRunHandlers.DbPayloadStore(), dryRunHandler)
+		// the shadow record collects the writes of the whole dry-run chain
+		dryRunHandler = q.Handlers.WithDryRun(queueName, dryRunHandler)
+		handler = v2queuehandler.WithMetrics(queueName, liveMode, handler)
+		dryRunHandler = v2queuehandler.WithMetrics(queueName, dryRunMode, dryRunHandler)
 		if err := startQueueMessageProcessing(
 			queue.client,
-			queue.handler,
+			handler,
+			dryRunHandler,
+			func() bool { return q.IsDryRun(queueName) },
 			queue.unprocessableHandler,
 			q.maxRetryAttempts,
+			q.retryBackoff,
+			q.throttle,
+			q.consumersCfg.GetWorkers(queueName),
 			q.processingTimeout,
+			q.stopCh,
+			&q.consumers,
 		); err != nil {
 			return err
 		}
@@ -110,7 +256,27 @@ func (q *Queues) StartReceivingMessages() error {
 	return nil
 }
 
-func (q *Queues) StopReceivingMessages() {
+// StopReceivingMessages stops pulling new messages from the queues and waits
+// for the messages being processed until the context is done. The messages
+// pulled but not processed are redelivered once the queues are closed.
+func (q *Queues) StopReceivingMessages(ctx context.Context) error {
+	q.stopOnce.Do(func() { close(q.stopCh) })
+
+	done := make(chan struct{})
+	go func() {
+		q.consumers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("queue messages still processing: %w", ctx.Err())
+	}
+}
+
+// Close closes the connections of the queue clients
+func (q *Queues) Close() {
 	activeQueueErr := q.ActiveStakingQueueClient.Stop()
 	if activeQueueErr != nil {
 		log.Error().Err(activeQueueErr).
@@ -141,84 +307,200 @@ func (q *Queues) StopReceivingMessages() {
 func startQueueMessageProcessing(
 	queueClient client.QueueClient,
 	handler v2queuehandler.MessageHandler,
+	dryRunHandler v2queuehandler.MessageHandler,
+	isDryRun func() bool,
 	unprocessableHandler v2queuehandler.UnprocessableMessageHandler,
-	maxRetryAttempts int32, processingTimeout time.Duration,
+	maxRetryAttempts int32, retryBackoff *config.QueueRetryBackoffConfig,
+	throttle *IngestionThrottle, workers int, processingTimeout time.Duration,
+	stop <-chan struct{}, consumers *sync.WaitGroup,
 ) error {
 	messagesChan, err := queueClient.ReceiveMessages()
-	log.Info().Str("queueName", queueClient.GetQueueName()).Msg("start receiving messages from queue")
+	log.Info().Str("queueName", queueClient.GetQueueName()).Int("workers", workers).
+		Msg("start receiving messages from queue")
 	if err != nil {
 		return fmt.Errorf("error setting up message channel from queue %q: %w", queueClient.GetQueueName(), err)
 	}
 
-	go func() {
-		for message := range messagesChan {
-			attempts := message.GetRetryAttempts()
-			// For each message, create a new context with a deadline or timeout
-			ctx, cancel := context.WithTimeout(context.Background(), processingTimeout)
-			ctx = attachLoggerContext(ctx, message, queueClient)
+	requeues := newDelayedRequeues()
+	processMessage := func(message client.QueueMessage) {
+		attempts := message.GetRetryAttempts()
+		// For each message, create a new context with a deadline or timeout
+		ctx, cancel := context.WithTimeout(context.Background(), processingTimeout)
+		defer cancel()
+		ctx = attachLoggerContext(ctx, message, queueClient)
+		runHandler := func(messageHandler v2queuehandler.MessageHandler, mode string) *types.Error {
 			// Attach the tracingInfo for the message processing
 			_, err := tracing.WrapWithSpan[any](ctx, "message_processing", func() (any, *types.Error) {
-				timer := metrics.StartEventProcessingDurationTimer(queueClient.GetQueueName(), attempts)
+				timer := metrics.StartEventProcessingDurationTimer(queueClient.GetQueueName(), attempts, mode)
+				startTime := time.Now()
 				// Process the message
-				err := handler(ctx, message.Body)
+				err := messageHandler(ctx, message.Body)
+				statusCode := http.StatusOK
 				if err != nil {
-					timer(err.StatusCode)
-				} else {
-					timer(http.StatusOK)
+					statusCode = err.StatusCode
+				}
+				timer(statusCode)
+				// The dry-run writes are skipped, their outcome says nothing
+				// about the database health
+				if mode == liveMode {
+					throttle.Observe(time.Since(startTime), statusCode)
 				}
 				return nil, err
 			})
-			if err != nil {
-				recordErrorLog(err)
-				// We will retry the message if it has not exceeded the max retry attempts
-				// otherwise, we will dump the message into db for manual inspection and remove from the queue
-				if attempts > maxRetryAttempts {
-					log.Ctx(ctx).Error().Err(err).
-						Msg("exceeded retry attempts, message will be dumped into db for manual inspection")
-					metrics.RecordUnprocessableEntity(queueClient.GetQueueName())
-					saveUnprocessableMsgErr := unprocessableHandler(ctx, message.Body, message.Receipt)
-					if saveUnprocessableMsgErr != nil {
-						log.Ctx(ctx).Error().Err(saveUnprocessableMsgErr).
-							Msg("error while saving unprocessable message")
-						metrics.RecordQueueOperationFailure("unprocessableHandler", queueClient.GetQueueName())
-						cancel()
-						continue
-					}
+			return err
+		}
+		// In dry-run mode the dry-run handler takes the place of the live one,
+		// the message is acknowledged once its shadow record is stored without
+		// being applied. The shadow record keeps the message body to replay it
+		// once the queue is back live.
+		messageHandler, mode := handler, liveMode
+		if isDryRun() {
+			messageHandler, mode = dryRunHandler, dryRunMode
+		}
+		err := runHandler(messageHandler, mode)
+		if err != nil {
+			recordErrorLog(err)
+			// We will retry the message if it has not exceeded the max retry attempts
+			// otherwise, we will dump the message into db for manual inspection and remove from the queue
+			if attempts > maxRetryAttempts {
+				log.Ctx(ctx).Error().Err(err).
+					Msg("exceeded retry attempts, message will be dumped into db for manual inspection")
+				metrics.RecordUnprocessableEntity(queueClient.GetQueueName())
+				saveUnprocessableMsgErr := unprocessableHandler(
+					ctx, queueClient.GetQueueName(), message.Body, message.Receipt, err.Error(), attempts,
+				)
+				if saveUnprocessableMsgErr != nil {
+					log.Ctx(ctx).Error().Err(saveUnprocessableMsgErr).
+						Msg("error while saving unprocessable message")
+					metrics.RecordQueueOperationFailure("unprocessableHandler", queueClient.GetQueueName())
+					return
+				}
+			} else {
+				log.Ctx(ctx).Error().Err(err).
+					Msg("error while processing message from queue, will be requeued")
+				if retryBackoff != nil {
+					// The message stays unacknowledged until the backoff
+					// elapses, the consumer moves on to the next messages
+					delay := retryBackoff.Delay(attempts)
+					reQueueCtx := context.WithoutCancel(ctx)
+					requeues.schedule(delay, func() {
+						reQueueMessage(reQueueCtx, queueClient, message, processingTimeout)
+					})
 				} else {
-					log.Ctx(ctx).Error().Err(err).
-						Msg("error while processing message from queue, will be requeued")
-					reQueueErr := queueClient.ReQueueMessage(ctx, message)
-					if reQueueErr != nil {
-						log.Ctx(ctx).Error().Err(reQueueErr).
-							Msg("error while requeuing message")
-						metrics.RecordQueueOperationFailure("reQueueMessage", queueClient.GetQueueName())
-					}
-					cancel()
-					continue
+					reQueueMessage(ctx, queueClient, message, processingTimeout)
+				}
+				return
+			}
+		}
+
+		delErr := queueClient.DeleteMessage(message.Receipt)
+		if delErr != nil {
+			log.Ctx(ctx).Error().Err(delErr).
+				Msg("error while deleting message from queue")
+			metrics.RecordQueueOperationFailure("deleteMessage", queueClient.GetQueueName())
+		}
+
+		tracingInfo := ctx.Value(tracing.TracingInfoKey)
+		logEvent := log.Ctx(ctx).Debug()
+		if tracingInfo != nil {
+			logEvent = logEvent.Interface("tracingInfo", tracingInfo)
+		}
+		logEvent.Msg("message processed successfully")
+	}
+
+	// Each worker processes its messages one at a time, the messages of a
+	// delegation are always dispatched to the same worker so that its state
+	// transitions are applied in order
+	workerChans := make([]chan client.QueueMessage, workers)
+	var pool sync.WaitGroup
+	for i := range workerChans {
+		workerChans[i] = make(chan client.QueueMessage)
+		pool.Add(1)
+		go func(messages <-chan client.QueueMessage) {
+			defer pool.Done()
+			for message := range messages {
+				processMessage(message)
+			}
+		}(workerChans[i])
+	}
+
+	consumers.Add(1)
+	go func() {
+		defer func() {
+			// the messages already dispatched are processed, and the ones
+			// waiting for their retry backoff requeued, before the consumer
+			// is reported as stopped
+			for _, workerChan := range workerChans {
+				close(workerChan)
+			}
+			pool.Wait()
+			requeues.flush()
+			log.Info().Str("queueName", queueClient.GetQueueName()).Msg("stopped receiving messages from queue")
+			consumers.Done()
+		}()
+		for {
+			if delay := throttle.Delay(); delay > 0 {
+				select {
+				case <-stop:
+					return
+				case <-time.After(delay):
 				}
 			}
 
-			delErr := queueClient.DeleteMessage(message.Receipt)
-			if delErr != nil {
-				log.Ctx(ctx).Error().Err(delErr).
-					Msg("error while deleting message from queue")
-				metrics.RecordQueueOperationFailure("deleteMessage", queueClient.GetQueueName())
+			var message client.QueueMessage
+			select {
+			case <-stop:
+				return
+			case received, ok := <-messagesChan:
+				if !ok {
+					return
+				}
+				message = received
 			}
 
-			tracingInfo := ctx.Value(tracing.TracingInfoKey)
-			logEvent := log.Ctx(ctx).Debug()
-			if tracingInfo != nil {
-				logEvent = logEvent.Interface("tracingInfo", tracingInfo)
+			// A message not dispatched before the stop is left unacknowledged
+			// and redelivered
+			select {
+			case <-stop:
+				return
+			case workerChans[workerIndex(message.Body, workers)] <- message:
 			}
-			logEvent.Msg("message processed successfully")
-			cancel()
 		}
-		log.Info().Str("queueName", queueClient.GetQueueName()).Msg("stopped receiving messages from queue")
 	}()
 
 	return nil
 }
 
+// workerIndex returns the worker processing the message, picked by the
+// staking tx hash of the event. Messages without one, such as the reference
+// messages, all go to the same worker.
+func workerIndex(messageBody string, workers int) int {
+	var event struct {
+		StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	}
+	// an undecodable message has no key, its handler reports the error
+	_ = json.Unmarshal([]byte(messageBody), &event)
+
+	hash := fnv.New32a()
+	hash.Write([]byte(event.StakingTxHashHex))
+	return int(hash.Sum32() % uint32(workers))
+}
+
+// reQueueMessage sends the message back to the queue with an incremented
+// retry attempts counter
+func reQueueMessage(
+	ctx context.Context, queueClient client.QueueClient, message client.QueueMessage, timeout time.Duration,
+) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := queueClient.ReQueueMessage(ctx, message); err != nil {
+		log.Ctx(ctx).Error().Err(err).
+			Msg("error while requeuing message")
+		metrics.RecordQueueOperationFailure("reQueueMessage", queueClient.GetQueueName())
+	}
+}
+
 func attachLoggerContext(ctx context.Context, message client.QueueMessage, queueClient client.QueueClient) context.Context {
 	ctx = tracing.AttachTracingIntoContext(ctx)
 
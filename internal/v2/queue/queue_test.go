@@ -0,0 +1,424 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQueueClient delivers the requeued messages right back to the consumer,
+// as the delay queue would once its ttl expired
+type fakeQueueClient struct {
+	messages chan client.QueueMessage
+	mu       sync.Mutex
+	deleted  []string
+	requeued int
+}
+
+func (c *fakeQueueClient) SendMessage(_ context.Context, messageBody string) error {
+	c.messages <- client.QueueMessage{Body: messageBody, Receipt: messageBody}
+	return nil
+}
+
+func (c *fakeQueueClient) ReceiveMessages() (<-chan client.QueueMessage, error) {
+	return c.messages, nil
+}
+
+func (c *fakeQueueClient) DeleteMessage(receipt string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deleted = append(c.deleted, receipt)
+	return nil
+}
+
+func (c *fakeQueueClient) Stop() error {
+	close(c.messages)
+	return nil
+}
+
+func (c *fakeQueueClient) GetQueueName() string {
+	return "test_queue"
+}
+
+func (c *fakeQueueClient) ReQueueMessage(_ context.Context, message client.QueueMessage) error {
+	c.mu.Lock()
+	c.requeued++
+	c.mu.Unlock()
+	message.IncrementRetryAttempts()
+	c.messages <- message
+	return nil
+}
+
+func (c *fakeQueueClient) Ping(context.Context) error {
+	return nil
+}
+
+type unprocessableMessage struct {
+	queueName, messageBody, processingErr string
+	retryAttempts                         int32
+}
+
+func TestFailingMessageIsDumpedAfterMaxRetryAttempts(t *testing.T) {
+	metrics.Init(0)
+
+	const maxRetryAttempts = 3
+	for name, retryBackoff := range map[string]*config.QueueRetryBackoffConfig{
+		"without backoff": nil,
+		"with backoff":    {BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+	} {
+		t.Run(name, func(t *testing.T) {
+			queueClient := &fakeQueueClient{messages: make(chan client.QueueMessage, 10)}
+			processed := make(chan string, 10)
+			unprocessable := make(chan unprocessableMessage, 10)
+
+			handler := func(_ context.Context, messageBody string) *types.Error {
+				if messageBody == "poison" {
+					return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "db unavailable")
+				}
+				processed <- messageBody
+				return nil
+			}
+			unprocessableHandler := func(
+				_ context.Context, queueName, messageBody, _, processingErr string, retryAttempts int32,
+			) *types.Error {
+				unprocessable <- unprocessableMessage{queueName, messageBody, processingErr, retryAttempts}
+				return nil
+			}
+
+			require.NoError(t, startQueueMessageProcessing(
+				queueClient, handler, handler, func() bool { return false },
+				unprocessableHandler, maxRetryAttempts, retryBackoff, nil, 1, time.Second,
+				make(chan struct{}), &sync.WaitGroup{},
+			))
+			defer queueClient.Stop()
+
+			require.NoError(t, queueClient.SendMessage(context.Background(), "poison"))
+			require.NoError(t, queueClient.SendMessage(context.Background(), "valid"))
+
+			// the failing message does not block the ones behind it
+			select {
+			case body := <-processed:
+				assert.Equal(t, "valid", body)
+			case <-time.After(time.Second):
+				t.Fatal("valid message was not processed")
+			}
+
+			select {
+			case msg := <-unprocessable:
+				assert.Equal(t, unprocessableMessage{
+					queueName:     "test_queue",
+					messageBody:   "poison",
+					processingErr: "db unavailable",
+					retryAttempts: maxRetryAttempts + 1,
+				}, msg)
+			case <-time.After(time.Second):
+				t.Fatal("failing message was not dumped")
+			}
+
+			require.Eventually(t, func() bool {
+				queueClient.mu.Lock()
+				defer queueClient.mu.Unlock()
+				return len(queueClient.deleted) == 2
+			}, time.Second, time.Millisecond)
+			queueClient.mu.Lock()
+			defer queueClient.mu.Unlock()
+			assert.Equal(t, maxRetryAttempts+1, queueClient.requeued)
+			assert.ElementsMatch(t, []string{"valid", "poison"}, queueClient.deleted)
+		})
+	}
+}
+
+func TestDryRunMessageIsNotAppliedLive(t *testing.T) {
+	metrics.Init(0)
+
+	queueClient := &fakeQueueClient{messages: make(chan client.QueueMessage, 10)}
+	handled := make(chan string, 10)
+	handler := func(_ context.Context, messageBody string) *types.Error {
+		handled <- "live:" + messageBody
+		return nil
+	}
+	// the dry-run handler fails the first attempt, the message is retried
+	// like a live one
+	var attempts int
+	dryRunHandler := func(_ context.Context, messageBody string) *types.Error {
+		handled <- "dry-run:" + messageBody
+		attempts++
+		if attempts == 1 {
+			return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "db unavailable")
+		}
+		return nil
+	}
+	require.NoError(t, startQueueMessageProcessing(
+		queueClient, handler, dryRunHandler, func() bool { return true }, nil, 3, nil, nil, 1, time.Second,
+		make(chan struct{}), &sync.WaitGroup{},
+	))
+	defer queueClient.Stop()
+
+	require.NoError(t, queueClient.SendMessage(context.Background(), "event"))
+	for _, expected := range []string{"dry-run:event", "dry-run:event"} {
+		select {
+		case got := <-handled:
+			assert.Equal(t, expected, got)
+		case <-time.After(time.Second):
+			t.Fatalf("%s was not handled", expected)
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		queueClient.mu.Lock()
+		defer queueClient.mu.Unlock()
+		return len(queueClient.deleted) == 1
+	}, time.Second, time.Millisecond)
+	// the live handler never sees the message
+	select {
+	case got := <-handled:
+		t.Fatalf("unexpected %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+	queueClient.mu.Lock()
+	defer queueClient.mu.Unlock()
+	assert.Equal(t, 1, queueClient.requeued)
+}
+
+func TestStopReceivingMessagesDrainsInFlightMessage(t *testing.T) {
+	metrics.Init(0)
+
+	queueClient := &fakeQueueClient{messages: make(chan client.QueueMessage, 10)}
+	started := make(chan string, 10)
+	release := make(chan struct{})
+	handler := func(_ context.Context, messageBody string) *types.Error {
+		started <- messageBody
+		<-release
+		return nil
+	}
+	q := &Queues{stopCh: make(chan struct{})}
+	require.NoError(t, startQueueMessageProcessing(
+		queueClient, handler, handler, func() bool { return false }, nil, 3, nil, nil, 1, time.Second,
+		q.stopCh, &q.consumers,
+	))
+
+	require.NoError(t, queueClient.SendMessage(context.Background(), "slow"))
+	select {
+	case body := <-started:
+		assert.Equal(t, "slow", body)
+	case <-time.After(time.Second):
+		t.Fatal("slow message was not picked up")
+	}
+
+	stopped := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stopped <- q.StopReceivingMessages(ctx)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("consumer stopped before the in-flight message was processed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("consumer did not stop after the in-flight message was processed")
+	}
+	queueClient.mu.Lock()
+	assert.Equal(t, []string{"slow"}, queueClient.deleted)
+	queueClient.mu.Unlock()
+
+	// messages sent after the stop are left in the queue for redelivery
+	require.NoError(t, queueClient.SendMessage(context.Background(), "late"))
+	select {
+	case body := <-started:
+		t.Fatalf("message %q was processed after the stop", body)
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(t, queueClient.Stop())
+}
+
+func TestStopReceivingMessagesTimesOut(t *testing.T) {
+	metrics.Init(0)
+
+	queueClient := &fakeQueueClient{messages: make(chan client.QueueMessage, 10)}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := func(_ context.Context, _ string) *types.Error {
+		close(started)
+		<-release
+		return nil
+	}
+	q := &Queues{stopCh: make(chan struct{})}
+	require.NoError(t, startQueueMessageProcessing(
+		queueClient, handler, handler, func() bool { return false }, nil, 3, nil, nil, 1, time.Second,
+		q.stopCh, &q.consumers,
+	))
+	require.NoError(t, queueClient.SendMessage(context.Background(), "stuck"))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := q.StopReceivingMessages(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestStopReceivingMessagesRequeuesBackedOffMessages(t *testing.T) {
+	metrics.Init(0)
+
+	queueClient := &fakeQueueClient{messages: make(chan client.QueueMessage, 10)}
+	failed := make(chan struct{}, 10)
+	handler := func(_ context.Context, _ string) *types.Error {
+		failed <- struct{}{}
+		return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "db unavailable")
+	}
+	q := &Queues{stopCh: make(chan struct{})}
+	// the backoff outlives the test, the message is only requeued by the stop
+	retryBackoff := &config.QueueRetryBackoffConfig{BaseDelay: time.Hour, MaxDelay: time.Hour}
+	require.NoError(t, startQueueMessageProcessing(
+		queueClient, handler, handler, func() bool { return false }, nil, 3, retryBackoff, nil, 1, time.Second,
+		q.stopCh, &q.consumers,
+	))
+
+	require.NoError(t, queueClient.SendMessage(context.Background(), "failing"))
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("failing message was not picked up")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, q.StopReceivingMessages(ctx))
+	queueClient.mu.Lock()
+	assert.Equal(t, 1, queueClient.requeued)
+	queueClient.mu.Unlock()
+	require.NoError(t, queueClient.Stop())
+}
+
+func TestQueueRetryBackoffDelay(t *testing.T) {
+	cfg := &config.QueueRetryBackoffConfig{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	assert.Equal(t, time.Second, cfg.Delay(0))
+	assert.Equal(t, 2*time.Second, cfg.Delay(1))
+	assert.Equal(t, 8*time.Second, cfg.Delay(3))
+	assert.Equal(t, 10*time.Second, cfg.Delay(4))
+	assert.Equal(t, 10*time.Second, cfg.Delay(60))
+}
+
+func TestWorkersProcessDelegationMessagesInOrder(t *testing.T) {
+	metrics.Init(0)
+
+	const (
+		workers     = 8
+		delegations = 50
+		events      = 6
+		retried     = 10
+	)
+	queueClient := &fakeQueueClient{messages: make(chan client.QueueMessage, delegations*events+retried)}
+
+	var (
+		mu            sync.Mutex
+		inFlight      = make(map[string]bool)
+		processed     = make(map[string][]int)
+		failedOnce    = make(map[string]bool)
+		running       int
+		maxRunning    int
+		concurrentKey string
+	)
+	done := make(chan struct{}, delegations*events+retried)
+	handler := func(_ context.Context, messageBody string) *types.Error {
+		var event struct {
+			StakingTxHashHex string `json:"staking_tx_hash_hex"`
+			Seq              int    `json:"seq"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(messageBody), &event))
+		key := event.StakingTxHashHex
+
+		mu.Lock()
+		if inFlight[key] {
+			concurrentKey = key
+		}
+		inFlight[key] = true
+		running++
+		maxRunning = max(maxRunning, running)
+		// the messages of the retried delegations fail their first attempt
+		fail := strings.HasPrefix(key, "retried") && !failedOnce[key]
+		failedOnce[key] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		inFlight[key] = false
+		running--
+		if fail {
+			return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "db unavailable")
+		}
+		processed[key] = append(processed[key], event.Seq)
+		done <- struct{}{}
+		return nil
+	}
+
+	q := &Queues{stopCh: make(chan struct{})}
+	require.NoError(t, startQueueMessageProcessing(
+		queueClient, handler, handler, func() bool { return false }, nil, 3, nil, nil, workers, time.Second,
+		q.stopCh, &q.consumers,
+	))
+
+	var sent []string
+	for seq := 0; seq < events; seq++ {
+		for d := 0; d < delegations; d++ {
+			sent = append(sent, fmt.Sprintf(`{"staking_tx_hash_hex":"tx-%d","seq":%d}`, d, seq))
+		}
+	}
+	for r := 0; r < retried; r++ {
+		sent = append(sent, fmt.Sprintf(`{"staking_tx_hash_hex":"retried-%d","seq":0}`, r))
+	}
+	for _, body := range sent {
+		require.NoError(t, queueClient.SendMessage(context.Background(), body))
+	}
+
+	for range sent {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("messages were not all processed")
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, q.StopReceivingMessages(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Empty(t, concurrentKey, "messages of the same delegation were processed concurrently")
+	assert.Greater(t, maxRunning, 1, "messages were not processed in parallel")
+	for d := 0; d < delegations; d++ {
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, processed[fmt.Sprintf("tx-%d", d)])
+	}
+	for r := 0; r < retried; r++ {
+		assert.Equal(t, []int{0}, processed[fmt.Sprintf("retried-%d", r)])
+	}
+
+	// every message is acknowledged once processed, the failed attempts are
+	// requeued instead
+	queueClient.mu.Lock()
+	defer queueClient.mu.Unlock()
+	assert.ElementsMatch(t, sent, queueClient.deleted)
+	assert.Equal(t, retried, queueClient.requeued)
+}
@@ -0,0 +1,121 @@
+package queue
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+// throttleRecoveryStep is restored to the throttle factor after every healthy
+// window, so that a recovering database is not hit at full speed at once
+const throttleRecoveryStep = 0.1
+
+// IngestionThrottle slows down the consumers of all the queues, which share
+// the database, based on the outcome of the messages they process. A factor
+// of 1 consumes at full speed, consumers wait longer between two messages as
+// the factor decreases. A manual override takes precedence over the factor
+// derived from the processing health until it is cleared.
+type IngestionThrottle struct {
+	// cfg is nil if the throttle only applies manual overrides
+	cfg *config.QueueThrottleConfig
+
+	mutex     sync.Mutex
+	factor    float64
+	override  *float64
+	durations []time.Duration
+	failures  int
+}
+
+func NewIngestionThrottle(cfg *config.QueueThrottleConfig) *IngestionThrottle {
+	return &IngestionThrottle{cfg: cfg, factor: 1}
+}
+
+// Observe records the outcome of a processed message, statusCode is the one of
+// its error or 200 if it succeeded. Messages rejected with a 4xx error tell
+// nothing about the database health and are not counted as failures.
+func (t *IngestionThrottle) Observe(duration time.Duration, statusCode int) {
+	if t == nil || t.cfg == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.durations = append(t.durations, duration)
+	if statusCode >= http.StatusInternalServerError {
+		t.failures++
+	}
+	if len(t.durations) < t.cfg.Window {
+		return
+	}
+
+	errorRate := float64(t.failures) / float64(len(t.durations))
+	sort.Slice(t.durations, func(i, j int) bool { return t.durations[i] < t.durations[j] })
+	// nearest rank, ceil(0.99 * n)
+	p99 := t.durations[(len(t.durations)*99+99)/100-1]
+	t.durations, t.failures = t.durations[:0], 0
+
+	previous := t.factor
+	if errorRate > t.cfg.MaxErrorRate || p99 > t.cfg.MaxP99Latency {
+		t.factor /= 2
+	} else {
+		t.factor = min(1, t.factor+throttleRecoveryStep)
+	}
+	if t.factor != previous {
+		log.Info().Float64("factor", t.factor).Float64("errorRate", errorRate).Dur("p99", p99).
+			Msg("queue throttle factor changed")
+	}
+	if t.override == nil {
+		metrics.SetQueueThrottleFactor(t.factor)
+	}
+}
+
+// Factor returns the factor the consumers are throttled with, and whether it
+// is manually overridden
+func (t *IngestionThrottle) Factor() (float64, bool) {
+	if t == nil {
+		return 1, false
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.override != nil {
+		return *t.override, true
+	}
+	return t.factor, false
+}
+
+// SetOverride throttles the consumers with the given factor, within [0, 1],
+// regardless of the processing health. A nil factor clears the override.
+func (t *IngestionThrottle) SetOverride(factor *float64) error {
+	if factor != nil && (*factor < 0 || *factor > 1) {
+		return errors.New("throttle factor must be within [0, 1]")
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.override = nil
+	effective := t.factor
+	if factor != nil {
+		override := *factor
+		t.override = &override
+		effective = override
+	}
+	log.Info().Float64("factor", effective).Bool("overridden", factor != nil).
+		Msg("queue throttle override changed")
+	metrics.SetQueueThrottleFactor(effective)
+	return nil
+}
+
+// Delay returns how long a consumer waits before its next message
+func (t *IngestionThrottle) Delay() time.Duration {
+	if t == nil {
+		return 0
+	}
+	factor, _ := t.Factor()
+	return time.Duration((1 - factor) * float64(t.cfg.GetMaxDelay()))
+}
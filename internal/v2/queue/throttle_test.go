@@ -0,0 +1,180 @@
+package queue
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestionThrottleFollowsDbHealth(t *testing.T) {
+	metrics.Init(0)
+	throttle := NewIngestionThrottle(&config.QueueThrottleConfig{
+		Window:        10,
+		MaxErrorRate:  0.2,
+		MaxP99Latency: time.Second,
+		MaxDelay:      time.Second,
+	})
+	// window feeds a window of messages, failing ones fail with a 5xx error
+	window := func(failing int, latency time.Duration) float64 {
+		for i := 0; i < 10; i++ {
+			statusCode := http.StatusOK
+			if i < failing {
+				statusCode = http.StatusInternalServerError
+			}
+			throttle.Observe(latency, statusCode)
+		}
+		factor, overridden := throttle.Factor()
+		require.False(t, overridden)
+		return factor
+	}
+
+	// healthy db
+	assert.Equal(t, 1.0, window(0, 10*time.Millisecond))
+	assert.Equal(t, time.Duration(0), throttle.Delay())
+	// errors within the tolerated rate, and bad messages rejected with a 4xx
+	assert.Equal(t, 1.0, window(2, 10*time.Millisecond))
+	for i := 0; i < 10; i++ {
+		throttle.Observe(10*time.Millisecond, http.StatusBadRequest)
+	}
+	factor, _ := throttle.Factor()
+	assert.Equal(t, 1.0, factor)
+
+	// the db degrades: errors, then slow queries
+	assert.Equal(t, 0.5, window(5, 10*time.Millisecond))
+	assert.Equal(t, 0.25, window(10, 10*time.Millisecond))
+	assert.Equal(t, 0.125, window(0, 3*time.Second))
+	assert.Equal(t, 875*time.Millisecond, throttle.Delay())
+
+	// the db recovers, the factor is restored gradually
+	assert.InDelta(t, 0.225, window(0, 10*time.Millisecond), 1e-9)
+	assert.InDelta(t, 0.325, window(0, 10*time.Millisecond), 1e-9)
+	for i := 0; i < 10; i++ {
+		window(0, 10*time.Millisecond)
+	}
+	assert.Equal(t, 1.0, window(0, 10*time.Millisecond))
+	assert.Equal(t, time.Duration(0), throttle.Delay())
+}
+
+func TestIngestionThrottleOverride(t *testing.T) {
+	metrics.Init(0)
+	// without config the throttle only applies the manual overrides
+	throttle := NewIngestionThrottle(nil)
+	for i := 0; i < 100; i++ {
+		throttle.Observe(time.Minute, http.StatusInternalServerError)
+	}
+	factor, overridden := throttle.Factor()
+	assert.Equal(t, 1.0, factor)
+	assert.False(t, overridden)
+
+	override := 0.2
+	require.NoError(t, throttle.SetOverride(&override))
+	factor, overridden = throttle.Factor()
+	assert.Equal(t, 0.2, factor)
+	assert.True(t, overridden)
+	assert.Equal(t, 4*time.Second, throttle.Delay())
+
+	invalid := 1.5
+	assert.Error(t, throttle.SetOverride(&invalid))
+
+	require.NoError(t, throttle.SetOverride(nil))
+	factor, overridden = throttle.Factor()
+	assert.Equal(t, 1.0, factor)
+	assert.False(t, overridden)
+}
+
+func TestConsumerThrottledWhileDbDegraded(t *testing.T) {
+	metrics.Init(0)
+
+	queueClient := &fakeQueueClient{messages: make(chan client.QueueMessage, 100)}
+	var degraded atomic.Bool
+	degraded.Store(true)
+	processed := make(chan string, 100)
+	// the fake db fails the messages while degraded, they are requeued
+	handler := func(_ context.Context, messageBody string) *types.Error {
+		if degraded.Load() {
+			return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "db unavailable")
+		}
+		processed <- messageBody
+		return nil
+	}
+	throttle := NewIngestionThrottle(&config.QueueThrottleConfig{
+		Window:        2,
+		MaxErrorRate:  0.5,
+		MaxP99Latency: time.Second,
+		MaxDelay:      5 * time.Millisecond,
+	})
+	stop := make(chan struct{})
+	require.NoError(t, startQueueMessageProcessing(
+		queueClient, handler, handler, func() bool { return false }, nil, 1000, nil, throttle, 1, time.Second,
+		stop, &sync.WaitGroup{},
+	))
+	defer close(stop)
+
+	require.NoError(t, queueClient.SendMessage(context.Background(), "event"))
+	require.Eventually(t, func() bool {
+		factor, _ := throttle.Factor()
+		return factor < 0.1
+	}, time.Second, time.Millisecond, "consumer was not throttled while the db is degraded")
+
+	degraded.Store(false)
+	select {
+	case body := <-processed:
+		assert.Equal(t, "event", body)
+	case <-time.After(time.Second):
+		t.Fatal("message was not processed once the db recovered")
+	}
+	for i := 0; i < 20; i++ {
+		require.NoError(t, queueClient.SendMessage(context.Background(), "event"))
+	}
+	require.Eventually(t, func() bool {
+		factor, _ := throttle.Factor()
+		return factor == 1
+	}, time.Second, time.Millisecond, "consumer throttle was not restored once the db recovered")
+}
+
+func TestConsumerThrottleIgnoresDryRun(t *testing.T) {
+	metrics.Init(0)
+
+	queueClient := &fakeQueueClient{messages: make(chan client.QueueMessage, 100)}
+	handled := make(chan struct{}, 100)
+	// the dry-run handler is slow and failing, the messages are requeued
+	dryRunHandler := func(_ context.Context, _ string) *types.Error {
+		time.Sleep(2 * time.Millisecond)
+		handled <- struct{}{}
+		return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "db unavailable")
+	}
+	throttle := NewIngestionThrottle(&config.QueueThrottleConfig{
+		Window:        2,
+		MaxErrorRate:  0.5,
+		MaxP99Latency: time.Millisecond,
+		MaxDelay:      5 * time.Millisecond,
+	})
+	stop := make(chan struct{})
+	require.NoError(t, startQueueMessageProcessing(
+		queueClient, dryRunHandler, dryRunHandler, func() bool { return true }, nil, 1000, nil, throttle, 1, time.Second,
+		stop, &sync.WaitGroup{},
+	))
+	defer close(stop)
+
+	require.NoError(t, queueClient.SendMessage(context.Background(), "event"))
+	for i := 0; i < 10; i++ {
+		select {
+		case <-handled:
+		case <-time.After(time.Second):
+			t.Fatal("message was not retried")
+		}
+	}
+	// neither the error rate nor the latency of the dry-run counts
+	factor, _ := throttle.Factor()
+	assert.Equal(t, float64(1), factor)
+}
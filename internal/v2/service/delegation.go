@@ -6,6 +6,7 @@ import (
 
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	v2types "github.com/babylonlabs-io/staking-api-service/internal/v2/types"
@@ -17,6 +18,7 @@ type DelegationStaking struct {
 	StakingTxHex       string          `json:"staking_tx_hex"`
 	StakingTimelock    uint32          `json:"staking_timelock"`
 	StakingAmount      uint64          `json:"staking_amount"`
+	StakingAmountBtc   string          `json:"staking_amount_btc,omitempty"`
 	StartHeight        uint32          `json:"start_height,omitempty"`
 	EndHeight          uint32          `json:"end_height,omitempty"`
 	BbnInceptionHeight int64           `json:"bbn_inception_height"`
@@ -55,6 +57,11 @@ type DelegationPublic struct {
 	State                     v2types.DelegationState `json:"state"`
 }
 
+// AddFormattedFields fills the BTC denominated amounts next to the satoshi ones
+func (d *DelegationPublic) AddFormattedFields() {
+	d.DelegationStaking.StakingAmountBtc = utils.FormatUnsignedSatoshisAsBtc(d.DelegationStaking.StakingAmount)
+}
+
 func FromDelegationDocument(delegation indexerdbmodel.IndexerDelegationDetails) (*DelegationPublic, *types.Error) {
 	state, err := v2types.MapDelegationState(delegation.State, delegation.SubState)
 	if err != nil {
@@ -115,8 +122,18 @@ func (s *V2Service) GetDelegation(ctx context.Context, stakingTxHashHex string)
 	return FromDelegationDocument(*delegation)
 }
 
-func (s *V2Service) GetDelegations(ctx context.Context, stakerPkHex string, paginationKey string) ([]*DelegationPublic, string, *types.Error) {
-	resultMap, err := s.DbClients.IndexerDBClient.GetDelegations(ctx, stakerPkHex, paginationKey)
+// GetDelegations returns the staker delegations, restricted to the ones made
+// under the given params version if it is not nil
+func (s *V2Service) GetDelegations(
+	ctx context.Context, stakerPkHex string, paramsVersion *uint32, paginationKey string,
+) ([]*DelegationPublic, string, *types.Error) {
+	if paramsVersion != nil {
+		if err := s.validateParamsVersion(ctx, *paramsVersion); err != nil {
+			return nil, "", err
+		}
+	}
+
+	resultMap, err := s.DbClients.IndexerDBClient.GetDelegations(ctx, stakerPkHex, paramsVersion, paginationKey)
 	if err != nil {
 		if db.IsNotFoundError(err) {
 			log.Ctx(ctx).Warn().Err(err).Str("stakingTxHashHex", stakerPkHex).Msg("Staking delegations not found")
@@ -148,8 +165,12 @@ func getUnbondingSignatures(covenantSignatures []indexerdbmodel.CovenantSignatur
 	return covenantSignaturesPublic
 }
 
-func (s *V2Service) SaveUnprocessableMessages(ctx context.Context, messageBody, receipt string) *types.Error {
-	err := s.DbClients.V2DBClient.SaveUnprocessableMessage(ctx, messageBody, receipt)
+func (s *V2Service) SaveUnprocessableMessages(
+	ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32,
+) *types.Error {
+	err := s.DbClients.V2DBClient.SaveUnprocessableMessage(
+		ctx, queueName, messageBody, receipt, processingErr, retryAttempts,
+	)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while saving unprocessable message")
 		return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "error while saving unprocessable message")
@@ -157,6 +178,14 @@ func (s *V2Service) SaveUnprocessableMessages(ctx context.Context, messageBody,
 	return nil
 }
 
+// WithTransaction runs fn in a transaction of the staking database, so that
+// either all or none of its writes are applied
+func (s *V2Service) WithTransaction(
+	ctx context.Context, fn func(ctx context.Context) *types.Error,
+) *types.Error {
+	return service.WithTransaction(ctx, s.DbClients.V2DBClient, fn)
+}
+
 // MarkV1DelegationAsTransitioned marks a v1 delegation as transitioned
 func (s *V2Service) MarkV1DelegationAsTransitioned(
 	ctx context.Context, stakingTxHashHex string,
@@ -0,0 +1,104 @@
+package v2service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testStakerPkHex = "staker"
+
+// seededDelegations pages through the delegations of the staker two at a
+// time, applying the params version filter as the indexer db client does
+func seededDelegations(
+	delegations []indexerdbmodel.IndexerDelegationDetails,
+) func(context.Context, string, *uint32, string) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error) {
+	const pageSize = 2
+	return func(
+		_ context.Context, stakerPkHex string, paramsVersion *uint32, paginationToken string,
+	) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error) {
+		var matching []indexerdbmodel.IndexerDelegationDetails
+		for _, d := range delegations {
+			if d.StakerBtcPkHex == stakerPkHex && (paramsVersion == nil || d.ParamsVersion == *paramsVersion) {
+				matching = append(matching, d)
+			}
+		}
+		offset := 0
+		if paginationToken != "" {
+			offset, _ = strconv.Atoi(paginationToken)
+		}
+		end := min(offset+pageSize, len(matching))
+		result := &db.DbResultMap[indexerdbmodel.IndexerDelegationDetails]{Data: matching[offset:end]}
+		if end < len(matching) {
+			result.PaginationToken = strconv.Itoa(end)
+		}
+		return result, nil
+	}
+}
+
+func TestGetDelegationsByParamsVersion(t *testing.T) {
+	ctx := context.Background()
+
+	var delegations []indexerdbmodel.IndexerDelegationDetails
+	for i, version := range []uint32{0, 1, 1, 2, 1, 0} {
+		delegations = append(delegations, indexerdbmodel.IndexerDelegationDetails{
+			StakingTxHashHex: fmt.Sprintf("tx%d", i),
+			StakerBtcPkHex:   testStakerPkHex,
+			ParamsVersion:    version,
+			State:            indexertypes.StateActive,
+		})
+	}
+
+	indexerDB := mocks.NewIndexerDBClient(t)
+	indexerDB.On("GetBbnStakingParams", ctx).Return([]*indexertypes.BbnStakingParams{
+		{Version: 2}, {Version: 0}, {Version: 1},
+	}, nil)
+	indexerDB.On("GetDelegations", ctx, testStakerPkHex, mock.Anything, mock.Anything).
+		Return(seededDelegations(delegations))
+
+	service, err := New(ctx, &config.Config{}, nil, &dbclients.DbClients{IndexerDBClient: indexerDB})
+	require.NoError(t, err)
+
+	fetchAll := func(paramsVersion *uint32) []string {
+		var txHashes []string
+		paginationKey := ""
+		for {
+			page, nextKey, rpcErr := service.GetDelegations(ctx, testStakerPkHex, paramsVersion, paginationKey)
+			require.Nil(t, rpcErr)
+			for _, d := range page {
+				txHashes = append(txHashes, d.DelegationStaking.StakingTxHashHex)
+				if paramsVersion != nil {
+					assert.Equal(t, *paramsVersion, d.ParamsVersion)
+				}
+			}
+			if nextKey == "" {
+				return txHashes
+			}
+			paginationKey = nextKey
+		}
+	}
+
+	version := uint32(1)
+	assert.Equal(t, []string{"tx1", "tx2", "tx4"}, fetchAll(&version))
+	assert.Len(t, fetchAll(nil), len(delegations))
+
+	invalidVersion := uint32(7)
+	_, _, rpcErr := service.GetDelegations(ctx, testStakerPkHex, &invalidVersion, "")
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, http.StatusBadRequest, rpcErr.StatusCode)
+	assert.Equal(t, types.BadRequest, rpcErr.ErrorCode)
+	assert.Equal(t, "invalid params_version 7, valid versions are: 0, 1, 2", rpcErr.Err.Error())
+}
@@ -0,0 +1,26 @@
+package v2service
+
+import (
+	"context"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// SaveDryRunShadowRecord stores the writes a queue handler would have applied
+// for the given message into the shadow collection.
+func (s *V2Service) SaveDryRunShadowRecord(
+	ctx context.Context, queueName, stakingTxHashHex, messageBody string, intendedWrites []string,
+) *types.Error {
+	record := dbmodel.NewDryRunShadowRecordDocument(
+		queueName, stakingTxHashHex, messageBody, intendedWrites, time.Now().Unix(),
+	)
+	if err := s.DbClients.V2DBClient.SaveDryRunShadowRecord(ctx, record); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("queueName", queueName).
+			Msg("error while saving dry-run shadow record")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package v2service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+type EventGapPublic struct {
+	QueueName       string `json:"queue_name"`
+	FromSequence    uint64 `json:"from_sequence"`
+	ToSequence      uint64 `json:"to_sequence"`
+	FromHeight      uint64 `json:"from_height"`
+	ToHeight        uint64 `json:"to_height"`
+	PreviousEventAt int64  `json:"previous_event_at"`
+	DetectedAt      int64  `json:"detected_at"`
+}
+
+// SaveEventGap stores a suspected gap in the events received on a queue
+func (s *V2Service) SaveEventGap(ctx context.Context, gap *v2dbmodel.EventGapDocument) *types.Error {
+	if err := s.DbClients.V2DBClient.SaveEventGap(ctx, gap); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("queueName", gap.QueueName).
+			Msg("error while saving event gap")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// GetEventGaps returns the suspected event gaps in a paginated way
+func (s *V2Service) GetEventGaps(
+	ctx context.Context, pageToken string,
+) ([]*EventGapPublic, string, *types.Error) {
+	resultMap, err := s.DbClients.V2DBClient.FindEventGaps(ctx, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching event gaps")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find event gaps")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	gaps := make([]*EventGapPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		gaps = append(gaps, &EventGapPublic{
+			QueueName:       d.QueueName,
+			FromSequence:    d.FromSequence,
+			ToSequence:      d.ToSequence,
+			FromHeight:      d.FromHeight,
+			ToHeight:        d.ToHeight,
+			PreviousEventAt: d.PreviousEventAt,
+			DetectedAt:      d.DetectedAt,
+		})
+	}
+	return gaps, resultMap.PaginationToken, nil
+}
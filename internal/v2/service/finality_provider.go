@@ -3,9 +3,12 @@ package v2service
 import (
 	"context"
 	"net/http"
+	"sort"
 
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
 	"github.com/rs/zerolog/log"
@@ -17,7 +20,56 @@ type FinalityProviderStatsPublic struct {
 	Description       types.FinalityProviderDescription   `json:"description"`
 	Commission        string                              `json:"commission"`
 	ActiveTvl         int64                               `json:"active_tvl"`
+	TotalTvl          int64                               `json:"total_tvl"`
 	ActiveDelegations int64                               `json:"active_delegations"`
+	TotalDelegations  int64                               `json:"total_delegations"`
+}
+
+// FinalityProviderOrderBy is the stats field the finality providers list
+// can be sorted by, always in descending order
+type FinalityProviderOrderBy string
+
+const (
+	FinalityProviderOrderByActiveTvl         FinalityProviderOrderBy = "active_tvl"
+	FinalityProviderOrderByTotalTvl          FinalityProviderOrderBy = "total_tvl"
+	FinalityProviderOrderByActiveDelegations FinalityProviderOrderBy = "active_delegations"
+	FinalityProviderOrderByTotalDelegations  FinalityProviderOrderBy = "total_delegations"
+)
+
+// SupportedFinalityProviderOrderBy returns the accepted values of the
+// finality providers order_by query parameter
+func SupportedFinalityProviderOrderBy() []FinalityProviderOrderBy {
+	return []FinalityProviderOrderBy{
+		FinalityProviderOrderByActiveTvl,
+		FinalityProviderOrderByTotalTvl,
+		FinalityProviderOrderByActiveDelegations,
+		FinalityProviderOrderByTotalDelegations,
+	}
+}
+
+func (o FinalityProviderOrderBy) statValue(fp *FinalityProviderStatsPublic) int64 {
+	switch o {
+	case FinalityProviderOrderByTotalTvl:
+		return fp.TotalTvl
+	case FinalityProviderOrderByActiveDelegations:
+		return fp.ActiveDelegations
+	case FinalityProviderOrderByTotalDelegations:
+		return fp.TotalDelegations
+	default:
+		return fp.ActiveTvl
+	}
+}
+
+// sortFinalityProviders sorts the finality providers by the given stat in
+// descending order, ties are broken by the btc pk to keep the order stable
+func sortFinalityProviders(fps []*FinalityProviderStatsPublic, orderBy FinalityProviderOrderBy) {
+	sort.SliceStable(fps, func(i, j int) bool {
+		vi, vj := orderBy.statValue(fps[i]), orderBy.statValue(fps[j])
+		if vi != vj {
+			return vi > vj
+		}
+		return fps[i].BtcPk < fps[j].BtcPk
+	})
 }
 
 type FinalityProvidersStatsPublic struct {
@@ -34,13 +86,31 @@ func mapToFinalityProviderStatsPublic(
 		Description:       types.FinalityProviderDescription(provider.Description),
 		Commission:        provider.Commission,
 		ActiveTvl:         fpStats.ActiveTvl,
+		TotalTvl:          fpStats.TotalTvl,
 		ActiveDelegations: fpStats.ActiveDelegations,
+		TotalDelegations:  fpStats.TotalDelegations,
 	}
 }
 
-// GetFinalityProvidersWithStats retrieves all finality providers and their associated statistics
+// GetFinalityProvidersWithStats retrieves all finality providers and their associated statistics.
+// If orderBy is not empty the result is sorted by the given stat in descending order.
+// The result is cached until the next stats update or for the configured ttl.
 func (s *V2Service) GetFinalityProvidersWithStats(
 	ctx context.Context,
+	orderBy FinalityProviderOrderBy,
+) ([]*FinalityProviderStatsPublic, *types.Error) {
+	return cache.GetOrLoad(
+		s.Cache, config.V2FinalityProvidersCacheEndpoint, string(orderBy),
+		s.responseCacheTtl(config.V2FinalityProvidersCacheEndpoint),
+		func() ([]*FinalityProviderStatsPublic, *types.Error) {
+			return s.loadFinalityProvidersWithStats(ctx, orderBy)
+		},
+	)
+}
+
+func (s *V2Service) loadFinalityProvidersWithStats(
+	ctx context.Context,
+	orderBy FinalityProviderOrderBy,
 ) ([]*FinalityProviderStatsPublic, *types.Error) {
 	finalityProviders, err := s.DbClients.IndexerDBClient.GetFinalityProviders(ctx)
 	if err != nil {
@@ -91,5 +161,8 @@ func (s *V2Service) GetFinalityProvidersWithStats(
 			mapToFinalityProviderStatsPublic(*provider, providerStats),
 		)
 	}
+	if orderBy != "" {
+		sortFinalityProviders(finalityProvidersWithStats, orderBy)
+	}
 	return finalityProvidersWithStats, nil
 }
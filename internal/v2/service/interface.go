@@ -2,24 +2,33 @@ package v2service
 
 import (
 	"context"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
 )
 
 type V2ServiceProvider interface {
-	GetFinalityProvidersWithStats(ctx context.Context) (
+	GetFinalityProvidersWithStats(ctx context.Context, orderBy FinalityProviderOrderBy) (
 		[]*FinalityProviderStatsPublic, *types.Error,
 	)
 	GetNetworkInfo(ctx context.Context) (*NetworkInfoPublic, *types.Error)
 	GetDelegation(ctx context.Context, stakingTxHashHex string) (*DelegationPublic, *types.Error)
-	GetDelegations(ctx context.Context, stakerPKHex string, paginationKey string) ([]*DelegationPublic, string, *types.Error)
+	GetDelegations(ctx context.Context, stakerPKHex string, paramsVersion *uint32, paginationKey string) ([]*DelegationPublic, string, *types.Error)
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) *types.Error) *types.Error
 	MarkV1DelegationAsTransitioned(ctx context.Context, stakingTxHashHex string) *types.Error
 	GetOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error)
 	GetStakerStats(ctx context.Context, stakerPKHex string) (*StakerStatsPublic, *types.Error)
+	GetStatsTimeSeries(ctx context.Context, from, to time.Time) ([]StatsTimeSeriesPointPublic, *types.Error)
 	ProcessAndSaveBtcAddresses(ctx context.Context, stakerPkHex string) *types.Error
-	SaveUnprocessableMessages(ctx context.Context, messageBody, receipt string) *types.Error
+	SaveUnprocessableMessages(ctx context.Context, queueName, messageBody, receipt, processingErr string, retryAttempts int32) *types.Error
 	ProcessActiveDelegationStats(ctx context.Context, stakingTxHashHex, stakerPkHex string, fpBtcPkHexes []string, amount uint64) *types.Error
 	ProcessUnbondingDelegationStats(ctx context.Context, stakingTxHashHex, stakerPkHex string, fpBtcPkHexes []string, amount uint64, stateHistory []string) *types.Error
 	ProcessWithdrawableDelegationStats(ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64, stateHistory []string) *types.Error
 	ProcessWithdrawnDelegationStats(ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64, stateHistory []string) *types.Error
+	SaveDryRunShadowRecord(ctx context.Context, queueName, stakingTxHashHex, messageBody string, intendedWrites []string) *types.Error
+	SaveEventGap(ctx context.Context, gap *v2dbmodel.EventGapDocument) *types.Error
+	GetQueuePayload(ctx context.Context, id string) (string, *types.Error)
+	DeleteQueuePayload(ctx context.Context, id string) *types.Error
+	GetEventGaps(ctx context.Context, pageToken string) ([]*EventGapPublic, string, *types.Error)
 }
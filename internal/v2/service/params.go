@@ -2,7 +2,11 @@ package v2service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
 	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
@@ -61,3 +65,33 @@ func (s *V2Service) getBtcCheckpointParams(ctx context.Context) ([]*indexertypes
 	}
 	return params, nil
 }
+
+// validateParamsVersion returns a bad request error listing the valid
+// versions if the given version is not part of the babylon staking params
+func (s *V2Service) validateParamsVersion(ctx context.Context, version uint32) *types.Error {
+	params, err := s.getBbnStakingParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	versions := make([]uint32, 0, len(params))
+	for _, p := range params {
+		if p.Version == version {
+			return nil
+		}
+		versions = append(versions, p.Version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	validVersions := make([]string, 0, len(versions))
+	for _, v := range versions {
+		validVersions = append(validVersions, strconv.FormatUint(uint64(v), 10))
+	}
+	return types.NewErrorWithMsg(
+		http.StatusBadRequest, types.BadRequest,
+		fmt.Sprintf(
+			"invalid params_version %d, valid versions are: %s",
+			version, strings.Join(validVersions, ", "),
+		),
+	)
+}
@@ -0,0 +1,33 @@
+package v2service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// GetQueuePayload returns the payload a reference queue message points to
+func (s *V2Service) GetQueuePayload(ctx context.Context, id string) (string, *types.Error) {
+	payload, err := s.DbClients.V2DBClient.FindQueuePayload(ctx, id)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("payloadId", id).Msg("queue payload not found")
+			return "", types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "queue payload not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Str("payloadId", id).Msg("error while fetching queue payload")
+		return "", types.NewInternalServiceError(err)
+	}
+	return payload.Payload, nil
+}
+
+// DeleteQueuePayload removes the payload of a processed reference queue message
+func (s *V2Service) DeleteQueuePayload(ctx context.Context, id string) *types.Error {
+	if err := s.DbClients.V2DBClient.DeleteQueuePayload(ctx, id); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("payloadId", id).Msg("error while deleting queue payload")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
@@ -2,16 +2,21 @@ package v2service
 
 import (
 	"context"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
 )
 
 type V2Service struct {
 	DbClients *dbclients.DbClients
 	Clients   *clients.Clients
 	Cfg       *config.Config
+	// Cache holds the responses of the stats and finality provider endpoints,
+	// they are not cached if nil
+	Cache cache.Cache
 }
 
 func New(
@@ -24,5 +29,14 @@ func New(
 		DbClients: dbClients,
 		Clients:   clients,
 		Cfg:       cfg,
+		Cache:     cache.NewMemoryCache(),
 	}, nil
 }
+
+// responseCacheTtl returns how long the responses of the endpoint are cached
+func (s *V2Service) responseCacheTtl(endpoint string) time.Duration {
+	if s.Cfg == nil {
+		return 0
+	}
+	return s.Cfg.ResponseCache.Ttl(endpoint)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
@@ -80,6 +81,9 @@ func (s *V2Service) GetStakerPublicKeysByAddresses(
 			ctx, taprootAddresses,
 		)
 		if err != nil {
+			if db.IsInListTooLargeError(err) {
+				return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, err.Error())
+			}
 			log.Ctx(ctx).Error().Err(err).
 				Msg("Failed to get pk mappings by taproot address")
 			return nil, types.NewInternalServiceError(err)
@@ -94,6 +98,9 @@ func (s *V2Service) GetStakerPublicKeysByAddresses(
 			ctx, nativeSegwitAddresses,
 		)
 		if err != nil {
+			if db.IsInListTooLargeError(err) {
+				return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, err.Error())
+			}
 			log.Ctx(ctx).Error().Err(err).
 				Msg("Failed to get pk mappings by native segwit address")
 			return nil, types.NewInternalServiceError(err)
@@ -4,13 +4,18 @@ import (
 	"context"
 
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/cache"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	"github.com/rs/zerolog/log"
 )
 
 type OverallStatsPublic struct {
 	ActiveTvl               int64  `json:"active_tvl"`
+	ActiveTvlBtc            string `json:"active_tvl_btc,omitempty"`
 	ActiveDelegations       int64  `json:"active_delegations"`
 	ActiveStakers           uint64 `json:"active_stakers"`
 	ActiveFinalityProviders uint64 `json:"active_finality_providers"`
@@ -20,14 +25,47 @@ type OverallStatsPublic struct {
 type StakerStatsPublic struct {
 	StakerPkHex             string `json:"staker_pk_hex"`
 	ActiveTvl               int64  `json:"active_tvl"`
+	ActiveTvlBtc            string `json:"active_tvl_btc,omitempty"`
 	ActiveDelegations       int64  `json:"active_delegations"`
 	UnbondingTvl            int64  `json:"unbonding_tvl"`
+	UnbondingTvlBtc         string `json:"unbonding_tvl_btc,omitempty"`
 	UnbondingDelegations    int64  `json:"unbonding_delegations"`
 	WithdrawableTvl         int64  `json:"withdrawable_tvl"`
+	WithdrawableTvlBtc      string `json:"withdrawable_tvl_btc,omitempty"`
 	WithdrawableDelegations int64  `json:"withdrawable_delegations"`
 }
 
+// AddFormattedFields fills the BTC denominated amounts next to the satoshi ones
+func (s *OverallStatsPublic) AddFormattedFields() {
+	s.ActiveTvlBtc = utils.FormatSatoshisAsBtc(s.ActiveTvl)
+}
+
+// AddFormattedFields fills the BTC denominated amounts next to the satoshi ones
+func (s *StakerStatsPublic) AddFormattedFields() {
+	s.ActiveTvlBtc = utils.FormatSatoshisAsBtc(s.ActiveTvl)
+	s.UnbondingTvlBtc = utils.FormatSatoshisAsBtc(s.UnbondingTvl)
+	s.WithdrawableTvlBtc = utils.FormatSatoshisAsBtc(s.WithdrawableTvl)
+}
+
+// GetOverallStats returns the overall stats, cached until the next stats
+// update or for the configured ttl. The returned stats are a copy of the
+// cached ones, they can be modified.
 func (s *V2Service) GetOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error) {
+	cached, err := cache.GetOrLoad(
+		s.Cache, config.V2OverallStatsCacheEndpoint, "",
+		s.responseCacheTtl(config.V2OverallStatsCacheEndpoint),
+		func() (*OverallStatsPublic, *types.Error) {
+			return s.loadOverallStats(ctx)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	stats := *cached
+	return &stats, nil
+}
+
+func (s *V2Service) loadOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error) {
 	overallStats, err := s.DbClients.V2DBClient.GetOverallStats(ctx)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats")
@@ -84,6 +122,8 @@ func (s *V2Service) GetStakerStats(ctx context.Context, stakerPKHex string) (*St
 
 // ProcessActiveDelegationStats calculates the active delegation stats and updates the database.
 func (s *V2Service) ProcessActiveDelegationStats(ctx context.Context, stakingTxHashHex, stakerPkHex string, fpBtcPkHexes []string, amount uint64) *types.Error {
+	defer s.invalidateStatsCache(ctx)
+
 	// Fetch existing or initialize the stats lock document if not exist
 	statsLockDocument, err := s.DbClients.V2DBClient.GetOrCreateStatsLock(
 		ctx, stakingTxHashHex, types.Active.ToString(),
@@ -156,6 +196,8 @@ func (s *V2Service) ProcessUnbondingDelegationStats(
 	amount uint64,
 	stateHistory []string,
 ) *types.Error {
+	defer s.invalidateStatsCache(ctx)
+
 	statsLockDocument, err := s.DbClients.V2DBClient.GetOrCreateStatsLock(
 		ctx,
 		stakingTxHashHex,
@@ -235,6 +277,8 @@ func (s *V2Service) ProcessWithdrawableDelegationStats(
 	amount uint64,
 	stateHistory []string,
 ) *types.Error {
+	defer s.invalidateStatsCache(ctx)
+
 	statsLockDocument, err := s.DbClients.V2DBClient.GetOrCreateStatsLock(
 		ctx,
 		stakingTxHashHex,
@@ -284,6 +328,8 @@ func (s *V2Service) ProcessWithdrawnDelegationStats(
 	amount uint64,
 	stateHistory []string,
 ) *types.Error {
+	defer s.invalidateStatsCache(ctx)
+
 	statsLockDocument, err := s.DbClients.V2DBClient.GetOrCreateStatsLock(
 		ctx,
 		stakingTxHashHex,
@@ -322,3 +368,18 @@ func (s *V2Service) ProcessWithdrawnDelegationStats(
 
 	return nil
 }
+
+// invalidateStatsCache drops the cached responses built from the stats. It
+// runs after every stats update, failed ones included as they may be partial,
+// once the transaction the update is part of is over so that the responses
+// are not cached again from the stats before the commit.
+func (s *V2Service) invalidateStatsCache(ctx context.Context) {
+	if s.Cache == nil {
+		return
+	}
+	service.AfterTransaction(ctx, func() {
+		s.Cache.Invalidate(
+			config.V2OverallStatsCacheEndpoint, config.V2FinalityProvidersCacheEndpoint,
+		)
+	})
+}
@@ -0,0 +1,84 @@
+package v2service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxStatsTimeSeriesDays is the maximum number of days a time series request
+// can span, both bounds included
+const MaxStatsTimeSeriesDays = 365
+
+type StatsTimeSeriesPointPublic struct {
+	Date              string `json:"date"`
+	ActiveTvl         int64  `json:"active_tvl"`
+	ActiveDelegations int64  `json:"active_delegations"`
+}
+
+// buildStatsTimeSeries accumulates the daily changes into one point per day
+// between from and to, days without changes carry the previous value forward
+func buildStatsTimeSeries(
+	dailyStats []v2dbmodel.V2DailyStatsDocument, from, to time.Time,
+) []StatsTimeSeriesPointPublic {
+	deltas := make(map[string]v2dbmodel.V2DailyStatsDocument, len(dailyStats))
+	fromDate := from.Format(v2dbmodel.DailyStatsDateFormat)
+	var tvl, delegations int64
+	for _, d := range dailyStats {
+		// everything before the range is the starting value of the series
+		if d.Date < fromDate {
+			tvl += d.TvlDelta
+			delegations += d.DelegationsDelta
+			continue
+		}
+		deltas[d.Date] = d
+	}
+
+	var series []StatsTimeSeriesPointPublic
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(v2dbmodel.DailyStatsDateFormat)
+		if d, ok := deltas[date]; ok {
+			tvl += d.TvlDelta
+			delegations += d.DelegationsDelta
+		}
+		series = append(series, StatsTimeSeriesPointPublic{
+			Date:              date,
+			ActiveTvl:         tvl,
+			ActiveDelegations: delegations,
+		})
+	}
+	return series
+}
+
+// GetStatsTimeSeries returns the active tvl and delegations at the end of
+// each UTC day between from and to, both included
+func (s *V2Service) GetStatsTimeSeries(
+	ctx context.Context, from, to time.Time,
+) ([]StatsTimeSeriesPointPublic, *types.Error) {
+	if to.Before(from) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "from must not be after to",
+		)
+	}
+	if int(to.Sub(from).Hours()/24)+1 > MaxStatsTimeSeriesDays {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("time series range must not exceed %d days", MaxStatsTimeSeriesDays),
+		)
+	}
+
+	dailyStats, err := s.DbClients.V2DBClient.FindDailyStats(
+		ctx, to.Format(v2dbmodel.DailyStatsDateFormat),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching daily stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return buildStatsTimeSeries(dailyStats, from, to), nil
+}
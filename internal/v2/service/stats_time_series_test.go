@@ -0,0 +1,105 @@
+package v2service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStatsTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	date := func(day int) time.Time {
+		return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	// replay active and unbonding events over several days, the same way the
+	// overall stats updates roll them up into the daily documents
+	events := []struct {
+		day    int
+		amount int64
+		active bool
+	}{
+		{day: 1, amount: 1000, active: true},
+		{day: 3, amount: 500, active: true},
+		{day: 3, amount: 200, active: true},
+		{day: 3, amount: 1000, active: false},
+		{day: 6, amount: 300, active: true},
+		{day: 9, amount: 500, active: false},
+	}
+	rollup := make(map[string]*v2dbmodel.V2DailyStatsDocument)
+	var dailyStats []v2dbmodel.V2DailyStatsDocument
+	for _, e := range events {
+		key := date(e.day).Format(v2dbmodel.DailyStatsDateFormat)
+		if _, ok := rollup[key]; !ok {
+			rollup[key] = &v2dbmodel.V2DailyStatsDocument{Date: key}
+		}
+		if e.active {
+			rollup[key].TvlDelta += e.amount
+			rollup[key].DelegationsDelta++
+		} else {
+			rollup[key].TvlDelta -= e.amount
+			rollup[key].DelegationsDelta--
+		}
+	}
+	for _, day := range []int{1, 3, 6, 9} {
+		dailyStats = append(dailyStats, *rollup[date(day).Format(v2dbmodel.DailyStatsDateFormat)])
+	}
+
+	t.Run("series is cumulative and continuous", func(t *testing.T) {
+		v2DB := &mocks.V2DBClient{}
+		defer v2DB.AssertExpectations(t)
+		// only the documents up to the end of the range are returned
+		v2DB.On("FindDailyStats", ctx, "2024-03-07").Return(dailyStats[:3], nil).Once()
+
+		service, err := New(ctx, &config.Config{}, nil, &dbclients.DbClients{V2DBClient: v2DB})
+		require.NoError(t, err)
+
+		series, rpcErr := service.GetStatsTimeSeries(ctx, date(2), date(7))
+		require.Nil(t, rpcErr)
+		assert.Equal(t, []StatsTimeSeriesPointPublic{
+			{Date: "2024-03-02", ActiveTvl: 1000, ActiveDelegations: 1},
+			{Date: "2024-03-03", ActiveTvl: 700, ActiveDelegations: 2},
+			{Date: "2024-03-04", ActiveTvl: 700, ActiveDelegations: 2},
+			{Date: "2024-03-05", ActiveTvl: 700, ActiveDelegations: 2},
+			{Date: "2024-03-06", ActiveTvl: 1000, ActiveDelegations: 3},
+			{Date: "2024-03-07", ActiveTvl: 1000, ActiveDelegations: 3},
+		}, series)
+	})
+
+	t.Run("range without any event", func(t *testing.T) {
+		v2DB := &mocks.V2DBClient{}
+		defer v2DB.AssertExpectations(t)
+		v2DB.On("FindDailyStats", ctx, "2024-02-02").Return(nil, nil).Once()
+
+		service, err := New(ctx, &config.Config{}, nil, &dbclients.DbClients{V2DBClient: v2DB})
+		require.NoError(t, err)
+
+		series, rpcErr := service.GetStatsTimeSeries(ctx, date(1).AddDate(0, -1, 0), date(2).AddDate(0, -1, 0))
+		require.Nil(t, rpcErr)
+		assert.Equal(t, []StatsTimeSeriesPointPublic{
+			{Date: "2024-02-01"},
+			{Date: "2024-02-02"},
+		}, series)
+	})
+
+	t.Run("invalid ranges are rejected", func(t *testing.T) {
+		service, err := New(ctx, &config.Config{}, nil, &dbclients.DbClients{V2DBClient: &mocks.V2DBClient{}})
+		require.NoError(t, err)
+
+		_, rpcErr := service.GetStatsTimeSeries(ctx, date(5), date(4))
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, http.StatusBadRequest, rpcErr.StatusCode)
+
+		_, rpcErr = service.GetStatsTimeSeries(ctx, date(1), date(1).AddDate(0, 0, MaxStatsTimeSeriesDays))
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, http.StatusBadRequest, rpcErr.StatusCode)
+	})
+}
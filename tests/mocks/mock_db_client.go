@@ -33,6 +33,36 @@ func (_m *DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt inte
 	return r0
 }
 
+// FindGlobalParamsUpdates provides a mock function with given fields: ctx
+func (_m *DBClient) FindGlobalParamsUpdates(ctx context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindGlobalParamsUpdates")
+	}
+
+	var r0 []dbmodel.GlobalParamsUpdateDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.GlobalParamsUpdateDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.GlobalParamsUpdateDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindPkMappingsByNativeSegwitAddress provides a mock function with given fields: ctx, nativeSegwitAddresses
 func (_m *DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, nativeSegwitAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
 	ret := _m.Called(ctx, nativeSegwitAddresses)
@@ -123,6 +153,24 @@ func (_m *DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.Un
 	return r0, r1
 }
 
+// InsertGlobalParamsUpdate provides a mock function with given fields: ctx, update
+func (_m *DBClient) InsertGlobalParamsUpdate(ctx context.Context, update *dbmodel.GlobalParamsUpdateDocument) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertGlobalParamsUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.GlobalParamsUpdateDocument) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertPkAddressMappings provides a mock function with given fields: ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven
 func (_m *DBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex string, taproot string, nativeSigwitOdd string, nativeSigwitEven string) error {
 	ret := _m.Called(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
@@ -159,17 +207,53 @@ func (_m *DBClient) Ping(ctx context.Context) error {
 	return r0
 }
 
-// SaveUnprocessableMessage provides a mock function with given fields: ctx, messageBody, receipt
-func (_m *DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string) error {
-	ret := _m.Called(ctx, messageBody, receipt)
+// SaveDryRunShadowRecord provides a mock function with given fields: ctx, record
+func (_m *DBClient) SaveDryRunShadowRecord(ctx context.Context, record *dbmodel.DryRunShadowRecordDocument) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDryRunShadowRecord")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.DryRunShadowRecordDocument) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUnprocessableMessage provides a mock function with given fields: ctx, queueName, messageBody, receipt, processingErr, retryAttempts
+func (_m *DBClient) SaveUnprocessableMessage(ctx context.Context, queueName string, messageBody string, receipt string, processingErr string, retryAttempts int32) error {
+	ret := _m.Called(ctx, queueName, messageBody, receipt, processingErr, retryAttempts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUnprocessableMessage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, messageBody, receipt)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int32) error); ok {
+		r0 = rf(ctx, queueName, messageBody, receipt, processingErr, retryAttempts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTransaction provides a mock function with given fields: ctx, fn
+func (_m *DBClient) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -20,27 +20,57 @@ type IndexerDBClient struct {
 	mock.Mock
 }
 
-// CheckDelegationExistByStakerPk provides a mock function with given fields: ctx, address, extraFilter
-func (_m *IndexerDBClient) CheckDelegationExistByStakerPk(ctx context.Context, address string, extraFilter *indexerdbclient.DelegationFilter) (bool, error) {
-	ret := _m.Called(ctx, address, extraFilter)
+// CountDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter
+func (_m *IndexerDBClient) CountDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *indexerdbclient.DelegationFilter) (int64, error) {
+	ret := _m.Called(ctx, stakerPk, extraFilter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckDelegationExistByStakerPk")
+		panic("no return value specified for CountDelegationsByStakerPk")
 	}
 
-	var r0 bool
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *indexerdbclient.DelegationFilter) (bool, error)); ok {
-		return rf(ctx, address, extraFilter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *indexerdbclient.DelegationFilter) (int64, error)); ok {
+		return rf(ctx, stakerPk, extraFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *indexerdbclient.DelegationFilter) bool); ok {
-		r0 = rf(ctx, address, extraFilter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *indexerdbclient.DelegationFilter) int64); ok {
+		r0 = rf(ctx, stakerPk, extraFilter)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, *indexerdbclient.DelegationFilter) error); ok {
-		r1 = rf(ctx, address, extraFilter)
+		r1 = rf(ctx, stakerPk, extraFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountDelegationsByState provides a mock function with given fields: ctx
+func (_m *IndexerDBClient) CountDelegationsByState(ctx context.Context) (map[indexertypes.DelegationState]int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDelegationsByState")
+	}
+
+	var r0 map[indexertypes.DelegationState]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[indexertypes.DelegationState]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[indexertypes.DelegationState]int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[indexertypes.DelegationState]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -138,9 +168,9 @@ func (_m *IndexerDBClient) GetDelegation(ctx context.Context, stakingTxHashHex s
 	return r0, r1
 }
 
-// GetDelegations provides a mock function with given fields: ctx, stakerPKHex, paginationToken
-func (_m *IndexerDBClient) GetDelegations(ctx context.Context, stakerPKHex string, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error) {
-	ret := _m.Called(ctx, stakerPKHex, paginationToken)
+// GetDelegations provides a mock function with given fields: ctx, stakerPKHex, paramsVersion, paginationToken
+func (_m *IndexerDBClient) GetDelegations(ctx context.Context, stakerPKHex string, paramsVersion *uint32, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error) {
+	ret := _m.Called(ctx, stakerPKHex, paramsVersion, paginationToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDelegations")
@@ -148,19 +178,19 @@ func (_m *IndexerDBClient) GetDelegations(ctx context.Context, stakerPKHex strin
 
 	var r0 *db.DbResultMap[indexerdbmodel.IndexerDelegationDetails]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error)); ok {
-		return rf(ctx, stakerPKHex, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *uint32, string) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error)); ok {
+		return rf(ctx, stakerPKHex, paramsVersion, paginationToken)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.DbResultMap[indexerdbmodel.IndexerDelegationDetails]); ok {
-		r0 = rf(ctx, stakerPKHex, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *uint32, string) *db.DbResultMap[indexerdbmodel.IndexerDelegationDetails]); ok {
+		r0 = rf(ctx, stakerPKHex, paramsVersion, paginationToken)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, stakerPKHex, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *uint32, string) error); ok {
+		r1 = rf(ctx, stakerPKHex, paramsVersion, paginationToken)
 	} else {
 		r1 = ret.Error(1)
 	}
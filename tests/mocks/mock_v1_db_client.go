@@ -10,6 +10,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 
 	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
@@ -22,6 +24,24 @@ type V1DBClient struct {
 	mock.Mock
 }
 
+// BackfillWithdrawalPath provides a mock function with given fields: ctx, txHashHex, withdrawalPath, amount
+func (_m *V1DBClient) BackfillWithdrawalPath(ctx context.Context, txHashHex string, withdrawalPath v1dbmodel.WithdrawalPath, amount uint64) error {
+	ret := _m.Called(ctx, txHashHex, withdrawalPath, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BackfillWithdrawalPath")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1dbmodel.WithdrawalPath, uint64) error); ok {
+		r0 = rf(ctx, txHashHex, withdrawalPath, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckDelegationExistByStakerPk provides a mock function with given fields: ctx, address, extraFilter
 func (_m *V1DBClient) CheckDelegationExistByStakerPk(ctx context.Context, address string, extraFilter *v1dbclient.DelegationFilter) (bool, error) {
 	ret := _m.Called(ctx, address, extraFilter)
@@ -50,47 +70,59 @@ func (_m *V1DBClient) CheckDelegationExistByStakerPk(ctx context.Context, addres
 	return r0, r1
 }
 
-// DeleteUnprocessableMessage provides a mock function with given fields: ctx, Receipt
-func (_m *V1DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
-	ret := _m.Called(ctx, Receipt)
+// CountActiveDelegationsByStakingValue provides a mock function with given fields: ctx
+func (_m *V1DBClient) CountActiveDelegationsByStakingValue(ctx context.Context) (map[uint64]int64, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUnprocessableMessage")
+		panic("no return value specified for CountActiveDelegationsByStakingValue")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
-		r0 = rf(ctx, Receipt)
+	var r0 map[uint64]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[uint64]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[uint64]int64); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uint64]int64)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// FindDelegationByTxHashHex provides a mock function with given fields: ctx, txHashHex
-func (_m *V1DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error) {
-	ret := _m.Called(ctx, txHashHex)
+// CountActiveDelegationsByStartHeight provides a mock function with given fields: ctx, stakerPk
+func (_m *V1DBClient) CountActiveDelegationsByStartHeight(ctx context.Context, stakerPk string) (map[uint64]int64, error) {
+	ret := _m.Called(ctx, stakerPk)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindDelegationByTxHashHex")
+		panic("no return value specified for CountActiveDelegationsByStartHeight")
 	}
 
-	var r0 *v1dbmodel.DelegationDocument
+	var r0 map[uint64]int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.DelegationDocument, error)); ok {
-		return rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[uint64]int64, error)); ok {
+		return rf(ctx, stakerPk)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.DelegationDocument); ok {
-		r0 = rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[uint64]int64); ok {
+		r0 = rf(ctx, stakerPk)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.DelegationDocument)
+			r0 = ret.Get(0).(map[uint64]int64)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, txHashHex)
+		r1 = rf(ctx, stakerPk)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,29 +130,29 @@ func (_m *V1DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex s
 	return r0, r1
 }
 
-// FindDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter, paginationToken
-func (_m *V1DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
-	ret := _m.Called(ctx, stakerPk, extraFilter, paginationToken)
+// CountActiveDelegationsByTimelock provides a mock function with given fields: ctx
+func (_m *V1DBClient) CountActiveDelegationsByTimelock(ctx context.Context) (map[uint64]int64, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindDelegationsByStakerPk")
+		panic("no return value specified for CountActiveDelegationsByTimelock")
 	}
 
-	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r0 map[uint64]int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
-		return rf(ctx, stakerPk, extraFilter, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context) (map[uint64]int64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
-		r0 = rf(ctx, stakerPk, extraFilter, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context) map[uint64]int64); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+			r0 = ret.Get(0).(map[uint64]int64)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *v1dbclient.DelegationFilter, string) error); ok {
-		r1 = rf(ctx, stakerPk, extraFilter, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -128,29 +160,89 @@ func (_m *V1DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk st
 	return r0, r1
 }
 
-// FindFinalityProviderStats provides a mock function with given fields: ctx, paginationToken
-func (_m *V1DBClient) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error) {
-	ret := _m.Called(ctx, paginationToken)
+// CountDelegationsByState provides a mock function with given fields: ctx
+func (_m *V1DBClient) CountDelegationsByState(ctx context.Context) (map[types.DelegationState]int64, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindFinalityProviderStats")
+		panic("no return value specified for CountDelegationsByState")
 	}
 
-	var r0 *db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]
+	var r0 map[types.DelegationState]int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error)); ok {
-		return rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context) (map[types.DelegationState]int64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]); ok {
-		r0 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context) map[types.DelegationState]int64); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument])
+			r0 = ret.Get(0).(map[types.DelegationState]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountFinalityProviderChurnSince provides a mock function with given fields: ctx, since
+func (_m *V1DBClient) CountFinalityProviderChurnSince(ctx context.Context, since int64) ([]v1dbmodel.FinalityProviderChurn, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountFinalityProviderChurnSince")
+	}
+
+	var r0 []v1dbmodel.FinalityProviderChurn
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]v1dbmodel.FinalityProviderChurn, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []v1dbmodel.FinalityProviderChurn); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.FinalityProviderChurn)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountStakerDelegationsByState provides a mock function with given fields: ctx, stakerPkHex
+func (_m *V1DBClient) CountStakerDelegationsByState(ctx context.Context, stakerPkHex string) (map[types.DelegationState]int64, error) {
+	ret := _m.Called(ctx, stakerPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountStakerDelegationsByState")
+	}
+
+	var r0 map[types.DelegationState]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[types.DelegationState]int64, error)); ok {
+		return rf(ctx, stakerPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[types.DelegationState]int64); ok {
+		r0 = rf(ctx, stakerPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[types.DelegationState]int64)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paginationToken)
+		r1 = rf(ctx, stakerPkHex)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -158,29 +250,29 @@ func (_m *V1DBClient) FindFinalityProviderStats(ctx context.Context, paginationT
 	return r0, r1
 }
 
-// FindFinalityProviderStatsByFinalityProviderPkHex provides a mock function with given fields: ctx, finalityProviderPkHex
-func (_m *V1DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context.Context, finalityProviderPkHex []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
-	ret := _m.Called(ctx, finalityProviderPkHex)
+// CountUniqueStakersByFinalityProvider provides a mock function with given fields: ctx, minStakers
+func (_m *V1DBClient) CountUniqueStakersByFinalityProvider(ctx context.Context, minStakers int64) ([]v1dbmodel.FinalityProviderStakersCount, error) {
+	ret := _m.Called(ctx, minStakers)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindFinalityProviderStatsByFinalityProviderPkHex")
+		panic("no return value specified for CountUniqueStakersByFinalityProvider")
 	}
 
-	var r0 []*v1dbmodel.FinalityProviderStatsDocument
+	var r0 []v1dbmodel.FinalityProviderStakersCount
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error)); ok {
-		return rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]v1dbmodel.FinalityProviderStakersCount, error)); ok {
+		return rf(ctx, minStakers)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*v1dbmodel.FinalityProviderStatsDocument); ok {
-		r0 = rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []v1dbmodel.FinalityProviderStakersCount); ok {
+		r0 = rf(ctx, minStakers)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1dbmodel.FinalityProviderStatsDocument)
+			r0 = ret.Get(0).([]v1dbmodel.FinalityProviderStakersCount)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, minStakers)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -188,29 +280,93 @@ func (_m *V1DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx conte
 	return r0, r1
 }
 
-// FindPkMappingsByNativeSegwitAddress provides a mock function with given fields: ctx, nativeSegwitAddresses
-func (_m *V1DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, nativeSegwitAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
-	ret := _m.Called(ctx, nativeSegwitAddresses)
+// CountWatchlistObserversByWatchedPk provides a mock function with given fields: ctx, watchedPkHex
+func (_m *V1DBClient) CountWatchlistObserversByWatchedPk(ctx context.Context, watchedPkHex string) (int64, error) {
+	ret := _m.Called(ctx, watchedPkHex)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindPkMappingsByNativeSegwitAddress")
+		panic("no return value specified for CountWatchlistObserversByWatchedPk")
 	}
 
-	var r0 []*dbmodel.PkAddressMapping
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*dbmodel.PkAddressMapping, error)); ok {
-		return rf(ctx, nativeSegwitAddresses)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, watchedPkHex)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dbmodel.PkAddressMapping); ok {
-		r0 = rf(ctx, nativeSegwitAddresses)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, watchedPkHex)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, watchedPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUnprocessableMessage provides a mock function with given fields: ctx, Receipt
+func (_m *V1DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
+	ret := _m.Called(ctx, Receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnprocessableMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, Receipt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWatchlistEntry provides a mock function with given fields: ctx, observerPkHex, watchedPkHex
+func (_m *V1DBClient) DeleteWatchlistEntry(ctx context.Context, observerPkHex string, watchedPkHex string) error {
+	ret := _m.Called(ctx, observerPkHex, watchedPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWatchlistEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, observerPkHex, watchedPkHex)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindActiveDelegationsBelowStakingValue provides a mock function with given fields: ctx, minStakingValue, paginationToken
+func (_m *V1DBClient) FindActiveDelegationsBelowStakingValue(ctx context.Context, minStakingValue uint64, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, minStakingValue, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveDelegationsBelowStakingValue")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, minStakingValue, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, minStakingValue, paginationToken)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*dbmodel.PkAddressMapping)
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, nativeSegwitAddresses)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, minStakingValue, paginationToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -218,85 +374,1259 @@ func (_m *V1DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, n
 	return r0, r1
 }
 
-// FindPkMappingsByTaprootAddress provides a mock function with given fields: ctx, taprootAddresses
-func (_m *V1DBClient) FindPkMappingsByTaprootAddress(ctx context.Context, taprootAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
-	ret := _m.Called(ctx, taprootAddresses)
+// FindActiveDelegationsByStakerPkExpiringBefore provides a mock function with given fields: ctx, stakerPk, expiryHeight
+func (_m *V1DBClient) FindActiveDelegationsByStakerPkExpiringBefore(ctx context.Context, stakerPk string, expiryHeight uint64) ([]v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, stakerPk, expiryHeight)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindPkMappingsByTaprootAddress")
+		panic("no return value specified for FindActiveDelegationsByStakerPkExpiringBefore")
 	}
 
-	var r0 []*dbmodel.PkAddressMapping
+	var r0 []v1dbmodel.DelegationDocument
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*dbmodel.PkAddressMapping, error)); ok {
-		return rf(ctx, taprootAddresses)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) ([]v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, stakerPk, expiryHeight)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dbmodel.PkAddressMapping); ok {
-		r0 = rf(ctx, taprootAddresses)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) []v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, stakerPk, expiryHeight)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*dbmodel.PkAddressMapping)
+			r0 = ret.Get(0).([]v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(ctx, stakerPk, expiryHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationByTxHashHex provides a mock function with given fields: ctx, txHashHex
+func (_m *V1DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, txHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationByTxHashHex")
+	}
+
+	var r0 *v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, txHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, txHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, txHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationTxHashesByStakerPk provides a mock function with given fields: ctx, stakerPk
+func (_m *V1DBClient) FindDelegationTxHashesByStakerPk(ctx context.Context, stakerPk string) ([]string, error) {
+	ret := _m.Called(ctx, stakerPk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationTxHashesByStakerPk")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, stakerPk)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, stakerPk)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, taprootAddresses)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter, paginationToken
+func (_m *V1DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, stakerPk, extraFilter, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByStakerPk")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, stakerPk, extraFilter, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, stakerPk, extraFilter, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1dbclient.DelegationFilter, string) error); ok {
+		r1 = rf(ctx, stakerPk, extraFilter, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByStakerPks provides a mock function with given fields: ctx, stakerPks, paginationToken
+func (_m *V1DBClient) FindDelegationsByStakerPks(ctx context.Context, stakerPks []string, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, stakerPks, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByStakerPks")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, stakerPks, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, stakerPks, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, stakerPks, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByStartHeight provides a mock function with given fields: ctx, height, paginationToken
+func (_m *V1DBClient) FindDelegationsByStartHeight(ctx context.Context, height uint64, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, height, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByStartHeight")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, height, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, height, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, height, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByTxHashHexes provides a mock function with given fields: ctx, stakingTxHashHexes
+func (_m *V1DBClient) FindDelegationsByTxHashHexes(ctx context.Context, stakingTxHashHexes []string) ([]v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHexes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByTxHashHexes")
+	}
+
+	var r0 []v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, stakingTxHashHexes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, stakingTxHashHexes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, stakingTxHashHexes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByWithdrawalAddress provides a mock function with given fields: ctx, address, paginationToken
+func (_m *V1DBClient) FindDelegationsByWithdrawalAddress(ctx context.Context, address string, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, address, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByWithdrawalAddress")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, address, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, address, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, address, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsOverdueForWithdrawal provides a mock function with given fields: ctx, expiryHeight, paginationToken
+func (_m *V1DBClient) FindDelegationsOverdueForWithdrawal(ctx context.Context, expiryHeight uint64, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, expiryHeight, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsOverdueForWithdrawal")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, expiryHeight, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, expiryHeight, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, expiryHeight, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsWithMinStakingValue provides a mock function with given fields: ctx, minStakingValue, paginationToken
+func (_m *V1DBClient) FindDelegationsWithMinStakingValue(ctx context.Context, minStakingValue uint64, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, minStakingValue, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsWithMinStakingValue")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, minStakingValue, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, minStakingValue, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, minStakingValue, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsWithUnknownFinalityProvider provides a mock function with given fields: ctx, registeredFpPkHexes, paginationToken
+func (_m *V1DBClient) FindDelegationsWithUnknownFinalityProvider(ctx context.Context, registeredFpPkHexes []string, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, registeredFpPkHexes, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsWithUnknownFinalityProvider")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, registeredFpPkHexes, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, registeredFpPkHexes, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, registeredFpPkHexes, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderRegistrations provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindFinalityProviderRegistrations(ctx context.Context) ([]v1dbmodel.FinalityProviderRegistrationDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderRegistrations")
+	}
+
+	var r0 []v1dbmodel.FinalityProviderRegistrationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]v1dbmodel.FinalityProviderRegistrationDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []v1dbmodel.FinalityProviderRegistrationDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.FinalityProviderRegistrationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderStakeHistories provides a mock function with given fields: ctx, fpPkHex
+func (_m *V1DBClient) FindFinalityProviderStakeHistories(ctx context.Context, fpPkHex string) ([]*v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, fpPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderStakeHistories")
+	}
+
+	var r0 []*v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, fpPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, fpPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fpPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderStats provides a mock function with given fields: ctx, paginationToken
+func (_m *V1DBClient) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error) {
+	ret := _m.Called(ctx, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderStats")
+	}
+
+	var r0 *db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error)); ok {
+		return rf(ctx, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]); ok {
+		r0 = rf(ctx, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderStatsByFinalityProviderPkHex provides a mock function with given fields: ctx, finalityProviderPkHex
+func (_m *V1DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context.Context, finalityProviderPkHex []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	ret := _m.Called(ctx, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderStatsByFinalityProviderPkHex")
+	}
+
+	var r0 []*v1dbmodel.FinalityProviderStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error)); ok {
+		return rf(ctx, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*v1dbmodel.FinalityProviderStatsDocument); ok {
+		r0 = rf(ctx, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1dbmodel.FinalityProviderStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProvidersWithoutActiveDelegations provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindFinalityProvidersWithoutActiveDelegations(ctx context.Context) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProvidersWithoutActiveDelegations")
+	}
+
+	var r0 []*v1dbmodel.FinalityProviderStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*v1dbmodel.FinalityProviderStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*v1dbmodel.FinalityProviderStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1dbmodel.FinalityProviderStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindGlobalParamsUpdates provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindGlobalParamsUpdates(ctx context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindGlobalParamsUpdates")
+	}
+
+	var r0 []dbmodel.GlobalParamsUpdateDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.GlobalParamsUpdateDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.GlobalParamsUpdateDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindIntegrityIssues provides a mock function with given fields: ctx, paginationToken
+func (_m *V1DBClient) FindIntegrityIssues(ctx context.Context, paginationToken string) (*db.DbResultMap[v1dbmodel.IntegrityIssueDocument], error) {
+	ret := _m.Called(ctx, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIntegrityIssues")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.IntegrityIssueDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[v1dbmodel.IntegrityIssueDocument], error)); ok {
+		return rf(ctx, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[v1dbmodel.IntegrityIssueDocument]); ok {
+		r0 = rf(ctx, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.IntegrityIssueDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPkMappingsByNativeSegwitAddress provides a mock function with given fields: ctx, nativeSegwitAddresses
+func (_m *V1DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, nativeSegwitAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
+	ret := _m.Called(ctx, nativeSegwitAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPkMappingsByNativeSegwitAddress")
+	}
+
+	var r0 []*dbmodel.PkAddressMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*dbmodel.PkAddressMapping, error)); ok {
+		return rf(ctx, nativeSegwitAddresses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dbmodel.PkAddressMapping); ok {
+		r0 = rf(ctx, nativeSegwitAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dbmodel.PkAddressMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, nativeSegwitAddresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPkMappingsByTaprootAddress provides a mock function with given fields: ctx, taprootAddresses
+func (_m *V1DBClient) FindPkMappingsByTaprootAddress(ctx context.Context, taprootAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
+	ret := _m.Called(ctx, taprootAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPkMappingsByTaprootAddress")
+	}
+
+	var r0 []*dbmodel.PkAddressMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*dbmodel.PkAddressMapping, error)); ok {
+		return rf(ctx, taprootAddresses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dbmodel.PkAddressMapping); ok {
+		r0 = rf(ctx, taprootAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dbmodel.PkAddressMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, taprootAddresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerPks provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindStakerPks(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerPks")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakersFirstSeenTimestamps provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindStakersFirstSeenTimestamps(ctx context.Context) ([]int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakersFirstSeenTimestamps")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStateHistoriesUnbondedSince provides a mock function with given fields: ctx, since
+func (_m *V1DBClient) FindStateHistoriesUnbondedSince(ctx context.Context, since int64) ([][]v1dbmodel.StateTransition, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStateHistoriesUnbondedSince")
+	}
+
+	var r0 [][]v1dbmodel.StateTransition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([][]v1dbmodel.StateTransition, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) [][]v1dbmodel.StateTransition); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]v1dbmodel.StateTransition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStateHistoriesWithdrawnSince provides a mock function with given fields: ctx, since
+func (_m *V1DBClient) FindStateHistoriesWithdrawnSince(ctx context.Context, since int64) ([][]v1dbmodel.StateTransition, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStateHistoriesWithdrawnSince")
+	}
+
+	var r0 [][]v1dbmodel.StateTransition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([][]v1dbmodel.StateTransition, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) [][]v1dbmodel.StateTransition); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]v1dbmodel.StateTransition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTopStakersByTvl provides a mock function with given fields: ctx, paginationToken
+func (_m *V1DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error) {
+	ret := _m.Called(ctx, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTopStakersByTvl")
+	}
+
+	var r0 *db.DbResultMap[*v1dbmodel.StakerStatsDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error)); ok {
+		return rf(ctx, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*v1dbmodel.StakerStatsDocument]); ok {
+		r0 = rf(ctx, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[*v1dbmodel.StakerStatsDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingDocumentsByStakingTxHashHexes provides a mock function with given fields: ctx, stakingTxHashHexes
+func (_m *V1DBClient) FindUnbondingDocumentsByStakingTxHashHexes(ctx context.Context, stakingTxHashHexes []string) ([]v1dbmodel.UnbondingDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHexes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingDocumentsByStakingTxHashHexes")
+	}
+
+	var r0 []v1dbmodel.UnbondingDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]v1dbmodel.UnbondingDocument, error)); ok {
+		return rf(ctx, stakingTxHashHexes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []v1dbmodel.UnbondingDocument); ok {
+		r0 = rf(ctx, stakingTxHashHexes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.UnbondingDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, stakingTxHashHexes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingDocumentsCreatedAfter provides a mock function with given fields: ctx, after
+func (_m *V1DBClient) FindUnbondingDocumentsCreatedAfter(ctx context.Context, after time.Time) ([]v1dbmodel.UnbondingDocument, error) {
+	ret := _m.Called(ctx, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingDocumentsCreatedAfter")
+	}
+
+	var r0 []v1dbmodel.UnbondingDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]v1dbmodel.UnbondingDocument, error)); ok {
+		return rf(ctx, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []v1dbmodel.UnbondingDocument); ok {
+		r0 = rf(ctx, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.UnbondingDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingRequestedDelegationsExpiringBefore provides a mock function with given fields: ctx, expiryHeight, paginationToken
+func (_m *V1DBClient) FindUnbondingRequestedDelegationsExpiringBefore(ctx context.Context, expiryHeight uint64, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, expiryHeight, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingRequestedDelegationsExpiringBefore")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, expiryHeight, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, expiryHeight, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, expiryHeight, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnprocessableMessages provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnprocessableMessages")
+	}
+
+	var r0 []dbmodel.UnprocessableMessageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.UnprocessableMessageDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.UnprocessableMessageDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.UnprocessableMessageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnprocessedUnbondingDocumentsCreatedBefore provides a mock function with given fields: ctx, before
+func (_m *V1DBClient) FindUnprocessedUnbondingDocumentsCreatedBefore(ctx context.Context, before time.Time) ([]v1dbmodel.UnbondingDocument, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnprocessedUnbondingDocumentsCreatedBefore")
+	}
+
+	var r0 []v1dbmodel.UnbondingDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]v1dbmodel.UnbondingDocument, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []v1dbmodel.UnbondingDocument); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.UnbondingDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindWatchlistByObserverPk provides a mock function with given fields: ctx, observerPkHex
+func (_m *V1DBClient) FindWatchlistByObserverPk(ctx context.Context, observerPkHex string) ([]v1dbmodel.WatchlistDocument, error) {
+	ret := _m.Called(ctx, observerPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWatchlistByObserverPk")
+	}
+
+	var r0 []v1dbmodel.WatchlistDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1dbmodel.WatchlistDocument, error)); ok {
+		return rf(ctx, observerPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1dbmodel.WatchlistDocument); ok {
+		r0 = rf(ctx, observerPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.WatchlistDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, observerPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindWithdrawnDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, paginationToken
+func (_m *V1DBClient) FindWithdrawnDelegationsByStakerPk(ctx context.Context, stakerPk string, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, stakerPk, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWithdrawnDelegationsByStakerPk")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, stakerPk, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, stakerPk, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stakerPk, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBtcInfo provides a mock function with given fields: ctx
+func (_m *V1DBClient) GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBtcInfo")
+	}
+
+	var r0 *v1dbmodel.BtcInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.BtcInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.BtcInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.BtcInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrCreateStatsLock provides a mock function with given fields: ctx, stakingTxHashHex, state
+func (_m *V1DBClient) GetOrCreateStatsLock(ctx context.Context, stakingTxHashHex string, state string) (*v1dbmodel.StatsLockDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrCreateStatsLock")
+	}
+
+	var r0 *v1dbmodel.StatsLockDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1dbmodel.StatsLockDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1dbmodel.StatsLockDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.StatsLockDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverallStats provides a mock function with given fields: ctx
+func (_m *V1DBClient) GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverallStats")
+	}
+
+	var r0 *v1dbmodel.OverallStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.OverallStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.OverallStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.OverallStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStakerStats provides a mock function with given fields: ctx, stakerPkHex
+func (_m *V1DBClient) GetStakerStats(ctx context.Context, stakerPkHex string) (*v1dbmodel.StakerStatsDocument, error) {
+	ret := _m.Called(ctx, stakerPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStakerStats")
+	}
+
+	var r0 *v1dbmodel.StakerStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.StakerStatsDocument, error)); ok {
+		return rf(ctx, stakerPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.StakerStatsDocument); ok {
+		r0 = rf(ctx, stakerPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.StakerStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatsLock provides a mock function with given fields: ctx, stakingTxHashHex, state
+func (_m *V1DBClient) GetStatsLock(ctx context.Context, stakingTxHashHex string, state string) (*v1dbmodel.StatsLockDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatsLock")
+	}
+
+	var r0 *v1dbmodel.StatsLockDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1dbmodel.StatsLockDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1dbmodel.StatsLockDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.StatsLockDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, amount
+func (_m *V1DBClient) IncrementFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFinalityProviderStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, fpPkHex, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrementOverallStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
+func (_m *V1DBClient) IncrementOverallStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementOverallStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrementStakerStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
+func (_m *V1DBClient) IncrementStakerStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementStakerStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertGlobalParamsUpdate provides a mock function with given fields: ctx, update
+func (_m *V1DBClient) InsertGlobalParamsUpdate(ctx context.Context, update *dbmodel.GlobalParamsUpdateDocument) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertGlobalParamsUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.GlobalParamsUpdateDocument) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertPkAddressMappings provides a mock function with given fields: ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven
+func (_m *V1DBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex string, taproot string, nativeSigwitOdd string, nativeSigwitEven string) error {
+	ret := _m.Called(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertPkAddressMappings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// FindTopStakersByTvl provides a mock function with given fields: ctx, paginationToken
-func (_m *V1DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error) {
-	ret := _m.Called(ctx, paginationToken)
+// IterateDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, fn
+func (_m *V1DBClient) IterateDelegationsByStakerPk(ctx context.Context, stakerPk string, fn func(*v1dbmodel.DelegationDocument) error) error {
+	ret := _m.Called(ctx, stakerPk, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindTopStakersByTvl")
+		panic("no return value specified for IterateDelegationsByStakerPk")
 	}
 
-	var r0 *db.DbResultMap[*v1dbmodel.StakerStatsDocument]
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error)); ok {
-		return rf(ctx, paginationToken)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*v1dbmodel.StakerStatsDocument]); ok {
-		r0 = rf(ctx, paginationToken)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1dbmodel.DelegationDocument) error) error); ok {
+		r0 = rf(ctx, stakerPk, fn)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[*v1dbmodel.StakerStatsDocument])
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paginationToken)
+	return r0
+}
+
+// IterateWithdrawnDelegationsWithoutPath provides a mock function with given fields: ctx, fn
+func (_m *V1DBClient) IterateWithdrawnDelegationsWithoutPath(ctx context.Context, fn func(*v1dbmodel.DelegationDocument) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IterateWithdrawnDelegationsWithoutPath")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*v1dbmodel.DelegationDocument) error) error); ok {
+		r0 = rf(ctx, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// FindUnprocessableMessages provides a mock function with given fields: ctx
-func (_m *V1DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
+// LowercaseStakerPks provides a mock function with given fields: ctx
+func (_m *V1DBClient) LowercaseStakerPks(ctx context.Context) (int64, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindUnprocessableMessages")
+		panic("no return value specified for LowercaseStakerPks")
 	}
 
-	var r0 []dbmodel.UnprocessableMessageDocument
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.UnprocessableMessageDocument, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.UnprocessableMessageDocument); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]dbmodel.UnprocessableMessageDocument)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -308,119 +1638,104 @@ func (_m *V1DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.
 	return r0, r1
 }
 
-// GetLatestBtcInfo provides a mock function with given fields: ctx
-func (_m *V1DBClient) GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error) {
+// Ping provides a mock function with given fields: ctx
+func (_m *V1DBClient) Ping(ctx context.Context) error {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLatestBtcInfo")
+		panic("no return value specified for Ping")
 	}
 
-	var r0 *v1dbmodel.BtcInfo
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.BtcInfo, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.BtcInfo); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.BtcInfo)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetOrCreateStatsLock provides a mock function with given fields: ctx, stakingTxHashHex, state
-func (_m *V1DBClient) GetOrCreateStatsLock(ctx context.Context, stakingTxHashHex string, state string) (*v1dbmodel.StatsLockDocument, error) {
-	ret := _m.Called(ctx, stakingTxHashHex, state)
+// QuarantineUnbondingDocument provides a mock function with given fields: ctx, unbondingTxHashHex
+func (_m *V1DBClient) QuarantineUnbondingDocument(ctx context.Context, unbondingTxHashHex string) error {
+	ret := _m.Called(ctx, unbondingTxHashHex)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOrCreateStatsLock")
-	}
-
-	var r0 *v1dbmodel.StatsLockDocument
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1dbmodel.StatsLockDocument, error)); ok {
-		return rf(ctx, stakingTxHashHex, state)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1dbmodel.StatsLockDocument); ok {
-		r0 = rf(ctx, stakingTxHashHex, state)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.StatsLockDocument)
-		}
+		panic("no return value specified for QuarantineUnbondingDocument")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, stakingTxHashHex, state)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, unbondingTxHashHex)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetOverallStats provides a mock function with given fields: ctx
-func (_m *V1DBClient) GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
-	ret := _m.Called(ctx)
+// RebuildStakerStats provides a mock function with given fields: ctx, stakerPkHex
+func (_m *V1DBClient) RebuildStakerStats(ctx context.Context, stakerPkHex string) (*v1dbmodel.StakerStatsDocument, *v1dbmodel.StakerStatsDocument, error) {
+	ret := _m.Called(ctx, stakerPkHex)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOverallStats")
+		panic("no return value specified for RebuildStakerStats")
 	}
 
-	var r0 *v1dbmodel.OverallStatsDocument
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.OverallStatsDocument, error)); ok {
-		return rf(ctx)
+	var r0 *v1dbmodel.StakerStatsDocument
+	var r1 *v1dbmodel.StakerStatsDocument
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.StakerStatsDocument, *v1dbmodel.StakerStatsDocument, error)); ok {
+		return rf(ctx, stakerPkHex)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.OverallStatsDocument); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.StakerStatsDocument); ok {
+		r0 = rf(ctx, stakerPkHex)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.OverallStatsDocument)
+			r0 = ret.Get(0).(*v1dbmodel.StakerStatsDocument)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) *v1dbmodel.StakerStatsDocument); ok {
+		r1 = rf(ctx, stakerPkHex)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*v1dbmodel.StakerStatsDocument)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, stakerPkHex)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetStakerStats provides a mock function with given fields: ctx, stakerPkHex
-func (_m *V1DBClient) GetStakerStats(ctx context.Context, stakerPkHex string) (*v1dbmodel.StakerStatsDocument, error) {
-	ret := _m.Called(ctx, stakerPkHex)
+// SampleDelegations provides a mock function with given fields: ctx, size
+func (_m *V1DBClient) SampleDelegations(ctx context.Context, size int64) ([]v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, size)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStakerStats")
+		panic("no return value specified for SampleDelegations")
 	}
 
-	var r0 *v1dbmodel.StakerStatsDocument
+	var r0 []v1dbmodel.DelegationDocument
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.StakerStatsDocument, error)); ok {
-		return rf(ctx, stakerPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, size)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.StakerStatsDocument); ok {
-		r0 = rf(ctx, stakerPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, size)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.StakerStatsDocument)
+			r0 = ret.Get(0).([]v1dbmodel.DelegationDocument)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, stakerPkHex)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, size)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -428,17 +1743,17 @@ func (_m *V1DBClient) GetStakerStats(ctx context.Context, stakerPkHex string) (*
 	return r0, r1
 }
 
-// IncrementFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, amount
-func (_m *V1DBClient) IncrementFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
-	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, amount)
+// SaveActiveStakingDelegation provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakingValueUsd
+func (_m *V1DBClient) SaveActiveStakingDelegation(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHex string, stakingTxHex string, amount uint64, startHeight uint64, timelock uint64, outputIndex uint64, startTimestamp int64, isOverflow bool, stakingValueUsd *float64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakingValueUsd)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementFinalityProviderStats")
+		panic("no return value specified for SaveActiveStakingDelegation")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, fpPkHex, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, uint64, uint64, uint64, uint64, int64, bool, *float64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakingValueUsd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -446,17 +1761,17 @@ func (_m *V1DBClient) IncrementFinalityProviderStats(ctx context.Context, stakin
 	return r0
 }
 
-// IncrementOverallStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
-func (_m *V1DBClient) IncrementOverallStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
-	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
+// SaveDryRunShadowRecord provides a mock function with given fields: ctx, record
+func (_m *V1DBClient) SaveDryRunShadowRecord(ctx context.Context, record *dbmodel.DryRunShadowRecordDocument) error {
+	ret := _m.Called(ctx, record)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementOverallStats")
+		panic("no return value specified for SaveDryRunShadowRecord")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.DryRunShadowRecordDocument) error); ok {
+		r0 = rf(ctx, record)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -464,53 +1779,47 @@ func (_m *V1DBClient) IncrementOverallStats(ctx context.Context, stakingTxHashHe
 	return r0
 }
 
-// IncrementStakerStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
-func (_m *V1DBClient) IncrementStakerStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
-	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
+// SaveFinalityProviderRegistrations provides a mock function with given fields: ctx, registrations
+func (_m *V1DBClient) SaveFinalityProviderRegistrations(ctx context.Context, registrations []*v1dbmodel.FinalityProviderRegistrationDocument) ([]int, error) {
+	ret := _m.Called(ctx, registrations)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementStakerStats")
+		panic("no return value specified for SaveFinalityProviderRegistrations")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, amount)
-	} else {
-		r0 = ret.Error(0)
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*v1dbmodel.FinalityProviderRegistrationDocument) ([]int, error)); ok {
+		return rf(ctx, registrations)
 	}
-
-	return r0
-}
-
-// InsertPkAddressMappings provides a mock function with given fields: ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven
-func (_m *V1DBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex string, taproot string, nativeSigwitOdd string, nativeSigwitEven string) error {
-	ret := _m.Called(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
-
-	if len(ret) == 0 {
-		panic("no return value specified for InsertPkAddressMappings")
+	if rf, ok := ret.Get(0).(func(context.Context, []*v1dbmodel.FinalityProviderRegistrationDocument) []int); ok {
+		r0 = rf(ctx, registrations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
+	if rf, ok := ret.Get(1).(func(context.Context, []*v1dbmodel.FinalityProviderRegistrationDocument) error); ok {
+		r1 = rf(ctx, registrations)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Ping provides a mock function with given fields: ctx
-func (_m *V1DBClient) Ping(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// SaveIntegrityIssue provides a mock function with given fields: ctx, issue
+func (_m *V1DBClient) SaveIntegrityIssue(ctx context.Context, issue *v1dbmodel.IntegrityIssueDocument) error {
+	ret := _m.Called(ctx, issue)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Ping")
+		panic("no return value specified for SaveIntegrityIssue")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1dbmodel.IntegrityIssueDocument) error); ok {
+		r0 = rf(ctx, issue)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -518,17 +1827,17 @@ func (_m *V1DBClient) Ping(ctx context.Context) error {
 	return r0
 }
 
-// SaveActiveStakingDelegation provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow
-func (_m *V1DBClient) SaveActiveStakingDelegation(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHex string, stakingTxHex string, amount uint64, startHeight uint64, timelock uint64, outputIndex uint64, startTimestamp int64, isOverflow bool) error {
-	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow)
+// SaveMisbehaviorReport provides a mock function with given fields: ctx, report
+func (_m *V1DBClient) SaveMisbehaviorReport(ctx context.Context, report *v1dbmodel.MisbehaviorReportDocument) error {
+	ret := _m.Called(ctx, report)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveActiveStakingDelegation")
+		panic("no return value specified for SaveMisbehaviorReport")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, uint64, uint64, uint64, uint64, int64, bool) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1dbmodel.MisbehaviorReportDocument) error); ok {
+		r0 = rf(ctx, report)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -572,17 +1881,35 @@ func (_m *V1DBClient) SaveUnbondingTx(ctx context.Context, stakingTxHashHex stri
 	return r0
 }
 
-// SaveUnprocessableMessage provides a mock function with given fields: ctx, messageBody, receipt
-func (_m *V1DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string) error {
-	ret := _m.Called(ctx, messageBody, receipt)
+// SaveUnprocessableMessage provides a mock function with given fields: ctx, queueName, messageBody, receipt, processingErr, retryAttempts
+func (_m *V1DBClient) SaveUnprocessableMessage(ctx context.Context, queueName string, messageBody string, receipt string, processingErr string, retryAttempts int32) error {
+	ret := _m.Called(ctx, queueName, messageBody, receipt, processingErr, retryAttempts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUnprocessableMessage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, messageBody, receipt)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int32) error); ok {
+		r0 = rf(ctx, queueName, messageBody, receipt, processingErr, retryAttempts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveWatchlistEntry provides a mock function with given fields: ctx, entry
+func (_m *V1DBClient) SaveWatchlistEntry(ctx context.Context, entry *v1dbmodel.WatchlistDocument) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWatchlistEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1dbmodel.WatchlistDocument) error); ok {
+		r0 = rf(ctx, entry)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -674,6 +2001,66 @@ func (_m *V1DBClient) SubtractStakerStats(ctx context.Context, stakingTxHashHex
 	return r0
 }
 
+// SumActiveDelegationsByFinalityProvider provides a mock function with given fields: ctx, stakerPk
+func (_m *V1DBClient) SumActiveDelegationsByFinalityProvider(ctx context.Context, stakerPk string) ([]v1dbmodel.FinalityProviderDelegationsSum, error) {
+	ret := _m.Called(ctx, stakerPk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumActiveDelegationsByFinalityProvider")
+	}
+
+	var r0 []v1dbmodel.FinalityProviderDelegationsSum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1dbmodel.FinalityProviderDelegationsSum, error)); ok {
+		return rf(ctx, stakerPk)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1dbmodel.FinalityProviderDelegationsSum); ok {
+		r0 = rf(ctx, stakerPk)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.FinalityProviderDelegationsSum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumActiveDelegationsByStaker provides a mock function with given fields: ctx, fpPkHex, excludedStakerPkHex
+func (_m *V1DBClient) SumActiveDelegationsByStaker(ctx context.Context, fpPkHex string, excludedStakerPkHex string) ([]v1dbmodel.StakerDelegationsSum, error) {
+	ret := _m.Called(ctx, fpPkHex, excludedStakerPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumActiveDelegationsByStaker")
+	}
+
+	var r0 []v1dbmodel.StakerDelegationsSum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v1dbmodel.StakerDelegationsSum, error)); ok {
+		return rf(ctx, fpPkHex, excludedStakerPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v1dbmodel.StakerDelegationsSum); ok {
+		r0 = rf(ctx, fpPkHex, excludedStakerPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.StakerDelegationsSum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, fpPkHex, excludedStakerPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TransitionToTransitionedState provides a mock function with given fields: ctx, stakingTxHashHex
 func (_m *V1DBClient) TransitionToTransitionedState(ctx context.Context, stakingTxHashHex string) error {
 	ret := _m.Called(ctx, stakingTxHashHex)
@@ -728,17 +2115,17 @@ func (_m *V1DBClient) TransitionToUnbondingState(ctx context.Context, txHashHex
 	return r0
 }
 
-// TransitionToWithdrawnState provides a mock function with given fields: ctx, txHashHex
-func (_m *V1DBClient) TransitionToWithdrawnState(ctx context.Context, txHashHex string) error {
-	ret := _m.Called(ctx, txHashHex)
+// TransitionToWithdrawnState provides a mock function with given fields: ctx, txHashHex, withdrawalTx, withdrawalPath, amount
+func (_m *V1DBClient) TransitionToWithdrawnState(ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction, withdrawalPath v1dbmodel.WithdrawalPath, amount uint64) error {
+	ret := _m.Called(ctx, txHashHex, withdrawalTx, withdrawalPath, amount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TransitionToWithdrawnState")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbmodel.WithdrawalTransaction, v1dbmodel.WithdrawalPath, uint64) error); ok {
+		r0 = rf(ctx, txHashHex, withdrawalTx, withdrawalPath, amount)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -764,6 +2151,24 @@ func (_m *V1DBClient) UpsertLatestBtcInfo(ctx context.Context, height uint64, co
 	return r0
 }
 
+// WithTransaction provides a mock function with given fields: ctx, fn
+func (_m *V1DBClient) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewV1DBClient creates a new instance of V1DBClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewV1DBClient(t interface {
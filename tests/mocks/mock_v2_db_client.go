@@ -5,7 +5,9 @@ package mocks
 import (
 	context "context"
 
+	db "github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+
 	mock "github.com/stretchr/testify/mock"
 
 	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
@@ -16,6 +18,24 @@ type V2DBClient struct {
 	mock.Mock
 }
 
+// DeleteQueuePayload provides a mock function with given fields: ctx, id
+func (_m *V2DBClient) DeleteQueuePayload(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteQueuePayload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteUnprocessableMessage provides a mock function with given fields: ctx, Receipt
 func (_m *V2DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
 	ret := _m.Called(ctx, Receipt)
@@ -34,6 +54,96 @@ func (_m *V2DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt in
 	return r0
 }
 
+// FindDailyStats provides a mock function with given fields: ctx, until
+func (_m *V2DBClient) FindDailyStats(ctx context.Context, until string) ([]v2dbmodel.V2DailyStatsDocument, error) {
+	ret := _m.Called(ctx, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDailyStats")
+	}
+
+	var r0 []v2dbmodel.V2DailyStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v2dbmodel.V2DailyStatsDocument, error)); ok {
+		return rf(ctx, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v2dbmodel.V2DailyStatsDocument); ok {
+		r0 = rf(ctx, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v2dbmodel.V2DailyStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindEventGaps provides a mock function with given fields: ctx, paginationToken
+func (_m *V2DBClient) FindEventGaps(ctx context.Context, paginationToken string) (*db.DbResultMap[v2dbmodel.EventGapDocument], error) {
+	ret := _m.Called(ctx, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEventGaps")
+	}
+
+	var r0 *db.DbResultMap[v2dbmodel.EventGapDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[v2dbmodel.EventGapDocument], error)); ok {
+		return rf(ctx, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[v2dbmodel.EventGapDocument]); ok {
+		r0 = rf(ctx, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v2dbmodel.EventGapDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindGlobalParamsUpdates provides a mock function with given fields: ctx
+func (_m *V2DBClient) FindGlobalParamsUpdates(ctx context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindGlobalParamsUpdates")
+	}
+
+	var r0 []dbmodel.GlobalParamsUpdateDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.GlobalParamsUpdateDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.GlobalParamsUpdateDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.GlobalParamsUpdateDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindPkMappingsByNativeSegwitAddress provides a mock function with given fields: ctx, nativeSegwitAddresses
 func (_m *V2DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, nativeSegwitAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
 	ret := _m.Called(ctx, nativeSegwitAddresses)
@@ -94,6 +204,36 @@ func (_m *V2DBClient) FindPkMappingsByTaprootAddress(ctx context.Context, taproo
 	return r0, r1
 }
 
+// FindQueuePayload provides a mock function with given fields: ctx, id
+func (_m *V2DBClient) FindQueuePayload(ctx context.Context, id string) (*v2dbmodel.QueuePayloadDocument, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindQueuePayload")
+	}
+
+	var r0 *v2dbmodel.QueuePayloadDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v2dbmodel.QueuePayloadDocument, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v2dbmodel.QueuePayloadDocument); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2dbmodel.QueuePayloadDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindUnprocessableMessages provides a mock function with given fields: ctx
 func (_m *V2DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
 	ret := _m.Called(ctx)
@@ -272,6 +412,36 @@ func (_m *V2DBClient) GetStakerStats(ctx context.Context, stakerPKHex string) (*
 	return r0, r1
 }
 
+// GetStatsLock provides a mock function with given fields: ctx, stakingTxHashHex, state
+func (_m *V2DBClient) GetStatsLock(ctx context.Context, stakingTxHashHex string, state string) (*v2dbmodel.V2StatsLockDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatsLock")
+	}
+
+	var r0 *v2dbmodel.V2StatsLockDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v2dbmodel.V2StatsLockDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v2dbmodel.V2StatsLockDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2dbmodel.V2StatsLockDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HandleActiveStakerStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
 func (_m *V2DBClient) HandleActiveStakerStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
 	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
@@ -380,6 +550,24 @@ func (_m *V2DBClient) IncrementOverallStats(ctx context.Context, stakingTxHashHe
 	return r0
 }
 
+// InsertGlobalParamsUpdate provides a mock function with given fields: ctx, update
+func (_m *V2DBClient) InsertGlobalParamsUpdate(ctx context.Context, update *dbmodel.GlobalParamsUpdateDocument) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertGlobalParamsUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.GlobalParamsUpdateDocument) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertPkAddressMappings provides a mock function with given fields: ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven
 func (_m *V2DBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex string, taproot string, nativeSigwitOdd string, nativeSigwitEven string) error {
 	ret := _m.Called(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
@@ -416,17 +604,53 @@ func (_m *V2DBClient) Ping(ctx context.Context) error {
 	return r0
 }
 
-// SaveUnprocessableMessage provides a mock function with given fields: ctx, messageBody, receipt
-func (_m *V2DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string) error {
-	ret := _m.Called(ctx, messageBody, receipt)
+// SaveDryRunShadowRecord provides a mock function with given fields: ctx, record
+func (_m *V2DBClient) SaveDryRunShadowRecord(ctx context.Context, record *dbmodel.DryRunShadowRecordDocument) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDryRunShadowRecord")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.DryRunShadowRecordDocument) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveEventGap provides a mock function with given fields: ctx, gap
+func (_m *V2DBClient) SaveEventGap(ctx context.Context, gap *v2dbmodel.EventGapDocument) error {
+	ret := _m.Called(ctx, gap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEventGap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v2dbmodel.EventGapDocument) error); ok {
+		r0 = rf(ctx, gap)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUnprocessableMessage provides a mock function with given fields: ctx, queueName, messageBody, receipt, processingErr, retryAttempts
+func (_m *V2DBClient) SaveUnprocessableMessage(ctx context.Context, queueName string, messageBody string, receipt string, processingErr string, retryAttempts int32) error {
+	ret := _m.Called(ctx, queueName, messageBody, receipt, processingErr, retryAttempts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUnprocessableMessage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, messageBody, receipt)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int32) error); ok {
+		r0 = rf(ctx, queueName, messageBody, receipt, processingErr, retryAttempts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -470,6 +694,24 @@ func (_m *V2DBClient) SubtractOverallStats(ctx context.Context, stakingTxHashHex
 	return r0
 }
 
+// WithTransaction provides a mock function with given fields: ctx, fn
+func (_m *V2DBClient) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewV2DBClient creates a new instance of V2DBClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewV2DBClient(t interface {